@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Authentication helpers",
+	Long:  `Manage the secrets used to sign access and invite tokens.`,
+}
+
+var authKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Generate a new JWT signing secret",
+	Long: `Generate a random secret for signing access and invite tokens.
+
+To rotate the secret without logging everyone out:
+
+1. Move the current GRGN_STACK_AUTH_JWT_SECRET to
+   GRGN_STACK_AUTH_PREVIOUS_JWT_SECRETS (comma-separated, newest first).
+2. Set GRGN_STACK_AUTH_JWT_SECRET to the generated secret and restart.
+3. Once GRGN_STACK_AUTH_TOKEN_TTL (and, for invite links,
+   GRGN_STACK_AUTH_INVITE_TOKEN_TTL) has passed, remove the old secret.
+
+Tokens signed with a secret that is no longer listed are rejected.`,
+	Args: cobra.NoArgs,
+	RunE: runAuthKeys,
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authKeysCmd)
+	authKeysCmd.Flags().Int("bytes", auth.MinSecretBytes, "Random bytes in the secret")
+}
+
+func runAuthKeys(cmd *cobra.Command, args []string) error {
+	n, _ := cmd.Flags().GetInt("bytes")
+
+	secret, err := auth.GenerateSecret(n)
+	if err != nil {
+		return err
+	}
+	fmt.Println(secret)
+	return nil
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/retry"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	tenantRepo "github.com/yourusername/grgn-stack/services/core/tenant/repository"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Database maintenance commands",
+	Long:  `Inspect and repair data in the Neo4j database.`,
+}
+
+var dbBackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Set defaults on nodes missing required properties",
+	Long: `Find User, Tenant, and Membership nodes missing properties the API
+requires and set sensible defaults:
+
+- status: ACTIVE
+- Tenant plan: FREE, isolationMode: SHARED
+- createdAt, joinedAt: now; updatedAt: createdAt
+
+Runs as a dry run by default and only reports counts.
+Use --apply to write the changes in batches.`,
+	RunE: runDBBackfill,
+}
+
+var dbRecountMembersCmd = &cobra.Command{
+	Use:   "recount-members",
+	Short: "Rebuild each tenant's stored member count",
+	Long: `Recompute the memberCount stored on every Tenant node from its
+memberships, excluding deleted users, and fix any that have drifted.
+
+Membership writes keep the count current; run this after manual edits
+or restores, or if member counts look wrong.`,
+	RunE: runDBRecountMembers,
+}
+
+func init() {
+	rootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbBackfillCmd)
+	dbBackfillCmd.Flags().Bool("apply", false, "Write the defaults instead of only reporting")
+	dbBackfillCmd.Flags().Int("batch-size", shared.DefaultBackfillBatchSize, "Nodes updated per transaction")
+	dbCmd.AddCommand(dbRecountMembersCmd)
+	dbRecountMembersCmd.Flags().Int("batch-size", tenantRepo.DefaultRecountBatchSize, "Tenants recounted per transaction")
+}
+
+func runDBBackfill(cmd *cobra.Command, args []string) error {
+	apply, _ := cmd.Flags().GetBool("apply")
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := shared.NewNeo4jDB(cfg, retry.Policy{})
+	if err != nil {
+		return fmt.Errorf("failed to connect to Neo4j: %w", err)
+	}
+	defer db.Close(context.Background())
+
+	ctx := context.Background()
+
+	if !apply {
+		fmt.Println("🔍 Dry run - no changes will be written (use --apply to backfill)")
+	} else {
+		fmt.Println("🔧 Backfilling missing properties...")
+	}
+
+	store := shared.NewNeo4jBackfillStore(db)
+	results, err := shared.Backfill(ctx, store, shared.BackfillRules, batchSize, !apply)
+	for _, result := range results {
+		label := fmt.Sprintf("%s.%s", result.Rule.Label, result.Rule.Property)
+		switch {
+		case result.Missing == 0:
+			fmt.Printf("  ✅ %-24s ok\n", label)
+		case apply:
+			fmt.Printf("  ✏️  %-24s %d/%d updated\n", label, result.Updated, result.Missing)
+		default:
+			fmt.Printf("  ⚠️  %-24s %d missing\n", label, result.Missing)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("✨ Backfill complete")
+	return nil
+}
+
+func runDBRecountMembers(cmd *cobra.Command, args []string) error {
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := shared.NewNeo4jDB(cfg, retry.Policy{})
+	if err != nil {
+		return fmt.Errorf("failed to connect to Neo4j: %w", err)
+	}
+	defer db.Close(context.Background())
+
+	fmt.Println("🔢 Recounting tenant members...")
+
+	store := tenantRepo.NewNeo4jMemberCountStore(db)
+	corrected, err := tenantRepo.RecountMembers(context.Background(), store, batchSize)
+	for _, count := range corrected {
+		fmt.Printf("  ✏️  %-36s %d -> %d\n", count.TenantID, count.Stored, count.Actual)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✨ Recount complete (%d corrected)\n", len(corrected))
+	return nil
+}
@@ -1,14 +1,16 @@
 package commands
 
 import (
-	"bufio"
 	"context"
 	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
@@ -25,7 +27,33 @@ var migrateCmd = &cobra.Command{
 var migrateUpCmd = &cobra.Command{
 	Use:   "up",
 	Short: "Apply all pending migrations",
-	RunE:  runMigrateUp,
+	Long: `Apply all pending migrations to the default database, or to the one
+named with --database.
+
+Use --all-tenant-databases to migrate the dedicated database of every
+DEDICATED isolation-mode tenant instead.
+
+Use --to to stop at a migration: only pending migrations whose IDs sort
+at or before it are applied.
+
+Use --dry-run to preview a run: pending migrations and the statements
+they would execute are printed, and nothing is written.
+
+Runs take an advisory lock in the default database, so a second run
+started meanwhile aborts. A lock older than --lock-ttl is assumed to be
+left by a run that died and is taken over.
+
+Each migration's data statements run in one transaction, together with
+recording the migration, so a failing statement rolls back all of them
+and leaves the migration pending. Neo4j won't run schema commands
+(CREATE/DROP CONSTRAINT or INDEX) in a transaction that writes data, so
+each of those auto-commits on its own; statements between them form
+separate transactions, run in file order. Write schema commands with
+IF [NOT] EXISTS so a migration that fails partway can be applied again.
+
+Applied migrations whose file changed since they were applied are
+reported; with --strict they abort the run before anything is applied.`,
+	RunE: runMigrateUp,
 }
 
 var migrateStatusCmd = &cobra.Command{
@@ -39,9 +67,12 @@ var migrateCreateCmd = &cobra.Command{
 	Short: "Create a new migration file",
 	Long: `Create a new migration file for a specific app.
 
+Use --with-down to also create the NNN_name.down.cypher that reverses it
+on 'grgn migrate down'.
+
 Examples:
   grgn migrate create add_user_roles --app core/identity
-  grgn migrate create add_tenant_settings --app core/tenant`,
+  grgn migrate create add_tenant_settings --app core/tenant --with-down`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMigrateCreate,
 }
@@ -51,14 +82,25 @@ var migrateDownCmd = &cobra.Command{
 	Short: "Rollback the last migration (if supported)",
 	Long: `Rollback the last applied migration.
 
-Note: Neo4j migrations are typically not reversible. This command will 
-mark the migration as unapplied but won't undo schema changes.
-Use with caution and consider creating a new migration instead.`,
+A migration NNN_name.cypher is reversible when it has a companion
+NNN_name.down.cypher, whose statements are run before the migration is
+marked as unapplied. Otherwise only the mark is removed and schema
+changes are not undone; consider creating a new migration instead.
+
+Use --to to roll back every applied migration whose ID sorts after the
+given one, newest first, leaving the database at that migration.`,
 	RunE: runMigrateDown,
 }
 
 var (
-	appFilter string
+	appFilter          string
+	databaseName       string
+	allTenantDatabases bool
+	strictChecksums    bool
+	withDown           bool
+	targetID           string
+	lockTTL            time.Duration
+	dryRun             bool
 )
 
 func init() {
@@ -72,7 +114,22 @@ func init() {
 	migrateStatusCmd.Flags().StringVar(&appFilter, "app", "", "Filter by app (e.g., core/identity)")
 	migrateCreateCmd.Flags().StringVar(&appFilter, "app", "", "App to create migration for (required, e.g., core/identity)")
 	migrateCreateCmd.MarkFlagRequired("app")
+	migrateCreateCmd.Flags().BoolVar(&withDown, "with-down", false, "Also create a .down.cypher file that reverses the migration")
 	migrateDownCmd.Flags().StringVar(&appFilter, "app", "", "Filter by app (e.g., core/identity)")
+
+	// Target database; the server default when empty
+	for _, c := range []*cobra.Command{migrateUpCmd, migrateStatusCmd, migrateDownCmd} {
+		c.Flags().StringVar(&databaseName, "database", "", "Neo4j database to migrate (default: server default)")
+	}
+	migrateUpCmd.Flags().BoolVar(&allTenantDatabases, "all-tenant-databases", false, "Migrate the database of every DEDICATED tenant")
+	migrateUpCmd.MarkFlagsMutuallyExclusive("database", "all-tenant-databases")
+	migrateUpCmd.Flags().BoolVar(&strictChecksums, "strict", false, "Fail if an applied migration changed since it was applied")
+	migrateUpCmd.Flags().StringVar(&targetID, "to", "", "Apply pending migrations up to and including this ID")
+	migrateUpCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the migrations and statements that would run without applying them")
+	migrateDownCmd.Flags().StringVar(&targetID, "to", "", "Roll back applied migrations after this ID")
+	for _, c := range []*cobra.Command{migrateUpCmd, migrateDownCmd} {
+		c.Flags().DurationVar(&lockTTL, "lock-ttl", defaultMigrationLockTTL, "Take over a migration lock held for longer than this")
+	}
 }
 
 // Migration represents a single migration file
@@ -82,8 +139,12 @@ type Migration struct {
 	Filename string // e.g., "001_user_schema.cypher"
 	Path     string // Full path to file
 	Checksum string // SHA256 of file contents
+	DownPath string // Path to the .down.cypher that reverses it, if any
 }
 
+// downSuffix names the file that reverses the migration of the same name.
+const downSuffix = ".down.cypher"
+
 // AppliedMigration represents a migration that has been applied
 type AppliedMigration struct {
 	ID        string
@@ -91,6 +152,14 @@ type AppliedMigration struct {
 	Checksum  string
 }
 
+// VersionedMigration is a :Migration record tracked by an integer version
+// instead of an ID, as Go-function migrators record them. The CLI never
+// applies, changes or removes these; it only reports them.
+type VersionedMigration struct {
+	Version   int64
+	AppliedAt time.Time
+}
+
 func runMigrateUp(cmd *cobra.Command, args []string) error {
 	fmt.Println("🚀 Running migrations...")
 
@@ -117,9 +186,13 @@ func runMigrateUp(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println("✅ Connected to Neo4j")
 
-	// Ensure migration tracking exists
-	if err := ensureMigrationTracking(ctx, driver); err != nil {
-		return fmt.Errorf("failed to ensure migration tracking: %w", err)
+	// A dry run writes nothing, not even the lock
+	if !dryRun {
+		release, err := acquireMigrationLock(ctx, driver, migrationLockHolder(), lockTTL)
+		if err != nil {
+			return err
+		}
+		defer release()
 	}
 
 	// Discover migrations
@@ -143,9 +216,62 @@ func runMigrateUp(cmd *cobra.Command, args []string) error {
 		fmt.Println("📭 No migrations found")
 		return nil
 	}
+	if err := validateTarget(migrations, targetID); err != nil {
+		return err
+	}
+
+	// Resolve target databases
+	databases := []string{databaseName}
+	if allTenantDatabases {
+		databases, err = listTenantDatabases(ctx, driver)
+		if err != nil {
+			return fmt.Errorf("failed to list tenant databases: %w", err)
+		}
+		if len(databases) == 0 {
+			fmt.Println("📭 No dedicated tenant databases found")
+			return nil
+		}
+		fmt.Printf("🏢 Migrating %d tenant database(s)\n", len(databases))
+	}
+
+	for _, database := range databases {
+		if database != "" {
+			fmt.Printf("\n🗄️  Database: %s\n", database)
+		}
+		if err := migrateDatabase(ctx, driver, database, migrations, upOptions{strict: strictChecksums, target: targetID, dryRun: dryRun}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upOptions controls which migrations migrateDatabase applies.
+type upOptions struct {
+	// strict refuses to apply anything while an applied migration changed
+	strict bool
+	// target, if set, is the ID of the last migration to apply
+	target string
+	// dryRun prints the pending migrations' statements instead of
+	// applying them, and writes nothing
+	dryRun bool
+}
+
+// migrateDatabase applies the migrations not yet recorded in database.
+// An empty database name targets the server's default database. Applied
+// migrations that changed on disk are reported, and with opts.strict
+// nothing is applied while any have.
+func migrateDatabase(ctx context.Context, driver neo4j.DriverWithContext, database string, migrations []Migration, opts upOptions) error {
+	// Ensure migration tracking exists; without it a dry run reads no
+	// applied migrations
+	if !opts.dryRun {
+		if err := ensureMigrationTracking(ctx, driver, database); err != nil {
+			return fmt.Errorf("failed to ensure migration tracking: %w", err)
+		}
+	}
 
 	// Get applied migrations
-	applied, err := getAppliedMigrations(ctx, driver)
+	applied, err := getAppliedMigrations(ctx, driver, database)
 	if err != nil {
 		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
@@ -156,12 +282,15 @@ func runMigrateUp(cmd *cobra.Command, args []string) error {
 		appliedMap[a.ID] = a
 	}
 
-	var pending []Migration
-	for _, m := range migrations {
-		if _, ok := appliedMap[m.ID]; !ok {
-			pending = append(pending, m)
-		}
+	changed := changedMigrations(migrations, appliedMap)
+	for _, m := range changed {
+		fmt.Printf("⚠️  Changed since applied: %s (%s)\n", m.ID, m.Path)
 	}
+	if opts.strict && len(changed) > 0 {
+		return fmt.Errorf("%d applied migration(s) changed since they were applied; restore the applied versions or add new migrations instead", len(changed))
+	}
+
+	pending := pendingMigrations(migrations, appliedMap, opts.target)
 
 	if len(pending) == 0 {
 		fmt.Println("✅ All migrations are up to date")
@@ -170,11 +299,15 @@ func runMigrateUp(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("📋 Found %d pending migration(s)\n", len(pending))
 
+	if opts.dryRun {
+		return printDryRun(pending)
+	}
+
 	// Apply pending migrations
 	for _, m := range pending {
 		fmt.Printf("\n⏳ Applying: %s\n", m.ID)
 
-		if err := applyMigration(ctx, driver, m); err != nil {
+		if err := applyMigration(ctx, driver, database, m); err != nil {
 			return fmt.Errorf("failed to apply migration %s: %w", m.ID, err)
 		}
 
@@ -185,6 +318,122 @@ func runMigrateUp(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// validateTarget checks that a --to target, if given, names one of
+// migrations.
+func validateTarget(migrations []Migration, target string) error {
+	if target == "" {
+		return nil
+	}
+	for _, m := range migrations {
+		if m.ID == target {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown target migration %q; run 'grgn migrate status' to list migration IDs", target)
+}
+
+// pendingMigrations returns the migrations not yet applied, in order,
+// stopping after target when it is set.
+func pendingMigrations(migrations []Migration, applied map[string]AppliedMigration, target string) []Migration {
+	var pending []Migration
+	for _, m := range migrations {
+		if target != "" && m.ID > target {
+			continue
+		}
+		if _, ok := applied[m.ID]; !ok {
+			pending = append(pending, m)
+		}
+	}
+	return pending
+}
+
+// rollbackTargets returns the applied migrations to roll back, newest
+// first: every one after target, or only the last when target is empty.
+// applied must be sorted by ID.
+func rollbackTargets(applied []AppliedMigration, target string) []AppliedMigration {
+	if target == "" {
+		if len(applied) == 0 {
+			return nil
+		}
+		return applied[len(applied)-1:]
+	}
+
+	var targets []AppliedMigration
+	for i := len(applied) - 1; i >= 0 && applied[i].ID > target; i-- {
+		targets = append(targets, applied[i])
+	}
+	return targets
+}
+
+// printDryRun prints the statements each pending migration would run, in
+// the order they would run.
+func printDryRun(pending []Migration) error {
+	for _, m := range pending {
+		content, err := os.ReadFile(m.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read migration file: %w", err)
+		}
+
+		fmt.Printf("\n📝 Would apply: %s\n", m.ID)
+		for _, stmt := range parseCypherStatements(string(content)) {
+			fmt.Printf("   %s;\n", strings.ReplaceAll(stmt, "\n", "\n   "))
+		}
+	}
+
+	fmt.Printf("\n🔍 Dry run: %d migration(s) would be applied; nothing was changed\n", len(pending))
+	return nil
+}
+
+// tenantDatabasePrefix prefixes database names derived from tenant slugs.
+const tenantDatabasePrefix = "tenant-"
+
+// tenantDatabaseName returns the Neo4j database for a DEDICATED tenant: its
+// databaseName property if set, otherwise one derived from the slug. Neo4j
+// database names cannot contain underscores, so they become hyphens.
+func tenantDatabaseName(slug string, override any) string {
+	if name, ok := override.(string); ok && name != "" {
+		return name
+	}
+	return tenantDatabasePrefix + strings.ReplaceAll(strings.ToLower(slug), "_", "-")
+}
+
+// listTenantDatabases returns the sorted, unique databases of active
+// DEDICATED tenants. Tenants are read from the default database.
+func listTenantDatabases(ctx context.Context, driver neo4j.DriverWithContext) ([]string, error) {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		MATCH (t:Tenant {isolationMode: $isolationMode})
+		WHERE t.status <> 'DELETED'
+		RETURN t.slug AS slug, t.databaseName AS databaseName
+	`, map[string]any{"isolationMode": "DEDICATED"})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var databases []string
+	for result.Next(ctx) {
+		record := result.Record()
+		slug, _ := record.Get("slug")
+		override, _ := record.Get("databaseName")
+
+		slugStr, _ := slug.(string)
+		name := tenantDatabaseName(slugStr, override)
+		if !seen[name] {
+			seen[name] = true
+			databases = append(databases, name)
+		}
+	}
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(databases)
+	return databases, nil
+}
+
 func runMigrateStatus(cmd *cobra.Command, args []string) error {
 	fmt.Println("📊 Migration Status")
 	fmt.Println()
@@ -229,7 +478,7 @@ func runMigrateStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get applied migrations
-	applied, err := getAppliedMigrations(ctx, driver)
+	applied, err := getAppliedMigrations(ctx, driver, databaseName)
 	if err != nil {
 		// If migration tracking doesn't exist yet, treat as no applied migrations
 		applied = []AppliedMigration{}
@@ -245,26 +494,120 @@ func runMigrateStatus(cmd *cobra.Command, args []string) error {
 	fmt.Println(strings.Repeat("-", 72))
 
 	for _, m := range migrations {
-		if a, ok := appliedMap[m.ID]; ok {
-			fmt.Printf("%-40s %-10s %-20s\n", m.ID, "✅ Applied", a.AppliedAt.Format("2006-01-02 15:04:05"))
-		} else {
+		a, ok := appliedMap[m.ID]
+		switch {
+		case !ok:
 			fmt.Printf("%-40s %-10s %-20s\n", m.ID, "⏳ Pending", "-")
+		case checksumChanged(m, a):
+			fmt.Printf("%-40s %-10s %-20s\n", m.ID, "⚠️  CHANGED", a.AppliedAt.Format("2006-01-02 15:04:05"))
+		default:
+			fmt.Printf("%-40s %-10s %-20s\n", m.ID, "✅ Applied", a.AppliedAt.Format("2006-01-02 15:04:05"))
+		}
+	}
+
+	if changed := changedMigrations(migrations, appliedMap); len(changed) > 0 {
+		fmt.Printf("\n⚠️  %d applied migration(s) changed since they were applied\n", len(changed))
+	}
+
+	// Version-tracked records share the :Migration label but aren't ours
+	versioned, err := getVersionedMigrations(ctx, driver, databaseName)
+	if err == nil && len(versioned) > 0 {
+		fmt.Printf("\n%d migration(s) tracked by version, not managed by grgn migrate:\n", len(versioned))
+		fmt.Printf("%-40s %-20s\n", "VERSION", "APPLIED AT")
+		for _, m := range versioned {
+			fmt.Printf("%-40d %-20s\n", m.Version, m.AppliedAt.Format("2006-01-02 15:04:05"))
 		}
 	}
 
 	return nil
 }
 
+// changedMigrations returns the applied migrations whose file no longer
+// matches the checksum recorded when they were applied.
+func changedMigrations(migrations []Migration, applied map[string]AppliedMigration) []Migration {
+	var changed []Migration
+	for _, m := range migrations {
+		if a, ok := applied[m.ID]; ok && checksumChanged(m, a) {
+			changed = append(changed, m)
+		}
+	}
+	return changed
+}
+
+// checksumChanged reports whether m's file differs from the one applied.
+// Records without a checksum can't be compared and never count as changed.
+func checksumChanged(m Migration, a AppliedMigration) bool {
+	return a.Checksum != "" && a.Checksum != m.Checksum
+}
+
+// migrationPatterns are the globs searched for migration files. The second
+// pattern also matches everything the first does; overlaps are de-duplicated.
+var migrationPatterns = []string{
+	"services/core/*/migrations/*.cypher",
+	"services/*/*/migrations/*.cypher",
+	"migrations/*.cypher",
+}
+
 func discoverMigrations() ([]Migration, error) {
-	var migrations []Migration
+	return discoverMigrationsWith(migrationPatterns, runtime.NumCPU())
+}
 
-	// Search patterns for migrations
-	patterns := []string{
-		"services/core/*/migrations/*.cypher",
-		"services/*/*/migrations/*.cypher",
-		"migrations/*.cypher",
+// discoverMigrationsWith globs patterns and parses the matches with up to
+// workers files read and hashed concurrently. The result is sorted by ID and
+// does not depend on the number of workers.
+func discoverMigrationsWith(patterns []string, workers int) ([]Migration, error) {
+	paths := globMigrations(patterns)
+	if workers < 1 {
+		workers = 1
 	}
 
+	// Each worker writes only its own slots, so results keep glob order
+	migrations := make([]Migration, len(paths))
+	errs := make([]error, len(paths))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(paths)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				migrations[i], errs[i] = parseMigration(paths[i])
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	valid := make([]Migration, 0, len(paths))
+	byID := make(map[string]string, len(paths))
+	for i, m := range migrations {
+		if errs[i] != nil {
+			fmt.Printf("⚠️  Skipping invalid migration: %s (%v)\n", paths[i], errs[i])
+			continue
+		}
+		if other, ok := byID[m.ID]; ok {
+			return nil, fmt.Errorf("duplicate migration ID %s: %s and %s", m.ID, other, m.Path)
+		}
+		byID[m.ID] = m.Path
+		valid = append(valid, m)
+	}
+
+	// Sort by ID
+	sort.Slice(valid, func(i, j int) bool {
+		return valid[i].ID < valid[j].ID
+	})
+
+	return valid, nil
+}
+
+// globMigrations returns the unique, cleaned paths matching patterns in
+// first-match order.
+func globMigrations(patterns []string) []string {
+	var paths []string
 	seen := make(map[string]bool)
 
 	for _, pattern := range patterns {
@@ -274,31 +617,18 @@ func discoverMigrations() ([]Migration, error) {
 		}
 
 		for _, path := range matches {
-			// Normalize path
-			path = filepath.ToSlash(path)
+			// Normalize path so equivalent spellings collapse
+			path = filepath.ToSlash(filepath.Clean(path))
 
-			if seen[path] {
+			if seen[path] || strings.HasSuffix(path, downSuffix) {
 				continue
 			}
 			seen[path] = true
-
-			// Parse migration info
-			m, err := parseMigration(path)
-			if err != nil {
-				fmt.Printf("⚠️  Skipping invalid migration: %s (%v)\n", path, err)
-				continue
-			}
-
-			migrations = append(migrations, m)
+			paths = append(paths, path)
 		}
 	}
 
-	// Sort by ID
-	sort.Slice(migrations, func(i, j int) bool {
-		return migrations[i].ID < migrations[j].ID
-	})
-
-	return migrations, nil
+	return paths
 }
 
 func parseMigration(path string) (Migration, error) {
@@ -340,17 +670,24 @@ func parseMigration(path string) (Migration, error) {
 	name := strings.TrimSuffix(filename, ".cypher")
 	id := app + "/" + name
 
+	var downPath string
+	down := strings.TrimSuffix(path, ".cypher") + downSuffix
+	if _, err := os.Stat(down); err == nil {
+		downPath = down
+	}
+
 	return Migration{
 		ID:       id,
 		App:      app,
 		Filename: filename,
 		Path:     path,
 		Checksum: checksum,
+		DownPath: downPath,
 	}, nil
 }
 
-func ensureMigrationTracking(ctx context.Context, driver neo4j.DriverWithContext) error {
-	session := driver.NewSession(ctx, neo4j.SessionConfig{})
+func ensureMigrationTracking(ctx context.Context, driver neo4j.DriverWithContext, database string) error {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: database})
 	defer session.Close(ctx)
 
 	_, err := session.Run(ctx, `
@@ -361,12 +698,14 @@ func ensureMigrationTracking(ctx context.Context, driver neo4j.DriverWithContext
 	return err
 }
 
-func getAppliedMigrations(ctx context.Context, driver neo4j.DriverWithContext) ([]AppliedMigration, error) {
-	session := driver.NewSession(ctx, neo4j.SessionConfig{})
+func getAppliedMigrations(ctx context.Context, driver neo4j.DriverWithContext, database string) ([]AppliedMigration, error) {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: database})
 	defer session.Close(ctx)
 
+	// Records without an ID belong to version-tracked migrators
 	result, err := session.Run(ctx, `
 		MATCH (m:Migration)
+		WHERE m.id IS NOT NULL
 		RETURN m.id AS id, m.appliedAt AS appliedAt, m.checksum AS checksum
 		ORDER BY m.id
 	`, nil)
@@ -381,10 +720,12 @@ func getAppliedMigrations(ctx context.Context, driver neo4j.DriverWithContext) (
 		appliedAt, _ := record.Get("appliedAt")
 		checksum, _ := record.Get("checksum")
 
-		a := AppliedMigration{
-			ID:       id.(string),
-			Checksum: checksum.(string),
+		idStr, ok := id.(string)
+		if !ok {
+			continue
 		}
+		a := AppliedMigration{ID: idStr}
+		a.Checksum, _ = checksum.(string)
 
 		// Handle Neo4j time type
 		if t, ok := appliedAt.(time.Time); ok {
@@ -397,34 +738,95 @@ func getAppliedMigrations(ctx context.Context, driver neo4j.DriverWithContext) (
 	return applied, result.Err()
 }
 
-func applyMigration(ctx context.Context, driver neo4j.DriverWithContext, m Migration) error {
-	// Read migration file
+// getVersionedMigrations returns the version-tracked :Migration records in
+// database, oldest version first.
+func getVersionedMigrations(ctx context.Context, driver neo4j.DriverWithContext, database string) ([]VersionedMigration, error) {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: database})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		MATCH (m:Migration)
+		WHERE m.id IS NULL AND m.version IS NOT NULL
+		RETURN m.version AS version, m.appliedAt AS appliedAt
+		ORDER BY m.version
+	`, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var versioned []VersionedMigration
+	for result.Next(ctx) {
+		record := result.Record()
+		version, _ := record.Get("version")
+		appliedAt, _ := record.Get("appliedAt")
+
+		v, ok := version.(int64)
+		if !ok {
+			continue
+		}
+		m := VersionedMigration{Version: v}
+		if t, ok := appliedAt.(time.Time); ok {
+			m.AppliedAt = t
+		}
+		versioned = append(versioned, m)
+	}
+
+	return versioned, result.Err()
+}
+
+// applyMigration runs m's statements in the phases planMigration splits
+// them into and records m as applied. The record is written in the
+// transaction of a final data phase, so a data-only migration applies
+// atomically.
+func applyMigration(ctx context.Context, driver neo4j.DriverWithContext, database string, m Migration) error {
 	content, err := os.ReadFile(m.Path)
 	if err != nil {
 		return fmt.Errorf("failed to read migration file: %w", err)
 	}
+	phases := planMigration(parseCypherStatements(string(content)))
 
-	// Parse and execute statements
-	statements := parseCypherStatements(string(content))
-
-	session := driver.NewSession(ctx, neo4j.SessionConfig{})
+	session := driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: database})
 	defer session.Close(ctx)
 
-	// Execute each statement
-	for _, stmt := range statements {
-		stmt = strings.TrimSpace(stmt)
-		if stmt == "" {
+	for i, phase := range phases {
+		if phase.schema {
+			for _, stmt := range phase.statements {
+				if _, err := session.Run(ctx, stmt, nil); err != nil {
+					return fmt.Errorf("failed to execute statement: %w\nStatement: %s", err, stmt)
+				}
+			}
 			continue
 		}
 
-		_, err := session.Run(ctx, stmt, nil)
+		last := i == len(phases)-1
+		_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			for _, stmt := range phase.statements {
+				if _, err := tx.Run(ctx, stmt, nil); err != nil {
+					return nil, fmt.Errorf("failed to execute statement: %w\nStatement: %s", err, stmt)
+				}
+			}
+			if last {
+				return nil, recordMigration(ctx, tx, m)
+			}
+			return nil, nil
+		})
 		if err != nil {
-			return fmt.Errorf("failed to execute statement: %w\nStatement: %s", err, stmt)
+			return err
 		}
 	}
 
-	// Record migration as applied
-	_, err = session.Run(ctx, `
+	if len(phases) > 0 && !phases[len(phases)-1].schema {
+		return nil
+	}
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return nil, recordMigration(ctx, tx, m)
+	})
+	return err
+}
+
+// recordMigration records m as applied in tx.
+func recordMigration(ctx context.Context, tx neo4j.ManagedTransaction, m Migration) error {
+	_, err := tx.Run(ctx, `
 		CREATE (m:Migration {
 			id: $id,
 			appliedAt: datetime(),
@@ -434,45 +836,114 @@ func applyMigration(ctx context.Context, driver neo4j.DriverWithContext, m Migra
 		"id":       m.ID,
 		"checksum": m.Checksum,
 	})
-
 	return err
 }
 
-func parseCypherStatements(content string) []string {
-	var statements []string
-	var current strings.Builder
+// schemaStatement matches the schema commands Neo4j refuses to run in a
+// transaction that also writes data.
+var schemaStatement = regexp.MustCompile(`(?i)^(CREATE|DROP)\s+((RANGE|TEXT|POINT|LOOKUP|FULLTEXT|VECTOR|BTREE)\s+)?(CONSTRAINT|INDEX)\b`)
 
-	scanner := bufio.NewScanner(strings.NewReader(content))
-	for scanner.Scan() {
-		line := scanner.Text()
+// migrationPhase is a run of consecutive statements of one kind. Schema
+// statements each auto-commit; data statements share a transaction.
+type migrationPhase struct {
+	schema     bool
+	statements []string
+}
 
-		// Skip comment-only lines
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "//") || trimmed == "" {
+// planMigration splits statements into phases, keeping their order.
+func planMigration(statements []string) []migrationPhase {
+	var phases []migrationPhase
+	for _, stmt := range statements {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
 			continue
 		}
 
-		current.WriteString(line)
-		current.WriteString("\n")
+		schema := schemaStatement.MatchString(stmt)
+		if n := len(phases); n > 0 && phases[n-1].schema == schema {
+			phases[n-1].statements = append(phases[n-1].statements, stmt)
+			continue
+		}
+		phases = append(phases, migrationPhase{schema: schema, statements: []string{stmt}})
+	}
+	return phases
+}
 
-		// Check if statement ends with semicolon
-		if strings.HasSuffix(trimmed, ";") {
-			stmt := strings.TrimSuffix(strings.TrimSpace(current.String()), ";")
-			if stmt != "" {
-				statements = append(statements, stmt)
-			}
-			current.Reset()
+// runCypherFile executes each statement in the Cypher file at path.
+func runCypherFile(ctx context.Context, session neo4j.SessionWithContext, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read migration file: %w", err)
+	}
+
+	for _, stmt := range parseCypherStatements(string(content)) {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+
+		if _, err := session.Run(ctx, stmt, nil); err != nil {
+			return fmt.Errorf("failed to execute statement: %w\nStatement: %s", err, stmt)
 		}
 	}
+	return nil
+}
 
-	// Handle final statement without semicolon
-	if current.Len() > 0 {
-		stmt := strings.TrimSpace(current.String())
-		if stmt != "" {
+// parseCypherStatements splits content into statements on the semicolons
+// that end them. Semicolons and // inside string literals and backtick
+// identifiers are part of the statement; outside them, // starts a comment
+// running to the end of the line. Blank and comment-only lines are dropped.
+func parseCypherStatements(content string) []string {
+	var statements []string
+	var current strings.Builder
+	// quote is the delimiter of the literal or identifier being read, if any
+	var quote rune
+
+	flush := func() {
+		if stmt := strings.TrimSpace(current.String()); stmt != "" {
 			statements = append(statements, stmt)
 		}
+		current.Reset()
 	}
 
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if quote == 0 {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || strings.HasPrefix(trimmed, "//") {
+				continue
+			}
+		}
+
+		chars := []rune(line)
+		for i := 0; i < len(chars); i++ {
+			c := chars[i]
+			switch {
+			case quote != 0:
+				current.WriteRune(c)
+				// Strings escape with a backslash; identifiers double the
+				// backtick, which closes and reopens the identifier
+				if c == '\\' && quote != '`' && i+1 < len(chars) {
+					i++
+					current.WriteRune(chars[i])
+				} else if c == quote {
+					quote = 0
+				}
+			case c == '\'' || c == '"' || c == '`':
+				quote = c
+				current.WriteRune(c)
+			case c == '/' && i+1 < len(chars) && chars[i+1] == '/':
+				i = len(chars)
+			case c == ';':
+				flush()
+			default:
+				current.WriteRune(c)
+			}
+		}
+		current.WriteString("\n")
+	}
+	flush()
+
 	return statements
 }
 
@@ -547,8 +1018,30 @@ func runMigrateCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("✅ Created migration: %s\n", filePath)
+
+	var downPath string
+	if withDown {
+		downPath = strings.TrimSuffix(filePath, ".cypher") + downSuffix
+		downContent := fmt.Sprintf(`// ============================================
+// Rollback: %s/%03d_%s
+// ============================================
+
+// Undo the migration's statements in reverse order, e.g.:
+// DROP INDEX example_status IF EXISTS;
+// DROP CONSTRAINT example_id_unique IF EXISTS;
+`, appFilter, nextNum, name)
+
+		if err := os.WriteFile(downPath, []byte(downContent), 0644); err != nil {
+			return fmt.Errorf("failed to write down migration file: %w", err)
+		}
+		fmt.Printf("✅ Created down migration: %s\n", downPath)
+	}
+
 	fmt.Printf("\n📝 Next steps:\n")
 	fmt.Printf("   1. Edit %s to add your schema changes\n", filePath)
+	if downPath != "" {
+		fmt.Printf("      and %s to reverse them\n", downPath)
+	}
 	fmt.Printf("   2. Run 'grgn migrate up' to apply the migration\n")
 	fmt.Printf("   3. Run 'grgn migrate status' to verify\n")
 
@@ -557,8 +1050,6 @@ func runMigrateCreate(cmd *cobra.Command, args []string) error {
 
 func runMigrateDown(cmd *cobra.Command, args []string) error {
 	fmt.Println("⚠️  Rolling back last migration...")
-	fmt.Println("   Note: This only marks the migration as unapplied.")
-	fmt.Println("   Schema changes in Neo4j are NOT automatically reversed.")
 
 	// Load config
 	cfg, err := config.Load()
@@ -582,8 +1073,14 @@ func runMigrateDown(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to connect to Neo4j: %w", err)
 	}
 
+	release, err := acquireMigrationLock(ctx, driver, migrationLockHolder(), lockTTL)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Get applied migrations
-	applied, err := getAppliedMigrations(ctx, driver)
+	applied, err := getAppliedMigrations(ctx, driver, databaseName)
 	if err != nil {
 		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
@@ -609,27 +1106,78 @@ func runMigrateDown(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Get the last applied migration
-	last := applied[len(applied)-1]
+	// Find down files, for migrations still on disk
+	migrations, err := discoverMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to discover migrations: %w", err)
+	}
+	if appFilter != "" {
+		var filtered []Migration
+		for _, m := range migrations {
+			if m.App == appFilter {
+				filtered = append(filtered, m)
+			}
+		}
+		migrations = filtered
+	}
+	if err := validateTarget(migrations, targetID); err != nil {
+		return err
+	}
+	downPaths := make(map[string]string, len(migrations))
+	for _, m := range migrations {
+		downPaths[m.ID] = m.DownPath
+	}
+
+	targets := rollbackTargets(applied, targetID)
+	if len(targets) == 0 {
+		fmt.Printf("✅ Already at %s\n", targetID)
+		return nil
+	}
+
+	irreversible := false
+	for _, a := range targets {
+		fmt.Printf("\n🔙 Rolling back: %s\n", a.ID)
+		fmt.Printf("   Applied at: %s\n", a.AppliedAt.Format("2006-01-02 15:04:05"))
 
-	fmt.Printf("\n🔙 Rolling back: %s\n", last.ID)
-	fmt.Printf("   Applied at: %s\n", last.AppliedAt.Format("2006-01-02 15:04:05"))
+		downPath := downPaths[a.ID]
+		if err := rollbackMigration(ctx, driver, databaseName, a.ID, downPath); err != nil {
+			return err
+		}
 
-	// Remove the migration record
-	session := driver.NewSession(ctx, neo4j.SessionConfig{})
+		if downPath != "" {
+			fmt.Printf("✅ Rolled back: %s\n", a.ID)
+			continue
+		}
+		fmt.Printf("✅ Migration record removed: %s\n", a.ID)
+		irreversible = true
+	}
+
+	if irreversible {
+		fmt.Println("\n⚠️  Remember: Schema changes have NOT been reversed (no .down.cypher).")
+		fmt.Println("   You may need to manually clean up constraints/indexes if needed.")
+	}
+
+	return nil
+}
+
+// rollbackMigration runs the statements in downPath, if set, and removes
+// the record that migration id was applied to database.
+func rollbackMigration(ctx context.Context, driver neo4j.DriverWithContext, database, id, downPath string) error {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: database})
 	defer session.Close(ctx)
 
-	_, err = session.Run(ctx, `
+	if downPath != "" {
+		if err := runCypherFile(ctx, session, downPath); err != nil {
+			return err
+		}
+	}
+
+	_, err := session.Run(ctx, `
 		MATCH (m:Migration {id: $id})
 		DELETE m
-	`, map[string]any{"id": last.ID})
+	`, map[string]any{"id": id})
 	if err != nil {
 		return fmt.Errorf("failed to remove migration record: %w", err)
 	}
-
-	fmt.Printf("✅ Migration record removed: %s\n", last.ID)
-	fmt.Println("\n⚠️  Remember: Schema changes have NOT been reversed.")
-	fmt.Println("   You may need to manually clean up constraints/indexes if needed.")
-
 	return nil
 }
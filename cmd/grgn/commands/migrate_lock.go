@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// defaultMigrationLockTTL is how long a migration lock is honoured before
+// another run may take it over. It should exceed the longest migration run.
+const defaultMigrationLockTTL = 15 * time.Minute
+
+// migrationLockID identifies the singleton MigrationLock node.
+const migrationLockID = "migrate"
+
+// migrationLockHolder identifies this process as the holder of the lock.
+func migrationLockHolder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s/%d", host, os.Getpid())
+}
+
+// acquireMigrationLock takes the advisory lock that keeps two migration
+// runs from working at once. The lock lives in the server's default
+// database, so it covers every database a run migrates. A lock held for
+// longer than ttl is considered stale and taken over. release removes the
+// lock, and only if this holder still has it.
+func acquireMigrationLock(ctx context.Context, driver neo4j.DriverWithContext, holder string, ttl time.Duration) (release func(), err error) {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{})
+	defer session.Close(ctx)
+
+	if _, err := session.Run(ctx, `
+		CREATE CONSTRAINT migration_lock_id_unique IF NOT EXISTS
+		FOR (l:MigrationLock) REQUIRE l.id IS UNIQUE
+	`, nil); err != nil {
+		return nil, fmt.Errorf("failed to ensure migration lock: %w", err)
+	}
+
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		// ON MATCH takes the node's write lock before the holder is read,
+		// so concurrent runs check and claim the lock one at a time
+		result, err := tx.Run(ctx, `
+			MERGE (l:MigrationLock {id: $id})
+			ON MATCH SET l.checkedAt = datetime()
+			RETURN l.holder AS holder, l.acquiredAt AS acquiredAt, datetime() AS now
+		`, map[string]any{"id": migrationLockID})
+		if err != nil {
+			return nil, err
+		}
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		current, _ := record.Get("holder")
+		acquiredAt, _ := record.Get("acquiredAt")
+		now, _ := record.Get("now")
+		currentHolder, _ := current.(string)
+		acquiredTime, _ := acquiredAt.(time.Time)
+		nowTime, _ := now.(time.Time)
+
+		if currentHolder != "" && currentHolder != holder {
+			expiresAt := acquiredTime.Add(ttl)
+			if nowTime.Before(expiresAt) {
+				return nil, fmt.Errorf("migrations are locked by %s since %s; try again once that run finishes, or after %s if it died",
+					currentHolder, acquiredTime.Format(time.RFC3339), expiresAt.Format(time.RFC3339))
+			}
+			fmt.Printf("⚠️  Taking over stale migration lock held by %s since %s\n", currentHolder, acquiredTime.Format(time.RFC3339))
+		}
+
+		_, err = tx.Run(ctx, `
+			MATCH (l:MigrationLock {id: $id})
+			SET l.holder = $holder,
+				l.acquiredAt = datetime()
+		`, map[string]any{"id": migrationLockID, "holder": holder})
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		session := driver.NewSession(ctx, neo4j.SessionConfig{})
+		defer session.Close(ctx)
+
+		_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			_, err := tx.Run(ctx, `
+				MATCH (l:MigrationLock {id: $id, holder: $holder})
+				DELETE l
+			`, map[string]any{"id": migrationLockID, "holder": holder})
+			return nil, err
+		})
+		if err != nil {
+			fmt.Printf("⚠️  Failed to release migration lock: %v\n", err)
+		}
+	}, nil
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockRecord is the lock state acquireMigrationLock reads.
+func lockRecord(holder any, acquiredAt any, now time.Time) []*neo4j.Record {
+	return []*neo4j.Record{{
+		Keys:   []string{"holder", "acquiredAt", "now"},
+		Values: []any{holder, acquiredAt, now},
+	}}
+}
+
+// lockClaimed reports whether holder's claim on the lock was committed.
+func lockClaimed(driver *fakeDriver, holder string) bool {
+	for _, q := range driver.queries {
+		if q.tx && q.params["holder"] == holder {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAcquireMigrationLock_Free(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	driver := &fakeDriver{records: lockRecord(nil, nil, now)}
+
+	// Act
+	release, err := acquireMigrationLock(context.Background(), driver, "ci-2", time.Minute)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, lockClaimed(driver, "ci-2"))
+	for _, config := range driver.sessions {
+		assert.Empty(t, config.DatabaseName, "the lock lives in the default database")
+	}
+
+	// Act - release only removes the lock this holder has
+	driver.queries = nil
+	release()
+
+	// Assert
+	require.Len(t, driver.queries, 1)
+	assert.Contains(t, driver.queries[0].cypher, "DELETE l")
+	assert.Equal(t, map[string]any{"id": migrationLockID, "holder": "ci-2"}, driver.queries[0].params)
+}
+
+func TestAcquireMigrationLock_Held(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	driver := &fakeDriver{records: lockRecord("ci-1", now.Add(-5*time.Minute), now)}
+
+	// Act
+	release, err := acquireMigrationLock(context.Background(), driver, "ci-2", 15*time.Minute)
+
+	// Assert
+	assert.Nil(t, release)
+	assert.ErrorContains(t, err, "migrations are locked by ci-1 since 2026-01-02T09:55:00Z")
+	assert.ErrorContains(t, err, "after 2026-01-02T10:10:00Z")
+	assert.False(t, lockClaimed(driver, "ci-2"))
+}
+
+func TestAcquireMigrationLock_StaleTakeover(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	driver := &fakeDriver{records: lockRecord("ci-1", now.Add(-20*time.Minute), now)}
+
+	// Act
+	release, err := acquireMigrationLock(context.Background(), driver, "ci-2", 15*time.Minute)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, release)
+	assert.True(t, lockClaimed(driver, "ci-2"))
+}
+
+func TestAcquireMigrationLock_HeldBySameHolder(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	driver := &fakeDriver{records: lockRecord("ci-2", now.Add(-time.Minute), now)}
+
+	// Act
+	_, err := acquireMigrationLock(context.Background(), driver, "ci-2", 15*time.Minute)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, lockClaimed(driver, "ci-2"))
+}
@@ -0,0 +1,854 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeMigrationFixtures creates migration files under a temp working directory.
+func writeMigrationFixtures(t *testing.T) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	files := []string{
+		"services/core/identity/migrations/001_user_schema.cypher",
+		"services/core/identity/migrations/002_user_indexes.cypher",
+		"services/core/tenant/migrations/001_tenant_schema.cypher",
+		"services/twitter/feed/migrations/001_feed_schema.cypher",
+	}
+	for i := range 20 {
+		files = append(files, fmt.Sprintf("services/commerce/orders/migrations/%03d_step.cypher", i))
+	}
+
+	for _, file := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte("// "+file+"\nRETURN 1;\n"), 0o644))
+	}
+}
+
+func TestDiscoverMigrations_DeterministicAcrossWorkers(t *testing.T) {
+	// Arrange
+	writeMigrationFixtures(t)
+
+	// Act
+	serial, err := discoverMigrationsWith(migrationPatterns, 1)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, serial, 24)
+	assert.True(t, sort.SliceIsSorted(serial, func(i, j int) bool { return serial[i].ID < serial[j].ID }))
+	for _, workers := range []int{2, 8, 64} {
+		parallel, err := discoverMigrationsWith(migrationPatterns, workers)
+		require.NoError(t, err)
+		assert.Equal(t, serial, parallel, "workers=%d", workers)
+	}
+}
+
+func TestDiscoverMigrations_OverlappingPatterns(t *testing.T) {
+	// Arrange - core migrations match both services globs, once via an unclean path
+	writeMigrationFixtures(t)
+	patterns := append([]string{"services/./core/*/migrations/*.cypher"}, migrationPatterns...)
+
+	// Act
+	migrations, err := discoverMigrationsWith(patterns, 4)
+
+	// Assert
+	require.NoError(t, err)
+	seen := make(map[string]bool)
+	for _, m := range migrations {
+		assert.False(t, seen[m.ID], "duplicate ID %s", m.ID)
+		seen[m.ID] = true
+	}
+	assert.Len(t, migrations, 24)
+	assert.Equal(t, "feed/001_feed_schema", migrations[0].ID)
+	assert.Equal(t, "identity/001_user_schema", migrations[1].ID)
+	assert.Equal(t, "services/core/identity/migrations/001_user_schema.cypher", migrations[1].Path)
+}
+
+func TestDiscoverMigrations_ConflictingIDs(t *testing.T) {
+	// Arrange - two apps named identity produce the same migration ID
+	writeMigrationFixtures(t)
+	file := "services/legacy/identity/migrations/001_user_schema.cypher"
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+	require.NoError(t, os.WriteFile(file, []byte("RETURN 1;\n"), 0o644))
+
+	// Act
+	migrations, err := discoverMigrationsWith(migrationPatterns, 4)
+
+	// Assert
+	assert.Nil(t, migrations)
+	assert.ErrorContains(t, err, "duplicate migration ID identity/001_user_schema")
+}
+
+func TestDiscoverMigrations_DownFiles(t *testing.T) {
+	// Arrange
+	writeMigrationFixtures(t)
+	down := "services/core/identity/migrations/002_user_indexes.down.cypher"
+	require.NoError(t, os.WriteFile(down, []byte("DROP INDEX user_idx IF EXISTS;\n"), 0o644))
+
+	// Act
+	migrations, err := discoverMigrationsWith(migrationPatterns, 4)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, migrations, 24, "down files are not migrations of their own")
+	byID := make(map[string]Migration)
+	for _, m := range migrations {
+		byID[m.ID] = m
+	}
+	assert.Equal(t, down, byID["identity/002_user_indexes"].DownPath)
+	assert.Empty(t, byID["identity/001_user_schema"].DownPath)
+}
+
+func TestParseMigration(t *testing.T) {
+	// Arrange
+	writeMigrationFixtures(t)
+	path := "services/core/identity/migrations/002_user_indexes.cypher"
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// Act
+	m, err := parseMigration(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Migration{
+		ID:       "identity/002_user_indexes",
+		App:      "identity",
+		Filename: "002_user_indexes.cypher",
+		Path:     path,
+		Checksum: fmt.Sprintf("%x", sha256.Sum256(content)),
+	}, m)
+}
+
+func TestParseMigration_ChecksumFollowsContent(t *testing.T) {
+	// Arrange
+	writeMigrationFixtures(t)
+	path := "services/core/identity/migrations/001_user_schema.cypher"
+	before, err := parseMigration(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("// edited after it was applied\nRETURN 2;\n"), 0o644))
+
+	// Act
+	after, err := parseMigration(path)
+	again, againErr := parseMigration(path)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, againErr)
+	assert.NotEqual(t, before.Checksum, after.Checksum)
+	assert.Equal(t, after.Checksum, again.Checksum)
+	assert.Equal(t, before.ID, after.ID)
+}
+
+func TestParseMigration_InvalidPath(t *testing.T) {
+	// Arrange
+	t.Chdir(t.TempDir())
+	path := "notes/001_user_schema.cypher"
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("RETURN 1;\n"), 0o644))
+
+	// Act
+	_, err := parseMigration(path)
+	_, missingErr := parseMigration("services/core/identity/migrations/404_missing.cypher")
+
+	// Assert
+	assert.ErrorContains(t, err, "invalid migration path structure")
+	assert.ErrorContains(t, missingErr, "failed to read file")
+}
+
+func TestChangedMigrations(t *testing.T) {
+	// Arrange
+	migrations := []Migration{
+		{ID: "identity/001_user_schema", Checksum: "aaa"},
+		{ID: "identity/002_user_indexes", Checksum: "bbb"},
+		{ID: "tenant/001_tenant_schema", Checksum: "ccc"},
+		{ID: "tenant/002_tenant_indexes", Checksum: "ddd"},
+	}
+	applied := map[string]AppliedMigration{
+		"identity/001_user_schema":  {ID: "identity/001_user_schema", Checksum: "aaa"},
+		"identity/002_user_indexes": {ID: "identity/002_user_indexes", Checksum: "stale"},
+		// Recorded without a checksum, so it can't be compared
+		"tenant/001_tenant_schema": {ID: "tenant/001_tenant_schema"},
+	}
+
+	// Act
+	changed := changedMigrations(migrations, applied)
+
+	// Assert
+	require.Len(t, changed, 1)
+	assert.Equal(t, "identity/002_user_indexes", changed[0].ID)
+}
+
+// coreMigration returns the repository's migration id, run from the
+// repository root.
+func coreMigration(t *testing.T, id string) Migration {
+	t.Helper()
+	t.Chdir("../../..")
+
+	migrations, err := discoverMigrations()
+	require.NoError(t, err)
+	for _, m := range migrations {
+		if m.ID == id {
+			return m
+		}
+	}
+	require.FailNow(t, "migration not discovered", id)
+	return Migration{}
+}
+
+// cypherStatements returns the statements in the Cypher file at path.
+func cypherStatements(t *testing.T, path string) []string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return parseCypherStatements(string(content))
+}
+
+func TestCoreMigrations_LookupIndexes(t *testing.T) {
+	testCases := []struct {
+		id       string
+		wantUp   []string
+		wantDown []string
+	}{
+		{
+			id: "identity/003_email_lower_index",
+			wantUp: []string{
+				"CREATE CONSTRAINT user_email_lower_unique IF NOT EXISTS\nFOR (u:User) REQUIRE u.emailLower IS UNIQUE",
+			},
+			wantDown: []string{
+				"DROP CONSTRAINT user_email_lower_unique IF EXISTS",
+			},
+		},
+		{
+			id: "tenant/004_lookup_indexes",
+			wantUp: []string{
+				"CREATE INDEX membership_status IF NOT EXISTS\nFOR (m:Membership) ON (m.status)",
+				"CREATE INDEX membership_status_role IF NOT EXISTS\nFOR (m:Membership) ON (m.status, m.role)",
+			},
+			wantDown: []string{
+				"DROP INDEX membership_status_role IF EXISTS",
+				"DROP INDEX membership_status IF EXISTS",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.id, func(t *testing.T) {
+			// Act
+			m := coreMigration(t, tc.id)
+
+			// Assert
+			require.NotEmpty(t, m.DownPath)
+			assert.Equal(t, tc.wantUp, cypherStatements(t, m.Path))
+			assert.Equal(t, tc.wantDown, cypherStatements(t, m.DownPath))
+		})
+	}
+}
+
+func TestParseCypherStatements(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "multi-line statements and comment lines",
+			content: "// header\nCREATE INDEX a IF NOT EXISTS\n// why\nFOR (u:User) ON (u.a);\n\nRETURN 1;\n",
+			want:    []string{"CREATE INDEX a IF NOT EXISTS\nFOR (u:User) ON (u.a)", "RETURN 1"},
+		},
+		{
+			name:    "last statement without semicolon",
+			content: "RETURN 1;\nRETURN 2\n",
+			want:    []string{"RETURN 1", "RETURN 2"},
+		},
+		{
+			name:    "semicolon in single quotes",
+			content: "MATCH (t:Tenant) SET t.note = 'a; b';\nRETURN 1;",
+			want:    []string{"MATCH (t:Tenant) SET t.note = 'a; b'", "RETURN 1"},
+		},
+		{
+			name:    "semicolon in double quotes at end of line",
+			content: "MATCH (t:Tenant) SET t.note = \"ends;\nwith;\"\nRETURN t;",
+			want:    []string{"MATCH (t:Tenant) SET t.note = \"ends;\nwith;\"\nRETURN t"},
+		},
+		{
+			name:    "escaped quotes",
+			content: `SET n.a = 'it\'s; fine', n.b = "say \"hi;\"";` + "\nRETURN 1;",
+			want:    []string{`SET n.a = 'it\'s; fine', n.b = "say \"hi;\""`, "RETURN 1"},
+		},
+		{
+			name:    "escaped backslash before closing quote",
+			content: `SET n.path = 'C:\\';` + "\nRETURN 1;",
+			want:    []string{`SET n.path = 'C:\\'`, "RETURN 1"},
+		},
+		{
+			name:    "backtick identifiers",
+			content: "MATCH (n:`odd;label`) SET n.`a``b;c` = 1;\nRETURN 1;",
+			want:    []string{"MATCH (n:`odd;label`) SET n.`a``b;c` = 1", "RETURN 1"},
+		},
+		{
+			name:    "slashes in strings",
+			content: "SET n.url = 'https://example.com/a;b'; // trailing comment\nRETURN 1;",
+			want:    []string{"SET n.url = 'https://example.com/a;b'", "RETURN 1"},
+		},
+		{
+			name:    "trailing comment hides semicolon",
+			content: "RETURN 1 // not the end;\n;\n",
+			want:    []string{"RETURN 1"},
+		},
+		{
+			name:    "quote inside comment",
+			content: "RETURN 1; // don't\nRETURN 2;",
+			want:    []string{"RETURN 1", "RETURN 2"},
+		},
+		{
+			name:    "CRLF line endings",
+			content: "RETURN 1;\r\n// comment\r\nRETURN 2;\r\n",
+			want:    []string{"RETURN 1", "RETURN 2"},
+		},
+		{
+			name:    "comments only",
+			content: "// nothing\n\n   // to run\n",
+			want:    nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			statements := parseCypherStatements(tc.content)
+
+			// Assert
+			assert.Equal(t, tc.want, statements)
+		})
+	}
+}
+
+// fakeDriver records the sessions opened on it and the queries they run.
+// Queries in a managed transaction are only recorded once it commits.
+// Methods not overridden panic via the nil embedded interface.
+type fakeDriver struct {
+	neo4j.DriverWithContext
+	sessions []neo4j.SessionConfig
+	queries  []fakeQuery
+	records  []*neo4j.Record
+	// failOn makes running this exact statement fail
+	failOn string
+}
+
+// fakeQuery is a query run on a fakeDriver session.
+type fakeQuery struct {
+	database string
+	cypher   string
+	params   map[string]any
+	// tx reports whether the query ran in a managed transaction
+	tx bool
+}
+
+func (d *fakeDriver) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	d.sessions = append(d.sessions, config)
+	return &fakeSession{driver: d, config: config}
+}
+
+type fakeSession struct {
+	neo4j.SessionWithContext
+	driver *fakeDriver
+	config neo4j.SessionConfig
+}
+
+func (s *fakeSession) Run(ctx context.Context, cypher string, params map[string]any, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ResultWithContext, error) {
+	if cypher == s.driver.failOn {
+		return nil, fmt.Errorf("statement failed")
+	}
+	s.driver.queries = append(s.driver.queries, fakeQuery{database: s.config.DatabaseName, cypher: cypher, params: params})
+	return &fakeResult{records: s.driver.records}, nil
+}
+
+func (s *fakeSession) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	tx := &fakeTx{session: s}
+	result, err := work(tx)
+	if err != nil {
+		return nil, err
+	}
+	s.driver.queries = append(s.driver.queries, tx.queries...)
+	return result, nil
+}
+
+// fakeTx buffers its queries until the fakeSession commits it.
+type fakeTx struct {
+	neo4j.ManagedTransaction
+	session *fakeSession
+	queries []fakeQuery
+}
+
+func (tx *fakeTx) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	if cypher == tx.session.driver.failOn {
+		return nil, fmt.Errorf("statement failed")
+	}
+	tx.queries = append(tx.queries, fakeQuery{database: tx.session.config.DatabaseName, cypher: cypher, params: params, tx: true})
+	return &fakeResult{records: tx.session.driver.records}, nil
+}
+
+func (s *fakeSession) Close(ctx context.Context) error {
+	return nil
+}
+
+// fakeResult iterates over fixed records.
+type fakeResult struct {
+	neo4j.ResultWithContext
+	records []*neo4j.Record
+	current *neo4j.Record
+}
+
+func (r *fakeResult) Next(ctx context.Context) bool {
+	if len(r.records) == 0 {
+		return false
+	}
+	r.current, r.records = r.records[0], r.records[1:]
+	return true
+}
+
+func (r *fakeResult) Single(ctx context.Context) (*neo4j.Record, error) {
+	if len(r.records) == 0 {
+		return nil, fmt.Errorf("result is empty")
+	}
+	return r.records[0], nil
+}
+
+func (r *fakeResult) Record() *neo4j.Record {
+	return r.current
+}
+
+func (r *fakeResult) Err() error {
+	return nil
+}
+
+func TestMigrateDatabase_UsesDatabaseName(t *testing.T) {
+	// Arrange
+	writeMigrationFixtures(t)
+	migrations, err := discoverMigrationsWith(migrationPatterns, 1)
+	require.NoError(t, err)
+	driver := &fakeDriver{}
+
+	// Act
+	err = migrateDatabase(context.Background(), driver, "tenant-acme", migrations[:2], upOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, driver.sessions)
+	for _, config := range driver.sessions {
+		assert.Equal(t, "tenant-acme", config.DatabaseName)
+	}
+	var recorded []any
+	for _, q := range driver.queries {
+		if id, ok := q.params["id"]; ok {
+			recorded = append(recorded, id)
+		}
+	}
+	assert.Equal(t, []any{migrations[0].ID, migrations[1].ID}, recorded)
+}
+
+func TestMigrateDatabase_ChangedMigrations(t *testing.T) {
+	testCases := []struct {
+		name        string
+		strict      bool
+		wantErr     string
+		wantApplied bool
+	}{
+		{name: "reported", strict: false, wantApplied: true},
+		{name: "strict aborts", strict: true, wantErr: "1 applied migration(s) changed", wantApplied: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange - the first migration was applied from a different file
+			writeMigrationFixtures(t)
+			migrations, err := discoverMigrationsWith(migrationPatterns, 1)
+			require.NoError(t, err)
+			driver := &fakeDriver{records: []*neo4j.Record{
+				{Keys: []string{"id", "appliedAt", "checksum"}, Values: []any{migrations[0].ID, nil, "stale"}},
+			}}
+
+			// Act
+			err = migrateDatabase(context.Background(), driver, "", migrations[:2], upOptions{strict: tc.strict})
+
+			// Assert
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			var recorded []any
+			for _, q := range driver.queries {
+				if id, ok := q.params["id"]; ok {
+					recorded = append(recorded, id)
+				}
+			}
+			if tc.wantApplied {
+				assert.Equal(t, []any{migrations[1].ID}, recorded)
+			} else {
+				assert.Empty(t, recorded, "nothing is applied")
+			}
+		})
+	}
+}
+
+func TestPlanMigration(t *testing.T) {
+	// Arrange
+	statements := []string{
+		"CREATE CONSTRAINT a IF NOT EXISTS FOR (n:A) REQUIRE n.id IS UNIQUE",
+		"create text index b if not exists for (n:A) on (n.name)",
+		"MATCH (n:A) SET n.name = coalesce(n.name, '')",
+		"MATCH (n:A) SET n.indexed = true",
+		"DROP INDEX c IF EXISTS",
+		"MATCH (n:IndexedAt) RETURN n",
+	}
+
+	// Act
+	phases := planMigration(statements)
+
+	// Assert
+	assert.Equal(t, []migrationPhase{
+		{schema: true, statements: statements[0:2]},
+		{schema: false, statements: statements[2:4]},
+		{schema: true, statements: statements[4:5]},
+		{schema: false, statements: statements[5:6]},
+	}, phases)
+}
+
+func TestApplyMigration_FailingStatementRollsBack(t *testing.T) {
+	// Arrange
+	writeMigrationFixtures(t)
+	path := "services/core/identity/migrations/001_user_schema.cypher"
+	content := "MATCH (u:User) SET u.a = 1;\nMATCH (u:User) SET u.b = 1 / 0;\nMATCH (u:User) SET u.c = 1;\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	m, err := parseMigration(path)
+	require.NoError(t, err)
+	driver := &fakeDriver{failOn: "MATCH (u:User) SET u.b = 1 / 0"}
+
+	// Act
+	err = applyMigration(context.Background(), driver, "", m)
+
+	// Assert
+	assert.ErrorContains(t, err, "Statement: MATCH (u:User) SET u.b = 1 / 0")
+	assert.Empty(t, driver.queries, "the first statement rolls back and the migration stays unrecorded")
+}
+
+func TestApplyMigration_SchemaStatementsAutoCommit(t *testing.T) {
+	// Arrange
+	writeMigrationFixtures(t)
+	path := "services/core/identity/migrations/001_user_schema.cypher"
+	content := "CREATE INDEX user_a IF NOT EXISTS\nFOR (u:User) ON (u.a);\nMATCH (u:User) SET u.a = 0;\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	m, err := parseMigration(path)
+	require.NoError(t, err)
+	driver := &fakeDriver{}
+
+	// Act
+	err = applyMigration(context.Background(), driver, "", m)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, driver.queries, 3)
+	assert.Equal(t, "CREATE INDEX user_a IF NOT EXISTS\nFOR (u:User) ON (u.a)", driver.queries[0].cypher)
+	assert.False(t, driver.queries[0].tx)
+	assert.Equal(t, "MATCH (u:User) SET u.a = 0", driver.queries[1].cypher)
+	assert.True(t, driver.queries[1].tx)
+	assert.Equal(t, m.ID, driver.queries[2].params["id"])
+	assert.True(t, driver.queries[2].tx, "recorded with the data statements")
+}
+
+func TestApplyMigration_SchemaOnly(t *testing.T) {
+	// Arrange
+	writeMigrationFixtures(t)
+	path := "services/core/identity/migrations/001_user_schema.cypher"
+	require.NoError(t, os.WriteFile(path, []byte("DROP INDEX user_a IF EXISTS;\n"), 0o644))
+	m, err := parseMigration(path)
+	require.NoError(t, err)
+	driver := &fakeDriver{}
+
+	// Act
+	err = applyMigration(context.Background(), driver, "", m)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, driver.queries, 2)
+	assert.False(t, driver.queries[0].tx)
+	assert.Equal(t, m.ID, driver.queries[1].params["id"])
+}
+
+// targetMigrations is a synthetic set of migrations across two apps, in
+// ID order.
+var targetMigrations = []Migration{
+	{ID: "identity/001_user_schema"},
+	{ID: "identity/002_user_indexes"},
+	{ID: "tenant/001_tenant_schema"},
+	{ID: "tenant/002_membership_schema"},
+	{ID: "tenant/003_lookup_indexes"},
+}
+
+func TestPendingMigrations_Target(t *testing.T) {
+	applied := map[string]AppliedMigration{
+		"identity/001_user_schema": {ID: "identity/001_user_schema"},
+		"tenant/001_tenant_schema": {ID: "tenant/001_tenant_schema"},
+	}
+	testCases := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{name: "no target", target: "", want: []string{"identity/002_user_indexes", "tenant/002_membership_schema", "tenant/003_lookup_indexes"}},
+		{name: "target is pending", target: "tenant/002_membership_schema", want: []string{"identity/002_user_indexes", "tenant/002_membership_schema"}},
+		{name: "target is applied", target: "tenant/001_tenant_schema", want: []string{"identity/002_user_indexes"}},
+		{name: "target before pending", target: "identity/001_user_schema", want: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			pending := pendingMigrations(targetMigrations, applied, tc.target)
+
+			// Assert
+			var ids []string
+			for _, m := range pending {
+				ids = append(ids, m.ID)
+			}
+			assert.Equal(t, tc.want, ids)
+		})
+	}
+}
+
+func TestRollbackTargets(t *testing.T) {
+	applied := []AppliedMigration{
+		{ID: "identity/001_user_schema"},
+		{ID: "identity/002_user_indexes"},
+		{ID: "tenant/001_tenant_schema"},
+		{ID: "tenant/002_membership_schema"},
+	}
+	testCases := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{name: "no target rolls back the last", target: "", want: []string{"tenant/002_membership_schema"}},
+		{name: "newest first", target: "identity/001_user_schema", want: []string{"tenant/002_membership_schema", "tenant/001_tenant_schema", "identity/002_user_indexes"}},
+		{name: "target is the last applied", target: "tenant/002_membership_schema", want: nil},
+		{name: "target is pending", target: "tenant/003_lookup_indexes", want: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			targets := rollbackTargets(applied, tc.target)
+
+			// Assert
+			var ids []string
+			for _, a := range targets {
+				ids = append(ids, a.ID)
+			}
+			assert.Equal(t, tc.want, ids)
+		})
+	}
+}
+
+func TestValidateTarget(t *testing.T) {
+	// Act
+	noTargetErr := validateTarget(targetMigrations, "")
+	knownErr := validateTarget(targetMigrations, "tenant/002_membership_schema")
+	unknownErr := validateTarget(targetMigrations, "tenant/004_missing")
+
+	// Assert
+	assert.NoError(t, noTargetErr)
+	assert.NoError(t, knownErr)
+	assert.ErrorContains(t, unknownErr, `unknown target migration "tenant/004_missing"`)
+}
+
+// mixedMigrationRecords are :Migration rows as a database holding both
+// ID-tracked and version-tracked records would return them.
+var mixedMigrationRecords = []*neo4j.Record{
+	{Keys: []string{"id", "appliedAt", "checksum", "version"}, Values: []any{"identity/001_user_schema", nil, "aaa", nil}},
+	{Keys: []string{"id", "appliedAt", "checksum", "version"}, Values: []any{nil, nil, nil, int64(3)}},
+	{Keys: []string{"id", "appliedAt", "checksum", "version"}, Values: []any{"tenant/001_tenant_schema", nil, "bbb", nil}},
+}
+
+func TestGetAppliedMigrations_IgnoresVersionedRecords(t *testing.T) {
+	// Arrange
+	driver := &fakeDriver{records: mixedMigrationRecords}
+
+	// Act
+	applied, err := getAppliedMigrations(context.Background(), driver, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []AppliedMigration{
+		{ID: "identity/001_user_schema", Checksum: "aaa"},
+		{ID: "tenant/001_tenant_schema", Checksum: "bbb"},
+	}, applied)
+	require.Len(t, driver.queries, 1)
+	assert.Contains(t, driver.queries[0].cypher, "WHERE m.id IS NOT NULL")
+}
+
+func TestGetVersionedMigrations(t *testing.T) {
+	// Arrange
+	driver := &fakeDriver{records: mixedMigrationRecords}
+
+	// Act
+	versioned, err := getVersionedMigrations(context.Background(), driver, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []VersionedMigration{{Version: 3}}, versioned)
+	require.Len(t, driver.queries, 1)
+	assert.Contains(t, driver.queries[0].cypher, "WHERE m.id IS NULL")
+}
+
+func TestMigrateDatabase_LeavesVersionedRecords(t *testing.T) {
+	// Arrange - a version-tracked record must not count as applied or be touched
+	writeMigrationFixtures(t)
+	migrations, err := discoverMigrationsWith(migrationPatterns, 1)
+	require.NoError(t, err)
+	driver := &fakeDriver{records: mixedMigrationRecords[1:2]}
+
+	// Act
+	err = migrateDatabase(context.Background(), driver, "", migrations[:2], upOptions{})
+
+	// Assert
+	require.NoError(t, err)
+	for _, q := range driver.queries {
+		assert.NotContains(t, q.cypher, "version", "ID-tracked writes never match version records")
+	}
+	var recorded []any
+	for _, q := range driver.queries {
+		if id, ok := q.params["id"]; ok {
+			recorded = append(recorded, id)
+		}
+	}
+	assert.Equal(t, []any{migrations[0].ID, migrations[1].ID}, recorded)
+}
+
+func TestMigrateDatabase_DryRun(t *testing.T) {
+	// Arrange - the first migration is applied, so only the second is pending
+	writeMigrationFixtures(t)
+	migrations, err := discoverMigrationsWith(migrationPatterns, 1)
+	require.NoError(t, err)
+	driver := &fakeDriver{records: []*neo4j.Record{
+		{Keys: []string{"id", "appliedAt", "checksum"}, Values: []any{migrations[0].ID, nil, migrations[0].Checksum}},
+	}}
+
+	// Act
+	err = migrateDatabase(context.Background(), driver, "", migrations[:2], upOptions{dryRun: true})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, driver.queries, 1, "only applied migrations are read")
+	assert.Contains(t, driver.queries[0].cypher, "MATCH (m:Migration)")
+	for _, q := range driver.queries {
+		assert.NotContains(t, q.cypher, "CREATE", "no :Migration node or constraint is created")
+		assert.False(t, q.tx, "no write transaction is opened")
+	}
+}
+
+func TestRollbackMigration_RunsDownFile(t *testing.T) {
+	// Arrange
+	writeMigrationFixtures(t)
+	down := "services/core/identity/migrations/002_user_indexes.down.cypher"
+	require.NoError(t, os.WriteFile(down, []byte("// undo\nDROP INDEX a IF EXISTS;\nDROP INDEX b IF EXISTS;\n"), 0o644))
+	driver := &fakeDriver{}
+
+	// Act
+	err := rollbackMigration(context.Background(), driver, "neo4j", "identity/002_user_indexes", down)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, driver.queries, 3)
+	assert.Equal(t, "DROP INDEX a IF EXISTS", driver.queries[0].cypher)
+	assert.Equal(t, "DROP INDEX b IF EXISTS", driver.queries[1].cypher)
+	assert.Equal(t, map[string]any{"id": "identity/002_user_indexes"}, driver.queries[2].params)
+}
+
+func TestRollbackMigration_WithoutDownFileRemovesRecordOnly(t *testing.T) {
+	// Arrange
+	driver := &fakeDriver{}
+
+	// Act
+	err := rollbackMigration(context.Background(), driver, "neo4j", "identity/001_user_schema", "")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, driver.queries, 1)
+	assert.Contains(t, driver.queries[0].cypher, "DELETE m")
+}
+
+func TestRunMigrateCreate_WithDown(t *testing.T) {
+	testCases := []struct {
+		name     string
+		withDown bool
+	}{
+		{name: "up only", withDown: false},
+		{name: "with down", withDown: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			writeMigrationFixtures(t)
+			appFilter, withDown = "core/identity", tc.withDown
+			t.Cleanup(func() { appFilter, withDown = "", false })
+
+			// Act
+			err := runMigrateCreate(migrateCreateCmd, []string{"add_user_roles"})
+
+			// Assert
+			require.NoError(t, err)
+			migrations, err := discoverMigrationsWith(migrationPatterns, 1)
+			require.NoError(t, err)
+			var created Migration
+			for _, m := range migrations {
+				if m.ID == "identity/003_add_user_roles" {
+					created = m
+				}
+			}
+			require.NotEmpty(t, created.Path, "the new migration is discovered")
+			if !tc.withDown {
+				assert.Empty(t, created.DownPath)
+				return
+			}
+			assert.Equal(t, "services/core/identity/migrations/003_add_user_roles.down.cypher", created.DownPath)
+			content, err := os.ReadFile(created.DownPath)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "// Rollback: core/identity/003_add_user_roles")
+			assert.Empty(t, parseCypherStatements(string(content)), "the scaffold only holds comments")
+		})
+	}
+}
+
+func TestListTenantDatabases_DedicatedTenants(t *testing.T) {
+	// Arrange - the query filters on isolation mode; rows are what it would return
+	driver := &fakeDriver{records: []*neo4j.Record{
+		{Keys: []string{"slug", "databaseName"}, Values: []any{"startup_inc", nil}},
+		{Keys: []string{"slug", "databaseName"}, Values: []any{"acme", "acme-prod"}},
+		{Keys: []string{"slug", "databaseName"}, Values: []any{"Beta", nil}},
+		{Keys: []string{"slug", "databaseName"}, Values: []any{"acme-copy", "acme-prod"}},
+	}}
+
+	// Act
+	databases, err := listTenantDatabases(context.Background(), driver)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme-prod", "tenant-beta", "tenant-startup-inc"}, databases)
+	require.Len(t, driver.queries, 1)
+	assert.Empty(t, driver.queries[0].database, "tenants are read from the default database")
+	assert.Equal(t, "DEDICATED", driver.queries[0].params["isolationMode"])
+	assert.Contains(t, driver.queries[0].cypher, "t.status <> 'DELETED'")
+}
@@ -3,11 +3,14 @@ package commands
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/spf13/cobra"
+	"github.com/yourusername/grgn-stack/pkg/auth"
 	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/retry"
+	identityRepo "github.com/yourusername/grgn-stack/services/core/identity/repository"
 	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
 )
 
@@ -16,11 +19,14 @@ var seedCmd = &cobra.Command{
 	Short: "Seed the database with test data",
 	Long: `Create test users, tenants, and memberships for development.
 
-This command creates:
+By default this command creates:
 - 3 test users (Alice, Bob, Charlie)
 - 2 test tenants (Acme Corp, Startup Inc)
 - Membership relationships with various roles
 
+Use --fixture to seed from a YAML file instead (see seed_fixture.go for the
+format). Seeding is idempotent: users and tenants are merged by email and slug.
+
 Use --clean to clear existing data before seeding.`,
 	RunE: runSeed,
 }
@@ -28,6 +34,7 @@ Use --clean to clear existing data before seeding.`,
 func init() {
 	rootCmd.AddCommand(seedCmd)
 	seedCmd.Flags().Bool("clean", false, "Clear existing data before seeding")
+	seedCmd.Flags().String("fixture", "", "YAML fixture of users and tenants to seed instead of the built-in data")
 }
 
 func runSeed(cmd *cobra.Command, args []string) error {
@@ -39,19 +46,36 @@ func runSeed(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Connect to Neo4j
-	db, err := shared.NewNeo4jDB(cfg)
+	// Load and validate seed data before touching the database
+	fixture := &defaultFixture
+	if fixturePath, _ := cmd.Flags().GetString("fixture"); fixturePath != "" {
+		fixture, err = loadFixture(fixturePath)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📄 Loaded fixture %s\n", fixturePath)
+	}
+	if err := fixture.validate(); err != nil {
+		return fmt.Errorf("invalid fixture:\n%w", err)
+	}
+
+	// Connect to Neo4j, giving a freshly started database time to come up
+	db, err := shared.NewNeo4jDB(cfg, retry.Policy{
+		MaxAttempts: 5,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Retryable:   shared.IsTransientConnectError,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			fmt.Printf("⏳ Database not ready, retrying in %s...\n", delay.Round(time.Millisecond))
+		},
+	})
 	if err != nil {
-		return fmt.Errorf("failed to connect to Neo4j: %w", err)
+		return err
 	}
 	defer db.Close(context.Background())
 
 	ctx := context.Background()
 
-	// Verify connectivity
-	if err := db.VerifyConnectivity(ctx); err != nil {
-		return fmt.Errorf("failed to verify database connectivity: %w", err)
-	}
 	fmt.Println("✅ Connected to Neo4j")
 
 	// Check for --clean flag
@@ -72,191 +96,60 @@ func runSeed(cmd *cobra.Command, args []string) error {
 		fmt.Println("  ✅ Existing data cleared")
 	}
 
-	// Create test users
-	users := []struct {
-		email string
-		name  string
-	}{
-		{"alice@example.com", "Alice Johnson"},
-		{"bob@example.com", "Bob Smith"},
-		{"charlie@example.com", "Charlie Brown"},
-	}
-
-	userIDs := make(map[string]string)
-
-	fmt.Println("\n👥 Creating users...")
-	for _, u := range users {
-		id := uuid.New().String()
-		_, err := db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-			result, err := tx.Run(ctx, `
-				MERGE (u:User {email: $email})
-				ON CREATE SET 
-					u.id = $id,
-					u.name = $name,
-					u.status = 'ACTIVE',
-					u.createdAt = datetime(),
-					u.updatedAt = datetime()
-				ON MATCH SET
-					u.name = $name,
-					u.updatedAt = datetime()
-				RETURN u.id as id
-			`, map[string]any{"id": id, "email": u.email, "name": u.name})
-			if err != nil {
-				return nil, err
-			}
-
-			record, err := result.Single(ctx)
-			if err != nil {
-				return nil, err
-			}
-
-			returnedID, _ := record.Get("id")
-			return returnedID.(string), nil
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create user %s: %w", u.email, err)
-		}
-		userIDs[u.email] = id
-		fmt.Printf("  ✅ %s <%s>\n", u.name, u.email)
+	fmt.Printf("\n🌱 Merging %d users and %d tenants...\n", len(fixture.Users), len(fixture.Tenants))
+	userIDs, err := applyFixture(ctx, db, fixture)
+	if err != nil {
+		return err
 	}
-
-	// Create test tenants with memberships
-	tenants := []struct {
-		name    string
-		slug    string
-		owner   string
-		members []struct {
-			email string
-			role  string
-		}
-	}{
-		{
-			name:  "Acme Corp",
-			slug:  "acme",
-			owner: "alice@example.com",
-			members: []struct {
-				email string
-				role  string
-			}{
-				{"bob@example.com", "ADMIN"},
-			},
-		},
-		{
-			name:  "Startup Inc",
-			slug:  "startup",
-			owner: "bob@example.com",
-			members: []struct {
-				email string
-				role  string
-			}{
-				{"alice@example.com", "MEMBER"},
-				{"charlie@example.com", "VIEWER"},
-			},
-		},
-	}
-
-	fmt.Println("\n🏢 Creating tenants...")
-	for _, t := range tenants {
-		tenantID := uuid.New().String()
-
-		// Create tenant
-		_, err := db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-			_, err := tx.Run(ctx, `
-				MERGE (t:Tenant {slug: $slug})
-				ON CREATE SET
-					t.id = $id,
-					t.name = $name,
-					t.plan = 'FREE',
-					t.status = 'ACTIVE',
-					t.isolationMode = 'SHARED',
-					t.createdAt = datetime(),
-					t.updatedAt = datetime()
-				ON MATCH SET
-					t.name = $name,
-					t.updatedAt = datetime()
-				RETURN t
-			`, map[string]any{"id": tenantID, "name": t.name, "slug": t.slug})
-			return nil, err
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create tenant %s: %w", t.name, err)
-		}
-		fmt.Printf("  ✅ %s (/%s)\n", t.name, t.slug)
-
-		// Create owner membership
-		_, err = db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-			_, err := tx.Run(ctx, `
-				MATCH (u:User {email: $email}), (t:Tenant {slug: $slug})
-				MERGE (u)-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t)
-				ON CREATE SET
-					m.id = $membershipId,
-					m.role = 'OWNER',
-					m.joinedAt = datetime()
-				RETURN m
-			`, map[string]any{
-				"email":        t.owner,
-				"slug":         t.slug,
-				"membershipId": uuid.New().String(),
-			})
-			return nil, err
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create owner membership: %w", err)
-		}
-		fmt.Printf("    👑 Owner: %s\n", t.owner)
-
-		// Create member memberships
-		for _, member := range t.members {
-			_, err = db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-				_, err := tx.Run(ctx, `
-					MATCH (u:User {email: $email}), (t:Tenant {slug: $slug})
-					MERGE (u)-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t)
-					ON CREATE SET
-						m.id = $membershipId,
-						m.role = $role,
-						m.joinedAt = datetime()
-					RETURN m
-				`, map[string]any{
-					"email":        member.email,
-					"slug":         t.slug,
-					"membershipId": uuid.New().String(),
-					"role":         member.role,
-				})
-				return nil, err
-			})
-			if err != nil {
-				return fmt.Errorf("failed to create member membership: %w", err)
-			}
-			fmt.Printf("    👤 %s: %s\n", member.role, member.email)
+	for _, t := range fixture.Tenants {
+		fmt.Printf("  ✅ %s (/%s)\n", t.Name, t.Slug)
+		for _, m := range t.Members {
+			fmt.Printf("    👤 %s: %s\n", m.Role, m.Email)
 		}
 	}
 
 	fmt.Println("\n🎉 Seeding complete!")
 	fmt.Println("\n📋 Test Data Summary:")
 	fmt.Println("   Users:")
-	for email, id := range userIDs {
-		fmt.Printf("     • %s: %s\n", email, id)
+	for _, u := range fixture.Users {
+		fmt.Printf("     • %s: %s\n", u.Email, userIDs[u.Email])
+	}
+	if len(fixture.Users) == 0 {
+		return nil
+	}
+	exampleID := userIDs[fixture.Users[0].Email]
+
+	// Issue a token for the first user so the examples below authenticate
+	// the way production clients do
+	epoch, err := identityRepo.NewUserRepository(db).GetTokenEpoch(ctx, exampleID)
+	if err != nil {
+		return fmt.Errorf("failed to read token epoch: %w", err)
+	}
+	token, err := auth.NewTokenManager(cfg.Auth.JWTSecret, cfg.Auth.TokenTTL, nil).IssueToken(exampleID, epoch)
+	if err != nil {
+		return err
 	}
 
 	fmt.Println("\n🧪 Test with GraphQL:")
 	fmt.Printf(`
-   # Start the server
+   # Start the server with the same GRGN_STACK_AUTH_JWT_SECRET
    go run ./cmd/server
 
-   # In another terminal, test queries:
-   
-   # Get Alice's tenants
+   # In another terminal, test queries as %s:
+   TOKEN=%s
+
+   # Get the user's tenants
    curl -X POST http://localhost:8080/graphql \
      -H "Content-Type: application/json" \
-     -H "X-User-ID: %s" \
+     -H "Authorization: Bearer $TOKEN" \
      -d '{"query": "{ myTenants { id name slug memberCount } }"}'
 
-   # Create a new tenant as Alice
+   # Create a new tenant as that user
    curl -X POST http://localhost:8080/graphql \
      -H "Content-Type: application/json" \
-     -H "X-User-ID: %s" \
+     -H "Authorization: Bearer $TOKEN" \
      -d '{"query": "mutation { createTenant(input: { name: \"New Corp\", slug: \"newcorp\" }) { id name } }"}'
-`, userIDs["alice@example.com"], userIDs["alice@example.com"])
+`, fixture.Users[0].Email, token)
 
 	return nil
 }
@@ -0,0 +1,263 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yourusername/grgn-stack/pkg/validation"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+	"gopkg.in/yaml.v3"
+)
+
+// seedBatchSize caps the rows sent in one UNWIND ... MERGE query.
+const seedBatchSize = 500
+
+// seedFixture is declarative seed data, loaded from YAML with --fixture:
+//
+//	users:
+//	  - email: alice@example.com
+//	    name: Alice Johnson
+//	tenants:
+//	  - name: Acme Corp
+//	    slug: acme
+//	    plan: PRO            # optional, defaults to FREE
+//	    members:
+//	      - email: alice@example.com
+//	        role: OWNER
+type seedFixture struct {
+	Users   []fixtureUser   `yaml:"users"`
+	Tenants []fixtureTenant `yaml:"tenants"`
+}
+
+type fixtureUser struct {
+	Email string `yaml:"email"`
+	Name  string `yaml:"name"`
+}
+
+type fixtureTenant struct {
+	Name    string          `yaml:"name"`
+	Slug    string          `yaml:"slug"`
+	Plan    string          `yaml:"plan"`
+	Members []fixtureMember `yaml:"members"`
+}
+
+type fixtureMember struct {
+	Email string `yaml:"email"`
+	Role  string `yaml:"role"`
+}
+
+// defaultFixture is seeded when no --fixture is given.
+var defaultFixture = seedFixture{
+	Users: []fixtureUser{
+		{Email: "alice@example.com", Name: "Alice Johnson"},
+		{Email: "bob@example.com", Name: "Bob Smith"},
+		{Email: "charlie@example.com", Name: "Charlie Brown"},
+	},
+	Tenants: []fixtureTenant{
+		{Name: "Acme Corp", Slug: "acme", Members: []fixtureMember{
+			{Email: "alice@example.com", Role: "OWNER"},
+			{Email: "bob@example.com", Role: "ADMIN"},
+		}},
+		{Name: "Startup Inc", Slug: "startup", Members: []fixtureMember{
+			{Email: "bob@example.com", Role: "OWNER"},
+			{Email: "alice@example.com", Role: "MEMBER"},
+			{Email: "charlie@example.com", Role: "VIEWER"},
+		}},
+	},
+}
+
+// loadFixture reads a YAML fixture. Unknown keys are rejected so typos
+// don't silently drop data.
+func loadFixture(path string) (*seedFixture, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open fixture: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+
+	var fixture seedFixture
+	if err := decoder.Decode(&fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	return &fixture, nil
+}
+
+// validate reports every problem in the fixture: invalid or duplicate
+// emails and slugs, unknown plans and roles, members that aren't declared
+// users, and tenants without an owner.
+func (f *seedFixture) validate() error {
+	var problems []error
+	fail := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	// Emails are unique case-insensitively, but members must reference a user
+	// exactly as declared since the graph matches on the stored email
+	users := make(map[string]bool, len(f.Users))
+	seenEmails := make(map[string]bool, len(f.Users))
+	for i, u := range f.Users {
+		if err := validation.ValidateEmail(u.Email); err != nil {
+			fail("users[%d]: invalid email %q", i, u.Email)
+		} else if seenEmails[strings.ToLower(u.Email)] {
+			fail("users[%d]: duplicate email %s", i, u.Email)
+		}
+		users[u.Email] = true
+		seenEmails[strings.ToLower(u.Email)] = true
+	}
+
+	slugs := make(map[string]bool, len(f.Tenants))
+	for i, t := range f.Tenants {
+		if err := validation.ValidateSlug(t.Slug); err != nil {
+			fail("tenants[%d]: invalid slug %q", i, t.Slug)
+		} else if validation.IsReservedSlug(t.Slug, validation.DefaultReservedSlugs) {
+			fail("tenants[%d]: slug %q is reserved", i, t.Slug)
+		} else if slugs[strings.ToLower(t.Slug)] {
+			fail("tenants[%d]: duplicate slug %s", i, t.Slug)
+		}
+		slugs[strings.ToLower(t.Slug)] = true
+
+		if strings.TrimSpace(t.Name) == "" {
+			fail("tenants[%d] (%s): name is required", i, t.Slug)
+		}
+		if t.Plan != "" && !model.TenantPlan(t.Plan).IsValid() {
+			fail("tenants[%d] (%s): unknown plan %q", i, t.Slug, t.Plan)
+		}
+
+		hasOwner := false
+		members := make(map[string]bool, len(t.Members))
+		for _, m := range t.Members {
+			if !users[m.Email] {
+				fail("tenants[%d] (%s): member %s is not a declared user", i, t.Slug, m.Email)
+			}
+			if members[m.Email] {
+				fail("tenants[%d] (%s): duplicate member %s", i, t.Slug, m.Email)
+			}
+			members[m.Email] = true
+
+			role := model.MembershipRole(m.Role)
+			if !role.IsValid() {
+				fail("tenants[%d] (%s): member %s has unknown role %q", i, t.Slug, m.Email, m.Role)
+			}
+			hasOwner = hasOwner || role == model.MembershipRoleOwner
+		}
+		if !hasOwner {
+			fail("tenants[%d] (%s): needs at least one OWNER", i, t.Slug)
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// applyFixture merges the fixture into the graph in one write transaction,
+// batching rows through UNWIND so large fixtures don't need a round trip per
+// node. Existing users and tenants are matched by email and slug and updated;
+// re-running a fixture is a no-op apart from updatedAt. Returns user IDs by email.
+func applyFixture(ctx context.Context, db shared.IDatabase, f *seedFixture) (map[string]string, error) {
+	users := make([]map[string]any, len(f.Users))
+	for i, u := range f.Users {
+		users[i] = map[string]any{"id": uuid.New().String(), "email": u.Email, "name": u.Name}
+	}
+
+	var tenants, memberships []map[string]any
+	for _, t := range f.Tenants {
+		plan := t.Plan
+		if plan == "" {
+			plan = string(model.TenantPlanFree)
+		}
+		tenants = append(tenants, map[string]any{"id": uuid.New().String(), "slug": t.Slug, "name": t.Name, "plan": plan})
+
+		for _, m := range t.Members {
+			memberships = append(memberships, map[string]any{
+				"id": uuid.New().String(), "email": m.Email, "slug": t.Slug, "role": m.Role,
+			})
+		}
+	}
+
+	result, err := db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		userIDs := make(map[string]string, len(users))
+		err := runBatches(ctx, tx, "users", users, `
+			UNWIND $users AS row
+			MERGE (u:User {email: row.email})
+			ON CREATE SET
+				u.id = row.id,
+				u.status = 'ACTIVE',
+				u.tokenEpoch = 0,
+				u.createdAt = datetime()
+			SET u.name = row.name, u.updatedAt = datetime()
+			RETURN u.email AS email, u.id AS id
+		`, func(record *neo4j.Record) {
+			email, _ := record.Get("email")
+			id, _ := record.Get("id")
+			userIDs[email.(string)] = id.(string)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge users: %w", err)
+		}
+
+		err = runBatches(ctx, tx, "tenants", tenants, `
+			UNWIND $tenants AS row
+			MERGE (t:Tenant {slug: row.slug})
+			ON CREATE SET
+				t.id = row.id,
+				t.status = 'ACTIVE',
+				t.isolationMode = 'SHARED',
+				t.memberCount = 0,
+				t.createdAt = datetime()
+			SET t.name = row.name, t.plan = row.plan, t.updatedAt = datetime()
+		`, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge tenants: %w", err)
+		}
+
+		err = runBatches(ctx, tx, "memberships", memberships, `
+			UNWIND $memberships AS row
+			MATCH (u:User {email: row.email}), (t:Tenant {slug: row.slug})
+			MERGE (u)-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t)
+			ON CREATE SET
+				m.id = row.id,
+				m.status = 'ACTIVE',
+				m.joinedAt = datetime(),
+				t.memberCount = coalesce(t.memberCount, 0) + 1
+			SET m.role = row.role
+		`, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge memberships: %w", err)
+		}
+
+		return userIDs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]string), nil
+}
+
+// runBatches runs query once per seedBatchSize rows, passing each batch as
+// $key. onRecord, when set, receives every returned record.
+func runBatches(ctx context.Context, tx neo4j.ManagedTransaction, key string, rows []map[string]any, query string, onRecord func(*neo4j.Record)) error {
+	for start := 0; start < len(rows); start += seedBatchSize {
+		batch := rows[start:min(start+seedBatchSize, len(rows))]
+		result, err := tx.Run(ctx, query, map[string]any{key: batch})
+		if err != nil {
+			return err
+		}
+		for result.Next(ctx) {
+			if onRecord != nil {
+				onRecord(result.Record())
+			}
+		}
+		if err := result.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
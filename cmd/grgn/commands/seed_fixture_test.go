@@ -0,0 +1,245 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+)
+
+const testFixtureYAML = `
+users:
+  - email: dana@example.com
+    name: Dana Scully
+  - email: fox@example.com
+    name: Fox Mulder
+tenants:
+  - name: X Files
+    slug: x-files
+    plan: PRO
+    members:
+      - email: dana@example.com
+        role: OWNER
+      - email: fox@example.com
+        role: ADMIN
+  - name: Basement
+    slug: basement
+    members:
+      - email: fox@example.com
+        role: OWNER
+`
+
+// writeFixture writes content to a fixture file in a temp directory.
+func writeFixture(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+// graphDB applies the seed's batched MERGE queries to an in-memory graph,
+// keyed the way the Cypher merges: users by email, tenants by slug and
+// memberships by user and tenant.
+type graphDB struct {
+	shared.IDatabase
+	users       map[string]map[string]any
+	tenants     map[string]map[string]any
+	memberships map[string]map[string]any
+	batches     int
+}
+
+func newGraphDB() *graphDB {
+	return &graphDB{
+		users:       map[string]map[string]any{},
+		tenants:     map[string]map[string]any{},
+		memberships: map[string]map[string]any{},
+	}
+}
+
+func (g *graphDB) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return work(&graphTx{graph: g})
+}
+
+type graphTx struct {
+	neo4j.ManagedTransaction
+	graph *graphDB
+}
+
+func (tx *graphTx) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	g := tx.graph
+	g.batches++
+
+	var records []*neo4j.Record
+	merge := func(nodes map[string]map[string]any, key string, row map[string]any, keep ...string) {
+		node, ok := nodes[key]
+		if !ok {
+			node = map[string]any{}
+			nodes[key] = node
+		}
+		for k, v := range row {
+			if _, exists := node[k]; exists && slices.Contains(keep, k) {
+				continue // ON CREATE SET only
+			}
+			node[k] = v
+		}
+	}
+
+	for key, rows := range params {
+		for _, row := range rows.([]map[string]any) {
+			switch key {
+			case "users":
+				merge(g.users, row["email"].(string), row, "id")
+				u := g.users[row["email"].(string)]
+				records = append(records, &neo4j.Record{Keys: []string{"email", "id"}, Values: []any{u["email"], u["id"]}})
+			case "tenants":
+				merge(g.tenants, row["slug"].(string), row, "id")
+			case "memberships":
+				if g.users[row["email"].(string)] == nil || g.tenants[row["slug"].(string)] == nil {
+					continue // MATCH found nothing
+				}
+				merge(g.memberships, row["email"].(string)+"/"+row["slug"].(string), row, "id")
+			}
+		}
+	}
+	return &fakeResult{records: records}, nil
+}
+
+func TestSeedFixture_LoadAndApply(t *testing.T) {
+	// Arrange
+	fixture, err := loadFixture(writeFixture(t, testFixtureYAML))
+	require.NoError(t, err)
+	require.NoError(t, fixture.validate())
+	graph := newGraphDB()
+
+	// Act
+	userIDs, err := applyFixture(context.Background(), graph, fixture)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, graph.batches, "one UNWIND per entity type")
+	require.Len(t, graph.users, 2)
+	assert.Equal(t, "Dana Scully", graph.users["dana@example.com"]["name"])
+	assert.Equal(t, graph.users["dana@example.com"]["id"], userIDs["dana@example.com"])
+
+	require.Len(t, graph.tenants, 2)
+	assert.Equal(t, "PRO", graph.tenants["x-files"]["plan"])
+	assert.Equal(t, "FREE", graph.tenants["basement"]["plan"], "plan defaults to FREE")
+
+	require.Len(t, graph.memberships, 3)
+	assert.Equal(t, "OWNER", graph.memberships["dana@example.com/x-files"]["role"])
+	assert.Equal(t, "ADMIN", graph.memberships["fox@example.com/x-files"]["role"])
+	assert.Equal(t, "OWNER", graph.memberships["fox@example.com/basement"]["role"])
+}
+
+func TestSeedFixture_ReapplyIsIdempotent(t *testing.T) {
+	// Arrange
+	fixture, err := loadFixture(writeFixture(t, testFixtureYAML))
+	require.NoError(t, err)
+	graph := newGraphDB()
+	first, err := applyFixture(context.Background(), graph, fixture)
+	require.NoError(t, err)
+	tenantID := graph.tenants["x-files"]["id"]
+
+	// Act
+	second, err := applyFixture(context.Background(), graph, fixture)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "existing users keep their IDs")
+	assert.Len(t, graph.users, 2)
+	assert.Len(t, graph.tenants, 2)
+	assert.Len(t, graph.memberships, 3)
+	assert.Equal(t, tenantID, graph.tenants["x-files"]["id"])
+}
+
+func TestSeedFixture_BatchesLargeFixtures(t *testing.T) {
+	// Arrange
+	fixture := &seedFixture{}
+	for i := range seedBatchSize + 1 {
+		fixture.Users = append(fixture.Users, fixtureUser{Email: fmt.Sprintf("user%d@example.com", i)})
+	}
+	graph := newGraphDB()
+
+	// Act
+	_, err := applyFixture(context.Background(), graph, fixture)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, graph.batches, "users split across two batches, no tenant or membership rows")
+}
+
+func TestSeedFixture_ValidateRejectsTenantWithoutOwner(t *testing.T) {
+	// Arrange
+	fixture, err := loadFixture(writeFixture(t, `
+users:
+  - email: fox@example.com
+tenants:
+  - name: Basement
+    slug: basement
+    members:
+      - email: fox@example.com
+        role: ADMIN
+`))
+	require.NoError(t, err)
+
+	// Act
+	err = fixture.validate()
+
+	// Assert
+	assert.ErrorContains(t, err, "tenants[0] (basement): needs at least one OWNER")
+}
+
+func TestSeedFixture_ValidateReportsEveryProblem(t *testing.T) {
+	// Arrange
+	fixture := &seedFixture{
+		Users: []fixtureUser{
+			{Email: "fox@example.com"},
+			{Email: "FOX@example.com"},
+			{Email: "not-an-email"},
+		},
+		Tenants: []fixtureTenant{
+			{Name: "One", Slug: "dup", Members: []fixtureMember{{Email: "fox@example.com", Role: "OWNER"}}},
+			{Name: "Two", Slug: "dup", Plan: "GOLD", Members: []fixtureMember{
+				{Email: "fox@example.com", Role: "OWNER"},
+				{Email: "ghost@example.com", Role: "SUPERUSER"},
+			}},
+			{Name: "Admin", Slug: "Admin", Members: []fixtureMember{{Email: "fox@example.com", Role: "OWNER"}}},
+		},
+	}
+
+	// Act
+	err := fixture.validate()
+
+	// Assert
+	require.Error(t, err)
+	for _, want := range []string{
+		"users[1]: duplicate email FOX@example.com",
+		`users[2]: invalid email "not-an-email"`,
+		"tenants[1]: duplicate slug dup",
+		`tenants[2]: slug "Admin" is reserved`,
+		`unknown plan "GOLD"`,
+		"member ghost@example.com is not a declared user",
+		`has unknown role "SUPERUSER"`,
+	} {
+		assert.ErrorContains(t, err, want)
+	}
+}
+
+func TestSeedFixture_RejectsUnknownKeys(t *testing.T) {
+	// Act
+	_, err := loadFixture(writeFixture(t, "users:\n  - email: fox@example.com\n    nmae: Fox\n"))
+
+	// Assert
+	assert.ErrorContains(t, err, "nmae")
+}
+
+func TestSeedFixture_DefaultFixtureIsValid(t *testing.T) {
+	assert.NoError(t, defaultFixture.validate())
+}
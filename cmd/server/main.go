@@ -3,7 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,11 +13,19 @@ import (
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gin-gonic/gin"
 	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/cache"
 	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/events"
+	"github.com/yourusername/grgn-stack/pkg/logging"
+	"github.com/yourusername/grgn-stack/pkg/notify"
+	"github.com/yourusername/grgn-stack/pkg/retry"
+	identityController "github.com/yourusername/grgn-stack/services/core/identity/controller"
 	identityRepo "github.com/yourusername/grgn-stack/services/core/identity/repository"
 	identitySvc "github.com/yourusername/grgn-stack/services/core/identity/service"
 	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 	tenantRepo "github.com/yourusername/grgn-stack/services/core/tenant/repository"
 	tenantSvc "github.com/yourusername/grgn-stack/services/core/tenant/service"
 )
@@ -26,37 +34,45 @@ func main() {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		slog.Error("failed to load configuration", "error", err)
+		os.Exit(1)
 	}
 
+	// Structured logs at the configured level: text in development, JSON elsewhere
+	logger := logging.New(cfg)
+	slog.SetDefault(logger)
+
 	// Initialize Neo4j database connection
-	log.Printf("Connecting to Neo4j database at %s...", cfg.Database.Neo4jURI)
-	db, err := shared.NewNeo4jDB(cfg)
+	dbLogger := logger.With("component", "database")
+	dbLogger.Info("connecting to Neo4j", "uri", cfg.Database.Neo4jURI)
+	connectPolicy := retry.Policy{
+		MaxAttempts: 10,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Retryable:   shared.IsTransientConnectError,
+	}
+	connectPolicy.OnRetry = func(attempt int, err error, delay time.Duration) {
+		dbLogger.Warn("database not ready, retrying", "attempt", attempt, "maxAttempts", connectPolicy.MaxAttempts, "delay", delay.Round(time.Millisecond), "error", err)
+	}
+	db, err := shared.NewNeo4jDB(cfg, connectPolicy)
 	if err != nil {
-		log.Fatalf("Failed to create database connection: %v", err)
+		dbLogger.Error("failed to initialize database", "error", err)
+		os.Exit(1)
 	}
-
-	// Verify database connectivity with retry
-	var dbConnected bool
-	for i := 0; i < 10; i++ {
-		log.Printf("Verifying database connectivity (attempt %d/10)...", i+1)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		err = db.VerifyConnectivity(ctx)
-		cancel()
-
-		if err == nil {
-			dbConnected = true
-			break
-		}
-
-		log.Printf("Database not ready: %v. Retrying in 2 seconds...", err)
-		time.Sleep(2 * time.Second)
+	dbLogger.Info("connected to Neo4j")
+	if cfg.Database.Neo4jReadURI != "" {
+		dbLogger.Info("routing reads to replica", "uri", cfg.Database.Neo4jReadURI)
 	}
 
-	if !dbConnected {
-		log.Fatalf("Failed to connect to Neo4j after 10 attempts: %v", err)
+	// Warn if critical uniqueness constraints are missing (e.g., unmigrated restore)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	missing, err := shared.CheckConstraints(ctx, db, shared.RequiredConstraints)
+	cancel()
+	if err != nil {
+		dbLogger.Warn("could not verify database constraints", "error", err)
+	} else if len(missing) > 0 {
+		dbLogger.Warn("missing database constraints; run 'grgn migrate up'", "constraints", missing)
 	}
-	log.Println("Successfully connected to Neo4j")
 
 	// Set up graceful shutdown
 	shutdownChan := make(chan os.Signal, 1)
@@ -64,16 +80,16 @@ func main() {
 
 	go func() {
 		<-shutdownChan
-		log.Println("Shutting down gracefully...")
+		logger.Info("shutting down gracefully")
 
 		// Close database connection
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
 		if err := db.Close(ctx); err != nil {
-			log.Printf("Error closing database: %v", err)
+			dbLogger.Error("failed to close database", "error", err)
 		} else {
-			log.Println("Database connection closed")
+			dbLogger.Info("database connection closed")
 		}
 
 		os.Exit(0)
@@ -81,12 +97,57 @@ func main() {
 
 	// Initialize repositories
 	userRepo := identityRepo.NewUserRepository(db)
-	tenantRepository := tenantRepo.NewTenantRepository(db)
+	var tenantRepository tenantRepo.ITenantRepository = tenantRepo.NewTenantRepository(db)
+	if cfg.Cache.Enabled {
+		tenantRepository = tenantRepo.NewCachedTenantRepository(
+			tenantRepository,
+			cache.NewLRU[string, model.Tenant](cfg.Cache.MaxEntries, cfg.Cache.TTL),
+			cache.NewLRU[string, string](cfg.Cache.MaxEntries, cfg.Cache.TTL),
+		)
+		logger.Info("tenant cache enabled", "component", "cache", "ttl", cfg.Cache.TTL, "maxEntries", cfg.Cache.MaxEntries)
+	}
 	membershipRepo := tenantRepo.NewMembershipRepository(db)
 
+	// Dispatch domain events written to the outbox by repository mutations
+	eventBroker := events.NewBroker(64)
+	outboxDispatcher := shared.NewOutboxDispatcher(shared.NewOutboxRepository(db), eventBroker, cfg.Outbox.BatchSize, logger.With("component", "outbox"))
+	dispatchCtx, stopDispatcher := context.WithCancel(context.Background())
+	defer stopDispatcher()
+	go outboxDispatcher.Run(dispatchCtx, cfg.Outbox.PollInterval)
+
+	// Signed tokens; the user repository supplies token epochs for revocation
+	// and memberships the tenant role claims
+	tokenManager := auth.NewTokenManager(cfg.Auth.JWTSecret, cfg.Auth.TokenTTL, userRepo).
+		WithPreviousSecrets(cfg.Auth.PreviousJWTSecrets...).
+		WithTenantRoles(membershipRepo)
+
+	// Refresh tokens mint new access tokens; expired ones are cleaned up in the background
+	refreshTokenRepo := identityRepo.NewRefreshTokenRepository(db)
+	tokenService := identitySvc.NewTokenService(userRepo, refreshTokenRepo, tokenManager, cfg.Auth.RefreshTokenTTL)
+	cleanupCtx, stopCleanup := context.WithCancel(context.Background())
+	defer stopCleanup()
+	go tokenService.RunCleanup(cleanupCtx, cfg.Auth.RefreshTokenCleanupInterval)
+
 	// Initialize services
-	userService := identitySvc.NewUserService(userRepo)
-	tenantService := tenantSvc.NewTenantService(tenantRepository, membershipRepo, userRepo)
+	userService := identitySvc.NewUserService(userRepo).WithRefreshTokens(refreshTokenRepo)
+	tenantService := tenantSvc.NewTenantService(tenantRepository, membershipRepo, userRepo, shared.NewNeo4jUnitOfWork(db)).
+		WithMaxTraversalDepth(cfg.Database.MaxTraversalDepth).
+		WithMaxPendingInvites(cfg.Tenant.MaxPendingInvites).
+		WithInviteTokens(tokenManager, cfg.Auth.InviteTokenTTL).
+		WithDenialRecorder(tenantSvc.MetricsDenialRecorder{})
+	switch {
+	case cfg.SMTP.Host != "":
+		notifier, err := notify.NewSMTPNotifier(cfg.SMTP, cfg.App.FrontendURL)
+		if err != nil {
+			logger.Error("failed to configure SMTP notifier", "component", "notify", "error", err)
+			os.Exit(1)
+		}
+		tenantService.WithNotifier(notifier)
+		logger.Info("email notifications enabled", "component", "notify", "host", cfg.SMTP.Host, "port", cfg.SMTP.Port)
+	case !cfg.IsProduction():
+		// No mailer configured; log invite links so email invites can be accepted locally
+		tenantService.WithNotifier(notify.Log{Logger: logger.With("component", "notify")})
+	}
 
 	// Set Gin mode based on environment
 	if cfg.IsProduction() {
@@ -97,34 +158,131 @@ func main() {
 		gin.SetMode(gin.DebugMode)
 	}
 
-	r := gin.Default()
+	// Structured access logs replace gin's default text logger
+	requestLogLevel, err := logging.ParseLevel(cfg.Server.RequestLogLevel)
+	if err != nil {
+		logger.Warn("invalid request log level, using info", "requestLogLevel", cfg.Server.RequestLogLevel)
+	}
 
-	// Dev-only: X-User-ID header middleware for testing
-	// This allows testing without authentication by passing the user ID in a header
-	if !cfg.IsProduction() {
-		r.Use(func(c *gin.Context) {
-			if userID := c.GetHeader("X-User-ID"); userID != "" {
-				ctx := auth.WithUserID(c.Request.Context(), userID)
-				c.Request = c.Request.WithContext(ctx)
-			}
-			c.Next()
-		})
-		log.Println("Dev mode: X-User-ID header authentication enabled")
+	r := gin.New()
+	r.Use(shared.RequestIDs())
+	r.Use(shared.RequestLogger(logger.With("component", "http"), shared.RequestLoggerOptions{
+		Level:     requestLogLevel,
+		SkipPaths: cfg.Server.RequestLogSkipPaths,
+	}))
+	r.Use(gin.Recovery())
+	// Prometheus metrics are opt-in; /metrics is unauthenticated, so keep it off public listeners
+	if cfg.Server.MetricsEnabled {
+		r.Use(shared.HTTPMetrics())
+		r.GET("/metrics", shared.HandleMetrics)
+		logger.Info("metrics enabled at /metrics", "component", "metrics")
+	}
+	r.Use(shared.RequestBookmarks())
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(func(c *gin.Context) {
+		shared.RespondError(c, errors.NewCodedError(errors.CodeNotFound, "route not found", nil))
+	})
+	r.NoMethod(func(c *gin.Context) {
+		shared.RespondError(c, errors.NewCodedError(errors.CodeMethodNotAllowed, "method not allowed", nil))
+	})
+
+	// X-User-ID header auth is opt-in for local development and never honored in production
+	if cfg.HeaderUserIDAllowed() {
+		r.Use(shared.HeaderUserAuth(cfg))
+		logger.Warn("X-User-ID header authentication enabled; any client can act as any user", "component", "auth")
+	} else if cfg.Auth.AllowHeaderUserID {
+		logger.Warn("auth.allow_header_user_id is ignored in production", "component", "auth")
 	}
 
 	// Create ping handler and register route
 	pingHandler := shared.NewPingHandler(db, cfg)
 	r.GET("/ping", pingHandler.HandlePing)
+	// Orchestrator probes: liveness never touches the database, readiness does
+	r.GET("/healthz", pingHandler.Liveness)
+	r.GET("/readyz", pingHandler.Readiness)
+
+	// Build info endpoint
+	versionHandler := shared.NewVersionHandler(cfg)
+	r.GET("/version", versionHandler.HandleVersion)
 
 	// GraphQL setup with dependency injection
 	gqlResolver := &graphql.Resolver{
 		UserService:   userService,
 		TenantService: tenantService,
 	}
-	gqlServer := handler.NewDefaultServer(graphql.NewExecutableSchema(graphql.Config{Resolvers: gqlResolver}))
+	gqlConfig := graphql.Config{Resolvers: gqlResolver}
+	// @trace times tagged resolvers in development and is a no-op elsewhere
+	gqlConfig.Directives.Trace = shared.TraceDirective(cfg.IsDevelopment(), logger.With("component", "graphql"), nil)
+	gqlServer := handler.NewDefaultServer(graphql.NewExecutableSchema(gqlConfig))
+	// Errors carry a code in extensions.code; production hides internal details
+	gqlServer.SetErrorPresenter(shared.NewErrorPresenter(cfg.IsProduction()))
+	gqlServer.SetRecoverFunc(shared.RecoverPanic)
+	// Queries should read and mutations write; mismatches are logged in development
+	gqlServer.Use(shared.TxModeHints{})
+	if cfg.Server.MetricsEnabled {
+		gqlServer.Use(shared.GraphQLMetrics{})
+	}
+	gqlServer.Use(shared.NewQueryLogger(logger.With("component", "graphql"), shared.QueryLoggerOptions{
+		LogAll:        cfg.GraphQL.LogAllOperations,
+		ScrubFields:   cfg.GraphQL.ScrubFields,
+		VisibleFields: cfg.GraphQL.VisibleFields,
+	}))
+
+	// Maintenance mode rejects mutations while keeping queries and /ping up
+	maintenance := shared.NewMaintenanceMode(cfg.Maintenance.Enabled, cfg.Maintenance.RetryAfter)
+	if maintenance.Enabled() {
+		logger.Warn("maintenance mode enabled; GraphQL mutations are rejected")
+	}
+
+	// Google and Apple sign-in issue the bearer tokens the API routes below require
+	if cfg.Auth.GoogleClientID != "" {
+		googleOAuth, err := identityController.NewGoogleOAuthHandler(cfg, userService, tokenManager, userRepo)
+		if err != nil {
+			logger.Error("failed to configure Google sign-in", "component", "auth", "error", err)
+			os.Exit(1)
+		}
+		googleOAuth.WithRefreshTokens(tokenService)
+		r.GET("/auth/google/login", googleOAuth.HandleLogin)
+		r.GET("/auth/google/callback", googleOAuth.HandleCallback)
+	}
+	if cfg.Auth.AppleClientID != "" {
+		appleOAuth, err := identityController.NewAppleOAuthHandler(cfg, userService, tokenManager, userRepo)
+		if err != nil {
+			logger.Error("failed to configure Apple sign-in", "component", "auth", "error", err)
+			os.Exit(1)
+		}
+		appleOAuth.WithRefreshTokens(tokenService)
+		r.GET("/auth/apple/login", appleOAuth.HandleLogin)
+		r.POST("/auth/apple/callback", appleOAuth.HandleCallback)
+	}
+
+	// Browsers on the allowed origins may call the routes the frontend uses,
+	// with credentials. OAuth callbacks are left out: Apple posts its
+	// callback from its own origin.
+	cors := shared.CORS(cfg.Server.AllowedOrigins)
+	for _, path := range []string{"/auth/refresh", "/auth/logout", "/tenants/:id/members.csv", "/graphql"} {
+		r.OPTIONS(path, cors)
+	}
+
+	// Refresh tokens are their own credential, so these routes take no bearer token
+	tokenHandler := identityController.NewTokenHandler(tokenService)
+	r.POST("/auth/refresh", cors, tokenHandler.HandleRefresh)
+	r.POST("/auth/logout", cors, tokenHandler.HandleLogout)
+
+	// API routes require a bearer token; /ping and /version stay public
+	requireAuth := shared.BearerAuth(tokenManager, cfg)
+
+	// Maintenance toggling is for platform admins only
+	requireAdmin := shared.RequirePlatformAdmin()
+	r.GET("/admin/maintenance", requireAuth, requireAdmin, maintenance.HandleStatus)
+	r.PUT("/admin/maintenance", requireAuth, requireAdmin, maintenance.HandleToggle)
+
+	// Member CSV export, streamed from the database cursor
+	memberExport := shared.NewMemberExportHandler(tenantService)
+	r.GET("/tenants/:id/members.csv", cors, requireAuth, memberExport.HandleExport)
 
 	// GraphQL endpoints
-	r.POST("/graphql", func(c *gin.Context) {
+	r.POST("/graphql", cors, requireAuth, maintenance.Middleware(), identityController.RequestUserLoader(userRepo), func(c *gin.Context) {
 		gqlServer.ServeHTTP(c.Writer, c.Request)
 	})
 
@@ -133,21 +291,25 @@ func main() {
 		r.GET("/graphql", func(c *gin.Context) {
 			playground.Handler("GRGN Stack GraphQL Playground", "/graphql").ServeHTTP(c.Writer, c.Request)
 		})
-		log.Printf("GraphQL Playground available at http://%s:%s/graphql", cfg.Server.Host, cfg.Server.Port)
 	}
 
 	// Start server
 	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	log.Printf("Starting %s server...", cfg.App.Name)
-	log.Printf("Environment: %s", cfg.Server.Environment)
-	log.Printf("Version: %s", cfg.App.Version)
-	log.Printf("Listening on: http://%s", addr)
-	log.Printf("GraphQL endpoint: http://%s/graphql", addr)
-	if !cfg.IsProduction() {
-		log.Printf("GraphQL Playground: http://%s/graphql", addr)
-	}
+	info := versionHandler.Info()
+	logger.Info("starting server",
+		"app", cfg.App.Name,
+		"environment", cfg.Server.Environment,
+		"version", info.Version,
+		"commit", info.Commit,
+		"buildTime", info.BuildTime,
+		"goVersion", info.GoVersion,
+		"addr", addr,
+		"graphql", fmt.Sprintf("http://%s/graphql", addr),
+		"playground", !cfg.IsProduction(),
+	)
 
 	if err := r.Run(addr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		logger.Error("failed to start server", "error", err)
+		os.Exit(1)
 	}
 }
@@ -1,5 +1,5 @@
-// Package auth provides authentication context helpers.
-// This is a stub that will be replaced when core/auth is implemented.
+// Package auth provides authentication context helpers and the signed
+// access and invite tokens that populate them.
 package auth
 
 import (
@@ -13,6 +13,12 @@ type contextKey string
 // UserIDKey is the context key for storing user ID
 const UserIDKey contextKey = "userID"
 
+// PlatformAdminKey is the context key marking a platform administrator
+const PlatformAdminKey contextKey = "platformAdmin"
+
+// ClaimsKey is the context key for storing verified token claims
+const ClaimsKey contextKey = "claims"
+
 // GetUserID extracts the user ID from context.
 // Returns ErrNotAuthenticated if no user ID is present.
 func GetUserID(ctx context.Context) (string, error) {
@@ -28,6 +34,27 @@ func WithUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, UserIDKey, userID)
 }
 
+// WithClaims adds verified token claims to context, along with their
+// subject as the user ID so GetUserID keeps working.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	ctx = context.WithValue(ctx, ClaimsKey, claims)
+	return WithUserID(ctx, claims.Subject)
+}
+
+// GetClaims extracts the token claims from context.
+// Returns false if the request was authenticated without a token.
+func GetClaims(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(ClaimsKey).(*Claims)
+	if !ok || claims == nil {
+		return nil, false
+	}
+	// A later WithUserID may have switched users; its claims don't apply
+	if id, _ := ctx.Value(UserIDKey).(string); id != claims.Subject {
+		return nil, false
+	}
+	return claims, true
+}
+
 // MustGetUserID extracts the user ID from context or panics.
 // Use only when you're certain the user is authenticated.
 func MustGetUserID(ctx context.Context) string {
@@ -37,3 +64,15 @@ func MustGetUserID(ctx context.Context) string {
 	}
 	return id
 }
+
+// WithPlatformAdmin marks the context as belonging to a platform administrator.
+// Platform admins may perform cross-tenant operations such as billing sync.
+func WithPlatformAdmin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, PlatformAdminKey, true)
+}
+
+// IsPlatformAdmin reports whether the context belongs to a platform administrator.
+func IsPlatformAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(PlatformAdminKey).(bool)
+	return admin
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithClaims(t *testing.T) {
+	// Arrange
+	claims := &Claims{
+		Roles:            map[string]string{"tenant-1": "ADMIN"},
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+	}
+
+	// Act
+	ctx := WithClaims(context.Background(), claims)
+	got, ok := GetClaims(ctx)
+	userID, err := GetUserID(ctx)
+
+	// Assert
+	require.True(t, ok)
+	assert.Same(t, claims, got)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID, "GetUserID reads the claims' subject")
+}
+
+func TestGetClaims_BareUserID(t *testing.T) {
+	testCases := []struct {
+		desc string
+		ctx  context.Context
+	}{
+		{"no claims", WithUserID(context.Background(), "user-1")},
+		{"claims for another user", WithUserID(WithClaims(context.Background(), &Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+		}), "user-2")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Act
+			claims, ok := GetClaims(tc.ctx)
+
+			// Assert
+			assert.False(t, ok)
+			assert.Nil(t, claims)
+		})
+	}
+}
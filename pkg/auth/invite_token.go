@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// InviteClaims are the JWT claims carried by invite links.
+// The membership ID is stored in the standard "sub" claim.
+type InviteClaims struct {
+	// Email is the invitee's email address at issue time.
+	Email string `json:"email"`
+
+	jwt.RegisteredClaims
+}
+
+// IssueInviteToken signs an invite token for a membership that expires after ttl.
+func (m *TokenManager) IssueInviteToken(membershipID, email string, ttl time.Duration) (string, error) {
+	now := m.now()
+	return m.sign(InviteClaims{
+		Email: email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   membershipID,
+			Audience:  jwt.ClaimStrings{inviteAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	})
+}
+
+// ParseInviteToken verifies an invite token's signature and expiry.
+// Returns ErrInvalidToken if the token is malformed, forged or expired.
+func (m *TokenManager) ParseInviteToken(tokenString string) (*InviteClaims, error) {
+	claims := &InviteClaims{}
+	if err := m.parse(tokenString, claims, inviteAudience); err != nil || claims.Subject == "" || claims.Email == "" {
+		return nil, errors.ErrInvalidToken
+	}
+	return claims, nil
+}
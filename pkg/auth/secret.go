@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// MinSecretBytes is the shortest signing secret GenerateSecret creates;
+// HS256 keys shorter than its 256-bit output weaken the signature.
+const MinSecretBytes = 32
+
+// GenerateSecret returns a random signing secret of n bytes, encoded as
+// unpadded base64url so it can be pasted into an env file as is.
+func GenerateSecret(n int) (string, error) {
+	if n < MinSecretBytes {
+		return "", fmt.Errorf("secret must be at least %d bytes, got %d", MinSecretBytes, n)
+	}
+
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate secret: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// Token audiences keep access and invite tokens from being used for each other.
+const (
+	accessAudience = "access"
+	inviteAudience = "invite"
+)
+
+// Claims are the JWT claims carried by access tokens.
+// The user ID is stored in the standard "sub" claim.
+type Claims struct {
+	// TokenEpoch is the user's token epoch at issue time. Tokens whose epoch
+	// is below the user's current epoch have been revoked.
+	TokenEpoch int `json:"tokenEpoch"`
+
+	// Roles maps tenant IDs to the user's role there when the token was
+	// issued, so authorization checks can skip the membership lookup.
+	// Roles only ever grant access: losing one must bump the user's role
+	// epoch, and a role gained since issue is found by the lookup.
+	Roles map[string]string `json:"roles,omitempty"`
+
+	// RoleEpoch is the user's role epoch at issue time. Roles issued below
+	// the user's current role epoch are dropped by ParseToken; the token
+	// itself stays valid.
+	RoleEpoch int `json:"roleEpoch,omitempty"`
+
+	jwt.RegisteredClaims
+}
+
+// TokenEpochSource looks up a user's current token epoch.
+type TokenEpochSource interface {
+	GetTokenEpoch(ctx context.Context, userID string) (int, error)
+}
+
+// RoleEpochSource is implemented by a TokenEpochSource that also tracks
+// role epochs. Bumping a user's role epoch withdraws the role claims of
+// their tokens without signing them out, so losing a role in one tenant
+// doesn't end their sessions in the others.
+type RoleEpochSource interface {
+	GetRoleEpoch(ctx context.Context, userID string) (int, error)
+}
+
+// TenantRoleSource looks up a user's active roles, keyed by tenant ID.
+type TenantRoleSource interface {
+	RolesByUserID(ctx context.Context, userID string) (map[string]string, error)
+}
+
+// TokenManager issues and parses HMAC-signed access tokens.
+type TokenManager struct {
+	secret   []byte
+	previous [][]byte
+	ttl      time.Duration
+	epochs   TokenEpochSource
+	roles    TenantRoleSource
+	now      func() time.Time
+}
+
+// NewTokenManager creates a new TokenManager.
+// When epochs is nil, ParseToken skips the revocation check.
+func NewTokenManager(secret string, ttl time.Duration, epochs TokenEpochSource) *TokenManager {
+	return &TokenManager{
+		secret: []byte(secret),
+		ttl:    ttl,
+		epochs: epochs,
+		now:    time.Now,
+	}
+}
+
+// WithPreviousSecrets makes tokens signed with any of secrets still verify,
+// so the signing secret can be rotated without logging everyone out: sign
+// with the new secret, list the old one here until the tokens it signed have
+// expired, then drop it. New tokens are always signed with the current
+// secret. Empty secrets are ignored.
+func (m *TokenManager) WithPreviousSecrets(secrets ...string) *TokenManager {
+	for _, secret := range secrets {
+		if secret != "" {
+			m.previous = append(m.previous, []byte(secret))
+		}
+	}
+	return m
+}
+
+// WithTenantRoles makes IssueSessionToken put the user's tenant roles from
+// roles in the tokens it signs.
+func (m *TokenManager) WithTenantRoles(roles TenantRoleSource) *TokenManager {
+	m.roles = roles
+	return m
+}
+
+// IssueToken signs a token for the user at the given token epoch.
+func (m *TokenManager) IssueToken(userID string, tokenEpoch int) (string, error) {
+	return m.IssueTokenWithRoles(userID, tokenEpoch, nil)
+}
+
+// IssueTokenWithRoles signs a token like IssueToken that also carries the
+// user's role in each tenant, keyed by tenant ID.
+func (m *TokenManager) IssueTokenWithRoles(userID string, tokenEpoch int, roles map[string]string) (string, error) {
+	return m.issue(userID, tokenEpoch, 0, roles)
+}
+
+// IssueSessionToken signs a token for a user signing in or refreshing:
+// like IssueToken, carrying the user's current tenant roles when a
+// TenantRoleSource is set.
+func (m *TokenManager) IssueSessionToken(ctx context.Context, userID string, tokenEpoch int) (string, error) {
+	if m.roles == nil {
+		return m.IssueToken(userID, tokenEpoch)
+	}
+
+	// Read the role epoch before the roles: a role lost in between bumps
+	// the epoch past the token's, so the stale roles are dropped
+	roleEpoch := 0
+	if source, ok := m.epochs.(RoleEpochSource); ok {
+		var err error
+		if roleEpoch, err = source.GetRoleEpoch(ctx, userID); err != nil {
+			return "", err
+		}
+	}
+	roles, err := m.roles.RolesByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return m.issue(userID, tokenEpoch, roleEpoch, roles)
+}
+
+// issue signs an access token with the given claims.
+func (m *TokenManager) issue(userID string, tokenEpoch, roleEpoch int, roles map[string]string) (string, error) {
+	now := m.now()
+	claims := Claims{
+		TokenEpoch: tokenEpoch,
+		Roles:      roles,
+		RoleEpoch:  roleEpoch,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			Audience:  jwt.ClaimStrings{accessAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.ttl)),
+		},
+	}
+
+	return m.sign(claims)
+}
+
+// ParseToken verifies the token's signature and expiry and rejects tokens
+// issued before the user's tokens were last revoked. Role claims issued
+// before the user last lost a role are dropped.
+// Returns ErrInvalidToken or ErrTokenRevoked.
+func (m *TokenManager) ParseToken(ctx context.Context, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	if err := m.parse(tokenString, claims, accessAudience); err != nil || claims.Subject == "" {
+		return nil, errors.ErrInvalidToken
+	}
+
+	if m.epochs != nil {
+		current, err := m.epochs.GetTokenEpoch(ctx, claims.Subject)
+		if errors.Is(err, errors.ErrUserNotFound) {
+			return nil, errors.ErrInvalidToken
+		}
+		if err != nil {
+			return nil, err
+		}
+		if claims.TokenEpoch < current {
+			return nil, errors.ErrTokenRevoked
+		}
+	}
+
+	if source, ok := m.epochs.(RoleEpochSource); ok && len(claims.Roles) > 0 {
+		current, err := source.GetRoleEpoch(ctx, claims.Subject)
+		if err != nil {
+			return nil, err
+		}
+		if claims.RoleEpoch < current {
+			claims.Roles = nil
+		}
+	}
+
+	return claims, nil
+}
+
+// sign signs claims with the manager's current secret.
+func (m *TokenManager) sign(claims jwt.Claims) (string, error) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", fmt.Errorf("sign token: %w", err)
+	}
+	return token, nil
+}
+
+// verificationKeys is the jwt.Keyfunc for parse: the current secret, then
+// any previous ones.
+func (m *TokenManager) verificationKeys(*jwt.Token) (any, error) {
+	if len(m.previous) == 0 {
+		return m.secret, nil
+	}
+
+	keys := jwt.VerificationKeySet{Keys: []jwt.VerificationKey{m.secret}}
+	for _, secret := range m.previous {
+		keys.Keys = append(keys.Keys, secret)
+	}
+	return keys, nil
+}
+
+// parse verifies the signature, expiry and audience of a token into claims.
+func (m *TokenManager) parse(tokenString string, claims jwt.Claims, audience string) error {
+	_, err := jwt.ParseWithClaims(tokenString, claims, m.verificationKeys,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.now),
+	)
+	return err
+}
@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// epochMap is an in-memory TokenEpochSource.
+type epochMap map[string]int
+
+func (e epochMap) GetTokenEpoch(ctx context.Context, userID string) (int, error) {
+	epoch, ok := e[userID]
+	if !ok {
+		return 0, errors.ErrUserNotFound
+	}
+	return epoch, nil
+}
+
+func TestTokenManager_IssueAndParse(t *testing.T) {
+	// Arrange
+	m := NewTokenManager("secret", time.Hour, epochMap{"user-1": 0})
+
+	// Act
+	token, err := m.IssueToken("user-1", 0)
+	require.NoError(t, err)
+	claims, err := m.ParseToken(context.Background(), token)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, 0, claims.TokenEpoch)
+}
+
+func TestTokenManager_IssueTokenWithRoles(t *testing.T) {
+	// Arrange
+	m := NewTokenManager("secret", time.Hour, epochMap{"user-1": 0})
+	roles := map[string]string{"tenant-1": "OWNER", "tenant-2": "VIEWER"}
+
+	// Act
+	token, err := m.IssueTokenWithRoles("user-1", 0, roles)
+	require.NoError(t, err)
+	claims, err := m.ParseToken(context.Background(), token)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, roles, claims.Roles)
+}
+
+// roleEpochs is an in-memory RoleEpochSource, and a TenantRoleSource
+// handing out the same roles to every user.
+type roleEpochs struct {
+	epochMap
+	roleEpochs map[string]int
+	roles      map[string]string
+}
+
+func (e roleEpochs) GetRoleEpoch(ctx context.Context, userID string) (int, error) {
+	return e.roleEpochs[userID], nil
+}
+
+func (e roleEpochs) RolesByUserID(ctx context.Context, userID string) (map[string]string, error) {
+	return e.roles, nil
+}
+
+func TestTokenManager_IssueSessionToken_CarriesTenantRoles(t *testing.T) {
+	// Arrange
+	source := roleEpochs{
+		epochMap:   epochMap{"user-1": 0},
+		roleEpochs: map[string]int{"user-1": 2},
+		roles:      map[string]string{"tenant-1": "OWNER"},
+	}
+	m := NewTokenManager("secret", time.Hour, source).WithTenantRoles(source)
+
+	// Act
+	token, err := m.IssueSessionToken(context.Background(), "user-1", 0)
+	require.NoError(t, err)
+	claims, err := m.ParseToken(context.Background(), token)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tenant-1": "OWNER"}, claims.Roles)
+	assert.Equal(t, 2, claims.RoleEpoch)
+}
+
+func TestTokenManager_ParseToken_DropsRolesBelowRoleEpoch(t *testing.T) {
+	// Arrange
+	source := roleEpochs{
+		epochMap:   epochMap{"user-1": 0},
+		roleEpochs: map[string]int{"user-1": 0},
+		roles:      map[string]string{"tenant-1": "OWNER", "tenant-2": "ADMIN"},
+	}
+	m := NewTokenManager("secret", time.Hour, source).WithTenantRoles(source)
+	token, err := m.IssueSessionToken(context.Background(), "user-1", 0)
+	require.NoError(t, err)
+
+	// Act - the user loses a role after the token was issued
+	source.roleEpochs["user-1"] = 1
+	claims, err := m.ParseToken(context.Background(), token)
+
+	// Assert - the session survives, only the role claims go
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Nil(t, claims.Roles)
+}
+
+func TestTokenManager_ParseToken_RejectsOlderEpoch(t *testing.T) {
+	// Arrange
+	epochs := epochMap{"user-1": 0}
+	m := NewTokenManager("secret", time.Hour, epochs)
+	oldToken, err := m.IssueToken("user-1", 0)
+	require.NoError(t, err)
+
+	// Act - bump the epoch, then issue a fresh token
+	epochs["user-1"] = 1
+	newToken, err := m.IssueToken("user-1", 1)
+	require.NoError(t, err)
+	_, oldErr := m.ParseToken(context.Background(), oldToken)
+	_, newErr := m.ParseToken(context.Background(), newToken)
+
+	// Assert
+	assert.ErrorIs(t, oldErr, errors.ErrTokenRevoked)
+	assert.NoError(t, newErr)
+}
+
+func TestTokenManager_ParseToken_Invalid(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	m := NewTokenManager("secret", time.Hour, epochMap{"user-1": 0})
+	m.now = func() time.Time { return now }
+	other := NewTokenManager("other-secret", time.Hour, nil)
+
+	expired, err := m.IssueToken("user-1", 0)
+	require.NoError(t, err)
+	forged, err := other.IssueToken("user-1", 0)
+	require.NoError(t, err)
+	unknown, err := m.IssueToken("user-2", 0)
+	require.NoError(t, err)
+
+	// Act
+	now = now.Add(2 * time.Hour)
+	_, expiredErr := m.ParseToken(context.Background(), expired)
+	now = now.Add(-2 * time.Hour)
+	_, forgedErr := m.ParseToken(context.Background(), forged)
+	_, unknownErr := m.ParseToken(context.Background(), unknown)
+	_, garbageErr := m.ParseToken(context.Background(), "not-a-token")
+
+	// Assert
+	assert.ErrorIs(t, expiredErr, errors.ErrInvalidToken)
+	assert.ErrorIs(t, forgedErr, errors.ErrInvalidToken)
+	assert.ErrorIs(t, unknownErr, errors.ErrInvalidToken)
+	assert.ErrorIs(t, garbageErr, errors.ErrInvalidToken)
+}
+
+func TestTokenManager_PreviousSecrets(t *testing.T) {
+	// Arrange - tokens issued before and after rotating "old" to "new"
+	epochs := epochMap{"user-1": 0}
+	before := NewTokenManager("old", time.Hour, epochs)
+	rotated := NewTokenManager("new", time.Hour, epochs).WithPreviousSecrets("", "old")
+	retired := NewTokenManager("new", time.Hour, epochs)
+
+	oldToken, err := before.IssueToken("user-1", 0)
+	require.NoError(t, err)
+	oldInvite, err := before.IssueInviteToken("membership-1", "alice@example.com", time.Hour)
+	require.NoError(t, err)
+	newToken, err := rotated.IssueToken("user-1", 0)
+	require.NoError(t, err)
+
+	// Act
+	_, oldErr := rotated.ParseToken(context.Background(), oldToken)
+	_, oldInviteErr := rotated.ParseInviteToken(oldInvite)
+	_, newErr := retired.ParseToken(context.Background(), newToken)
+	_, signedWithOldErr := before.ParseToken(context.Background(), newToken)
+	_, removedErr := retired.ParseToken(context.Background(), oldToken)
+	_, removedInviteErr := retired.ParseInviteToken(oldInvite)
+
+	// Assert
+	assert.NoError(t, oldErr, "a listed previous secret still verifies")
+	assert.NoError(t, oldInviteErr)
+	assert.NoError(t, newErr, "new tokens are signed with the current secret")
+	assert.ErrorIs(t, signedWithOldErr, errors.ErrInvalidToken)
+	assert.ErrorIs(t, removedErr, errors.ErrInvalidToken, "a removed secret no longer verifies")
+	assert.ErrorIs(t, removedInviteErr, errors.ErrInvalidToken)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	// Act
+	first, err := GenerateSecret(MinSecretBytes)
+	require.NoError(t, err)
+	second, err := GenerateSecret(MinSecretBytes)
+	require.NoError(t, err)
+	_, shortErr := GenerateSecret(MinSecretBytes - 1)
+
+	// Assert
+	assert.Len(t, first, 43, "32 bytes in unpadded base64url")
+	assert.NotEqual(t, first, second)
+	assert.Error(t, shortErr)
+}
+
+func TestTokenManager_InviteToken(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	m := NewTokenManager("secret", time.Hour, epochMap{"user-1": 0})
+	m.now = func() time.Time { return now }
+
+	invite, err := m.IssueInviteToken("membership-1", "alice@example.com", time.Hour)
+	require.NoError(t, err)
+	access, err := m.IssueToken("user-1", 0)
+	require.NoError(t, err)
+
+	// Act
+	claims, inviteErr := m.ParseInviteToken(invite)
+	_, crossAccessErr := m.ParseToken(context.Background(), invite)
+	_, crossInviteErr := m.ParseInviteToken(access)
+	now = now.Add(2 * time.Hour)
+	_, expiredErr := m.ParseInviteToken(invite)
+
+	// Assert
+	require.NoError(t, inviteErr)
+	assert.Equal(t, "membership-1", claims.Subject)
+	assert.Equal(t, "alice@example.com", claims.Email)
+	assert.ErrorIs(t, crossAccessErr, errors.ErrInvalidToken)
+	assert.ErrorIs(t, crossInviteErr, errors.ErrInvalidToken)
+	assert.ErrorIs(t, expiredErr, errors.ErrInvalidToken)
+}
@@ -0,0 +1,25 @@
+// Package buildinfo exposes build metadata injected at link time.
+//
+// Set the values with ldflags, for example:
+//
+//	go build -ldflags "-X github.com/yourusername/grgn-stack/pkg/buildinfo.Version=1.2.3 \
+//	  -X github.com/yourusername/grgn-stack/pkg/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/yourusername/grgn-stack/pkg/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/server
+package buildinfo
+
+import "runtime"
+
+// Unknown is reported for values that were not injected at build time.
+const Unknown = "unknown"
+
+// Build-time values, overridden via -ldflags "-X ...".
+var (
+	Version   = ""
+	Commit    = Unknown
+	BuildTime = Unknown
+)
+
+// GoVersion returns the Go toolchain version the binary was built with.
+func GoVersion() string {
+	return runtime.Version()
+}
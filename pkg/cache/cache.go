@@ -0,0 +1,137 @@
+// Package cache provides a small generic caching abstraction.
+// The Cache interface allows the in-memory LRU to be swapped for a
+// distributed implementation (e.g., Redis) without changing callers.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache defines the contract for a key/value cache.
+type Cache[K comparable, V any] interface {
+	// Get returns the cached value and true, or the zero value and false on a miss.
+	Get(key K) (V, bool)
+
+	// Set stores a value, evicting older entries if the cache is full.
+	Set(key K, value V)
+
+	// Delete removes a single entry. Deleting a missing key is a no-op.
+	Delete(key K)
+
+	// Purge removes all entries.
+	Purge()
+}
+
+// entry is a single LRU element.
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// LRU is a thread-safe in-memory cache with a fixed capacity and per-entry TTL.
+// The least recently used entry is evicted when capacity is exceeded.
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	ll       *list.List
+	items    map[K]*list.Element
+
+	// now is overridable for testing TTL expiry
+	now func() time.Time
+}
+
+// NewLRU creates a new LRU cache.
+// A capacity <= 0 means unbounded; a ttl <= 0 means entries never expire.
+func NewLRU[K comparable, V any](capacity int, ttl time.Duration) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    make(map[K]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get returns the cached value for key if present and not expired.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	el, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+
+	e := el.Value.(*entry[K, V])
+	if c.ttl > 0 && !c.now().Before(e.expiresAt) {
+		c.removeElement(el)
+		return zero, false
+	}
+
+	c.ll.MoveToFront(el)
+	return e.value, true
+}
+
+// Set stores value under key, refreshing its TTL and recency.
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[K, V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	c.items[key] = el
+
+	if c.capacity > 0 && c.ll.Len() > c.capacity {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+// Delete removes key from the cache.
+func (c *LRU[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+// Purge removes all entries from the cache.
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[K]*list.Element)
+}
+
+// Len returns the number of entries currently held (including expired ones
+// that have not yet been evicted).
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// removeElement unlinks an element from both the list and the index.
+func (c *LRU[K, V]) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*entry[K, V]).key)
+}
+
+// Ensure LRU implements Cache
+var _ Cache[string, any] = (*LRU[string, any])(nil)
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_GetSet(t *testing.T) {
+	// Arrange
+	c := NewLRU[string, int](10, time.Minute)
+
+	// Act
+	c.Set("a", 1)
+	value, ok := c.Get("a")
+	_, missOk := c.Get("missing")
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.False(t, missOk)
+}
+
+func TestLRU_TTLExpiry(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	c := NewLRU[string, int](10, time.Minute)
+	c.now = func() time.Time { return now }
+	c.Set("a", 1)
+
+	// Act - advance past the TTL
+	now = now.Add(2 * time.Minute)
+	_, ok := c.Get("a")
+
+	// Assert
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	// Arrange
+	c := NewLRU[string, int](2, time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	// Touch "a" so "b" becomes least recently used
+	c.Get("a")
+
+	// Act
+	c.Set("c", 3)
+
+	// Assert
+	_, okA := c.Get("a")
+	_, okB := c.Get("b")
+	_, okC := c.Get("c")
+	assert.True(t, okA)
+	assert.False(t, okB)
+	assert.True(t, okC)
+}
+
+func TestLRU_DeleteAndPurge(t *testing.T) {
+	// Arrange
+	c := NewLRU[string, int](10, 0)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	// Act & Assert - delete
+	c.Delete("a")
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	// Act & Assert - purge
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+}
@@ -2,19 +2,30 @@ package config
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Auth     AuthConfig
-	App      AppConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Auth        AuthConfig
+	App         AppConfig
+	Cache       CacheConfig
+	GraphQL     GraphQLConfig
+	Outbox      OutboxConfig
+	Maintenance MaintenanceConfig
+	SMTP        SMTPConfig
+	Tenant      TenantConfig
 }
 
 // ServerConfig holds server-specific configuration
@@ -22,6 +33,18 @@ type ServerConfig struct {
 	Port        string `mapstructure:"port"`
 	Environment string `mapstructure:"environment"`
 	Host        string `mapstructure:"host"`
+
+	// RequestLogLevel is the slog level for per-request access logs
+	RequestLogLevel string `mapstructure:"request_log_level"`
+	// RequestLogSkipPaths are paths (e.g., health checks) excluded from access logs
+	RequestLogSkipPaths []string `mapstructure:"request_log_skip_paths"`
+
+	// MetricsEnabled serves Prometheus metrics at /metrics
+	MetricsEnabled bool `mapstructure:"metrics_enabled"`
+
+	// AllowedOrigins are the browser origins allowed to call the API
+	// cross-origin; defaults to App.FrontendURL
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
 }
 
 // DatabaseConfig holds database connection configuration
@@ -29,16 +52,75 @@ type DatabaseConfig struct {
 	Neo4jURI      string `mapstructure:"neo4j_uri"`
 	Neo4jUsername string `mapstructure:"neo4j_username"`
 	Neo4jPassword string `mapstructure:"neo4j_password"`
+
+	// Neo4jReadURI optionally points reads at a replica endpoint; writes use Neo4jURI
+	Neo4jReadURI string `mapstructure:"neo4j_read_uri"`
+
+	// ReadRouting is "followers" or "causal"; empty picks causal when
+	// Neo4jReadURI is set and followers otherwise
+	ReadRouting string `mapstructure:"read_routing"`
+
+	// MaxTraversalDepth caps variable-length graph traversals (e.g., invite chains)
+	MaxTraversalDepth int `mapstructure:"max_traversal_depth"`
+
+	// ConnectionLivenessCheckTimeout is how long a pooled connection may sit idle
+	// before it is tested on acquire; 0 tests every acquired connection
+	ConnectionLivenessCheckTimeout time.Duration `mapstructure:"connection_liveness_check_timeout"`
+
+	// MaxConnectionPoolSize caps the connections each driver opens; 0 keeps
+	// the environment's default (100 in production, 10 in development, 50
+	// otherwise)
+	MaxConnectionPoolSize int `mapstructure:"max_connection_pool_size"`
+
+	// ConnectionAcquisitionTimeout is how long a query waits for a pooled
+	// connection when the pool is full; 0 keeps the default of 2 minutes
+	ConnectionAcquisitionTimeout time.Duration `mapstructure:"connection_acquisition_timeout"`
+
+	// QueryTimeout bounds each ExecuteRead/ExecuteWrite transaction; 0 leaves
+	// only the caller's deadline
+	QueryTimeout time.Duration `mapstructure:"query_timeout"`
+
+	// SlowQueryThreshold logs read and write transactions that take at least
+	// this long; 0 disables the log
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
 }
 
 // AuthConfig holds authentication configuration
 type AuthConfig struct {
-	JWTSecret          string `mapstructure:"jwt_secret"`
-	GoogleClientID     string `mapstructure:"google_client_id"`
-	GoogleClientSecret string `mapstructure:"google_client_secret"`
-	AppleClientID      string `mapstructure:"apple_client_id"`
-	AppleClientSecret  string `mapstructure:"apple_client_secret"`
-	SessionSecret      string `mapstructure:"session_secret"`
+	JWTSecret          string   `mapstructure:"jwt_secret"`
+	GoogleClientID     string   `mapstructure:"google_client_id"`
+	GoogleClientSecret string   `mapstructure:"google_client_secret"`
+	AppleClientID      string   `mapstructure:"apple_client_id"`
+	AppleClientSecret  string   `mapstructure:"apple_client_secret"`
+	SessionSecret      string   `mapstructure:"session_secret"`
+	PlatformAdminIDs   []string `mapstructure:"platform_admin_ids"`
+
+	// PreviousJWTSecrets still verify tokens, but never sign them, while the
+	// tokens signed before JWTSecret was rotated expire
+	PreviousJWTSecrets []string `mapstructure:"previous_jwt_secrets"`
+
+	// GoogleRedirectURL is the sign-in callback registered with Google,
+	// ending in /auth/google/callback
+	GoogleRedirectURL string `mapstructure:"google_redirect_url"`
+
+	// AppleClientSecret is not sent to Apple: it holds the PEM-encoded
+	// Sign in with Apple private key (.p8) that client secrets are signed
+	// with. AppleKeyID identifies that key and AppleTeamID the developer
+	// account; AppleRedirectURL ends in /auth/apple/callback
+	AppleTeamID      string `mapstructure:"apple_team_id"`
+	AppleKeyID       string `mapstructure:"apple_key_id"`
+	AppleRedirectURL string `mapstructure:"apple_redirect_url"`
+
+	// TokenTTL is how long issued access tokens remain valid
+	TokenTTL time.Duration `mapstructure:"token_ttl"`
+	// InviteTokenTTL is how long invite links remain valid
+	InviteTokenTTL time.Duration `mapstructure:"invite_token_ttl"`
+	// RefreshTokenTTL is how long refresh tokens can mint new access tokens
+	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl"`
+	// RefreshTokenCleanupInterval is how often expired refresh tokens are deleted
+	RefreshTokenCleanupInterval time.Duration `mapstructure:"refresh_token_cleanup_interval"`
+	// AllowHeaderUserID trusts the X-User-ID header for local development; never honored in production
+	AllowHeaderUserID bool `mapstructure:"allow_header_user_id"`
 }
 
 // AppConfig holds application-level configuration
@@ -49,6 +131,49 @@ type AppConfig struct {
 	FrontendURL string `mapstructure:"frontend_url"`
 }
 
+// CacheConfig holds read-through cache configuration
+type CacheConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	TTL        time.Duration `mapstructure:"ttl"`
+	MaxEntries int           `mapstructure:"max_entries"`
+}
+
+// GraphQLConfig holds GraphQL server configuration
+type GraphQLConfig struct {
+	LogAllOperations bool     `mapstructure:"log_all_operations"`
+	ScrubFields      []string `mapstructure:"scrub_fields"`
+	VisibleFields    []string `mapstructure:"visible_fields"`
+}
+
+// OutboxConfig holds domain event outbox dispatcher configuration
+type OutboxConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+}
+
+// MaintenanceConfig holds maintenance mode configuration
+type MaintenanceConfig struct {
+	// Enabled starts the server with mutations rejected; it can be toggled at runtime
+	Enabled bool `mapstructure:"enabled"`
+	// RetryAfter is advertised to clients whose mutations are rejected
+	RetryAfter time.Duration `mapstructure:"retry_after"`
+}
+
+// SMTPConfig holds outgoing mail configuration; notifications are emailed only when Host is set
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	From     string `mapstructure:"from"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// TenantConfig holds tenant-level limits
+type TenantConfig struct {
+	// MaxPendingInvites caps outstanding email invites per tenant
+	MaxPendingInvites int `mapstructure:"max_pending_invites"`
+}
+
 // Load reads configuration from environment variables and config files
 func Load() (*Config, error) {
 	v := viper.New()
@@ -64,6 +189,13 @@ func Load() (*Config, error) {
 		}
 	}
 
+	// Merge an optional YAML or JSON config file onto the defaults, after
+	// .env so it can name the file; env vars bound below still take
+	// precedence over it
+	if err := readConfigFile(v); err != nil {
+		return nil, err
+	}
+
 	// Read from environment variables with GRGN_STACK prefix
 	// Map environment variables to nested config structure
 	v.SetEnvPrefix("GRGN_STACK")
@@ -73,31 +205,157 @@ func Load() (*Config, error) {
 	v.BindEnv("server.port", "GRGN_STACK_SERVER_PORT")
 	v.BindEnv("server.environment", "GRGN_STACK_SERVER_ENVIRONMENT")
 	v.BindEnv("server.host", "GRGN_STACK_SERVER_HOST")
+	v.BindEnv("server.request_log_level", "GRGN_STACK_SERVER_REQUEST_LOG_LEVEL")
+	v.BindEnv("server.request_log_skip_paths", "GRGN_STACK_SERVER_REQUEST_LOG_SKIP_PATHS")
+	v.BindEnv("server.metrics_enabled", "GRGN_STACK_SERVER_METRICS_ENABLED")
+	v.BindEnv("server.allowed_origins", "GRGN_STACK_SERVER_ALLOWED_ORIGINS")
 
 	v.BindEnv("database.neo4j_uri", "GRGN_STACK_DATABASE_NEO4J_URI")
 	v.BindEnv("database.neo4j_username", "GRGN_STACK_DATABASE_NEO4J_USERNAME")
 	v.BindEnv("database.neo4j_password", "GRGN_STACK_DATABASE_NEO4J_PASSWORD")
+	v.BindEnv("database.neo4j_read_uri", "GRGN_STACK_DATABASE_NEO4J_READ_URI")
+	v.BindEnv("database.read_routing", "GRGN_STACK_DATABASE_READ_ROUTING")
+	v.BindEnv("database.max_traversal_depth", "GRGN_STACK_DATABASE_MAX_TRAVERSAL_DEPTH")
+	v.BindEnv("database.connection_liveness_check_timeout", "GRGN_STACK_DATABASE_CONNECTION_LIVENESS_CHECK_TIMEOUT")
+	v.BindEnv("database.max_connection_pool_size", "GRGN_STACK_DATABASE_MAX_CONNECTION_POOL_SIZE")
+	v.BindEnv("database.connection_acquisition_timeout", "GRGN_STACK_DATABASE_CONNECTION_ACQUISITION_TIMEOUT")
+	v.BindEnv("database.query_timeout", "GRGN_STACK_DATABASE_QUERY_TIMEOUT")
+	v.BindEnv("database.slow_query_threshold", "GRGN_STACK_DATABASE_SLOW_QUERY_THRESHOLD")
 
 	v.BindEnv("auth.jwt_secret", "GRGN_STACK_AUTH_JWT_SECRET")
+	v.BindEnv("auth.previous_jwt_secrets", "GRGN_STACK_AUTH_PREVIOUS_JWT_SECRETS")
 	v.BindEnv("auth.google_client_id", "GRGN_STACK_AUTH_GOOGLE_CLIENT_ID")
 	v.BindEnv("auth.google_client_secret", "GRGN_STACK_AUTH_GOOGLE_CLIENT_SECRET")
+	v.BindEnv("auth.google_redirect_url", "GRGN_STACK_AUTH_GOOGLE_REDIRECT_URL")
 	v.BindEnv("auth.apple_client_id", "GRGN_STACK_AUTH_APPLE_CLIENT_ID")
 	v.BindEnv("auth.apple_client_secret", "GRGN_STACK_AUTH_APPLE_CLIENT_SECRET")
+	v.BindEnv("auth.apple_team_id", "GRGN_STACK_AUTH_APPLE_TEAM_ID")
+	v.BindEnv("auth.apple_key_id", "GRGN_STACK_AUTH_APPLE_KEY_ID")
+	v.BindEnv("auth.apple_redirect_url", "GRGN_STACK_AUTH_APPLE_REDIRECT_URL")
 	v.BindEnv("auth.session_secret", "GRGN_STACK_AUTH_SESSION_SECRET")
+	v.BindEnv("auth.platform_admin_ids", "GRGN_STACK_AUTH_PLATFORM_ADMIN_IDS")
+	v.BindEnv("auth.token_ttl", "GRGN_STACK_AUTH_TOKEN_TTL")
+	v.BindEnv("auth.invite_token_ttl", "GRGN_STACK_AUTH_INVITE_TOKEN_TTL")
+	v.BindEnv("auth.refresh_token_ttl", "GRGN_STACK_AUTH_REFRESH_TOKEN_TTL")
+	v.BindEnv("auth.refresh_token_cleanup_interval", "GRGN_STACK_AUTH_REFRESH_TOKEN_CLEANUP_INTERVAL")
+	v.BindEnv("auth.allow_header_user_id", "GRGN_STACK_AUTH_ALLOW_HEADER_USER_ID")
 
 	v.BindEnv("app.name", "GRGN_STACK_APP_NAME")
 	v.BindEnv("app.version", "GRGN_STACK_APP_VERSION")
 	v.BindEnv("app.log_level", "GRGN_STACK_APP_LOG_LEVEL")
 	v.BindEnv("app.frontend_url", "GRGN_STACK_APP_FRONTEND_URL")
 
+	v.BindEnv("cache.enabled", "GRGN_STACK_CACHE_ENABLED")
+	v.BindEnv("cache.ttl", "GRGN_STACK_CACHE_TTL")
+	v.BindEnv("cache.max_entries", "GRGN_STACK_CACHE_MAX_ENTRIES")
+
+	v.BindEnv("graphql.log_all_operations", "GRGN_STACK_GRAPHQL_LOG_ALL_OPERATIONS")
+	v.BindEnv("graphql.scrub_fields", "GRGN_STACK_GRAPHQL_SCRUB_FIELDS")
+	v.BindEnv("graphql.visible_fields", "GRGN_STACK_GRAPHQL_VISIBLE_FIELDS")
+
+	v.BindEnv("outbox.poll_interval", "GRGN_STACK_OUTBOX_POLL_INTERVAL")
+	v.BindEnv("outbox.batch_size", "GRGN_STACK_OUTBOX_BATCH_SIZE")
+
+	// Maintenance configuration
+	v.BindEnv("maintenance.enabled", "GRGN_STACK_MAINTENANCE_ENABLED")
+	v.BindEnv("maintenance.retry_after", "GRGN_STACK_MAINTENANCE_RETRY_AFTER")
+
+	// SMTP configuration
+	v.BindEnv("smtp.host", "GRGN_STACK_SMTP_HOST")
+	v.BindEnv("smtp.port", "GRGN_STACK_SMTP_PORT")
+	v.BindEnv("smtp.from", "GRGN_STACK_SMTP_FROM")
+	v.BindEnv("smtp.username", "GRGN_STACK_SMTP_USERNAME")
+	v.BindEnv("smtp.password", "GRGN_STACK_SMTP_PASSWORD")
+
+	// Tenant configuration
+	v.BindEnv("tenant.max_pending_invites", "GRGN_STACK_TENANT_MAX_PENDING_INVITES")
+
 	var config Config
 	if err := v.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("unable to decode config: %w", err)
 	}
+	if len(config.Server.AllowedOrigins) == 0 && config.App.FrontendURL != "" {
+		config.Server.AllowedOrigins = []string{config.App.FrontendURL}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config:\n%w", err)
+	}
 
 	return &config, nil
 }
 
+// ConfigFileEnv names the environment variable holding an explicit config
+// file path.
+const ConfigFileEnv = "GRGN_STACK_CONFIG"
+
+// configFileNames are the config files Load looks for, in order, in each of
+// the directories it searches for .env files.
+var configFileNames = []string{"config.yaml", "config.yml", "config.json"}
+
+// readConfigFile reads the config file named by GRGN_STACK_CONFIG, which
+// must exist, or else the first config file found in the current directory
+// or its two parents. Finding none is not an error.
+func readConfigFile(v *viper.Viper) error {
+	path := os.Getenv(ConfigFileEnv)
+	if path == "" {
+		path = findConfigFile()
+	}
+	if path == "" {
+		return nil
+	}
+
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("unable to read config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// findConfigFile returns the first of configFileNames present in the
+// directories searched for .env files, or "" if there is none.
+func findConfigFile() string {
+	for _, dir := range []string{".", "..", filepath.Join("..", "..")} {
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// Environments are the recognized values of server.environment.
+var Environments = []string{"development", "staging", "production"}
+
+// defaultNeo4jPassword is the development password production must not use.
+const defaultNeo4jPassword = "password"
+
+// Validate checks that the configuration can run a working server. It
+// returns every problem found, joined, or nil if there are none.
+func (c *Config) Validate() error {
+	var errs []error
+	if !slices.Contains(Environments, c.Server.Environment) {
+		errs = append(errs, fmt.Errorf("server.environment %q is not one of %s", c.Server.Environment, strings.Join(Environments, ", ")))
+	}
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port %q is not a port number", c.Server.Port))
+	}
+	if c.Database.Neo4jURI == "" {
+		errs = append(errs, errors.New("database.neo4j_uri is required"))
+	}
+	if c.Database.Neo4jUsername == "" {
+		errs = append(errs, errors.New("database.neo4j_username is required"))
+	}
+	if c.Auth.JWTSecret == "" && !c.IsDevelopment() {
+		errs = append(errs, fmt.Errorf("auth.jwt_secret is required in %s", c.Server.Environment))
+	}
+	if c.IsProduction() && (c.Database.Neo4jPassword == "" || c.Database.Neo4jPassword == defaultNeo4jPassword) {
+		errs = append(errs, errors.New("database.neo4j_password must be set to a non-default value in production"))
+	}
+	return errors.Join(errs...)
+}
+
 // loadEnvFile loads environment variables from a .env file
 func loadEnvFile(filePath string) error {
 	file, err := os.Open(filePath)
@@ -147,17 +405,54 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.port", "8080")
 	v.SetDefault("server.environment", "development")
 	v.SetDefault("server.host", "0.0.0.0")
+	v.SetDefault("server.request_log_level", "info")
+	v.SetDefault("server.request_log_skip_paths", []string{"/ping", "/healthz", "/readyz"})
+	v.SetDefault("server.metrics_enabled", false)
 
 	// Database defaults
 	v.SetDefault("database.neo4j_uri", "bolt://localhost:7687")
 	v.SetDefault("database.neo4j_username", "neo4j")
-	v.SetDefault("database.neo4j_password", "password")
+	v.SetDefault("database.neo4j_password", defaultNeo4jPassword)
+	v.SetDefault("database.max_traversal_depth", 25)
+	v.SetDefault("database.connection_liveness_check_timeout", "30s")
+	v.SetDefault("database.query_timeout", "30s")
+	v.SetDefault("database.slow_query_threshold", "200ms")
+
+	// Auth defaults
+	v.SetDefault("auth.google_redirect_url", "http://localhost:8080/auth/google/callback")
+	v.SetDefault("auth.token_ttl", "24h")
+	v.SetDefault("auth.invite_token_ttl", "168h")
+	v.SetDefault("auth.refresh_token_ttl", "720h")
+	v.SetDefault("auth.refresh_token_cleanup_interval", "1h")
+	v.SetDefault("auth.allow_header_user_id", false)
 
 	// App defaults
 	v.SetDefault("app.name", "GRGN Stack")
 	v.SetDefault("app.version", "0.1.0")
 	v.SetDefault("app.log_level", "info")
 	v.SetDefault("app.frontend_url", "http://localhost:5173")
+
+	// Cache defaults (opt-in)
+	v.SetDefault("cache.enabled", false)
+	v.SetDefault("cache.ttl", "5m")
+	v.SetDefault("cache.max_entries", 1000)
+
+	// GraphQL defaults (log failed operations only)
+	v.SetDefault("graphql.log_all_operations", false)
+
+	// Outbox defaults
+	v.SetDefault("outbox.poll_interval", "1s")
+	v.SetDefault("outbox.batch_size", 100)
+
+	// Maintenance defaults
+	v.SetDefault("maintenance.enabled", false)
+	v.SetDefault("maintenance.retry_after", "2m")
+
+	// SMTP defaults (disabled until a host is set)
+	v.SetDefault("smtp.port", 587)
+
+	// Tenant defaults
+	v.SetDefault("tenant.max_pending_invites", 500)
 }
 
 // IsDevelopment returns true if running in development mode
@@ -170,6 +465,12 @@ func (c *Config) IsProduction() bool {
 	return c.Server.Environment == "production"
 }
 
+// HeaderUserIDAllowed returns true if the X-User-ID header may authenticate
+// requests: only when explicitly enabled, and never in production
+func (c *Config) HeaderUserIDAllowed() bool {
+	return c.Auth.AllowHeaderUserID && !c.IsProduction()
+}
+
 // IsStaging returns true if running in staging mode
 func (c *Config) IsStaging() bool {
 	return c.Server.Environment == "staging"
@@ -0,0 +1,258 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig returns a configuration that passes Validate in environment.
+func validConfig(environment string) *Config {
+	return &Config{
+		Server:   ServerConfig{Port: "8080", Environment: environment},
+		Database: DatabaseConfig{Neo4jURI: "bolt://localhost:7687", Neo4jUsername: "neo4j", Neo4jPassword: "s3cret"},
+		Auth:     AuthConfig{JWTSecret: "jwt-secret"},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{"valid development", func(c *Config) {}, ""},
+		{"valid production", func(c *Config) { c.Server.Environment = "production" }, ""},
+		{"unknown environment", func(c *Config) { c.Server.Environment = "prod" }, `server.environment "prod" is not one of development, staging, production`},
+		{"non-numeric port", func(c *Config) { c.Server.Port = "http" }, `server.port "http" is not a port number`},
+		{"port out of range", func(c *Config) { c.Server.Port = "70000" }, `server.port "70000" is not a port number`},
+		{"missing Neo4j URI", func(c *Config) { c.Database.Neo4jURI = "" }, "database.neo4j_uri is required"},
+		{"missing Neo4j username", func(c *Config) { c.Database.Neo4jUsername = "" }, "database.neo4j_username is required"},
+		{"JWT secret optional in development", func(c *Config) { c.Auth.JWTSecret = "" }, ""},
+		{"JWT secret required in staging", func(c *Config) {
+			c.Server.Environment = "staging"
+			c.Auth.JWTSecret = ""
+		}, "auth.jwt_secret is required in staging"},
+		{"JWT secret required in production", func(c *Config) {
+			c.Server.Environment = "production"
+			c.Auth.JWTSecret = ""
+		}, "auth.jwt_secret is required in production"},
+		{"default password allowed in development", func(c *Config) { c.Database.Neo4jPassword = defaultNeo4jPassword }, ""},
+		{"default password rejected in production", func(c *Config) {
+			c.Server.Environment = "production"
+			c.Database.Neo4jPassword = defaultNeo4jPassword
+		}, "database.neo4j_password must be set to a non-default value in production"},
+		{"empty password rejected in production", func(c *Config) {
+			c.Server.Environment = "production"
+			c.Database.Neo4jPassword = ""
+		}, "database.neo4j_password must be set to a non-default value in production"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			cfg := validConfig("development")
+			tc.mutate(cfg)
+
+			// Act
+			err := cfg.Validate()
+
+			// Assert
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestConfig_Validate_ReportsEveryProblem(t *testing.T) {
+	// Arrange
+	cfg := validConfig("production")
+	cfg.Server.Port = ""
+	cfg.Database.Neo4jURI = ""
+	cfg.Auth.JWTSecret = ""
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "server.port")
+	assert.ErrorContains(t, err, "database.neo4j_uri is required")
+	assert.ErrorContains(t, err, "auth.jwt_secret is required in production")
+}
+
+func TestLoad_InvalidConfig(t *testing.T) {
+	// Arrange
+	t.Setenv("GRGN_STACK_SERVER_ENVIRONMENT", "development")
+	t.Setenv("GRGN_STACK_SERVER_PORT", "not-a-port")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	assert.Nil(t, cfg)
+	assert.ErrorContains(t, err, `server.port "not-a-port" is not a port number`)
+}
+
+// secretConfig returns a configuration with every secret field set.
+func secretConfig() *Config {
+	cfg := validConfig("production")
+	cfg.Database.Neo4jPassword = "neo4j-pass-1"
+	cfg.Auth.JWTSecret = "jwt-secret-2"
+	cfg.Auth.PreviousJWTSecrets = []string{"old-jwt-3"}
+	cfg.Auth.GoogleClientSecret = "google-secret-4"
+	cfg.Auth.AppleClientSecret = "apple-key-5"
+	cfg.Auth.SessionSecret = "session-secret-6"
+	cfg.SMTP.Password = "smtp-pass-7"
+	return cfg
+}
+
+// secretValues are the secrets secretConfig sets.
+var secretValues = []string{"neo4j-pass-1", "jwt-secret-2", "old-jwt-3", "google-secret-4", "apple-key-5", "session-secret-6", "smtp-pass-7"}
+
+func TestConfig_String_RedactsSecrets(t *testing.T) {
+	// Arrange
+	cfg := secretConfig()
+
+	// Act
+	outputs := []string{cfg.String(), fmt.Sprintf("%v", cfg), fmt.Sprintf("%+v", *cfg), cfg.Redacted().String()}
+
+	// Assert
+	for _, out := range outputs {
+		for _, secret := range secretValues {
+			assert.NotContains(t, out, secret)
+		}
+		assert.Contains(t, out, "Neo4jPassword:***redacted***(12)")
+		assert.Contains(t, out, "PreviousJWTSecrets:[***redacted***(9)]")
+		assert.Contains(t, out, "Neo4jUsername:neo4j")
+	}
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	// Arrange
+	cfg := secretConfig()
+	cfg.Auth.PlatformAdminIDs = []string{"admin-1"}
+
+	// Act
+	redacted := cfg.Redacted()
+	data, err := json.Marshal(redacted)
+	redacted.Auth.PlatformAdminIDs[0] = "changed"
+
+	// Assert
+	require.NoError(t, err)
+	for _, secret := range secretValues {
+		assert.NotContains(t, string(data), secret)
+	}
+	assert.Equal(t, "***redacted***(12)", redacted.Database.Neo4jPassword)
+	assert.Equal(t, "jwt-secret-2", cfg.Auth.JWTSecret, "the original is untouched")
+	assert.Equal(t, "admin-1", cfg.Auth.PlatformAdminIDs[0], "slices are copied")
+}
+
+func TestLoad_ConfigFile(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+server:
+  port: "9000"
+app:
+  name: From File
+graphql:
+  scrub_fields: [email, ssn]
+`), 0o600))
+	t.Setenv(ConfigFileEnv, path)
+	t.Setenv("GRGN_STACK_SERVER_ENVIRONMENT", "development")
+	t.Setenv("GRGN_STACK_SERVER_PORT", "9100")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "9100", cfg.Server.Port, "env vars override the file")
+	assert.Equal(t, "From File", cfg.App.Name)
+	assert.Equal(t, []string{"email", "ssn"}, cfg.GraphQL.ScrubFields)
+	assert.Equal(t, "neo4j", cfg.Database.Neo4jUsername, "defaults fill keys the file omits")
+}
+
+func TestLoad_ConfigFileJSON(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"cache": {"enabled": true, "max_entries": 50}}`), 0o600))
+	t.Setenv(ConfigFileEnv, path)
+	t.Setenv("GRGN_STACK_SERVER_ENVIRONMENT", "development")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, cfg.Cache.Enabled)
+	assert.Equal(t, 50, cfg.Cache.MaxEntries)
+}
+
+func TestLoad_ConfigFileNamedInDotEnv(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: From Dotenv File\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte(ConfigFileEnv+"="+path+"\n"), 0o600))
+	t.Chdir(dir)
+	t.Setenv(ConfigFileEnv, "")
+	t.Setenv("GRGN_STACK_SERVER_ENVIRONMENT", "development")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "From Dotenv File", cfg.App.Name)
+}
+
+func TestLoad_MissingConfigFile(t *testing.T) {
+	// Arrange
+	t.Setenv(ConfigFileEnv, filepath.Join(t.TempDir(), "missing.yaml"))
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	assert.Nil(t, cfg)
+	assert.ErrorContains(t, err, "unable to read config file")
+}
+
+func TestLoad_AllowedOriginsDefaultToFrontendURL(t *testing.T) {
+	// Arrange
+	t.Setenv("GRGN_STACK_SERVER_ENVIRONMENT", "development")
+	t.Setenv("GRGN_STACK_APP_FRONTEND_URL", "https://app.example")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://app.example"}, cfg.Server.AllowedOrigins)
+}
+
+func TestLoad_PoolSettings(t *testing.T) {
+	// Arrange
+	t.Setenv("GRGN_STACK_SERVER_ENVIRONMENT", "development")
+	t.Setenv("GRGN_STACK_DATABASE_MAX_CONNECTION_POOL_SIZE", "40")
+	t.Setenv("GRGN_STACK_DATABASE_CONNECTION_ACQUISITION_TIMEOUT", "30s")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 40, cfg.Database.MaxConnectionPoolSize)
+	assert.Equal(t, 30*time.Second, cfg.Database.ConnectionAcquisitionTimeout)
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// redactedValue replaces secret values in String output and Redacted copies.
+const redactedValue = "***redacted***"
+
+// redact masks a secret, keeping only its length. An empty secret stays
+// empty, so an unset value is still visible, and a masked one is left as is
+// so formatting a Redacted copy keeps the original lengths.
+func redact(secret string) string {
+	if secret == "" || strings.HasPrefix(secret, redactedValue) {
+		return secret
+	}
+	return fmt.Sprintf("%s(%d)", redactedValue, len(secret))
+}
+
+// Redacted returns a deep copy of c with every secret masked, safe to log
+// or serialize to JSON.
+func (c *Config) Redacted() *Config {
+	out := *c
+	out.Server.RequestLogSkipPaths = slices.Clone(c.Server.RequestLogSkipPaths)
+	out.Database = c.Database.redacted()
+	out.Auth = c.Auth.redacted()
+	out.GraphQL.ScrubFields = slices.Clone(c.GraphQL.ScrubFields)
+	out.GraphQL.VisibleFields = slices.Clone(c.GraphQL.VisibleFields)
+	out.SMTP = c.SMTP.redacted()
+	return &out
+}
+
+// String formats the configuration with secrets masked. It has a value
+// receiver so printing a Config or a *Config both redact.
+func (c Config) String() string {
+	// plain drops the method so formatting doesn't recurse; the sections
+	// carrying secrets mask them in their own String methods
+	type plain Config
+	return fmt.Sprintf("%+v", plain(c))
+}
+
+// redacted returns a copy of d with the password masked.
+func (d DatabaseConfig) redacted() DatabaseConfig {
+	d.Neo4jPassword = redact(d.Neo4jPassword)
+	return d
+}
+
+// String formats the database configuration with the password masked.
+func (d DatabaseConfig) String() string {
+	type plain DatabaseConfig
+	return fmt.Sprintf("%+v", plain(d.redacted()))
+}
+
+// redacted returns a deep copy of a with the signing secrets, OAuth
+// secrets and Apple private key masked.
+func (a AuthConfig) redacted() AuthConfig {
+	var previous []string
+	for _, secret := range a.PreviousJWTSecrets {
+		previous = append(previous, redact(secret))
+	}
+
+	a.JWTSecret = redact(a.JWTSecret)
+	a.PreviousJWTSecrets = previous
+	a.GoogleClientSecret = redact(a.GoogleClientSecret)
+	a.AppleClientSecret = redact(a.AppleClientSecret)
+	a.SessionSecret = redact(a.SessionSecret)
+	a.PlatformAdminIDs = slices.Clone(a.PlatformAdminIDs)
+	return a
+}
+
+// String formats the auth configuration with secrets masked.
+func (a AuthConfig) String() string {
+	type plain AuthConfig
+	return fmt.Sprintf("%+v", plain(a.redacted()))
+}
+
+// redacted returns a copy of s with the password masked.
+func (s SMTPConfig) redacted() SMTPConfig {
+	s.Password = redact(s.Password)
+	return s
+}
+
+// String formats the SMTP configuration with the password masked.
+func (s SMTPConfig) String() string {
+	type plain SMTPConfig
+	return fmt.Sprintf("%+v", plain(s.redacted()))
+}
@@ -0,0 +1,135 @@
+// Package dataloader batches lookups by key that are made concurrently, such
+// as by GraphQL field resolvers, into one fetch and caches the results.
+// A Loader is meant to live for a single request.
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Defaults for NewLoader.
+const (
+	// DefaultWait is how long a batch collects keys before it is fetched.
+	DefaultWait = 2 * time.Millisecond
+	// DefaultMaxBatch is how many keys a batch holds before it is fetched
+	// without waiting.
+	DefaultMaxBatch = 100
+)
+
+// BatchFunc fetches the values for keys in one call. Keys missing from the
+// returned map load as the zero value.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader batches and caches lookups of V by K.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[K]*entry[V]
+	batch *batch[K, V]
+}
+
+// entry is one key's result, available once done is closed.
+type entry[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// batch collects the keys fetched together, with the context of the first
+// lookup. once guards the fetch, since a batch that fills up is fetched
+// before its timer fires.
+type batch[K comparable, V any] struct {
+	ctx     context.Context
+	keys    []K
+	entries []*entry[V]
+	once    sync.Once
+}
+
+// NewLoader creates a Loader that fetches with fetch, waiting DefaultWait
+// for keys and fetching at most DefaultMaxBatch at a time.
+func NewLoader[K comparable, V any](fetch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     DefaultWait,
+		maxBatch: DefaultMaxBatch,
+		cache:    make(map[K]*entry[V]),
+	}
+}
+
+// WithWait sets how long a batch collects keys before it is fetched.
+func (l *Loader[K, V]) WithWait(wait time.Duration) *Loader[K, V] {
+	l.wait = wait
+	return l
+}
+
+// WithMaxBatch sets how many keys a batch holds before it is fetched
+// without waiting.
+func (l *Loader[K, V]) WithMaxBatch(n int) *Loader[K, V] {
+	l.maxBatch = n
+	return l
+}
+
+// Load returns the value for key, fetching it with the other keys loaded
+// within the wait, or from the cache if it was loaded before. A key the
+// fetch didn't return loads as the zero value.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	e, ok := l.cache[key]
+	if !ok {
+		e = &entry[V]{done: make(chan struct{})}
+		l.cache[key] = e
+		l.enqueue(ctx, key, e)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-e.done:
+		return e.value, e.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// enqueue adds key to the pending batch, starting one if there is none.
+// l.mu must be held.
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K, e *entry[V]) {
+	if l.batch == nil {
+		b := &batch[K, V]{ctx: ctx}
+		l.batch = b
+		time.AfterFunc(l.wait, func() { l.dispatch(b) })
+	}
+
+	b := l.batch
+	b.keys = append(b.keys, key)
+	b.entries = append(b.entries, e)
+	if len(b.keys) >= l.maxBatch {
+		l.batch = nil
+		go l.dispatch(b)
+	}
+}
+
+// dispatch fetches b, unless it already was, and completes its entries.
+// The fetch outlives the first caller's cancellation, since other callers
+// share it.
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	b.once.Do(func() {
+		l.mu.Lock()
+		if l.batch == b {
+			l.batch = nil
+		}
+		l.mu.Unlock()
+
+		values, err := l.fetch(context.WithoutCancel(b.ctx), b.keys)
+		for i, key := range b.keys {
+			e := b.entries[i]
+			e.value, e.err = values[key], err
+			close(e.done)
+		}
+	})
+}
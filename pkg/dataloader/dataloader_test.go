@@ -0,0 +1,130 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingFetch returns a BatchFunc that doubles keys and records each batch.
+func recordingFetch(mu *sync.Mutex, batches *[][]int) BatchFunc[int, int] {
+	return func(ctx context.Context, keys []int) (map[int]int, error) {
+		mu.Lock()
+		*batches = append(*batches, append([]int(nil), keys...))
+		mu.Unlock()
+
+		values := make(map[int]int, len(keys))
+		for _, key := range keys {
+			values[key] = key * 2
+		}
+		return values, nil
+	}
+}
+
+// loadAll loads keys concurrently and returns the values by key.
+func loadAll(t *testing.T, loader *Loader[int, int], keys []int) map[int]int {
+	t.Helper()
+	var mu sync.Mutex
+	values := make(map[int]int, len(keys))
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := loader.Load(context.Background(), key)
+			assert.NoError(t, err)
+			mu.Lock()
+			values[key] = value
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return values
+}
+
+func TestLoader_BatchesConcurrentLoads(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	var batches [][]int
+	loader := NewLoader(recordingFetch(&mu, &batches)).WithWait(20 * time.Millisecond)
+	keys := []int{1, 2, 3, 4, 5}
+
+	// Act
+	values := loadAll(t, loader, keys)
+
+	// Assert
+	require.Len(t, batches, 1)
+	assert.ElementsMatch(t, keys, batches[0])
+	assert.Equal(t, map[int]int{1: 2, 2: 4, 3: 6, 4: 8, 5: 10}, values)
+}
+
+func TestLoader_SplitsFullBatches(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	var batches [][]int
+	loader := NewLoader(recordingFetch(&mu, &batches)).WithWait(20 * time.Millisecond).WithMaxBatch(2)
+
+	// Act
+	values := loadAll(t, loader, []int{1, 2, 3, 4, 5})
+
+	// Assert
+	assert.Len(t, batches, 3)
+	assert.Len(t, values, 5)
+}
+
+func TestLoader_CachesLoadedKeys(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	var batches [][]int
+	loader := NewLoader(recordingFetch(&mu, &batches))
+
+	// Act
+	first, err1 := loader.Load(context.Background(), 7)
+	second, err2 := loader.Load(context.Background(), 7)
+
+	// Assert
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	assert.Equal(t, 14, first)
+	assert.Equal(t, 14, second)
+	assert.Len(t, batches, 1)
+}
+
+func TestLoader_MissingKeysAndErrors(t *testing.T) {
+	// Arrange
+	missing := NewLoader(func(ctx context.Context, keys []string) (map[string]*string, error) {
+		return nil, nil
+	})
+	failing := NewLoader(func(ctx context.Context, keys []string) (map[string]*string, error) {
+		return nil, errors.New("database down")
+	})
+
+	// Act
+	value, missingErr := missing.Load(context.Background(), "a")
+	_, failingErr := failing.Load(context.Background(), "a")
+
+	// Assert
+	assert.NoError(t, missingErr)
+	assert.Nil(t, value)
+	assert.EqualError(t, failingErr, "database down")
+}
+
+func TestLoader_LoadHonorsContext(t *testing.T) {
+	// Arrange
+	loader := NewLoader(func(ctx context.Context, keys []int) (map[int]int, error) {
+		return nil, nil
+	}).WithWait(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := loader.Load(ctx, 1)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+}
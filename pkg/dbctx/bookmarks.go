@@ -0,0 +1,62 @@
+// Package dbctx carries per-request database state in a context.
+package dbctx
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+type contextKey string
+
+// bookmarksKey is the context key for the request's bookmark holder
+const bookmarksKey contextKey = "bookmarks"
+
+// bookmarkHolder collects the bookmarks of one request's writes. Resolvers
+// may run concurrently, so access is guarded.
+type bookmarkHolder struct {
+	mu        sync.Mutex
+	bookmarks []string
+}
+
+// WithBookmarks returns a context that collects the bookmarks of writes made
+// with it, typically installed once per request. A context that already
+// carries a holder is returned unchanged, so nested calls share it.
+func WithBookmarks(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(bookmarksKey).(*bookmarkHolder); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, bookmarksKey, &bookmarkHolder{})
+}
+
+// Bookmarks returns a copy of the bookmarks collected in ctx, or nil if ctx
+// has no holder or no write has completed yet.
+func Bookmarks(ctx context.Context) []string {
+	holder, ok := ctx.Value(bookmarksKey).(*bookmarkHolder)
+	if !ok {
+		return nil
+	}
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	if len(holder.bookmarks) == 0 {
+		return nil
+	}
+	return append([]string(nil), holder.bookmarks...)
+}
+
+// AddBookmarks records bookmarks from a completed write in ctx's holder,
+// skipping ones already present. It reports false if ctx has no holder.
+func AddBookmarks(ctx context.Context, bookmarks []string) bool {
+	holder, ok := ctx.Value(bookmarksKey).(*bookmarkHolder)
+	if !ok {
+		return false
+	}
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	for _, bookmark := range bookmarks {
+		if !slices.Contains(holder.bookmarks, bookmark) {
+			holder.bookmarks = append(holder.bookmarks, bookmark)
+		}
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package dbctx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarks_CollectsWrites(t *testing.T) {
+	// Arrange
+	ctx := WithBookmarks(context.Background())
+
+	// Act
+	AddBookmarks(ctx, []string{"bm:1"})
+	AddBookmarks(WithBookmarks(ctx), []string{"bm:1", "bm:2"})
+	got := Bookmarks(ctx)
+	got[0] = "mutated"
+
+	// Assert
+	assert.Equal(t, []string{"bm:1", "bm:2"}, Bookmarks(ctx), "nested holders are shared and results are copies")
+}
+
+func TestBookmarks_WithoutHolder(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	added := AddBookmarks(ctx, []string{"bm:1"})
+
+	// Assert
+	assert.False(t, added)
+	assert.Nil(t, Bookmarks(ctx))
+	assert.Nil(t, Bookmarks(WithBookmarks(ctx)))
+}
@@ -0,0 +1,29 @@
+package dbctx
+
+import "context"
+
+// TxMode is the kind of transaction an operation is expected to run.
+type TxMode string
+
+const (
+	// TxModeRead marks operations that should only read, such as GraphQL queries.
+	TxModeRead TxMode = "read"
+
+	// TxModeWrite marks operations that should only write, such as GraphQL mutations.
+	TxModeWrite TxMode = "write"
+)
+
+// txModeKey is the context key for the operation's expected TxMode
+const txModeKey contextKey = "txMode"
+
+// WithTxMode returns a context hinting that the transactions run with it
+// should be of mode.
+func WithTxMode(ctx context.Context, mode TxMode) context.Context {
+	return context.WithValue(ctx, txModeKey, mode)
+}
+
+// TxModeFrom returns the TxMode hinted in ctx, if any.
+func TxModeFrom(ctx context.Context) (TxMode, bool) {
+	mode, ok := ctx.Value(txModeKey).(TxMode)
+	return mode, ok
+}
@@ -1,7 +1,11 @@
 // Package errors provides custom error types for the GRGN stack.
 package errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Sentinel errors for common cases
 var (
@@ -15,35 +19,227 @@ var (
 	ErrNotAuthenticated = errors.New("user not authenticated")
 	ErrUnauthorized     = errors.New("unauthorized")
 	ErrForbidden        = errors.New("forbidden: insufficient permissions")
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrTokenRevoked     = errors.New("token has been revoked")
 
 	// Validation errors
 	ErrInvalidInput = errors.New("invalid input")
 	ErrInvalidSlug  = errors.New("invalid slug format")
 	ErrSlugTaken    = errors.New("slug already taken")
+	ErrReservedSlug = errors.New("slug is reserved")
 	ErrEmailTaken   = errors.New("email already taken")
+	ErrInvalidEmail = errors.New("invalid email address")
+
+	// ErrProviderMismatch means a sign-in identity can't be linked to the
+	// account, e.g. because the account signs in with another provider
+	ErrProviderMismatch = errors.New("account uses a different sign-in method")
 
 	// Business rule errors
 	ErrLastOwner     = errors.New("cannot remove or demote the last owner")
 	ErrAlreadyMember = errors.New("user is already a member")
 	ErrNotMember     = errors.New("user is not a member of this tenant")
 	ErrCannotLeave   = errors.New("cannot leave: you are the last owner")
+
+	ErrInviteLimitReached = errors.New("tenant has reached its pending invite limit")
+
+	// ErrTenantSuspended means a write targeted a suspended tenant, which is
+	// read-only until an owner unsuspends it
+	ErrTenantSuspended = errors.New("tenant is suspended")
+
+	// ErrNotSuspended means an unsuspend targeted a tenant that isn't suspended
+	ErrNotSuspended = errors.New("tenant is not suspended")
+
+	// ErrAlreadySuspended means a suspend targeted a tenant that is already
+	// suspended
+	ErrAlreadySuspended = errors.New("tenant is already suspended")
+
+	// ErrNotDeleted means a restore targeted something that isn't deleted
+	ErrNotDeleted = errors.New("resource is not deleted")
+
+	// Request lifecycle errors
+	ErrTimeout   = errors.New("request timed out")
+	ErrCancelled = errors.New("request cancelled")
+
+	// Availability errors
+	ErrMaintenance = errors.New("service is in maintenance mode; try again later")
 )
 
 // ValidationError wraps validation errors with field info
 type ValidationError struct {
 	Field   string
 	Message string
+
+	// Err is the sentinel the problem came from, if any, e.g. ErrInvalidSlug
+	Err error
 }
 
 func (e *ValidationError) Error() string {
 	return e.Field + ": " + e.Message
 }
 
+// Unwrap returns the sentinel the problem came from, if any
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
 // NewValidationError creates a new validation error
 func NewValidationError(field, message string) *ValidationError {
 	return &ValidationError{Field: field, Message: message}
 }
 
+// ValidationErrors collects every problem with an input, so a client can
+// show them all at once instead of one per attempt. Is and As see each
+// ValidationError in it.
+type ValidationErrors []ValidationError
+
+// Add records a problem with field.
+func (e *ValidationErrors) Add(field, message string) {
+	*e = append(*e, ValidationError{Field: field, Message: message})
+}
+
+// AddError records err against field, doing nothing if err is nil. A
+// ValidationError keeps its own field and message; a ValidationErrors adds
+// all of its problems; other errors, such as ErrInvalidSlug, are recorded
+// with their message and stay matchable with Is.
+func (e *ValidationErrors) AddError(field string, err error) {
+	var list ValidationErrors
+	var single *ValidationError
+	switch {
+	case err == nil:
+	case errors.As(err, &list):
+		*e = append(*e, list...)
+	case errors.As(err, &single):
+		*e = append(*e, *single)
+	default:
+		*e = append(*e, ValidationError{Field: field, Message: err.Error(), Err: err})
+	}
+}
+
+// HasErrors reports whether any problem was recorded.
+func (e ValidationErrors) HasErrors() bool {
+	return len(e) > 0
+}
+
+// Err returns e as an error, or nil if no problem was recorded.
+func (e ValidationErrors) Err() error {
+	if !e.HasErrors() {
+		return nil
+	}
+	return e
+}
+
+// Error joins the problems' messages with "; ".
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i := range e {
+		messages[i] = e[i].Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns each problem, for Is and As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i := range e {
+		errs[i] = &e[i]
+	}
+	return errs
+}
+
+// Machine-readable error codes shared by the GraphQL and HTTP layers
+const (
+	CodeNotFound        = "NOT_FOUND"
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeForbidden       = "FORBIDDEN"
+	CodeNotMember       = "NOT_MEMBER"
+	CodeInvalidInput    = "INVALID_INPUT"
+	CodeConflict        = "CONFLICT"
+	CodeTimeout         = "TIMEOUT"
+	CodeCancelled       = "CANCELLED"
+	CodeMaintenance     = "MAINTENANCE"
+	CodeSuspended       = "TENANT_SUSPENDED"
+	CodeInternal        = "INTERNAL"
+)
+
+// CodedError attaches a code and client-safe message to an underlying error.
+type CodedError struct {
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *CodedError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying error
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// NewCodedError creates a new coded error
+func NewCodedError(code, message string, err error) *CodedError {
+	return &CodedError{Code: code, Message: message, Err: err}
+}
+
+// sentinelCodes maps sentinel errors to their codes, checked in order
+var sentinelCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrNotFound, CodeNotFound},
+	{ErrUserNotFound, CodeNotFound},
+	{ErrTenantNotFound, CodeNotFound},
+	{ErrMembershipNotFound, CodeNotFound},
+	{ErrNotAuthenticated, CodeUnauthenticated},
+	{ErrUnauthorized, CodeUnauthenticated},
+	{ErrInvalidToken, CodeUnauthenticated},
+	{ErrTokenRevoked, CodeUnauthenticated},
+	{ErrForbidden, CodeForbidden},
+	{ErrNotMember, CodeNotMember},
+	{ErrInvalidInput, CodeInvalidInput},
+	{ErrInvalidSlug, CodeInvalidInput},
+	{ErrReservedSlug, CodeInvalidInput},
+	{ErrInvalidEmail, CodeInvalidInput},
+	{ErrSlugTaken, CodeConflict},
+	{ErrEmailTaken, CodeConflict},
+	{ErrProviderMismatch, CodeConflict},
+	{ErrAlreadyMember, CodeConflict},
+	{ErrLastOwner, CodeConflict},
+	{ErrCannotLeave, CodeConflict},
+	{ErrInviteLimitReached, CodeConflict},
+	{ErrNotDeleted, CodeConflict},
+	{ErrTenantSuspended, CodeSuspended},
+	{ErrNotSuspended, CodeConflict},
+	{ErrAlreadySuspended, CodeConflict},
+	{ErrTimeout, CodeTimeout},
+	{ErrCancelled, CodeCancelled},
+	{ErrMaintenance, CodeMaintenance},
+}
+
+// CodeOf returns the code for err, or CodeInternal if it is not a known error.
+func CodeOf(err error) string {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+
+	var validation *ValidationError
+	if errors.As(err, &validation) {
+		return CodeInvalidInput
+	}
+
+	for _, sc := range sentinelCodes {
+		if errors.Is(err, sc.err) {
+			return sc.code
+		}
+	}
+	return CodeInternal
+}
+
 // Is checks if target error matches
 func Is(err, target error) bool {
 	return errors.Is(err, target)
@@ -54,10 +250,19 @@ func As(err error, target any) bool {
 	return errors.As(err, target)
 }
 
-// Wrap wraps an error with additional context
+// Wrap wraps an error with additional context. The result still matches
+// err with Is and As. Wrapping nil returns nil.
 func Wrap(err error, message string) error {
 	if err == nil {
 		return nil
 	}
-	return errors.New(message + ": " + err.Error())
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+// Wrapf is Wrap with a formatted message.
+func Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err)
 }
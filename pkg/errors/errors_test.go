@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap_KeepsSentinel(t *testing.T) {
+	// Act
+	err := Wrap(Wrap(ErrUserNotFound, "find user"), "sign in")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.Equal(t, "sign in: find user: user not found", err.Error())
+	assert.Equal(t, CodeNotFound, CodeOf(err))
+}
+
+func TestWrap_KeepsErrorTypes(t *testing.T) {
+	// Act
+	err := Wrap(NewValidationError("email", "is required"), "create user")
+
+	// Assert
+	var validation *ValidationError
+	require.ErrorAs(t, err, &validation)
+	assert.Equal(t, "email", validation.Field)
+}
+
+func TestWrapf(t *testing.T) {
+	// Act
+	err := Wrapf(ErrTenantNotFound, "load tenant %s", "tenant-1")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTenantNotFound)
+	assert.Equal(t, "load tenant tenant-1: tenant not found", err.Error())
+}
+
+func TestWrap_Nil(t *testing.T) {
+	assert.NoError(t, Wrap(nil, "find user"))
+	assert.NoError(t, Wrapf(nil, "find user %s", "user-1"))
+}
+
+func TestValidationErrors_Accumulates(t *testing.T) {
+	// Arrange
+	var problems ValidationErrors
+
+	// Act
+	problems.Add("name", "is required")
+	problems.AddError("email", NewValidationError("email", "must be a valid email address"))
+	problems.AddError("slug", Wrap(ErrInvalidSlug, "check slug"))
+	problems.AddError("plan", nil)
+
+	// Assert
+	require.True(t, problems.HasErrors())
+	require.Len(t, problems, 3)
+	assert.Equal(t, "name: is required; email: must be a valid email address; slug: check slug: invalid slug format", problems.Error())
+	assert.Equal(t, "slug", problems[2].Field)
+}
+
+func TestValidationErrors_IsAndAs(t *testing.T) {
+	// Arrange
+	var problems ValidationErrors
+	problems.Add("name", "is required")
+	problems.AddError("slug", ErrReservedSlug)
+
+	// Act
+	err := Wrap(problems.Err(), "create tenant")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrReservedSlug)
+	var single *ValidationError
+	require.ErrorAs(t, err, &single)
+	assert.Equal(t, "name", single.Field)
+	assert.Equal(t, CodeInvalidInput, CodeOf(err))
+	assert.Equal(t, 422, HTTPStatus(err))
+}
+
+func TestValidationErrors_AddErrorFlattensLists(t *testing.T) {
+	// Arrange
+	var inner, outer ValidationErrors
+	inner.Add("name", "is required")
+	inner.Add("slug", "is required")
+
+	// Act
+	outer.AddError("input", inner.Err())
+
+	// Assert
+	assert.Len(t, outer, 2)
+}
+
+func TestValidationErrors_Empty(t *testing.T) {
+	// Arrange
+	var problems ValidationErrors
+
+	// Act & Assert
+	assert.False(t, problems.HasErrors())
+	assert.NoError(t, problems.Err())
+}
@@ -0,0 +1,43 @@
+package errors
+
+import "net/http"
+
+// CodeMethodNotAllowed is returned for routes hit with an unsupported HTTP method.
+const CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+
+// codeStatus maps error codes to HTTP status codes.
+var codeStatus = map[string]int{
+	CodeNotFound:         http.StatusNotFound,
+	CodeUnauthenticated:  http.StatusUnauthorized,
+	CodeForbidden:        http.StatusForbidden,
+	CodeNotMember:        http.StatusForbidden,
+	CodeInvalidInput:     http.StatusBadRequest,
+	CodeConflict:         http.StatusConflict,
+	CodeTimeout:          http.StatusGatewayTimeout,
+	CodeCancelled:        499, // client closed request
+	CodeMaintenance:      http.StatusServiceUnavailable,
+	CodeSuspended:        http.StatusForbidden,
+	CodeInternal:         http.StatusInternalServerError,
+	CodeMethodNotAllowed: http.StatusMethodNotAllowed,
+}
+
+// StatusForCode returns the HTTP status for an error code.
+// Unknown codes map to 500.
+func StatusForCode(code string) int {
+	if status, ok := codeStatus[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// HTTPStatus returns the HTTP status for err: the status of its code, except
+// that a ValidationError, naming the field that is wrong in a well-formed
+// request, is 422. Unknown errors map to 500.
+func HTTPStatus(err error) int {
+	code := CodeOf(err)
+	var validation *ValidationError
+	if code == CodeInvalidInput && As(err, &validation) {
+		return http.StatusUnprocessableEntity
+	}
+	return StatusForCode(code)
+}
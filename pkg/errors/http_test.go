@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	testCases := []struct {
+		err    error
+		status int
+		desc   string
+	}{
+		{ErrNotAuthenticated, http.StatusUnauthorized, "not authenticated"},
+		{ErrInvalidToken, http.StatusUnauthorized, "invalid token"},
+		{ErrForbidden, http.StatusForbidden, "forbidden"},
+		{ErrNotMember, http.StatusForbidden, "not a member"},
+		{ErrUserNotFound, http.StatusNotFound, "user not found"},
+		{ErrTenantNotFound, http.StatusNotFound, "tenant not found"},
+		{fmt.Errorf("find tenant: %w", ErrTenantNotFound), http.StatusNotFound, "wrapped sentinel"},
+		{ErrSlugTaken, http.StatusConflict, "slug taken"},
+		{ErrEmailTaken, http.StatusConflict, "email taken"},
+		{NewValidationError("email", "must be a valid email address"), http.StatusUnprocessableEntity, "validation error"},
+		{fmt.Errorf("create user: %w", NewValidationError("name", "cannot be empty")), http.StatusUnprocessableEntity, "wrapped validation error"},
+		{ErrInvalidSlug, http.StatusBadRequest, "invalid input sentinel"},
+		{NewCodedError(CodeInvalidInput, "invalid request body", nil), http.StatusBadRequest, "coded invalid input"},
+		{NewCodedError(CodeMethodNotAllowed, "method not allowed", nil), http.StatusMethodNotAllowed, "method not allowed"},
+		{ErrTimeout, http.StatusGatewayTimeout, "timeout"},
+		{ErrMaintenance, http.StatusServiceUnavailable, "maintenance"},
+		{ErrTenantSuspended, http.StatusForbidden, "tenant suspended"},
+		{ErrAlreadySuspended, http.StatusConflict, "already suspended"},
+		{context.Canceled, http.StatusInternalServerError, "unmapped context error"},
+		{fmt.Errorf("neo4j: connection reset"), http.StatusInternalServerError, "unknown error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.status, HTTPStatus(tc.err))
+		})
+	}
+}
+
+func TestStatusForCode_UnknownCode(t *testing.T) {
+	assert.Equal(t, http.StatusInternalServerError, StatusForCode("NO_SUCH_CODE"))
+}
@@ -0,0 +1,89 @@
+// Package events defines domain events and a lightweight in-process pub/sub broker.
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Domain event types.
+const (
+	TenantCreated     = "tenant.created"
+	TenantUpdated     = "tenant.updated"
+	TenantDeleted     = "tenant.deleted"
+	TenantRestored    = "tenant.restored"
+	MemberAdded       = "membership.created"
+	MemberInvited     = "membership.invited"
+	MemberRoleChanged = "membership.role_changed"
+	MemberRemoved     = "membership.deleted"
+)
+
+// Event is a domain event recorded alongside the change that produced it.
+type Event struct {
+	ID          string
+	Type        string
+	AggregateID string
+	Payload     map[string]any
+	CreatedAt   time.Time
+}
+
+// Publisher delivers events to interested subscribers.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Broker is an in-memory Publisher that fans events out to subscribers.
+// Slow subscribers drop events rather than block publishers.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[int]chan Event
+	nextID      int
+	bufferSize  int
+}
+
+// NewBroker creates a new Broker whose subscriber channels buffer bufferSize events.
+func NewBroker(bufferSize int) *Broker {
+	return &Broker{
+		subscribers: make(map[int]chan Event),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe registers a subscriber and returns its channel and an unsubscribe function.
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan Event, b.bufferSize)
+	b.subscribers[id] = ch
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if sub, ok := b.subscribers[id]; ok {
+			delete(b.subscribers, id)
+			close(sub)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// Publish delivers the event to every current subscriber.
+func (b *Broker) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Ensure Broker implements Publisher
+var _ Publisher = (*Broker)(nil)
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroker_PublishFansOut(t *testing.T) {
+	// Arrange
+	b := NewBroker(1)
+	first, unsubFirst := b.Subscribe()
+	defer unsubFirst()
+	second, unsubSecond := b.Subscribe()
+	defer unsubSecond()
+
+	// Act
+	err := b.Publish(context.Background(), Event{ID: "evt-1", Type: TenantCreated})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "evt-1", (<-first).ID)
+	assert.Equal(t, "evt-1", (<-second).ID)
+}
+
+func TestBroker_Unsubscribe(t *testing.T) {
+	// Arrange
+	b := NewBroker(1)
+	ch, unsubscribe := b.Subscribe()
+
+	// Act
+	unsubscribe()
+	unsubscribe() // idempotent
+	err := b.Publish(context.Background(), Event{ID: "evt-1"})
+
+	// Assert
+	require.NoError(t, err)
+	_, open := <-ch
+	assert.False(t, open)
+}
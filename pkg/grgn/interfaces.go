@@ -27,6 +27,12 @@ type HealthStatus struct {
 	Status  string `json:"status"`
 	Message string `json:"message,omitempty"`
 	Error   string `json:"error,omitempty"`
+
+	// LatencyMs is how long the component took to answer the check
+	LatencyMs float64 `json:"latencyMs"`
+	// Version and Edition describe the component's server, when known
+	Version string `json:"version,omitempty"`
+	Edition string `json:"edition,omitempty"`
 }
 
 // HealthResponse represents the full health check response
@@ -0,0 +1,64 @@
+// Package logging builds the structured slog logger the server writes to
+// and carries request-scoped loggers in contexts, so code deep in a request
+// such as repositories logs with the request's fields attached.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/yourusername/grgn-stack/pkg/config"
+)
+
+// New creates a logger writing to stderr at cfg.App.LogLevel. Development
+// logs are text for reading in a terminal; other environments log JSON for
+// ingestion. An invalid level logs at info and says so.
+func New(cfg *config.Config) *slog.Logger {
+	return newLogger(os.Stderr, cfg)
+}
+
+// newLogger creates the logger New describes, writing to w.
+func newLogger(w io.Writer, cfg *config.Config) *slog.Logger {
+	level, err := ParseLevel(cfg.App.LogLevel)
+	opts := &slog.HandlerOptions{Level: level}
+
+	var logger *slog.Logger
+	if cfg.IsDevelopment() {
+		logger = slog.New(slog.NewTextHandler(w, opts))
+	} else {
+		logger = slog.New(slog.NewJSONHandler(w, opts))
+	}
+
+	if err != nil {
+		logger.Warn("invalid log level, using info", "logLevel", cfg.App.LogLevel)
+	}
+	return logger
+}
+
+// ParseLevel parses a level name such as "debug" or "warn", returning info
+// along with the error if name isn't one.
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return slog.LevelInfo, err
+	}
+	return level, nil
+}
+
+// contextKey is the context key for the request-scoped logger.
+type contextKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger in ctx, or slog.Default() if it has none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
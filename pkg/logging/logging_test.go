@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/config"
+)
+
+// testConfig returns a config for environment logging at level.
+func testConfig(environment, level string) *config.Config {
+	cfg := &config.Config{}
+	cfg.Server.Environment = environment
+	cfg.App.LogLevel = level
+	return cfg
+}
+
+func TestNewLogger_FiltersBelowLevel(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := newLogger(&buf, testConfig("production", "warn"))
+
+	// Act
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+
+	// Assert
+	var messages []string
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var record map[string]any
+		require.NoError(t, decoder.Decode(&record))
+		messages = append(messages, record["msg"].(string))
+	}
+	assert.Equal(t, []string{"warn", "error"}, messages)
+}
+
+func TestNewLogger_TextInDevelopment(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := newLogger(&buf, testConfig("development", "debug"))
+
+	// Act
+	logger.Debug("hello", "component", "test")
+
+	// Assert
+	assert.Contains(t, buf.String(), "level=DEBUG msg=hello component=test")
+}
+
+func TestNewLogger_InvalidLevelUsesInfo(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := newLogger(&buf, testConfig("production", "loud"))
+
+	// Act
+	logger.Debug("dropped")
+
+	// Assert - only the warning about the level is written
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "invalid log level, using info", record["msg"])
+	assert.Equal(t, "loud", record["logLevel"])
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    slog.Level
+		wantErr bool
+	}{
+		{name: "debug", want: slog.LevelDebug},
+		{name: "INFO", want: slog.LevelInfo},
+		{name: "warn", want: slog.LevelWarn},
+		{name: "error", want: slog.LevelError},
+		{name: "", want: slog.LevelInfo, wantErr: true},
+		{name: "verbose", want: slog.LevelInfo, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			level, err := ParseLevel(tt.name)
+
+			// Assert
+			assert.Equal(t, tt.want, level)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	// Arrange
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	// Act
+	ctx := WithLogger(context.Background(), logger)
+
+	// Assert
+	assert.Same(t, logger, FromContext(ctx))
+	assert.Same(t, slog.Default(), FromContext(context.Background()))
+}
@@ -0,0 +1,118 @@
+// Package metrics holds the server's Prometheus collectors. They are
+// registered once, on a registry of their own, and served by Handler.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric name.
+const namespace = "grgn"
+
+// Result label values.
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests served, by route, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to serve HTTP requests, by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	graphqlDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "graphql_operation_duration_seconds",
+		Help:      "Time taken to execute GraphQL operations, by operation type and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type", "result"})
+
+	dbTransactions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_transactions_total",
+		Help:      "Neo4j transactions run, by access mode and result.",
+	}, []string{"mode", "result"})
+
+	dbDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_transaction_duration_seconds",
+		Help:      "Time taken by Neo4j transactions including retries, by access mode and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"mode", "result"})
+
+	authzDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "authorization_denials_total",
+		Help:      "Requests denied by tenant authorization, by operation and required role.",
+	}, []string{"operation", "role"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		graphqlDuration,
+		dbTransactions,
+		dbDuration,
+		authzDenials,
+	)
+}
+
+// Handler serves the registered metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records a served request. route is the route pattern,
+// such as "/tenants/:id/members.csv", so IDs don't become label values.
+func ObserveHTTPRequest(route, method string, status int, elapsed time.Duration) {
+	httpRequests.WithLabelValues(route, method, strconv.Itoa(status)).Inc()
+	httpDuration.WithLabelValues(route, method).Observe(elapsed.Seconds())
+}
+
+// ObserveGraphQLOperation records an executed operation of opType, such as
+// "query" or "mutation", that failed if its response carried errors.
+func ObserveGraphQLOperation(opType string, failed bool, elapsed time.Duration) {
+	graphqlDuration.WithLabelValues(opType, result(failed)).Observe(elapsed.Seconds())
+}
+
+// ObserveTransaction records a Neo4j transaction run in mode, "read" or
+// "write", that failed if err is non-nil.
+func ObserveTransaction(mode string, err error, elapsed time.Duration) {
+	r := result(err != nil)
+	dbTransactions.WithLabelValues(mode, r).Inc()
+	dbDuration.WithLabelValues(mode, r).Observe(elapsed.Seconds())
+}
+
+// ObserveDenial records an authorization denial of operation, such as
+// "UpdateTenant", for a caller lacking role.
+func ObserveDenial(operation, role string) {
+	authzDenials.WithLabelValues(operation, role).Inc()
+}
+
+// result returns the result label for an outcome.
+func result(failed bool) string {
+	if failed {
+		return ResultError
+	}
+	return ResultSuccess
+}
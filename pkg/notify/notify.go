@@ -0,0 +1,71 @@
+// Package notify delivers user-facing notifications such as invite emails.
+// The Notifier interface lets the SMTP implementation be swapped for another
+// provider, or for a no-op where mail isn't configured.
+package notify
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Invite asks someone to join a tenant.
+type Invite struct {
+	To           string
+	TenantName   string
+	Role         string
+	MembershipID string
+	// Token accepts the invite; empty when invite links are disabled
+	Token string
+}
+
+// RoleChanged tells a member their role in a tenant changed.
+type RoleChanged struct {
+	To         string
+	TenantName string
+	Role       string
+}
+
+// Notifier defines the contract for sending notifications.
+// Callers invoke it after the change it describes has committed, so a
+// failed notification never undoes that change.
+type Notifier interface {
+	// SendInvite notifies someone that they were invited to a tenant.
+	SendInvite(ctx context.Context, invite Invite) error
+
+	// SendRoleChanged notifies a member that their role changed.
+	SendRoleChanged(ctx context.Context, change RoleChanged) error
+}
+
+// Nop discards every notification.
+type Nop struct{}
+
+// SendInvite does nothing.
+func (Nop) SendInvite(ctx context.Context, invite Invite) error { return nil }
+
+// SendRoleChanged does nothing.
+func (Nop) SendRoleChanged(ctx context.Context, change RoleChanged) error { return nil }
+
+// Log writes notifications to a logger instead of sending them.
+// Intended for development, where no mailer is configured.
+type Log struct {
+	Logger *slog.Logger
+}
+
+// SendInvite logs the invite, including its token.
+func (l Log) SendInvite(ctx context.Context, invite Invite) error {
+	l.Logger.InfoContext(ctx, "invite notification",
+		"to", invite.To, "tenant", invite.TenantName, "membershipId", invite.MembershipID, "token", invite.Token)
+	return nil
+}
+
+// SendRoleChanged logs the role change.
+func (l Log) SendRoleChanged(ctx context.Context, change RoleChanged) error {
+	l.Logger.InfoContext(ctx, "role changed notification",
+		"to", change.To, "tenant", change.TenantName, "role", change.Role)
+	return nil
+}
+
+var (
+	_ Notifier = Nop{}
+	_ Notifier = Log{}
+)
@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/grgn-stack/pkg/config"
+)
+
+// smtpTimeout bounds a whole send, dial included, so a slow or unreachable
+// mail server can't hold up the request that triggered the notification.
+const smtpTimeout = 10 * time.Second
+
+// sendMailFunc sends mail like smtp.SendMail, giving up when ctx ends, so
+// tests can capture outgoing mail.
+type sendMailFunc func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// SMTPNotifier emails notifications through an SMTP server.
+type SMTPNotifier struct {
+	addr     string
+	from     string
+	auth     smtp.Auth
+	appURL   string
+	sendMail sendMailFunc
+}
+
+// NewSMTPNotifier creates an SMTPNotifier. Invite emails link to appURL.
+// PLAIN auth is used when a username is configured.
+func NewSMTPNotifier(cfg config.SMTPConfig, appURL string) (*SMTPNotifier, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("smtp host is required")
+	}
+	if cfg.From == "" {
+		return nil, fmt.Errorf("smtp from address is required")
+	}
+
+	n := &SMTPNotifier{
+		addr:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		from:     cfg.From,
+		appURL:   strings.TrimRight(appURL, "/"),
+		sendMail: sendMail,
+	}
+	if cfg.Username != "" {
+		n.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return n, nil
+}
+
+// SendInvite emails the invite with a link to accept it.
+func (n *SMTPNotifier) SendInvite(ctx context.Context, invite Invite) error {
+	body := fmt.Sprintf("You have been invited to join %s as %s.\r\n", invite.TenantName, strings.ToLower(invite.Role))
+	if invite.Token != "" {
+		body += fmt.Sprintf("\r\nAccept the invite: %s/invites/accept?token=%s\r\n", n.appURL, url.QueryEscape(invite.Token))
+	}
+	return n.send(ctx, invite.To, "You're invited to "+invite.TenantName, body)
+}
+
+// SendRoleChanged emails the member their new role.
+func (n *SMTPNotifier) SendRoleChanged(ctx context.Context, change RoleChanged) error {
+	body := fmt.Sprintf("Your role in %s is now %s.\r\n", change.TenantName, strings.ToLower(change.Role))
+	return n.send(ctx, change.To, "Your role in "+change.TenantName+" changed", body)
+}
+
+// send writes a plain-text message to a single recipient.
+func (n *SMTPNotifier) send(ctx context.Context, to, subject, body string) error {
+	// Header values come from user input; refuse anything that could inject headers
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return fmt.Errorf("invalid mail header")
+	}
+
+	msg := "From: " + n.from + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + body
+
+	if err := n.sendMail(ctx, n.addr, n.auth, n.from, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("smtp send to %s: %w", to, err)
+	}
+	return nil
+}
+
+// sendMail is smtp.SendMail over a connection that is abandoned when ctx
+// ends or smtpTimeout passes, whichever is first. It then returns ctx's
+// error rather than the i/o error the abandoned connection reports.
+func sendMail(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	err := sendMailConn(ctx, addr, a, from, to, msg)
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// sendMailConn implements sendMail; ctx must have a deadline.
+func sendMailConn(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	// Unblock a pending read or write as soon as ctx is cancelled
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if a != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp server doesn't support AUTH")
+		}
+		if err := c.Auth(a); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+var _ Notifier = (*SMTPNotifier)(nil)
@@ -0,0 +1,133 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/config"
+)
+
+// sentMail is one message captured instead of being sent.
+type sentMail struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+	msg  string
+}
+
+func newTestNotifier(t *testing.T, cfg config.SMTPConfig) (*SMTPNotifier, *[]sentMail) {
+	t.Helper()
+	n, err := NewSMTPNotifier(cfg, "https://app.example.com/")
+	require.NoError(t, err)
+
+	var sent []sentMail
+	n.sendMail = func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		sent = append(sent, sentMail{addr: addr, auth: a, from: from, to: to, msg: string(msg)})
+		return nil
+	}
+	return n, &sent
+}
+
+func TestSMTPNotifier_SendInvite(t *testing.T) {
+	// Arrange
+	n, sent := newTestNotifier(t, config.SMTPConfig{Host: "mail.example.com", Port: 587, From: "noreply@example.com"})
+
+	// Act
+	err := n.SendInvite(context.Background(), Invite{To: "new@example.com", TenantName: "Acme", Role: "MEMBER", Token: "a+b"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, *sent, 1)
+	mail := (*sent)[0]
+	assert.Equal(t, "mail.example.com:587", mail.addr)
+	assert.Nil(t, mail.auth, "no credentials configured")
+	assert.Equal(t, "noreply@example.com", mail.from)
+	assert.Equal(t, []string{"new@example.com"}, mail.to)
+	assert.Contains(t, mail.msg, "To: new@example.com\r\n")
+	assert.Contains(t, mail.msg, "Subject: You're invited to Acme\r\n")
+	assert.Contains(t, mail.msg, "https://app.example.com/invites/accept?token=a%2Bb")
+}
+
+func TestSMTPNotifier_SendRoleChanged(t *testing.T) {
+	// Arrange
+	n, sent := newTestNotifier(t, config.SMTPConfig{Host: "mail.example.com", Port: 25, From: "noreply@example.com", Username: "user", Password: "pass"})
+
+	// Act
+	err := n.SendRoleChanged(context.Background(), RoleChanged{To: "member@example.com", TenantName: "Acme", Role: "ADMIN"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, *sent, 1)
+	assert.NotNil(t, (*sent)[0].auth)
+	assert.Equal(t, []string{"member@example.com"}, (*sent)[0].to)
+	assert.Contains(t, (*sent)[0].msg, "Your role in Acme is now admin.")
+}
+
+func TestSMTPNotifier_RejectsHeaderInjection(t *testing.T) {
+	// Arrange
+	n, sent := newTestNotifier(t, config.SMTPConfig{Host: "mail.example.com", Port: 587, From: "noreply@example.com"})
+
+	// Act
+	err := n.SendInvite(context.Background(), Invite{To: "new@example.com", TenantName: "Acme\r\nBcc: victim@example.com"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, *sent)
+}
+
+func TestSMTPNotifier_WrapsSendError(t *testing.T) {
+	// Arrange
+	n, _ := newTestNotifier(t, config.SMTPConfig{Host: "mail.example.com", Port: 587, From: "noreply@example.com"})
+	refused := fmt.Errorf("connection refused")
+	n.sendMail = func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		return refused
+	}
+
+	// Act
+	err := n.SendInvite(context.Background(), Invite{To: "new@example.com", TenantName: "Acme"})
+
+	// Assert
+	assert.ErrorIs(t, err, refused)
+}
+
+func TestNewSMTPNotifier_RequiresHostAndFrom(t *testing.T) {
+	// Act
+	_, missingHost := NewSMTPNotifier(config.SMTPConfig{From: "noreply@example.com"}, "")
+	_, missingFrom := NewSMTPNotifier(config.SMTPConfig{Host: "mail.example.com"}, "")
+
+	// Assert
+	assert.Error(t, missingHost)
+	assert.Error(t, missingFrom)
+}
+
+func TestSendMail_GivesUpWhenContextEnds(t *testing.T) {
+	// Arrange - a server that accepts connections but never greets
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			<-done
+			conn.Close()
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	start := time.Now()
+	err = sendMail(ctx, listener.Addr().String(), nil, "noreply@example.com", []string{"new@example.com"}, []byte("hi"))
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), smtpTimeout)
+}
@@ -0,0 +1,21 @@
+// Package reqid carries the ID of the request being served in a context, so
+// logs written deep in a request can be correlated with it.
+package reqid
+
+import "context"
+
+type contextKey string
+
+// requestIDKey is the context key for the request ID
+const requestIDKey contextKey = "requestID"
+
+// WithRequestID adds the request ID to context
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// FromContext returns the request ID in ctx, or "" if it has none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
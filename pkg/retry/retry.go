@@ -0,0 +1,93 @@
+// Package retry runs operations with exponential backoff and full jitter.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Policy controls how Do retries an operation.
+type Policy struct {
+	// MaxAttempts is the total number of calls, including the first.
+	// Values below 1 mean a single attempt.
+	MaxAttempts int
+
+	// BaseDelay is the backoff ceiling after the first failure; it doubles
+	// with each further failure up to MaxDelay.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff ceiling. Zero means no cap.
+	MaxDelay time.Duration
+
+	// Retryable reports whether an error is worth retrying.
+	// Nil retries every error.
+	Retryable func(error) bool
+
+	// OnRetry, if set, is called before sleeping with the attempt that
+	// failed (starting at 1), its error and the chosen delay.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// Do calls fn until it succeeds, returns a non-retryable error, or the
+// policy's attempts run out, sleeping a random delay between zero and the
+// current backoff ceiling (full jitter) between attempts. It returns the
+// last error from fn, or the context error wrapped around it if ctx ends
+// first.
+func Do(ctx context.Context, policy Policy, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	attempts := max(policy.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if attempt == attempts || (policy.Retryable != nil && !policy.Retryable(err)) {
+			return err
+		}
+
+		delay := policy.jitter(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// maxDuration is the longest representable time.Duration.
+const maxDuration = time.Duration(1<<63 - 1)
+
+// ceiling returns the backoff ceiling after the given failed attempt.
+func (p Policy) ceiling(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < maxDuration/2; i++ {
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxDelay > 0 {
+		delay = min(delay, p.MaxDelay)
+	}
+	return delay
+}
+
+// jitter picks a delay uniformly from [0, ceiling).
+func (p Policy) jitter(attempt int) time.Duration {
+	ceiling := p.ceiling(attempt)
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(ceiling)))
+}
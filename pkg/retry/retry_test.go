@@ -0,0 +1,130 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTransient = errors.New("transient")
+
+func TestDo_SucceedsAfterFailures(t *testing.T) {
+	// Arrange
+	calls := 0
+	var retried []int
+	policy := Policy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Millisecond,
+		OnRetry:     func(attempt int, err error, delay time.Duration) { retried = append(retried, attempt) },
+	}
+
+	// Act
+	err := Do(context.Background(), policy, func() error {
+		calls++
+		if calls < 3 {
+			return errTransient
+		}
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 2}, retried)
+}
+
+func TestDo_ExhaustsAttempts(t *testing.T) {
+	// Arrange
+	calls := 0
+
+	// Act
+	err := Do(context.Background(), Policy{MaxAttempts: 3, BaseDelay: time.Millisecond}, func() error {
+		calls++
+		return errTransient
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, errTransient)
+	assert.Equal(t, 3, calls)
+}
+
+func TestDo_NonRetryableShortCircuits(t *testing.T) {
+	// Arrange
+	errFatal := errors.New("fatal")
+	calls := 0
+	policy := Policy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Millisecond,
+		Retryable:   func(err error) bool { return !errors.Is(err, errFatal) },
+	}
+
+	// Act
+	err := Do(context.Background(), policy, func() error {
+		calls++
+		return errFatal
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, errFatal)
+	assert.Equal(t, 1, calls)
+}
+
+func TestDo_ContextCancelledDuringBackoff(t *testing.T) {
+	// Arrange - the first backoff would sleep far longer than the test
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	policy := Policy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Hour,
+		OnRetry:     func(int, error, time.Duration) { cancel() },
+	}
+
+	// Act
+	start := time.Now()
+	err := Do(ctx, policy, func() error {
+		calls++
+		return errTransient
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, "transient")
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDo_ContextAlreadyDone(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+
+	// Act
+	err := Do(ctx, Policy{MaxAttempts: 3}, func() error {
+		calls++
+		return nil
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, calls)
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	p := Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, p.ceiling(1))
+	assert.Equal(t, 200*time.Millisecond, p.ceiling(2))
+	assert.Equal(t, 800*time.Millisecond, p.ceiling(4))
+	assert.Equal(t, time.Second, p.ceiling(5), "capped at MaxDelay")
+	assert.Greater(t, Policy{BaseDelay: time.Second}.ceiling(100), maxDuration/2, "uncapped doubling stops before overflow")
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		delay := p.jitter(attempt)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.Less(t, delay, p.ceiling(attempt))
+	}
+}
@@ -0,0 +1,28 @@
+package validation
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// MaxEmailLength is the longest address ValidateEmail accepts (RFC 5321 path limit).
+const MaxEmailLength = 254
+
+// NormalizeEmail trims surrounding whitespace and lowercases an address.
+// "  Billing@Acme.COM " becomes "billing@acme.com".
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateEmail checks that email is a bare address such as "a@example.com".
+// Display names ("Bob <bob@example.com>") and overlong addresses are rejected.
+func ValidateEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || len(email) > MaxEmailLength ||
+		!strings.Contains(email[strings.LastIndex(email, "@")+1:], ".") {
+		return errors.NewValidationError("email", "must be a valid email address")
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "billing@acme.com", NormalizeEmail("  Billing@Acme.COM "))
+	assert.Equal(t, "", NormalizeEmail("   "))
+}
+
+func TestValidateEmail(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+		desc  string
+	}{
+		{"billing@acme.com", true, "plain address"},
+		{"first.last+tag@mail.acme.co.uk", true, "subaddress and subdomains"},
+		{"", false, "empty"},
+		{"billing", false, "missing domain"},
+		{"billing@localhost", false, "domain without a dot"},
+		{"Bob <bob@acme.com>", false, "display name"},
+		{" billing@acme.com", false, "surrounding whitespace"},
+		{strings.Repeat("a", 250) + "@acme.com", false, "too long"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateEmail(tc.input)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"unicode/utf8"
+
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// MaxTenantNameLength is the longest tenant name accepted, in characters.
+const MaxTenantNameLength = 100
+
+// ValidateTenantName checks that a normalized tenant name is non-empty and
+// at most MaxTenantNameLength characters.
+func ValidateTenantName(name string) error {
+	if name == "" {
+		return errors.NewValidationError("name", "is required")
+	}
+	if utf8.RuneCountInString(name) > MaxTenantNameLength {
+		return errors.NewValidationError("name", "must be at most 100 characters")
+	}
+	return nil
+}
+
+// MaxUserNameLength is the longest user display name accepted, in characters.
+const MaxUserNameLength = 100
+
+// ValidateUserName checks that a normalized user name is non-empty and at
+// most MaxUserNameLength characters.
+func ValidateUserName(name string) error {
+	if name == "" {
+		return errors.NewValidationError("name", "cannot be empty")
+	}
+	if utf8.RuneCountInString(name) > MaxUserNameLength {
+		return errors.NewValidationError("name", "must be at most 100 characters")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTenantName(t *testing.T) {
+	testCases := []struct {
+		name  string
+		valid bool
+		desc  string
+	}{
+		{"Acme Corp", true, "plain name"},
+		{"Café Müller", true, "non-ASCII letters"},
+		{strings.Repeat("ü", MaxTenantNameLength), true, "at the limit in characters"},
+		{strings.Repeat("a", MaxTenantNameLength+1), false, "over the limit"},
+		{"", false, "empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateTenantName(tc.name)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateUserName(t *testing.T) {
+	testCases := []struct {
+		name  string
+		valid bool
+		desc  string
+	}{
+		{"Mary Jane Watson", true, "plain name"},
+		{strings.Repeat("ü", MaxUserNameLength), true, "at the limit in characters"},
+		{strings.Repeat("a", MaxUserNameLength+1), false, "over the limit"},
+		{"", false, "empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateUserName(tc.name)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package validation
+
+import "strings"
+
+// NormalizeSpace trims leading and trailing whitespace and collapses internal
+// runs of whitespace into a single space.
+// "  Acme   Corp\t" becomes "Acme Corp".
+func NormalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// NormalizeSpacePtr applies NormalizeSpace to an optional value.
+// Returns nil when s is nil.
+func NormalizeSpacePtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	normalized := NormalizeSpace(*s)
+	return &normalized
+}
+
+// TrimPtr trims leading and trailing whitespace from an optional value.
+// Returns nil when s is nil.
+func TrimPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	return &trimmed
+}
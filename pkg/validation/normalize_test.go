@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSpace(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"acme", "acme", "already normalized"},
+		{"  acme  ", "acme", "edges trimmed"},
+		{"Acme Corp", "Acme Corp", "internal space preserved"},
+		{"  Acme \t  Corp\n", "Acme Corp", "internal runs collapsed"},
+		{"   ", "", "whitespace only"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NormalizeSpace(tc.input))
+		})
+	}
+}
+
+func TestNormalizeSpacePtr(t *testing.T) {
+	assert.Nil(t, NormalizeSpacePtr(nil))
+
+	name := "  Jane   Doe "
+	assert.Equal(t, "Jane Doe", *NormalizeSpacePtr(&name))
+}
+
+func TestTrimPtr(t *testing.T) {
+	assert.Nil(t, TrimPtr(nil))
+
+	url := " https://example.com/a b.png "
+	assert.Equal(t, "https://example.com/a b.png", *TrimPtr(&url))
+}
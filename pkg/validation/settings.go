@@ -0,0 +1,35 @@
+package validation
+
+import (
+	"regexp"
+	"time"
+
+	// Embeds the time zone database so timezones validate the same on hosts
+	// without one, such as distroless images
+	_ "time/tzdata"
+
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+var hexColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ValidateBrandingColor checks that color is a #RRGGBB hex color.
+func ValidateBrandingColor(color string) error {
+	if !hexColorRegex.MatchString(color) {
+		return errors.NewValidationError("brandingColor", "must be a color like #1A2B3C")
+	}
+	return nil
+}
+
+// ValidateTimezone checks that timezone is an IANA time zone name such as
+// "Europe/Berlin" or "UTC". "Local" is rejected since it means whatever the
+// server's zone is.
+func ValidateTimezone(timezone string) error {
+	if timezone == "" || timezone == "Local" {
+		return errors.NewValidationError("timezone", "must be an IANA time zone such as Europe/Berlin")
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return errors.NewValidationError("timezone", "must be an IANA time zone such as Europe/Berlin")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBrandingColor(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+		desc  string
+	}{
+		{"#1a2b3c", true, "lowercase"},
+		{"#1A2B3C", true, "uppercase"},
+		{"1A2B3C", false, "missing hash"},
+		{"#FFF", false, "short form"},
+		{"#1A2B3C4D", false, "with alpha"},
+		{"#GGGGGG", false, "not hex"},
+		{"red", false, "named color"},
+		{"", false, "empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateBrandingColor(tc.input)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+		desc  string
+	}{
+		{"Europe/Berlin", true, "region/city"},
+		{"America/Argentina/Buenos_Aires", true, "nested"},
+		{"UTC", true, "UTC"},
+		{"Local", false, "server zone"},
+		{"Mars/Olympus_Mons", false, "unknown zone"},
+		{"../etc/passwd", false, "path"},
+		{"", false, "empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateTimezone(tc.input)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
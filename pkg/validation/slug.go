@@ -3,6 +3,8 @@ package validation
 
 import (
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/yourusername/grgn-stack/pkg/errors"
 )
@@ -22,7 +24,79 @@ func ValidateSlug(slug string) error {
 	return nil
 }
 
+// DefaultReservedSlugs are the names of the app's own routes plus common
+// route names, which tenant slugs can't take.
+var DefaultReservedSlugs = []string{
+	"admin", "api", "auth", "graphql", "healthz", "login", "logout",
+	"metrics", "ping", "readyz", "signup", "tenants", "version",
+}
+
+// ValidateTenantSlug checks slug's format with ValidateSlug, then returns
+// ErrReservedSlug if it is one of reserved, ignoring case.
+func ValidateTenantSlug(slug string, reserved []string) error {
+	if err := ValidateSlug(slug); err != nil {
+		return err
+	}
+	if IsReservedSlug(slug, reserved) {
+		return errors.ErrReservedSlug
+	}
+	return nil
+}
+
+// IsReservedSlug returns true if slug is one of reserved, ignoring case.
+func IsReservedSlug(slug string, reserved []string) bool {
+	for _, word := range reserved {
+		if strings.EqualFold(slug, word) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsValidSlug returns true if the slug is valid
 func IsValidSlug(slug string) bool {
 	return slugRegex.MatchString(slug)
 }
+
+// MaxSlugLength is the longest slug ValidateSlug accepts.
+const MaxSlugLength = 50
+
+// slugFallback fills in slugs whose names have too few usable characters.
+const slugFallback = "tenant"
+
+// invalidSlugChars matches runs of characters not allowed in a slug.
+var invalidSlugChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// SlugifyName derives a valid slug from a display name: lowercased, with
+// spaces and other invalid characters collapsed to single hyphens, and
+// capped at MaxSlugLength. Names with fewer than 3 usable characters are
+// padded with a fallback so the result always passes ValidateSlug.
+func SlugifyName(name string) string {
+	slug := invalidSlugChars.ReplaceAllString(strings.ToLower(name), "-")
+	slug = trimSlug(slug, MaxSlugLength)
+
+	switch {
+	case slug == "":
+		return slugFallback
+	case len(slug) < 3:
+		return slug + "-" + slugFallback
+	}
+	return slug
+}
+
+// SlugWithSuffix appends "-<n>" to slug, shortening slug as needed so the
+// result stays within MaxSlugLength.
+func SlugWithSuffix(slug string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	return trimSlug(slug, MaxSlugLength-len(suffix)) + suffix
+}
+
+// trimSlug caps slug at max bytes and strips leading and trailing hyphens.
+// Slugs are ASCII, so byte slicing is safe.
+func trimSlug(slug string, max int) string {
+	slug = strings.Trim(slug, "-")
+	if len(slug) > max {
+		slug = strings.TrimRight(slug[:max], "-")
+	}
+	return slug
+}
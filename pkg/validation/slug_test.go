@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+func TestSlugifyName(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		desc     string
+	}{
+		{"Acme Corp", "acme-corp", "spaces become hyphens"},
+		{"  Acme   Corp!!  ", "acme-corp", "runs collapsed and edges trimmed"},
+		{"Ben & Jerry's", "ben-jerry-s", "punctuation replaced"},
+		{"my_team-2024", "my_team-2024", "valid characters kept"},
+		{"Café Müller", "caf-m-ller", "non-ASCII replaced"},
+		{"--Acme--", "acme", "edge hyphens stripped"},
+		{"AB", "ab-tenant", "too short is padded"},
+		{"!!!", "tenant", "nothing usable"},
+		{"", "tenant", "empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			slug := SlugifyName(tc.input)
+			assert.Equal(t, tc.expected, slug)
+			assert.True(t, IsValidSlug(slug))
+		})
+	}
+}
+
+func TestSlugifyName_CapsLength(t *testing.T) {
+	// Arrange - the 50th character is a separator
+	name := strings.Repeat("a", 49) + " tail"
+
+	// Act
+	slug := SlugifyName(name)
+
+	// Assert
+	assert.Equal(t, strings.Repeat("a", 49), slug, "trailing hyphen left by the cut is stripped")
+	assert.True(t, IsValidSlug(SlugifyName(strings.Repeat("word ", 30))))
+}
+
+func TestSlugWithSuffix(t *testing.T) {
+	assert.Equal(t, "acme-2", SlugWithSuffix("acme", 2))
+
+	long := strings.Repeat("a", MaxSlugLength)
+	slug := SlugWithSuffix(long, 12)
+	assert.Len(t, slug, MaxSlugLength)
+	assert.True(t, strings.HasSuffix(slug, "-12"))
+	assert.True(t, IsValidSlug(slug))
+}
+
+func TestValidateTenantSlug(t *testing.T) {
+	reserved := []string{"admin", "graphql"}
+	testCases := []struct {
+		slug     string
+		expected error
+		desc     string
+	}{
+		{"acme", nil, "not reserved"},
+		{"admin", errors.ErrReservedSlug, "reserved"},
+		{"GraphQL", errors.ErrReservedSlug, "reserved ignoring case"},
+		{"admin-team", nil, "containing a reserved word"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateTenantSlug(tc.slug, reserved)
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestValidateTenantSlug_ChecksFormatFirst(t *testing.T) {
+	// Act
+	err := ValidateTenantSlug("a!", []string{"a!"})
+
+	// Assert
+	var validationErr *errors.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestValidateTenantSlug_DefaultsCoverRoutes(t *testing.T) {
+	for _, slug := range []string{"admin", "api", "graphql", "login", "ping"} {
+		assert.ErrorIs(t, ValidateTenantSlug(slug, DefaultReservedSlugs), errors.ErrReservedSlug, slug)
+	}
+}
@@ -0,0 +1,23 @@
+package validation
+
+import (
+	"net/url"
+
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// MaxAvatarURLLength is the longest avatar URL ValidateAvatarURL accepts.
+const MaxAvatarURLLength = 2048
+
+// ValidateAvatarURL checks that avatarURL is an absolute http or https URL,
+// so schemes such as javascript: or data: never reach an <img> tag.
+func ValidateAvatarURL(avatarURL string) error {
+	if len(avatarURL) > MaxAvatarURLLength {
+		return errors.NewValidationError("avatarUrl", "must be at most 2048 characters")
+	}
+	u, err := url.Parse(avatarURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NewValidationError("avatarUrl", "must be an http or https URL")
+	}
+	return nil
+}
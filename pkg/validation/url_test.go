@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAvatarURL(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+		desc  string
+	}{
+		{"https://example.com/avatar.png", true, "https"},
+		{"http://example.com/avatar.png?size=64", true, "http with query"},
+		{"HTTPS://example.com/a.png", true, "scheme case ignored"},
+		{"javascript:alert(1)", false, "javascript scheme"},
+		{"data:image/png;base64,AAAA", false, "data scheme"},
+		{"ftp://example.com/a.png", false, "other scheme"},
+		{"/avatars/a.png", false, "relative"},
+		{"https:///a.png", false, "missing host"},
+		{"", false, "empty"},
+		{"https://example.com/" + strings.Repeat("a", MaxAvatarURLLength), false, "too long"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateAvatarURL(tc.input)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/identity/service"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+)
+
+// Apple's Sign in with Apple endpoints. ID tokens are issued by appleIssuer.
+const (
+	appleIssuer   = "https://appleid.apple.com"
+	appleAuthURL  = "https://appleid.apple.com/auth/authorize"
+	appleTokenURL = "https://appleid.apple.com/auth/token"
+	appleKeysURL  = "https://appleid.apple.com/auth/keys"
+)
+
+const (
+	// AppleProvider names Apple identities linked to users.
+	AppleProvider = "apple"
+
+	// appleStateCookie carries the signed state between login and callback.
+	appleStateCookie = "grgn_apple_oauth_state"
+	// appleClientSecretTTL is how long each client secret is valid. Apple
+	// allows up to six months; one is signed for every code exchange.
+	appleClientSecretTTL = 5 * time.Minute
+)
+
+// AppleOAuthHandler signs users in with Apple using the authorization code
+// flow and hands the frontend an access token.
+type AppleOAuthHandler struct {
+	clientID    string
+	teamID      string
+	keyID       string
+	signingKey  *ecdsa.PrivateKey
+	redirectURL string
+
+	state  *oauthState
+	signIn *providerSignIn
+	keys   *jwksKeySet
+
+	client   *http.Client
+	authURL  string
+	tokenURL string
+	now      func() time.Time
+}
+
+// NewAppleOAuthHandler creates an AppleOAuthHandler from the auth config.
+// epochs supplies the token epoch new access tokens are issued at.
+// Returns an error if a setting is missing or AppleClientSecret is not an
+// EC private key in PEM form.
+func NewAppleOAuthHandler(cfg *config.Config, users IProviderSignIn, tokens *auth.TokenManager, epochs auth.TokenEpochSource) (*AppleOAuthHandler, error) {
+	switch {
+	case cfg.Auth.AppleClientID == "" || cfg.Auth.AppleClientSecret == "":
+		return nil, fmt.Errorf("apple client ID and private key are required")
+	case cfg.Auth.AppleTeamID == "" || cfg.Auth.AppleKeyID == "":
+		return nil, fmt.Errorf("apple team ID and key ID are required")
+	case cfg.Auth.AppleRedirectURL == "":
+		return nil, fmt.Errorf("apple redirect URL is required")
+	case cfg.Auth.SessionSecret == "":
+		return nil, fmt.Errorf("session secret is required to sign OAuth state")
+	}
+
+	// Environment variables can't easily hold newlines, so allow \n escapes
+	pem := strings.ReplaceAll(cfg.Auth.AppleClientSecret, `\n`, "\n")
+	signingKey, err := jwt.ParseECPrivateKeyFromPEM([]byte(pem))
+	if err != nil {
+		return nil, fmt.Errorf("parse apple private key: %w", err)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	return &AppleOAuthHandler{
+		clientID:    cfg.Auth.AppleClientID,
+		teamID:      cfg.Auth.AppleTeamID,
+		keyID:       cfg.Auth.AppleKeyID,
+		signingKey:  signingKey,
+		redirectURL: cfg.Auth.AppleRedirectURL,
+		// Apple posts the callback from its own site, and browsers only send
+		// SameSite=None cookies, which must be Secure, on cross-site POSTs
+		state: &oauthState{
+			secret:   []byte(cfg.Auth.SessionSecret),
+			cookie:   appleStateCookie,
+			path:     "/auth/apple",
+			sameSite: http.SameSiteNoneMode,
+			secure:   true,
+			now:      time.Now,
+		},
+		signIn: &providerSignIn{
+			users:       users,
+			tokens:      tokens,
+			epochs:      epochs,
+			frontendURL: strings.TrimRight(cfg.App.FrontendURL, "/"),
+		},
+		keys:     newJWKSKeySet(appleKeysURL, client),
+		client:   client,
+		authURL:  appleAuthURL,
+		tokenURL: appleTokenURL,
+		now:      time.Now,
+	}, nil
+}
+
+// WithRefreshTokens makes sign-in hand the frontend a refresh token along
+// with the access token.
+func (h *AppleOAuthHandler) WithRefreshTokens(tokens service.ITokenService) *AppleOAuthHandler {
+	h.signIn.refreshTokens = tokens
+	return h
+}
+
+// HandleLogin redirects to Apple's consent screen, asking for the user's
+// name and email. Apple posts the result back to the callback as a form.
+func (h *AppleOAuthHandler) HandleLogin(c *gin.Context) {
+	state, err := h.state.issue(c)
+	if err != nil {
+		shared.RespondError(c, err)
+		return
+	}
+
+	query := url.Values{
+		"client_id":     {h.clientID},
+		"redirect_uri":  {h.redirectURL},
+		"response_type": {"code"},
+		"response_mode": {"form_post"},
+		"scope":         {"name email"},
+		"state":         {state},
+	}
+	c.Redirect(http.StatusFound, h.authURL+"?"+query.Encode())
+}
+
+// HandleCallback completes a sign-in: it checks the state, exchanges the
+// code for an ID token, verifies it against Apple's keys and signs the user
+// in. Apple sends the user's name only on their first authorization, in
+// the user form field, and it is passed on only when present.
+func (h *AppleOAuthHandler) HandleCallback(c *gin.Context) {
+	stateValid := h.state.consume(c, c.PostForm("state"))
+
+	if reason := c.PostForm("error"); reason != "" {
+		shared.RespondError(c, errors.NewCodedError(errors.CodeUnauthenticated, "Apple sign-in failed: "+reason, nil))
+		return
+	}
+	if !stateValid {
+		shared.RespondError(c, errors.NewCodedError(errors.CodeUnauthenticated, "sign-in state is invalid or expired; start again", nil))
+		return
+	}
+	code := c.PostForm("code")
+	if code == "" {
+		shared.RespondError(c, errors.NewValidationError("code", "is required"))
+		return
+	}
+	name, err := appleUserName(c.PostForm("user"))
+	if err != nil {
+		shared.RespondError(c, err)
+		return
+	}
+
+	identity, err := h.fetchIdentity(c.Request.Context(), code)
+	if err != nil {
+		shared.RespondError(c, err)
+		return
+	}
+	identity.Name = name
+
+	h.signIn.complete(c, identity)
+}
+
+// appleUser is the user form field Apple sends on first authorization.
+type appleUser struct {
+	Name struct {
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+	} `json:"name"`
+}
+
+// appleUserName returns the full name in the user form field, or nil if
+// the field is empty or names no one.
+func appleUserName(field string) (*string, error) {
+	if field == "" {
+		return nil, nil
+	}
+
+	var user appleUser
+	if err := json.Unmarshal([]byte(field), &user); err != nil {
+		return nil, errors.NewValidationError("user", "must be the JSON Apple sends")
+	}
+	name := strings.TrimSpace(user.Name.FirstName + " " + user.Name.LastName)
+	if name == "" {
+		return nil, nil
+	}
+	return &name, nil
+}
+
+// appleIDClaims are the ID token claims we use. Apple has sent
+// email_verified both as a boolean and as the string "true".
+type appleIDClaims struct {
+	Email         string          `json:"email"`
+	EmailVerified json.RawMessage `json:"email_verified"`
+	jwt.RegisteredClaims
+}
+
+// fetchIdentity exchanges an authorization code for an ID token and
+// returns the identity it verifiably asserts.
+func (h *AppleOAuthHandler) fetchIdentity(ctx context.Context, code string) (service.ProviderIdentity, error) {
+	secret, err := h.clientSecret()
+	if err != nil {
+		return service.ProviderIdentity{}, err
+	}
+
+	form := url.Values{
+		"code":          {code},
+		"client_id":     {h.clientID},
+		"client_secret": {secret},
+		"redirect_uri":  {h.redirectURL},
+		"grant_type":    {"authorization_code"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return service.ProviderIdentity{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := doJSON(h.client, req, &token); err != nil {
+		return service.ProviderIdentity{}, fmt.Errorf("apple token exchange: %w", err)
+	}
+
+	claims := &appleIDClaims{}
+	_, err = jwt.ParseWithClaims(token.IDToken, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return h.keys.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(appleIssuer),
+		jwt.WithAudience(h.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(h.now),
+	)
+	if err != nil || claims.Subject == "" {
+		return service.ProviderIdentity{}, errors.NewCodedError(errors.CodeUnauthenticated, "Apple ID token is invalid", err)
+	}
+
+	verified := string(claims.EmailVerified)
+	return service.ProviderIdentity{
+		Provider:      AppleProvider,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: verified == "true" || verified == `"true"`,
+	}, nil
+}
+
+// clientSecret signs the short-lived ES256 JWT Apple accepts in place of
+// a static client secret.
+func (h *AppleOAuthHandler) clientSecret() (string, error) {
+	now := h.now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.RegisteredClaims{
+		Issuer:    h.teamID,
+		Subject:   h.clientID,
+		Audience:  jwt.ClaimStrings{appleIssuer},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appleClientSecretTTL)),
+	})
+	token.Header["kid"] = h.keyID
+
+	secret, err := token.SignedString(h.signingKey)
+	if err != nil {
+		return "", fmt.Errorf("sign apple client secret: %w", err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,403 @@
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/identity/repository"
+	"github.com/yourusername/grgn-stack/services/core/identity/service"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+const (
+	testAppleClientID = "com.example.web"
+	testAppleTeamID   = "TEAM123"
+	testAppleKeyID    = "KEY123"
+)
+
+var (
+	appleKeysOnce sync.Once
+	appleRSAKey   *rsa.PrivateKey
+	otherRSAKey   *rsa.PrivateKey
+)
+
+// appleSigningKeys returns the key fake Apple signs ID tokens with and one
+// it doesn't publish, generated once as RSA keys are slow to make.
+func appleSigningKeys(t *testing.T) (*rsa.PrivateKey, *rsa.PrivateKey) {
+	appleKeysOnce.Do(func() {
+		appleRSAKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+		otherRSAKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	})
+	require.NotNil(t, appleRSAKey)
+	return appleRSAKey, otherRSAKey
+}
+
+// fakeApple serves Apple's token and key endpoints. It accepts only the
+// code "good-code" with a client secret signed by clientKey, and issues ID
+// tokens for subject signed by signWith.
+type fakeApple struct {
+	clientKey *ecdsa.PublicKey
+	published *rsa.PrivateKey
+	signWith  *rsa.PrivateKey
+	audience  string
+	subject   string
+	email     string
+}
+
+func (f *fakeApple) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/token":
+		_ = r.ParseForm()
+		if !f.validClientSecret(r.PostForm.Get("client_secret")) {
+			http.Error(w, `{"error":"invalid_client"}`, http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("code") != "good-code" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":            appleIssuer,
+			"aud":            f.audience,
+			"sub":            f.subject,
+			"email":          f.email,
+			"email_verified": "true",
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(10 * time.Minute).Unix(),
+		})
+		idToken.Header["kid"] = "apple-key-1"
+		signed, _ := idToken.SignedString(f.signWith)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": signed})
+	case "/keys":
+		pub := f.published.PublicKey
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "apple-key-1",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// validClientSecret reports whether secret is a client secret Apple would
+// accept for the test app.
+func (f *fakeApple) validClientSecret(secret string) bool {
+	claims := &jwt.RegisteredClaims{}
+	token, err := jwt.ParseWithClaims(secret, claims, func(*jwt.Token) (any, error) {
+		return f.clientKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}),
+		jwt.WithIssuer(testAppleTeamID),
+		jwt.WithSubject(testAppleClientID),
+		jwt.WithAudience(appleIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	return err == nil && token.Header["kid"] == testAppleKeyID
+}
+
+// applePrivateKeyPEM returns key as the PEM a .p8 file holds.
+func applePrivateKeyPEM(t *testing.T, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func appleConfig(privateKey string) *config.Config {
+	return &config.Config{
+		Auth: config.AuthConfig{
+			AppleClientID:     testAppleClientID,
+			AppleClientSecret: privateKey,
+			AppleTeamID:       testAppleTeamID,
+			AppleKeyID:        testAppleKeyID,
+			AppleRedirectURL:  "https://api.test/auth/apple/callback",
+			SessionSecret:     "session-secret",
+		},
+		App: config.AppConfig{FrontendURL: "https://app.test"},
+	}
+}
+
+type appleTest struct {
+	router *gin.Engine
+	apple  *fakeApple
+	users  *repository.MockUserRepository
+}
+
+// setupAppleOAuth serves an AppleOAuthHandler wired to a fake Apple that
+// signs in jane@example.com.
+func setupAppleOAuth(t *testing.T) *appleTest {
+	gin.SetMode(gin.TestMode)
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	published, _ := appleSigningKeys(t)
+	apple := &fakeApple{
+		clientKey: &clientKey.PublicKey,
+		published: published,
+		signWith:  published,
+		audience:  testAppleClientID,
+		subject:   "apple-001",
+		email:     "jane@example.com",
+	}
+	server := httptest.NewServer(apple)
+	t.Cleanup(server.Close)
+
+	users := repository.NewMockUserRepository()
+	tokens := auth.NewTokenManager("jwt-secret", time.Hour, users)
+	h, err := NewAppleOAuthHandler(appleConfig(applePrivateKeyPEM(t, clientKey)), service.NewUserService(users), tokens, users)
+	require.NoError(t, err)
+	h.tokenURL = server.URL + "/token"
+	h.keys = newJWKSKeySet(server.URL+"/keys", server.Client())
+
+	r := gin.New()
+	r.GET("/auth/apple/login", h.HandleLogin)
+	r.POST("/auth/apple/callback", h.HandleCallback)
+	return &appleTest{router: r, apple: apple, users: users}
+}
+
+// login starts a sign-in and returns the state sent to Apple and the
+// state cookie set on the browser.
+func (a *appleTest) login(t *testing.T) (string, *http.Cookie) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/auth/apple/login", nil)
+	a.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusFound, w.Code)
+
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	return location.Query().Get("state"), cookies[0]
+}
+
+// callback posts form to the callback endpoint as Apple does, with cookie.
+func (a *appleTest) callback(form url.Values, cookie *http.Cookie) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/auth/apple/callback", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	a.router.ServeHTTP(w, req)
+	return w
+}
+
+// signIn runs a whole sign-in, passing user as Apple's user field if set.
+func (a *appleTest) signIn(t *testing.T, user string) *httptest.ResponseRecorder {
+	state, cookie := a.login(t)
+	form := url.Values{"code": {"good-code"}, "state": {state}}
+	if user != "" {
+		form.Set("user", user)
+	}
+	return a.callback(form, cookie)
+}
+
+const janeAppleseed = `{"name":{"firstName":"Jane","lastName":"Appleseed"},"email":"jane@example.com"}`
+
+func TestAppleOAuth_Login_RedirectsToApple(t *testing.T) {
+	// Arrange
+	a := setupAppleOAuth(t)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/auth/apple/login", nil)
+	a.router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusFound, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "appleid.apple.com", location.Host)
+	assert.Equal(t, testAppleClientID, location.Query().Get("client_id"))
+	assert.Equal(t, "form_post", location.Query().Get("response_mode"))
+	assert.Equal(t, "name email", location.Query().Get("scope"))
+	assert.NotEmpty(t, location.Query().Get("state"))
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, appleStateCookie, cookies[0].Name)
+	assert.Equal(t, http.SameSiteNoneMode, cookies[0].SameSite, "sent on Apple's cross-site POST")
+	assert.True(t, cookies[0].Secure)
+}
+
+func TestAppleOAuth_Callback_FirstSignInSavesName(t *testing.T) {
+	// Arrange
+	a := setupAppleOAuth(t)
+
+	// Act
+	w := a.signIn(t, janeAppleseed)
+
+	// Assert
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	assert.True(t, strings.HasPrefix(w.Header().Get("Location"), "https://app.test/auth/callback#token="))
+	user, err := a.users.FindByProvider(context.Background(), AppleProvider, "apple-001")
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", user.Email)
+	require.NotNil(t, user.Name)
+	assert.Equal(t, "Jane Appleseed", *user.Name)
+}
+
+func TestAppleOAuth_Callback_LaterSignInKeepsName(t *testing.T) {
+	// Arrange - Apple sends the name only the first time; the user then renames themselves
+	a := setupAppleOAuth(t)
+	require.Equal(t, http.StatusFound, a.signIn(t, janeAppleseed).Code)
+	user, err := a.users.FindByProvider(context.Background(), AppleProvider, "apple-001")
+	require.NoError(t, err)
+	renamed := "J. Appleseed"
+	_, err = a.users.Update(context.Background(), user.ID, model.UpdateProfileInput{Name: &renamed})
+	require.NoError(t, err)
+
+	// Act
+	w := a.signIn(t, "")
+
+	// Assert
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	user, err = a.users.FindByID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, user.Name)
+	assert.Equal(t, "J. Appleseed", *user.Name)
+	assert.Len(t, a.users.GetUsers(), 1)
+}
+
+func TestAppleOAuth_Callback_NoNameLeavesNameUnset(t *testing.T) {
+	// Arrange
+	a := setupAppleOAuth(t)
+
+	// Act
+	w := a.signIn(t, "")
+
+	// Assert
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	user, err := a.users.FindByProvider(context.Background(), AppleProvider, "apple-001")
+	require.NoError(t, err)
+	assert.Nil(t, user.Name)
+}
+
+func TestAppleOAuth_Callback_Rejected(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		form       func(state string) url.Values
+		tamper     func(f *fakeApple)
+		wantStatus int
+	}{
+		{desc: "state mismatch", form: func(string) url.Values {
+			return url.Values{"code": {"good-code"}, "state": {"forged"}}
+		}, wantStatus: http.StatusUnauthorized},
+		{desc: "code rejected by token endpoint", form: func(state string) url.Values {
+			return url.Values{"code": {"expired-code"}, "state": {state}}
+		}, wantStatus: http.StatusUnauthorized},
+		{desc: "ID token for another app", tamper: func(f *fakeApple) {
+			f.audience = "com.example.other"
+		}, wantStatus: http.StatusUnauthorized},
+		{desc: "ID token not signed by Apple", tamper: func(f *fakeApple) {
+			_, f.signWith = appleSigningKeys(t)
+		}, wantStatus: http.StatusUnauthorized},
+		{desc: "malformed user field", form: func(state string) url.Values {
+			return url.Values{"code": {"good-code"}, "state": {state}, "user": {"{"}}
+		}, wantStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			a := setupAppleOAuth(t)
+			if tc.tamper != nil {
+				tc.tamper(a.apple)
+			}
+			state, cookie := a.login(t)
+			form := url.Values{"code": {"good-code"}, "state": {state}}
+			if tc.form != nil {
+				form = tc.form(state)
+			}
+
+			// Act
+			w := a.callback(form, cookie)
+
+			// Assert
+			assert.Equal(t, tc.wantStatus, w.Code)
+			assert.Empty(t, a.users.GetUsers(), "no user is created")
+		})
+	}
+}
+
+func TestAppleOAuth_Callback_EmailUsedByAnotherProvider(t *testing.T) {
+	// Arrange
+	a := setupAppleOAuth(t)
+	a.users.AddUser(&model.User{ID: "user-1", Email: "jane@example.com", Status: model.UserStatusActive})
+	require.NoError(t, a.users.LinkProvider(context.Background(), "user-1", GoogleProvider, "google-1"))
+
+	// Act
+	w := a.signIn(t, janeAppleseed)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, errors.CodeConflict, errorCode(t, w))
+}
+
+func TestNewAppleOAuthHandler_PrivateKey(t *testing.T) {
+	// Arrange
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	escaped := strings.ReplaceAll(applePrivateKeyPEM(t, key), "\n", `\n`)
+
+	// Act
+	h, err := NewAppleOAuthHandler(appleConfig(escaped), nil, nil, nil)
+	_, invalidErr := NewAppleOAuthHandler(appleConfig("not-a-key"), nil, nil, nil)
+
+	// Assert
+	require.NoError(t, err, "newlines may be escaped")
+	assert.True(t, h.signingKey.Equal(key))
+	assert.ErrorContains(t, invalidErr, "parse apple private key")
+}
+
+func TestJWKSKeySet_RefetchesRotatedKeys(t *testing.T) {
+	// Arrange - the set first publishes only "old", then only "new"
+	key, _ := appleSigningKeys(t)
+	kid := "old"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(server.Close)
+	keys := newJWKSKeySet(server.URL, server.Client())
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+	_, err := keys.key(context.Background(), "old")
+	require.NoError(t, err)
+	kid = "new"
+
+	// Act
+	_, soonErr := keys.key(context.Background(), "new")
+	now = now.Add(jwksRefetchInterval)
+	rotated, laterErr := keys.key(context.Background(), "new")
+
+	// Assert
+	assert.ErrorContains(t, soonErr, "unknown signing key", "refetches are rate limited")
+	require.NoError(t, laterErr)
+	assert.Equal(t, key.N, rotated.N)
+}
@@ -0,0 +1,202 @@
+// Package controller provides HTTP handlers for the identity domain.
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/identity/service"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+)
+
+// Google's OAuth 2.0 and OpenID Connect endpoints.
+const (
+	googleAuthURL     = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenURL    = "https://oauth2.googleapis.com/token"
+	googleUserInfoURL = "https://openidconnect.googleapis.com/v1/userinfo"
+)
+
+const (
+	// GoogleProvider names Google identities linked to users.
+	GoogleProvider = "google"
+
+	// googleStateCookie carries the signed state between login and callback.
+	googleStateCookie = "grgn_oauth_state"
+)
+
+// GoogleOAuthHandler signs users in with Google using the authorization code
+// flow and hands the frontend an access token.
+type GoogleOAuthHandler struct {
+	clientID     string
+	clientSecret string
+	redirectURL  string
+
+	state  *oauthState
+	signIn *providerSignIn
+
+	client      *http.Client
+	authURL     string
+	tokenURL    string
+	userInfoURL string
+}
+
+// NewGoogleOAuthHandler creates a GoogleOAuthHandler from the auth config.
+// epochs supplies the token epoch new access tokens are issued at.
+// Returns an error if the client credentials, redirect URL or session
+// secret are missing.
+func NewGoogleOAuthHandler(cfg *config.Config, users IProviderSignIn, tokens *auth.TokenManager, epochs auth.TokenEpochSource) (*GoogleOAuthHandler, error) {
+	switch {
+	case cfg.Auth.GoogleClientID == "" || cfg.Auth.GoogleClientSecret == "":
+		return nil, fmt.Errorf("google client ID and secret are required")
+	case cfg.Auth.GoogleRedirectURL == "":
+		return nil, fmt.Errorf("google redirect URL is required")
+	case cfg.Auth.SessionSecret == "":
+		return nil, fmt.Errorf("session secret is required to sign OAuth state")
+	}
+
+	return &GoogleOAuthHandler{
+		clientID:     cfg.Auth.GoogleClientID,
+		clientSecret: cfg.Auth.GoogleClientSecret,
+		redirectURL:  cfg.Auth.GoogleRedirectURL,
+		state: &oauthState{
+			secret:   []byte(cfg.Auth.SessionSecret),
+			cookie:   googleStateCookie,
+			path:     "/auth/google",
+			sameSite: http.SameSiteLaxMode,
+			secure:   cfg.IsProduction(),
+			now:      time.Now,
+		},
+		signIn: &providerSignIn{
+			users:       users,
+			tokens:      tokens,
+			epochs:      epochs,
+			frontendURL: strings.TrimRight(cfg.App.FrontendURL, "/"),
+		},
+		client:      &http.Client{Timeout: 10 * time.Second},
+		authURL:     googleAuthURL,
+		tokenURL:    googleTokenURL,
+		userInfoURL: googleUserInfoURL,
+	}, nil
+}
+
+// WithRefreshTokens makes sign-in hand the frontend a refresh token along
+// with the access token.
+func (h *GoogleOAuthHandler) WithRefreshTokens(tokens service.ITokenService) *GoogleOAuthHandler {
+	h.signIn.refreshTokens = tokens
+	return h
+}
+
+// HandleLogin redirects to Google's consent screen. A random state is sent
+// along and kept in a short-lived signed cookie, so the callback can tell
+// that it completes a sign-in this browser started.
+func (h *GoogleOAuthHandler) HandleLogin(c *gin.Context) {
+	state, err := h.state.issue(c)
+	if err != nil {
+		shared.RespondError(c, err)
+		return
+	}
+
+	query := url.Values{
+		"client_id":     {h.clientID},
+		"redirect_uri":  {h.redirectURL},
+		"response_type": {"code"},
+		"scope":         {"openid email profile"},
+		"state":         {state},
+	}
+	c.Redirect(http.StatusFound, h.authURL+"?"+query.Encode())
+}
+
+// HandleCallback completes a sign-in: it checks the state, exchanges the
+// code for Google's profile of the user, signs them in as the matching user
+// and redirects to the frontend's /auth/callback with the access token in
+// the URL fragment, which browsers don't send to servers.
+func (h *GoogleOAuthHandler) HandleCallback(c *gin.Context) {
+	stateValid := h.state.consume(c, c.Query("state"))
+
+	if reason := c.Query("error"); reason != "" {
+		shared.RespondError(c, errors.NewCodedError(errors.CodeUnauthenticated, "Google sign-in failed: "+reason, nil))
+		return
+	}
+	if !stateValid {
+		shared.RespondError(c, errors.NewCodedError(errors.CodeUnauthenticated, "sign-in state is invalid or expired; start again", nil))
+		return
+	}
+	code := c.Query("code")
+	if code == "" {
+		shared.RespondError(c, errors.NewValidationError("code", "is required"))
+		return
+	}
+
+	identity, err := h.fetchIdentity(c.Request.Context(), code)
+	if err != nil {
+		shared.RespondError(c, err)
+		return
+	}
+
+	h.signIn.complete(c, identity)
+}
+
+// googleUserInfo is the subset of Google's OpenID Connect profile we use.
+type googleUserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+// fetchIdentity exchanges an authorization code for an access token and
+// reads the user's profile with it.
+func (h *GoogleOAuthHandler) fetchIdentity(ctx context.Context, code string) (service.ProviderIdentity, error) {
+	form := url.Values{
+		"code":          {code},
+		"client_id":     {h.clientID},
+		"client_secret": {h.clientSecret},
+		"redirect_uri":  {h.redirectURL},
+		"grant_type":    {"authorization_code"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return service.ProviderIdentity{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := doJSON(h.client, req, &token); err != nil {
+		return service.ProviderIdentity{}, fmt.Errorf("google token exchange: %w", err)
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, h.userInfoURL, nil)
+	if err != nil {
+		return service.ProviderIdentity{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	var info googleUserInfo
+	if err := doJSON(h.client, req, &info); err != nil {
+		return service.ProviderIdentity{}, fmt.Errorf("google userinfo: %w", err)
+	}
+	if info.Subject == "" || info.Email == "" {
+		return service.ProviderIdentity{}, fmt.Errorf("google userinfo: missing sub or email")
+	}
+
+	identity := service.ProviderIdentity{
+		Provider:      GoogleProvider,
+		Subject:       info.Subject,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+	}
+	if info.Name != "" {
+		identity.Name = &info.Name
+	}
+	return identity, nil
+}
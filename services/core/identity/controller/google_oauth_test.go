@@ -0,0 +1,304 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/identity/repository"
+	"github.com/yourusername/grgn-stack/services/core/identity/service"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// fakeGoogle serves Google's token and userinfo endpoints, accepting only
+// the code "good-code" and answering with profile.
+type fakeGoogle struct {
+	profile googleUserInfo
+	form    url.Values
+}
+
+func (f *fakeGoogle) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/token":
+		_ = r.ParseForm()
+		f.form = r.PostForm
+		if r.PostForm.Get("code") != "good-code" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "google-access-token"})
+	case "/userinfo":
+		if r.Header.Get("Authorization") != "Bearer google-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(f.profile)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+type oauthTest struct {
+	router  *gin.Engine
+	handler *GoogleOAuthHandler
+	google  *fakeGoogle
+	users   *repository.MockUserRepository
+	tokens  *auth.TokenManager
+}
+
+// setupGoogleOAuth serves a GoogleOAuthHandler wired to a fake Google that
+// reports a verified alice@example.com.
+func setupGoogleOAuth(t *testing.T) *oauthTest {
+	gin.SetMode(gin.TestMode)
+	google := &fakeGoogle{profile: googleUserInfo{
+		Subject: "google-1", Email: "alice@example.com", EmailVerified: true, Name: "Alice",
+	}}
+	server := httptest.NewServer(google)
+	t.Cleanup(server.Close)
+
+	users := repository.NewMockUserRepository()
+	tokens := auth.NewTokenManager("jwt-secret", time.Hour, users)
+	cfg := &config.Config{
+		Auth: config.AuthConfig{
+			GoogleClientID:     "client-id",
+			GoogleClientSecret: "client-secret",
+			GoogleRedirectURL:  "http://api.test/auth/google/callback",
+			SessionSecret:      "session-secret",
+		},
+		App: config.AppConfig{FrontendURL: "http://app.test/"},
+	}
+	h, err := NewGoogleOAuthHandler(cfg, service.NewUserService(users), tokens, users)
+	require.NoError(t, err)
+	h.tokenURL = server.URL + "/token"
+	h.userInfoURL = server.URL + "/userinfo"
+
+	r := gin.New()
+	r.GET("/auth/google/login", h.HandleLogin)
+	r.GET("/auth/google/callback", h.HandleCallback)
+	return &oauthTest{router: r, handler: h, google: google, users: users, tokens: tokens}
+}
+
+// login starts a sign-in and returns the state sent to Google and the
+// state cookie set on the browser.
+func (o *oauthTest) login(t *testing.T) (string, *http.Cookie) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/auth/google/login", nil)
+	o.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusFound, w.Code)
+
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	return location.Query().Get("state"), cookies[0]
+}
+
+// callback calls the callback endpoint with query and, if set, cookie.
+func (o *oauthTest) callback(query url.Values, cookie *http.Cookie) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/auth/google/callback?"+query.Encode(), nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	o.router.ServeHTTP(w, req)
+	return w
+}
+
+// errorCode decodes the code from a JSON error response.
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	var body shared.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body.Error.Code
+}
+
+func TestGoogleOAuth_Login_RedirectsToGoogle(t *testing.T) {
+	// Arrange
+	o := setupGoogleOAuth(t)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/auth/google/login", nil)
+	o.router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusFound, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "accounts.google.com", location.Host)
+	assert.Equal(t, "client-id", location.Query().Get("client_id"))
+	assert.Equal(t, "http://api.test/auth/google/callback", location.Query().Get("redirect_uri"))
+	assert.Equal(t, "code", location.Query().Get("response_type"))
+	assert.NotEmpty(t, location.Query().Get("state"))
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, googleStateCookie, cookies[0].Name)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.NotContains(t, cookies[0].Value, location.Query().Get("state"), "the cookie is signed, not the bare state")
+}
+
+func TestGoogleOAuth_Callback_CreatesUserAndIssuesToken(t *testing.T) {
+	// Arrange
+	o := setupGoogleOAuth(t)
+	state, cookie := o.login(t)
+
+	// Act
+	w := o.callback(url.Values{"code": {"good-code"}, "state": {state}}, cookie)
+
+	// Assert
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	location := w.Header().Get("Location")
+	require.True(t, strings.HasPrefix(location, "http://app.test/auth/callback#token="), location)
+	token, err := url.QueryUnescape(strings.TrimPrefix(location, "http://app.test/auth/callback#token="))
+	require.NoError(t, err)
+
+	user, err := o.users.FindByProvider(context.Background(), GoogleProvider, "google-1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", user.Email)
+	require.NotNil(t, user.Name)
+	assert.Equal(t, "Alice", *user.Name)
+
+	claims, err := o.tokens.ParseToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, claims.Subject)
+
+	assert.Equal(t, "authorization_code", o.google.form.Get("grant_type"))
+	assert.Equal(t, "client-secret", o.google.form.Get("client_secret"))
+}
+
+func TestGoogleOAuth_Callback_ReturningUserSignsInAgain(t *testing.T) {
+	// Arrange
+	o := setupGoogleOAuth(t)
+	state, cookie := o.login(t)
+	require.Equal(t, http.StatusFound, o.callback(url.Values{"code": {"good-code"}, "state": {state}}, cookie).Code)
+	first, err := o.users.FindByProvider(context.Background(), GoogleProvider, "google-1")
+	require.NoError(t, err)
+	o.google.profile.Email = "alice@new.example.com"
+	state, cookie = o.login(t)
+
+	// Act
+	w := o.callback(url.Values{"code": {"good-code"}, "state": {state}}, cookie)
+
+	// Assert
+	require.Equal(t, http.StatusFound, w.Code)
+	assert.Len(t, o.users.GetUsers(), 1, "the Google identity, not the email, picks the user")
+	second, err := o.users.FindByProvider(context.Background(), GoogleProvider, "google-1")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+}
+
+func TestGoogleOAuth_Callback_EmailUsedByAnotherProvider(t *testing.T) {
+	// Arrange
+	o := setupGoogleOAuth(t)
+	o.users.AddUser(&model.User{ID: "user-1", Email: "alice@example.com", Status: model.UserStatusActive})
+	require.NoError(t, o.users.LinkProvider(context.Background(), "user-1", "apple", "apple-1"))
+	state, cookie := o.login(t)
+
+	// Act
+	w := o.callback(url.Values{"code": {"good-code"}, "state": {state}}, cookie)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, errors.CodeConflict, errorCode(t, w))
+	_, err := o.users.FindByProvider(context.Background(), GoogleProvider, "google-1")
+	assert.ErrorIs(t, err, errors.ErrUserNotFound, "the Google identity is not linked")
+}
+
+func TestGoogleOAuth_Callback_Rejected(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		query      func(state string) url.Values
+		noCookie   bool
+		unverified bool
+		wantStatus int
+	}{
+		{desc: "state mismatch", query: func(string) url.Values {
+			return url.Values{"code": {"good-code"}, "state": {"forged"}}
+		}, wantStatus: http.StatusUnauthorized},
+		{desc: "missing state cookie", query: func(state string) url.Values {
+			return url.Values{"code": {"good-code"}, "state": {state}}
+		}, noCookie: true, wantStatus: http.StatusUnauthorized},
+		{desc: "user denied consent", query: func(state string) url.Values {
+			return url.Values{"error": {"access_denied"}, "state": {state}}
+		}, wantStatus: http.StatusUnauthorized},
+		{desc: "code rejected by token endpoint", query: func(state string) url.Values {
+			return url.Values{"code": {"expired-code"}, "state": {state}}
+		}, wantStatus: http.StatusUnauthorized},
+		{desc: "missing code", query: func(state string) url.Values {
+			return url.Values{"state": {state}}
+		}, wantStatus: http.StatusUnprocessableEntity},
+		{desc: "unverified email", query: func(state string) url.Values {
+			return url.Values{"code": {"good-code"}, "state": {state}}
+		}, unverified: true, wantStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			o := setupGoogleOAuth(t)
+			o.google.profile.EmailVerified = !tc.unverified
+			state, cookie := o.login(t)
+			if tc.noCookie {
+				cookie = nil
+			}
+
+			// Act
+			w := o.callback(tc.query(state), cookie)
+
+			// Assert
+			assert.Equal(t, tc.wantStatus, w.Code)
+			assert.Empty(t, o.users.GetUsers(), "no user is created")
+		})
+	}
+}
+
+func TestGoogleOAuth_Callback_ExpiredState(t *testing.T) {
+	// Arrange
+	o := setupGoogleOAuth(t)
+	state, cookie := o.login(t)
+	o.handler.state.now = func() time.Time { return time.Now().Add(oauthStateTTL + time.Minute) }
+
+	// Act
+	w := o.callback(url.Values{"code": {"good-code"}, "state": {state}}, cookie)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, o.users.GetUsers())
+}
+
+func TestNewGoogleOAuthHandler_RequiresSettings(t *testing.T) {
+	// Arrange
+	complete := config.AuthConfig{
+		GoogleClientID: "client-id", GoogleClientSecret: "client-secret",
+		GoogleRedirectURL: "http://api.test/auth/google/callback", SessionSecret: "session-secret",
+	}
+	missing := []func(a *config.AuthConfig){
+		func(a *config.AuthConfig) { a.GoogleClientSecret = "" },
+		func(a *config.AuthConfig) { a.GoogleRedirectURL = "" },
+		func(a *config.AuthConfig) { a.SessionSecret = "" },
+	}
+
+	for _, unset := range missing {
+		authCfg := complete
+		unset(&authCfg)
+
+		// Act
+		h, err := NewGoogleOAuthHandler(&config.Config{Auth: authCfg}, nil, nil, nil)
+
+		// Assert
+		assert.Nil(t, h)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// jwksRefetchInterval limits how often an unknown key ID refetches the key
+// set, so forged tokens can't make us hammer the provider.
+const jwksRefetchInterval = time.Minute
+
+// jwksKeySet caches the RSA signing keys a provider publishes as a JSON Web
+// Key Set. Providers rotate keys, so an unknown key ID refetches the set.
+type jwksKeySet struct {
+	url    string
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// newJWKSKeySet creates a jwksKeySet that fetches the set at url.
+func newJWKSKeySet(url string, client *http.Client) *jwksKeySet {
+	return &jwksKeySet{url: url, client: client, now: time.Now}
+}
+
+// key returns the key with ID kid, fetching the set if it is not cached.
+func (s *jwksKeySet) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	if s.keys != nil && s.now().Sub(s.fetchedAt) < jwksRefetchInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.keys, s.fetchedAt = keys, s.now()
+
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// fetch downloads the key set, skipping keys that aren't RSA.
+func (s *jwksKeySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := doJSON(s.client, req, &set); err != nil {
+		return nil, fmt.Errorf("fetch signing keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %q: invalid modulus", k.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %q: invalid exponent", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/identity/service"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+const (
+	// oauthStateTTL bounds how long a user may take to sign in with a provider.
+	oauthStateTTL = 10 * time.Minute
+	// oauthStateAudience keeps state cookies from being used as other tokens.
+	oauthStateAudience = "oauth-state"
+)
+
+// IProviderSignIn resolves external identities to users.
+// Satisfied by the user service.
+type IProviderSignIn interface {
+	SignInWithProvider(ctx context.Context, identity service.ProviderIdentity) (*model.User, error)
+}
+
+// oauthState issues and checks the state that ties a provider's callback to
+// the sign-in this browser started. The state is sent to the provider and
+// kept in a short-lived cookie signed with the session secret.
+type oauthState struct {
+	secret   []byte
+	cookie   string
+	path     string
+	sameSite http.SameSite
+	secure   bool
+	now      func() time.Time
+}
+
+// issue generates a state and sets the cookie holding it.
+func (s *oauthState) issue(c *gin.Context) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate oauth state: %w", err)
+	}
+	state := base64.RawURLEncoding.EncodeToString(b)
+
+	now := s.now()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ID:        state,
+		Audience:  jwt.ClaimStrings{oauthStateAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(oauthStateTTL)),
+	}).SignedString(s.secret)
+	if err != nil {
+		return "", fmt.Errorf("sign oauth state: %w", err)
+	}
+
+	c.SetSameSite(s.sameSite)
+	c.SetCookie(s.cookie, signed, int(oauthStateTTL.Seconds()), s.path, "", s.secure, true)
+	return state, nil
+}
+
+// consume clears the state cookie and reports whether it held state, so
+// each state completes at most one sign-in.
+func (s *oauthState) consume(c *gin.Context, state string) bool {
+	cookie, _ := c.Cookie(s.cookie)
+	c.SetSameSite(s.sameSite)
+	c.SetCookie(s.cookie, "", -1, s.path, "", s.secure, true)
+	return s.valid(cookie, state)
+}
+
+// valid reports whether cookie is an unexpired state cookie for state.
+func (s *oauthState) valid(cookie, state string) bool {
+	if cookie == "" || state == "" {
+		return false
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(cookie, claims, func(*jwt.Token) (any, error) {
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(oauthStateAudience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now),
+	)
+	return err == nil && subtle.ConstantTimeCompare([]byte(claims.ID), []byte(state)) == 1
+}
+
+// providerSignIn signs provider identities in as users and hands the
+// frontend an access token for them, along with a refresh token when
+// refreshTokens is set.
+type providerSignIn struct {
+	users         IProviderSignIn
+	tokens        *auth.TokenManager
+	epochs        auth.TokenEpochSource
+	refreshTokens service.ITokenService
+	frontendURL   string
+}
+
+// complete signs identity in and redirects to the frontend's /auth/callback
+// with the tokens in the URL fragment, which browsers don't send to
+// servers.
+func (p *providerSignIn) complete(c *gin.Context, identity service.ProviderIdentity) {
+	ctx := c.Request.Context()
+	user, err := p.users.SignInWithProvider(ctx, identity)
+	if err != nil {
+		shared.RespondError(c, err)
+		return
+	}
+
+	if p.refreshTokens != nil {
+		pair, err := p.refreshTokens.IssueTokens(ctx, user.ID)
+		if err != nil {
+			shared.RespondError(c, err)
+			return
+		}
+		fragment := url.Values{"token": {pair.AccessToken}, "refreshToken": {pair.RefreshToken}}
+		c.Redirect(http.StatusFound, p.frontendURL+"/auth/callback#"+fragment.Encode())
+		return
+	}
+
+	epoch, err := p.epochs.GetTokenEpoch(ctx, user.ID)
+	if err != nil {
+		shared.RespondError(c, err)
+		return
+	}
+	token, err := p.tokens.IssueSessionToken(ctx, user.ID, epoch)
+	if err != nil {
+		shared.RespondError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, p.frontendURL+"/auth/callback#token="+url.QueryEscape(token))
+}
+
+// doJSON sends req and decodes a 200 response into v. A 400 or 401 means
+// the provider rejected the code or token, which usually means the user
+// took too long or replayed the callback, so it is reported as
+// unauthenticated.
+func doJSON(client *http.Client, req *http.Request, v any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized:
+		return errors.NewCodedError(errors.CodeUnauthenticated, "the sign-in provider rejected the sign-in; start again", nil)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/identity/service"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+)
+
+// RefreshRequest is the body of refresh and logout requests.
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// TokenHandler exchanges refresh tokens for new access tokens and revokes
+// them on logout. The refresh token in the body is the credential, so the
+// routes don't need a bearer token.
+type TokenHandler struct {
+	tokens service.ITokenService
+}
+
+// NewTokenHandler creates a new TokenHandler.
+func NewTokenHandler(tokens service.ITokenService) *TokenHandler {
+	return &TokenHandler{tokens: tokens}
+}
+
+// HandleRefresh responds with a new TokenPair for the refresh token in the
+// body, which stops working.
+func (h *TokenHandler) HandleRefresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		shared.RespondError(c, errors.NewCodedError(errors.CodeInvalidInput, "invalid refresh request", err))
+		return
+	}
+
+	pair, err := h.tokens.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		shared.RespondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pair)
+}
+
+// HandleLogout revokes the refresh token in the body. Access tokens
+// already issued stay valid until they expire.
+func (h *TokenHandler) HandleLogout(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		shared.RespondError(c, errors.NewCodedError(errors.CodeInvalidInput, "invalid logout request", err))
+		return
+	}
+
+	if err := h.tokens.Revoke(c.Request.Context(), req.RefreshToken); err != nil {
+		shared.RespondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
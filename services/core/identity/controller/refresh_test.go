@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/identity/repository"
+	"github.com/yourusername/grgn-stack/services/core/identity/service"
+	"github.com/yourusername/grgn-stack/services/core/shared/testutil"
+)
+
+// setupTokenHandler serves a TokenHandler backed by mock repositories
+// holding the active user user-123.
+func setupTokenHandler(t *testing.T) (*gin.Engine, *service.TokenService, *auth.TokenManager) {
+	gin.SetMode(gin.TestMode)
+	users := repository.NewMockUserRepository()
+	testutil.AddActiveUser(users, "user-123")
+	tokens := auth.NewTokenManager("jwt-secret", time.Hour, users)
+	svc := service.NewTokenService(users, repository.NewMockRefreshTokenRepository(), tokens, time.Hour)
+
+	h := NewTokenHandler(svc)
+	r := gin.New()
+	r.POST("/auth/refresh", h.HandleRefresh)
+	r.POST("/auth/logout", h.HandleLogout)
+	return r, svc, tokens
+}
+
+// postToken posts refreshToken to path.
+func postToken(r *gin.Engine, path, refreshToken string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(RefreshRequest{RefreshToken: refreshToken})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestTokenHandler_Refresh(t *testing.T) {
+	// Arrange
+	r, svc, tokens := setupTokenHandler(t)
+	pair, err := svc.IssueTokens(context.Background(), "user-123")
+	require.NoError(t, err)
+
+	// Act
+	w := postToken(r, "/auth/refresh", pair.RefreshToken)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var refreshed service.TokenPair
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &refreshed))
+	claims, err := tokens.ParseToken(context.Background(), refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.Subject)
+	assert.NotEqual(t, pair.RefreshToken, refreshed.RefreshToken)
+
+	reused := postToken(r, "/auth/refresh", pair.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, reused.Code)
+	assert.Equal(t, errors.CodeUnauthenticated, errorCode(t, reused))
+}
+
+func TestTokenHandler_LogoutRevokesRefreshToken(t *testing.T) {
+	// Arrange
+	r, svc, _ := setupTokenHandler(t)
+	pair, err := svc.IssueTokens(context.Background(), "user-123")
+	require.NoError(t, err)
+
+	// Act
+	w := postToken(r, "/auth/logout", pair.RefreshToken)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, http.StatusUnauthorized, postToken(r, "/auth/refresh", pair.RefreshToken).Code)
+}
+
+func TestGoogleOAuth_Callback_IssuesRefreshToken(t *testing.T) {
+	// Arrange
+	o := setupGoogleOAuth(t)
+	refreshTokens := service.NewTokenService(o.users, repository.NewMockRefreshTokenRepository(), o.tokens, time.Hour)
+	o.handler.WithRefreshTokens(refreshTokens)
+	state, cookie := o.login(t)
+
+	// Act
+	w := o.callback(url.Values{"code": {"good-code"}, "state": {state}}, cookie)
+
+	// Assert
+	require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+	location := w.Header().Get("Location")
+	require.True(t, strings.HasPrefix(location, "http://app.test/auth/callback#"), location)
+	fragment, err := url.ParseQuery(strings.TrimPrefix(location, "http://app.test/auth/callback#"))
+	require.NoError(t, err)
+
+	_, err = o.tokens.ParseToken(context.Background(), fragment.Get("token"))
+	require.NoError(t, err)
+	_, err = refreshTokens.Refresh(context.Background(), fragment.Get("refreshToken"))
+	assert.NoError(t, err)
+}
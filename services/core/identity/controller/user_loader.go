@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/grgn-stack/services/core/identity/repository"
+	"github.com/yourusername/grgn-stack/services/core/identity/service"
+)
+
+// RequestUserLoader gives each request its own UserLoader, so the user
+// lookups its resolvers make are batched together and nothing is cached
+// across requests.
+func RequestUserLoader(users repository.IUserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := service.WithUserLoader(c.Request.Context(), service.NewUserLoader(users))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
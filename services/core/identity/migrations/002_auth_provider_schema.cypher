@@ -0,0 +1,11 @@
+// ============================================
+// Migration: core/identity/002_auth_provider_schema
+// Description: External sign-in identities linked to users
+// (:User)-[:AUTHENTICATED_BY]->(:AuthProvider {provider, subject})
+// ============================================
+
+// ----- CONSTRAINTS -----
+
+// Each provider identity belongs to at most one node, and so one user
+CREATE CONSTRAINT auth_provider_identity_unique IF NOT EXISTS
+FOR (p:AuthProvider) REQUIRE (p.provider, p.subject) IS UNIQUE;
@@ -0,0 +1,11 @@
+// ============================================
+// Migration: core/identity/003_email_lower_index
+// Description: Index the lowercased email used for case-insensitive lookups
+// ============================================
+
+// ----- CONSTRAINTS -----
+
+// Emails differing only in case belong to one user; the constraint also
+// backs the index lookups on emailLower use
+CREATE CONSTRAINT user_email_lower_unique IF NOT EXISTS
+FOR (u:User) REQUIRE u.emailLower IS UNIQUE;
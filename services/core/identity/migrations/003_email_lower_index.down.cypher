@@ -0,0 +1,5 @@
+// ============================================
+// Rollback: core/identity/003_email_lower_index
+// ============================================
+
+DROP CONSTRAINT user_email_lower_unique IF EXISTS;
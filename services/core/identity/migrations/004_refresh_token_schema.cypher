@@ -0,0 +1,20 @@
+// ============================================
+// Migration: core/identity/004_refresh_token_schema
+// Description: Refresh tokens that mint new access tokens for a user
+// (:User)-[:HAS_REFRESH_TOKEN]->(:RefreshToken {id, tokenHash, expiresAt})
+// ============================================
+
+// ----- CONSTRAINTS -----
+
+CREATE CONSTRAINT refresh_token_id_unique IF NOT EXISTS
+FOR (t:RefreshToken) REQUIRE t.id IS UNIQUE;
+
+// Tokens are looked up by the hash of the value the client holds
+CREATE CONSTRAINT refresh_token_hash_unique IF NOT EXISTS
+FOR (t:RefreshToken) REQUIRE t.tokenHash IS UNIQUE;
+
+// ----- INDEXES -----
+
+// Cleanup deletes expired tokens in batches
+CREATE INDEX refresh_token_expires_at IF NOT EXISTS
+FOR (t:RefreshToken) ON (t.expiresAt);
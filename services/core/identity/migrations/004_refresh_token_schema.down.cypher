@@ -0,0 +1,7 @@
+// ============================================
+// Rollback: core/identity/004_refresh_token_schema
+// ============================================
+
+DROP INDEX refresh_token_expires_at IF EXISTS;
+DROP CONSTRAINT refresh_token_hash_unique IF EXISTS;
+DROP CONSTRAINT refresh_token_id_unique IF EXISTS;
@@ -3,7 +3,9 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 )
 
@@ -13,6 +15,11 @@ type IUserRepository interface {
 	// Returns ErrUserNotFound if the user doesn't exist or is deleted.
 	FindByID(ctx context.Context, id string) (*model.User, error)
 
+	// FindByIDs retrieves the users with the given IDs in one query, in no
+	// particular order. IDs of users that don't exist or are deleted are
+	// skipped.
+	FindByIDs(ctx context.Context, ids []string) ([]*model.User, error)
+
 	// FindByEmail retrieves a user by their email address.
 	// Returns ErrUserNotFound if the user doesn't exist or is deleted.
 	FindByEmail(ctx context.Context, email string) (*model.User, error)
@@ -21,17 +28,123 @@ type IUserRepository interface {
 	// Returns ErrEmailTaken if the email already exists.
 	Create(ctx context.Context, user *model.User) (*model.User, error)
 
-	// Update updates an existing user's profile.
+	// FindOrCreateByEmail returns the user with the given email, creating one
+	// with only the email and status set if none exists. created reports
+	// whether the user was created.
+	// Returns ErrEmailTaken if the email belongs to a deleted user.
+	FindOrCreateByEmail(ctx context.Context, email string, status model.UserStatus) (user *model.User, created bool, err error)
+
+	// Update updates an existing user's profile. Nil fields are left unchanged;
+	// ClearAvatar removes the avatar.
 	// Returns ErrUserNotFound if the user doesn't exist.
 	Update(ctx context.Context, id string, input model.UpdateProfileInput) (*model.User, error)
 
-	// Delete soft-deletes a user by setting their status to DELETED.
+	// Activate marks a PENDING user, such as the placeholder created for an
+	// invited email, ACTIVE once they sign up. Other users are returned
+	// unchanged.
+	// Returns ErrUserNotFound if the user doesn't exist or is deleted.
+	Activate(ctx context.Context, id string) (*model.User, error)
+
+	// Delete soft-deletes a user by setting their status to DELETED and
+	// recording who deleted them and, optionally, why.
 	// Returns ErrUserNotFound if the user doesn't exist.
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id, deletedBy string, reason *string) error
+
+	// DeleteTx soft-deletes a user in tx, as part of a unit of work.
+	// Returns ErrUserNotFound if the user doesn't exist.
+	DeleteTx(ctx context.Context, tx neo4j.ManagedTransaction, id, deletedBy string, reason *string) error
+
+	// FindDeletion retrieves the deletion details of a deleted user.
+	// Returns ErrUserNotFound if the user doesn't exist or isn't deleted.
+	FindDeletion(ctx context.Context, id string) (*model.DeletionInfo, error)
+
+	// Restore reverses a soft delete, making the user ACTIVE again.
+	// Returns ErrUserNotFound if the user doesn't exist, ErrNotDeleted if
+	// they aren't deleted, and ErrEmailTaken if another user who isn't
+	// deleted has their email.
+	Restore(ctx context.Context, id string) (*model.User, error)
 
-	// List retrieves users with pagination.
+	// List retrieves users with pagination, newest first.
 	List(ctx context.Context, limit, offset int) ([]*model.User, error)
 
+	// ListWithCount retrieves a page like List along with the total number
+	// of users that aren't deleted, read in the same transaction.
+	ListWithCount(ctx context.Context, limit, offset int) (users []*model.User, total int, err error)
+
+	// ListAfter retrieves up to limit users that come after afterID in
+	// List's order, or the first page when afterID is empty. Unlike offsets,
+	// the cursor stays stable as users are created or deleted.
+	// Returns ErrUserNotFound if afterID doesn't exist.
+	ListAfter(ctx context.Context, afterID string, limit int) ([]*model.User, error)
+
+	// FindByProvider retrieves the user linked to an external identity, such
+	// as a Google account, by provider name and the provider's subject ID.
+	// Returns ErrUserNotFound if no user is linked or the user is deleted.
+	FindByProvider(ctx context.Context, provider, subject string) (*model.User, error)
+
+	// FindProviders returns the names of the providers a user has linked
+	// identities from, e.g. "google", sorted.
+	FindProviders(ctx context.Context, userID string) ([]string, error)
+
+	// LinkProvider links an external identity to a user. Linking an identity
+	// the user already has is a no-op.
+	// Returns ErrUserNotFound if the user doesn't exist or is deleted, and
+	// ErrProviderMismatch if the identity is linked to another user.
+	LinkProvider(ctx context.Context, userID, provider, subject string) error
+
 	// ExistsByEmail checks if a user with the given email exists.
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+
+	// GetTokenEpoch returns the user's current token epoch.
+	// Tokens issued at a lower epoch are revoked.
+	// Returns ErrUserNotFound if the user doesn't exist or is deleted.
+	GetTokenEpoch(ctx context.Context, userID string) (int, error)
+
+	// IncrementTokenEpoch bumps the user's token epoch and returns the new value.
+	// Returns ErrUserNotFound if the user doesn't exist or is deleted.
+	IncrementTokenEpoch(ctx context.Context, userID string) (int, error)
+
+	// GetRoleEpoch returns the user's current role epoch.
+	// Role claims issued at a lower epoch are no longer trusted.
+	// Returns ErrUserNotFound if the user doesn't exist or is deleted.
+	GetRoleEpoch(ctx context.Context, userID string) (int, error)
+
+	// IncrementRoleEpoch bumps the user's role epoch and returns the new value.
+	// Returns ErrUserNotFound if the user doesn't exist or is deleted.
+	IncrementRoleEpoch(ctx context.Context, userID string) (int, error)
+}
+
+// RefreshToken is a stored refresh token. Only the hash of the value the
+// client holds is stored.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// IRefreshTokenRepository defines the contract for refresh token storage.
+// Tokens are identified by the hash of their value.
+type IRefreshTokenRepository interface {
+	// Create stores a refresh token for a user.
+	// Returns ErrUserNotFound if the user doesn't exist or is deleted.
+	Create(ctx context.Context, userID, tokenHash string, expiresAt time.Time) (*RefreshToken, error)
+
+	// Consume deletes a refresh token and returns it, so each token can be
+	// exchanged at most once. Expired tokens are returned too; checking
+	// expiry is the caller's job.
+	// Returns ErrInvalidToken if no token has the hash.
+	Consume(ctx context.Context, tokenHash string) (*RefreshToken, error)
+
+	// Delete revokes a refresh token. Deleting a missing token is a no-op.
+	Delete(ctx context.Context, tokenHash string) error
+
+	// RevokeAllForUser deletes every refresh token of a user and returns
+	// how many were deleted.
+	RevokeAllForUser(ctx context.Context, userID string) (int, error)
+
+	// DeleteExpired deletes up to limit tokens that expired before the
+	// given time and returns how many were deleted. Small batches keep
+	// each transaction short, so cleanup can run alongside live traffic.
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error)
 }
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// MockRefreshTokenRepository is a mock implementation of
+// IRefreshTokenRepository for testing.
+type MockRefreshTokenRepository struct {
+	mu sync.Mutex
+	// tokens maps token hashes to the stored tokens
+	tokens map[string]*RefreshToken
+
+	// Function overrides for testing specific behaviors
+	CreateFunc  func(ctx context.Context, userID, tokenHash string, expiresAt time.Time) (*RefreshToken, error)
+	ConsumeFunc func(ctx context.Context, tokenHash string) (*RefreshToken, error)
+
+	// Users, when set, is checked by Create the way the Neo4j
+	// implementation requires the user to exist
+	Users *MockUserRepository
+}
+
+// NewMockRefreshTokenRepository creates a new MockRefreshTokenRepository.
+func NewMockRefreshTokenRepository() *MockRefreshTokenRepository {
+	return &MockRefreshTokenRepository{tokens: make(map[string]*RefreshToken)}
+}
+
+// Tokens returns the stored tokens of a user.
+func (m *MockRefreshTokenRepository) Tokens(userID string) []*RefreshToken {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var tokens []*RefreshToken
+	for _, token := range m.tokens {
+		if token.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// Create stores a refresh token for a user.
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, userID, tokenHash string, expiresAt time.Time) (*RefreshToken, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, userID, tokenHash, expiresAt)
+	}
+	if m.Users != nil {
+		if _, err := m.Users.FindByID(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token := &RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+	m.tokens[tokenHash] = token
+	return token, nil
+}
+
+// Consume deletes a refresh token and returns it.
+func (m *MockRefreshTokenRepository) Consume(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	if m.ConsumeFunc != nil {
+		return m.ConsumeFunc(ctx, tokenHash)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.tokens[tokenHash]
+	if !ok {
+		return nil, errors.ErrInvalidToken
+	}
+	delete(m.tokens, tokenHash)
+	return token, nil
+}
+
+// Delete revokes a refresh token.
+func (m *MockRefreshTokenRepository) Delete(ctx context.Context, tokenHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.tokens, tokenHash)
+	return nil
+}
+
+// RevokeAllForUser deletes every refresh token of a user.
+func (m *MockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := 0
+	for hash, token := range m.tokens {
+		if token.UserID == userID {
+			delete(m.tokens, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteExpired deletes up to limit tokens that expired before the given time.
+func (m *MockRefreshTokenRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := 0
+	for hash, token := range m.tokens {
+		if deleted == limit {
+			break
+		}
+		if token.ExpiresAt.Before(before) {
+			delete(m.tokens, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// Ensure MockRefreshTokenRepository implements IRefreshTokenRepository
+var _ IRefreshTokenRepository = (*MockRefreshTokenRepository)(nil)
@@ -2,33 +2,55 @@ package repository
 
 import (
 	"context"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/yourusername/grgn-stack/pkg/errors"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 )
 
 // MockUserRepository is a mock implementation of IUserRepository for testing.
 type MockUserRepository struct {
-	mu    sync.RWMutex
-	users map[string]*model.User
+	mu          sync.RWMutex
+	users       map[string]*model.User
+	tokenEpochs map[string]int
+	roleEpochs  map[string]int
+	deletions   map[string]*model.DeletionInfo
+	// providers maps "provider:subject" identities to the linked user ID
+	providers map[string]string
 
 	// Function overrides for testing specific behaviors
-	FindByIDFunc      func(ctx context.Context, id string) (*model.User, error)
-	FindByEmailFunc   func(ctx context.Context, email string) (*model.User, error)
-	CreateFunc        func(ctx context.Context, user *model.User) (*model.User, error)
-	UpdateFunc        func(ctx context.Context, id string, input model.UpdateProfileInput) (*model.User, error)
-	DeleteFunc        func(ctx context.Context, id string) error
-	ListFunc          func(ctx context.Context, limit, offset int) ([]*model.User, error)
-	ExistsByEmailFunc func(ctx context.Context, email string) (bool, error)
+	FindByIDFunc            func(ctx context.Context, id string) (*model.User, error)
+	FindByIDsFunc           func(ctx context.Context, ids []string) ([]*model.User, error)
+	FindByEmailFunc         func(ctx context.Context, email string) (*model.User, error)
+	CreateFunc              func(ctx context.Context, user *model.User) (*model.User, error)
+	FindOrCreateByEmailFunc func(ctx context.Context, email string, status model.UserStatus) (*model.User, bool, error)
+	UpdateFunc              func(ctx context.Context, id string, input model.UpdateProfileInput) (*model.User, error)
+	DeleteFunc              func(ctx context.Context, id, deletedBy string, reason *string) error
+	DeleteTxFunc            func(ctx context.Context, tx neo4j.ManagedTransaction, id, deletedBy string, reason *string) error
+	RestoreFunc             func(ctx context.Context, id string) (*model.User, error)
+	ListFunc                func(ctx context.Context, limit, offset int) ([]*model.User, error)
+	ListWithCountFunc       func(ctx context.Context, limit, offset int) ([]*model.User, int, error)
+	ListAfterFunc           func(ctx context.Context, afterID string, limit int) ([]*model.User, error)
+	ExistsByEmailFunc       func(ctx context.Context, email string) (bool, error)
+	LinkProviderFunc        func(ctx context.Context, userID, provider, subject string) error
+	GetTokenEpochFunc       func(ctx context.Context, userID string) (int, error)
 }
 
 // NewMockUserRepository creates a new MockUserRepository.
 func NewMockUserRepository() *MockUserRepository {
 	return &MockUserRepository{
-		users: make(map[string]*model.User),
+		users:       make(map[string]*model.User),
+		tokenEpochs: make(map[string]int),
+		roleEpochs:  make(map[string]int),
+		deletions:   make(map[string]*model.DeletionInfo),
+		providers:   make(map[string]string),
 	}
 }
 
@@ -51,6 +73,10 @@ func (m *MockUserRepository) Reset() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.users = make(map[string]*model.User)
+	m.tokenEpochs = make(map[string]int)
+	m.roleEpochs = make(map[string]int)
+	m.deletions = make(map[string]*model.DeletionInfo)
+	m.providers = make(map[string]string)
 }
 
 // FindByID retrieves a user by ID.
@@ -69,6 +95,25 @@ func (m *MockUserRepository) FindByID(ctx context.Context, id string) (*model.Us
 	return user, nil
 }
 
+// FindByIDs retrieves the users with the given IDs, skipping missing and
+// deleted ones.
+func (m *MockUserRepository) FindByIDs(ctx context.Context, ids []string) ([]*model.User, error) {
+	if m.FindByIDsFunc != nil {
+		return m.FindByIDsFunc(ctx, ids)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	users := []*model.User{}
+	for _, id := range ids {
+		if user, ok := m.users[id]; ok && user.Status != model.UserStatusDeleted {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
 // FindByEmail retrieves a user by email.
 func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
 	if m.FindByEmailFunc != nil {
@@ -119,6 +164,41 @@ func (m *MockUserRepository) Create(ctx context.Context, user *model.User) (*mod
 	return user, nil
 }
 
+// FindOrCreateByEmail returns the user with the given email, creating one if absent.
+func (m *MockUserRepository) FindOrCreateByEmail(ctx context.Context, email string, status model.UserStatus) (*model.User, bool, error) {
+	if m.FindOrCreateByEmailFunc != nil {
+		return m.FindOrCreateByEmailFunc(ctx, email, status)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := false
+	for _, existing := range m.users {
+		if existing.Email != email {
+			continue
+		}
+		if existing.Status != model.UserStatusDeleted {
+			return existing, false, nil
+		}
+		deleted = true
+	}
+	if deleted {
+		return nil, false, errors.ErrEmailTaken
+	}
+
+	now := time.Now()
+	user := &model.User{
+		ID:        uuid.New().String(),
+		Email:     email,
+		Status:    status,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	m.users[user.ID] = user
+	return user, true, nil
+}
+
 // Update updates a user's profile.
 func (m *MockUserRepository) Update(ctx context.Context, id string, input model.UpdateProfileInput) (*model.User, error) {
 	if m.UpdateFunc != nil {
@@ -139,15 +219,34 @@ func (m *MockUserRepository) Update(ctx context.Context, id string, input model.
 	if input.AvatarURL != nil {
 		user.AvatarURL = input.AvatarURL
 	}
+	if input.ClearAvatar != nil && *input.ClearAvatar {
+		user.AvatarURL = nil
+	}
 	user.UpdatedAt = time.Now()
 
 	return user, nil
 }
 
+// Activate marks a PENDING user ACTIVE.
+func (m *MockUserRepository) Activate(ctx context.Context, id string) (*model.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok || user.Status == model.UserStatusDeleted {
+		return nil, errors.ErrUserNotFound
+	}
+	if user.Status == model.UserStatusPending {
+		user.Status = model.UserStatusActive
+		user.UpdatedAt = time.Now()
+	}
+	return user, nil
+}
+
 // Delete soft-deletes a user.
-func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+func (m *MockUserRepository) Delete(ctx context.Context, id, deletedBy string, reason *string) error {
 	if m.DeleteFunc != nil {
-		return m.DeleteFunc(ctx, id)
+		return m.DeleteFunc(ctx, id, deletedBy, reason)
 	}
 
 	m.mu.Lock()
@@ -160,9 +259,80 @@ func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
 
 	user.Status = model.UserStatusDeleted
 	user.UpdatedAt = time.Now()
+	m.deletions[id] = &model.DeletionInfo{DeletedAt: user.UpdatedAt, DeletedBy: &deletedBy, Reason: reason}
 	return nil
 }
 
+// DeleteTx soft-deletes a user in tx. A MockUnitOfWork restores the user
+// if tx rolls back.
+func (m *MockUserRepository) DeleteTx(ctx context.Context, tx neo4j.ManagedTransaction, id, deletedBy string, reason *string) error {
+	if m.DeleteTxFunc != nil {
+		return m.DeleteTxFunc(ctx, tx, id, deletedBy, reason)
+	}
+
+	m.mu.Lock()
+	user, ok := m.users[id]
+	var previous model.User
+	if ok {
+		previous = *user
+	}
+	m.mu.Unlock()
+
+	if err := m.Delete(ctx, id, deletedBy, reason); err != nil {
+		return err
+	}
+	shared.TrackMockChange(tx, func() {
+		m.mu.Lock()
+		*user = previous
+		delete(m.deletions, id)
+		m.mu.Unlock()
+	}, nil)
+	return nil
+}
+
+// FindDeletion retrieves the deletion details of a deleted user.
+func (m *MockUserRepository) FindDeletion(ctx context.Context, id string) (*model.DeletionInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[id]
+	if !ok || user.Status != model.UserStatusDeleted {
+		return nil, errors.ErrUserNotFound
+	}
+	if info, ok := m.deletions[id]; ok {
+		return info, nil
+	}
+	return &model.DeletionInfo{DeletedAt: user.UpdatedAt}, nil
+}
+
+// Restore reverses a soft delete, making the user ACTIVE again.
+func (m *MockUserRepository) Restore(ctx context.Context, id string) (*model.User, error) {
+	if m.RestoreFunc != nil {
+		return m.RestoreFunc(ctx, id)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.ErrUserNotFound
+	}
+	if user.Status != model.UserStatusDeleted {
+		return nil, errors.ErrNotDeleted
+	}
+	for _, other := range m.users {
+		if other.ID != id && other.Email == user.Email && other.Status != model.UserStatusDeleted {
+			return nil, errors.ErrEmailTaken
+		}
+	}
+
+	user.Status = model.UserStatusActive
+	user.UpdatedAt = time.Now()
+	delete(m.deletions, id)
+	return user, nil
+}
+
 // List retrieves users with pagination.
 func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*model.User, error) {
 	if m.ListFunc != nil {
@@ -172,6 +342,49 @@ func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*mo
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	return page(m.sortedUsersLocked(), offset, limit), nil
+}
+
+// ListWithCount retrieves a page of users and the total count.
+func (m *MockUserRepository) ListWithCount(ctx context.Context, limit, offset int) ([]*model.User, int, error) {
+	if m.ListWithCountFunc != nil {
+		return m.ListWithCountFunc(ctx, limit, offset)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	users := m.sortedUsersLocked()
+	return page(users, offset, limit), len(users), nil
+}
+
+// ListAfter retrieves the page of users following afterID.
+func (m *MockUserRepository) ListAfter(ctx context.Context, afterID string, limit int) ([]*model.User, error) {
+	if m.ListAfterFunc != nil {
+		return m.ListAfterFunc(ctx, afterID, limit)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	users := m.sortedUsersLocked()
+	if afterID == "" {
+		return page(users, 0, limit), nil
+	}
+
+	after, ok := m.users[afterID]
+	if !ok {
+		return nil, errors.ErrUserNotFound
+	}
+	start := sort.Search(len(users), func(i int) bool {
+		return listsBefore(after, users[i])
+	})
+	return page(users, start, limit), nil
+}
+
+// sortedUsersLocked returns the users that aren't deleted, sorted like the
+// real repository's ORDER BY so pages are deterministic.
+func (m *MockUserRepository) sortedUsersLocked() []*model.User {
 	var users []*model.User
 	for _, user := range m.users {
 		if user.Status != model.UserStatusDeleted {
@@ -179,18 +392,81 @@ func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*mo
 		}
 	}
 
-	// Apply pagination
-	start := offset
-	if start > len(users) {
-		return []*model.User{}, nil
+	sort.Slice(users, func(i, j int) bool {
+		return listsBefore(users[i], users[j])
+	})
+	return users
+}
+
+// listsBefore reports whether a comes before b: newest first, ties broken by ID.
+func listsBefore(a, b *model.User) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+
+// page returns up to limit users starting at offset.
+func page(users []*model.User, offset, limit int) []*model.User {
+	if offset >= len(users) {
+		return []*model.User{}
 	}
 
-	end := start + limit
+	end := offset + limit
 	if end > len(users) {
 		end = len(users)
 	}
 
-	return users[start:end], nil
+	return users[offset:end]
+}
+
+// FindByProvider retrieves the user linked to an external identity.
+func (m *MockUserRepository) FindByProvider(ctx context.Context, provider, subject string) (*model.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[m.providers[provider+":"+subject]]
+	if !ok || user.Status == model.UserStatusDeleted {
+		return nil, errors.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// FindProviders returns the providers a user has linked identities from.
+func (m *MockUserRepository) FindProviders(ctx context.Context, userID string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	providers := []string{}
+	for identity, linkedID := range m.providers {
+		provider, _, _ := strings.Cut(identity, ":")
+		if linkedID == userID && !slices.Contains(providers, provider) {
+			providers = append(providers, provider)
+		}
+	}
+	sort.Strings(providers)
+	return providers, nil
+}
+
+// LinkProvider links an external identity to a user.
+func (m *MockUserRepository) LinkProvider(ctx context.Context, userID, provider, subject string) error {
+	if m.LinkProviderFunc != nil {
+		return m.LinkProviderFunc(ctx, userID, provider, subject)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok || user.Status == model.UserStatusDeleted {
+		return errors.ErrUserNotFound
+	}
+	identity := provider + ":" + subject
+	if linkedID, ok := m.providers[identity]; ok && linkedID != userID {
+		return errors.ErrProviderMismatch
+	}
+	m.providers[identity] = userID
+	return nil
 }
 
 // ExistsByEmail checks if a user with the given email exists.
@@ -210,5 +486,59 @@ func (m *MockUserRepository) ExistsByEmail(ctx context.Context, email string) (b
 	return false, nil
 }
 
+// GetTokenEpoch returns the user's current token epoch.
+func (m *MockUserRepository) GetTokenEpoch(ctx context.Context, userID string) (int, error) {
+	if m.GetTokenEpochFunc != nil {
+		return m.GetTokenEpochFunc(ctx, userID)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[userID]
+	if !ok || user.Status == model.UserStatusDeleted {
+		return 0, errors.ErrUserNotFound
+	}
+	return m.tokenEpochs[userID], nil
+}
+
+// IncrementTokenEpoch bumps the user's token epoch and returns the new value.
+func (m *MockUserRepository) IncrementTokenEpoch(ctx context.Context, userID string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok || user.Status == model.UserStatusDeleted {
+		return 0, errors.ErrUserNotFound
+	}
+	m.tokenEpochs[userID]++
+	return m.tokenEpochs[userID], nil
+}
+
+// GetRoleEpoch returns the user's current role epoch.
+func (m *MockUserRepository) GetRoleEpoch(ctx context.Context, userID string) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[userID]
+	if !ok || user.Status == model.UserStatusDeleted {
+		return 0, errors.ErrUserNotFound
+	}
+	return m.roleEpochs[userID], nil
+}
+
+// IncrementRoleEpoch bumps the user's role epoch and returns the new value.
+func (m *MockUserRepository) IncrementRoleEpoch(ctx context.Context, userID string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok || user.Status == model.UserStatusDeleted {
+		return 0, errors.ErrUserNotFound
+	}
+	m.roleEpochs[userID]++
+	return m.roleEpochs[userID], nil
+}
+
 // Ensure MockUserRepository implements IUserRepository
 var _ IUserRepository = (*MockUserRepository)(nil)
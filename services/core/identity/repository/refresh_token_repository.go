@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+)
+
+// RefreshTokenRepository implements IRefreshTokenRepository using Neo4j.
+type RefreshTokenRepository struct {
+	db shared.IDatabase
+}
+
+// NewRefreshTokenRepository creates a new RefreshTokenRepository.
+func NewRefreshTokenRepository(db shared.IDatabase) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// Create stores a refresh token for a user.
+func (r *RefreshTokenRepository) Create(ctx context.Context, userID, tokenHash string, expiresAt time.Time) (*RefreshToken, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $userId})
+			WHERE u.status <> 'DELETED'
+			CREATE (u)-[:HAS_REFRESH_TOKEN]->(t:RefreshToken {
+				id: $id,
+				tokenHash: $tokenHash,
+				expiresAt: $expiresAt,
+				createdAt: datetime()
+			})
+			RETURN u.id as userId, t
+		`, map[string]any{
+			"userId":    userID,
+			"id":        uuid.New().String(),
+			"tokenHash": tokenHash,
+			"expiresAt": expiresAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
+		}
+
+		return mapRecordToRefreshToken(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*RefreshToken), nil
+}
+
+// Consume deletes a refresh token and returns it.
+func (r *RefreshTokenRepository) Consume(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		// Keep a copy of the node's properties to return once it is deleted
+		result, err := tx.Run(ctx, `
+			MATCH (u:User)-[:HAS_REFRESH_TOKEN]->(token:RefreshToken {tokenHash: $tokenHash})
+			WITH u, token, token {.*} as t
+			DETACH DELETE token
+			RETURN u.id as userId, t
+		`, map[string]any{"tokenHash": tokenHash})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrInvalidToken
+		}
+
+		return mapRecordToRefreshToken(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*RefreshToken), nil
+}
+
+// Delete revokes a refresh token.
+func (r *RefreshTokenRepository) Delete(ctx context.Context, tokenHash string) error {
+	_, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		_, err := tx.Run(ctx, `
+			MATCH (t:RefreshToken {tokenHash: $tokenHash})
+			DETACH DELETE t
+		`, map[string]any{"tokenHash": tokenHash})
+		return nil, err
+	})
+	return err
+}
+
+// RevokeAllForUser deletes every refresh token of a user.
+func (r *RefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) (int, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (:User {id: $userId})-[:HAS_REFRESH_TOKEN]->(t:RefreshToken)
+			DETACH DELETE t
+			RETURN count(t) as deleted
+		`, map[string]any{"userId": userID})
+		if err != nil {
+			return nil, err
+		}
+		return singleCount(ctx, result, "deleted")
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// DeleteExpired deletes up to limit tokens that expired before the given time.
+func (r *RefreshTokenRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (t:RefreshToken)
+			WHERE t.expiresAt < $before
+			WITH t LIMIT $limit
+			DETACH DELETE t
+			RETURN count(t) as deleted
+		`, map[string]any{"before": before, "limit": limit})
+		if err != nil {
+			return nil, err
+		}
+		return singleCount(ctx, result, "deleted")
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// singleCount reads the integer column key from a single-record result.
+func singleCount(ctx context.Context, result neo4j.ResultWithContext, key string) (int, error) {
+	record, err := result.Single(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count, _ := record.Get(key)
+	return int(count.(int64)), nil
+}
+
+// mapRecordToRefreshToken converts a record with a userId column and a
+// token t, as a node or a map of its properties, to a RefreshToken.
+func mapRecordToRefreshToken(record *neo4j.Record) (*RefreshToken, error) {
+	userID, _ := record.Get("userId")
+	value, _ := record.Get("t")
+
+	props, ok := value.(map[string]any)
+	if node, isNode := value.(neo4j.Node); isNode {
+		props, ok = node.Props, true
+	}
+	if !ok {
+		return nil, errors.ErrInvalidToken
+	}
+
+	token := &RefreshToken{UserID: userID.(string)}
+	token.ID, _ = props["id"].(string)
+	token.ExpiresAt, _ = props["expiresAt"].(time.Time)
+	token.CreatedAt, _ = props["createdAt"].(time.Time)
+	return token, nil
+}
+
+// Ensure RefreshTokenRepository implements IRefreshTokenRepository
+var _ IRefreshTokenRepository = (*RefreshTokenRepository)(nil)
@@ -46,6 +46,30 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*model.User,
 	return result.(*model.User), nil
 }
 
+// FindByIDs retrieves the users with the given IDs.
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []string) ([]*model.User, error) {
+	if len(ids) == 0 {
+		return []*model.User{}, nil
+	}
+
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User) WHERE u.id IN $ids
+			AND u.status <> 'DELETED'
+			RETURN u
+		`, map[string]any{"ids": ids})
+		if err != nil {
+			return nil, err
+		}
+
+		return r.collectUsers(ctx, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*model.User), nil
+}
+
 // FindByEmail retrieves a user by their email address.
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
 	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
@@ -121,6 +145,7 @@ func (r *UserRepository) Create(ctx context.Context, user *model.User) (*model.U
 				name: $name,
 				avatarUrl: $avatarUrl,
 				status: $status,
+				tokenEpoch: 0,
 				createdAt: datetime(),
 				updatedAt: datetime()
 			})
@@ -143,6 +168,51 @@ func (r *UserRepository) Create(ctx context.Context, user *model.User) (*model.U
 	return result.(*model.User), nil
 }
 
+// FindOrCreateByEmail returns the user with the given email, creating one if absent.
+func (r *UserRepository) FindOrCreateByEmail(ctx context.Context, email string, status model.UserStatus) (*model.User, bool, error) {
+	type found struct {
+		user    *model.User
+		created bool
+	}
+
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MERGE (u:User {email: $email})
+			ON CREATE SET
+				u.id = $id,
+				u.status = $status,
+				u.tokenEpoch = 0,
+				u.createdAt = datetime(),
+				u.updatedAt = datetime()
+			RETURN u, u.id = $id AS created
+		`, map[string]any{"email": email, "id": uuid.New().String(), "status": string(status)})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := r.mapRecordToUser(record, "u")
+		if err != nil {
+			return nil, err
+		}
+		if user.Status == model.UserStatusDeleted {
+			return nil, errors.ErrEmailTaken
+		}
+
+		created, _ := record.Get("created")
+		return found{user: user, created: created.(bool)}, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	f := result.(found)
+	return f.user, f.created, nil
+}
+
 // Update updates an existing user's profile.
 func (r *UserRepository) Update(ctx context.Context, id string, input model.UpdateProfileInput) (*model.User, error) {
 	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
@@ -162,10 +232,17 @@ func (r *UserRepository) Update(ctx context.Context, id string, input model.Upda
 			setClause += ", u.avatarUrl = $avatarUrl"
 		}
 
+		// Clearing removes the property so no-change (nil) stays distinct
+		removeClause := ""
+		if input.ClearAvatar != nil && *input.ClearAvatar {
+			removeClause = "REMOVE u.avatarUrl"
+		}
+
 		query := `
 			MATCH (u:User {id: $id})
 			WHERE u.status <> 'DELETED'
 			SET ` + setClause + `
+			` + removeClause + `
 			RETURN u
 		`
 
@@ -187,59 +264,365 @@ func (r *UserRepository) Update(ctx context.Context, id string, input model.Upda
 	return result.(*model.User), nil
 }
 
-// Delete soft-deletes a user by setting their status to DELETED.
-func (r *UserRepository) Delete(ctx context.Context, id string) error {
-	_, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+// Activate marks a PENDING user ACTIVE.
+func (r *UserRepository) Activate(ctx context.Context, id string) (*model.User, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, `
 			MATCH (u:User {id: $id})
 			WHERE u.status <> 'DELETED'
-			SET u.status = 'DELETED', u.updatedAt = datetime()
+			FOREACH (_ IN CASE WHEN u.status = 'PENDING' THEN [1] ELSE [] END |
+				SET u.status = 'ACTIVE', u.updatedAt = datetime()
+			)
 			RETURN u
 		`, map[string]any{"id": id})
 		if err != nil {
 			return nil, err
 		}
 
-		_, err = result.Single(ctx)
+		record, err := result.Single(ctx)
 		if err != nil {
 			return nil, errors.ErrUserNotFound
 		}
 
-		return nil, nil
+		return r.mapRecordToUser(record, "u")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.User), nil
+}
+
+// Delete soft-deletes a user by setting their status to DELETED. Deleted
+// users no longer count towards their tenants' stored memberCount.
+func (r *UserRepository) Delete(ctx context.Context, id, deletedBy string, reason *string) error {
+	_, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return nil, r.DeleteTx(ctx, tx, id, deletedBy, reason)
 	})
 	return err
 }
 
+// DeleteTx soft-deletes a user in tx, leaving commit or rollback to the
+// caller.
+func (r *UserRepository) DeleteTx(ctx context.Context, tx neo4j.ManagedTransaction, id, deletedBy string, reason *string) error {
+	result, err := tx.Run(ctx, `
+		MATCH (u:User {id: $id})
+		WHERE u.status <> 'DELETED'
+		SET u.status = 'DELETED',
+			u.deletedAt = datetime(),
+			u.deletedBy = $deletedBy,
+			u.deletedReason = $reason,
+			u.updatedAt = datetime()
+		WITH u
+		CALL {
+			WITH u
+			MATCH (u)-[:HAS_MEMBERSHIP]->(:Membership)-[:IN_TENANT]->(t:Tenant)
+			SET t.memberCount = coalesce(t.memberCount, 1) - 1
+		}
+		RETURN u
+	`, map[string]any{"id": id, "deletedBy": deletedBy, "reason": reason})
+	if err != nil {
+		return err
+	}
+
+	if _, err := result.Single(ctx); err != nil {
+		return errors.ErrUserNotFound
+	}
+	return nil
+}
+
+// FindDeletion retrieves the deletion details of a deleted user.
+func (r *UserRepository) FindDeletion(ctx context.Context, id string) (*model.DeletionInfo, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $id})
+			WHERE u.status = 'DELETED'
+			RETURN coalesce(u.deletedAt, u.updatedAt) as deletedAt,
+				u.deletedBy as deletedBy,
+				u.deletedReason as reason
+		`, map[string]any{"id": id})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
+		}
+
+		return shared.MapDeletionInfo(record), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.DeletionInfo), nil
+}
+
+// Restore reverses a soft delete, making the user ACTIVE again and counting
+// them towards their tenants' stored memberCount once more. The check for
+// another user having taken the email shares the restoring transaction.
+func (r *UserRepository) Restore(ctx context.Context, id string) (*model.User, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		checkResult, err := tx.Run(ctx, `
+			MATCH (u:User {id: $id})
+			RETURN u.status = 'DELETED' as deleted,
+				COUNT {
+					MATCH (other:User {email: u.email})
+					WHERE other.id <> u.id AND other.status <> 'DELETED'
+				} > 0 as emailTaken
+		`, map[string]any{"id": id})
+		if err != nil {
+			return nil, err
+		}
+
+		checkRecord, err := checkResult.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
+		}
+		if deleted, _ := checkRecord.Get("deleted"); !deleted.(bool) {
+			return nil, errors.ErrNotDeleted
+		}
+		if taken, _ := checkRecord.Get("emailTaken"); taken.(bool) {
+			return nil, errors.ErrEmailTaken
+		}
+
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $id})
+			SET u.status = 'ACTIVE',
+				u.updatedAt = datetime()
+			REMOVE u.deletedAt, u.deletedBy, u.deletedReason
+			WITH u
+			CALL {
+				WITH u
+				MATCH (u)-[:HAS_MEMBERSHIP]->(:Membership)-[:IN_TENANT]->(t:Tenant)
+				SET t.memberCount = coalesce(t.memberCount, 0) + 1
+			}
+			RETURN u
+		`, map[string]any{"id": id})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
+		}
+
+		return r.mapRecordToUser(record, "u")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.User), nil
+}
+
 // List retrieves users with pagination.
 func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*model.User, error) {
 	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return r.listPage(ctx, tx, limit, offset)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*model.User), nil
+}
+
+// ListWithCount retrieves a page of users and the total count.
+func (r *UserRepository) ListWithCount(ctx context.Context, limit, offset int) ([]*model.User, int, error) {
+	type page struct {
+		users []*model.User
+		total int
+	}
+
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		users, err := r.listPage(ctx, tx, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := tx.Run(ctx, `
+			MATCH (u:User)
+			WHERE u.status <> 'DELETED'
+			RETURN count(u) as total
+		`, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		total, _ := record.Get("total")
+		return page{users: users, total: int(total.(int64))}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	p := result.(page)
+	return p.users, p.total, nil
+}
+
+// ListAfter retrieves the page of users following afterID.
+func (r *UserRepository) ListAfter(ctx context.Context, afterID string, limit int) ([]*model.User, error) {
+	if afterID == "" {
+		return r.List(ctx, limit, 0)
+	}
+
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		// The cursor may have been deleted since the last page; its position
+		// is still valid, so it is matched whatever its status
 		result, err := tx.Run(ctx, `
+			MATCH (after:User {id: $afterId})
+			RETURN after.createdAt as createdAt
+		`, map[string]any{"afterId": afterID})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
+		}
+		createdAt, _ := record.Get("createdAt")
+
+		result, err = tx.Run(ctx, `
 			MATCH (u:User)
 			WHERE u.status <> 'DELETED'
+				AND (u.createdAt < $createdAt
+					OR (u.createdAt = $createdAt AND u.id > $afterId))
 			RETURN u
-			ORDER BY u.createdAt DESC
-			SKIP $offset
+			ORDER BY u.createdAt DESC, u.id
 			LIMIT $limit
-		`, map[string]any{"limit": limit, "offset": offset})
+		`, map[string]any{"afterId": afterID, "createdAt": createdAt, "limit": limit})
 		if err != nil {
 			return nil, err
 		}
 
-		var users []*model.User
-		for result.Next(ctx) {
-			user, err := r.mapRecordToUser(result.Record(), "u")
-			if err != nil {
-				return nil, err
-			}
-			users = append(users, user)
+		return r.collectUsers(ctx, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*model.User), nil
+}
+
+// listPage reads one page of users in tx, in List's order.
+func (r *UserRepository) listPage(ctx context.Context, tx neo4j.ManagedTransaction, limit, offset int) ([]*model.User, error) {
+	result, err := tx.Run(ctx, `
+		MATCH (u:User)
+		WHERE u.status <> 'DELETED'
+		RETURN u
+		ORDER BY u.createdAt DESC, u.id
+		SKIP $offset
+		LIMIT $limit
+	`, map[string]any{"limit": limit, "offset": offset})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.collectUsers(ctx, result)
+}
+
+// collectUsers maps every record's "u" node, returning an empty slice when
+// there are none.
+func (r *UserRepository) collectUsers(ctx context.Context, result neo4j.ResultWithContext) ([]*model.User, error) {
+	users := []*model.User{}
+	for result.Next(ctx) {
+		user, err := r.mapRecordToUser(result.Record(), "u")
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// FindByProvider retrieves the user linked to an external identity.
+func (r *UserRepository) FindByProvider(ctx context.Context, provider, subject string) (*model.User, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User)-[:AUTHENTICATED_BY]->(:AuthProvider {provider: $provider, subject: $subject})
+			WHERE u.status <> 'DELETED'
+			RETURN u
+		`, map[string]any{"provider": provider, "subject": subject})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
 		}
 
-		return users, nil
+		return r.mapRecordToUser(record, "u")
 	})
 	if err != nil {
 		return nil, err
 	}
-	return result.([]*model.User), nil
+	return result.(*model.User), nil
+}
+
+// FindProviders returns the providers a user has linked identities from.
+func (r *UserRepository) FindProviders(ctx context.Context, userID string) ([]string, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (:User {id: $userId})-[:AUTHENTICATED_BY]->(p:AuthProvider)
+			RETURN DISTINCT p.provider as provider
+			ORDER BY provider
+		`, map[string]any{"userId": userID})
+		if err != nil {
+			return nil, err
+		}
+
+		providers := []string{}
+		for result.Next(ctx) {
+			provider, _ := result.Record().Get("provider")
+			providers = append(providers, provider.(string))
+		}
+		return providers, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
+
+// LinkProvider links an external identity to a user.
+func (r *UserRepository) LinkProvider(ctx context.Context, userID, provider, subject string) error {
+	_, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		// Only link an identity no one else holds; the uniqueness constraint
+		// on (provider, subject) keeps concurrent links to one node
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $userId})
+			WHERE u.status <> 'DELETED'
+			MERGE (p:AuthProvider {provider: $provider, subject: $subject})
+			ON CREATE SET p.createdAt = datetime()
+			WITH u, p
+			OPTIONAL MATCH (other:User)-[:AUTHENTICATED_BY]->(p)
+			WHERE other <> u
+			WITH u, p, count(other) as others
+			FOREACH (_ IN CASE WHEN others = 0 THEN [1] ELSE [] END |
+				MERGE (u)-[:AUTHENTICATED_BY]->(p)
+			)
+			RETURN others
+		`, map[string]any{"userId": userID, "provider": provider, "subject": subject})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
+		}
+
+		if others, _ := record.Get("others"); others.(int64) > 0 {
+			return nil, errors.ErrProviderMismatch
+		}
+		return nil, nil
+	})
+	return err
 }
 
 // ExistsByEmail checks if a user with the given email exists.
@@ -268,6 +651,112 @@ func (r *UserRepository) ExistsByEmail(ctx context.Context, email string) (bool,
 	return result.(bool), nil
 }
 
+// GetTokenEpoch returns the user's current token epoch.
+func (r *UserRepository) GetTokenEpoch(ctx context.Context, userID string) (int, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $id})
+			WHERE u.status <> 'DELETED'
+			RETURN coalesce(u.tokenEpoch, 0) as tokenEpoch
+		`, map[string]any{"id": userID})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
+		}
+
+		epoch, _ := record.Get("tokenEpoch")
+		return int(epoch.(int64)), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// IncrementTokenEpoch bumps the user's token epoch and returns the new value.
+func (r *UserRepository) IncrementTokenEpoch(ctx context.Context, userID string) (int, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $id})
+			WHERE u.status <> 'DELETED'
+			SET u.tokenEpoch = coalesce(u.tokenEpoch, 0) + 1, u.updatedAt = datetime()
+			RETURN u.tokenEpoch as tokenEpoch
+		`, map[string]any{"id": userID})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
+		}
+
+		epoch, _ := record.Get("tokenEpoch")
+		return int(epoch.(int64)), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// GetRoleEpoch returns the user's current role epoch.
+func (r *UserRepository) GetRoleEpoch(ctx context.Context, userID string) (int, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $id})
+			WHERE u.status <> 'DELETED'
+			RETURN coalesce(u.roleEpoch, 0) as roleEpoch
+		`, map[string]any{"id": userID})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
+		}
+
+		epoch, _ := record.Get("roleEpoch")
+		return int(epoch.(int64)), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// IncrementRoleEpoch bumps the user's role epoch and returns the new value.
+func (r *UserRepository) IncrementRoleEpoch(ctx context.Context, userID string) (int, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $id})
+			WHERE u.status <> 'DELETED'
+			SET u.roleEpoch = coalesce(u.roleEpoch, 0) + 1, u.updatedAt = datetime()
+			RETURN u.roleEpoch as roleEpoch
+		`, map[string]any{"id": userID})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrUserNotFound
+		}
+
+		epoch, _ := record.Get("roleEpoch")
+		return int(epoch.(int64)), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
 // mapRecordToUser converts a Neo4j record to a User model.
 func (r *UserRepository) mapRecordToUser(record *neo4j.Record, key string) (*model.User, error) {
 	nodeVal, ok := record.Get(key)
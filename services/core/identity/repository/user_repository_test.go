@@ -62,6 +62,21 @@ func TestMockUserRepository_FindByID_DeletedUser(t *testing.T) {
 	assert.ErrorIs(t, err, errors.ErrUserNotFound)
 }
 
+func TestMockUserRepository_FindByIDs(t *testing.T) {
+	// Arrange
+	repo := NewMockUserRepository()
+	repo.AddUser(&model.User{ID: "user-1", Email: "one@example.com", Status: model.UserStatusActive})
+	repo.AddUser(&model.User{ID: "user-2", Email: "two@example.com", Status: model.UserStatusDeleted})
+
+	// Act
+	result, err := repo.FindByIDs(context.Background(), []string{"user-1", "user-2", "nonexistent"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "user-1", result[0].ID)
+}
+
 func TestMockUserRepository_FindByEmail_Success(t *testing.T) {
 	// Arrange
 	repo := NewMockUserRepository()
@@ -184,7 +199,7 @@ func TestMockUserRepository_Delete_Success(t *testing.T) {
 	repo.AddUser(user)
 
 	// Act
-	err := repo.Delete(context.Background(), "user-123")
+	err := repo.Delete(context.Background(), "user-123", "user-123", nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -199,7 +214,7 @@ func TestMockUserRepository_Delete_NotFound(t *testing.T) {
 	repo := NewMockUserRepository()
 
 	// Act
-	err := repo.Delete(context.Background(), "nonexistent")
+	err := repo.Delete(context.Background(), "nonexistent", "nonexistent", nil)
 
 	// Assert
 	assert.ErrorIs(t, err, errors.ErrUserNotFound)
@@ -263,3 +278,158 @@ func TestMockUserRepository_List_WithOffset(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, users, 2) // 5 total - 3 offset = 2 remaining
 }
+
+func TestMockUserRepository_List_DeterministicPages(t *testing.T) {
+	// Arrange - two users share a createdAt so the ID tiebreak matters
+	repo := NewMockUserRepository()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, offset := range []int{0, 2, 1, 2, 3} {
+		repo.AddUser(&model.User{
+			ID:        string(rune('a' + i)),
+			Email:     string(rune('a'+i)) + "@example.com",
+			Status:    model.UserStatusActive,
+			CreatedAt: base.Add(time.Duration(offset) * time.Hour),
+		})
+	}
+
+	pageIDs := func(limit, offset int) []string {
+		users, err := repo.List(context.Background(), limit, offset)
+		require.NoError(t, err)
+		ids := make([]string, len(users))
+		for i, user := range users {
+			ids[i] = user.ID
+		}
+		return ids
+	}
+
+	// Act & Assert - newest first, ties broken by ID, identical on every call
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, []string{"e", "b", "d"}, pageIDs(3, 0))
+		assert.Equal(t, []string{"c", "a"}, pageIDs(3, 3))
+	}
+}
+
+// setupListUsers returns a repository with five active users whose newest
+// first order is e, d, c, b, a, plus a deleted user between b and c.
+func setupListUsers() *MockUserRepository {
+	repo := NewMockUserRepository()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		repo.AddUser(&model.User{
+			ID:        string(rune('a' + i)),
+			Email:     string(rune('a'+i)) + "@example.com",
+			Status:    model.UserStatusActive,
+			CreatedAt: base.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	repo.AddUser(&model.User{
+		ID:        "deleted",
+		Email:     "deleted@example.com",
+		Status:    model.UserStatusDeleted,
+		CreatedAt: base.Add(90 * time.Minute),
+	})
+	return repo
+}
+
+func userIDs(users []*model.User) []string {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+func TestMockUserRepository_ListWithCount(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		limit   int
+		offset  int
+		wantIDs []string
+	}{
+		{desc: "first page", limit: 2, offset: 0, wantIDs: []string{"e", "d"}},
+		{desc: "last partial page", limit: 2, offset: 4, wantIDs: []string{"a"}},
+		{desc: "offset at the end", limit: 2, offset: 5, wantIDs: []string{}},
+		{desc: "offset past the end", limit: 2, offset: 50, wantIDs: []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			repo := setupListUsers()
+
+			// Act
+			users, total, err := repo.ListWithCount(context.Background(), tc.limit, tc.offset)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantIDs, userIDs(users))
+			assert.Equal(t, 5, total, "total excludes deleted users and ignores paging")
+		})
+	}
+}
+
+func TestMockUserRepository_ListWithCount_Empty(t *testing.T) {
+	// Arrange
+	repo := NewMockUserRepository()
+
+	// Act
+	users, total, err := repo.ListWithCount(context.Background(), 10, 0)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, users)
+	assert.Zero(t, total)
+}
+
+func TestMockUserRepository_ListAfter(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		afterID string
+		limit   int
+		wantIDs []string
+	}{
+		{desc: "no cursor starts at the top", afterID: "", limit: 2, wantIDs: []string{"e", "d"}},
+		{desc: "next page", afterID: "d", limit: 2, wantIDs: []string{"c", "b"}},
+		{desc: "deleted cursor keeps its place", afterID: "deleted", limit: 2, wantIDs: []string{"b", "a"}},
+		{desc: "cursor at the end", afterID: "a", limit: 2, wantIDs: []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			repo := setupListUsers()
+
+			// Act
+			users, err := repo.ListAfter(context.Background(), tc.afterID, tc.limit)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantIDs, userIDs(users))
+		})
+	}
+}
+
+func TestMockUserRepository_ListAfter_StableWhenUsersAreCreated(t *testing.T) {
+	// Arrange
+	repo := setupListUsers()
+	first, err := repo.ListAfter(context.Background(), "", 2)
+	require.NoError(t, err)
+	repo.AddUser(&model.User{ID: "newest", Email: "newest@example.com", Status: model.UserStatusActive, CreatedAt: time.Now()})
+
+	// Act
+	second, err := repo.ListAfter(context.Background(), first[len(first)-1].ID, 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b"}, userIDs(second), "a new user doesn't shift later pages")
+}
+
+func TestMockUserRepository_ListAfter_UnknownCursor(t *testing.T) {
+	// Arrange
+	repo := setupListUsers()
+
+	// Act
+	users, err := repo.ListAfter(context.Background(), "missing", 2)
+
+	// Assert
+	assert.Nil(t, users)
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+}
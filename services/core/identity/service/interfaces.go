@@ -7,6 +7,20 @@ import (
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 )
 
+// ProviderIdentity is a user as an external sign-in provider reports them.
+type ProviderIdentity struct {
+	// Provider names the provider, e.g. "google".
+	Provider string
+	// Subject is the provider's stable ID for the user.
+	Subject string
+	Email   string
+	// EmailVerified reports whether the provider has verified Email.
+	EmailVerified bool
+	// Name is nil when the provider doesn't send it, as Apple does after
+	// the first sign-in.
+	Name *string
+}
+
 // IUserService defines the contract for user business operations.
 type IUserService interface {
 	// GetCurrentUser retrieves the currently authenticated user.
@@ -18,17 +32,60 @@ type IUserService interface {
 	GetUserByID(ctx context.Context, id string) (*model.User, error)
 
 	// UpdateProfile updates the current user's profile.
-	// Returns ErrNotAuthenticated if no user is in context.
+	// Returns ErrNotAuthenticated if no user is in context, and a
+	// ValidationError naming the field if the name is empty or over 100
+	// characters or the avatar isn't an http or https URL.
 	UpdateProfile(ctx context.Context, input model.UpdateProfileInput) (*model.User, error)
 
-	// DeleteAccount soft-deletes the current user's account.
+	// DeleteAccount soft-deletes the current user's account, recording the
+	// optional reason.
 	// Returns ErrNotAuthenticated if no user is in context.
-	DeleteAccount(ctx context.Context) error
+	DeleteAccount(ctx context.Context, reason *string) error
+
+	// GetUserDeletion retrieves who deleted a user, when and why.
+	// Returns ErrForbidden if the caller is not a platform admin.
+	GetUserDeletion(ctx context.Context, userID string) (*model.DeletionInfo, error)
+
+	// RestoreAccount reverses the soft delete of a user's account.
+	// Returns ErrForbidden if the caller is not a platform admin,
+	// ErrNotDeleted if the account isn't deleted, and ErrEmailTaken if
+	// another account has since taken the email.
+	RestoreAccount(ctx context.Context, userID string) (*model.User, error)
 
 	// CreateUser creates a new user (internal use, e.g., seed command).
-	// Returns ErrEmailTaken if the email already exists.
+	// The email is stored lowercased and trimmed. Returns ErrInvalidEmail if
+	// it is malformed and ErrEmailTaken if it already exists.
 	CreateUser(ctx context.Context, email string, name *string) (*model.User, error)
 
 	// GetUserByEmail retrieves a user by email (internal use).
 	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+
+	// SignInWithProvider returns the user an external identity signs in as,
+	// creating the user on first sign-in.
+	// Returns ErrProviderMismatch if the email belongs to an account that
+	// signs in another way.
+	SignInWithProvider(ctx context.Context, identity ProviderIdentity) (*model.User, error)
+
+	// RevokeUserTokens invalidates every token issued to the user so far by
+	// bumping their token epoch, and deletes their refresh tokens when
+	// configured with them. Requires a platform admin.
+	// Returns ErrForbidden if the caller is not a platform admin.
+	RevokeUserTokens(ctx context.Context, userID string) error
+}
+
+// ITokenService defines the contract for issuing and refreshing tokens.
+type ITokenService interface {
+	// IssueTokens issues an access token and a refresh token for a user.
+	// Returns ErrUserNotFound if the user doesn't exist or is deleted.
+	IssueTokens(ctx context.Context, userID string) (*TokenPair, error)
+
+	// Refresh exchanges a refresh token for a new pair; the token it is
+	// given stops working.
+	// Returns ErrInvalidToken if the token is unknown, revoked, already
+	// exchanged or expired, or its user is deleted.
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, error)
+
+	// Revoke revokes a refresh token, as on logout. Revoking an unknown
+	// token is a no-op.
+	Revoke(ctx context.Context, refreshToken string) error
 }
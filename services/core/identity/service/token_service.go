@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/identity/repository"
+)
+
+// refreshTokenCleanupBatch is how many expired refresh tokens each cleanup
+// transaction deletes.
+const refreshTokenCleanupBatch = 500
+
+// TokenPair is a short-lived access token together with the refresh token
+// that replaces it once it expires.
+type TokenPair struct {
+	AccessToken           string    `json:"accessToken"`
+	RefreshToken          string    `json:"refreshToken"`
+	RefreshTokenExpiresAt time.Time `json:"refreshTokenExpiresAt"`
+}
+
+// TokenService issues access tokens with opaque refresh tokens and
+// exchanges refresh tokens for new pairs. Each refresh token can be
+// exchanged once; the exchange rotates it.
+type TokenService struct {
+	users         repository.IUserRepository
+	refreshTokens repository.IRefreshTokenRepository
+	tokens        *auth.TokenManager
+	refreshTTL    time.Duration
+	now           func() time.Time
+}
+
+// NewTokenService creates a new TokenService whose refresh tokens are
+// valid for refreshTTL.
+func NewTokenService(users repository.IUserRepository, refreshTokens repository.IRefreshTokenRepository, tokens *auth.TokenManager, refreshTTL time.Duration) *TokenService {
+	return &TokenService{
+		users:         users,
+		refreshTokens: refreshTokens,
+		tokens:        tokens,
+		refreshTTL:    refreshTTL,
+		now:           time.Now,
+	}
+}
+
+// IssueTokens issues a new token pair for a user.
+func (s *TokenService) IssueTokens(ctx context.Context, userID string) (*TokenPair, error) {
+	epoch, err := s.users.GetTokenEpoch(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := s.tokens.IssueSessionToken(ctx, userID, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.refreshTokens.Create(ctx, userID, hashRefreshToken(refreshToken), s.now().Add(s.refreshTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair, revoking it.
+func (s *TokenService) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	if refreshToken == "" {
+		return nil, errors.ErrInvalidToken
+	}
+	stored, err := s.refreshTokens.Consume(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if !s.now().Before(stored.ExpiresAt) {
+		return nil, errors.ErrInvalidToken
+	}
+
+	pair, err := s.IssueTokens(ctx, stored.UserID)
+	if errors.Is(err, errors.ErrUserNotFound) {
+		return nil, errors.ErrInvalidToken
+	}
+	return pair, err
+}
+
+// Revoke revokes a refresh token, as on logout.
+func (s *TokenService) Revoke(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return nil
+	}
+	return s.refreshTokens.Delete(ctx, hashRefreshToken(refreshToken))
+}
+
+// CleanupExpired deletes every expired refresh token, in batches, and
+// returns how many were deleted.
+func (s *TokenService) CleanupExpired(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		deleted, err := s.refreshTokens.DeleteExpired(ctx, s.now(), refreshTokenCleanupBatch)
+		total += deleted
+		if err != nil || deleted < refreshTokenCleanupBatch {
+			return total, err
+		}
+	}
+}
+
+// RunCleanup deletes expired refresh tokens every interval until ctx is
+// cancelled.
+func (s *TokenService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.CleanupExpired(ctx); err != nil && ctx.Err() == nil {
+				slog.WarnContext(ctx, "refresh token cleanup failed", "error", err)
+			}
+		}
+	}
+}
+
+// newRefreshToken generates an opaque refresh token.
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate refresh token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashRefreshToken returns the hash refresh tokens are stored under, so a
+// database leak doesn't leak usable tokens.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Ensure TokenService implements ITokenService
+var _ ITokenService = (*TokenService)(nil)
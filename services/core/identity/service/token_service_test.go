@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/identity/repository"
+	"github.com/yourusername/grgn-stack/services/core/shared/testutil"
+)
+
+// setupTokenService returns a TokenService issuing refresh tokens valid for
+// an hour, for the active user user-123, and the clock it reads.
+func setupTokenService() (*TokenService, *repository.MockUserRepository, *repository.MockRefreshTokenRepository, *time.Time) {
+	users := repository.NewMockUserRepository()
+	testutil.AddActiveUser(users, "user-123")
+	refreshTokens := repository.NewMockRefreshTokenRepository()
+	refreshTokens.Users = users
+
+	svc := NewTokenService(users, refreshTokens, auth.NewTokenManager("secret", time.Minute, users), time.Hour)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	return svc, users, refreshTokens, &now
+}
+
+func TestTokenService_IssueTokens(t *testing.T) {
+	// Arrange
+	svc, users, refreshTokens, now := setupTokenService()
+	ctx := context.Background()
+
+	// Act
+	pair, err := svc.IssueTokens(ctx, "user-123")
+
+	// Assert
+	require.NoError(t, err)
+	claims, err := auth.NewTokenManager("secret", time.Minute, users).ParseToken(ctx, pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.Subject)
+	assert.Equal(t, now.Add(time.Hour), pair.RefreshTokenExpiresAt)
+
+	stored := refreshTokens.Tokens("user-123")
+	require.Len(t, stored, 1)
+	assert.Equal(t, pair.RefreshTokenExpiresAt, stored[0].ExpiresAt)
+}
+
+func TestTokenService_Refresh_RotatesToken(t *testing.T) {
+	// Arrange
+	svc, _, refreshTokens, _ := setupTokenService()
+	ctx := context.Background()
+	first, err := svc.IssueTokens(ctx, "user-123")
+	require.NoError(t, err)
+
+	// Act
+	second, err := svc.Refresh(ctx, first.RefreshToken)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	assert.NotEmpty(t, second.AccessToken)
+	assert.Len(t, refreshTokens.Tokens("user-123"), 1)
+
+	_, err = svc.Refresh(ctx, first.RefreshToken)
+	assert.ErrorIs(t, err, errors.ErrInvalidToken, "an exchanged token can't be reused")
+	_, err = svc.Refresh(ctx, second.RefreshToken)
+	assert.NoError(t, err)
+}
+
+func TestTokenService_Refresh_RejectsRevokedTokens(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		revoke func(svc *TokenService, users *repository.MockUserRepository, pair *TokenPair) error
+	}{
+		{"logout", func(svc *TokenService, _ *repository.MockUserRepository, pair *TokenPair) error {
+			return svc.Revoke(context.Background(), pair.RefreshToken)
+		}},
+		{"all tokens revoked", func(svc *TokenService, users *repository.MockUserRepository, _ *TokenPair) error {
+			return NewUserService(users).WithRefreshTokens(svc.refreshTokens).
+				RevokeUserTokens(testutil.AsPlatformAdmin("admin-1"), "user-123")
+		}},
+		{"user deleted", func(_ *TokenService, users *repository.MockUserRepository, _ *TokenPair) error {
+			return users.Delete(context.Background(), "user-123", "admin-1", nil)
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			svc, users, _, _ := setupTokenService()
+			ctx := context.Background()
+			pair, err := svc.IssueTokens(ctx, "user-123")
+			require.NoError(t, err)
+			require.NoError(t, tc.revoke(svc, users, pair))
+
+			// Act
+			refreshed, err := svc.Refresh(ctx, pair.RefreshToken)
+
+			// Assert
+			assert.Nil(t, refreshed)
+			assert.ErrorIs(t, err, errors.ErrInvalidToken)
+		})
+	}
+}
+
+func TestTokenService_Refresh_Expired(t *testing.T) {
+	// Arrange
+	svc, _, _, now := setupTokenService()
+	ctx := context.Background()
+	pair, err := svc.IssueTokens(ctx, "user-123")
+	require.NoError(t, err)
+	*now = now.Add(time.Hour)
+
+	// Act
+	refreshed, err := svc.Refresh(ctx, pair.RefreshToken)
+
+	// Assert
+	assert.Nil(t, refreshed)
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+}
+
+func TestTokenService_CleanupExpired(t *testing.T) {
+	// Arrange
+	svc, _, refreshTokens, now := setupTokenService()
+	ctx := context.Background()
+	for range refreshTokenCleanupBatch + 1 {
+		_, err := svc.IssueTokens(ctx, "user-123")
+		require.NoError(t, err)
+	}
+	*now = now.Add(2 * time.Hour)
+	live, err := svc.IssueTokens(ctx, "user-123")
+	require.NoError(t, err)
+
+	// Act
+	deleted, err := svc.CleanupExpired(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, refreshTokenCleanupBatch+1, deleted)
+	assert.Len(t, refreshTokens.Tokens("user-123"), 1)
+	_, err = svc.Refresh(ctx, live.RefreshToken)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/grgn-stack/pkg/dataloader"
+	"github.com/yourusername/grgn-stack/services/core/identity/repository"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// UserLoader batches the user lookups resolvers make within a request, such
+// as the user behind each of a tenant's memberships, into one query.
+type UserLoader = dataloader.Loader[string, *model.User]
+
+// NewUserLoader creates a UserLoader fetching from users. Missing and deleted
+// users load as nil.
+func NewUserLoader(users repository.IUserRepository) *UserLoader {
+	return dataloader.NewLoader(func(ctx context.Context, ids []string) (map[string]*model.User, error) {
+		found, err := users.FindByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]*model.User, len(found))
+		for _, user := range found {
+			byID[user.ID] = user
+		}
+		return byID, nil
+	})
+}
+
+// userLoaderKey is the context key for the request's UserLoader.
+type userLoaderKey struct{}
+
+// WithUserLoader returns a copy of ctx carrying loader.
+func WithUserLoader(ctx context.Context, loader *UserLoader) context.Context {
+	return context.WithValue(ctx, userLoaderKey{}, loader)
+}
+
+// UserLoaderFromContext returns the UserLoader in ctx, if it has one.
+func UserLoaderFromContext(ctx context.Context) (*UserLoader, bool) {
+	loader, ok := ctx.Value(userLoaderKey{}).(*UserLoader)
+	return loader, ok
+}
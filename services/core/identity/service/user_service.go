@@ -4,13 +4,16 @@ import (
 	"context"
 
 	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/validation"
 	"github.com/yourusername/grgn-stack/services/core/identity/repository"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 )
 
 // UserService implements IUserService with business logic.
 type UserService struct {
-	userRepo repository.IUserRepository
+	userRepo      repository.IUserRepository
+	refreshTokens repository.IRefreshTokenRepository
 }
 
 // NewUserService creates a new UserService.
@@ -20,6 +23,13 @@ func NewUserService(userRepo repository.IUserRepository) *UserService {
 	}
 }
 
+// WithRefreshTokens makes RevokeUserTokens revoke the user's refresh tokens
+// too, so they can't mint new access tokens.
+func (s *UserService) WithRefreshTokens(refreshTokens repository.IRefreshTokenRepository) *UserService {
+	s.refreshTokens = refreshTokens
+	return s
+}
+
 // GetCurrentUser retrieves the currently authenticated user.
 func (s *UserService) GetCurrentUser(ctx context.Context) (*model.User, error) {
 	userID, err := auth.GetUserID(ctx)
@@ -42,24 +52,72 @@ func (s *UserService) UpdateProfile(ctx context.Context, input model.UpdateProfi
 		return nil, err
 	}
 
+	input.Name = validation.NormalizeSpacePtr(input.Name)
+	input.AvatarURL = validation.TrimPtr(input.AvatarURL)
+
+	var problems errors.ValidationErrors
+	if input.Name != nil {
+		problems.AddError("name", validation.ValidateUserName(*input.Name))
+	}
+	switch {
+	case input.ClearAvatar != nil && *input.ClearAvatar && input.AvatarURL != nil:
+		problems.Add("avatarUrl", "cannot set and clear the avatar in one update")
+	case input.AvatarURL != nil:
+		problems.AddError("avatarUrl", validation.ValidateAvatarURL(*input.AvatarURL))
+	}
+	if err := problems.Err(); err != nil {
+		return nil, err
+	}
+
 	return s.userRepo.Update(ctx, userID, input)
 }
 
 // DeleteAccount soft-deletes the current user's account.
-func (s *UserService) DeleteAccount(ctx context.Context) error {
+func (s *UserService) DeleteAccount(ctx context.Context, reason *string) error {
 	userID, err := auth.GetUserID(ctx)
 	if err != nil {
 		return err
 	}
 
-	return s.userRepo.Delete(ctx, userID)
+	return s.userRepo.Delete(ctx, userID, userID, validation.NormalizeSpacePtr(reason))
+}
+
+// GetUserDeletion retrieves who deleted a user, when and why.
+func (s *UserService) GetUserDeletion(ctx context.Context, userID string) (*model.DeletionInfo, error) {
+	if _, err := auth.GetUserID(ctx); err != nil {
+		return nil, err
+	}
+	if !auth.IsPlatformAdmin(ctx) {
+		return nil, errors.ErrForbidden
+	}
+
+	return s.userRepo.FindDeletion(ctx, userID)
 }
 
-// CreateUser creates a new user (internal use).
+// RestoreAccount reverses the soft delete of a user's account. Requires
+// platform admin, as the deleted user can no longer sign in.
+func (s *UserService) RestoreAccount(ctx context.Context, userID string) (*model.User, error) {
+	if _, err := auth.GetUserID(ctx); err != nil {
+		return nil, err
+	}
+	if !auth.IsPlatformAdmin(ctx) {
+		return nil, errors.ErrForbidden
+	}
+
+	return s.userRepo.Restore(ctx, userID)
+}
+
+// CreateUser creates a new user (internal use). The email is stored
+// normalized, and ErrInvalidEmail is returned if it is malformed.
 func (s *UserService) CreateUser(ctx context.Context, email string, name *string) (*model.User, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
 	user := &model.User{
 		Email:  email,
-		Name:   name,
+		Name:   validation.NormalizeSpacePtr(name),
 		Status: model.UserStatusActive,
 	}
 
@@ -68,7 +126,111 @@ func (s *UserService) CreateUser(ctx context.Context, email string, name *string
 
 // GetUserByEmail retrieves a user by email (internal use).
 func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
-	return s.userRepo.FindByEmail(ctx, email)
+	return s.userRepo.FindByEmail(ctx, validation.NormalizeEmail(email))
+}
+
+// normalizeEmail returns email in the form it is stored in, or
+// ErrInvalidEmail if it is malformed.
+func normalizeEmail(email string) (string, error) {
+	email = validation.NormalizeEmail(email)
+	if err := validation.ValidateEmail(email); err != nil {
+		return "", errors.ErrInvalidEmail
+	}
+	return email, nil
+}
+
+// SignInWithProvider resolves an external identity to a user. An identity
+// seen before signs in as the user it is linked to. Otherwise it is linked
+// to the user with its email, who is created if needed, provided the
+// provider has verified the email and the user has no other linked
+// identities: accounts created by invites or seeding can be claimed this
+// way, but one that signs in with another provider can't be taken over.
+// Claiming an invited email's PENDING placeholder activates it.
+//
+// The provider's name for the user is saved when the identity is linked,
+// and later only if the user has no name, so providers that send it on
+// every sign-in don't undo profile edits and those that send it once,
+// like Apple, keep it.
+func (s *UserService) SignInWithProvider(ctx context.Context, identity ProviderIdentity) (*model.User, error) {
+	name := validation.NormalizeSpacePtr(identity.Name)
+	if name != nil && *name == "" {
+		name = nil
+	}
+
+	user, err := s.userRepo.FindByProvider(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		if name != nil && user.Name == nil {
+			return s.userRepo.Update(ctx, user.ID, model.UpdateProfileInput{Name: name})
+		}
+		return user, nil
+	}
+	if !errors.Is(err, errors.ErrUserNotFound) {
+		return nil, err
+	}
+
+	if !identity.EmailVerified {
+		return nil, errors.NewValidationError("email", "must be verified by the sign-in provider")
+	}
+
+	email, err := normalizeEmail(identity.Email)
+	if err != nil {
+		return nil, err
+	}
+	user, err = s.userRepo.FindByEmail(ctx, email)
+	switch {
+	case errors.Is(err, errors.ErrUserNotFound):
+		user, err = s.CreateUser(ctx, email, name)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		providers, err := s.userRepo.FindProviders(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(providers) > 0 {
+			return nil, errors.ErrProviderMismatch
+		}
+	}
+
+	if err := s.userRepo.LinkProvider(ctx, user.ID, identity.Provider, identity.Subject); err != nil {
+		return nil, err
+	}
+	// Invited emails get a PENDING placeholder user; signing up activates it
+	if user.Status == model.UserStatusPending {
+		user, err = s.userRepo.Activate(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if name != nil && (user.Name == nil || *user.Name != *name) {
+		return s.userRepo.Update(ctx, user.ID, model.UpdateProfileInput{Name: name})
+	}
+	return user, nil
+}
+
+// RevokeUserTokens invalidates every token issued to the user so far,
+// refresh tokens included. Tokens issued after the call carry the new epoch
+// and remain valid.
+func (s *UserService) RevokeUserTokens(ctx context.Context, userID string) error {
+	if _, err := auth.GetUserID(ctx); err != nil {
+		return err
+	}
+	if !auth.IsPlatformAdmin(ctx) {
+		return errors.ErrForbidden
+	}
+
+	if _, err := s.userRepo.IncrementTokenEpoch(ctx, userID); err != nil {
+		return err
+	}
+	if s.refreshTokens != nil {
+		if _, err := s.refreshTokens.RevokeAllForUser(ctx, userID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Ensure UserService implements IUserService
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/yourusername/grgn-stack/pkg/errors"
 	"github.com/yourusername/grgn-stack/services/core/identity/repository"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+	"github.com/yourusername/grgn-stack/services/core/shared/testutil"
 )
 
 func TestUserService_GetCurrentUser_Success(t *testing.T) {
@@ -123,6 +125,29 @@ func TestUserService_UpdateProfile_Success(t *testing.T) {
 	assert.Equal(t, "Updated Name", *user.Name)
 }
 
+func TestUserService_UpdateProfile_NormalizesName(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	mockRepo.AddUser(&model.User{
+		ID:     "user-123",
+		Email:  "test@example.com",
+		Status: model.UserStatusActive,
+	})
+
+	svc := NewUserService(mockRepo)
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	newName := "  Mary  Jane Watson "
+	input := model.UpdateProfileInput{Name: &newName}
+
+	// Act
+	user, err := svc.UpdateProfile(ctx, input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Mary Jane Watson", *user.Name)
+}
+
 func TestUserService_UpdateProfile_NotAuthenticated(t *testing.T) {
 	// Arrange
 	mockRepo := repository.NewMockUserRepository()
@@ -140,6 +165,127 @@ func TestUserService_UpdateProfile_NotAuthenticated(t *testing.T) {
 	assert.ErrorIs(t, err, errors.ErrNotAuthenticated)
 }
 
+// setupAvatarUser returns a service for a user who already has an avatar.
+func setupAvatarUser() (*UserService, *repository.MockUserRepository, context.Context) {
+	avatar := "https://example.com/old.png"
+	mockRepo := repository.NewMockUserRepository()
+	mockRepo.AddUser(&model.User{
+		ID:        "user-123",
+		Email:     "test@example.com",
+		AvatarURL: &avatar,
+		Status:    model.UserStatusActive,
+	})
+	return NewUserService(mockRepo), mockRepo, auth.WithUserID(context.Background(), "user-123")
+}
+
+func TestUserService_UpdateProfile_SetsAvatar(t *testing.T) {
+	// Arrange
+	svc, _, ctx := setupAvatarUser()
+	avatar := " https://example.com/new.png "
+
+	// Act
+	user, err := svc.UpdateProfile(ctx, model.UpdateProfileInput{AvatarURL: &avatar})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, user.AvatarURL)
+	assert.Equal(t, "https://example.com/new.png", *user.AvatarURL)
+}
+
+func TestUserService_UpdateProfile_LeavesAvatarUnchanged(t *testing.T) {
+	// Arrange
+	svc, _, ctx := setupAvatarUser()
+	newName := "Updated Name"
+	clearAvatar := false
+
+	// Act
+	user, err := svc.UpdateProfile(ctx, model.UpdateProfileInput{Name: &newName, ClearAvatar: &clearAvatar})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, user.AvatarURL)
+	assert.Equal(t, "https://example.com/old.png", *user.AvatarURL)
+}
+
+func TestUserService_UpdateProfile_ClearsAvatar(t *testing.T) {
+	// Arrange
+	svc, mockRepo, ctx := setupAvatarUser()
+	clearAvatar := true
+
+	// Act
+	user, err := svc.UpdateProfile(ctx, model.UpdateProfileInput{ClearAvatar: &clearAvatar})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, user.AvatarURL)
+	assert.Nil(t, mockRepo.GetUsers()["user-123"].AvatarURL)
+}
+
+func TestUserService_UpdateProfile_SetAndClearAvatarRejected(t *testing.T) {
+	// Arrange
+	svc, mockRepo, ctx := setupAvatarUser()
+	avatar := "https://example.com/new.png"
+	clearAvatar := true
+
+	// Act
+	user, err := svc.UpdateProfile(ctx, model.UpdateProfileInput{AvatarURL: &avatar, ClearAvatar: &clearAvatar})
+
+	// Assert
+	assert.Nil(t, user)
+	var validationErr *errors.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "https://example.com/old.png", *mockRepo.GetUsers()["user-123"].AvatarURL)
+}
+
+func TestUserService_UpdateProfile_InvalidFields(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input model.UpdateProfileInput
+		field string
+	}{
+		{"oversized name", model.UpdateProfileInput{Name: strPtr(strings.Repeat("a", 101))}, "name"},
+		{"empty after trim", model.UpdateProfileInput{Name: strPtr("   ")}, "name"},
+		{"javascript avatar", model.UpdateProfileInput{AvatarURL: strPtr("javascript:alert(1)")}, "avatarUrl"},
+		{"relative avatar", model.UpdateProfileInput{AvatarURL: strPtr("/avatars/me.png")}, "avatarUrl"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			svc, mockRepo, ctx := setupAvatarUser()
+			mockRepo.UpdateFunc = func(ctx context.Context, id string, input model.UpdateProfileInput) (*model.User, error) {
+				t.Fatal("invalid input must not reach the repository")
+				return nil, nil
+			}
+
+			// Act
+			user, err := svc.UpdateProfile(ctx, tc.input)
+
+			// Assert
+			assert.Nil(t, user)
+			var validationErr *errors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tc.field, validationErr.Field)
+		})
+	}
+}
+
+func TestUserService_UpdateProfile_ReportsEveryInvalidField(t *testing.T) {
+	// Arrange
+	svc, _, ctx := setupAvatarUser()
+
+	// Act
+	user, err := svc.UpdateProfile(ctx, model.UpdateProfileInput{Name: strPtr(" "), AvatarURL: strPtr("javascript:alert(1)")})
+
+	// Assert
+	assert.Nil(t, user)
+	var problems errors.ValidationErrors
+	require.ErrorAs(t, err, &problems)
+	require.Len(t, problems, 2)
+	assert.Equal(t, "name", problems[0].Field)
+	assert.Equal(t, "avatarUrl", problems[1].Field)
+}
+
 func TestUserService_UpdateProfile_UserNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := repository.NewMockUserRepository()
@@ -159,25 +305,130 @@ func TestUserService_UpdateProfile_UserNotFound(t *testing.T) {
 
 func TestUserService_DeleteAccount_Success(t *testing.T) {
 	// Arrange
-	mockRepo := repository.NewMockUserRepository()
-	mockRepo.AddUser(&model.User{
-		ID:     "user-123",
-		Email:  "test@example.com",
-		Status: model.UserStatusActive,
-	})
+	h := testutil.NewHarness()
+	svc := NewUserService(h.Users)
+	ctx := h.AsActiveUser("user-123")
+
+	// Act
+	err := svc.DeleteAccount(ctx, nil)
+
+	// Assert
+	require.NoError(t, err)
+
+	// Verify user is now deleted
+	_, findErr := h.Users.FindByID(context.Background(), "user-123")
+	assert.ErrorIs(t, findErr, errors.ErrUserNotFound)
+}
 
+func TestUserService_DeleteAccount_RecordsReasonForAdmins(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	mockRepo.AddUser(&model.User{ID: "user-123", Email: "test@example.com", Status: model.UserStatusActive})
 	svc := NewUserService(mockRepo)
 	ctx := auth.WithUserID(context.Background(), "user-123")
+	reason := "  no longer   needed "
 
 	// Act
-	err := svc.DeleteAccount(ctx)
+	before := time.Now()
+	err := svc.DeleteAccount(ctx, &reason)
+	require.NoError(t, err)
+
+	adminCtx := auth.WithPlatformAdmin(auth.WithUserID(context.Background(), "admin-1"))
+	info, infoErr := svc.GetUserDeletion(adminCtx, "user-123")
+	_, forbiddenErr := svc.GetUserDeletion(ctx, "user-123")
+
+	// Assert
+	require.NoError(t, infoErr)
+	require.NotNil(t, info.DeletedBy)
+	assert.Equal(t, "user-123", *info.DeletedBy)
+	require.NotNil(t, info.Reason)
+	assert.Equal(t, "no longer needed", *info.Reason)
+	assert.False(t, info.DeletedAt.Before(before))
+	assert.ErrorIs(t, forbiddenErr, errors.ErrForbidden)
+}
+
+func TestUserService_GetUserDeletion_NotDeleted(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	h.AddUser("user-123")
+	svc := NewUserService(h.Users)
+	ctx := testutil.AsPlatformAdmin("admin-1")
+
+	// Act
+	info, err := svc.GetUserDeletion(ctx, "user-123")
+
+	// Assert
+	assert.Nil(t, info)
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+}
+
+func TestUserService_RestoreAccount_Success(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	h.AddUser("user-123")
+	require.NoError(t, h.Users.Delete(context.Background(), "user-123", "user-123", nil))
+	svc := NewUserService(h.Users)
+	ctx := testutil.AsPlatformAdmin("admin-1")
+
+	// Act
+	restored, err := svc.RestoreAccount(ctx, "user-123")
 
 	// Assert
 	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusActive, restored.Status)
+	_, findErr := h.Users.FindByID(context.Background(), "user-123")
+	assert.NoError(t, findErr)
+	_, deletionErr := svc.GetUserDeletion(ctx, "user-123")
+	assert.ErrorIs(t, deletionErr, errors.ErrUserNotFound)
+}
 
-	// Verify user is now deleted
-	_, findErr := mockRepo.FindByID(context.Background(), "user-123")
-	assert.ErrorIs(t, findErr, errors.ErrUserNotFound)
+func TestUserService_RestoreAccount_NotDeleted(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	h.AddUser("user-123")
+	svc := NewUserService(h.Users)
+	ctx := testutil.AsPlatformAdmin("admin-1")
+
+	// Act
+	restored, err := svc.RestoreAccount(ctx, "user-123")
+
+	// Assert
+	assert.Nil(t, restored)
+	assert.ErrorIs(t, err, errors.ErrNotDeleted)
+}
+
+func TestUserService_RestoreAccount_EmailReclaimed(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	mockRepo.AddUser(&model.User{ID: "user-123", Email: "test@example.com", Status: model.UserStatusActive})
+	require.NoError(t, mockRepo.Delete(context.Background(), "user-123", "user-123", nil))
+	_, err := mockRepo.Create(context.Background(), &model.User{ID: "user-456", Email: "test@example.com"})
+	require.NoError(t, err)
+	svc := NewUserService(mockRepo)
+	ctx := testutil.AsPlatformAdmin("admin-1")
+
+	// Act
+	restored, err := svc.RestoreAccount(ctx, "user-123")
+
+	// Assert
+	assert.Nil(t, restored)
+	assert.ErrorIs(t, err, errors.ErrEmailTaken)
+}
+
+func TestUserService_RestoreAccount_RequiresPlatformAdmin(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	h.AddUser("user-123")
+	require.NoError(t, h.Users.Delete(context.Background(), "user-123", "user-123", nil))
+	svc := NewUserService(h.Users)
+	ctx := testutil.AsUser("user-456")
+
+	// Act
+	restored, err := svc.RestoreAccount(ctx, "user-123")
+
+	// Assert
+	assert.Nil(t, restored)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
 }
 
 func TestUserService_DeleteAccount_NotAuthenticated(t *testing.T) {
@@ -187,7 +438,7 @@ func TestUserService_DeleteAccount_NotAuthenticated(t *testing.T) {
 	ctx := context.Background()
 
 	// Act
-	err := svc.DeleteAccount(ctx)
+	err := svc.DeleteAccount(ctx, nil)
 
 	// Assert
 	assert.ErrorIs(t, err, errors.ErrNotAuthenticated)
@@ -211,6 +462,64 @@ func TestUserService_CreateUser_Success(t *testing.T) {
 	assert.Equal(t, model.UserStatusActive, user.Status)
 }
 
+func TestUserService_CreateUser_NormalizesInput(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	svc := NewUserService(mockRepo)
+
+	name := " Test  User "
+
+	// Act
+	user, err := svc.CreateUser(context.Background(), " Test@Example.COM\n", &name)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "test@example.com", user.Email)
+	assert.Equal(t, "Test User", *user.Name)
+}
+
+func TestUserService_CreateUser_InvalidEmail(t *testing.T) {
+	testCases := []struct {
+		email string
+		desc  string
+	}{
+		{"", "empty"},
+		{"test", "missing domain"},
+		{"test@localhost", "domain without a dot"},
+		{"Test <test@example.com>", "display name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			mockRepo := repository.NewMockUserRepository()
+			svc := NewUserService(mockRepo)
+
+			// Act
+			user, err := svc.CreateUser(context.Background(), tc.email, nil)
+
+			// Assert
+			assert.Nil(t, user)
+			assert.ErrorIs(t, err, errors.ErrInvalidEmail)
+			assert.Empty(t, mockRepo.GetUsers())
+		})
+	}
+}
+
+func TestUserService_CreateUser_DuplicateEmailDiffersInCase(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	mockRepo.AddUser(&model.User{ID: "existing-user", Email: "test@example.com", Status: model.UserStatusActive})
+	svc := NewUserService(mockRepo)
+
+	// Act
+	user, err := svc.CreateUser(context.Background(), "Test@Example.com", nil)
+
+	// Assert
+	assert.Nil(t, user)
+	assert.ErrorIs(t, err, errors.ErrEmailTaken)
+}
+
 func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 	// Arrange
 	mockRepo := repository.NewMockUserRepository()
@@ -243,7 +552,7 @@ func TestUserService_GetUserByEmail_Success(t *testing.T) {
 	svc := NewUserService(mockRepo)
 
 	// Act
-	user, err := svc.GetUserByEmail(context.Background(), "test@example.com")
+	user, err := svc.GetUserByEmail(context.Background(), " Test@Example.com")
 
 	// Assert
 	require.NoError(t, err)
@@ -262,3 +571,236 @@ func TestUserService_GetUserByEmail_NotFound(t *testing.T) {
 	assert.Nil(t, user)
 	assert.ErrorIs(t, err, errors.ErrUserNotFound)
 }
+
+func TestUserService_RevokeUserTokens_InvalidatesIssuedTokens(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	mockRepo.AddUser(&model.User{ID: "user-123", Email: "test@example.com", Status: model.UserStatusActive})
+	service := NewUserService(mockRepo)
+	tokens := auth.NewTokenManager("secret", time.Hour, mockRepo)
+
+	oldToken, err := tokens.IssueToken("user-123", 0)
+	require.NoError(t, err)
+
+	ctx := auth.WithPlatformAdmin(auth.WithUserID(context.Background(), "admin-1"))
+
+	// Act
+	err = service.RevokeUserTokens(ctx, "user-123")
+	require.NoError(t, err)
+
+	epoch, err := mockRepo.GetTokenEpoch(ctx, "user-123")
+	require.NoError(t, err)
+	newToken, err := tokens.IssueToken("user-123", epoch)
+	require.NoError(t, err)
+
+	_, oldErr := tokens.ParseToken(ctx, oldToken)
+	claims, newErr := tokens.ParseToken(ctx, newToken)
+
+	// Assert
+	assert.Equal(t, 1, epoch)
+	assert.ErrorIs(t, oldErr, errors.ErrTokenRevoked)
+	require.NoError(t, newErr)
+	assert.Equal(t, "user-123", claims.Subject)
+}
+
+func TestUserService_RevokeUserTokens_RequiresPlatformAdmin(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	mockRepo.AddUser(&model.User{ID: "user-123", Email: "test@example.com", Status: model.UserStatusActive})
+	service := NewUserService(mockRepo)
+	ctx := auth.WithUserID(context.Background(), "user-456")
+
+	// Act
+	err := service.RevokeUserTokens(ctx, "user-123")
+
+	// Assert
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+	epoch, _ := mockRepo.GetTokenEpoch(ctx, "user-123")
+	assert.Equal(t, 0, epoch)
+}
+
+func googleIdentity(email string) ProviderIdentity {
+	return ProviderIdentity{Provider: "google", Subject: "google-1", Email: email, EmailVerified: true}
+}
+
+func TestUserService_SignInWithProvider_CreatesAndLinksUser(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	service := NewUserService(mockRepo)
+
+	// Act
+	user, err := service.SignInWithProvider(context.Background(), googleIdentity(" new@example.com "))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", user.Email)
+	linked, err := mockRepo.FindByProvider(context.Background(), "google", "google-1")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, linked.ID)
+}
+
+func TestUserService_SignInWithProvider_LinkedIdentitySignsIn(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	existing := testutil.AddActiveUser(mockRepo, "user-123")
+	require.NoError(t, mockRepo.LinkProvider(context.Background(), existing.ID, "google", "google-1"))
+	service := NewUserService(mockRepo)
+
+	// Act
+	user, err := service.SignInWithProvider(context.Background(), ProviderIdentity{
+		Provider: "google", Subject: "google-1", Email: "changed@example.com",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, user.ID)
+	assert.Len(t, mockRepo.GetUsers(), 1)
+}
+
+func TestUserService_SignInWithProvider_ClaimsUnlinkedUser(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	existing := testutil.AddActiveUser(mockRepo, "user-123")
+	service := NewUserService(mockRepo)
+
+	// Act
+	user, err := service.SignInWithProvider(context.Background(), googleIdentity(existing.Email))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, user.ID)
+	providers, err := mockRepo.FindProviders(context.Background(), existing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"google"}, providers)
+}
+
+func TestUserService_SignInWithProvider_ClaimsUserWithDifferentlyCasedEmail(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	existing := testutil.AddActiveUser(mockRepo, "user-123")
+	service := NewUserService(mockRepo)
+
+	// Act
+	user, err := service.SignInWithProvider(context.Background(), googleIdentity(" "+strings.ToUpper(existing.Email)))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, user.ID)
+	assert.Len(t, mockRepo.GetUsers(), 1)
+}
+
+func TestUserService_SignInWithProvider_InvalidEmail(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	service := NewUserService(mockRepo)
+
+	// Act
+	user, err := service.SignInWithProvider(context.Background(), googleIdentity("not-an-email"))
+
+	// Assert
+	assert.Nil(t, user)
+	assert.ErrorIs(t, err, errors.ErrInvalidEmail)
+	_, err = mockRepo.FindByProvider(context.Background(), "google", "google-1")
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+}
+
+func TestUserService_SignInWithProvider_ActivatesInvitedPlaceholder(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	placeholder, _, err := mockRepo.FindOrCreateByEmail(context.Background(), "invitee@example.com", model.UserStatusPending)
+	require.NoError(t, err)
+	service := NewUserService(mockRepo)
+
+	// Act
+	user, err := service.SignInWithProvider(context.Background(), googleIdentity("invitee@example.com"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, placeholder.ID, user.ID)
+	assert.Equal(t, model.UserStatusActive, user.Status)
+}
+
+func TestUserService_SignInWithProvider_EmailLinkedToAnotherProvider(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	existing := testutil.AddActiveUser(mockRepo, "user-123")
+	require.NoError(t, mockRepo.LinkProvider(context.Background(), existing.ID, "apple", "apple-1"))
+	service := NewUserService(mockRepo)
+
+	// Act
+	user, err := service.SignInWithProvider(context.Background(), googleIdentity(existing.Email))
+
+	// Assert
+	assert.Nil(t, user)
+	assert.ErrorIs(t, err, errors.ErrProviderMismatch)
+	_, err = mockRepo.FindByProvider(context.Background(), "google", "google-1")
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+}
+
+func TestUserService_SignInWithProvider_UnverifiedEmail(t *testing.T) {
+	// Arrange
+	mockRepo := repository.NewMockUserRepository()
+	existing := testutil.AddActiveUser(mockRepo, "user-123")
+	service := NewUserService(mockRepo)
+	identity := googleIdentity(existing.Email)
+	identity.EmailVerified = false
+
+	// Act
+	user, err := service.SignInWithProvider(context.Background(), identity)
+
+	// Assert
+	assert.Nil(t, user)
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "email", validationErr.Field)
+	providers, err := mockRepo.FindProviders(context.Background(), existing.ID)
+	require.NoError(t, err)
+	assert.Empty(t, providers, "an unverified email can't claim an account")
+}
+
+func TestUserService_SignInWithProvider_Name(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		linked   bool
+		existing *string
+		sent     *string
+		wantName *string
+	}{
+		{desc: "first sign-in creates the user with the name", sent: strPtr("  Jane   Appleseed "), wantName: strPtr("Jane Appleseed")},
+		{desc: "first sign-in replaces an unlinked user's name", existing: strPtr("Invitee"), sent: strPtr("Jane Appleseed"), wantName: strPtr("Jane Appleseed")},
+		{desc: "first sign-in without a name keeps the user's", existing: strPtr("Invitee"), wantName: strPtr("Invitee")},
+		{desc: "later sign-in keeps an edited name", linked: true, existing: strPtr("J. Appleseed"), sent: strPtr("Jane Appleseed"), wantName: strPtr("J. Appleseed")},
+		{desc: "later sign-in fills a missing name", linked: true, sent: strPtr("Jane Appleseed"), wantName: strPtr("Jane Appleseed")},
+		{desc: "later sign-in without a name leaves it unset", linked: true},
+		{desc: "blank name is ignored", sent: strPtr("  ")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			mockRepo := repository.NewMockUserRepository()
+			if tc.linked || tc.existing != nil {
+				mockRepo.AddUser(&model.User{ID: "user-123", Email: "jane@example.com", Name: tc.existing, Status: model.UserStatusActive})
+			}
+			if tc.linked {
+				require.NoError(t, mockRepo.LinkProvider(context.Background(), "user-123", "apple", "apple-1"))
+			}
+			service := NewUserService(mockRepo)
+			identity := ProviderIdentity{Provider: "apple", Subject: "apple-1", Email: "jane@example.com", EmailVerified: true, Name: tc.sent}
+
+			// Act
+			user, err := service.SignInWithProvider(context.Background(), identity)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantName, user.Name)
+			stored, err := mockRepo.FindByID(context.Background(), user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantName, stored.Name)
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,140 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// DefaultBackfillBatchSize is the number of nodes updated per write transaction.
+const DefaultBackfillBatchSize = 500
+
+// BackfillRule fills a property that the repository mappers require.
+// Default is a Cypher expression evaluated per node, bound as n.
+type BackfillRule struct {
+	Label    string
+	Property string
+	Default  string
+}
+
+// BackfillRules are the properties seed data or manual inserts commonly lack.
+// Within a label, createdAt precedes updatedAt so updatedAt can reuse it.
+var BackfillRules = []BackfillRule{
+	{Label: "User", Property: "status", Default: "'ACTIVE'"},
+	{Label: "User", Property: "createdAt", Default: "datetime()"},
+	{Label: "User", Property: "updatedAt", Default: "coalesce(n.createdAt, datetime())"},
+	{Label: "Tenant", Property: "status", Default: "'ACTIVE'"},
+	{Label: "Tenant", Property: "plan", Default: "'FREE'"},
+	{Label: "Tenant", Property: "isolationMode", Default: "'SHARED'"},
+	{Label: "Tenant", Property: "createdAt", Default: "datetime()"},
+	{Label: "Tenant", Property: "updatedAt", Default: "coalesce(n.createdAt, datetime())"},
+	{Label: "Membership", Property: "status", Default: "'ACTIVE'"},
+	{Label: "Membership", Property: "joinedAt", Default: "datetime()"},
+}
+
+// IBackfillStore counts and fills nodes missing a property.
+type IBackfillStore interface {
+	// CountMissing returns the number of nodes matching the rule that lack its property.
+	CountMissing(ctx context.Context, rule BackfillRule) (int, error)
+
+	// FillMissing sets the default on up to limit such nodes and returns how many were updated.
+	FillMissing(ctx context.Context, rule BackfillRule, limit int) (int, error)
+}
+
+// BackfillResult reports the nodes found (and, unless dry-run, fixed) for a rule.
+type BackfillResult struct {
+	Rule    BackfillRule
+	Missing int
+	Updated int
+}
+
+// Backfill applies rules in order, filling missing properties in batches.
+// With dryRun set it only counts, leaving the data untouched.
+func Backfill(ctx context.Context, store IBackfillStore, rules []BackfillRule, batchSize int, dryRun bool) ([]BackfillResult, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBackfillBatchSize
+	}
+
+	results := make([]BackfillResult, 0, len(rules))
+	for _, rule := range rules {
+		missing, err := store.CountMissing(ctx, rule)
+		if err != nil {
+			return results, fmt.Errorf("count %s.%s: %w", rule.Label, rule.Property, err)
+		}
+
+		result := BackfillResult{Rule: rule, Missing: missing}
+		for !dryRun && result.Updated < missing {
+			updated, err := store.FillMissing(ctx, rule, batchSize)
+			if err != nil {
+				results = append(results, result)
+				return results, fmt.Errorf("backfill %s.%s: %w", rule.Label, rule.Property, err)
+			}
+			if updated == 0 {
+				break
+			}
+			result.Updated += updated
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Neo4jBackfillStore implements IBackfillStore with Cypher.
+// Labels and properties come from trusted rules and are inlined
+// because Cypher cannot parameterize them.
+type Neo4jBackfillStore struct {
+	db IDatabase
+}
+
+// NewNeo4jBackfillStore creates a new Neo4jBackfillStore.
+func NewNeo4jBackfillStore(db IDatabase) *Neo4jBackfillStore {
+	return &Neo4jBackfillStore{db: db}
+}
+
+// CountMissing returns the number of nodes lacking the rule's property.
+func (s *Neo4jBackfillStore) CountMissing(ctx context.Context, rule BackfillRule) (int, error) {
+	query := fmt.Sprintf("MATCH (n:`%s`) WHERE n.`%s` IS NULL RETURN count(n) as missing", rule.Label, rule.Property)
+	return s.count(ctx, s.db.ExecuteRead, query, "missing", nil)
+}
+
+// FillMissing sets the rule's default on up to limit nodes lacking the property.
+func (s *Neo4jBackfillStore) FillMissing(ctx context.Context, rule BackfillRule, limit int) (int, error) {
+	query := fmt.Sprintf(`
+		MATCH (n:`+"`%s`"+`) WHERE n.`+"`%s`"+` IS NULL
+		WITH n LIMIT $limit
+		SET n.`+"`%s`"+` = %s
+		RETURN count(n) as updated
+	`, rule.Label, rule.Property, rule.Property, rule.Default)
+	return s.count(ctx, s.db.ExecuteWrite, query, "updated", map[string]any{"limit": limit})
+}
+
+// count runs a query returning a single count column.
+func (s *Neo4jBackfillStore) count(
+	ctx context.Context,
+	execute func(context.Context, neo4j.ManagedTransactionWork) (any, error),
+	query, column string,
+	params map[string]any,
+) (int, error) {
+	result, err := execute(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := res.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		value, _ := record.Get(column)
+		return int(value.(int64)), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// Ensure Neo4jBackfillStore implements IBackfillStore
+var _ IBackfillStore = (*Neo4jBackfillStore)(nil)
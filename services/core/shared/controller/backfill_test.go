@@ -0,0 +1,145 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixtureStore is an in-memory IBackfillStore over labelled property maps.
+// Defaults are stored verbatim so tests can assert which rule filled them.
+type fixtureStore struct {
+	nodes     map[string][]map[string]any
+	fillCalls int
+	fillErr   error
+}
+
+func (s *fixtureStore) CountMissing(ctx context.Context, rule BackfillRule) (int, error) {
+	missing := 0
+	for _, node := range s.nodes[rule.Label] {
+		if _, ok := node[rule.Property]; !ok {
+			missing++
+		}
+	}
+	return missing, nil
+}
+
+func (s *fixtureStore) FillMissing(ctx context.Context, rule BackfillRule, limit int) (int, error) {
+	s.fillCalls++
+	if s.fillErr != nil {
+		return 0, s.fillErr
+	}
+	updated := 0
+	for _, node := range s.nodes[rule.Label] {
+		if updated == limit {
+			break
+		}
+		if _, ok := node[rule.Property]; !ok {
+			node[rule.Property] = rule.Default
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func newFixtureStore() *fixtureStore {
+	return &fixtureStore{nodes: map[string][]map[string]any{
+		"User": {
+			{"id": "user-1", "email": "alice@example.com", "status": "ACTIVE", "createdAt": "then", "updatedAt": "then"},
+			{"id": "user-2", "email": "bob@example.com"},
+			{"id": "user-3", "email": "carol@example.com", "status": "SUSPENDED"},
+		},
+		"Tenant": {
+			{"id": "tenant-1", "name": "Acme", "slug": "acme", "plan": "PRO"},
+		},
+		"Membership": {
+			{"id": "membership-1", "role": "OWNER"},
+			{"id": "membership-2", "role": "MEMBER", "status": "PENDING", "joinedAt": "then"},
+		},
+	}}
+}
+
+func TestBackfill_DryRun_ChangesNothing(t *testing.T) {
+	// Arrange
+	store := newFixtureStore()
+
+	// Act
+	results, err := Backfill(context.Background(), store, BackfillRules, 10, true)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, len(BackfillRules))
+	assert.Zero(t, store.fillCalls)
+	assert.Equal(t, newFixtureStore().nodes, store.nodes)
+	for _, result := range results {
+		assert.Zero(t, result.Updated)
+	}
+	assert.Equal(t, BackfillResult{Rule: BackfillRules[0], Missing: 1}, results[0], "User.status")
+}
+
+func TestBackfill_Apply_SetsDefaults(t *testing.T) {
+	// Arrange
+	store := newFixtureStore()
+
+	// Act
+	results, err := Backfill(context.Background(), store, BackfillRules, 10, false)
+
+	// Assert
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.Equal(t, result.Missing, result.Updated, "%s.%s", result.Rule.Label, result.Rule.Property)
+	}
+
+	bob := store.nodes["User"][1]
+	assert.Equal(t, "'ACTIVE'", bob["status"])
+	assert.Equal(t, "datetime()", bob["createdAt"])
+	assert.Equal(t, "coalesce(n.createdAt, datetime())", bob["updatedAt"])
+	assert.Equal(t, "SUSPENDED", store.nodes["User"][2]["status"], "existing values are kept")
+	assert.Equal(t, "then", store.nodes["User"][0]["createdAt"])
+
+	acme := store.nodes["Tenant"][0]
+	assert.Equal(t, "PRO", acme["plan"])
+	assert.Equal(t, "'SHARED'", acme["isolationMode"])
+	assert.Equal(t, "'ACTIVE'", acme["status"])
+
+	assert.Equal(t, "'ACTIVE'", store.nodes["Membership"][0]["status"])
+	assert.Equal(t, "datetime()", store.nodes["Membership"][0]["joinedAt"])
+	assert.Equal(t, "PENDING", store.nodes["Membership"][1]["status"])
+
+	// A second run finds nothing left to fill
+	again, err := Backfill(context.Background(), store, BackfillRules, 10, true)
+	require.NoError(t, err)
+	for _, result := range again {
+		assert.Zero(t, result.Missing)
+	}
+}
+
+func TestBackfill_Apply_Batches(t *testing.T) {
+	// Arrange - five users missing status, filled two at a time
+	store := &fixtureStore{nodes: map[string][]map[string]any{"User": {{}, {}, {}, {}, {}}}}
+	rules := BackfillRules[:1]
+
+	// Act
+	results, err := Backfill(context.Background(), store, rules, 2, false)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []BackfillResult{{Rule: rules[0], Missing: 5, Updated: 5}}, results)
+	assert.Equal(t, 3, store.fillCalls)
+}
+
+func TestBackfill_Apply_Error(t *testing.T) {
+	// Arrange
+	store := newFixtureStore()
+	store.fillErr = errors.New("connection refused")
+
+	// Act
+	results, err := Backfill(context.Background(), store, BackfillRules, 10, false)
+
+	// Assert
+	assert.ErrorContains(t, err, "backfill User.status")
+	assert.Len(t, results, 1)
+}
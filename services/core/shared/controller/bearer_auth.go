@@ -0,0 +1,78 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// bearerPrefix starts an Authorization header carrying an access token.
+const bearerPrefix = "Bearer "
+
+// BearerAuth requires an access token in an "Authorization: Bearer <token>"
+// header and authenticates the request as the token's subject. Users listed
+// in platform_admin_ids also become platform admins. Requests without a
+// valid token are rejected with 401, except those HeaderUserAuth has already
+// authenticated in development.
+func BearerAuth(tokens *auth.TokenManager, cfg *config.Config) gin.HandlerFunc {
+	platformAdmins := platformAdminSet(cfg)
+
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			if _, err := auth.GetUserID(c.Request.Context()); err != nil {
+				RespondError(c, errors.ErrNotAuthenticated)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+			RespondError(c, errors.ErrInvalidToken)
+			return
+		}
+
+		claims, err := tokens.ParseToken(c.Request.Context(), strings.TrimSpace(header[len(bearerPrefix):]))
+		if err != nil {
+			RespondError(c, err)
+			return
+		}
+
+		ctx := auth.WithClaims(c.Request.Context(), claims)
+		if platformAdmins[claims.Subject] {
+			ctx = auth.WithPlatformAdmin(ctx)
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// platformAdminSet returns the user IDs listed in platform_admin_ids.
+func platformAdminSet(cfg *config.Config) map[string]bool {
+	platformAdmins := make(map[string]bool, len(cfg.Auth.PlatformAdminIDs))
+	for _, id := range cfg.Auth.PlatformAdminIDs {
+		platformAdmins[strings.TrimSpace(id)] = true
+	}
+	return platformAdmins
+}
+
+// RequirePlatformAdmin rejects requests that aren't from a platform admin;
+// unauthenticated ones get 401 and others 403. It goes after BearerAuth.
+func RequirePlatformAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if _, err := auth.GetUserID(ctx); err != nil {
+			RespondError(c, err)
+			return
+		}
+		if !auth.IsPlatformAdmin(ctx) {
+			RespondError(c, errors.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
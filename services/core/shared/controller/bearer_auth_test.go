@@ -0,0 +1,119 @@
+package shared
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// serveBearerAuth serves whoami through HeaderUserAuth and BearerAuth with
+// the given request headers.
+func serveBearerAuth(cfg *config.Config, tokens *auth.TokenManager, headers map[string]string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(HeaderUserAuth(cfg))
+	r.GET("/whoami", BearerAuth(tokens, cfg), func(c *gin.Context) {
+		id, _ := auth.GetUserID(c.Request.Context())
+		if auth.IsPlatformAdmin(c.Request.Context()) {
+			id += " (platform admin)"
+		}
+		c.String(http.StatusOK, id)
+	})
+
+	req, _ := http.NewRequest("GET", "/whoami", nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestBearerAuth(t *testing.T) {
+	tokens := auth.NewTokenManager("secret", time.Hour, nil)
+	valid, err := tokens.IssueToken("user-1", 0)
+	require.NoError(t, err)
+	expired, err := auth.NewTokenManager("secret", -time.Minute, nil).IssueToken("user-1", 0)
+	require.NoError(t, err)
+	forged, err := auth.NewTokenManager("other-secret", time.Hour, nil).IssueToken("user-1", 0)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc          string
+		authorization string
+		wantStatus    int
+		wantBody      string
+	}{
+		{desc: "valid token", authorization: "Bearer " + valid, wantStatus: http.StatusOK, wantBody: "user-1"},
+		{desc: "scheme is case-insensitive", authorization: "bearer " + valid, wantStatus: http.StatusOK, wantBody: "user-1"},
+		{desc: "expired token", authorization: "Bearer " + expired, wantStatus: http.StatusUnauthorized},
+		{desc: "forged token", authorization: "Bearer " + forged, wantStatus: http.StatusUnauthorized},
+		{desc: "malformed token", authorization: "Bearer not-a-token", wantStatus: http.StatusUnauthorized},
+		{desc: "wrong scheme", authorization: "Basic dXNlcjpwYXNz", wantStatus: http.StatusUnauthorized},
+		{desc: "missing token", authorization: "", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			cfg := &config.Config{}
+			headers := map[string]string{}
+			if tc.authorization != "" {
+				headers["Authorization"] = tc.authorization
+			}
+
+			// Act
+			w := serveBearerAuth(cfg, tokens, headers)
+
+			// Assert
+			assert.Equal(t, tc.wantStatus, w.Code)
+			if tc.wantStatus == http.StatusOK {
+				assert.Equal(t, tc.wantBody, w.Body.String())
+				return
+			}
+			var body ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, errors.CodeUnauthenticated, body.Error.Code)
+		})
+	}
+}
+
+func TestBearerAuth_PlatformAdmin(t *testing.T) {
+	// Arrange
+	tokens := auth.NewTokenManager("secret", time.Hour, nil)
+	token, err := tokens.IssueToken("admin-1", 0)
+	require.NoError(t, err)
+	cfg := &config.Config{Auth: config.AuthConfig{PlatformAdminIDs: []string{"admin-1"}}}
+
+	// Act
+	w := serveBearerAuth(cfg, tokens, map[string]string{"Authorization": "Bearer " + token})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "admin-1 (platform admin)", w.Body.String())
+}
+
+func TestBearerAuth_HeaderUserInDevelopment(t *testing.T) {
+	// Arrange
+	tokens := auth.NewTokenManager("secret", time.Hour, nil)
+	cfg := &config.Config{
+		Server: config.ServerConfig{Environment: "development"},
+		Auth:   config.AuthConfig{AllowHeaderUserID: true},
+	}
+
+	// Act
+	w := serveBearerAuth(cfg, tokens, map[string]string{UserIDHeader: "user-1"})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user-1", w.Body.String(), "header auth stands in for a token")
+}
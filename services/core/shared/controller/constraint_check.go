@@ -0,0 +1,66 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// RequiredConstraints are the uniqueness constraints the application relies on
+// for data integrity. They are created by the core migrations.
+var RequiredConstraints = []string{
+	"user_id_unique",
+	"user_email_unique",
+	"tenant_id_unique",
+	"tenant_slug_unique",
+	"membership_id_unique",
+}
+
+// CheckConstraints verifies that every required constraint exists in the database.
+// It returns the names of any missing constraints, in the order given.
+// A freshly restored or partially migrated database can lack these, which
+// silently allows duplicate records.
+func CheckConstraints(ctx context.Context, db IDatabase, required []string) ([]string, error) {
+	result, err := db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, "SHOW CONSTRAINTS YIELD name RETURN name", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		records, err := res.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, 0, len(records))
+		for _, record := range records {
+			if name, ok := record.Values[0].(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list constraints: %w", err)
+	}
+
+	present, _ := result.([]string)
+	return missingConstraints(present, required), nil
+}
+
+// missingConstraints returns the required names not found in present.
+func missingConstraints(present, required []string) []string {
+	existing := make(map[string]bool, len(present))
+	for _, name := range present {
+		existing[name] = true
+	}
+
+	var missing []string
+	for _, name := range required {
+		if !existing[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,51 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConstraints_AllPresent(t *testing.T) {
+	// Arrange - SHOW CONSTRAINTS fixture including an unrelated constraint
+	mockDB := &MockDatabase{readResult: append([]string{"feature_flag_key_unique"}, RequiredConstraints...)}
+
+	// Act
+	missing, err := CheckConstraints(context.Background(), mockDB, RequiredConstraints)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestCheckConstraints_DetectsMissing(t *testing.T) {
+	// Arrange - user_email_unique was never created
+	mockDB := &MockDatabase{readResult: []string{
+		"user_id_unique",
+		"tenant_id_unique",
+		"tenant_slug_unique",
+		"membership_id_unique",
+	}}
+
+	// Act
+	missing, err := CheckConstraints(context.Background(), mockDB, RequiredConstraints)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user_email_unique"}, missing)
+}
+
+func TestCheckConstraints_QueryError(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{readError: errors.New("connection refused")}
+
+	// Act
+	missing, err := CheckConstraints(context.Background(), mockDB, RequiredConstraints)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, missing)
+}
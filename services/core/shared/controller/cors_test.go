@@ -0,0 +1,107 @@
+package shared
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCORSRouter returns a router guarding /graphql with CORS for the frontend origin.
+func newCORSRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	cors := CORS([]string{"http://localhost:5173/"})
+	r := gin.New()
+	r.OPTIONS("/graphql", cors)
+	r.POST("/graphql", cors, func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	// Arrange
+	r := newCORSRouter()
+	req, _ := http.NewRequest("OPTIONS", "/graphql", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "authorization, content-type")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "http://localhost:5173", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "POST")
+	assert.Equal(t, "Authorization, Content-Type, X-Request-ID", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+}
+
+func TestCORS_AllowedRequest(t *testing.T) {
+	// Arrange
+	r := newCORSRouter()
+	req, _ := http.NewRequest("POST", "/graphql", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "http://localhost:5173", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, RequestIDHeader, w.Header().Get("Access-Control-Expose-Headers"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func TestCORS_DisallowedOrigin(t *testing.T) {
+	for _, method := range []string{"OPTIONS", "POST"} {
+		// Arrange
+		r := newCORSRouter()
+		req, _ := http.NewRequest(method, "/graphql", nil)
+		req.Header.Set("Origin", "https://evil.example")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+
+		// Act
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, w.Code, method)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"), method)
+		assert.Contains(t, w.Body.String(), `"code":"FORBIDDEN"`, method)
+	}
+}
+
+func TestCORS_SameOriginAndNonBrowserRequests(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		origin string
+	}{
+		{"no Origin header", ""},
+		{"same origin", "http://api.example:8080"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			r := newCORSRouter()
+			req, _ := http.NewRequest("POST", "http://api.example:8080/graphql", nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+
+			// Act
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
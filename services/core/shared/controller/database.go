@@ -5,57 +5,139 @@ package shared
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/dbctx"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/logging"
+	"github.com/yourusername/grgn-stack/pkg/metrics"
+	"github.com/yourusername/grgn-stack/pkg/retry"
 )
 
 // Neo4jDB wraps the Neo4j driver and provides database operations.
 // It implements the database abstraction for the GRGN stack.
+//
+// When a read URI is configured, reads go to a separate replica driver and
+// writes to the primary. In ReadRoutingCausal mode all sessions share a
+// bookmark manager so a read issued after a write observes that write.
 type Neo4jDB struct {
-	driver neo4j.DriverWithContext
-	config *config.Config
+	driver     neo4j.DriverWithContext
+	readDriver neo4j.DriverWithContext // nil unless a read URI is configured
+	bookmarks  neo4j.BookmarkManager   // shared across sessions in ReadRoutingCausal mode
+	config     *config.Config
+
+	serverInfoWarning sync.Once // logs the first partial GetServerInfo
 }
 
-// NewNeo4jDB creates a new Neo4j database connection with connection pooling.
-func NewNeo4jDB(cfg *config.Config) (*Neo4jDB, error) {
+// NewNeo4jDB creates a new Neo4j database connection with connection pooling
+// and verifies connectivity, retrying with policy while the database starts
+// up. The zero Policy makes a single attempt.
+func NewNeo4jDB(cfg *config.Config, policy retry.Policy) (*Neo4jDB, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("config cannot be nil")
 	}
 
-	// Configure connection pool settings
-	poolConfig := func(conf *neo4j.Config) {
+	if cfg.Database.ConnectionLivenessCheckTimeout < 0 {
+		return nil, fmt.Errorf("connection liveness check timeout cannot be negative")
+	}
+	if cfg.Database.MaxConnectionPoolSize < 0 {
+		return nil, fmt.Errorf("max connection pool size cannot be negative")
+	}
+	if cfg.Database.ConnectionAcquisitionTimeout < 0 {
+		return nil, fmt.Errorf("connection acquisition timeout cannot be negative")
+	}
+	if cfg.Database.QueryTimeout < 0 {
+		return nil, fmt.Errorf("query timeout cannot be negative")
+	}
+	routing, err := parseReadRoutingMode(cfg.Database.ReadRouting, cfg.Database.Neo4jReadURI != "")
+	if err != nil {
+		return nil, err
+	}
+	poolConfig := driverConfig(cfg)
+
+	auth := neo4j.BasicAuth(cfg.Database.Neo4jUsername, cfg.Database.Neo4jPassword, "")
+
+	// Create the driver
+	driver, err := neo4j.NewDriverWithContext(cfg.Database.Neo4jURI, auth, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
+	}
+
+	db := &Neo4jDB{
+		driver: driver,
+		config: cfg,
+	}
+
+	// Optional read replica driver
+	if cfg.Database.Neo4jReadURI != "" {
+		readDriver, err := neo4j.NewDriverWithContext(cfg.Database.Neo4jReadURI, auth, poolConfig)
+		if err != nil {
+			_ = driver.Close(context.Background())
+			return nil, fmt.Errorf("failed to create Neo4j read driver: %w", err)
+		}
+		db.readDriver = readDriver
+	}
+	if routing == ReadRoutingCausal {
+		db.bookmarks = neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{})
+	}
+
+	if err := WaitForConnectivity(context.Background(), db, policy); err != nil {
+		_ = db.Close(context.Background())
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// driverConfig returns the connection pool settings applied to each driver.
+func driverConfig(cfg *config.Config) func(conf *neo4j.Config) {
+	return func(conf *neo4j.Config) {
 		conf.MaxConnectionPoolSize = 50
 		conf.MaxConnectionLifetime = 1 * time.Hour
 		conf.ConnectionAcquisitionTimeout = 2 * time.Minute
 		conf.SocketConnectTimeout = 5 * time.Second
 		conf.SocketKeepalive = true
 
+		// Verify idle connections on acquire so ones dropped by a proxy are
+		// replaced instead of failing the query
+		conf.ConnectionLivenessCheckTimeout = cfg.Database.ConnectionLivenessCheckTimeout
+
 		// Adjust pool size for production vs development
 		if cfg.IsProduction() {
 			conf.MaxConnectionPoolSize = 100
 		} else if cfg.Server.Environment == "development" {
 			conf.MaxConnectionPoolSize = 10
 		}
-	}
 
-	// Create the driver
-	driver, err := neo4j.NewDriverWithContext(
-		cfg.Database.Neo4jURI,
-		neo4j.BasicAuth(cfg.Database.Neo4jUsername, cfg.Database.Neo4jPassword, ""),
-		poolConfig,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
+		// Configured values override the defaults
+		if cfg.Database.MaxConnectionPoolSize > 0 {
+			conf.MaxConnectionPoolSize = cfg.Database.MaxConnectionPoolSize
+		}
+		if cfg.Database.ConnectionAcquisitionTimeout > 0 {
+			conf.ConnectionAcquisitionTimeout = cfg.Database.ConnectionAcquisitionTimeout
+		}
 	}
+}
 
-	db := &Neo4jDB{
-		driver: driver,
-		config: cfg,
+// driverFor returns the driver serving the given access mode.
+func (db *Neo4jDB) driverFor(mode neo4j.AccessMode) neo4j.DriverWithContext {
+	if mode == neo4j.AccessModeRead && db.readDriver != nil {
+		return db.readDriver
 	}
+	return db.driver
+}
 
-	return db, nil
+// newSession opens a session on the driver for config.AccessMode, attaching
+// the shared bookmark manager unless the caller supplied one.
+func (db *Neo4jDB) newSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	if config.BookmarkManager == nil && db.bookmarks != nil {
+		config.BookmarkManager = db.bookmarks
+	}
+	return db.driverFor(config.AccessMode).NewSession(ctx, config)
 }
 
 // VerifyConnectivity checks if the database is accessible and responsive.
@@ -70,43 +152,153 @@ func (db *Neo4jDB) VerifyConnectivity(ctx context.Context) error {
 		return fmt.Errorf("failed to verify connectivity: %w", err)
 	}
 
+	if db.readDriver != nil {
+		if err := db.readDriver.VerifyConnectivity(ctx); err != nil {
+			return fmt.Errorf("failed to verify read replica connectivity: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// connectivityAttemptTimeout bounds each check made by WaitForConnectivity.
+const connectivityAttemptTimeout = 5 * time.Second
+
+// WaitForConnectivity verifies connectivity, retrying with policy while the
+// database starts up. On failure the error reports how many attempts were
+// made and wraps the last one.
+func WaitForConnectivity(ctx context.Context, db IDatabase, policy retry.Policy) error {
+	attempts := 0
+	err := retry.Do(ctx, policy, func() error {
+		attempts++
+		attemptCtx, cancel := context.WithTimeout(ctx, connectivityAttemptTimeout)
+		defer cancel()
+		return db.VerifyConnectivity(attemptCtx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to Neo4j after %d attempt(s): %w", attempts, err)
+	}
+	return nil
+}
+
+// IsTransientConnectError reports whether a connectivity check failed in a
+// way waiting can fix, such as the database still starting up, for use as
+// a retry.Policy's Retryable. Rejected credentials, other Neo4j client
+// errors and driver misuse fail the same way on every attempt.
+func IsTransientConnectError(err error) bool {
+	// The driver reports handshake failures wrapped without Unwrap
+	var connectivityErr *neo4j.ConnectivityError
+	if errors.As(err, &connectivityErr) && connectivityErr.Inner != nil {
+		err = connectivityErr.Inner
+	}
+
+	var neo4jErr *neo4j.Neo4jError
+	if errors.As(err, &neo4jErr) && neo4jErr.Classification() == "ClientError" {
+		return false
+	}
+	var usageErr *neo4j.UsageError
+	var authErr *neo4j.InvalidAuthenticationError
+	var tokenErr *neo4j.TokenExpiredError
+	return !errors.As(err, &usageErr) && !errors.As(err, &authErr) && !errors.As(err, &tokenErr)
+}
+
 // GetDriver returns the underlying Neo4j driver for advanced usage.
 func (db *Neo4jDB) GetDriver() neo4j.DriverWithContext {
 	return db.driver
 }
 
-// ExecuteRead executes a read transaction with automatic retry.
+// ExecuteRead executes a read transaction with automatic retry, bounded by
+// the configured query timeout.
 func (db *Neo4jDB) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
-	session := db.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	return db.ExecuteReadWithTimeout(ctx, db.queryTimeout(), work)
+}
+
+// ExecuteWrite executes a write transaction with automatic retry, bounded by
+// the configured query timeout.
+func (db *Neo4jDB) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return db.ExecuteWriteWithTimeout(ctx, db.queryTimeout(), work)
+}
+
+// ExecuteReadWithTimeout executes a read transaction with automatic retry,
+// aborting it after timeout. A non-positive timeout leaves only ctx's deadline.
+func (db *Neo4jDB) ExecuteReadWithTimeout(ctx context.Context, timeout time.Duration, work neo4j.ManagedTransactionWork) (any, error) {
+	return db.executeRead(ctx, timeout, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead}, work)
+}
+
+// executeRead runs work in a read transaction on a session opened with config.
+func (db *Neo4jDB) executeRead(ctx context.Context, timeout time.Duration, config neo4j.SessionConfig, work neo4j.ManagedTransactionWork) (any, error) {
+	ctx, cancel := withQueryTimeout(ctx, timeout)
+	defer cancel()
+
+	db.checkTxMode(ctx, neo4j.AccessModeRead)
+	session := db.newSession(ctx, config)
 	defer session.Close(ctx)
 
-	result, err := session.ExecuteRead(ctx, work)
+	start := time.Now()
+	result, err := session.ExecuteRead(ctx, work, txTimeout(timeout)...)
+	elapsed := time.Since(start)
+	db.logSlowQuery(ctx, neo4j.AccessModeRead, elapsed)
+	metrics.ObserveTransaction("read", err, elapsed)
 	if err != nil {
-		return nil, fmt.Errorf("read transaction failed: %w", err)
+		return nil, fmt.Errorf("read transaction failed: %w", mapContextError(err))
 	}
 
 	return result, nil
 }
 
-// ExecuteWrite executes a write transaction with automatic retry.
-func (db *Neo4jDB) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
-	session := db.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+// ExecuteWriteWithTimeout executes a write transaction with automatic retry,
+// aborting it after timeout. A non-positive timeout leaves only ctx's deadline.
+// The write's bookmarks are recorded in ctx for ExecuteReadAfterWrite.
+func (db *Neo4jDB) ExecuteWriteWithTimeout(ctx context.Context, timeout time.Duration, work neo4j.ManagedTransactionWork) (any, error) {
+	ctx, cancel := withQueryTimeout(ctx, timeout)
+	defer cancel()
+
+	db.checkTxMode(ctx, neo4j.AccessModeWrite)
+	session := db.newSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer session.Close(ctx)
 
-	result, err := session.ExecuteWrite(ctx, work)
+	start := time.Now()
+	result, err := session.ExecuteWrite(ctx, work, txTimeout(timeout)...)
+	elapsed := time.Since(start)
+	db.logSlowQuery(ctx, neo4j.AccessModeWrite, elapsed)
+	metrics.ObserveTransaction("write", err, elapsed)
 	if err != nil {
-		return nil, fmt.Errorf("write transaction failed: %w", err)
+		return nil, fmt.Errorf("write transaction failed: %w", mapContextError(err))
 	}
+	dbctx.AddBookmarks(ctx, session.LastBookmarks())
 
 	return result, nil
 }
 
+// queryTimeout returns the configured per-transaction timeout.
+func (db *Neo4jDB) queryTimeout() time.Duration {
+	if db.config == nil {
+		return 0
+	}
+	return db.config.Database.QueryTimeout
+}
+
+// withQueryTimeout derives a context that ends after timeout, if positive.
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// txTimeout also asks the server to abort the transaction after timeout, so
+// a slow query stops running rather than only being abandoned client-side.
+func txTimeout(timeout time.Duration) []func(*neo4j.TransactionConfig) {
+	if timeout <= 0 {
+		return nil
+	}
+	return []func(*neo4j.TransactionConfig){neo4j.WithTxTimeout(timeout)}
+}
+
 // NewSession creates a new session for manual transaction management.
+// Read-mode sessions use the read replica when one is configured.
 func (db *Neo4jDB) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
-	return db.driver.NewSession(ctx, config)
+	return db.newSession(ctx, config)
 }
 
 // Close gracefully closes the database connection and releases resources.
@@ -115,6 +307,12 @@ func (db *Neo4jDB) Close(ctx context.Context) error {
 		return nil
 	}
 
+	if db.readDriver != nil {
+		if err := db.readDriver.Close(ctx); err != nil {
+			return fmt.Errorf("failed to close Neo4j read driver: %w", err)
+		}
+	}
+
 	if err := db.driver.Close(ctx); err != nil {
 		return fmt.Errorf("failed to close Neo4j driver: %w", err)
 	}
@@ -127,17 +325,31 @@ func (db *Neo4jDB) Ping(ctx context.Context) error {
 	return db.VerifyConnectivity(ctx)
 }
 
+// UnknownServerVersion stands in for server details GetServerInfo could not read.
+const UnknownServerVersion = "unknown"
+
 // GetServerInfo retrieves information about the connected Neo4j server.
+//
+// dbms.components() needs privileges the application user may not have. When
+// the procedure is denied or missing, GetServerInfo falls back to the agent
+// string from the connection handshake and reports whatever it can't read as
+// UnknownServerVersion, with "partial" set, instead of failing.
 func (db *Neo4jDB) GetServerInfo(ctx context.Context) (map[string]any, error) {
 	session := db.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
 	result, err := session.Run(ctx, "CALL dbms.components() YIELD name, versions, edition", nil)
+	if isProcedureUnavailable(err) {
+		return db.partialServerInfo(ctx, err), nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get server info: %w", err)
 	}
 
 	record, err := result.Single(ctx)
+	if isProcedureUnavailable(err) {
+		return db.partialServerInfo(ctx, err), nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read server info: %w", err)
 	}
@@ -149,3 +361,51 @@ func (db *Neo4jDB) GetServerInfo(ctx context.Context) (map[string]any, error) {
 
 	return info, nil
 }
+
+// partialServerInfo builds server info without dbms.components(), logging
+// cause the first time it is needed.
+func (db *Neo4jDB) partialServerInfo(ctx context.Context, cause error) map[string]any {
+	db.serverInfoWarning.Do(func() {
+		logging.FromContext(ctx).WarnContext(ctx, "dbms.components() unavailable, reporting partial server info", "error", cause)
+	})
+
+	info := map[string]any{
+		"name":     "Neo4j Kernel",
+		"versions": []any{UnknownServerVersion},
+		"edition":  UnknownServerVersion,
+		"partial":  true,
+	}
+
+	// The agent is sent during the handshake, so it needs no privileges
+	if server, err := db.driver.GetServerInfo(ctx); err == nil {
+		if version, ok := strings.CutPrefix(server.Agent(), "Neo4j/"); ok && version != "" {
+			info["versions"] = []any{version}
+		}
+	}
+	return info
+}
+
+// isProcedureUnavailable reports whether err means the server refused or
+// doesn't provide a procedure, as opposed to the query failing.
+func isProcedureUnavailable(err error) bool {
+	var neoErr *neo4j.Neo4jError
+	if !errors.As(err, &neoErr) {
+		return false
+	}
+	return strings.HasPrefix(neoErr.Code, "Neo.ClientError.Security.") ||
+		neoErr.Code == "Neo.ClientError.Procedure.ProcedureNotFound"
+}
+
+// mapContextError tags deadline and cancellation failures with ErrTimeout or
+// ErrCancelled so callers can tell them apart from database errors.
+// The original error stays in the chain.
+func mapContextError(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %w", errors.ErrTimeout, err)
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("%w: %w", errors.ErrCancelled, err)
+	default:
+		return err
+	}
+}
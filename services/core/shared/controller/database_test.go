@@ -0,0 +1,569 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/retry"
+)
+
+// fakeDriver records the sessions opened on it and the deadline and
+// transaction timeout of the last transaction run. Transactions take delay.
+// Methods not overridden panic via the nil embedded interface.
+type fakeDriver struct {
+	neo4j.DriverWithContext
+	name     string
+	sessions []neo4j.SessionConfig
+	delay    time.Duration
+
+	deadline    time.Time
+	hasDeadline bool
+	txTimeout   time.Duration
+
+	// err fails every transaction when set
+	err error
+}
+
+// record captures ctx's deadline and the transaction config.
+func (d *fakeDriver) record(ctx context.Context, configurers []func(*neo4j.TransactionConfig)) {
+	time.Sleep(d.delay)
+	d.deadline, d.hasDeadline = ctx.Deadline()
+	config := neo4j.TransactionConfig{}
+	for _, configure := range configurers {
+		configure(&config)
+	}
+	d.txTimeout = config.Timeout
+}
+
+func (d *fakeDriver) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	d.sessions = append(d.sessions, config)
+	return &fakeSession{driver: d, config: config}
+}
+
+// fakeSession simulates bookmark handling: writes publish a bookmark to the
+// session's bookmark manager, reads capture the bookmarks they would wait for,
+// both the session's initial ones and the manager's.
+type fakeSession struct {
+	neo4j.SessionWithContext
+	driver *fakeDriver
+	config neo4j.SessionConfig
+	seen   neo4j.Bookmarks
+	last   neo4j.Bookmarks
+}
+
+func (s *fakeSession) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	s.driver.record(ctx, configurers)
+	if s.driver.err != nil {
+		return nil, s.driver.err
+	}
+	s.seen = s.config.Bookmarks
+	if s.config.BookmarkManager != nil {
+		bookmarks, err := s.config.BookmarkManager.GetBookmarks(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.seen = neo4j.CombineBookmarks(s.seen, bookmarks)
+	}
+	return s.seen, nil
+}
+
+func (s *fakeSession) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	s.driver.record(ctx, configurers)
+	if s.driver.err != nil {
+		return nil, s.driver.err
+	}
+	s.last = neo4j.Bookmarks{"bm:" + s.driver.name}
+	if s.config.BookmarkManager != nil {
+		if err := s.config.BookmarkManager.UpdateBookmarks(ctx, nil, s.last); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeSession) LastBookmarks() neo4j.Bookmarks {
+	return s.last
+}
+
+func (s *fakeSession) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestNeo4jDB_ReadReplica_RoutesByAccessMode(t *testing.T) {
+	// Arrange
+	primary := &fakeDriver{name: "primary"}
+	replica := &fakeDriver{name: "replica"}
+	db := &Neo4jDB{
+		driver:     primary,
+		readDriver: replica,
+		bookmarks:  neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{}),
+	}
+	ctx := context.Background()
+
+	// Act
+	_, writeErr := db.ExecuteWrite(ctx, nil)
+	seen, readErr := db.ExecuteRead(ctx, nil)
+
+	// Assert
+	require.NoError(t, writeErr)
+	require.NoError(t, readErr)
+
+	require.Len(t, primary.sessions, 1)
+	assert.Equal(t, neo4j.AccessModeWrite, primary.sessions[0].AccessMode)
+	require.Len(t, replica.sessions, 1)
+	assert.Equal(t, neo4j.AccessModeRead, replica.sessions[0].AccessMode)
+
+	// The read on the replica waits for the bookmark produced by the primary write
+	assert.Same(t, primary.sessions[0].BookmarkManager, replica.sessions[0].BookmarkManager)
+	assert.Equal(t, neo4j.Bookmarks{"bm:primary"}, seen)
+}
+
+func TestNeo4jDB_NoReadReplica_UsesPrimary(t *testing.T) {
+	// Arrange
+	primary := &fakeDriver{name: "primary"}
+	db := &Neo4jDB{driver: primary}
+	ctx := context.Background()
+
+	// Act
+	_, err := db.ExecuteRead(ctx, nil)
+	require.NoError(t, err)
+	session := db.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+
+	// Assert
+	assert.NotNil(t, session)
+	require.Len(t, primary.sessions, 2)
+	assert.Nil(t, primary.sessions[0].BookmarkManager)
+}
+
+func TestNeo4jDB_QueryTimeout(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		configured time.Duration
+		execute    func(db *Neo4jDB, ctx context.Context) error
+		want       time.Duration
+	}{
+		{"read uses configured timeout", 30 * time.Second, func(db *Neo4jDB, ctx context.Context) error {
+			_, err := db.ExecuteRead(ctx, nil)
+			return err
+		}, 30 * time.Second},
+		{"write uses configured timeout", 30 * time.Second, func(db *Neo4jDB, ctx context.Context) error {
+			_, err := db.ExecuteWrite(ctx, nil)
+			return err
+		}, 30 * time.Second},
+		{"read overrides configured timeout", 30 * time.Second, func(db *Neo4jDB, ctx context.Context) error {
+			_, err := db.ExecuteReadWithTimeout(ctx, 2*time.Second, nil)
+			return err
+		}, 2 * time.Second},
+		{"write overrides configured timeout", 0, func(db *Neo4jDB, ctx context.Context) error {
+			_, err := db.ExecuteWriteWithTimeout(ctx, 2*time.Second, nil)
+			return err
+		}, 2 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			driver := &fakeDriver{name: "primary"}
+			db := &Neo4jDB{
+				driver: driver,
+				config: &config.Config{Database: config.DatabaseConfig{QueryTimeout: tc.configured}},
+			}
+			start := time.Now()
+
+			// Act
+			err := tc.execute(db, context.Background())
+
+			// Assert
+			require.NoError(t, err)
+			require.True(t, driver.hasDeadline)
+			assert.WithinDuration(t, start.Add(tc.want), driver.deadline, time.Second)
+			assert.Equal(t, tc.want, driver.txTimeout)
+		})
+	}
+}
+
+func TestNeo4jDB_ZeroQueryTimeout_KeepsCallerDeadline(t *testing.T) {
+	// Arrange
+	driver := &fakeDriver{name: "primary"}
+	db := &Neo4jDB{driver: driver, config: &config.Config{}}
+
+	// Act
+	_, err := db.ExecuteRead(context.Background(), nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, driver.hasDeadline)
+	assert.Zero(t, driver.txTimeout)
+}
+
+func TestNewNeo4jDB_NegativeQueryTimeout(t *testing.T) {
+	// Arrange
+	cfg := &config.Config{Database: config.DatabaseConfig{
+		Neo4jURI:     "bolt://localhost:7687",
+		QueryTimeout: -time.Second,
+	}}
+
+	// Act
+	db, err := NewNeo4jDB(cfg, retry.Policy{})
+
+	// Assert
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, "query timeout")
+}
+
+func TestDriverConfig_AppliesLivenessCheckTimeout(t *testing.T) {
+	testCases := []struct {
+		timeout time.Duration
+		desc    string
+	}{
+		{30 * time.Second, "configured timeout"},
+		{0, "zero tests every acquired connection"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			cfg := &config.Config{Database: config.DatabaseConfig{ConnectionLivenessCheckTimeout: tc.timeout}}
+			conf := &neo4j.Config{ConnectionLivenessCheckTimeout: time.Hour}
+
+			// Act
+			driverConfig(cfg)(conf)
+
+			// Assert
+			assert.Equal(t, tc.timeout, conf.ConnectionLivenessCheckTimeout)
+			assert.Equal(t, time.Hour, conf.MaxConnectionLifetime)
+		})
+	}
+}
+
+func TestDriverConfig_PoolSettings(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		environment string
+		database    config.DatabaseConfig
+		poolSize    int
+		acquisition time.Duration
+	}{
+		{"production default", "production", config.DatabaseConfig{}, 100, 2 * time.Minute},
+		{"development default", "development", config.DatabaseConfig{}, 10, 2 * time.Minute},
+		{"staging default", "staging", config.DatabaseConfig{}, 50, 2 * time.Minute},
+		{
+			"configured values override", "production",
+			config.DatabaseConfig{MaxConnectionPoolSize: 25, ConnectionAcquisitionTimeout: 15 * time.Second},
+			25, 15 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			cfg := &config.Config{Server: config.ServerConfig{Environment: tc.environment}, Database: tc.database}
+			conf := &neo4j.Config{}
+
+			// Act
+			driverConfig(cfg)(conf)
+
+			// Assert
+			assert.Equal(t, tc.poolSize, conf.MaxConnectionPoolSize)
+			assert.Equal(t, tc.acquisition, conf.ConnectionAcquisitionTimeout)
+		})
+	}
+}
+
+func TestNewNeo4jDB_NegativePoolSettings(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		database config.DatabaseConfig
+		message  string
+	}{
+		{"pool size", config.DatabaseConfig{MaxConnectionPoolSize: -1}, "max connection pool size"},
+		{"acquisition timeout", config.DatabaseConfig{ConnectionAcquisitionTimeout: -time.Second}, "connection acquisition timeout"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			tc.database.Neo4jURI = "bolt://localhost:7687"
+			cfg := &config.Config{Database: tc.database}
+
+			// Act
+			db, err := NewNeo4jDB(cfg, retry.Policy{})
+
+			// Assert
+			assert.Nil(t, db)
+			assert.ErrorContains(t, err, tc.message)
+		})
+	}
+}
+
+func TestNewNeo4jDB_NegativeLivenessCheckTimeout(t *testing.T) {
+	// Arrange
+	cfg := &config.Config{Database: config.DatabaseConfig{
+		Neo4jURI:                       "bolt://localhost:7687",
+		ConnectionLivenessCheckTimeout: -time.Second,
+	}}
+
+	// Act
+	db, err := NewNeo4jDB(cfg, retry.Policy{})
+
+	// Assert
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, "liveness check timeout")
+}
+
+// flakyDatabase fails connectivity checks until the given attempt.
+type flakyDatabase struct {
+	MockDatabase
+	readyAt int
+	checks  int
+}
+
+func (d *flakyDatabase) VerifyConnectivity(ctx context.Context) error {
+	d.checks++
+	if d.checks < d.readyAt {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestWaitForConnectivity_RetriesUntilReady(t *testing.T) {
+	// Arrange
+	db := &flakyDatabase{readyAt: 3}
+	policy := retry.Policy{MaxAttempts: 5, BaseDelay: time.Millisecond}
+
+	// Act
+	err := WaitForConnectivity(context.Background(), db, policy)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, db.checks)
+}
+
+func TestWaitForConnectivity_GivesUp(t *testing.T) {
+	// Arrange
+	db := &flakyDatabase{readyAt: 10}
+	policy := retry.Policy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+
+	// Act
+	err := WaitForConnectivity(context.Background(), db, policy)
+
+	// Assert
+	assert.ErrorContains(t, err, "after 2 attempt(s)")
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, 2, db.checks)
+}
+
+func TestIsTransientConnectError(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		err       error
+		transient bool
+	}{
+		{"connection refused", errors.New("connection refused"), true},
+		{"transient server error", &neo4j.Neo4jError{Code: "Neo.TransientError.General.DatabaseUnavailable"}, true},
+		{"bad credentials", &neo4j.Neo4jError{Code: "Neo.ClientError.Security.Unauthorized"}, false},
+		{"bad credentials in handshake", &neo4j.ConnectivityError{Inner: &neo4j.Neo4jError{Code: "Neo.ClientError.Security.Unauthorized"}}, false},
+		{"unknown database", fmt.Errorf("failed to verify connectivity: %w", &neo4j.Neo4jError{Code: "Neo.ClientError.Database.DatabaseNotFound"}), false},
+		{"driver misuse", &neo4j.UsageError{Message: "unsupported scheme"}, false},
+		{"invalid auth token", &neo4j.InvalidAuthenticationError{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.transient, IsTransientConnectError(tc.err))
+		})
+	}
+}
+
+// rejectedDatabase fails every connectivity check with bad credentials.
+type rejectedDatabase struct {
+	MockDatabase
+	checks int
+}
+
+func (d *rejectedDatabase) VerifyConnectivity(ctx context.Context) error {
+	d.checks++
+	return fmt.Errorf("failed to verify connectivity: %w", &neo4j.Neo4jError{Code: "Neo.ClientError.Security.Unauthorized"})
+}
+
+func TestWaitForConnectivity_StopsOnBadCredentials(t *testing.T) {
+	// Arrange
+	db := &rejectedDatabase{}
+	policy := retry.Policy{MaxAttempts: 5, BaseDelay: time.Millisecond, Retryable: IsTransientConnectError}
+
+	// Act
+	err := WaitForConnectivity(context.Background(), db, policy)
+
+	// Assert
+	assert.ErrorContains(t, err, "after 1 attempt(s)")
+	assert.Equal(t, 1, db.checks)
+}
+
+func TestNewNeo4jDB_RetriesConnectivity(t *testing.T) {
+	// Arrange - nothing listens on port 1, so every check is refused
+	cfg := &config.Config{Database: config.DatabaseConfig{Neo4jURI: "bolt://127.0.0.1:1"}}
+	retries := 0
+	policy := retry.Policy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		OnRetry:     func(attempt int, err error, delay time.Duration) { retries++ },
+	}
+
+	// Act
+	db, err := NewNeo4jDB(cfg, policy)
+
+	// Assert
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, "after 3 attempt(s)")
+	assert.Equal(t, 2, retries)
+}
+
+func TestNewNeo4jDB_ZeroPolicyTriesOnce(t *testing.T) {
+	// Arrange
+	cfg := &config.Config{Database: config.DatabaseConfig{Neo4jURI: "bolt://127.0.0.1:1"}}
+
+	// Act
+	db, err := NewNeo4jDB(cfg, retry.Policy{})
+
+	// Assert
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, "after 1 attempt(s)")
+}
+
+// serverInfoDriver answers dbms.components() with runErr, or a record when
+// runErr is nil, and reports agent from the handshake.
+type serverInfoDriver struct {
+	neo4j.DriverWithContext
+	runErr error
+	agent  string
+}
+
+func (d *serverInfoDriver) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	return &serverInfoSession{driver: d}
+}
+
+func (d *serverInfoDriver) GetServerInfo(ctx context.Context) (neo4j.ServerInfo, error) {
+	return &agentServerInfo{agent: d.agent}, nil
+}
+
+type serverInfoSession struct {
+	neo4j.SessionWithContext
+	driver *serverInfoDriver
+}
+
+func (s *serverInfoSession) Run(ctx context.Context, cypher string, params map[string]any, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ResultWithContext, error) {
+	if s.driver.runErr != nil {
+		return nil, s.driver.runErr
+	}
+	return &componentsResult{}, nil
+}
+
+func (s *serverInfoSession) Close(ctx context.Context) error {
+	return nil
+}
+
+type componentsResult struct {
+	neo4j.ResultWithContext
+}
+
+func (r *componentsResult) Single(ctx context.Context) (*neo4j.Record, error) {
+	return &neo4j.Record{
+		Keys:   []string{"name", "versions", "edition"},
+		Values: []any{"Neo4j Kernel", []any{"5.12.0"}, "enterprise"},
+	}, nil
+}
+
+type agentServerInfo struct {
+	neo4j.ServerInfo
+	agent string
+}
+
+func (i *agentServerInfo) Agent() string {
+	return i.agent
+}
+
+func TestNeo4jDB_GetServerInfo(t *testing.T) {
+	// Arrange
+	db := &Neo4jDB{driver: &serverInfoDriver{}}
+
+	// Act
+	info, err := db.GetServerInfo(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name": "Neo4j Kernel", "versions": []any{"5.12.0"}, "edition": "enterprise",
+	}, info)
+}
+
+func TestNeo4jDB_GetServerInfo_ProcedureUnavailable(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		code         string
+		agent        string
+		wantVersions []any
+	}{
+		{"permission denied", "Neo.ClientError.Security.Forbidden", "Neo4j/5.12.0", []any{"5.12.0"}},
+		{"procedure not found", "Neo.ClientError.Procedure.ProcedureNotFound", "Neo4j/5.12.0", []any{"5.12.0"}},
+		{"unrecognised agent", "Neo.ClientError.Security.Forbidden", "Memgraph", []any{UnknownServerVersion}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			db := &Neo4jDB{driver: &serverInfoDriver{
+				runErr: &neo4j.Neo4jError{Code: tc.code, Msg: "denied"},
+				agent:  tc.agent,
+			}}
+
+			// Act
+			info, err := db.GetServerInfo(context.Background())
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{
+				"name": "Neo4j Kernel", "versions": tc.wantVersions,
+				"edition": UnknownServerVersion, "partial": true,
+			}, info)
+		})
+	}
+}
+
+func TestNeo4jDB_GetServerInfo_WarnsOnce(t *testing.T) {
+	// Arrange
+	buf := captureDefaultLog(t)
+	db := &Neo4jDB{driver: &serverInfoDriver{
+		runErr: &neo4j.Neo4jError{Code: "Neo.ClientError.Security.Forbidden", Msg: "denied"},
+	}}
+
+	// Act
+	for range 3 {
+		_, err := db.GetServerInfo(context.Background())
+		require.NoError(t, err)
+	}
+
+	// Assert
+	assert.Equal(t, 1, strings.Count(buf.String(), "partial server info"))
+}
+
+func TestNeo4jDB_GetServerInfo_OtherErrorsFail(t *testing.T) {
+	// Arrange
+	db := &Neo4jDB{driver: &serverInfoDriver{
+		runErr: &neo4j.Neo4jError{Code: "Neo.TransientError.General.DatabaseUnavailable", Msg: "down"},
+	}}
+
+	// Act
+	info, err := db.GetServerInfo(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "failed to get server info")
+	assert.Nil(t, info)
+}
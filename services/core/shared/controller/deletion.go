@@ -0,0 +1,27 @@
+package shared
+
+import (
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// MapDeletionInfo converts a record with deletedAt, deletedBy and reason
+// columns into a DeletionInfo. Records deleted before the actor and reason
+// were tracked have neither set.
+func MapDeletionInfo(record *neo4j.Record) *model.DeletionInfo {
+	info := &model.DeletionInfo{}
+	if deletedAt, ok := record.Get("deletedAt"); ok && deletedAt != nil {
+		info.DeletedAt = deletedAt.(time.Time)
+	}
+	if deletedBy, ok := record.Get("deletedBy"); ok && deletedBy != nil {
+		by := deletedBy.(string)
+		info.DeletedBy = &by
+	}
+	if reason, ok := record.Get("reason"); ok && reason != nil {
+		r := reason.(string)
+		info.Reason = &r
+	}
+	return info
+}
@@ -0,0 +1,118 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/logging"
+)
+
+// ErrorPresenter converts resolver errors into client-facing GraphQL errors
+// with the error's code in extensions.code, so clients can branch on codes
+// instead of messages. Timeouts and cancellations get a clean message
+// instead of the wrapped driver error. Tenant access errors are told apart
+// so clients can offer to join (NOT_MEMBER) or to ask an admin (FORBIDDEN),
+// and a missing membership, e.g. from a stale invite link, is NOT_FOUND.
+// Unexpected errors are coded INTERNAL and keep their message; see
+// NewErrorPresenter to hide it.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	return presentError(ctx, err, false)
+}
+
+// NewErrorPresenter returns ErrorPresenter, replacing the messages of
+// unexpected errors with a generic one when maskInternal is set, as it is
+// in production, so driver and query details don't reach clients. The
+// original error is logged to the request's logger first.
+func NewErrorPresenter(maskInternal bool) graphql.ErrorPresenterFunc {
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		return presentError(ctx, err, maskInternal)
+	}
+}
+
+// presentError implements ErrorPresenter.
+func presentError(ctx context.Context, err error, maskInternal bool) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	switch {
+	case errors.Is(err, errors.ErrTimeout) || errors.Is(err, context.DeadlineExceeded):
+		setCode(gqlErr, errors.CodeTimeout, errors.ErrTimeout.Error())
+	case errors.Is(err, errors.ErrCancelled) || errors.Is(err, context.Canceled):
+		setCode(gqlErr, errors.CodeCancelled, errors.ErrCancelled.Error())
+	case errors.Is(err, errors.ErrMembershipNotFound):
+		setCode(gqlErr, errors.CodeNotFound, errors.ErrMembershipNotFound.Error())
+	case errors.Is(err, errors.ErrNotMember):
+		setCode(gqlErr, errors.CodeNotMember, errors.ErrNotMember.Error())
+	case errors.Is(err, errors.ErrForbidden):
+		setCode(gqlErr, errors.CodeForbidden, errors.ErrForbidden.Error())
+	case gqlErr.Err == nil:
+		// Built by gqlgen itself, e.g. a null for a non-null field; its
+		// message is already meant for clients
+	default:
+		code := errors.CodeOf(err)
+		message := gqlErr.Message
+		var coded *errors.CodedError
+		if errors.As(err, &coded) {
+			message = coded.Message
+		}
+		if code == errors.CodeInternal && maskInternal {
+			// The response and QueryLogger only see the masked message, so
+			// this is the one place the cause is kept
+			logging.FromContext(ctx).ErrorContext(ctx, "graphql internal error",
+				"path", gqlErr.Path.String(),
+				"error", err.Error(),
+			)
+			message = internalErrorMessage
+		}
+		setCode(gqlErr, code, message)
+		if fields := validationFields(err); fields != nil {
+			gqlErr.Extensions["fields"] = fields
+		}
+	}
+
+	return gqlErr
+}
+
+// validationFields lists the field problems err reports, as
+// extensions.fields entries for clients to highlight, or nil if it
+// reports none.
+func validationFields(err error) []map[string]string {
+	var problems errors.ValidationErrors
+	var single *errors.ValidationError
+	switch {
+	case errors.As(err, &problems):
+	case errors.As(err, &single):
+		problems = errors.ValidationErrors{*single}
+	default:
+		return nil
+	}
+
+	fields := make([]map[string]string, len(problems))
+	for i, problem := range problems {
+		fields[i] = map[string]string{"field": problem.Field, "message": problem.Message}
+	}
+	return fields
+}
+
+// setCode replaces the message and records the code extension.
+func setCode(gqlErr *gqlerror.Error, code, message string) {
+	gqlErr.Message = message
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]any{}
+	}
+	gqlErr.Extensions["code"] = code
+}
+
+// RecoverPanic logs a resolver panic with its stack to the request's logger
+// and fails the field with an INTERNAL error instead of gqlgen's default of
+// printing to stderr.
+func RecoverPanic(ctx context.Context, recovered any) error {
+	logging.FromContext(ctx).ErrorContext(ctx, "graphql resolver panicked",
+		"panic", fmt.Sprint(recovered),
+		"stack", string(debug.Stack()),
+	)
+	return errors.NewCodedError(errors.CodeInternal, internalErrorMessage, fmt.Errorf("panic: %v", recovered))
+}
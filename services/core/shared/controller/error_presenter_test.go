@@ -0,0 +1,204 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/logging"
+)
+
+func TestErrorPresenter_DeadlineExceededThroughLayers(t *testing.T) {
+	// Arrange - driver error, as wrapped by ExecuteRead and then a repository
+	dbErr := fmt.Errorf("read transaction failed: %w", mapContextError(context.DeadlineExceeded))
+	repoErr := fmt.Errorf("find tenant: %w", dbErr)
+
+	// Act
+	gqlErr := ErrorPresenter(context.Background(), repoErr)
+
+	// Assert
+	assert.ErrorIs(t, dbErr, errors.ErrTimeout)
+	assert.ErrorIs(t, dbErr, context.DeadlineExceeded)
+	assert.Equal(t, errors.CodeTimeout, gqlErr.Extensions["code"])
+	assert.Equal(t, "request timed out", gqlErr.Message)
+	assert.NotContains(t, gqlErr.Message, "transaction failed")
+}
+
+func TestErrorPresenter_Cancelled(t *testing.T) {
+	// Arrange
+	err := fmt.Errorf("write transaction failed: %w", mapContextError(context.Canceled))
+
+	// Act
+	gqlErr := ErrorPresenter(context.Background(), err)
+
+	// Assert
+	assert.Equal(t, errors.CodeCancelled, gqlErr.Extensions["code"])
+	assert.Equal(t, "request cancelled", gqlErr.Message)
+}
+
+func TestErrorPresenter_SentinelCodes(t *testing.T) {
+	testCases := []struct {
+		err     error
+		code    string
+		message string
+		desc    string
+	}{
+		{errors.ErrTenantNotFound, errors.CodeNotFound, "tenant not found", "not found"},
+		{fmt.Errorf("find user: %w", errors.ErrUserNotFound), errors.CodeNotFound, "find user: user not found", "wrapped"},
+		{errors.ErrAlreadyMember, errors.CodeConflict, errors.ErrAlreadyMember.Error(), "conflict"},
+		{errors.ErrNotAuthenticated, errors.CodeUnauthenticated, "user not authenticated", "unauthenticated"},
+		{errors.NewValidationError("name", "is required"), errors.CodeInvalidInput, "name: is required", "validation"},
+		{errors.NewCodedError(errors.CodeConflict, "tenant is busy", fmt.Errorf("lock held")), errors.CodeConflict, "tenant is busy", "coded"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Act
+			gqlErr := ErrorPresenter(context.Background(), tc.err)
+
+			// Assert
+			assert.Equal(t, tc.code, gqlErr.Extensions["code"])
+			assert.Equal(t, tc.message, gqlErr.Message)
+		})
+	}
+}
+
+func TestErrorPresenter_ValidationFields(t *testing.T) {
+	// Arrange
+	var problems errors.ValidationErrors
+	problems.Add("name", "is required")
+	problems.AddError("slug", errors.ErrInvalidSlug)
+
+	// Act
+	gqlErr := ErrorPresenter(context.Background(), problems.Err())
+
+	// Assert
+	assert.Equal(t, errors.CodeInvalidInput, gqlErr.Extensions["code"])
+	assert.Equal(t, "name: is required; slug: invalid slug format", gqlErr.Message)
+	assert.Equal(t, []map[string]string{
+		{"field": "name", "message": "is required"},
+		{"field": "slug", "message": "invalid slug format"},
+	}, gqlErr.Extensions["fields"])
+}
+
+func TestErrorPresenter_SingleValidationErrorField(t *testing.T) {
+	// Act
+	gqlErr := ErrorPresenter(context.Background(), errors.NewValidationError("email", "is required"))
+
+	// Assert
+	assert.Equal(t, []map[string]string{{"field": "email", "message": "is required"}}, gqlErr.Extensions["fields"])
+}
+
+func TestErrorPresenter_InternalErrors(t *testing.T) {
+	// Arrange
+	err := fmt.Errorf("create tenant: %w", fmt.Errorf("Neo.ClientError.Statement.SyntaxError"))
+
+	// Act
+	development := ErrorPresenter(context.Background(), err)
+	production := NewErrorPresenter(true)(context.Background(), err)
+
+	// Assert
+	assert.Equal(t, errors.CodeInternal, development.Extensions["code"])
+	assert.Contains(t, development.Message, "SyntaxError")
+	assert.Equal(t, errors.CodeInternal, production.Extensions["code"])
+	assert.Equal(t, "internal server error", production.Message)
+}
+
+func TestErrorPresenter_LogsMaskedInternalErrors(t *testing.T) {
+	// Arrange
+	var logs bytes.Buffer
+	ctx := logging.WithLogger(context.Background(), slog.New(slog.NewJSONHandler(&logs, nil)))
+	err := fmt.Errorf("create tenant: %w", fmt.Errorf("Neo.ClientError.Statement.SyntaxError"))
+
+	// Act
+	gqlErr := NewErrorPresenter(true)(ctx, err)
+
+	// Assert
+	assert.Equal(t, "internal server error", gqlErr.Message)
+	assert.Contains(t, logs.String(), `"msg":"graphql internal error"`)
+	assert.Contains(t, logs.String(), `"error":"create tenant: Neo.ClientError.Statement.SyntaxError"`)
+}
+
+func TestErrorPresenter_MasksOnlyInternalErrors(t *testing.T) {
+	// Act
+	gqlErr := NewErrorPresenter(true)(context.Background(), errors.ErrForbidden)
+
+	// Assert
+	assert.Equal(t, errors.CodeForbidden, gqlErr.Extensions["code"])
+	assert.Equal(t, errors.ErrForbidden.Error(), gqlErr.Message)
+}
+
+func TestErrorPresenter_GqlgenErrorsUnchanged(t *testing.T) {
+	// Arrange - gqlgen builds errors like this one when a non-null field resolves to null
+	err := gqlerror.Errorf("must not be null")
+
+	// Act
+	gqlErr := NewErrorPresenter(true)(context.Background(), err)
+
+	// Assert
+	assert.Equal(t, "must not be null", gqlErr.Message)
+	assert.Nil(t, gqlErr.Extensions)
+}
+
+func TestRecoverPanic(t *testing.T) {
+	// Arrange
+	var logs bytes.Buffer
+	ctx := logging.WithLogger(context.Background(), slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	// Act
+	err := RecoverPanic(ctx, "nil map write")
+	gqlErr := NewErrorPresenter(true)(ctx, err)
+
+	// Assert
+	assert.Equal(t, errors.CodeInternal, gqlErr.Extensions["code"])
+	assert.Equal(t, "internal server error", gqlErr.Message)
+	assert.Contains(t, logs.String(), `"panic":"nil map write"`)
+	assert.Contains(t, logs.String(), `"stack":`)
+}
+
+func TestErrorPresenter_MembershipNotFound(t *testing.T) {
+	// Act
+	gqlErr := ErrorPresenter(context.Background(), fmt.Errorf("find membership: %w", errors.ErrMembershipNotFound))
+
+	// Assert
+	assert.Equal(t, errors.CodeNotFound, gqlErr.Extensions["code"])
+	assert.Equal(t, "membership not found", gqlErr.Message)
+}
+
+func TestErrorPresenter_DistinguishesNotMemberFromForbidden(t *testing.T) {
+	testCases := []struct {
+		err  error
+		code string
+		desc string
+	}{
+		{errors.ErrNotMember, errors.CodeNotMember, "not a member"},
+		{fmt.Errorf("invite member: %w", errors.ErrNotMember), errors.CodeNotMember, "wrapped not a member"},
+		{errors.ErrForbidden, errors.CodeForbidden, "insufficient role"},
+		{fmt.Errorf("invite member: %w", errors.ErrForbidden), errors.CodeForbidden, "wrapped insufficient role"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Act
+			gqlErr := ErrorPresenter(context.Background(), tc.err)
+
+			// Assert
+			assert.Equal(t, tc.code, gqlErr.Extensions["code"])
+			assert.Equal(t, tc.code, errors.CodeOf(tc.err))
+			assert.NotContains(t, gqlErr.Message, "invite member")
+		})
+	}
+}
+
+func TestMapContextError_PassesThroughOtherErrors(t *testing.T) {
+	// Arrange
+	err := errors.ErrSlugTaken
+
+	// Act & Assert
+	assert.Equal(t, err, mapContextError(err))
+}
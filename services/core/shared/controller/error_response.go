@@ -0,0 +1,58 @@
+package shared
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/reqid"
+)
+
+// RequestIDHeader carries the request ID set by the client or a proxy, and
+// the ID the request was served under in responses.
+const RequestIDHeader = "X-Request-ID"
+
+// internalErrorMessage replaces messages of unexpected errors so internals don't leak.
+const internalErrorMessage = "internal server error"
+
+// ErrorBody is the error payload returned by non-GraphQL routes.
+type ErrorBody struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// ErrorResponse wraps ErrorBody as {"error": {...}}.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// RespondError writes err as the standard JSON error shape, with the status
+// errors.HTTPStatus gives it, and aborts the request.
+func RespondError(c *gin.Context, err error) {
+	code := errors.CodeOf(err)
+
+	message := err.Error()
+	var coded *errors.CodedError
+	if errors.As(err, &coded) {
+		message = coded.Message
+	}
+	if code == errors.CodeInternal {
+		message = internalErrorMessage
+	}
+
+	c.AbortWithStatusJSON(errors.HTTPStatus(err), ErrorResponse{
+		Error: ErrorBody{
+			Code:      code,
+			Message:   message,
+			RequestID: RequestID(c),
+		},
+	})
+}
+
+// RequestID returns the ID of the current request, if any: the one
+// RequestIDs assigned, or else the one the client sent.
+func RequestID(c *gin.Context) string {
+	if id := reqid.FromContext(c.Request.Context()); id != "" {
+		return id
+	}
+	return c.GetHeader(RequestIDHeader)
+}
@@ -0,0 +1,85 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// serveError runs a handler that fails with err and decodes the response.
+func serveError(t *testing.T, err error) (*httptest.ResponseRecorder, ErrorResponse) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/export", func(c *gin.Context) {
+		RespondError(c, err)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/export", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	r.ServeHTTP(w, req)
+
+	var body ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w, body
+}
+
+func TestRespondError_Forbidden(t *testing.T) {
+	// Act
+	w, body := serveError(t, errors.ErrForbidden)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, errors.CodeForbidden, body.Error.Code)
+	assert.Equal(t, errors.ErrForbidden.Error(), body.Error.Message)
+	assert.Equal(t, "req-123", body.Error.RequestID)
+}
+
+func TestRespondError_NotMember(t *testing.T) {
+	// Act
+	w, body := serveError(t, errors.ErrNotMember)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, errors.CodeNotMember, body.Error.Code)
+}
+
+func TestRespondError_CodedErrorUsesItsMessage(t *testing.T) {
+	// Arrange
+	err := errors.NewCodedError(errors.CodeInvalidInput, "format must be csv", fmt.Errorf("got xml"))
+
+	// Act
+	w, body := serveError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, errors.CodeInvalidInput, body.Error.Code)
+	assert.Equal(t, "format must be csv", body.Error.Message)
+}
+
+func TestRespondError_ValidationError(t *testing.T) {
+	// Act
+	w, body := serveError(t, errors.NewValidationError("slug", "is required"))
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, errors.CodeInvalidInput, body.Error.Code)
+	assert.Equal(t, "slug: is required", body.Error.Message)
+}
+
+func TestRespondError_UnknownErrorIsMasked(t *testing.T) {
+	// Act
+	w, body := serveError(t, fmt.Errorf("neo4j: connection reset by peer"))
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, errors.CodeInternal, body.Error.Code)
+	assert.Equal(t, internalErrorMessage, body.Error.Message)
+}
@@ -0,0 +1,80 @@
+package shared
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/config"
+)
+
+// whoAmI serves the authenticated user ID, or "anonymous", through HeaderUserAuth.
+func whoAmI(cfg *config.Config, userID string) string {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(HeaderUserAuth(cfg))
+	r.GET("/whoami", func(c *gin.Context) {
+		id, err := auth.GetUserID(c.Request.Context())
+		if err != nil {
+			id = "anonymous"
+		}
+		if auth.IsPlatformAdmin(c.Request.Context()) {
+			id += " (platform admin)"
+		}
+		c.String(http.StatusOK, id)
+	})
+
+	req, _ := http.NewRequest("GET", "/whoami", nil)
+	req.Header.Set(UserIDHeader, userID)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Body.String()
+}
+
+func TestHeaderUserAuth(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		environment string
+		allow       bool
+		want        string
+	}{
+		{"ignored when flag is off", "development", false, "anonymous"},
+		{"honored when flag is on", "development", true, "user-1"},
+		{"ignored in production even when flag is on", "production", true, "anonymous"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			cfg := &config.Config{
+				Server: config.ServerConfig{Environment: tc.environment},
+				Auth:   config.AuthConfig{AllowHeaderUserID: tc.allow},
+			}
+
+			// Act
+			got := whoAmI(cfg, "user-1")
+
+			// Assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHeaderUserAuth_PlatformAdmin(t *testing.T) {
+	// Arrange
+	cfg := &config.Config{
+		Server: config.ServerConfig{Environment: "development"},
+		Auth:   config.AuthConfig{AllowHeaderUserID: true, PlatformAdminIDs: []string{" admin-1"}},
+	}
+
+	// Act
+	admin := whoAmI(cfg, "admin-1")
+	other := whoAmI(cfg, "user-1")
+
+	// Assert
+	assert.Equal(t, "admin-1 (platform admin)", admin)
+	assert.Equal(t, "user-1", other)
+}
@@ -2,6 +2,7 @@ package shared
 
 import (
 	"context"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
@@ -25,11 +26,26 @@ type IDatabase interface {
 	// ExecuteWrite executes a write transaction with automatic retry
 	ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error)
 
+	// ExecuteReadWithTimeout executes a read transaction that is aborted
+	// after timeout instead of the configured query timeout
+	ExecuteReadWithTimeout(ctx context.Context, timeout time.Duration, work neo4j.ManagedTransactionWork) (any, error)
+
+	// ExecuteWriteWithTimeout executes a write transaction that is aborted
+	// after timeout instead of the configured query timeout
+	ExecuteWriteWithTimeout(ctx context.Context, timeout time.Duration, work neo4j.ManagedTransactionWork) (any, error)
+
+	// ExecuteReadAfterWrite executes a read transaction that observes the
+	// writes made earlier with the same request context
+	ExecuteReadAfterWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error)
+
 	// NewSession creates a new session for manual transaction management
 	NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext
 
 	// GetDriver returns the underlying driver for advanced usage
 	GetDriver() neo4j.DriverWithContext
+
+	// GetServerInfo returns the connected server's name, versions and edition
+	GetServerInfo(ctx context.Context) (map[string]any, error)
 }
 
 // Ensure Neo4jDB implements IDatabase
@@ -0,0 +1,136 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/gin-gonic/gin"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+)
+
+// MaintenanceMode rejects GraphQL mutations while enabled so operators can
+// run migrations or handle incidents with reads and health checks still up.
+// It can be toggled at runtime.
+type MaintenanceMode struct {
+	enabled    atomic.Bool
+	retryAfter time.Duration
+}
+
+// NewMaintenanceMode creates a new MaintenanceMode.
+// retryAfter is advertised to clients in the Retry-After header.
+func NewMaintenanceMode(enabled bool, retryAfter time.Duration) *MaintenanceMode {
+	m := &MaintenanceMode{retryAfter: retryAfter}
+	m.enabled.Store(enabled)
+	return m
+}
+
+// Enabled reports whether maintenance mode is on.
+func (m *MaintenanceMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// SetEnabled turns maintenance mode on or off.
+func (m *MaintenanceMode) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// Middleware rejects GraphQL mutations with 503 and a MAINTENANCE error while
+// maintenance mode is on. Queries pass through. Multipart requests (file
+// uploads) are always mutations and are rejected too.
+func (m *MaintenanceMode) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.Enabled() || !isMutationRequest(c.Request) {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(int(m.retryAfter.Seconds())))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, graphql.Response{
+			Errors: gqlerror.List{{
+				Message:    errors.ErrMaintenance.Error(),
+				Extensions: map[string]any{"code": errors.CodeMaintenance},
+			}},
+		})
+	}
+}
+
+// MaintenanceStatus is the body of the maintenance toggle endpoint.
+type MaintenanceStatus struct {
+	Enabled bool `json:"enabled"`
+}
+
+// HandleStatus reports whether maintenance mode is on.
+func (m *MaintenanceMode) HandleStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, MaintenanceStatus{Enabled: m.Enabled()})
+}
+
+// HandleToggle sets maintenance mode from a MaintenanceStatus body.
+// Requires a platform admin.
+func (m *MaintenanceMode) HandleToggle(c *gin.Context) {
+	ctx := c.Request.Context()
+	if _, err := auth.GetUserID(ctx); err != nil {
+		RespondError(c, err)
+		return
+	}
+	if !auth.IsPlatformAdmin(ctx) {
+		RespondError(c, errors.ErrForbidden)
+		return
+	}
+
+	var status MaintenanceStatus
+	if err := c.ShouldBindJSON(&status); err != nil {
+		RespondError(c, errors.NewCodedError(errors.CodeInvalidInput, "invalid maintenance status", err))
+		return
+	}
+
+	m.SetEnabled(status.Enabled)
+	c.JSON(http.StatusOK, status)
+}
+
+// graphqlRequest is the subset of a GraphQL POST body needed to find the operation.
+type graphqlRequest struct {
+	Query         string `json:"query"`
+	OperationName string `json:"operationName"`
+}
+
+// isMutationRequest reports whether the request executes a GraphQL mutation.
+// The body is restored so the GraphQL handler can read it again. Unparseable
+// requests are let through for the GraphQL handler to reject.
+func isMutationRequest(r *http.Request) bool {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return true
+	}
+	if r.Body == nil {
+		return false
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	var req graphqlRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return false
+	}
+
+	doc, err := parser.ParseQuery(&ast.Source{Input: req.Query})
+	if err != nil {
+		return false
+	}
+
+	op := doc.Operations.ForName(req.OperationName)
+	return op != nil && op.Operation == ast.Mutation
+}
@@ -0,0 +1,119 @@
+package shared
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// memberSlice streams fixed memberships, or fails with err before any row.
+type memberSlice struct {
+	members  []*model.Membership
+	err      error
+	tenantID string
+}
+
+func (s *memberSlice) StreamTenantMembers(ctx context.Context, tenantID string, fn func(*model.Membership) error) error {
+	s.tenantID = tenantID
+	if s.err != nil {
+		return s.err
+	}
+	for _, m := range s.members {
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func serveMemberExport(streamer IMemberStreamer) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/tenants/:id/members.csv", NewMemberExportHandler(streamer).HandleExport)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/tenants/tenant-1/members.csv", nil)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMemberExportHandler_StreamsRows(t *testing.T) {
+	// Arrange
+	name := "Alice"
+	joined := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	streamer := &memberSlice{members: []*model.Membership{
+		{
+			ID: "m1", Role: model.MembershipRoleOwner, Status: model.MembershipStatusActive, JoinedAt: joined,
+			User: &model.User{ID: "u1", Email: "alice@example.com", Name: &name},
+		},
+		{
+			ID: "m2", Role: model.MembershipRoleMember, Status: model.MembershipStatusPending,
+			User:      &model.User{ID: "u2", Email: "bob@example.com"},
+			InvitedBy: &model.User{ID: "u1", Email: "alice@example.com"},
+		},
+	}}
+
+	// Act
+	w := serveMemberExport(streamer)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "tenant-1", streamer.tenantID)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	rows, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		memberExportHeader,
+		{"m1", "u1", "alice@example.com", "Alice", "OWNER", "ACTIVE", "2026-01-02T03:04:05Z", ""},
+		{"m2", "u2", "bob@example.com", "", "MEMBER", "PENDING", "", "alice@example.com"},
+	}, rows)
+}
+
+func TestMemberExportHandler_NeutralizesFormulas(t *testing.T) {
+	// Arrange
+	name := "=HYPERLINK(\"http://evil.example\")"
+	streamer := &memberSlice{members: []*model.Membership{{
+		ID: "m1", Role: model.MembershipRoleMember, Status: model.MembershipStatusActive,
+		User:      &model.User{ID: "u1", Email: "+1@example.com", Name: &name},
+		InvitedBy: &model.User{ID: "u2", Email: "@admin.example.com"},
+	}}}
+
+	// Act
+	w := serveMemberExport(streamer)
+
+	// Assert
+	rows, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{
+		"m1", "u1", "'+1@example.com", "'=HYPERLINK(\"http://evil.example\")", "MEMBER", "ACTIVE", "", "'@admin.example.com",
+	}, rows[1])
+}
+
+func TestMemberExportHandler_EmptyTenant(t *testing.T) {
+	// Act
+	w := serveMemberExport(&memberSlice{})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, strings.Join(memberExportHeader, ",")+"\n", w.Body.String())
+}
+
+func TestMemberExportHandler_ErrorBeforeRows(t *testing.T) {
+	// Act
+	w := serveMemberExport(&memberSlice{err: errors.ErrForbidden})
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
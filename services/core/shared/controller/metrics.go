@@ -0,0 +1,64 @@
+package shared
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/grgn-stack/pkg/metrics"
+)
+
+// unmatchedRoute labels requests that matched no route, so arbitrary paths
+// don't become label values.
+const unmatchedRoute = "unmatched"
+
+// HTTPMetrics returns a middleware that counts and times each request by
+// route pattern, method and status.
+func HTTPMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.ObserveHTTPRequest(route, c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// HandleMetrics serves the Prometheus metrics.
+func HandleMetrics(c *gin.Context) {
+	metrics.Handler().ServeHTTP(c.Writer, c.Request)
+}
+
+// GraphQLMetrics is a gqlgen extension that times each GraphQL operation by
+// operation type and whether it returned errors.
+type GraphQLMetrics struct{}
+
+// ExtensionName returns the gqlgen extension name.
+func (GraphQLMetrics) ExtensionName() string {
+	return "GraphQLMetrics"
+}
+
+// Validate satisfies graphql.HandlerExtension.
+func (GraphQLMetrics) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptResponse records the operation once its response is available.
+func (GraphQLMetrics) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if resp == nil || !graphql.HasOperationContext(ctx) {
+		return resp
+	}
+
+	oc := graphql.GetOperationContext(ctx)
+	opType := "unknown"
+	if oc.Operation != nil {
+		opType = string(oc.Operation.Operation)
+	}
+	metrics.ObserveGraphQLOperation(opType, len(resp.Errors) > 0, time.Since(oc.Stats.OperationStart))
+	return resp
+}
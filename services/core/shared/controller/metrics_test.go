@@ -0,0 +1,80 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMetricsRouter returns a router that instruments requests and serves /metrics.
+func newMetricsRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(HTTPMetrics())
+	r.GET("/metrics", HandleMetrics)
+	r.GET("/tenants/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return r
+}
+
+// scrapeMetric fetches /metrics and returns the value of the sample named
+// series, e.g. `grgn_db_transactions_total{mode="read",result="success"}`,
+// or 0 if it has not been recorded.
+func scrapeMetric(t *testing.T, r *gin.Engine, series string) float64 {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	for _, line := range strings.Split(w.Body.String(), "\n") {
+		if value, ok := strings.CutPrefix(line, series+" "); ok {
+			v, err := strconv.ParseFloat(value, 64)
+			require.NoError(t, err)
+			return v
+		}
+	}
+	return 0
+}
+
+func TestMetrics_CountsTransactions(t *testing.T) {
+	// Arrange
+	r := newMetricsRouter()
+	db := &Neo4jDB{driver: &fakeDriver{name: "primary"}}
+	failing := &Neo4jDB{driver: &fakeDriver{name: "primary", err: errors.New("boom")}}
+	readSeries := `grgn_db_transactions_total{mode="read",result="success"}`
+	writeSeries := `grgn_db_transactions_total{mode="write",result="error"}`
+	reads := scrapeMetric(t, r, readSeries)
+	failedWrites := scrapeMetric(t, r, writeSeries)
+
+	// Act
+	_, readErr := db.ExecuteRead(context.Background(), nil)
+	_, writeErr := failing.ExecuteWrite(context.Background(), nil)
+
+	// Assert
+	require.NoError(t, readErr)
+	require.Error(t, writeErr)
+	assert.Equal(t, reads+1, scrapeMetric(t, r, readSeries))
+	assert.Equal(t, failedWrites+1, scrapeMetric(t, r, writeSeries))
+}
+
+func TestMetrics_CountsRequestsByRoute(t *testing.T) {
+	// Arrange
+	r := newMetricsRouter()
+	series := `grgn_http_requests_total{method="GET",route="/tenants/:id",status="204"}`
+	before := scrapeMetric(t, r, series)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/tenants/tenant-1", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert
+	assert.Equal(t, before+1, scrapeMetric(t, r, series))
+}
@@ -0,0 +1,92 @@
+package shared
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/grgn-stack/pkg/events"
+)
+
+// MockOutboxRepository is an in-memory implementation of IOutboxRepository for testing.
+// Mock repositories append to it where the Neo4j implementation would call WriteOutboxEvent.
+type MockOutboxRepository struct {
+	mu        sync.RWMutex
+	events    []events.Event
+	processed map[string]int // eventID -> times marked processed
+
+	// Function overrides for testing specific behaviors
+	FetchPendingFunc  func(ctx context.Context, limit int) ([]events.Event, error)
+	MarkProcessedFunc func(ctx context.Context, id string) error
+}
+
+// NewMockOutboxRepository creates a new MockOutboxRepository.
+func NewMockOutboxRepository() *MockOutboxRepository {
+	return &MockOutboxRepository{
+		processed: make(map[string]int),
+	}
+}
+
+// Record appends an event to the outbox, as WriteOutboxEvent would.
+func (m *MockOutboxRepository) Record(eventType, aggregateID string, payload map[string]any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, events.Event{
+		ID:          uuid.New().String(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     payload,
+		CreatedAt:   time.Now(),
+	})
+}
+
+// Events returns every recorded event in order, processed or not.
+func (m *MockOutboxRepository) Events() []events.Event {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]events.Event(nil), m.events...)
+}
+
+// ProcessedCount returns how many times an event was marked processed.
+func (m *MockOutboxRepository) ProcessedCount(id string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.processed[id]
+}
+
+// FetchPending returns up to limit unprocessed events, oldest first.
+func (m *MockOutboxRepository) FetchPending(ctx context.Context, limit int) ([]events.Event, error) {
+	if m.FetchPendingFunc != nil {
+		return m.FetchPendingFunc(ctx, limit)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pending := []events.Event{}
+	for _, evt := range m.events {
+		if len(pending) == limit {
+			break
+		}
+		if m.processed[evt.ID] == 0 {
+			pending = append(pending, evt)
+		}
+	}
+	return pending, nil
+}
+
+// MarkProcessed flags an event as dispatched.
+func (m *MockOutboxRepository) MarkProcessed(ctx context.Context, id string) error {
+	if m.MarkProcessedFunc != nil {
+		return m.MarkProcessedFunc(ctx, id)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.processed[id]++
+	return nil
+}
+
+// Ensure MockOutboxRepository implements IOutboxRepository
+var _ IOutboxRepository = (*MockOutboxRepository)(nil)
@@ -0,0 +1,98 @@
+package shared
+
+import (
+	"context"
+	"sync"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// MockUnitOfWork is an in-memory implementation of IUnitOfWork for testing.
+// Work runs against a MockTx; mock repositories apply their ...Tx changes
+// right away and register how to undo them, and if work fails the changes
+// are undone newest first, as a rolled-back Neo4j transaction would.
+type MockUnitOfWork struct {
+	mu        sync.Mutex
+	commits   int
+	rollbacks int
+
+	// Function overrides for testing specific behaviors
+	WriteFunc func(ctx context.Context, work func(tx neo4j.ManagedTransaction) error) error
+}
+
+// NewMockUnitOfWork creates a new MockUnitOfWork.
+func NewMockUnitOfWork() *MockUnitOfWork {
+	return &MockUnitOfWork{}
+}
+
+// Write runs work against a new MockTx, committing or rolling it back.
+func (m *MockUnitOfWork) Write(ctx context.Context, work func(tx neo4j.ManagedTransaction) error) error {
+	if m.WriteFunc != nil {
+		return m.WriteFunc(ctx, work)
+	}
+
+	tx := &MockTx{}
+	err := work(tx)
+
+	m.mu.Lock()
+	if err != nil {
+		m.rollbacks++
+	} else {
+		m.commits++
+	}
+	m.mu.Unlock()
+
+	if err != nil {
+		for i := len(tx.undo) - 1; i >= 0; i-- {
+			tx.undo[i]()
+		}
+		return err
+	}
+	for _, fn := range tx.onCommit {
+		fn()
+	}
+	return nil
+}
+
+// Commits returns how many units of work committed.
+func (m *MockUnitOfWork) Commits() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.commits
+}
+
+// Rollbacks returns how many units of work rolled back.
+func (m *MockUnitOfWork) Rollbacks() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rollbacks
+}
+
+// MockTx is the transaction MockUnitOfWork passes to work. Running Cypher
+// on it panics via the nil embedded interface.
+type MockTx struct {
+	neo4j.ManagedTransaction
+	undo     []func()
+	onCommit []func()
+}
+
+// TrackMockChange registers a change a mock repository made in tx: undo
+// runs if tx rolls back and onCommit, which may be nil, once it commits,
+// e.g. to record outbox events. When tx is not a MockTx the change is
+// already final, so onCommit runs immediately.
+func TrackMockChange(tx neo4j.ManagedTransaction, undo, onCommit func()) {
+	mockTx, ok := tx.(*MockTx)
+	if !ok {
+		if onCommit != nil {
+			onCommit()
+		}
+		return
+	}
+	mockTx.undo = append(mockTx.undo, undo)
+	if onCommit != nil {
+		mockTx.onCommit = append(mockTx.onCommit, onCommit)
+	}
+}
+
+// Ensure MockUnitOfWork implements IUnitOfWork
+var _ IUnitOfWork = (*MockUnitOfWork)(nil)
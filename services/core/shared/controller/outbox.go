@@ -0,0 +1,183 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yourusername/grgn-stack/pkg/events"
+)
+
+// WriteOutboxEvent records a domain event as an (:Event) node inside tx.
+// Calling it from the same transaction as the change guarantees the event
+// exists if and only if the change was committed.
+func WriteOutboxEvent(ctx context.Context, tx neo4j.ManagedTransaction, eventType, aggregateID string, payload map[string]any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	_, err = tx.Run(ctx, `
+		CREATE (e:Event {
+			id: $id,
+			type: $type,
+			aggregateId: $aggregateId,
+			payload: $payload,
+			createdAt: datetime()
+		})
+	`, map[string]any{
+		"id":          uuid.New().String(),
+		"type":        eventType,
+		"aggregateId": aggregateID,
+		"payload":     string(data),
+	})
+	return err
+}
+
+// IOutboxRepository defines the contract for reading and acknowledging outbox events.
+type IOutboxRepository interface {
+	// FetchPending returns up to limit unprocessed events, oldest first.
+	FetchPending(ctx context.Context, limit int) ([]events.Event, error)
+
+	// MarkProcessed flags an event as dispatched so it is not published again.
+	MarkProcessed(ctx context.Context, id string) error
+}
+
+// OutboxRepository implements IOutboxRepository using Neo4j.
+type OutboxRepository struct {
+	db IDatabase
+}
+
+// NewOutboxRepository creates a new OutboxRepository.
+func NewOutboxRepository(db IDatabase) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// FetchPending returns up to limit unprocessed events, oldest first.
+func (r *OutboxRepository) FetchPending(ctx context.Context, limit int) ([]events.Event, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, `
+			MATCH (e:Event)
+			WHERE e.processedAt IS NULL
+			RETURN e
+			ORDER BY e.createdAt ASC
+			LIMIT $limit
+		`, map[string]any{"limit": limit})
+		if err != nil {
+			return nil, err
+		}
+
+		pending := []events.Event{}
+		for res.Next(ctx) {
+			node, ok := res.Record().Values[0].(neo4j.Node)
+			if !ok {
+				return nil, fmt.Errorf("unexpected event record")
+			}
+			pending = append(pending, mapNodeToEvent(node))
+		}
+		return pending, res.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]events.Event), nil
+}
+
+// MarkProcessed flags an event as dispatched.
+// Events that are already processed are left untouched.
+func (r *OutboxRepository) MarkProcessed(ctx context.Context, id string) error {
+	_, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		_, err := tx.Run(ctx, `
+			MATCH (e:Event {id: $id})
+			WHERE e.processedAt IS NULL
+			SET e.processedAt = datetime()
+		`, map[string]any{"id": id})
+		return nil, err
+	})
+	return err
+}
+
+// mapNodeToEvent converts an (:Event) node to an events.Event.
+func mapNodeToEvent(node neo4j.Node) events.Event {
+	props := node.Props
+
+	evt := events.Event{}
+	evt.ID, _ = props["id"].(string)
+	evt.Type, _ = props["type"].(string)
+	evt.AggregateID, _ = props["aggregateId"].(string)
+	if createdAt, ok := props["createdAt"].(time.Time); ok {
+		evt.CreatedAt = createdAt
+	}
+	if payload, ok := props["payload"].(string); ok {
+		_ = json.Unmarshal([]byte(payload), &evt.Payload)
+	}
+	return evt
+}
+
+// OutboxDispatcher publishes committed outbox events to a Publisher.
+// Delivery is at-least-once: an event is marked processed only after it has
+// been published, so a crash in between republishes it on restart.
+type OutboxDispatcher struct {
+	outbox    IOutboxRepository
+	publisher events.Publisher
+	batchSize int
+	logger    *slog.Logger
+}
+
+// NewOutboxDispatcher creates a new OutboxDispatcher.
+func NewOutboxDispatcher(outbox IOutboxRepository, publisher events.Publisher, batchSize int, logger *slog.Logger) *OutboxDispatcher {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	return &OutboxDispatcher{
+		outbox:    outbox,
+		publisher: publisher,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+// DispatchOnce publishes one batch of pending events and returns how many were
+// dispatched. It stops at the first failure so ordering is preserved.
+func (d *OutboxDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	pending, err := d.outbox.FetchPending(ctx, d.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch outbox events: %w", err)
+	}
+
+	dispatched := 0
+	for _, evt := range pending {
+		if err := d.publisher.Publish(ctx, evt); err != nil {
+			return dispatched, fmt.Errorf("failed to publish event %s: %w", evt.ID, err)
+		}
+		if err := d.outbox.MarkProcessed(ctx, evt.ID); err != nil {
+			return dispatched, fmt.Errorf("failed to mark event %s processed: %w", evt.ID, err)
+		}
+		dispatched++
+	}
+	return dispatched, nil
+}
+
+// Run polls the outbox every interval until ctx is cancelled.
+func (d *OutboxDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.DispatchOnce(ctx); err != nil && ctx.Err() == nil {
+				d.logger.WarnContext(ctx, "outbox dispatch failed", "error", err)
+			}
+		}
+	}
+}
+
+// Ensure OutboxRepository implements IOutboxRepository
+var _ IOutboxRepository = (*OutboxRepository)(nil)
@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/events"
+)
+
+// failingPublisher rejects every event.
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(ctx context.Context, event events.Event) error {
+	return errors.New("broker unavailable")
+}
+
+func newTestDispatcher(outbox IOutboxRepository, publisher events.Publisher) *OutboxDispatcher {
+	return NewOutboxDispatcher(outbox, publisher, 10, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestOutboxDispatcher_DispatchOnce_PublishesAndMarksProcessedOnce(t *testing.T) {
+	// Arrange
+	outbox := NewMockOutboxRepository()
+	outbox.Record(events.TenantCreated, "tenant-1", map[string]any{"tenantId": "tenant-1"})
+
+	broker := events.NewBroker(10)
+	received, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	dispatcher := newTestDispatcher(outbox, broker)
+
+	// Act - the second pass must find nothing left to do
+	first, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	second, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 0, second)
+
+	evt := <-received
+	assert.Equal(t, events.TenantCreated, evt.Type)
+	assert.Equal(t, "tenant-1", evt.AggregateID)
+	assert.Equal(t, 1, outbox.ProcessedCount(evt.ID))
+	assert.Empty(t, received)
+}
+
+func TestOutboxDispatcher_DispatchOnce_PublishFailureLeavesEventPending(t *testing.T) {
+	// Arrange
+	outbox := NewMockOutboxRepository()
+	outbox.Record(events.MemberAdded, "membership-1", nil)
+	dispatcher := newTestDispatcher(outbox, failingPublisher{})
+
+	// Act
+	dispatched, err := dispatcher.DispatchOnce(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, 0, dispatched)
+
+	pending, _ := outbox.FetchPending(context.Background(), 10)
+	assert.Len(t, pending, 1)
+}
@@ -3,26 +3,36 @@ package shared
 import (
 	"context"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/grgn"
 )
 
+// healthCheckTimeout bounds the database checks made by health probes.
+const healthCheckTimeout = 2 * time.Second
+
 // PingHandler handles health check requests for the application.
-// It checks database connectivity and returns the health status.
+// It checks database connectivity and returns the health status, and
+// serves the liveness and readiness probes.
 type PingHandler struct {
 	db     IDatabase
 	config *config.Config
+
+	// The server's version and edition, read once they're first available
+	mu           sync.Mutex
+	version      string
+	edition      string
+	versionKnown bool
 }
 
-// PingResponse represents the response from the ping endpoint.
-type PingResponse struct {
-	Message     string `json:"message"`
-	Environment string `json:"environment"`
-	Version     string `json:"version"`
-	Database    string `json:"database"`
-	Error       string `json:"error,omitempty"`
+// ProbeResponse represents the response from the liveness and readiness
+// probes.
+type ProbeResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
 }
 
 // NewPingHandler creates a new PingHandler with the given dependencies.
@@ -36,46 +46,98 @@ func NewPingHandler(db IDatabase, cfg *config.Config) *PingHandler {
 // HandlePing processes the health check request.
 // It verifies database connectivity and returns the service health status.
 func (h *PingHandler) HandlePing(c *gin.Context) {
-	response := PingResponse{
-		Message:     "pong",
-		Environment: h.config.Server.Environment,
-		Version:     h.config.App.Version,
-		Database:    "healthy",
+	response, err := h.CheckHealth(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
 	}
 
-	// Check database connectivity with timeout
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+	c.JSON(http.StatusOK, response)
+}
+
+// Liveness reports that the process is up and serving requests. It never
+// checks the database, so an orchestrator doesn't restart the server when
+// only Neo4j is unavailable.
+func (h *PingHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, ProbeResponse{Status: "ok"})
+}
+
+// Readiness reports whether the server can serve traffic, which requires a
+// reachable database. It returns 503 while the database is unhealthy. Only
+// connectivity is checked, since orchestrators poll it often.
+func (h *PingHandler) Readiness(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthCheckTimeout)
 	defer cancel()
 
-	if err := h.db.Ping(ctx); err != nil {
-		response.Database = "unhealthy"
-		response.Error = err.Error()
-		c.JSON(http.StatusServiceUnavailable, response)
+	if err := h.db.VerifyConnectivity(ctx); err != nil {
+		c.JSON(http.StatusServiceUnavailable, ProbeResponse{Status: "unavailable", Error: err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, ProbeResponse{Status: "ready"})
 }
 
 // CheckHealth performs a health check and returns the result.
 // This method can be called programmatically without HTTP context.
-func (h *PingHandler) CheckHealth(ctx context.Context) (*PingResponse, error) {
-	response := &PingResponse{
+// The database report carries the ping latency and, once the ping
+// succeeds, the server version and edition, which are read from the server
+// only until they are first known; a server that won't describe itself is
+// still healthy, with its version reported as UnknownServerVersion.
+func (h *PingHandler) CheckHealth(ctx context.Context) (*grgn.HealthResponse, error) {
+	response := &grgn.HealthResponse{
 		Message:     "pong",
 		Environment: h.config.Server.Environment,
 		Version:     h.config.App.Version,
-		Database:    "healthy",
+		Database:    grgn.HealthStatus{Status: "healthy"},
 	}
 
 	// Check database connectivity
-	checkCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
 	defer cancel()
 
-	if err := h.db.Ping(checkCtx); err != nil {
-		response.Database = "unhealthy"
-		response.Error = err.Error()
+	start := time.Now()
+	err := h.db.Ping(checkCtx)
+	response.Database.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		response.Database.Status = "unhealthy"
+		response.Database.Error = err.Error()
 		return response, err
 	}
 
+	response.Database.Version, response.Database.Edition = h.serverVersion(checkCtx)
 	return response, nil
 }
+
+// serverVersion returns the database server's version and edition, or
+// UnknownServerVersion for whatever GetServerInfo can't provide. The first
+// successful answer is kept; failed reads are retried on the next check.
+func (h *PingHandler) serverVersion(ctx context.Context) (version, edition string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.versionKnown {
+		return h.version, h.edition
+	}
+
+	info, err := h.db.GetServerInfo(ctx)
+	if err != nil {
+		return UnknownServerVersion, UnknownServerVersion
+	}
+	h.version, h.edition = parseServerInfo(info)
+	h.versionKnown = true
+	return h.version, h.edition
+}
+
+// parseServerInfo reads the version and edition from GetServerInfo's
+// result, using UnknownServerVersion for either that is missing.
+func parseServerInfo(info map[string]any) (version, edition string) {
+	version, edition = UnknownServerVersion, UnknownServerVersion
+	if versions, ok := info["versions"].([]any); ok && len(versions) > 0 {
+		if v, ok := versions[0].(string); ok && v != "" {
+			version = v
+		}
+	}
+	if e, ok := info["edition"].(string); ok && e != "" {
+		edition = e
+	}
+	return version, edition
+}
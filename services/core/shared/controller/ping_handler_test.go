@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
@@ -15,10 +16,18 @@ import (
 
 // MockDatabase implements IDatabase for testing
 type MockDatabase struct {
-	pingError error
+	pingError  error
+	pingDelay  time.Duration
+	readResult any
+	readError  error
+
+	serverInfo      map[string]any
+	serverInfoError error
+	serverInfoCalls int
 }
 
 func (m *MockDatabase) Ping(ctx context.Context) error {
+	time.Sleep(m.pingDelay)
 	return m.pingError
 }
 
@@ -31,13 +40,25 @@ func (m *MockDatabase) VerifyConnectivity(ctx context.Context) error {
 }
 
 func (m *MockDatabase) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
-	return nil, nil
+	return m.readResult, m.readError
 }
 
 func (m *MockDatabase) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
 	return nil, nil
 }
 
+func (m *MockDatabase) ExecuteReadWithTimeout(ctx context.Context, timeout time.Duration, work neo4j.ManagedTransactionWork) (any, error) {
+	return m.ExecuteRead(ctx, work)
+}
+
+func (m *MockDatabase) ExecuteWriteWithTimeout(ctx context.Context, timeout time.Duration, work neo4j.ManagedTransactionWork) (any, error) {
+	return m.ExecuteWrite(ctx, work)
+}
+
+func (m *MockDatabase) ExecuteReadAfterWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return m.ExecuteRead(ctx, work)
+}
+
 func (m *MockDatabase) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
 	return nil
 }
@@ -46,6 +67,11 @@ func (m *MockDatabase) GetDriver() neo4j.DriverWithContext {
 	return nil
 }
 
+func (m *MockDatabase) GetServerInfo(ctx context.Context) (map[string]any, error) {
+	m.serverInfoCalls++
+	return m.serverInfo, m.serverInfoError
+}
+
 func newTestConfig() *config.Config {
 	return &config.Config{
 		Server: config.ServerConfig{
@@ -78,7 +104,7 @@ func TestPingHandler_HandlePing_Healthy(t *testing.T) {
 	// Assert response
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), `"message":"pong"`)
-	assert.Contains(t, w.Body.String(), `"database":"healthy"`)
+	assert.Contains(t, w.Body.String(), `"status":"healthy"`)
 	assert.Contains(t, w.Body.String(), `"environment":"test"`)
 	assert.Contains(t, w.Body.String(), `"version":"1.0.0-test"`)
 	assert.NotContains(t, w.Body.String(), `"error"`)
@@ -105,7 +131,7 @@ func TestPingHandler_HandlePing_Unhealthy(t *testing.T) {
 	// Assert response
 	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
 	assert.Contains(t, w.Body.String(), `"message":"pong"`)
-	assert.Contains(t, w.Body.String(), `"database":"unhealthy"`)
+	assert.Contains(t, w.Body.String(), `"status":"unhealthy"`)
 	assert.Contains(t, w.Body.String(), `"error":"connection refused"`)
 }
 
@@ -119,8 +145,8 @@ func TestPingHandler_CheckHealth_Healthy(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
 	assert.Equal(t, "pong", response.Message)
-	assert.Equal(t, "healthy", response.Database)
-	assert.Empty(t, response.Error)
+	assert.Equal(t, "healthy", response.Database.Status)
+	assert.Empty(t, response.Database.Error)
 }
 
 func TestPingHandler_CheckHealth_Unhealthy(t *testing.T) {
@@ -133,6 +159,131 @@ func TestPingHandler_CheckHealth_Unhealthy(t *testing.T) {
 	assert.Error(t, err)
 	assert.NotNil(t, response)
 	assert.Equal(t, "pong", response.Message)
-	assert.Equal(t, "unhealthy", response.Database)
-	assert.Equal(t, "database unavailable", response.Error)
+	assert.Equal(t, "unhealthy", response.Database.Status)
+	assert.Equal(t, "database unavailable", response.Database.Error)
+}
+
+func TestPingHandler_CheckHealth_ServerDetails(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{
+		pingDelay: 5 * time.Millisecond,
+		serverInfo: map[string]any{
+			"name":     "Neo4j Kernel",
+			"versions": []any{"5.26.0"},
+			"edition":  "community",
+		},
+	}
+	handler := NewPingHandler(mockDB, newTestConfig())
+
+	// Act
+	response, err := handler.CheckHealth(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "healthy", response.Database.Status)
+	assert.GreaterOrEqual(t, response.Database.LatencyMs, 5.0)
+	assert.Equal(t, "5.26.0", response.Database.Version)
+	assert.Equal(t, "community", response.Database.Edition)
+}
+
+func TestPingHandler_CheckHealth_VersionUnavailable(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{serverInfoError: errors.New("permission denied")}
+	handler := NewPingHandler(mockDB, newTestConfig())
+
+	// Act
+	response, err := handler.CheckHealth(context.Background())
+
+	// Assert - the database is up even though it won't describe itself
+	assert.NoError(t, err)
+	assert.Equal(t, "healthy", response.Database.Status)
+	assert.Equal(t, UnknownServerVersion, response.Database.Version)
+	assert.Equal(t, UnknownServerVersion, response.Database.Edition)
+	assert.Empty(t, response.Database.Error)
+}
+
+func TestPingHandler_CheckHealth_ReadsVersionOnce(t *testing.T) {
+	// Arrange - the first read fails, the next succeeds
+	mockDB := &MockDatabase{serverInfoError: errors.New("timeout")}
+	handler := NewPingHandler(mockDB, newTestConfig())
+
+	// Act
+	failed, failedErr := handler.CheckHealth(context.Background())
+	mockDB.serverInfoError = nil
+	mockDB.serverInfo = map[string]any{"versions": []any{"5.26.0"}, "edition": "enterprise"}
+	for range 3 {
+		_, err := handler.CheckHealth(context.Background())
+		assert.NoError(t, err)
+	}
+	response, err := handler.CheckHealth(context.Background())
+
+	// Assert
+	assert.NoError(t, failedErr)
+	assert.NoError(t, err)
+	assert.Equal(t, UnknownServerVersion, failed.Database.Version)
+	assert.Equal(t, "5.26.0", response.Database.Version)
+	assert.Equal(t, "enterprise", response.Database.Edition)
+	assert.Equal(t, 2, mockDB.serverInfoCalls)
+}
+
+// serveProbe routes path to probe and returns the response.
+func serveProbe(path string, probe gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET(path, probe)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", path, nil)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestPingHandler_Liveness(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		pingError error
+	}{
+		{"healthy database", nil},
+		{"unhealthy database", errors.New("connection refused")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			handler := NewPingHandler(&MockDatabase{pingError: tc.pingError}, newTestConfig())
+
+			// Act
+			w := serveProbe("/healthz", handler.Liveness)
+
+			// Assert - liveness ignores the database
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+		})
+	}
+}
+
+func TestPingHandler_Readiness_Healthy(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{pingError: nil}
+	handler := NewPingHandler(mockDB, newTestConfig())
+
+	// Act
+	w := serveProbe("/readyz", handler.Readiness)
+
+	// Assert - readiness only checks connectivity
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ready"}`, w.Body.String())
+	assert.Zero(t, mockDB.serverInfoCalls)
+}
+
+func TestPingHandler_Readiness_Unhealthy(t *testing.T) {
+	// Arrange
+	handler := NewPingHandler(&MockDatabase{pingError: errors.New("connection refused")}, newTestConfig())
+
+	// Act
+	w := serveProbe("/readyz", handler.Readiness)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"status":"unavailable","error":"connection refused"}`, w.Body.String())
 }
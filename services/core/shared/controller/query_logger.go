@@ -0,0 +1,239 @@
+package shared
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// scrubbedValue replaces the value of any variable considered PII.
+const scrubbedValue = "***"
+
+// DefaultScrubFields are argument and input field names masked when no
+// denylist is configured.
+var DefaultScrubFields = []string{"email", "name", "password", "token", "secret", "avatarUrl"}
+
+// QueryLogger is a gqlgen extension that logs GraphQL operations together with
+// their variables, masking PII fields before they reach the log.
+// By default only failed operations are logged.
+//
+// Clients choose variable names, so a variable is masked by the arguments
+// and input fields the operation passes it as: $t in acceptInviteByToken(token: $t)
+// is masked as a token.
+type QueryLogger struct {
+	logger *slog.Logger
+	logAll bool
+	deny   map[string]bool
+	allow  map[string]bool
+}
+
+// QueryLoggerOptions configures a QueryLogger.
+type QueryLoggerOptions struct {
+	// LogAll logs successful operations at info level in addition to failures.
+	LogAll bool
+
+	// ScrubFields are argument and input field names (case-insensitive)
+	// whose values are masked. Defaults to DefaultScrubFields when empty.
+	ScrubFields []string
+
+	// VisibleFields, when non-empty, switches to allowlist mode: only values
+	// passed as these arguments and input fields are logged verbatim and
+	// every other scalar is masked.
+	VisibleFields []string
+}
+
+// NewQueryLogger creates a new QueryLogger.
+func NewQueryLogger(logger *slog.Logger, opts QueryLoggerOptions) *QueryLogger {
+	scrub := opts.ScrubFields
+	if len(scrub) == 0 {
+		scrub = DefaultScrubFields
+	}
+
+	return &QueryLogger{
+		logger: logger,
+		logAll: opts.LogAll,
+		deny:   toFieldSet(scrub),
+		allow:  toFieldSet(opts.VisibleFields),
+	}
+}
+
+// ExtensionName returns the gqlgen extension name.
+func (l *QueryLogger) ExtensionName() string {
+	return "QueryLogger"
+}
+
+// Validate satisfies graphql.HandlerExtension.
+func (l *QueryLogger) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptResponse logs the operation once its response is available.
+func (l *QueryLogger) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if resp == nil || !graphql.HasOperationContext(ctx) {
+		return resp
+	}
+
+	failed := len(resp.Errors) > 0
+	if !failed && !l.logAll {
+		return resp
+	}
+
+	oc := graphql.GetOperationContext(ctx)
+	attrs := []any{
+		"operation", oc.OperationName,
+		"variables", l.scrubVariables(oc.Variables, variableBindings(oc.Operation)),
+	}
+
+	if failed {
+		attrs = append(attrs, "errors", resp.Errors.Error())
+		l.logger.WarnContext(ctx, "graphql operation failed", attrs...)
+	} else {
+		l.logger.InfoContext(ctx, "graphql operation", attrs...)
+	}
+
+	return resp
+}
+
+// Scrub returns a copy of variables with PII fields masked, taking each
+// variable's name as the field it is passed as.
+func (l *QueryLogger) Scrub(variables map[string]any) map[string]any {
+	return l.scrubVariables(variables, nil)
+}
+
+// scrubVariables masks variables by the names in bindings they are passed
+// as, or by their own name if they are passed as nothing.
+func (l *QueryLogger) scrubVariables(variables map[string]any, bindings map[string][]string) map[string]any {
+	if variables == nil {
+		return nil
+	}
+	out := make(map[string]any, len(variables))
+	for key, value := range variables {
+		names, ok := bindings[key]
+		if !ok {
+			names = []string{key}
+		}
+		out[key] = l.scrubValue(names, value)
+	}
+	return out
+}
+
+func (l *QueryLogger) scrubMap(in map[string]any) map[string]any {
+	out := make(map[string]any, len(in))
+	for key, value := range in {
+		out[key] = l.scrubValue([]string{key}, value)
+	}
+	return out
+}
+
+// scrubValue masks value if any of the names it is passed as is denied,
+// or, in allowlist mode, if any is not visible.
+func (l *QueryLogger) scrubValue(names []string, value any) any {
+	for _, name := range names {
+		if l.deny[strings.ToLower(name)] {
+			return scrubbedValue
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return l.scrubMap(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = l.scrubValue(names, item)
+		}
+		return out
+	}
+
+	if len(l.allow) > 0 {
+		for _, name := range names {
+			if !l.allow[strings.ToLower(name)] {
+				return scrubbedValue
+			}
+		}
+	}
+	return value
+}
+
+// variableBindings maps each variable used in op to the names of the
+// arguments and input fields it is passed as.
+func variableBindings(op *ast.OperationDefinition) map[string][]string {
+	bindings := make(map[string][]string)
+	if op != nil {
+		bindSelections(bindings, op.SelectionSet, make(map[string]bool))
+	}
+	return bindings
+}
+
+// bindSelections records the variables passed in a selection set and the
+// fragments it spreads, visiting each fragment once.
+func bindSelections(bindings map[string][]string, selections ast.SelectionSet, visited map[string]bool) {
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			for _, arg := range s.Arguments {
+				bindValue(bindings, arg.Name, arg.Value)
+			}
+			bindDirectives(bindings, s.Directives)
+			bindSelections(bindings, s.SelectionSet, visited)
+		case *ast.InlineFragment:
+			bindDirectives(bindings, s.Directives)
+			bindSelections(bindings, s.SelectionSet, visited)
+		case *ast.FragmentSpread:
+			bindDirectives(bindings, s.Directives)
+			if s.Definition != nil && !visited[s.Name] {
+				visited[s.Name] = true
+				bindSelections(bindings, s.Definition.SelectionSet, visited)
+			}
+		}
+	}
+}
+
+func bindDirectives(bindings map[string][]string, directives ast.DirectiveList) {
+	for _, directive := range directives {
+		for _, arg := range directive.Arguments {
+			bindValue(bindings, arg.Name, arg.Value)
+		}
+	}
+}
+
+// bindValue records the variables in value as passed as name, and those in
+// an input object as passed as its fields.
+func bindValue(bindings map[string][]string, name string, value *ast.Value) {
+	if value == nil {
+		return
+	}
+	switch value.Kind {
+	case ast.Variable:
+		bindings[value.Raw] = append(bindings[value.Raw], name)
+	case ast.ObjectValue:
+		for _, child := range value.Children {
+			bindValue(bindings, child.Name, child.Value)
+		}
+	case ast.ListValue:
+		for _, child := range value.Children {
+			bindValue(bindings, name, child.Value)
+		}
+	}
+}
+
+// toFieldSet builds a case-insensitive lookup set of field names.
+func toFieldSet(fields []string) map[string]bool {
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			set[strings.ToLower(f)] = true
+		}
+	}
+	return set
+}
+
+// Ensure QueryLogger implements the gqlgen extension interfaces
+var (
+	_ graphql.HandlerExtension    = (*QueryLogger)(nil)
+	_ graphql.ResponseInterceptor = (*QueryLogger)(nil)
+)
@@ -0,0 +1,157 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func newTestQueryLogger(opts QueryLoggerOptions) (*QueryLogger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	return NewQueryLogger(logger, opts), &buf
+}
+
+func operationContext(name string, variables map[string]any) context.Context {
+	return graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+		OperationName: name,
+		Variables:     variables,
+	})
+}
+
+func TestQueryLogger_FailedMutation_LogsScrubbedVariables(t *testing.T) {
+	// Arrange
+	ql, buf := newTestQueryLogger(QueryLoggerOptions{})
+	ctx := operationContext("InviteMember", map[string]any{
+		"tenantId": "tenant-123",
+		"input":    map[string]any{"email": "alice@example.com", "role": "ADMIN"},
+	})
+
+	// Act
+	ql.InterceptResponse(ctx, func(ctx context.Context) *graphql.Response {
+		return &graphql.Response{Errors: gqlerror.List{gqlerror.Errorf("forbidden")}}
+	})
+
+	// Assert
+	out := buf.String()
+	assert.Contains(t, out, "graphql operation failed")
+	assert.Contains(t, out, "InviteMember")
+	assert.Contains(t, out, "tenant-123")
+	assert.Contains(t, out, "ADMIN")
+	assert.NotContains(t, out, "alice@example.com")
+}
+
+func TestQueryLogger_SuccessfulOperation_LogsNothingByDefault(t *testing.T) {
+	// Arrange
+	ql, buf := newTestQueryLogger(QueryLoggerOptions{})
+	ctx := operationContext("CreateTenant", map[string]any{"name": "Acme"})
+
+	// Act
+	ql.InterceptResponse(ctx, func(ctx context.Context) *graphql.Response {
+		return &graphql.Response{}
+	})
+
+	// Assert
+	assert.Empty(t, buf.String())
+}
+
+func TestQueryLogger_LogAll_LogsSuccessAtInfo(t *testing.T) {
+	// Arrange
+	ql, buf := newTestQueryLogger(QueryLoggerOptions{LogAll: true})
+	ctx := operationContext("CreateTenant", map[string]any{"name": "Acme", "slug": "acme"})
+
+	// Act
+	ql.InterceptResponse(ctx, func(ctx context.Context) *graphql.Response {
+		return &graphql.Response{}
+	})
+
+	// Assert
+	out := buf.String()
+	assert.Contains(t, out, "level=INFO")
+	assert.Contains(t, out, "acme")
+	assert.NotContains(t, out, "Acme")
+}
+
+func TestQueryLogger_Scrub_AllowlistMode(t *testing.T) {
+	// Arrange
+	ql, _ := newTestQueryLogger(QueryLoggerOptions{VisibleFields: []string{"tenantId"}})
+
+	// Act
+	scrubbed := ql.Scrub(map[string]any{
+		"tenantId": "tenant-123",
+		"role":     "ADMIN",
+		"ids":      []any{"a", "b"},
+	})
+
+	// Assert
+	assert.Equal(t, "tenant-123", scrubbed["tenantId"])
+	assert.Equal(t, scrubbedValue, scrubbed["role"])
+	assert.Equal(t, []any{scrubbedValue, scrubbedValue}, scrubbed["ids"])
+}
+
+func TestQueryLogger_RenamedVariables_ScrubbedByArgument(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		opts      QueryLoggerOptions
+		query     string
+		variables map[string]any
+		masked    []string
+		visible   []string
+	}{
+		{
+			desc:      "argument",
+			query:     `mutation($t: String!) { acceptInviteByToken(token: $t) { id } }`,
+			variables: map[string]any{"t": "invite-token-123"},
+			masked:    []string{"t"},
+		},
+		{
+			desc:      "among other arguments",
+			query:     `mutation($id: ID!, $e: String!) { setTenantBillingEmail(tenantId: $id, email: $e) { id } }`,
+			variables: map[string]any{"id": "tenant-123", "e": "billing@example.com"},
+			masked:    []string{"e"},
+			visible:   []string{"id"},
+		},
+		{
+			desc:      "input field in a fragment",
+			query:     `mutation($x: String!) { ... on Mutation { inviteMember(input: {email: $x, role: ADMIN}) { id } } }`,
+			variables: map[string]any{"x": "alice@example.com"},
+			masked:    []string{"x"},
+		},
+		{
+			desc:      "allowlist",
+			opts:      QueryLoggerOptions{VisibleFields: []string{"id"}},
+			query:     `query($v: ID!, $q: String) { tenant(id: $v) { members(search: $q) { id } } }`,
+			variables: map[string]any{"v": "tenant-123", "q": "alice"},
+			masked:    []string{"q"},
+			visible:   []string{"v"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			ql, _ := newTestQueryLogger(tc.opts)
+			doc, err := parser.ParseQuery(&ast.Source{Input: tc.query})
+			require.NoError(t, err)
+
+			// Act
+			scrubbed := ql.scrubVariables(tc.variables, variableBindings(doc.Operations[0]))
+
+			// Assert
+			for _, name := range tc.masked {
+				assert.Equal(t, scrubbedValue, scrubbed[name], name)
+			}
+			for _, name := range tc.visible {
+				assert.Equal(t, tc.variables[name], scrubbed[name], name)
+			}
+		})
+	}
+}
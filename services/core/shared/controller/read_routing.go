@@ -0,0 +1,68 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yourusername/grgn-stack/pkg/dbctx"
+)
+
+// ReadRoutingMode selects whether reads wait for earlier writes. In either
+// mode reads open AccessModeRead sessions, which a neo4j:// or neo4j+s://
+// routing driver sends to followers and read replicas.
+type ReadRoutingMode string
+
+const (
+	// ReadRoutingAuto is causal when a read URI is configured and followers otherwise.
+	ReadRoutingAuto ReadRoutingMode = ""
+
+	// ReadRoutingFollowers lets reads lag writes; ExecuteReadAfterWrite
+	// still observes the writes made earlier in the same request.
+	ReadRoutingFollowers ReadRoutingMode = "followers"
+
+	// ReadRoutingCausal shares one bookmark manager across all sessions, so
+	// every read waits for every write this process has completed.
+	ReadRoutingCausal ReadRoutingMode = "causal"
+)
+
+// parseReadRoutingMode validates mode, resolving ReadRoutingAuto.
+func parseReadRoutingMode(mode string, hasReadURI bool) (ReadRoutingMode, error) {
+	switch ReadRoutingMode(mode) {
+	case ReadRoutingAuto:
+		if hasReadURI {
+			return ReadRoutingCausal, nil
+		}
+		return ReadRoutingFollowers, nil
+	case ReadRoutingFollowers, ReadRoutingCausal:
+		return ReadRoutingMode(mode), nil
+	default:
+		return "", fmt.Errorf("unknown read routing mode %q (want %q or %q)", mode, ReadRoutingFollowers, ReadRoutingCausal)
+	}
+}
+
+// ExecuteReadAfterWrite executes a read transaction like ExecuteRead that
+// observes every write made earlier through ExecuteWrite or
+// ExecuteWriteWithTimeout with the same request context.
+//
+// The guarantee is causal, not global: the read waits until the serving
+// member has applied the bookmarks collected by dbctx.WithBookmarks, so it
+// sees those writes and anything they depended on, but may still miss
+// writes made by other requests. Without a bookmark holder in ctx, or
+// before the request has written, it behaves exactly like ExecuteRead.
+func (db *Neo4jDB) ExecuteReadAfterWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return db.executeRead(ctx, db.queryTimeout(), neo4j.SessionConfig{
+		AccessMode: neo4j.AccessModeRead,
+		Bookmarks:  dbctx.Bookmarks(ctx),
+	}, work)
+}
+
+// RequestBookmarks installs a bookmark holder in each request's context so
+// ExecuteReadAfterWrite can observe the request's own writes.
+func RequestBookmarks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(dbctx.WithBookmarks(c.Request.Context()))
+		c.Next()
+	}
+}
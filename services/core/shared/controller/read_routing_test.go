@@ -0,0 +1,119 @@
+package shared
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/dbctx"
+	"github.com/yourusername/grgn-stack/pkg/retry"
+)
+
+func TestNeo4jDB_ExecuteReadAfterWrite_WaitsForRequestWrites(t *testing.T) {
+	// Arrange
+	primary := &fakeDriver{name: "primary"}
+	replica := &fakeDriver{name: "replica"}
+	db := &Neo4jDB{driver: primary, readDriver: replica}
+	ctx := dbctx.WithBookmarks(context.Background())
+
+	// Act
+	_, writeErr := db.ExecuteWrite(ctx, nil)
+	afterWrite, afterWriteErr := db.ExecuteReadAfterWrite(ctx, nil)
+	plain, plainErr := db.ExecuteRead(ctx, nil)
+
+	// Assert
+	require.NoError(t, writeErr)
+	require.NoError(t, afterWriteErr)
+	require.NoError(t, plainErr)
+	require.Len(t, replica.sessions, 2)
+	assert.Equal(t, neo4j.AccessModeRead, replica.sessions[0].AccessMode)
+	assert.Equal(t, neo4j.Bookmarks{"bm:primary"}, afterWrite)
+	assert.Empty(t, plain, "followers mode lets plain reads lag writes")
+}
+
+func TestNeo4jDB_ExecuteReadAfterWrite_WithoutHolderIsPlainRead(t *testing.T) {
+	// Arrange
+	primary := &fakeDriver{name: "primary"}
+	db := &Neo4jDB{driver: primary}
+	ctx := context.Background()
+
+	// Act
+	_, writeErr := db.ExecuteWrite(ctx, nil)
+	seen, readErr := db.ExecuteReadAfterWrite(ctx, nil)
+
+	// Assert
+	require.NoError(t, writeErr)
+	require.NoError(t, readErr)
+	assert.Empty(t, seen)
+	require.Len(t, primary.sessions, 2)
+	assert.Empty(t, primary.sessions[1].Bookmarks)
+}
+
+func TestParseReadRoutingMode(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		mode       string
+		hasReadURI bool
+		want       ReadRoutingMode
+	}{
+		{"auto without read URI", "", false, ReadRoutingFollowers},
+		{"auto with read URI", "", true, ReadRoutingCausal},
+		{"explicit followers with read URI", "followers", true, ReadRoutingFollowers},
+		{"explicit causal", "causal", false, ReadRoutingCausal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Act
+			got, err := parseReadRoutingMode(tc.mode, tc.hasReadURI)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNewNeo4jDB_UnknownReadRouting(t *testing.T) {
+	// Arrange
+	cfg := &config.Config{Database: config.DatabaseConfig{
+		Neo4jURI:    "bolt://localhost:7687",
+		ReadRouting: "leader",
+	}}
+
+	// Act
+	db, err := NewNeo4jDB(cfg, retry.Policy{})
+
+	// Assert
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, `unknown read routing mode "leader"`)
+}
+
+func TestRequestBookmarks_InstallsHolderPerRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestBookmarks())
+	var seen [][]string
+	r.GET("/write", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		seen = append(seen, dbctx.Bookmarks(ctx))
+		assert.True(t, dbctx.AddBookmarks(ctx, []string{"bm:1"}))
+		c.Status(http.StatusNoContent)
+	})
+
+	// Act
+	for range 2 {
+		req, _ := http.NewRequest("GET", "/write", nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Assert
+	assert.Equal(t, [][]string{nil, nil}, seen, "bookmarks do not leak between requests")
+}
@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yourusername/grgn-stack/pkg/reqid"
+)
+
+// maxRequestIDLength bounds the client-supplied request IDs that are kept.
+const maxRequestIDLength = 128
+
+// RequestIDs gives each request an ID: the client's or proxy's X-Request-ID
+// if it sent a usable one, otherwise a new UUID. The ID is stored in the
+// request context for reqid.FromContext and echoed on the response.
+// Register it before RequestLogger so access logs carry the ID.
+func RequestIDs() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.New().String()
+		}
+		c.Request = c.Request.WithContext(reqid.WithRequestID(c.Request.Context(), id))
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// validRequestID reports whether a client-supplied ID is safe to log and
+// echo: non-empty, bounded and printable ASCII.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
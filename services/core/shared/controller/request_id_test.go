@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/reqid"
+)
+
+// serveWithRequestID runs a request through RequestIDs, returning the
+// response and the ID the handler found in its context.
+func serveWithRequestID(header string) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.TestMode)
+	var seen string
+	r := gin.New()
+	r.Use(RequestIDs())
+	r.GET("/ping", func(c *gin.Context) {
+		seen = reqid.FromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest("GET", "/ping", nil)
+	if header != "" {
+		req.Header.Set(RequestIDHeader, header)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w, seen
+}
+
+func TestRequestIDs_GeneratedWhenAbsent(t *testing.T) {
+	// Act
+	w, seen := serveWithRequestID("")
+
+	// Assert
+	id := w.Header().Get(RequestIDHeader)
+	_, err := uuid.Parse(id)
+	require.NoError(t, err)
+	assert.Equal(t, id, seen)
+}
+
+func TestRequestIDs_PreservedWhenPresent(t *testing.T) {
+	// Act
+	w, seen := serveWithRequestID("req-123")
+
+	// Assert
+	assert.Equal(t, "req-123", w.Header().Get(RequestIDHeader))
+	assert.Equal(t, "req-123", seen)
+}
+
+func TestRequestIDs_ReplacesUnusableIDs(t *testing.T) {
+	for _, header := range []string{strings.Repeat("a", maxRequestIDLength+1), "two words"} {
+		// Act
+		w, seen := serveWithRequestID(header)
+
+		// Assert
+		id := w.Header().Get(RequestIDHeader)
+		assert.NotEqual(t, header, id)
+		_, err := uuid.Parse(id)
+		assert.NoError(t, err)
+		assert.Equal(t, id, seen)
+	}
+}
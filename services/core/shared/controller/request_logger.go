@@ -0,0 +1,73 @@
+package shared
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/logging"
+)
+
+// RequestLoggerOptions configures RequestLogger.
+type RequestLoggerOptions struct {
+	// Level is the level for successful and client-error requests.
+	// Server errors (5xx) are always logged at error level.
+	Level slog.Level
+
+	// SkipPaths are request paths (e.g., health checks) that are not logged.
+	SkipPaths []string
+}
+
+// RequestLogger returns a middleware that writes one structured access log
+// record per request, replacing gin's default text logger. Handlers find a
+// logger carrying the request ID in the request context, via
+// logging.FromContext.
+func RequestLogger(logger *slog.Logger, opts RequestLoggerOptions) gin.HandlerFunc {
+	skip := make(map[string]bool, len(opts.SkipPaths))
+	for _, path := range opts.SkipPaths {
+		skip[path] = true
+	}
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if skip[path] {
+			c.Next()
+			return
+		}
+
+		requestLogger := logger
+		if requestID := RequestID(c); requestID != "" {
+			requestLogger = logger.With("requestId", requestID)
+		}
+		c.Request = c.Request.WithContext(logging.WithLogger(c.Request.Context(), requestLogger))
+
+		start := time.Now()
+		c.Next()
+		latency := time.Since(start)
+
+		status := c.Writer.Status()
+		level := opts.Level
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		ctx := c.Request.Context()
+		attrs := []any{
+			"method", c.Request.Method,
+			"path", path,
+			"status", status,
+			"latency", latency,
+			"clientIp", c.ClientIP(),
+		}
+		if requestID := RequestID(c); requestID != "" {
+			attrs = append(attrs, "requestId", requestID)
+		}
+		if userID, err := auth.GetUserID(ctx); err == nil {
+			attrs = append(attrs, "userId", userID)
+		}
+
+		logger.Log(ctx, level, "http request", attrs...)
+	}
+}
@@ -0,0 +1,110 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/logging"
+)
+
+// newLoggedRouter returns a router using RequestLogger that writes JSON records to the buffer.
+func newLoggedRouter(opts RequestLoggerOptions) (*gin.Engine, *bytes.Buffer) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	r := gin.New()
+	r.Use(RequestLogger(logger, opts))
+	r.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-User-ID"); userID != "" {
+			c.Request = c.Request.WithContext(auth.WithUserID(c.Request.Context(), userID))
+		}
+		c.Next()
+	})
+	r.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	r.GET("/tenants", func(c *gin.Context) { c.String(http.StatusCreated, "ok") })
+	r.GET("/boom", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+	r.GET("/work", func(c *gin.Context) {
+		logging.FromContext(c.Request.Context()).Info("working")
+		c.Status(http.StatusOK)
+	})
+	return r, &buf
+}
+
+func TestRequestLogger_RecordsRequestFields(t *testing.T) {
+	// Arrange
+	r, buf := newLoggedRouter(RequestLoggerOptions{Level: slog.LevelInfo})
+	req, _ := http.NewRequest("GET", "/tenants", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	req.Header.Set("X-User-ID", "user-1")
+	req.RemoteAddr = "10.0.0.1:1234"
+
+	// Act
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "http request", record["msg"])
+	assert.Equal(t, "GET", record["method"])
+	assert.Equal(t, "/tenants", record["path"])
+	assert.Equal(t, float64(http.StatusCreated), record["status"])
+	assert.Equal(t, "10.0.0.1", record["clientIp"])
+	assert.Equal(t, "req-123", record["requestId"])
+	assert.Equal(t, "user-1", record["userId"])
+	assert.Contains(t, record, "latency")
+}
+
+func TestRequestLogger_SkipPaths(t *testing.T) {
+	// Arrange
+	r, buf := newLoggedRouter(RequestLoggerOptions{SkipPaths: []string{"/ping"}})
+
+	// Act
+	req, _ := http.NewRequest("GET", "/ping", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, buf.String())
+}
+
+func TestRequestLogger_ServerErrorsLoggedAtError(t *testing.T) {
+	// Arrange
+	r, buf := newLoggedRouter(RequestLoggerOptions{Level: slog.LevelDebug})
+
+	// Act
+	req, _ := http.NewRequest("GET", "/boom", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "ERROR", record["level"])
+	assert.NotContains(t, record, "userId")
+}
+
+func TestRequestLogger_ContextLoggerCarriesRequestID(t *testing.T) {
+	// Arrange
+	r, buf := newLoggedRouter(RequestLoggerOptions{Level: slog.LevelInfo})
+	req, _ := http.NewRequest("GET", "/work", nil)
+	req.Header.Set(RequestIDHeader, "req-456")
+
+	// Act
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert - the handler's record comes first, then the access log
+	var record map[string]any
+	require.NoError(t, json.NewDecoder(buf).Decode(&record))
+	assert.Equal(t, "working", record["msg"])
+	assert.Equal(t, "req-456", record["requestId"])
+}
@@ -0,0 +1,124 @@
+package shared
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yourusername/grgn-stack/pkg/logging"
+	"github.com/yourusername/grgn-stack/pkg/reqid"
+)
+
+// queryLabelKey is the context key for the label set by WithQueryLabel.
+type queryLabelKey struct{}
+
+// unlabeledQuery is logged for transactions whose caller can't be found.
+const unlabeledQuery = "unlabeled"
+
+// WithQueryLabel returns a context that names the transactions run with it
+// in slow query logs, e.g. "tenant.FindByUserID". Transactions run without
+// one are named after the function that ran them.
+func WithQueryLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, queryLabelKey{}, label)
+}
+
+// QueryLabel returns the label set by WithQueryLabel, if any.
+func QueryLabel(ctx context.Context) (string, bool) {
+	label, ok := ctx.Value(queryLabelKey{}).(string)
+	return label, ok && label != ""
+}
+
+// txPlumbing prefixes the functions that run transactions on a caller's
+// behalf, which callerLabel looks past.
+var txPlumbing = func() []string {
+	pkg := reflect.TypeOf(Neo4jDB{}).PkgPath()
+	return []string{pkg + ".(*Neo4jDB).", pkg + ".(*Neo4jUnitOfWork)."}
+}()
+
+// queryLabel returns the label set by WithQueryLabel, or one naming the
+// function that ran the transaction, such as "membership.ListMembers" for
+// MembershipRepository.ListMembers. It must be called from a Neo4jDB method.
+func queryLabel(ctx context.Context) string {
+	if label, ok := QueryLabel(ctx); ok {
+		return label
+	}
+
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		plumbing := false
+		for _, prefix := range txPlumbing {
+			plumbing = plumbing || strings.HasPrefix(frame.Function, prefix)
+		}
+		if !plumbing && frame.Function != "" {
+			return callerLabel(frame.Function)
+		}
+		if !more {
+			return unlabeledQuery
+		}
+	}
+}
+
+// callerLabel shortens a qualified function name to a query label: the
+// receiver type without its Repository suffix, or the package for plain
+// functions, then the function. Closures are named after the function
+// they are in.
+func callerLabel(function string) string {
+	parts := strings.Split(function[strings.LastIndex(function, "/")+1:], ".")
+	for len(parts) > 2 && isClosureName(parts[len(parts)-1]) {
+		parts = parts[:len(parts)-1]
+	}
+
+	owner := parts[0]
+	if len(parts) > 2 {
+		receiver, _, _ := strings.Cut(strings.Trim(parts[1], "(*)"), "[")
+		if receiver = strings.TrimSuffix(receiver, "Repository"); receiver != "" {
+			owner = receiver
+		}
+	}
+	runes := []rune(owner)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes) + "." + strings.TrimSuffix(parts[len(parts)-1], "-fm")
+}
+
+// isClosureName reports whether part of a function name is one the
+// compiler gives closures, such as func1 or, when nested, 2.
+func isClosureName(part string) bool {
+	return strings.HasPrefix(part, "func") || strings.Trim(part, "0123456789") == ""
+}
+
+// logSlowQuery logs a transaction that took longer than the configured slow
+// query threshold. A zero threshold disables the log.
+func (db *Neo4jDB) logSlowQuery(ctx context.Context, mode neo4j.AccessMode, elapsed time.Duration) {
+	if db.config == nil {
+		return
+	}
+	threshold := db.config.Database.SlowQueryThreshold
+	if threshold <= 0 || elapsed < threshold {
+		return
+	}
+
+	label := queryLabel(ctx)
+	accessMode := "write"
+	if mode == neo4j.AccessModeRead {
+		accessMode = "read"
+	}
+	attrs := []any{
+		"label", label,
+		"duration", elapsed,
+		"mode", accessMode,
+		"threshold", threshold,
+	}
+	// A request-scoped logger already carries the request ID
+	logger := logging.FromContext(ctx)
+	if id := reqid.FromContext(ctx); id != "" && logger == slog.Default() {
+		attrs = append(attrs, "requestId", id)
+	}
+	logger.WarnContext(ctx, "slow query", attrs...)
+}
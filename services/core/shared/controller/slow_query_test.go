@@ -0,0 +1,160 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/logging"
+	"github.com/yourusername/grgn-stack/pkg/reqid"
+)
+
+// captureDefaultLog sends the default slog logger to a buffer for the test.
+func captureDefaultLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestNeo4jDB_SlowQueryLog(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		threshold time.Duration
+		delay     time.Duration
+		logged    bool
+	}{
+		{"slower than threshold", time.Millisecond, 5 * time.Millisecond, true},
+		{"faster than threshold", time.Hour, 0, false},
+		{"zero threshold disables", 0, 5 * time.Millisecond, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			buf := captureDefaultLog(t)
+			db := &Neo4jDB{
+				driver: &fakeDriver{name: "primary", delay: tc.delay},
+				config: &config.Config{Database: config.DatabaseConfig{SlowQueryThreshold: tc.threshold}},
+			}
+			ctx := WithQueryLabel(context.Background(), "tenant.FindByID")
+
+			// Act
+			_, err := db.ExecuteRead(ctx, nil)
+
+			// Assert
+			require.NoError(t, err)
+			if !tc.logged {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Contains(t, buf.String(), "slow query")
+			assert.Contains(t, buf.String(), "label=tenant.FindByID")
+			assert.Contains(t, buf.String(), "mode=read")
+			assert.Contains(t, buf.String(), "duration=")
+		})
+	}
+}
+
+// widgetRepository runs transactions the way the services' repositories do.
+type widgetRepository struct {
+	db  *Neo4jDB
+	uow IUnitOfWork
+}
+
+func (r *widgetRepository) FindByID(ctx context.Context) error {
+	_, err := r.db.ExecuteRead(ctx, nil)
+	return err
+}
+
+func (r *widgetRepository) Rename(ctx context.Context) error {
+	return r.uow.Write(ctx, func(tx neo4j.ManagedTransaction) error { return nil })
+}
+
+func TestNeo4jDB_SlowQueryLog_LabelsByCaller(t *testing.T) {
+	// Arrange
+	buf := captureDefaultLog(t)
+	db := &Neo4jDB{
+		driver: &fakeDriver{name: "primary", delay: 5 * time.Millisecond},
+		config: &config.Config{Database: config.DatabaseConfig{SlowQueryThreshold: time.Millisecond}},
+	}
+	repo := &widgetRepository{db: db, uow: NewNeo4jUnitOfWork(db)}
+
+	// Act
+	readErr := repo.FindByID(context.Background())
+	writeErr := repo.Rename(context.Background())
+
+	// Assert - the unit of work is looked past to the repository
+	require.NoError(t, readErr)
+	require.NoError(t, writeErr)
+	assert.Contains(t, buf.String(), "label=widget.FindByID duration=")
+	assert.Contains(t, buf.String(), "label=widget.Rename duration=")
+	assert.Contains(t, buf.String(), "mode=write")
+}
+
+func TestCallerLabel(t *testing.T) {
+	testCases := []struct {
+		function string
+		want     string
+	}{
+		{"github.com/acme/app/tenant/repository.(*MembershipRepository).ListMembers", "membership.ListMembers"},
+		{"github.com/acme/app/tenant/repository.(*TenantRepository).FindBySlug.func1", "tenant.FindBySlug"},
+		{"github.com/acme/app/tenant/service.(*TenantService).OffboardUser.func2.1", "tenantService.OffboardUser"},
+		{"github.com/acme/app/shared.OutboxRepository.Claim-fm", "outbox.Claim"},
+		{"github.com/acme/app/shared.(*Repository[...]).Get", "shared.Get"},
+		{"github.com/acme/app/migrate.runAll.func1", "migrate.runAll"},
+		{"main.main", "main.main"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, callerLabel(tc.function))
+		})
+	}
+}
+
+func TestNeo4jDB_SlowQueryLog_IncludesRequestID(t *testing.T) {
+	// Arrange
+	buf := captureDefaultLog(t)
+	db := &Neo4jDB{
+		driver: &fakeDriver{name: "primary", delay: 5 * time.Millisecond},
+		config: &config.Config{Database: config.DatabaseConfig{SlowQueryThreshold: time.Millisecond}},
+	}
+	ctx := reqid.WithRequestID(context.Background(), "req-789")
+
+	// Act
+	_, err := db.ExecuteWrite(ctx, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "requestId=req-789")
+}
+
+func TestNeo4jDB_SlowQueryLog_UsesRequestLogger(t *testing.T) {
+	// Arrange
+	defaultLog := captureDefaultLog(t)
+	var requestLog bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&requestLog, nil)).With("requestId", "req-789")
+	db := &Neo4jDB{
+		driver: &fakeDriver{name: "primary", delay: 5 * time.Millisecond},
+		config: &config.Config{Database: config.DatabaseConfig{SlowQueryThreshold: time.Millisecond}},
+	}
+	ctx := logging.WithLogger(reqid.WithRequestID(context.Background(), "req-789"), logger)
+
+	// Act
+	_, err := db.ExecuteRead(ctx, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, defaultLog.String())
+	assert.Contains(t, requestLog.String(), "slow query")
+	assert.Equal(t, 1, bytes.Count(requestLog.Bytes(), []byte("requestId=req-789")))
+}
@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// ResolverTiming is the duration of one call to a resolver tagged with @trace.
+type ResolverTiming struct {
+	// Field is the schema coordinate, e.g. "Query.myTenants".
+	Field string
+	// Label is the optional label given to the directive.
+	Label string
+	// Path is the field's position in the response, e.g. "myTenants[0].owners".
+	Path     string
+	Duration time.Duration
+	Err      error
+}
+
+// ITimingRecorder receives resolver timings, e.g. to feed a metrics backend.
+type ITimingRecorder interface {
+	RecordTiming(ctx context.Context, timing ResolverTiming)
+}
+
+// TraceDirectiveFunc is the signature gqlgen expects for the @trace directive.
+type TraceDirectiveFunc func(ctx context.Context, obj any, next graphql.Resolver, label *string) (any, error)
+
+// TraceDirective implements @trace. When enabled, tagged resolvers are timed
+// and each call is logged at debug level and passed to recorder, which may be
+// nil. When disabled the directive only calls the resolver, so tags can stay
+// in the schema outside development.
+func TraceDirective(enabled bool, logger *slog.Logger, recorder ITimingRecorder) TraceDirectiveFunc {
+	if !enabled {
+		return func(ctx context.Context, obj any, next graphql.Resolver, label *string) (any, error) {
+			return next(ctx)
+		}
+	}
+
+	return func(ctx context.Context, obj any, next graphql.Resolver, label *string) (any, error) {
+		start := time.Now()
+		res, err := next(ctx)
+
+		timing := ResolverTiming{Duration: time.Since(start), Err: err}
+		if label != nil {
+			timing.Label = *label
+		}
+		if fc := graphql.GetFieldContext(ctx); fc != nil {
+			timing.Field = fc.Object + "." + fc.Field.Name
+			timing.Path = fc.Path().String()
+		}
+
+		logger.DebugContext(ctx, "resolver trace",
+			"field", timing.Field, "label", timing.Label, "path", timing.Path,
+			"duration", timing.Duration, "error", err)
+		if recorder != nil {
+			recorder.RecordTiming(ctx, timing)
+		}
+		return res, err
+	}
+}
@@ -0,0 +1,93 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// timingRecorder collects the timings it receives.
+type timingRecorder struct {
+	timings []ResolverTiming
+}
+
+func (r *timingRecorder) RecordTiming(ctx context.Context, timing ResolverTiming) {
+	r.timings = append(r.timings, timing)
+}
+
+func fieldContext(object, field string) context.Context {
+	return graphql.WithFieldContext(context.Background(), &graphql.FieldContext{
+		Object: object,
+		Field:  graphql.CollectedField{Field: &ast.Field{Name: field, Alias: field}},
+	})
+}
+
+func TestTraceDirective_Enabled_RecordsTiming(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	recorder := &timingRecorder{}
+	trace := TraceDirective(true, logger, recorder)
+	label := "slow-list"
+	resolveErr := errors.New("boom")
+
+	// Act
+	res, err := trace(fieldContext("Query", "myTenants"), nil, func(ctx context.Context) (any, error) {
+		return "result", resolveErr
+	}, &label)
+
+	// Assert
+	assert.Equal(t, "result", res)
+	assert.Same(t, resolveErr, err)
+	require.Len(t, recorder.timings, 1)
+	timing := recorder.timings[0]
+	assert.Equal(t, "Query.myTenants", timing.Field)
+	assert.Equal(t, "myTenants", timing.Path)
+	assert.Equal(t, "slow-list", timing.Label)
+	assert.Same(t, resolveErr, timing.Err)
+	assert.Positive(t, timing.Duration)
+	assert.Contains(t, buf.String(), "resolver trace")
+	assert.Contains(t, buf.String(), "Query.myTenants")
+}
+
+func TestTraceDirective_Disabled_OnlyResolves(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	recorder := &timingRecorder{}
+	trace := TraceDirective(false, logger, recorder)
+
+	// Act
+	res, err := trace(fieldContext("Query", "myTenants"), nil, func(ctx context.Context) (any, error) {
+		return "result", nil
+	}, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "result", res)
+	assert.Empty(t, recorder.timings)
+	assert.Empty(t, buf.String())
+}
+
+func TestTraceDirective_NilRecorder_Logs(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	trace := TraceDirective(true, logger, nil)
+
+	// Act
+	_, err := trace(fieldContext("Tenant", "owners"), nil, func(ctx context.Context) (any, error) {
+		return nil, nil
+	}, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Tenant.owners")
+}
@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/yourusername/grgn-stack/pkg/dbctx"
+	"github.com/yourusername/grgn-stack/pkg/logging"
+)
+
+// TxModeHints is a gqlgen extension that hints the transaction mode each
+// operation should use: read for queries and subscriptions, write for
+// mutations. In development, Neo4jDB logs write transactions run by
+// queries and subscriptions. Mutations read as well as write, so reads in
+// them are expected.
+type TxModeHints struct{}
+
+// ExtensionName returns the gqlgen extension name.
+func (TxModeHints) ExtensionName() string {
+	return "TxModeHints"
+}
+
+// Validate satisfies graphql.HandlerExtension.
+func (TxModeHints) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptOperation places the operation's TxMode in its context.
+func (TxModeHints) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	if oc.Operation == nil {
+		return next(ctx)
+	}
+	mode := dbctx.TxModeRead
+	if oc.Operation.Operation == ast.Mutation {
+		mode = dbctx.TxModeWrite
+	}
+	return next(dbctx.WithTxMode(ctx, mode))
+}
+
+// checkTxMode logs, in development only, a write transaction run where ctx
+// hints TxModeRead, naming it by its query label.
+func (db *Neo4jDB) checkTxMode(ctx context.Context, mode neo4j.AccessMode) {
+	if db.config == nil || !db.config.IsDevelopment() || mode != neo4j.AccessModeWrite {
+		return
+	}
+	if hint, ok := dbctx.TxModeFrom(ctx); !ok || hint != dbctx.TxModeRead {
+		return
+	}
+
+	logging.FromContext(ctx).WarnContext(ctx, "transaction mode does not match operation",
+		"label", queryLabel(ctx),
+		"mode", string(dbctx.TxModeWrite),
+		"expected", string(dbctx.TxModeRead),
+	)
+}
+
+var (
+	_ graphql.HandlerExtension     = TxModeHints{}
+	_ graphql.OperationInterceptor = TxModeHints{}
+)
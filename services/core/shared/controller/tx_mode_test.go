@@ -0,0 +1,118 @@
+package shared
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/yourusername/grgn-stack/pkg/config"
+	"github.com/yourusername/grgn-stack/pkg/dbctx"
+)
+
+func TestTxModeHints_InterceptOperation(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		operation ast.Operation
+		want      dbctx.TxMode
+	}{
+		{"query", ast.Query, dbctx.TxModeRead},
+		{"mutation", ast.Mutation, dbctx.TxModeWrite},
+		{"subscription", ast.Subscription, dbctx.TxModeRead},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			ctx := graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+				Operation: &ast.OperationDefinition{Operation: tc.operation},
+			})
+			var got dbctx.TxMode
+			var ok bool
+
+			// Act
+			TxModeHints{}.InterceptOperation(ctx, func(ctx context.Context) graphql.ResponseHandler {
+				got, ok = dbctx.TxModeFrom(ctx)
+				return nil
+			})
+
+			// Assert
+			require.True(t, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNeo4jDB_TxModeMismatchLog(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		environment string
+		hint        *dbctx.TxMode
+		write       bool
+		logged      bool
+	}{
+		{"write in query", "development", ptrTo(dbctx.TxModeRead), true, true},
+		{"read in mutation", "development", ptrTo(dbctx.TxModeWrite), false, false},
+		{"read in query", "development", ptrTo(dbctx.TxModeRead), false, false},
+		{"write in mutation", "development", ptrTo(dbctx.TxModeWrite), true, false},
+		{"outside an operation", "development", nil, false, false},
+		{"production", "production", ptrTo(dbctx.TxModeRead), true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			buf := captureDefaultLog(t)
+			db := &Neo4jDB{
+				driver: &fakeDriver{name: "primary"},
+				config: &config.Config{Server: config.ServerConfig{Environment: tc.environment}},
+			}
+			ctx := WithQueryLabel(context.Background(), "tenant.FindByID")
+			if tc.hint != nil {
+				ctx = dbctx.WithTxMode(ctx, *tc.hint)
+			}
+
+			// Act
+			var err error
+			if tc.write {
+				_, err = db.ExecuteWrite(ctx, nil)
+			} else {
+				_, err = db.ExecuteRead(ctx, nil)
+			}
+
+			// Assert
+			require.NoError(t, err)
+			if !tc.logged {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Contains(t, buf.String(), "level=WARN")
+			assert.Contains(t, buf.String(), "transaction mode does not match operation")
+			assert.Contains(t, buf.String(), "label=tenant.FindByID")
+		})
+	}
+}
+
+func TestNeo4jDB_TxModeMismatchLog_LabelsByCaller(t *testing.T) {
+	// Arrange
+	buf := captureDefaultLog(t)
+	db := &Neo4jDB{
+		driver: &fakeDriver{name: "primary"},
+		config: &config.Config{Server: config.ServerConfig{Environment: "development"}},
+	}
+	repo := &widgetRepository{db: db, uow: NewNeo4jUnitOfWork(db)}
+
+	// Act
+	err := repo.Rename(dbctx.WithTxMode(context.Background(), dbctx.TxModeRead))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "label=widget.Rename")
+}
+
+// ptrTo returns a pointer to v, for optional fields in test tables.
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,39 @@
+package shared
+
+import (
+	"context"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// IUnitOfWork runs writes that span several repositories in one transaction.
+// Repositories take part through their ...Tx methods, which run in the
+// transaction they are given instead of opening their own.
+type IUnitOfWork interface {
+	// Write runs work in a single write transaction, committed only if work
+	// returns nil; any error rolls back every change work made. work may be
+	// retried on transient failures, so it must not have side effects
+	// outside tx.
+	Write(ctx context.Context, work func(tx neo4j.ManagedTransaction) error) error
+}
+
+// Neo4jUnitOfWork implements IUnitOfWork with a managed write transaction.
+type Neo4jUnitOfWork struct {
+	db IDatabase
+}
+
+// NewNeo4jUnitOfWork creates a new Neo4jUnitOfWork.
+func NewNeo4jUnitOfWork(db IDatabase) *Neo4jUnitOfWork {
+	return &Neo4jUnitOfWork{db: db}
+}
+
+// Write implements IUnitOfWork.
+func (u *Neo4jUnitOfWork) Write(ctx context.Context, work func(tx neo4j.ManagedTransaction) error) error {
+	_, err := u.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return nil, work(tx)
+	})
+	return err
+}
+
+// Ensure Neo4jUnitOfWork implements IUnitOfWork
+var _ IUnitOfWork = (*Neo4jUnitOfWork)(nil)
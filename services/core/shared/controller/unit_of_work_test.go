@@ -0,0 +1,72 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeOnlyDatabase runs write work with a fixed transaction and counts calls.
+type writeOnlyDatabase struct {
+	IDatabase
+	tx     neo4j.ManagedTransaction
+	writes int
+}
+
+func (d *writeOnlyDatabase) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	d.writes++
+	return work(d.tx)
+}
+
+func TestNeo4jUnitOfWork_RunsWorkInOneWriteTransaction(t *testing.T) {
+	// Arrange
+	db := &writeOnlyDatabase{tx: &MockTx{}}
+	uow := NewNeo4jUnitOfWork(db)
+	failure := errors.New("write failed")
+	var seen neo4j.ManagedTransaction
+
+	// Act
+	err := uow.Write(context.Background(), func(tx neo4j.ManagedTransaction) error {
+		seen = tx
+		return failure
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 1, db.writes)
+	assert.Same(t, db.tx, seen)
+}
+
+func TestMockUnitOfWork_UndoesChangesOnFailure(t *testing.T) {
+	// Arrange
+	uow := NewMockUnitOfWork()
+	var log []string
+	change := func(name string) func(tx neo4j.ManagedTransaction) {
+		return func(tx neo4j.ManagedTransaction) {
+			log = append(log, "apply "+name)
+			TrackMockChange(tx, func() { log = append(log, "undo "+name) }, func() { log = append(log, "commit "+name) })
+		}
+	}
+
+	// Act
+	committedErr := uow.Write(context.Background(), func(tx neo4j.ManagedTransaction) error {
+		change("a")(tx)
+		return nil
+	})
+	failedErr := uow.Write(context.Background(), func(tx neo4j.ManagedTransaction) error {
+		change("b")(tx)
+		change("c")(tx)
+		return errors.New("failed")
+	})
+
+	// Assert
+	require.NoError(t, committedErr)
+	assert.Error(t, failedErr)
+	assert.Equal(t, []string{"apply a", "commit a", "apply b", "apply c", "undo c", "undo b"}, log)
+	assert.Equal(t, 1, uow.Commits())
+	assert.Equal(t, 1, uow.Rollbacks())
+}
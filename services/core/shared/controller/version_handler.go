@@ -0,0 +1,53 @@
+package shared
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/grgn-stack/pkg/buildinfo"
+	"github.com/yourusername/grgn-stack/pkg/config"
+)
+
+// VersionHandler reports which build of the application is running.
+// Unlike /ping it never touches the database.
+type VersionHandler struct {
+	config *config.Config
+}
+
+// VersionResponse represents the response from the version endpoint.
+type VersionResponse struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Commit      string `json:"commit"`
+	BuildTime   string `json:"buildTime"`
+	GoVersion   string `json:"goVersion"`
+	Environment string `json:"environment"`
+}
+
+// NewVersionHandler creates a new VersionHandler.
+func NewVersionHandler(cfg *config.Config) *VersionHandler {
+	return &VersionHandler{config: cfg}
+}
+
+// HandleVersion returns build information as JSON.
+func (h *VersionHandler) HandleVersion(c *gin.Context) {
+	c.JSON(http.StatusOK, h.Info())
+}
+
+// Info returns the build information for the running binary.
+// The version injected at build time takes precedence over configuration.
+func (h *VersionHandler) Info() VersionResponse {
+	version := buildinfo.Version
+	if version == "" {
+		version = h.config.App.Version
+	}
+
+	return VersionResponse{
+		Name:        h.config.App.Name,
+		Version:     version,
+		Commit:      buildinfo.Commit,
+		BuildTime:   buildinfo.BuildTime,
+		GoVersion:   buildinfo.GoVersion(),
+		Environment: h.config.Server.Environment,
+	}
+}
@@ -0,0 +1,57 @@
+package shared
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/buildinfo"
+)
+
+func TestVersionHandler_HandleVersion_Defaults(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	cfg := newTestConfig()
+	cfg.App.Name = "GRGN Stack"
+	handler := NewVersionHandler(cfg)
+
+	r := gin.New()
+	r.GET("/version", handler.HandleVersion)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/version", nil)
+	r.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp VersionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "GRGN Stack", resp.Name)
+	assert.Equal(t, "1.0.0-test", resp.Version)
+	assert.Equal(t, buildinfo.Unknown, resp.Commit)
+	assert.Equal(t, buildinfo.Unknown, resp.BuildTime)
+	assert.Equal(t, runtime.Version(), resp.GoVersion)
+	assert.Equal(t, "test", resp.Environment)
+}
+
+func TestVersionHandler_Info_PrefersInjectedVersion(t *testing.T) {
+	// Arrange
+	original := buildinfo.Version
+	buildinfo.Version = "2.3.4"
+	t.Cleanup(func() { buildinfo.Version = original })
+
+	handler := NewVersionHandler(newTestConfig())
+
+	// Act
+	info := handler.Info()
+
+	// Assert
+	assert.Equal(t, "2.3.4", info.Version)
+}
@@ -39,45 +39,113 @@ type Config struct {
 }
 
 type ResolverRoot interface {
+	Membership() MembershipResolver
 	Mutation() MutationResolver
 	Query() QueryResolver
 	Subscription() SubscriptionResolver
+	Tenant() TenantResolver
 }
 
 type DirectiveRoot struct {
+	Trace func(ctx context.Context, obj any, next graphql.Resolver, label *string) (res any, err error)
 }
 
 type ComplexityRoot struct {
+	DeleteTenantResult struct {
+		MemberCount func(childComplexity int) int
+		Success     func(childComplexity int) int
+		TenantID    func(childComplexity int) int
+	}
+
+	DeletionInfo struct {
+		DeletedAt func(childComplexity int) int
+		DeletedBy func(childComplexity int) int
+		Reason    func(childComplexity int) int
+	}
+
+	LeaveEligibility struct {
+		Allowed func(childComplexity int) int
+		Reason  func(childComplexity int) int
+	}
+
+	MemberRemovalResult struct {
+		InvitesHandedOff     func(childComplexity int) int
+		MembershipID         func(childComplexity int) int
+		RemainingMemberCount func(childComplexity int) int
+		RemainingOwnerCount  func(childComplexity int) int
+		Success              func(childComplexity int) int
+		TenantID             func(childComplexity int) int
+		UserID               func(childComplexity int) int
+	}
+
 	Membership struct {
 		ID        func(childComplexity int) int
 		InvitedBy func(childComplexity int) int
 		JoinedAt  func(childComplexity int) int
 		Role      func(childComplexity int) int
+		Status    func(childComplexity int) int
 		Tenant    func(childComplexity int) int
 		User      func(childComplexity int) int
 	}
 
 	Mutation struct {
-		CreateTenant     func(childComplexity int, input model.CreateTenantInput) int
-		DeleteAccount    func(childComplexity int) int
-		DeleteTenant     func(childComplexity int, id string) int
-		Empty            func(childComplexity int) int
-		InviteMember     func(childComplexity int, tenantID string, input model.InviteMemberInput) int
-		LeaveTenant      func(childComplexity int, tenantID string) int
-		RemoveMember     func(childComplexity int, membershipID string) int
-		UpdateMemberRole func(childComplexity int, membershipID string, role model.MembershipRole) int
-		UpdateProfile    func(childComplexity int, input model.UpdateProfileInput) int
-		UpdateTenant     func(childComplexity int, id string, input model.UpdateTenantInput) int
+		AcceptInviteByToken    func(childComplexity int, token string) int
+		CreateInviteToken      func(childComplexity int, membershipID string) int
+		CreateTenant           func(childComplexity int, input model.CreateTenantInput) int
+		DeleteAccount          func(childComplexity int, reason *string) int
+		DeleteTenant           func(childComplexity int, id string, reason *string) int
+		Empty                  func(childComplexity int) int
+		InviteByEmail          func(childComplexity int, tenantID string, input model.InviteMemberInput) int
+		InviteMember           func(childComplexity int, tenantID string, input model.InviteMemberInput) int
+		LeaveTenant            func(childComplexity int, tenantID string) int
+		OffboardUser           func(childComplexity int, userID string, ownershipSuccessors []*model.OwnershipSuccessor) int
+		ReassignInvites        func(childComplexity int, tenantID string, fromUserID string, toUserID string) int
+		RemoveMember           func(childComplexity int, membershipID string) int
+		RenameTenant           func(childComplexity int, id string, name string, slug string) int
+		RestoreAccount         func(childComplexity int, userID string) int
+		RestoreTenant          func(childComplexity int, id string) int
+		RevokeUserTokens       func(childComplexity int, userID string) int
+		SetTenantBillingEmail  func(childComplexity int, tenantID string, email string) int
+		SuspendTenant          func(childComplexity int, id string) int
+		UnsuspendTenant        func(childComplexity int, id string) int
+		UpdateMemberRole       func(childComplexity int, membershipID string, role model.MembershipRole) int
+		UpdateMemberRoleByUser func(childComplexity int, tenantID string, userID string, role model.MembershipRole) int
+		UpdateProfile          func(childComplexity int, input model.UpdateProfileInput) int
+		UpdateTenant           func(childComplexity int, id string, input model.UpdateTenantInput) int
+		UpdateTenantPlans      func(childComplexity int, changes []*model.PlanChange) int
+	}
+
+	OffboardUserResult struct {
+		InvitesHandedOff       func(childComplexity int) int
+		RemovedMembershipCount func(childComplexity int) int
+		TransferredOwnerships  func(childComplexity int) int
+		UserID                 func(childComplexity int) int
+	}
+
+	PlanChangeResult struct {
+		Applied         func(childComplexity int) int
+		Error           func(childComplexity int) int
+		MemberCount     func(childComplexity int) int
+		OverMemberLimit func(childComplexity int) int
+		Plan            func(childComplexity int) int
+		TenantID        func(childComplexity int) int
 	}
 
 	Query struct {
-		Health        func(childComplexity int) int
-		Me            func(childComplexity int) int
-		MyTenants     func(childComplexity int) int
-		Tenant        func(childComplexity int, id string) int
-		TenantBySlug  func(childComplexity int, slug string) int
-		TenantMembers func(childComplexity int, tenantID string) int
-		User          func(childComplexity int, id string) int
+		Health             func(childComplexity int) int
+		Me                 func(childComplexity int) int
+		Membership         func(childComplexity int, id string) int
+		MyInvitations      func(childComplexity int, limit *int, offset *int) int
+		MyTenantSummary    func(childComplexity int) int
+		MyTenants          func(childComplexity int, limit *int, offset *int) int
+		PendingInvitations func(childComplexity int, tenantID string) int
+		SuggestTenantSlug  func(childComplexity int, name string) int
+		Tenant             func(childComplexity int, id string) int
+		TenantBySlug       func(childComplexity int, slug string) int
+		TenantDeletion     func(childComplexity int, tenantID string) int
+		TenantMembers      func(childComplexity int, tenantID string) int
+		User               func(childComplexity int, id string) int
+		UserDeletion       func(childComplexity int, userID string) int
 	}
 
 	Subscription struct {
@@ -85,18 +153,27 @@ type ComplexityRoot struct {
 	}
 
 	Tenant struct {
+		BillingEmail  func(childComplexity int) int
+		CanLeave      func(childComplexity int) int
 		CreatedAt     func(childComplexity int) int
 		ID            func(childComplexity int) int
 		IsolationMode func(childComplexity int) int
 		MemberCount   func(childComplexity int) int
 		Members       func(childComplexity int) int
+		MyRole        func(childComplexity int) int
 		Name          func(childComplexity int) int
+		Owners        func(childComplexity int) int
 		Plan          func(childComplexity int) int
 		Slug          func(childComplexity int) int
 		Status        func(childComplexity int) int
 		UpdatedAt     func(childComplexity int) int
 	}
 
+	TenantRoleCount struct {
+		Count func(childComplexity int) int
+		Role  func(childComplexity int) int
+	}
+
 	User struct {
 		AvatarURL func(childComplexity int) int
 		CreatedAt func(childComplexity int) int
@@ -108,30 +185,63 @@ type ComplexityRoot struct {
 	}
 }
 
+type MembershipResolver interface {
+	User(ctx context.Context, obj *model.Membership) (*model.User, error)
+
+	InvitedBy(ctx context.Context, obj *model.Membership) (*model.User, error)
+}
 type MutationResolver interface {
 	Empty(ctx context.Context) (*string, error)
 	UpdateProfile(ctx context.Context, input model.UpdateProfileInput) (*model.User, error)
-	DeleteAccount(ctx context.Context) (bool, error)
+	DeleteAccount(ctx context.Context, reason *string) (bool, error)
+	RestoreAccount(ctx context.Context, userID string) (*model.User, error)
+	RevokeUserTokens(ctx context.Context, userID string) (bool, error)
 	CreateTenant(ctx context.Context, input model.CreateTenantInput) (*model.Tenant, error)
 	UpdateTenant(ctx context.Context, id string, input model.UpdateTenantInput) (*model.Tenant, error)
-	DeleteTenant(ctx context.Context, id string) (bool, error)
+	RenameTenant(ctx context.Context, id string, name string, slug string) (*model.Tenant, error)
+	SetTenantBillingEmail(ctx context.Context, tenantID string, email string) (*model.Tenant, error)
+	DeleteTenant(ctx context.Context, id string, reason *string) (*model.DeleteTenantResult, error)
+	RestoreTenant(ctx context.Context, id string) (*model.Tenant, error)
+	SuspendTenant(ctx context.Context, id string) (*model.Tenant, error)
+	UnsuspendTenant(ctx context.Context, id string) (*model.Tenant, error)
 	InviteMember(ctx context.Context, tenantID string, input model.InviteMemberInput) (*model.Membership, error)
+	InviteByEmail(ctx context.Context, tenantID string, input model.InviteMemberInput) (*model.Membership, error)
 	UpdateMemberRole(ctx context.Context, membershipID string, role model.MembershipRole) (*model.Membership, error)
-	RemoveMember(ctx context.Context, membershipID string) (bool, error)
-	LeaveTenant(ctx context.Context, tenantID string) (bool, error)
+	UpdateMemberRoleByUser(ctx context.Context, tenantID string, userID string, role model.MembershipRole) (*model.Membership, error)
+	RemoveMember(ctx context.Context, membershipID string) (*model.MemberRemovalResult, error)
+	LeaveTenant(ctx context.Context, tenantID string) (*model.MemberRemovalResult, error)
+	ReassignInvites(ctx context.Context, tenantID string, fromUserID string, toUserID string) (int, error)
+	CreateInviteToken(ctx context.Context, membershipID string) (string, error)
+	AcceptInviteByToken(ctx context.Context, token string) (*model.Membership, error)
+	UpdateTenantPlans(ctx context.Context, changes []*model.PlanChange) ([]*model.PlanChangeResult, error)
+	OffboardUser(ctx context.Context, userID string, ownershipSuccessors []*model.OwnershipSuccessor) (*model.OffboardUserResult, error)
 }
 type QueryResolver interface {
 	Health(ctx context.Context) (string, error)
 	Me(ctx context.Context) (*model.User, error)
 	User(ctx context.Context, id string) (*model.User, error)
+	UserDeletion(ctx context.Context, userID string) (*model.DeletionInfo, error)
 	Tenant(ctx context.Context, id string) (*model.Tenant, error)
 	TenantBySlug(ctx context.Context, slug string) (*model.Tenant, error)
-	MyTenants(ctx context.Context) ([]*model.Tenant, error)
+	SuggestTenantSlug(ctx context.Context, name string) (string, error)
+	MyTenants(ctx context.Context, limit *int, offset *int) ([]*model.Tenant, error)
+	MyInvitations(ctx context.Context, limit *int, offset *int) ([]*model.Membership, error)
+	MyTenantSummary(ctx context.Context) ([]*model.TenantRoleCount, error)
 	TenantMembers(ctx context.Context, tenantID string) ([]*model.Membership, error)
+	PendingInvitations(ctx context.Context, tenantID string) ([]*model.Membership, error)
+	Membership(ctx context.Context, id string) (*model.Membership, error)
+	TenantDeletion(ctx context.Context, tenantID string) (*model.DeletionInfo, error)
 }
 type SubscriptionResolver interface {
 	Empty(ctx context.Context) (<-chan *string, error)
 }
+type TenantResolver interface {
+	Owners(ctx context.Context, obj *model.Tenant) ([]*model.Membership, error)
+
+	BillingEmail(ctx context.Context, obj *model.Tenant) (*string, error)
+
+	CanLeave(ctx context.Context, obj *model.Tenant) (*model.LeaveEligibility, error)
+}
 
 type executableSchema struct {
 	schema     *ast.Schema
@@ -152,6 +262,100 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 	_ = ec
 	switch typeName + "." + field {
 
+	case "DeleteTenantResult.memberCount":
+		if e.complexity.DeleteTenantResult.MemberCount == nil {
+			break
+		}
+
+		return e.complexity.DeleteTenantResult.MemberCount(childComplexity), true
+	case "DeleteTenantResult.success":
+		if e.complexity.DeleteTenantResult.Success == nil {
+			break
+		}
+
+		return e.complexity.DeleteTenantResult.Success(childComplexity), true
+	case "DeleteTenantResult.tenantId":
+		if e.complexity.DeleteTenantResult.TenantID == nil {
+			break
+		}
+
+		return e.complexity.DeleteTenantResult.TenantID(childComplexity), true
+
+	case "DeletionInfo.deletedAt":
+		if e.complexity.DeletionInfo.DeletedAt == nil {
+			break
+		}
+
+		return e.complexity.DeletionInfo.DeletedAt(childComplexity), true
+	case "DeletionInfo.deletedBy":
+		if e.complexity.DeletionInfo.DeletedBy == nil {
+			break
+		}
+
+		return e.complexity.DeletionInfo.DeletedBy(childComplexity), true
+	case "DeletionInfo.reason":
+		if e.complexity.DeletionInfo.Reason == nil {
+			break
+		}
+
+		return e.complexity.DeletionInfo.Reason(childComplexity), true
+
+	case "LeaveEligibility.allowed":
+		if e.complexity.LeaveEligibility.Allowed == nil {
+			break
+		}
+
+		return e.complexity.LeaveEligibility.Allowed(childComplexity), true
+	case "LeaveEligibility.reason":
+		if e.complexity.LeaveEligibility.Reason == nil {
+			break
+		}
+
+		return e.complexity.LeaveEligibility.Reason(childComplexity), true
+
+	case "MemberRemovalResult.invitesHandedOff":
+		if e.complexity.MemberRemovalResult.InvitesHandedOff == nil {
+			break
+		}
+
+		return e.complexity.MemberRemovalResult.InvitesHandedOff(childComplexity), true
+	case "MemberRemovalResult.membershipId":
+		if e.complexity.MemberRemovalResult.MembershipID == nil {
+			break
+		}
+
+		return e.complexity.MemberRemovalResult.MembershipID(childComplexity), true
+	case "MemberRemovalResult.remainingMemberCount":
+		if e.complexity.MemberRemovalResult.RemainingMemberCount == nil {
+			break
+		}
+
+		return e.complexity.MemberRemovalResult.RemainingMemberCount(childComplexity), true
+	case "MemberRemovalResult.remainingOwnerCount":
+		if e.complexity.MemberRemovalResult.RemainingOwnerCount == nil {
+			break
+		}
+
+		return e.complexity.MemberRemovalResult.RemainingOwnerCount(childComplexity), true
+	case "MemberRemovalResult.success":
+		if e.complexity.MemberRemovalResult.Success == nil {
+			break
+		}
+
+		return e.complexity.MemberRemovalResult.Success(childComplexity), true
+	case "MemberRemovalResult.tenantId":
+		if e.complexity.MemberRemovalResult.TenantID == nil {
+			break
+		}
+
+		return e.complexity.MemberRemovalResult.TenantID(childComplexity), true
+	case "MemberRemovalResult.userId":
+		if e.complexity.MemberRemovalResult.UserID == nil {
+			break
+		}
+
+		return e.complexity.MemberRemovalResult.UserID(childComplexity), true
+
 	case "Membership.id":
 		if e.complexity.Membership.ID == nil {
 			break
@@ -176,6 +380,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Membership.Role(childComplexity), true
+	case "Membership.status":
+		if e.complexity.Membership.Status == nil {
+			break
+		}
+
+		return e.complexity.Membership.Status(childComplexity), true
 	case "Membership.tenant":
 		if e.complexity.Membership.Tenant == nil {
 			break
@@ -189,6 +399,28 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Membership.User(childComplexity), true
 
+	case "Mutation.acceptInviteByToken":
+		if e.complexity.Mutation.AcceptInviteByToken == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_acceptInviteByToken_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.AcceptInviteByToken(childComplexity, args["token"].(string)), true
+	case "Mutation.createInviteToken":
+		if e.complexity.Mutation.CreateInviteToken == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_createInviteToken_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.CreateInviteToken(childComplexity, args["membershipId"].(string)), true
 	case "Mutation.createTenant":
 		if e.complexity.Mutation.CreateTenant == nil {
 			break
@@ -205,7 +437,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			break
 		}
 
-		return e.complexity.Mutation.DeleteAccount(childComplexity), true
+		args, err := ec.field_Mutation_deleteAccount_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DeleteAccount(childComplexity, args["reason"].(*string)), true
 	case "Mutation.deleteTenant":
 		if e.complexity.Mutation.DeleteTenant == nil {
 			break
@@ -216,13 +453,24 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Mutation.DeleteTenant(childComplexity, args["id"].(string)), true
+		return e.complexity.Mutation.DeleteTenant(childComplexity, args["id"].(string), args["reason"].(*string)), true
 	case "Mutation._empty":
 		if e.complexity.Mutation.Empty == nil {
 			break
 		}
 
 		return e.complexity.Mutation.Empty(childComplexity), true
+	case "Mutation.inviteByEmail":
+		if e.complexity.Mutation.InviteByEmail == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_inviteByEmail_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.InviteByEmail(childComplexity, args["tenantId"].(string), args["input"].(model.InviteMemberInput)), true
 	case "Mutation.inviteMember":
 		if e.complexity.Mutation.InviteMember == nil {
 			break
@@ -245,6 +493,28 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.LeaveTenant(childComplexity, args["tenantId"].(string)), true
+	case "Mutation.offboardUser":
+		if e.complexity.Mutation.OffboardUser == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_offboardUser_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.OffboardUser(childComplexity, args["userId"].(string), args["ownershipSuccessors"].([]*model.OwnershipSuccessor)), true
+	case "Mutation.reassignInvites":
+		if e.complexity.Mutation.ReassignInvites == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_reassignInvites_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ReassignInvites(childComplexity, args["tenantId"].(string), args["fromUserId"].(string), args["toUserId"].(string)), true
 	case "Mutation.removeMember":
 		if e.complexity.Mutation.RemoveMember == nil {
 			break
@@ -256,217 +526,492 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.RemoveMember(childComplexity, args["membershipId"].(string)), true
-	case "Mutation.updateMemberRole":
-		if e.complexity.Mutation.UpdateMemberRole == nil {
+	case "Mutation.renameTenant":
+		if e.complexity.Mutation.RenameTenant == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_updateMemberRole_args(ctx, rawArgs)
+		args, err := ec.field_Mutation_renameTenant_args(ctx, rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateMemberRole(childComplexity, args["membershipId"].(string), args["role"].(model.MembershipRole)), true
-	case "Mutation.updateProfile":
-		if e.complexity.Mutation.UpdateProfile == nil {
+		return e.complexity.Mutation.RenameTenant(childComplexity, args["id"].(string), args["name"].(string), args["slug"].(string)), true
+	case "Mutation.restoreAccount":
+		if e.complexity.Mutation.RestoreAccount == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_updateProfile_args(ctx, rawArgs)
+		args, err := ec.field_Mutation_restoreAccount_args(ctx, rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateProfile(childComplexity, args["input"].(model.UpdateProfileInput)), true
-	case "Mutation.updateTenant":
-		if e.complexity.Mutation.UpdateTenant == nil {
+		return e.complexity.Mutation.RestoreAccount(childComplexity, args["userId"].(string)), true
+	case "Mutation.restoreTenant":
+		if e.complexity.Mutation.RestoreTenant == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_updateTenant_args(ctx, rawArgs)
+		args, err := ec.field_Mutation_restoreTenant_args(ctx, rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateTenant(childComplexity, args["id"].(string), args["input"].(model.UpdateTenantInput)), true
-
-	case "Query.health":
-		if e.complexity.Query.Health == nil {
+		return e.complexity.Mutation.RestoreTenant(childComplexity, args["id"].(string)), true
+	case "Mutation.revokeUserTokens":
+		if e.complexity.Mutation.RevokeUserTokens == nil {
 			break
 		}
 
-		return e.complexity.Query.Health(childComplexity), true
-	case "Query.me":
-		if e.complexity.Query.Me == nil {
+		args, err := ec.field_Mutation_revokeUserTokens_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RevokeUserTokens(childComplexity, args["userId"].(string)), true
+	case "Mutation.setTenantBillingEmail":
+		if e.complexity.Mutation.SetTenantBillingEmail == nil {
 			break
 		}
 
-		return e.complexity.Query.Me(childComplexity), true
-	case "Query.myTenants":
-		if e.complexity.Query.MyTenants == nil {
+		args, err := ec.field_Mutation_setTenantBillingEmail_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.SetTenantBillingEmail(childComplexity, args["tenantId"].(string), args["email"].(string)), true
+	case "Mutation.suspendTenant":
+		if e.complexity.Mutation.SuspendTenant == nil {
 			break
 		}
 
-		return e.complexity.Query.MyTenants(childComplexity), true
-	case "Query.tenant":
-		if e.complexity.Query.Tenant == nil {
+		args, err := ec.field_Mutation_suspendTenant_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.SuspendTenant(childComplexity, args["id"].(string)), true
+	case "Mutation.unsuspendTenant":
+		if e.complexity.Mutation.UnsuspendTenant == nil {
 			break
 		}
 
-		args, err := ec.field_Query_tenant_args(ctx, rawArgs)
+		args, err := ec.field_Mutation_unsuspendTenant_args(ctx, rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.Tenant(childComplexity, args["id"].(string)), true
-	case "Query.tenantBySlug":
-		if e.complexity.Query.TenantBySlug == nil {
+		return e.complexity.Mutation.UnsuspendTenant(childComplexity, args["id"].(string)), true
+	case "Mutation.updateMemberRole":
+		if e.complexity.Mutation.UpdateMemberRole == nil {
 			break
 		}
 
-		args, err := ec.field_Query_tenantBySlug_args(ctx, rawArgs)
+		args, err := ec.field_Mutation_updateMemberRole_args(ctx, rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.TenantBySlug(childComplexity, args["slug"].(string)), true
-	case "Query.tenantMembers":
-		if e.complexity.Query.TenantMembers == nil {
+		return e.complexity.Mutation.UpdateMemberRole(childComplexity, args["membershipId"].(string), args["role"].(model.MembershipRole)), true
+	case "Mutation.updateMemberRoleByUser":
+		if e.complexity.Mutation.UpdateMemberRoleByUser == nil {
 			break
 		}
 
-		args, err := ec.field_Query_tenantMembers_args(ctx, rawArgs)
+		args, err := ec.field_Mutation_updateMemberRoleByUser_args(ctx, rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.TenantMembers(childComplexity, args["tenantId"].(string)), true
-	case "Query.user":
-		if e.complexity.Query.User == nil {
+		return e.complexity.Mutation.UpdateMemberRoleByUser(childComplexity, args["tenantId"].(string), args["userId"].(string), args["role"].(model.MembershipRole)), true
+	case "Mutation.updateProfile":
+		if e.complexity.Mutation.UpdateProfile == nil {
 			break
 		}
 
-		args, err := ec.field_Query_user_args(ctx, rawArgs)
+		args, err := ec.field_Mutation_updateProfile_args(ctx, rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.User(childComplexity, args["id"].(string)), true
+		return e.complexity.Mutation.UpdateProfile(childComplexity, args["input"].(model.UpdateProfileInput)), true
+	case "Mutation.updateTenant":
+		if e.complexity.Mutation.UpdateTenant == nil {
+			break
+		}
 
-	case "Subscription._empty":
-		if e.complexity.Subscription.Empty == nil {
+		args, err := ec.field_Mutation_updateTenant_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdateTenant(childComplexity, args["id"].(string), args["input"].(model.UpdateTenantInput)), true
+	case "Mutation.updateTenantPlans":
+		if e.complexity.Mutation.UpdateTenantPlans == nil {
 			break
 		}
 
-		return e.complexity.Subscription.Empty(childComplexity), true
+		args, err := ec.field_Mutation_updateTenantPlans_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-	case "Tenant.createdAt":
-		if e.complexity.Tenant.CreatedAt == nil {
+		return e.complexity.Mutation.UpdateTenantPlans(childComplexity, args["changes"].([]*model.PlanChange)), true
+
+	case "OffboardUserResult.invitesHandedOff":
+		if e.complexity.OffboardUserResult.InvitesHandedOff == nil {
 			break
 		}
 
-		return e.complexity.Tenant.CreatedAt(childComplexity), true
-	case "Tenant.id":
-		if e.complexity.Tenant.ID == nil {
+		return e.complexity.OffboardUserResult.InvitesHandedOff(childComplexity), true
+	case "OffboardUserResult.removedMembershipCount":
+		if e.complexity.OffboardUserResult.RemovedMembershipCount == nil {
 			break
 		}
 
-		return e.complexity.Tenant.ID(childComplexity), true
-	case "Tenant.isolationMode":
-		if e.complexity.Tenant.IsolationMode == nil {
+		return e.complexity.OffboardUserResult.RemovedMembershipCount(childComplexity), true
+	case "OffboardUserResult.transferredOwnerships":
+		if e.complexity.OffboardUserResult.TransferredOwnerships == nil {
 			break
 		}
 
-		return e.complexity.Tenant.IsolationMode(childComplexity), true
-	case "Tenant.memberCount":
-		if e.complexity.Tenant.MemberCount == nil {
+		return e.complexity.OffboardUserResult.TransferredOwnerships(childComplexity), true
+	case "OffboardUserResult.userId":
+		if e.complexity.OffboardUserResult.UserID == nil {
 			break
 		}
 
-		return e.complexity.Tenant.MemberCount(childComplexity), true
-	case "Tenant.members":
-		if e.complexity.Tenant.Members == nil {
+		return e.complexity.OffboardUserResult.UserID(childComplexity), true
+
+	case "PlanChangeResult.applied":
+		if e.complexity.PlanChangeResult.Applied == nil {
 			break
 		}
 
-		return e.complexity.Tenant.Members(childComplexity), true
-	case "Tenant.name":
-		if e.complexity.Tenant.Name == nil {
+		return e.complexity.PlanChangeResult.Applied(childComplexity), true
+	case "PlanChangeResult.error":
+		if e.complexity.PlanChangeResult.Error == nil {
 			break
 		}
 
-		return e.complexity.Tenant.Name(childComplexity), true
-	case "Tenant.plan":
-		if e.complexity.Tenant.Plan == nil {
+		return e.complexity.PlanChangeResult.Error(childComplexity), true
+	case "PlanChangeResult.memberCount":
+		if e.complexity.PlanChangeResult.MemberCount == nil {
 			break
 		}
 
-		return e.complexity.Tenant.Plan(childComplexity), true
-	case "Tenant.slug":
-		if e.complexity.Tenant.Slug == nil {
+		return e.complexity.PlanChangeResult.MemberCount(childComplexity), true
+	case "PlanChangeResult.overMemberLimit":
+		if e.complexity.PlanChangeResult.OverMemberLimit == nil {
 			break
 		}
 
-		return e.complexity.Tenant.Slug(childComplexity), true
-	case "Tenant.status":
-		if e.complexity.Tenant.Status == nil {
+		return e.complexity.PlanChangeResult.OverMemberLimit(childComplexity), true
+	case "PlanChangeResult.plan":
+		if e.complexity.PlanChangeResult.Plan == nil {
 			break
 		}
 
-		return e.complexity.Tenant.Status(childComplexity), true
-	case "Tenant.updatedAt":
-		if e.complexity.Tenant.UpdatedAt == nil {
+		return e.complexity.PlanChangeResult.Plan(childComplexity), true
+	case "PlanChangeResult.tenantId":
+		if e.complexity.PlanChangeResult.TenantID == nil {
 			break
 		}
 
-		return e.complexity.Tenant.UpdatedAt(childComplexity), true
+		return e.complexity.PlanChangeResult.TenantID(childComplexity), true
 
-	case "User.avatarUrl":
-		if e.complexity.User.AvatarURL == nil {
+	case "Query.health":
+		if e.complexity.Query.Health == nil {
 			break
 		}
 
-		return e.complexity.User.AvatarURL(childComplexity), true
-	case "User.createdAt":
-		if e.complexity.User.CreatedAt == nil {
+		return e.complexity.Query.Health(childComplexity), true
+	case "Query.me":
+		if e.complexity.Query.Me == nil {
 			break
 		}
 
-		return e.complexity.User.CreatedAt(childComplexity), true
-	case "User.email":
-		if e.complexity.User.Email == nil {
+		return e.complexity.Query.Me(childComplexity), true
+	case "Query.membership":
+		if e.complexity.Query.Membership == nil {
 			break
 		}
 
-		return e.complexity.User.Email(childComplexity), true
-	case "User.id":
-		if e.complexity.User.ID == nil {
-			break
+		args, err := ec.field_Query_membership_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-		return e.complexity.User.ID(childComplexity), true
-	case "User.name":
-		if e.complexity.User.Name == nil {
+		return e.complexity.Query.Membership(childComplexity, args["id"].(string)), true
+	case "Query.myInvitations":
+		if e.complexity.Query.MyInvitations == nil {
 			break
 		}
 
-		return e.complexity.User.Name(childComplexity), true
-	case "User.status":
-		if e.complexity.User.Status == nil {
+		args, err := ec.field_Query_myInvitations_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.MyInvitations(childComplexity, args["limit"].(*int), args["offset"].(*int)), true
+	case "Query.myTenantSummary":
+		if e.complexity.Query.MyTenantSummary == nil {
 			break
 		}
 
-		return e.complexity.User.Status(childComplexity), true
-	case "User.updatedAt":
-		if e.complexity.User.UpdatedAt == nil {
+		return e.complexity.Query.MyTenantSummary(childComplexity), true
+	case "Query.myTenants":
+		if e.complexity.Query.MyTenants == nil {
 			break
 		}
 
-		return e.complexity.User.UpdatedAt(childComplexity), true
+		args, err := ec.field_Query_myTenants_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-	}
-	return 0, false
-}
+		return e.complexity.Query.MyTenants(childComplexity, args["limit"].(*int), args["offset"].(*int)), true
+	case "Query.pendingInvitations":
+		if e.complexity.Query.PendingInvitations == nil {
+			break
+		}
+
+		args, err := ec.field_Query_pendingInvitations_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.PendingInvitations(childComplexity, args["tenantId"].(string)), true
+	case "Query.suggestTenantSlug":
+		if e.complexity.Query.SuggestTenantSlug == nil {
+			break
+		}
+
+		args, err := ec.field_Query_suggestTenantSlug_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.SuggestTenantSlug(childComplexity, args["name"].(string)), true
+	case "Query.tenant":
+		if e.complexity.Query.Tenant == nil {
+			break
+		}
+
+		args, err := ec.field_Query_tenant_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Tenant(childComplexity, args["id"].(string)), true
+	case "Query.tenantBySlug":
+		if e.complexity.Query.TenantBySlug == nil {
+			break
+		}
+
+		args, err := ec.field_Query_tenantBySlug_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.TenantBySlug(childComplexity, args["slug"].(string)), true
+	case "Query.tenantDeletion":
+		if e.complexity.Query.TenantDeletion == nil {
+			break
+		}
+
+		args, err := ec.field_Query_tenantDeletion_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.TenantDeletion(childComplexity, args["tenantId"].(string)), true
+	case "Query.tenantMembers":
+		if e.complexity.Query.TenantMembers == nil {
+			break
+		}
+
+		args, err := ec.field_Query_tenantMembers_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.TenantMembers(childComplexity, args["tenantId"].(string)), true
+	case "Query.user":
+		if e.complexity.Query.User == nil {
+			break
+		}
+
+		args, err := ec.field_Query_user_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.User(childComplexity, args["id"].(string)), true
+	case "Query.userDeletion":
+		if e.complexity.Query.UserDeletion == nil {
+			break
+		}
+
+		args, err := ec.field_Query_userDeletion_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.UserDeletion(childComplexity, args["userId"].(string)), true
+
+	case "Subscription._empty":
+		if e.complexity.Subscription.Empty == nil {
+			break
+		}
+
+		return e.complexity.Subscription.Empty(childComplexity), true
+
+	case "Tenant.billingEmail":
+		if e.complexity.Tenant.BillingEmail == nil {
+			break
+		}
+
+		return e.complexity.Tenant.BillingEmail(childComplexity), true
+	case "Tenant.canLeave":
+		if e.complexity.Tenant.CanLeave == nil {
+			break
+		}
+
+		return e.complexity.Tenant.CanLeave(childComplexity), true
+	case "Tenant.createdAt":
+		if e.complexity.Tenant.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.Tenant.CreatedAt(childComplexity), true
+	case "Tenant.id":
+		if e.complexity.Tenant.ID == nil {
+			break
+		}
+
+		return e.complexity.Tenant.ID(childComplexity), true
+	case "Tenant.isolationMode":
+		if e.complexity.Tenant.IsolationMode == nil {
+			break
+		}
+
+		return e.complexity.Tenant.IsolationMode(childComplexity), true
+	case "Tenant.memberCount":
+		if e.complexity.Tenant.MemberCount == nil {
+			break
+		}
+
+		return e.complexity.Tenant.MemberCount(childComplexity), true
+	case "Tenant.members":
+		if e.complexity.Tenant.Members == nil {
+			break
+		}
+
+		return e.complexity.Tenant.Members(childComplexity), true
+	case "Tenant.myRole":
+		if e.complexity.Tenant.MyRole == nil {
+			break
+		}
+
+		return e.complexity.Tenant.MyRole(childComplexity), true
+	case "Tenant.name":
+		if e.complexity.Tenant.Name == nil {
+			break
+		}
+
+		return e.complexity.Tenant.Name(childComplexity), true
+	case "Tenant.owners":
+		if e.complexity.Tenant.Owners == nil {
+			break
+		}
+
+		return e.complexity.Tenant.Owners(childComplexity), true
+	case "Tenant.plan":
+		if e.complexity.Tenant.Plan == nil {
+			break
+		}
+
+		return e.complexity.Tenant.Plan(childComplexity), true
+	case "Tenant.slug":
+		if e.complexity.Tenant.Slug == nil {
+			break
+		}
+
+		return e.complexity.Tenant.Slug(childComplexity), true
+	case "Tenant.status":
+		if e.complexity.Tenant.Status == nil {
+			break
+		}
+
+		return e.complexity.Tenant.Status(childComplexity), true
+	case "Tenant.updatedAt":
+		if e.complexity.Tenant.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.Tenant.UpdatedAt(childComplexity), true
+
+	case "TenantRoleCount.count":
+		if e.complexity.TenantRoleCount.Count == nil {
+			break
+		}
+
+		return e.complexity.TenantRoleCount.Count(childComplexity), true
+	case "TenantRoleCount.role":
+		if e.complexity.TenantRoleCount.Role == nil {
+			break
+		}
+
+		return e.complexity.TenantRoleCount.Role(childComplexity), true
+
+	case "User.avatarUrl":
+		if e.complexity.User.AvatarURL == nil {
+			break
+		}
+
+		return e.complexity.User.AvatarURL(childComplexity), true
+	case "User.createdAt":
+		if e.complexity.User.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.User.CreatedAt(childComplexity), true
+	case "User.email":
+		if e.complexity.User.Email == nil {
+			break
+		}
+
+		return e.complexity.User.Email(childComplexity), true
+	case "User.id":
+		if e.complexity.User.ID == nil {
+			break
+		}
+
+		return e.complexity.User.ID(childComplexity), true
+	case "User.name":
+		if e.complexity.User.Name == nil {
+			break
+		}
+
+		return e.complexity.User.Name(childComplexity), true
+	case "User.status":
+		if e.complexity.User.Status == nil {
+			break
+		}
+
+		return e.complexity.User.Status(childComplexity), true
+	case "User.updatedAt":
+		if e.complexity.User.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.User.UpdatedAt(childComplexity), true
+
+	}
+	return 0, false
+}
 
 func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 	opCtx := graphql.GetOperationContext(ctx)
@@ -474,6 +1019,8 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 	inputUnmarshalMap := graphql.BuildUnmarshalerMap(
 		ec.unmarshalInputCreateTenantInput,
 		ec.unmarshalInputInviteMemberInput,
+		ec.unmarshalInputOwnershipSuccessor,
+		ec.unmarshalInputPlanChange,
 		ec.unmarshalInputUpdateProfileInput,
 		ec.unmarshalInputUpdateTenantInput,
 	)
@@ -599,6 +1146,9 @@ scalar UUID
 
 scalar Time
 
+# Times the resolver and logs its duration (development only; a no-op elsewhere)
+directive @trace(label: String) on FIELD_DEFINITION
+
 # Root Query type - extended by apps
 type Query {
   # Health check
@@ -629,6 +1179,8 @@ enum UserStatus {
 input UpdateProfileInput {
   name: String
   avatarUrl: String
+  # Remove the avatar; cannot be combined with avatarUrl
+  clearAvatar: Boolean
 }
 `, BuiltIn: false},
 	{Name: "../../../identity/model/types.graphql", Input: `# Identity App - Core Types
@@ -643,20 +1195,36 @@ type User {
   updatedAt: DateTime!
 }
 
+# Who soft-deleted a record, when and why (platform admin only)
+type DeletionInfo {
+  deletedAt: DateTime!
+  deletedBy: ID
+  reason: String
+}
+
 extend type Query {
   # Get current authenticated user
   me: User
   
   # Get user by ID
   user(id: ID!): User
+
+  # Deletion details of a deleted user (platform admin only)
+  userDeletion(userId: ID!): DeletionInfo
 }
 
 extend type Mutation {
   # Update current user's profile
   updateProfile(input: UpdateProfileInput!): User!
   
-  # Delete current user's account
-  deleteAccount: Boolean!
+  # Delete current user's account, optionally recording why
+  deleteAccount(reason: String): Boolean!
+
+  # Restore a deleted user's account (platform admin only)
+  restoreAccount(userId: ID!): User!
+
+  # Invalidate every token previously issued to a user (platform admin only)
+  revokeUserTokens(userId: ID!): Boolean!
 }
 `, BuiltIn: false},
 	{Name: "../../../tenant/model/enums.graphql", Input: `# Tenant App - Enums
@@ -684,6 +1252,16 @@ enum MembershipRole {
   MEMBER      # Standard access
   VIEWER      # Read-only access
 }
+
+enum MembershipStatus {
+  ACTIVE      # Member has joined the tenant
+  PENDING     # Invited but not yet accepted
+}
+
+enum LeaveBlockedReason {
+  NOT_MEMBER  # The current user isn't a member of the tenant
+  SOLE_OWNER  # The current user is the tenant's only owner
+}
 `, BuiltIn: false},
 	{Name: "../../../tenant/model/inputs.graphql", Input: `# Tenant App - Input Types
 
@@ -699,6 +1277,18 @@ input UpdateTenantInput {
   status: TenantStatus
 }
 
+# A plan change pushed by the billing system
+input PlanChange {
+  tenantId: ID!
+  plan: TenantPlan!
+}
+
+# The member who takes over a tenant from an offboarded sole owner
+input OwnershipSuccessor {
+  tenantId: ID!
+  membershipId: ID!
+}
+
 input InviteMemberInput {
   email: String!
   role: MembershipRole = MEMBER
@@ -714,20 +1304,85 @@ type Tenant {
   isolationMode: TenantIsolationMode!
   status: TenantStatus!
   members: [Membership!]!
+  # Owner memberships only, earliest first
+  owners: [Membership!]! @trace
   memberCount: Int!
+  # Billing/contact email (visible to tenant admins only, null otherwise)
+  billingEmail: String
+  # Current user's role in this tenant (set by myTenants, null elsewhere)
+  myRole: MembershipRole
+  # Whether the current user can leave this tenant
+  canLeave: LeaveEligibility!
   createdAt: DateTime!
   updatedAt: DateTime!
 }
 
+# Outcome of a single plan change in a bulk update
+type PlanChangeResult {
+  tenantId: ID!
+  plan: TenantPlan!
+  applied: Boolean!
+  # Current members exceed the new plan's member limit
+  overMemberLimit: Boolean!
+  memberCount: Int!
+  error: String
+}
+
+# Outcome of deleting a tenant
+type DeleteTenantResult {
+  success: Boolean!
+  tenantId: ID!
+  # Members who had access when it was deleted (null if it couldn't be read)
+  memberCount: Int
+}
+
+# Whether the current user can leave a tenant
+type LeaveEligibility {
+  allowed: Boolean!
+  # Why leaving is refused (null when allowed)
+  reason: LeaveBlockedReason
+}
+
+# Outcome of removing a member or leaving a tenant
+type MemberRemovalResult {
+  success: Boolean!
+  tenantId: ID!
+  membershipId: ID!
+  userId: ID!
+  # Counts after the removal (null if they couldn't be read)
+  remainingMemberCount: Int
+  remainingOwnerCount: Int
+  # Invites the removed member had sent that were repointed or detached
+  invitesHandedOff: Int!
+}
+
+# Outcome of offboarding a user
+type OffboardUserResult {
+  userId: ID!
+  # Successor memberships promoted to OWNER
+  transferredOwnerships: [Membership!]!
+  # Memberships the user was removed from, pending invites included
+  removedMembershipCount: Int!
+  # Invites the user had sent that were repointed or detached
+  invitesHandedOff: Int!
+}
+
 type Membership {
   id: ID!
   user: User!
   tenant: Tenant!
   role: MembershipRole!
+  status: MembershipStatus!
   joinedAt: DateTime!
   invitedBy: User
 }
 
+# Number of tenants the current user holds a role in
+type TenantRoleCount {
+  role: MembershipRole!
+  count: Int!
+}
+
 extend type Query {
   # Get tenant by ID
   tenant(id: ID!): Tenant
@@ -735,11 +1390,29 @@ extend type Query {
   # Get tenant by slug
   tenantBySlug(slug: String!): Tenant
   
-  # Get all tenants current user belongs to
-  myTenants: [Tenant!]!
+  # Suggest an unused slug derived from a tenant name
+  suggestTenantSlug(name: String!): String!
+  
+  # Get tenants current user belongs to, newest first (limit defaults to 50, max 100)
+  myTenants(limit: Int, offset: Int): [Tenant!]! @trace
+  
+  # Get current user's pending invitations, newest first (limit defaults to 50, max 100)
+  myInvitations(limit: Int, offset: Int): [Membership!]!
+  
+  # Count current user's active memberships by role (every role listed, zero if none held)
+  myTenantSummary: [TenantRoleCount!]!
   
   # Get all members of a tenant
-  tenantMembers(tenantId: ID!): [Membership!]!
+  tenantMembers(tenantId: ID!): [Membership!]! @trace
+
+  # Pending invitations of a tenant, newest first, including emails without an account (admin only)
+  pendingInvitations(tenantId: ID!): [Membership!]!
+
+  # Get a membership by ID (tenant members and the invited user only)
+  membership(id: ID!): Membership
+
+  # Deletion details of a deleted tenant (platform admin only)
+  tenantDeletion(tenantId: ID!): DeletionInfo
 }
 
 extend type Mutation {
@@ -749,20 +1422,59 @@ extend type Mutation {
   # Update tenant details
   updateTenant(id: ID!, input: UpdateTenantInput!): Tenant!
   
-  # Delete a tenant (owner only)
-  deleteTenant(id: ID!): Boolean!
+  # Change a tenant's name and slug together; neither changes if either is rejected (admin only)
+  renameTenant(id: ID!, name: String!, slug: String!): Tenant!
   
-  # Invite a user to tenant
+  # Set the tenant's billing/contact email (admin only)
+  setTenantBillingEmail(tenantId: ID!, email: String!): Tenant!
+  
+  # Delete a tenant (owner only), optionally recording why
+  deleteTenant(id: ID!, reason: String): DeleteTenantResult!
+
+  # Restore a deleted tenant (platform admin only)
+  restoreTenant(id: ID!): Tenant!
+
+  # Make a tenant read-only until it is unsuspended (owner only)
+  suspendTenant(id: ID!): Tenant!
+
+  # Make a suspended tenant writable again (owner only)
+  unsuspendTenant(id: ID!): Tenant!
+  
+  # Invite someone to a tenant by email; people without an account get a pending invite
   inviteMember(tenantId: ID!, input: InviteMemberInput!): Membership!
   
+  # Invite by email, creating a pending account and membership if needed
+  inviteByEmail(tenantId: ID!, input: InviteMemberInput!): Membership!
+  
   # Update member's role
   updateMemberRole(membershipId: ID!, role: MembershipRole!): Membership!
   
+  # Update member's role by user and tenant instead of membership ID
+  updateMemberRoleByUser(tenantId: ID!, userId: ID!, role: MembershipRole!): Membership!
+  
   # Remove a member from tenant
-  removeMember(membershipId: ID!): Boolean!
+  removeMember(membershipId: ID!): MemberRemovalResult!
   
   # Leave a tenant (current user)
-  leaveTenant(tenantId: ID!): Boolean!
+  leaveTenant(tenantId: ID!): MemberRemovalResult!
+
+  # Repoint invites created by a departing user to another active admin
+  # (owner only), accepted memberships included
+  # Returns the number of memberships reassigned
+  reassignInvites(tenantId: ID!, fromUserId: ID!, toUserId: ID!): Int!
+
+  # Create a signed invite link token for a membership (admin only)
+  createInviteToken(membershipId: ID!): String!
+
+  # Accept a pending invite from an invite link; the token's email must match the caller
+  acceptInviteByToken(token: String!): Membership!
+
+  # Apply plan changes for many tenants at once (platform admin only)
+  updateTenantPlans(changes: [PlanChange!]!): [PlanChangeResult!]!
+
+  # Hand a departing user's sole ownerships to successors, remove all their
+  # memberships and delete their account in one step (platform admin only)
+  offboardUser(userId: ID!, ownershipSuccessors: [OwnershipSuccessor!]! = []): OffboardUserResult!
 }
 `, BuiltIn: false},
 }
@@ -772,18 +1484,62 @@ var parsedSchema = gqlparser.MustLoadSchema(sources...)
 
 // region    ***************************** args.gotpl *****************************
 
-func (ec *executionContext) field_Mutation_createTenant_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) dir_trace_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNCreateTenantInput2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐCreateTenantInput)
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "label", ec.unmarshalOString2ᚖstring)
 	if err != nil {
 		return nil, err
 	}
-	args["input"] = arg0
+	args["label"] = arg0
 	return args, nil
 }
 
-func (ec *executionContext) field_Mutation_deleteTenant_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Mutation_acceptInviteByToken_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "token", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["token"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_createInviteToken_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "membershipId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["membershipId"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_createTenant_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNCreateTenantInput2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐCreateTenantInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_deleteAccount_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "reason", ec.unmarshalOString2ᚖstring)
+	if err != nil {
+		return nil, err
+	}
+	args["reason"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_deleteTenant_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
 	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "id", ec.unmarshalNID2string)
@@ -791,6 +1547,27 @@ func (ec *executionContext) field_Mutation_deleteTenant_args(ctx context.Context
 		return nil, err
 	}
 	args["id"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "reason", ec.unmarshalOString2ᚖstring)
+	if err != nil {
+		return nil, err
+	}
+	args["reason"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_inviteByEmail_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "tenantId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["tenantId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNInviteMemberInput2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐInviteMemberInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg1
 	return args, nil
 }
 
@@ -821,6 +1598,43 @@ func (ec *executionContext) field_Mutation_leaveTenant_args(ctx context.Context,
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_offboardUser_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "userId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["userId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "ownershipSuccessors", ec.unmarshalNOwnershipSuccessor2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐOwnershipSuccessorᚄ)
+	if err != nil {
+		return nil, err
+	}
+	args["ownershipSuccessors"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_reassignInvites_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "tenantId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["tenantId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "fromUserId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["fromUserId"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "toUserId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["toUserId"] = arg2
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_removeMember_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -832,6 +1646,119 @@ func (ec *executionContext) field_Mutation_removeMember_args(ctx context.Context
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_renameTenant_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "id", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "name", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["name"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "slug", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["slug"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_restoreAccount_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "userId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["userId"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_restoreTenant_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "id", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_revokeUserTokens_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "userId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["userId"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_setTenantBillingEmail_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "tenantId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["tenantId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "email", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["email"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_suspendTenant_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "id", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_unsuspendTenant_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "id", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_updateMemberRoleByUser_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "tenantId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["tenantId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "userId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["userId"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "role", ec.unmarshalNMembershipRole2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipRole)
+	if err != nil {
+		return nil, err
+	}
+	args["role"] = arg2
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_updateMemberRole_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -859,6 +1786,17 @@ func (ec *executionContext) field_Mutation_updateProfile_args(ctx context.Contex
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_updateTenantPlans_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "changes", ec.unmarshalNPlanChange2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐPlanChangeᚄ)
+	if err != nil {
+		return nil, err
+	}
+	args["changes"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_updateTenant_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -886,6 +1824,71 @@ func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_membership_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "id", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_myInvitations_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "limit", ec.unmarshalOInt2ᚖint)
+	if err != nil {
+		return nil, err
+	}
+	args["limit"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "offset", ec.unmarshalOInt2ᚖint)
+	if err != nil {
+		return nil, err
+	}
+	args["offset"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_myTenants_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "limit", ec.unmarshalOInt2ᚖint)
+	if err != nil {
+		return nil, err
+	}
+	args["limit"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "offset", ec.unmarshalOInt2ᚖint)
+	if err != nil {
+		return nil, err
+	}
+	args["offset"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_pendingInvitations_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "tenantId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["tenantId"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_suggestTenantSlug_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "name", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["name"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_tenantBySlug_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -897,6 +1900,17 @@ func (ec *executionContext) field_Query_tenantBySlug_args(ctx context.Context, r
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_tenantDeletion_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "tenantId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["tenantId"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_tenantMembers_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -919,6 +1933,17 @@ func (ec *executionContext) field_Query_tenant_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_userDeletion_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "userId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["userId"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_user_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -982,328 +2007,246 @@ func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArg
 
 // region    **************************** field.gotpl *****************************
 
-func (ec *executionContext) _Membership_id(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
+func (ec *executionContext) _DeleteTenantResult_success(ctx context.Context, field graphql.CollectedField, obj *model.DeleteTenantResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Membership_id,
+		ec.fieldContext_DeleteTenantResult_success,
 		func(ctx context.Context) (any, error) {
-			return obj.ID, nil
+			return obj.Success, nil
 		},
 		nil,
-		ec.marshalNID2string,
+		ec.marshalNBoolean2bool,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Membership_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DeleteTenantResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Membership",
+		Object:     "DeleteTenantResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Membership_user(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
+func (ec *executionContext) _DeleteTenantResult_tenantId(ctx context.Context, field graphql.CollectedField, obj *model.DeleteTenantResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Membership_user,
+		ec.fieldContext_DeleteTenantResult_tenantId,
 		func(ctx context.Context) (any, error) {
-			return obj.User, nil
+			return obj.TenantID, nil
 		},
 		nil,
-		ec.marshalNUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
+		ec.marshalNID2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Membership_user(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DeleteTenantResult_tenantId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Membership",
+		Object:     "DeleteTenantResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_User_id(ctx, field)
-			case "email":
-				return ec.fieldContext_User_email(ctx, field)
-			case "name":
-				return ec.fieldContext_User_name(ctx, field)
-			case "avatarUrl":
-				return ec.fieldContext_User_avatarUrl(ctx, field)
-			case "status":
-				return ec.fieldContext_User_status(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_User_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_User_updatedAt(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Membership_tenant(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
+func (ec *executionContext) _DeleteTenantResult_memberCount(ctx context.Context, field graphql.CollectedField, obj *model.DeleteTenantResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Membership_tenant,
+		ec.fieldContext_DeleteTenantResult_memberCount,
 		func(ctx context.Context) (any, error) {
-			return obj.Tenant, nil
+			return obj.MemberCount, nil
 		},
 		nil,
-		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
-		true,
+		ec.marshalOInt2ᚖint,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Membership_tenant(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DeleteTenantResult_memberCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Membership",
+		Object:     "DeleteTenantResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Tenant_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Tenant_name(ctx, field)
-			case "slug":
-				return ec.fieldContext_Tenant_slug(ctx, field)
-			case "plan":
-				return ec.fieldContext_Tenant_plan(ctx, field)
-			case "isolationMode":
-				return ec.fieldContext_Tenant_isolationMode(ctx, field)
-			case "status":
-				return ec.fieldContext_Tenant_status(ctx, field)
-			case "members":
-				return ec.fieldContext_Tenant_members(ctx, field)
-			case "memberCount":
-				return ec.fieldContext_Tenant_memberCount(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Tenant_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Tenant_updatedAt(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Membership_role(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
+func (ec *executionContext) _DeletionInfo_deletedAt(ctx context.Context, field graphql.CollectedField, obj *model.DeletionInfo) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Membership_role,
+		ec.fieldContext_DeletionInfo_deletedAt,
 		func(ctx context.Context) (any, error) {
-			return obj.Role, nil
+			return obj.DeletedAt, nil
 		},
 		nil,
-		ec.marshalNMembershipRole2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipRole,
+		ec.marshalNDateTime2timeᚐTime,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Membership_role(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DeletionInfo_deletedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Membership",
+		Object:     "DeletionInfo",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type MembershipRole does not have child fields")
+			return nil, errors.New("field of type DateTime does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Membership_joinedAt(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
+func (ec *executionContext) _DeletionInfo_deletedBy(ctx context.Context, field graphql.CollectedField, obj *model.DeletionInfo) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Membership_joinedAt,
+		ec.fieldContext_DeletionInfo_deletedBy,
 		func(ctx context.Context) (any, error) {
-			return obj.JoinedAt, nil
+			return obj.DeletedBy, nil
 		},
 		nil,
-		ec.marshalNDateTime2timeᚐTime,
-		true,
+		ec.marshalOID2ᚖstring,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Membership_joinedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DeletionInfo_deletedBy(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Membership",
+		Object:     "DeletionInfo",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type DateTime does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Membership_invitedBy(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
+func (ec *executionContext) _DeletionInfo_reason(ctx context.Context, field graphql.CollectedField, obj *model.DeletionInfo) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Membership_invitedBy,
+		ec.fieldContext_DeletionInfo_reason,
 		func(ctx context.Context) (any, error) {
-			return obj.InvitedBy, nil
+			return obj.Reason, nil
 		},
 		nil,
-		ec.marshalOUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
+		ec.marshalOString2ᚖstring,
 		true,
 		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Membership_invitedBy(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DeletionInfo_reason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Membership",
+		Object:     "DeletionInfo",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_User_id(ctx, field)
-			case "email":
-				return ec.fieldContext_User_email(ctx, field)
-			case "name":
-				return ec.fieldContext_User_name(ctx, field)
-			case "avatarUrl":
-				return ec.fieldContext_User_avatarUrl(ctx, field)
-			case "status":
-				return ec.fieldContext_User_status(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_User_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_User_updatedAt(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation__empty(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _LeaveEligibility_allowed(ctx context.Context, field graphql.CollectedField, obj *model.LeaveEligibility) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation__empty,
+		ec.fieldContext_LeaveEligibility_allowed,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Mutation().Empty(ctx)
+			return obj.Allowed, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNBoolean2bool,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation__empty(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_LeaveEligibility_allowed(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "LeaveEligibility",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_updateProfile(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _LeaveEligibility_reason(ctx context.Context, field graphql.CollectedField, obj *model.LeaveEligibility) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_updateProfile,
+		ec.fieldContext_LeaveEligibility_reason,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().UpdateProfile(ctx, fc.Args["input"].(model.UpdateProfileInput))
+			return obj.Reason, nil
 		},
 		nil,
-		ec.marshalNUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
-		true,
+		ec.marshalOLeaveBlockedReason2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐLeaveBlockedReason,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_updateProfile(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_LeaveEligibility_reason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "LeaveEligibility",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_User_id(ctx, field)
-			case "email":
-				return ec.fieldContext_User_email(ctx, field)
-			case "name":
-				return ec.fieldContext_User_name(ctx, field)
-			case "avatarUrl":
-				return ec.fieldContext_User_avatarUrl(ctx, field)
-			case "status":
-				return ec.fieldContext_User_status(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_User_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_User_updatedAt(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
+			return nil, errors.New("field of type LeaveBlockedReason does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_updateProfile_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_deleteAccount(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _MemberRemovalResult_success(ctx context.Context, field graphql.CollectedField, obj *model.MemberRemovalResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_deleteAccount,
+		ec.fieldContext_MemberRemovalResult_success,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Mutation().DeleteAccount(ctx)
+			return obj.Success, nil
 		},
 		nil,
 		ec.marshalNBoolean2bool,
@@ -1312,12 +2255,12 @@ func (ec *executionContext) _Mutation_deleteAccount(ctx context.Context, field g
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_deleteAccount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MemberRemovalResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "MemberRemovalResult",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			return nil, errors.New("field of type Boolean does not have child fields")
 		},
@@ -1325,413 +2268,228 @@ func (ec *executionContext) fieldContext_Mutation_deleteAccount(_ context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_createTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _MemberRemovalResult_tenantId(ctx context.Context, field graphql.CollectedField, obj *model.MemberRemovalResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_createTenant,
+		ec.fieldContext_MemberRemovalResult_tenantId,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().CreateTenant(ctx, fc.Args["input"].(model.CreateTenantInput))
+			return obj.TenantID, nil
 		},
 		nil,
-		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
+		ec.marshalNID2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_createTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MemberRemovalResult_tenantId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "MemberRemovalResult",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Tenant_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Tenant_name(ctx, field)
-			case "slug":
-				return ec.fieldContext_Tenant_slug(ctx, field)
-			case "plan":
-				return ec.fieldContext_Tenant_plan(ctx, field)
-			case "isolationMode":
-				return ec.fieldContext_Tenant_isolationMode(ctx, field)
-			case "status":
-				return ec.fieldContext_Tenant_status(ctx, field)
-			case "members":
-				return ec.fieldContext_Tenant_members(ctx, field)
-			case "memberCount":
-				return ec.fieldContext_Tenant_memberCount(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Tenant_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Tenant_updatedAt(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_createTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_updateTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _MemberRemovalResult_membershipId(ctx context.Context, field graphql.CollectedField, obj *model.MemberRemovalResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_updateTenant,
+		ec.fieldContext_MemberRemovalResult_membershipId,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().UpdateTenant(ctx, fc.Args["id"].(string), fc.Args["input"].(model.UpdateTenantInput))
+			return obj.MembershipID, nil
 		},
 		nil,
-		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
+		ec.marshalNID2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_updateTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MemberRemovalResult_membershipId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "MemberRemovalResult",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Tenant_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Tenant_name(ctx, field)
-			case "slug":
-				return ec.fieldContext_Tenant_slug(ctx, field)
-			case "plan":
-				return ec.fieldContext_Tenant_plan(ctx, field)
-			case "isolationMode":
-				return ec.fieldContext_Tenant_isolationMode(ctx, field)
-			case "status":
-				return ec.fieldContext_Tenant_status(ctx, field)
-			case "members":
-				return ec.fieldContext_Tenant_members(ctx, field)
-			case "memberCount":
-				return ec.fieldContext_Tenant_memberCount(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Tenant_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Tenant_updatedAt(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_updateTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_deleteTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _MemberRemovalResult_userId(ctx context.Context, field graphql.CollectedField, obj *model.MemberRemovalResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_deleteTenant,
+		ec.fieldContext_MemberRemovalResult_userId,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().DeleteTenant(ctx, fc.Args["id"].(string))
+			return obj.UserID, nil
 		},
 		nil,
-		ec.marshalNBoolean2bool,
+		ec.marshalNID2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_deleteTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MemberRemovalResult_userId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "MemberRemovalResult",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_deleteTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_inviteMember(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _MemberRemovalResult_remainingMemberCount(ctx context.Context, field graphql.CollectedField, obj *model.MemberRemovalResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_inviteMember,
+		ec.fieldContext_MemberRemovalResult_remainingMemberCount,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().InviteMember(ctx, fc.Args["tenantId"].(string), fc.Args["input"].(model.InviteMemberInput))
+			return obj.RemainingMemberCount, nil
 		},
 		nil,
-		ec.marshalNMembership2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembership,
-		true,
+		ec.marshalOInt2ᚖint,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_inviteMember(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MemberRemovalResult_remainingMemberCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "MemberRemovalResult",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Membership_id(ctx, field)
-			case "user":
-				return ec.fieldContext_Membership_user(ctx, field)
-			case "tenant":
-				return ec.fieldContext_Membership_tenant(ctx, field)
-			case "role":
-				return ec.fieldContext_Membership_role(ctx, field)
-			case "joinedAt":
-				return ec.fieldContext_Membership_joinedAt(ctx, field)
-			case "invitedBy":
-				return ec.fieldContext_Membership_invitedBy(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_inviteMember_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_updateMemberRole(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _MemberRemovalResult_remainingOwnerCount(ctx context.Context, field graphql.CollectedField, obj *model.MemberRemovalResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_updateMemberRole,
+		ec.fieldContext_MemberRemovalResult_remainingOwnerCount,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().UpdateMemberRole(ctx, fc.Args["membershipId"].(string), fc.Args["role"].(model.MembershipRole))
+			return obj.RemainingOwnerCount, nil
 		},
 		nil,
-		ec.marshalNMembership2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembership,
-		true,
+		ec.marshalOInt2ᚖint,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_updateMemberRole(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MemberRemovalResult_remainingOwnerCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "MemberRemovalResult",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Membership_id(ctx, field)
-			case "user":
-				return ec.fieldContext_Membership_user(ctx, field)
-			case "tenant":
-				return ec.fieldContext_Membership_tenant(ctx, field)
-			case "role":
-				return ec.fieldContext_Membership_role(ctx, field)
-			case "joinedAt":
-				return ec.fieldContext_Membership_joinedAt(ctx, field)
-			case "invitedBy":
-				return ec.fieldContext_Membership_invitedBy(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_updateMemberRole_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_removeMember(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _MemberRemovalResult_invitesHandedOff(ctx context.Context, field graphql.CollectedField, obj *model.MemberRemovalResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_removeMember,
+		ec.fieldContext_MemberRemovalResult_invitesHandedOff,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().RemoveMember(ctx, fc.Args["membershipId"].(string))
+			return obj.InvitesHandedOff, nil
 		},
 		nil,
-		ec.marshalNBoolean2bool,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_removeMember(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MemberRemovalResult_invitesHandedOff(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "MemberRemovalResult",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_removeMember_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_leaveTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Membership_id(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_leaveTenant,
+		ec.fieldContext_Membership_id,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().LeaveTenant(ctx, fc.Args["tenantId"].(string))
+			return obj.ID, nil
 		},
 		nil,
-		ec.marshalNBoolean2bool,
+		ec.marshalNID2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_leaveTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Membership_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "Membership",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_leaveTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_health(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Membership_user(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_health,
+		ec.fieldContext_Membership_user,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Query().Health(ctx)
+			return ec.resolvers.Membership().User(ctx, obj)
 		},
 		nil,
-		ec.marshalNString2string,
-		true,
+		ec.marshalNUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
 		true,
-	)
-}
-
-func (ec *executionContext) fieldContext_Query_health(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
-	fc = &graphql.FieldContext{
-		Object:     "Query",
-		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
-		},
-	}
-	return fc, nil
-}
-
-func (ec *executionContext) _Query_me(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	return graphql.ResolveField(
-		ctx,
-		ec.OperationContext,
-		field,
-		ec.fieldContext_Query_me,
-		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Query().Me(ctx)
-		},
-		nil,
-		ec.marshalOUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_me(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Membership_user(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Membership",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
@@ -1758,279 +2516,267 @@ func (ec *executionContext) fieldContext_Query_me(_ context.Context, field graph
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_user(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Membership_tenant(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_user,
+		ec.fieldContext_Membership_tenant,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Query().User(ctx, fc.Args["id"].(string))
+			return obj.Tenant, nil
 		},
 		nil,
-		ec.marshalOUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
+		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_user(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Membership_tenant(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Membership",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_User_id(ctx, field)
-			case "email":
-				return ec.fieldContext_User_email(ctx, field)
+				return ec.fieldContext_Tenant_id(ctx, field)
 			case "name":
-				return ec.fieldContext_User_name(ctx, field)
-			case "avatarUrl":
-				return ec.fieldContext_User_avatarUrl(ctx, field)
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
 			case "status":
-				return ec.fieldContext_User_status(ctx, field)
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
 			case "createdAt":
-				return ec.fieldContext_User_createdAt(ctx, field)
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
 			case "updatedAt":
-				return ec.fieldContext_User_updatedAt(ctx, field)
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_user_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
+	return fc, nil
+}
+
+func (ec *executionContext) _Membership_role(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Membership_role,
+		func(ctx context.Context) (any, error) {
+			return obj.Role, nil
+		},
+		nil,
+		ec.marshalNMembershipRole2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipRole,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Membership_role(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Membership",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type MembershipRole does not have child fields")
+		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_tenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Membership_status(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_tenant,
+		ec.fieldContext_Membership_status,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Query().Tenant(ctx, fc.Args["id"].(string))
+			return obj.Status, nil
 		},
 		nil,
-		ec.marshalOTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
+		ec.marshalNMembershipStatus2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipStatus,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_tenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Membership_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Membership",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Tenant_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Tenant_name(ctx, field)
-			case "slug":
-				return ec.fieldContext_Tenant_slug(ctx, field)
-			case "plan":
-				return ec.fieldContext_Tenant_plan(ctx, field)
-			case "isolationMode":
-				return ec.fieldContext_Tenant_isolationMode(ctx, field)
-			case "status":
-				return ec.fieldContext_Tenant_status(ctx, field)
-			case "members":
-				return ec.fieldContext_Tenant_members(ctx, field)
-			case "memberCount":
-				return ec.fieldContext_Tenant_memberCount(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Tenant_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Tenant_updatedAt(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+			return nil, errors.New("field of type MembershipStatus does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_tenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
+	return fc, nil
+}
+
+func (ec *executionContext) _Membership_joinedAt(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Membership_joinedAt,
+		func(ctx context.Context) (any, error) {
+			return obj.JoinedAt, nil
+		},
+		nil,
+		ec.marshalNDateTime2timeᚐTime,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Membership_joinedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Membership",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type DateTime does not have child fields")
+		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_tenantBySlug(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Membership_invitedBy(ctx context.Context, field graphql.CollectedField, obj *model.Membership) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_tenantBySlug,
+		ec.fieldContext_Membership_invitedBy,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Query().TenantBySlug(ctx, fc.Args["slug"].(string))
+			return ec.resolvers.Membership().InvitedBy(ctx, obj)
 		},
 		nil,
-		ec.marshalOTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
+		ec.marshalOUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
 		true,
 		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_tenantBySlug(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Membership_invitedBy(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Membership",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Tenant_id(ctx, field)
+				return ec.fieldContext_User_id(ctx, field)
+			case "email":
+				return ec.fieldContext_User_email(ctx, field)
 			case "name":
-				return ec.fieldContext_Tenant_name(ctx, field)
-			case "slug":
-				return ec.fieldContext_Tenant_slug(ctx, field)
-			case "plan":
-				return ec.fieldContext_Tenant_plan(ctx, field)
-			case "isolationMode":
-				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+				return ec.fieldContext_User_name(ctx, field)
+			case "avatarUrl":
+				return ec.fieldContext_User_avatarUrl(ctx, field)
 			case "status":
-				return ec.fieldContext_Tenant_status(ctx, field)
-			case "members":
-				return ec.fieldContext_Tenant_members(ctx, field)
-			case "memberCount":
-				return ec.fieldContext_Tenant_memberCount(ctx, field)
+				return ec.fieldContext_User_status(ctx, field)
 			case "createdAt":
-				return ec.fieldContext_Tenant_createdAt(ctx, field)
+				return ec.fieldContext_User_createdAt(ctx, field)
 			case "updatedAt":
-				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+				return ec.fieldContext_User_updatedAt(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_tenantBySlug_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_myTenants(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation__empty(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_myTenants,
+		ec.fieldContext_Mutation__empty,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Query().MyTenants(ctx)
+			return ec.resolvers.Mutation().Empty(ctx)
 		},
 		nil,
-		ec.marshalNTenant2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantᚄ,
-		true,
+		ec.marshalOString2ᚖstring,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_myTenants(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation__empty(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Tenant_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Tenant_name(ctx, field)
-			case "slug":
-				return ec.fieldContext_Tenant_slug(ctx, field)
-			case "plan":
-				return ec.fieldContext_Tenant_plan(ctx, field)
-			case "isolationMode":
-				return ec.fieldContext_Tenant_isolationMode(ctx, field)
-			case "status":
-				return ec.fieldContext_Tenant_status(ctx, field)
-			case "members":
-				return ec.fieldContext_Tenant_members(ctx, field)
-			case "memberCount":
-				return ec.fieldContext_Tenant_memberCount(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Tenant_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Tenant_updatedAt(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_tenantMembers(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_updateProfile(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_tenantMembers,
+		ec.fieldContext_Mutation_updateProfile,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Query().TenantMembers(ctx, fc.Args["tenantId"].(string))
+			return ec.resolvers.Mutation().UpdateProfile(ctx, fc.Args["input"].(model.UpdateProfileInput))
 		},
 		nil,
-		ec.marshalNMembership2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipᚄ,
+		ec.marshalNUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_tenantMembers(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_updateProfile(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Membership_id(ctx, field)
-			case "user":
-				return ec.fieldContext_Membership_user(ctx, field)
-			case "tenant":
-				return ec.fieldContext_Membership_tenant(ctx, field)
-			case "role":
-				return ec.fieldContext_Membership_role(ctx, field)
-			case "joinedAt":
-				return ec.fieldContext_Membership_joinedAt(ctx, field)
-			case "invitedBy":
-				return ec.fieldContext_Membership_invitedBy(ctx, field)
+				return ec.fieldContext_User_id(ctx, field)
+			case "email":
+				return ec.fieldContext_User_email(ctx, field)
+			case "name":
+				return ec.fieldContext_User_name(ctx, field)
+			case "avatarUrl":
+				return ec.fieldContext_User_avatarUrl(ctx, field)
+			case "status":
+				return ec.fieldContext_User_status(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_User_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_User_updatedAt(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
 		},
 	}
 	defer func() {
@@ -2040,62 +2786,38 @@ func (ec *executionContext) fieldContext_Query_tenantMembers(ctx context.Context
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_tenantMembers_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_updateProfile_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_deleteAccount(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query___type,
+		ec.fieldContext_Mutation_deleteAccount,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.introspectType(fc.Args["name"].(string))
+			return ec.resolvers.Mutation().DeleteAccount(ctx, fc.Args["reason"].(*string))
 		},
 		nil,
-		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		ec.marshalNBoolean2bool,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_deleteAccount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: false,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "isOneOf":
-				return ec.fieldContext___Type_isOneOf(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	defer func() {
@@ -2105,194 +2827,2568 @@ func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_deleteAccount_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_restoreAccount(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query___schema,
+		ec.fieldContext_Mutation_restoreAccount,
 		func(ctx context.Context) (any, error) {
-			return ec.introspectSchema()
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().RestoreAccount(ctx, fc.Args["userId"].(string))
 		},
 		nil,
-		ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema,
+		ec.marshalNUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_restoreAccount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: false,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "description":
-				return ec.fieldContext___Schema_description(ctx, field)
-			case "types":
-				return ec.fieldContext___Schema_types(ctx, field)
-			case "queryType":
-				return ec.fieldContext___Schema_queryType(ctx, field)
-			case "mutationType":
-				return ec.fieldContext___Schema_mutationType(ctx, field)
-			case "subscriptionType":
-				return ec.fieldContext___Schema_subscriptionType(ctx, field)
-			case "directives":
-				return ec.fieldContext___Schema_directives(ctx, field)
+			case "id":
+				return ec.fieldContext_User_id(ctx, field)
+			case "email":
+				return ec.fieldContext_User_email(ctx, field)
+			case "name":
+				return ec.fieldContext_User_name(ctx, field)
+			case "avatarUrl":
+				return ec.fieldContext_User_avatarUrl(ctx, field)
+			case "status":
+				return ec.fieldContext_User_status(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_User_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_User_updatedAt(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_restoreAccount_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Subscription__empty(ctx context.Context, field graphql.CollectedField) (ret func(ctx context.Context) graphql.Marshaler) {
-	return graphql.ResolveFieldStream(
+func (ec *executionContext) _Mutation_revokeUserTokens(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Subscription__empty,
+		ec.fieldContext_Mutation_revokeUserTokens,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Subscription().Empty(ctx)
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().RevokeUserTokens(ctx, fc.Args["userId"].(string))
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNBoolean2bool,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Subscription__empty(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_revokeUserTokens(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Subscription",
+		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_revokeUserTokens_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Tenant_id(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_createTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Tenant_id,
+		ec.fieldContext_Mutation_createTenant,
 		func(ctx context.Context) (any, error) {
-			return obj.ID, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().CreateTenant(ctx, fc.Args["input"].(model.CreateTenantInput))
 		},
 		nil,
-		ec.marshalNID2string,
+		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Tenant_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_createTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Tenant",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+			case "status":
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_createTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Tenant_name(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_updateTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Tenant_name,
+		ec.fieldContext_Mutation_updateTenant,
 		func(ctx context.Context) (any, error) {
-			return obj.Name, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().UpdateTenant(ctx, fc.Args["id"].(string), fc.Args["input"].(model.UpdateTenantInput))
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Tenant_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_updateTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Tenant",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+			case "status":
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_updateTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Tenant_slug(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_renameTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Tenant_slug,
+		ec.fieldContext_Mutation_renameTenant,
 		func(ctx context.Context) (any, error) {
-			return obj.Slug, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().RenameTenant(ctx, fc.Args["id"].(string), fc.Args["name"].(string), fc.Args["slug"].(string))
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Tenant_slug(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_renameTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Tenant",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+			case "status":
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_renameTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Tenant_plan(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_setTenantBillingEmail(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Tenant_plan,
+		ec.fieldContext_Mutation_setTenantBillingEmail,
 		func(ctx context.Context) (any, error) {
-			return obj.Plan, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().SetTenantBillingEmail(ctx, fc.Args["tenantId"].(string), fc.Args["email"].(string))
 		},
 		nil,
-		ec.marshalNTenantPlan2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantPlan,
+		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Tenant_plan(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_setTenantBillingEmail(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Tenant",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+			case "status":
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_setTenantBillingEmail_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_deleteTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_deleteTenant,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().DeleteTenant(ctx, fc.Args["id"].(string), fc.Args["reason"].(*string))
+		},
+		nil,
+		ec.marshalNDeleteTenantResult2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐDeleteTenantResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_deleteTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_DeleteTenantResult_success(ctx, field)
+			case "tenantId":
+				return ec.fieldContext_DeleteTenantResult_tenantId(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_DeleteTenantResult_memberCount(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DeleteTenantResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_deleteTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_restoreTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_restoreTenant,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().RestoreTenant(ctx, fc.Args["id"].(string))
+		},
+		nil,
+		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_restoreTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+			case "status":
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_restoreTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_suspendTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_suspendTenant,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().SuspendTenant(ctx, fc.Args["id"].(string))
+		},
+		nil,
+		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_suspendTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+			case "status":
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_suspendTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_unsuspendTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_unsuspendTenant,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().UnsuspendTenant(ctx, fc.Args["id"].(string))
+		},
+		nil,
+		ec.marshalNTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_unsuspendTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+			case "status":
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_unsuspendTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_inviteMember(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_inviteMember,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().InviteMember(ctx, fc.Args["tenantId"].(string), fc.Args["input"].(model.InviteMemberInput))
+		},
+		nil,
+		ec.marshalNMembership2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembership,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_inviteMember(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_inviteMember_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_inviteByEmail(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_inviteByEmail,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().InviteByEmail(ctx, fc.Args["tenantId"].(string), fc.Args["input"].(model.InviteMemberInput))
+		},
+		nil,
+		ec.marshalNMembership2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembership,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_inviteByEmail(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_inviteByEmail_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_updateMemberRole(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_updateMemberRole,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().UpdateMemberRole(ctx, fc.Args["membershipId"].(string), fc.Args["role"].(model.MembershipRole))
+		},
+		nil,
+		ec.marshalNMembership2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembership,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_updateMemberRole(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_updateMemberRole_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_updateMemberRoleByUser(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_updateMemberRoleByUser,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().UpdateMemberRoleByUser(ctx, fc.Args["tenantId"].(string), fc.Args["userId"].(string), fc.Args["role"].(model.MembershipRole))
+		},
+		nil,
+		ec.marshalNMembership2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembership,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_updateMemberRoleByUser(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_updateMemberRoleByUser_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_removeMember(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_removeMember,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().RemoveMember(ctx, fc.Args["membershipId"].(string))
+		},
+		nil,
+		ec.marshalNMemberRemovalResult2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMemberRemovalResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_removeMember(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_MemberRemovalResult_success(ctx, field)
+			case "tenantId":
+				return ec.fieldContext_MemberRemovalResult_tenantId(ctx, field)
+			case "membershipId":
+				return ec.fieldContext_MemberRemovalResult_membershipId(ctx, field)
+			case "userId":
+				return ec.fieldContext_MemberRemovalResult_userId(ctx, field)
+			case "remainingMemberCount":
+				return ec.fieldContext_MemberRemovalResult_remainingMemberCount(ctx, field)
+			case "remainingOwnerCount":
+				return ec.fieldContext_MemberRemovalResult_remainingOwnerCount(ctx, field)
+			case "invitesHandedOff":
+				return ec.fieldContext_MemberRemovalResult_invitesHandedOff(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MemberRemovalResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_removeMember_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_leaveTenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_leaveTenant,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().LeaveTenant(ctx, fc.Args["tenantId"].(string))
+		},
+		nil,
+		ec.marshalNMemberRemovalResult2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMemberRemovalResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_leaveTenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_MemberRemovalResult_success(ctx, field)
+			case "tenantId":
+				return ec.fieldContext_MemberRemovalResult_tenantId(ctx, field)
+			case "membershipId":
+				return ec.fieldContext_MemberRemovalResult_membershipId(ctx, field)
+			case "userId":
+				return ec.fieldContext_MemberRemovalResult_userId(ctx, field)
+			case "remainingMemberCount":
+				return ec.fieldContext_MemberRemovalResult_remainingMemberCount(ctx, field)
+			case "remainingOwnerCount":
+				return ec.fieldContext_MemberRemovalResult_remainingOwnerCount(ctx, field)
+			case "invitesHandedOff":
+				return ec.fieldContext_MemberRemovalResult_invitesHandedOff(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MemberRemovalResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_leaveTenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_reassignInvites(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_reassignInvites,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().ReassignInvites(ctx, fc.Args["tenantId"].(string), fc.Args["fromUserId"].(string), fc.Args["toUserId"].(string))
+		},
+		nil,
+		ec.marshalNInt2int,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_reassignInvites(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_reassignInvites_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_createInviteToken(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_createInviteToken,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().CreateInviteToken(ctx, fc.Args["membershipId"].(string))
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_createInviteToken(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_createInviteToken_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_acceptInviteByToken(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_acceptInviteByToken,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().AcceptInviteByToken(ctx, fc.Args["token"].(string))
+		},
+		nil,
+		ec.marshalNMembership2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembership,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_acceptInviteByToken(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_acceptInviteByToken_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_updateTenantPlans(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_updateTenantPlans,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().UpdateTenantPlans(ctx, fc.Args["changes"].([]*model.PlanChange))
+		},
+		nil,
+		ec.marshalNPlanChangeResult2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐPlanChangeResultᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_updateTenantPlans(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "tenantId":
+				return ec.fieldContext_PlanChangeResult_tenantId(ctx, field)
+			case "plan":
+				return ec.fieldContext_PlanChangeResult_plan(ctx, field)
+			case "applied":
+				return ec.fieldContext_PlanChangeResult_applied(ctx, field)
+			case "overMemberLimit":
+				return ec.fieldContext_PlanChangeResult_overMemberLimit(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_PlanChangeResult_memberCount(ctx, field)
+			case "error":
+				return ec.fieldContext_PlanChangeResult_error(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PlanChangeResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_updateTenantPlans_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_offboardUser(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_offboardUser,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().OffboardUser(ctx, fc.Args["userId"].(string), fc.Args["ownershipSuccessors"].([]*model.OwnershipSuccessor))
+		},
+		nil,
+		ec.marshalNOffboardUserResult2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐOffboardUserResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_offboardUser(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "userId":
+				return ec.fieldContext_OffboardUserResult_userId(ctx, field)
+			case "transferredOwnerships":
+				return ec.fieldContext_OffboardUserResult_transferredOwnerships(ctx, field)
+			case "removedMembershipCount":
+				return ec.fieldContext_OffboardUserResult_removedMembershipCount(ctx, field)
+			case "invitesHandedOff":
+				return ec.fieldContext_OffboardUserResult_invitesHandedOff(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OffboardUserResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_offboardUser_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OffboardUserResult_userId(ctx context.Context, field graphql.CollectedField, obj *model.OffboardUserResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OffboardUserResult_userId,
+		func(ctx context.Context) (any, error) {
+			return obj.UserID, nil
+		},
+		nil,
+		ec.marshalNID2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OffboardUserResult_userId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OffboardUserResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OffboardUserResult_transferredOwnerships(ctx context.Context, field graphql.CollectedField, obj *model.OffboardUserResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OffboardUserResult_transferredOwnerships,
+		func(ctx context.Context) (any, error) {
+			return obj.TransferredOwnerships, nil
+		},
+		nil,
+		ec.marshalNMembership2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OffboardUserResult_transferredOwnerships(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OffboardUserResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OffboardUserResult_removedMembershipCount(ctx context.Context, field graphql.CollectedField, obj *model.OffboardUserResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OffboardUserResult_removedMembershipCount,
+		func(ctx context.Context) (any, error) {
+			return obj.RemovedMembershipCount, nil
+		},
+		nil,
+		ec.marshalNInt2int,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OffboardUserResult_removedMembershipCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OffboardUserResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OffboardUserResult_invitesHandedOff(ctx context.Context, field graphql.CollectedField, obj *model.OffboardUserResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OffboardUserResult_invitesHandedOff,
+		func(ctx context.Context) (any, error) {
+			return obj.InvitesHandedOff, nil
+		},
+		nil,
+		ec.marshalNInt2int,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OffboardUserResult_invitesHandedOff(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OffboardUserResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PlanChangeResult_tenantId(ctx context.Context, field graphql.CollectedField, obj *model.PlanChangeResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_PlanChangeResult_tenantId,
+		func(ctx context.Context) (any, error) {
+			return obj.TenantID, nil
+		},
+		nil,
+		ec.marshalNID2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_PlanChangeResult_tenantId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PlanChangeResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PlanChangeResult_plan(ctx context.Context, field graphql.CollectedField, obj *model.PlanChangeResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_PlanChangeResult_plan,
+		func(ctx context.Context) (any, error) {
+			return obj.Plan, nil
+		},
+		nil,
+		ec.marshalNTenantPlan2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantPlan,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_PlanChangeResult_plan(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PlanChangeResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type TenantPlan does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PlanChangeResult_applied(ctx context.Context, field graphql.CollectedField, obj *model.PlanChangeResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_PlanChangeResult_applied,
+		func(ctx context.Context) (any, error) {
+			return obj.Applied, nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_PlanChangeResult_applied(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PlanChangeResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PlanChangeResult_overMemberLimit(ctx context.Context, field graphql.CollectedField, obj *model.PlanChangeResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_PlanChangeResult_overMemberLimit,
+		func(ctx context.Context) (any, error) {
+			return obj.OverMemberLimit, nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_PlanChangeResult_overMemberLimit(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PlanChangeResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PlanChangeResult_memberCount(ctx context.Context, field graphql.CollectedField, obj *model.PlanChangeResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_PlanChangeResult_memberCount,
+		func(ctx context.Context) (any, error) {
+			return obj.MemberCount, nil
+		},
+		nil,
+		ec.marshalNInt2int,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_PlanChangeResult_memberCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PlanChangeResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PlanChangeResult_error(ctx context.Context, field graphql.CollectedField, obj *model.PlanChangeResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_PlanChangeResult_error,
+		func(ctx context.Context) (any, error) {
+			return obj.Error, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_PlanChangeResult_error(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PlanChangeResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_health(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_health,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Query().Health(ctx)
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_health(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_me(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_me,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Query().Me(ctx)
+		},
+		nil,
+		ec.marshalOUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_me(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_User_id(ctx, field)
+			case "email":
+				return ec.fieldContext_User_email(ctx, field)
+			case "name":
+				return ec.fieldContext_User_name(ctx, field)
+			case "avatarUrl":
+				return ec.fieldContext_User_avatarUrl(ctx, field)
+			case "status":
+				return ec.fieldContext_User_status(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_User_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_User_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_user(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_user,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().User(ctx, fc.Args["id"].(string))
+		},
+		nil,
+		ec.marshalOUser2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐUser,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_user(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_User_id(ctx, field)
+			case "email":
+				return ec.fieldContext_User_email(ctx, field)
+			case "name":
+				return ec.fieldContext_User_name(ctx, field)
+			case "avatarUrl":
+				return ec.fieldContext_User_avatarUrl(ctx, field)
+			case "status":
+				return ec.fieldContext_User_status(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_User_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_User_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_user_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_userDeletion(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_userDeletion,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().UserDeletion(ctx, fc.Args["userId"].(string))
+		},
+		nil,
+		ec.marshalODeletionInfo2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐDeletionInfo,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_userDeletion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "deletedAt":
+				return ec.fieldContext_DeletionInfo_deletedAt(ctx, field)
+			case "deletedBy":
+				return ec.fieldContext_DeletionInfo_deletedBy(ctx, field)
+			case "reason":
+				return ec.fieldContext_DeletionInfo_reason(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DeletionInfo", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_userDeletion_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_tenant(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_tenant,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().Tenant(ctx, fc.Args["id"].(string))
+		},
+		nil,
+		ec.marshalOTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_tenant(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+			case "status":
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_tenant_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_tenantBySlug(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_tenantBySlug,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().TenantBySlug(ctx, fc.Args["slug"].(string))
+		},
+		nil,
+		ec.marshalOTenant2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenant,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_tenantBySlug(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+			case "status":
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_tenantBySlug_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_suggestTenantSlug(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_suggestTenantSlug,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().SuggestTenantSlug(ctx, fc.Args["name"].(string))
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_suggestTenantSlug(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_suggestTenantSlug_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_myTenants(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_myTenants,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().MyTenants(ctx, fc.Args["limit"].(*int), fc.Args["offset"].(*int))
+		},
+		func(ctx context.Context, next graphql.Resolver) graphql.Resolver {
+			directive0 := next
+
+			directive1 := func(ctx context.Context) (any, error) {
+				if ec.directives.Trace == nil {
+					var zeroVal []*model.Tenant
+					return zeroVal, errors.New("directive trace is not implemented")
+				}
+				return ec.directives.Trace(ctx, nil, directive0, nil)
+			}
+
+			next = directive1
+			return next
+		},
+		ec.marshalNTenant2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_myTenants(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Tenant_slug(ctx, field)
+			case "plan":
+				return ec.fieldContext_Tenant_plan(ctx, field)
+			case "isolationMode":
+				return ec.fieldContext_Tenant_isolationMode(ctx, field)
+			case "status":
+				return ec.fieldContext_Tenant_status(ctx, field)
+			case "members":
+				return ec.fieldContext_Tenant_members(ctx, field)
+			case "owners":
+				return ec.fieldContext_Tenant_owners(ctx, field)
+			case "memberCount":
+				return ec.fieldContext_Tenant_memberCount(ctx, field)
+			case "billingEmail":
+				return ec.fieldContext_Tenant_billingEmail(ctx, field)
+			case "myRole":
+				return ec.fieldContext_Tenant_myRole(ctx, field)
+			case "canLeave":
+				return ec.fieldContext_Tenant_canLeave(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_myTenants_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_myInvitations(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_myInvitations,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().MyInvitations(ctx, fc.Args["limit"].(*int), fc.Args["offset"].(*int))
+		},
+		nil,
+		ec.marshalNMembership2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_myInvitations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_myInvitations_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_myTenantSummary(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_myTenantSummary,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Query().MyTenantSummary(ctx)
+		},
+		nil,
+		ec.marshalNTenantRoleCount2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantRoleCountᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_myTenantSummary(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "role":
+				return ec.fieldContext_TenantRoleCount_role(ctx, field)
+			case "count":
+				return ec.fieldContext_TenantRoleCount_count(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type TenantRoleCount", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_tenantMembers(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_tenantMembers,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().TenantMembers(ctx, fc.Args["tenantId"].(string))
+		},
+		func(ctx context.Context, next graphql.Resolver) graphql.Resolver {
+			directive0 := next
+
+			directive1 := func(ctx context.Context) (any, error) {
+				if ec.directives.Trace == nil {
+					var zeroVal []*model.Membership
+					return zeroVal, errors.New("directive trace is not implemented")
+				}
+				return ec.directives.Trace(ctx, nil, directive0, nil)
+			}
+
+			next = directive1
+			return next
+		},
+		ec.marshalNMembership2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_tenantMembers(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_tenantMembers_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_pendingInvitations(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_pendingInvitations,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().PendingInvitations(ctx, fc.Args["tenantId"].(string))
+		},
+		nil,
+		ec.marshalNMembership2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_pendingInvitations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_pendingInvitations_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_membership(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_membership,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().Membership(ctx, fc.Args["id"].(string))
+		},
+		nil,
+		ec.marshalOMembership2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembership,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_membership(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_membership_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_tenantDeletion(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_tenantDeletion,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().TenantDeletion(ctx, fc.Args["tenantId"].(string))
+		},
+		nil,
+		ec.marshalODeletionInfo2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐDeletionInfo,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_tenantDeletion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "deletedAt":
+				return ec.fieldContext_DeletionInfo_deletedAt(ctx, field)
+			case "deletedBy":
+				return ec.fieldContext_DeletionInfo_deletedBy(ctx, field)
+			case "reason":
+				return ec.fieldContext_DeletionInfo_reason(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DeletionInfo", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_tenantDeletion_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query___type,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.introspectType(fc.Args["name"].(string))
+		},
+		nil,
+		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "isOneOf":
+				return ec.fieldContext___Type_isOneOf(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query___schema,
+		func(ctx context.Context) (any, error) {
+			return ec.introspectSchema()
+		},
+		nil,
+		ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "description":
+				return ec.fieldContext___Schema_description(ctx, field)
+			case "types":
+				return ec.fieldContext___Schema_types(ctx, field)
+			case "queryType":
+				return ec.fieldContext___Schema_queryType(ctx, field)
+			case "mutationType":
+				return ec.fieldContext___Schema_mutationType(ctx, field)
+			case "subscriptionType":
+				return ec.fieldContext___Schema_subscriptionType(ctx, field)
+			case "directives":
+				return ec.fieldContext___Schema_directives(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Subscription__empty(ctx context.Context, field graphql.CollectedField) (ret func(ctx context.Context) graphql.Marshaler) {
+	return graphql.ResolveFieldStream(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Subscription__empty,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Subscription().Empty(ctx)
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Subscription__empty(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Subscription",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_id(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Tenant_id,
+		func(ctx context.Context) (any, error) {
+			return obj.ID, nil
+		},
+		nil,
+		ec.marshalNID2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Tenant_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_name(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Tenant_name,
+		func(ctx context.Context) (any, error) {
+			return obj.Name, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Tenant_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_slug(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Tenant_slug,
+		func(ctx context.Context) (any, error) {
+			return obj.Slug, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Tenant_slug(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_plan(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Tenant_plan,
+		func(ctx context.Context) (any, error) {
+			return obj.Plan, nil
+		},
+		nil,
+		ec.marshalNTenantPlan2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantPlan,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Tenant_plan(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			return nil, errors.New("field of type TenantPlan does not have child fields")
 		},
@@ -2300,131 +5396,284 @@ func (ec *executionContext) fieldContext_Tenant_plan(_ context.Context, field gr
 	return fc, nil
 }
 
-func (ec *executionContext) _Tenant_isolationMode(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+func (ec *executionContext) _Tenant_isolationMode(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Tenant_isolationMode,
+		func(ctx context.Context) (any, error) {
+			return obj.IsolationMode, nil
+		},
+		nil,
+		ec.marshalNTenantIsolationMode2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantIsolationMode,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Tenant_isolationMode(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type TenantIsolationMode does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_status(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Tenant_status,
+		func(ctx context.Context) (any, error) {
+			return obj.Status, nil
+		},
+		nil,
+		ec.marshalNTenantStatus2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantStatus,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Tenant_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type TenantStatus does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_members(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Tenant_members,
+		func(ctx context.Context) (any, error) {
+			return obj.Members, nil
+		},
+		nil,
+		ec.marshalNMembership2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Tenant_members(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_owners(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Tenant_owners,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Tenant().Owners(ctx, obj)
+		},
+		func(ctx context.Context, next graphql.Resolver) graphql.Resolver {
+			directive0 := next
+
+			directive1 := func(ctx context.Context) (any, error) {
+				if ec.directives.Trace == nil {
+					var zeroVal []*model.Membership
+					return zeroVal, errors.New("directive trace is not implemented")
+				}
+				return ec.directives.Trace(ctx, obj, directive0, nil)
+			}
+
+			next = directive1
+			return next
+		},
+		ec.marshalNMembership2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Tenant_owners(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Membership_id(ctx, field)
+			case "user":
+				return ec.fieldContext_Membership_user(ctx, field)
+			case "tenant":
+				return ec.fieldContext_Membership_tenant(ctx, field)
+			case "role":
+				return ec.fieldContext_Membership_role(ctx, field)
+			case "status":
+				return ec.fieldContext_Membership_status(ctx, field)
+			case "joinedAt":
+				return ec.fieldContext_Membership_joinedAt(ctx, field)
+			case "invitedBy":
+				return ec.fieldContext_Membership_invitedBy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_memberCount(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Tenant_isolationMode,
+		ec.fieldContext_Tenant_memberCount,
 		func(ctx context.Context) (any, error) {
-			return obj.IsolationMode, nil
+			return obj.MemberCount, nil
 		},
 		nil,
-		ec.marshalNTenantIsolationMode2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantIsolationMode,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Tenant_isolationMode(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Tenant_memberCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Tenant",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type TenantIsolationMode does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Tenant_status(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+func (ec *executionContext) _Tenant_billingEmail(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Tenant_status,
+		ec.fieldContext_Tenant_billingEmail,
 		func(ctx context.Context) (any, error) {
-			return obj.Status, nil
+			return ec.resolvers.Tenant().BillingEmail(ctx, obj)
 		},
 		nil,
-		ec.marshalNTenantStatus2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantStatus,
-		true,
+		ec.marshalOString2ᚖstring,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Tenant_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Tenant_billingEmail(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Tenant",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type TenantStatus does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Tenant_members(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+func (ec *executionContext) _Tenant_myRole(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Tenant_members,
+		ec.fieldContext_Tenant_myRole,
 		func(ctx context.Context) (any, error) {
-			return obj.Members, nil
+			return obj.MyRole, nil
 		},
 		nil,
-		ec.marshalNMembership2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipᚄ,
-		true,
+		ec.marshalOMembershipRole2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipRole,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Tenant_members(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Tenant_myRole(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Tenant",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Membership_id(ctx, field)
-			case "user":
-				return ec.fieldContext_Membership_user(ctx, field)
-			case "tenant":
-				return ec.fieldContext_Membership_tenant(ctx, field)
-			case "role":
-				return ec.fieldContext_Membership_role(ctx, field)
-			case "joinedAt":
-				return ec.fieldContext_Membership_joinedAt(ctx, field)
-			case "invitedBy":
-				return ec.fieldContext_Membership_invitedBy(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Membership", field.Name)
+			return nil, errors.New("field of type MembershipRole does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Tenant_memberCount(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
+func (ec *executionContext) _Tenant_canLeave(ctx context.Context, field graphql.CollectedField, obj *model.Tenant) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Tenant_memberCount,
+		ec.fieldContext_Tenant_canLeave,
 		func(ctx context.Context) (any, error) {
-			return obj.MemberCount, nil
+			return ec.resolvers.Tenant().CanLeave(ctx, obj)
 		},
 		nil,
-		ec.marshalNInt2int,
+		ec.marshalNLeaveEligibility2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐLeaveEligibility,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Tenant_memberCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Tenant_canLeave(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Tenant",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "allowed":
+				return ec.fieldContext_LeaveEligibility_allowed(ctx, field)
+			case "reason":
+				return ec.fieldContext_LeaveEligibility_reason(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type LeaveEligibility", field.Name)
 		},
 	}
 	return fc, nil
@@ -2488,6 +5737,64 @@ func (ec *executionContext) fieldContext_Tenant_updatedAt(_ context.Context, fie
 	return fc, nil
 }
 
+func (ec *executionContext) _TenantRoleCount_role(ctx context.Context, field graphql.CollectedField, obj *model.TenantRoleCount) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_TenantRoleCount_role,
+		func(ctx context.Context) (any, error) {
+			return obj.Role, nil
+		},
+		nil,
+		ec.marshalNMembershipRole2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipRole,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_TenantRoleCount_role(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TenantRoleCount",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type MembershipRole does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TenantRoleCount_count(ctx context.Context, field graphql.CollectedField, obj *model.TenantRoleCount) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_TenantRoleCount_count,
+		func(ctx context.Context) (any, error) {
+			return obj.Count, nil
+		},
+		nil,
+		ec.marshalNInt2int,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_TenantRoleCount_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TenantRoleCount",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _User_id(ctx context.Context, field graphql.CollectedField, obj *model.User) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -4220,6 +7527,74 @@ func (ec *executionContext) unmarshalInputInviteMemberInput(ctx context.Context,
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputOwnershipSuccessor(ctx context.Context, obj any) (model.OwnershipSuccessor, error) {
+	var it model.OwnershipSuccessor
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"tenantId", "membershipId"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "tenantId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("tenantId"))
+			data, err := ec.unmarshalNID2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.TenantID = data
+		case "membershipId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("membershipId"))
+			data, err := ec.unmarshalNID2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.MembershipID = data
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputPlanChange(ctx context.Context, obj any) (model.PlanChange, error) {
+	var it model.PlanChange
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"tenantId", "plan"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "tenantId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("tenantId"))
+			data, err := ec.unmarshalNID2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.TenantID = data
+		case "plan":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("plan"))
+			data, err := ec.unmarshalNTenantPlan2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantPlan(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Plan = data
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputUpdateProfileInput(ctx context.Context, obj any) (model.UpdateProfileInput, error) {
 	var it model.UpdateProfileInput
 	asMap := map[string]any{}
@@ -4227,7 +7602,7 @@ func (ec *executionContext) unmarshalInputUpdateProfileInput(ctx context.Context
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"name", "avatarUrl"}
+	fieldsInOrder := [...]string{"name", "avatarUrl", "clearAvatar"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -4248,6 +7623,13 @@ func (ec *executionContext) unmarshalInputUpdateProfileInput(ctx context.Context
 				return it, err
 			}
 			it.AvatarURL = data
+		case "clearAvatar":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("clearAvatar"))
+			data, err := ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ClearAvatar = data
 		}
 	}
 
@@ -4288,20 +7670,213 @@ func (ec *executionContext) unmarshalInputUpdateTenantInput(ctx context.Context,
 			if err != nil {
 				return it, err
 			}
-			it.Status = data
+			it.Status = data
+		}
+	}
+
+	return it, nil
+}
+
+// endregion **************************** input.gotpl *****************************
+
+// region    ************************** interface.gotpl ***************************
+
+// endregion ************************** interface.gotpl ***************************
+
+// region    **************************** object.gotpl ****************************
+
+var deleteTenantResultImplementors = []string{"DeleteTenantResult"}
+
+func (ec *executionContext) _DeleteTenantResult(ctx context.Context, sel ast.SelectionSet, obj *model.DeleteTenantResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, deleteTenantResultImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DeleteTenantResult")
+		case "success":
+			out.Values[i] = ec._DeleteTenantResult_success(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "tenantId":
+			out.Values[i] = ec._DeleteTenantResult_tenantId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "memberCount":
+			out.Values[i] = ec._DeleteTenantResult_memberCount(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var deletionInfoImplementors = []string{"DeletionInfo"}
+
+func (ec *executionContext) _DeletionInfo(ctx context.Context, sel ast.SelectionSet, obj *model.DeletionInfo) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, deletionInfoImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DeletionInfo")
+		case "deletedAt":
+			out.Values[i] = ec._DeletionInfo_deletedAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "deletedBy":
+			out.Values[i] = ec._DeletionInfo_deletedBy(ctx, field, obj)
+		case "reason":
+			out.Values[i] = ec._DeletionInfo_reason(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var leaveEligibilityImplementors = []string{"LeaveEligibility"}
+
+func (ec *executionContext) _LeaveEligibility(ctx context.Context, sel ast.SelectionSet, obj *model.LeaveEligibility) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, leaveEligibilityImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("LeaveEligibility")
+		case "allowed":
+			out.Values[i] = ec._LeaveEligibility_allowed(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "reason":
+			out.Values[i] = ec._LeaveEligibility_reason(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
 		}
 	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
 
-	return it, nil
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
 }
 
-// endregion **************************** input.gotpl *****************************
+var memberRemovalResultImplementors = []string{"MemberRemovalResult"}
 
-// region    ************************** interface.gotpl ***************************
+func (ec *executionContext) _MemberRemovalResult(ctx context.Context, sel ast.SelectionSet, obj *model.MemberRemovalResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, memberRemovalResultImplementors)
 
-// endregion ************************** interface.gotpl ***************************
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("MemberRemovalResult")
+		case "success":
+			out.Values[i] = ec._MemberRemovalResult_success(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "tenantId":
+			out.Values[i] = ec._MemberRemovalResult_tenantId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "membershipId":
+			out.Values[i] = ec._MemberRemovalResult_membershipId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "userId":
+			out.Values[i] = ec._MemberRemovalResult_userId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "remainingMemberCount":
+			out.Values[i] = ec._MemberRemovalResult_remainingMemberCount(ctx, field, obj)
+		case "remainingOwnerCount":
+			out.Values[i] = ec._MemberRemovalResult_remainingOwnerCount(ctx, field, obj)
+		case "invitesHandedOff":
+			out.Values[i] = ec._MemberRemovalResult_invitesHandedOff(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
 
-// region    **************************** object.gotpl ****************************
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
 
 var membershipImplementors = []string{"Membership"}
 
@@ -4317,30 +7892,97 @@ func (ec *executionContext) _Membership(ctx context.Context, sel ast.SelectionSe
 		case "id":
 			out.Values[i] = ec._Membership_id(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "user":
-			out.Values[i] = ec._Membership_user(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Membership_user(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
 			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 		case "tenant":
 			out.Values[i] = ec._Membership_tenant(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "role":
 			out.Values[i] = ec._Membership_role(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "status":
+			out.Values[i] = ec._Membership_status(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "joinedAt":
 			out.Values[i] = ec._Membership_joinedAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "invitedBy":
-			out.Values[i] = ec._Membership_invitedBy(ctx, field, obj)
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Membership_invitedBy(ctx, field, obj)
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4401,6 +8043,20 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "restoreAccount":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_restoreAccount(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "revokeUserTokens":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_revokeUserTokens(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "createTenant":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_createTenant(ctx, field)
@@ -4415,6 +8071,20 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "renameTenant":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_renameTenant(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "setTenantBillingEmail":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_setTenantBillingEmail(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "deleteTenant":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_deleteTenant(ctx, field)
@@ -4422,34 +8092,219 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "inviteMember":
-			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Mutation_inviteMember(ctx, field)
-			})
+		case "restoreTenant":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_restoreTenant(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "suspendTenant":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_suspendTenant(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "unsuspendTenant":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_unsuspendTenant(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "inviteMember":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_inviteMember(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "inviteByEmail":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_inviteByEmail(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "updateMemberRole":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_updateMemberRole(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "updateMemberRoleByUser":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_updateMemberRoleByUser(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "removeMember":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_removeMember(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "leaveTenant":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_leaveTenant(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "reassignInvites":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_reassignInvites(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "createInviteToken":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_createInviteToken(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "acceptInviteByToken":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_acceptInviteByToken(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "updateTenantPlans":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_updateTenantPlans(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "offboardUser":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_offboardUser(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var offboardUserResultImplementors = []string{"OffboardUserResult"}
+
+func (ec *executionContext) _OffboardUserResult(ctx context.Context, sel ast.SelectionSet, obj *model.OffboardUserResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, offboardUserResultImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("OffboardUserResult")
+		case "userId":
+			out.Values[i] = ec._OffboardUserResult_userId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "transferredOwnerships":
+			out.Values[i] = ec._OffboardUserResult_transferredOwnerships(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "removedMembershipCount":
+			out.Values[i] = ec._OffboardUserResult_removedMembershipCount(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "invitesHandedOff":
+			out.Values[i] = ec._OffboardUserResult_invitesHandedOff(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var planChangeResultImplementors = []string{"PlanChangeResult"}
+
+func (ec *executionContext) _PlanChangeResult(ctx context.Context, sel ast.SelectionSet, obj *model.PlanChangeResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, planChangeResultImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PlanChangeResult")
+		case "tenantId":
+			out.Values[i] = ec._PlanChangeResult_tenantId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "plan":
+			out.Values[i] = ec._PlanChangeResult_plan(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "updateMemberRole":
-			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Mutation_updateMemberRole(ctx, field)
-			})
+		case "applied":
+			out.Values[i] = ec._PlanChangeResult_applied(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "removeMember":
-			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Mutation_removeMember(ctx, field)
-			})
+		case "overMemberLimit":
+			out.Values[i] = ec._PlanChangeResult_overMemberLimit(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "leaveTenant":
-			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Mutation_leaveTenant(ctx, field)
-			})
+		case "memberCount":
+			out.Values[i] = ec._PlanChangeResult_memberCount(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "error":
+			out.Values[i] = ec._PlanChangeResult_error(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4551,6 +8406,25 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "userDeletion":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_userDeletion(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "tenant":
 			field := field
@@ -4589,6 +8463,28 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "suggestTenantSlug":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_suggestTenantSlug(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "myTenants":
 			field := field
@@ -4611,6 +8507,50 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "myInvitations":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_myInvitations(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "myTenantSummary":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_myTenantSummary(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "tenantMembers":
 			field := field
@@ -4633,6 +8573,66 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "pendingInvitations":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_pendingInvitations(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "membership":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_membership(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "tenantDeletion":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_tenantDeletion(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "__type":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
@@ -4699,50 +8699,201 @@ func (ec *executionContext) _Tenant(ctx context.Context, sel ast.SelectionSet, o
 		case "id":
 			out.Values[i] = ec._Tenant_id(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "name":
 			out.Values[i] = ec._Tenant_name(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "slug":
 			out.Values[i] = ec._Tenant_slug(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "plan":
 			out.Values[i] = ec._Tenant_plan(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "isolationMode":
 			out.Values[i] = ec._Tenant_isolationMode(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "status":
 			out.Values[i] = ec._Tenant_status(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "members":
 			out.Values[i] = ec._Tenant_members(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "owners":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Tenant_owners(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
 			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 		case "memberCount":
 			out.Values[i] = ec._Tenant_memberCount(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "billingEmail":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Tenant_billingEmail(ctx, field, obj)
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "myRole":
+			out.Values[i] = ec._Tenant_myRole(ctx, field, obj)
+		case "canLeave":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Tenant_canLeave(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
 			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 		case "createdAt":
 			out.Values[i] = ec._Tenant_createdAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "updatedAt":
+			out.Values[i] = ec._Tenant_updatedAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var tenantRoleCountImplementors = []string{"TenantRoleCount"}
+
+func (ec *executionContext) _TenantRoleCount(ctx context.Context, sel ast.SelectionSet, obj *model.TenantRoleCount) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, tenantRoleCountImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("TenantRoleCount")
+		case "role":
+			out.Values[i] = ec._TenantRoleCount_role(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "updatedAt":
-			out.Values[i] = ec._Tenant_updatedAt(ctx, field, obj)
+		case "count":
+			out.Values[i] = ec._TenantRoleCount_count(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -5204,6 +9355,20 @@ func (ec *executionContext) marshalNDateTime2timeᚐTime(ctx context.Context, se
 	return res
 }
 
+func (ec *executionContext) marshalNDeleteTenantResult2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐDeleteTenantResult(ctx context.Context, sel ast.SelectionSet, v model.DeleteTenantResult) graphql.Marshaler {
+	return ec._DeleteTenantResult(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNDeleteTenantResult2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐDeleteTenantResult(ctx context.Context, sel ast.SelectionSet, v *model.DeleteTenantResult) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._DeleteTenantResult(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNID2string(ctx context.Context, v any) (string, error) {
 	res, err := graphql.UnmarshalID(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -5241,6 +9406,34 @@ func (ec *executionContext) unmarshalNInviteMemberInput2githubᚗcomᚋyourusern
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) marshalNLeaveEligibility2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐLeaveEligibility(ctx context.Context, sel ast.SelectionSet, v model.LeaveEligibility) graphql.Marshaler {
+	return ec._LeaveEligibility(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNLeaveEligibility2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐLeaveEligibility(ctx context.Context, sel ast.SelectionSet, v *model.LeaveEligibility) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._LeaveEligibility(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNMemberRemovalResult2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMemberRemovalResult(ctx context.Context, sel ast.SelectionSet, v model.MemberRemovalResult) graphql.Marshaler {
+	return ec._MemberRemovalResult(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNMemberRemovalResult2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMemberRemovalResult(ctx context.Context, sel ast.SelectionSet, v *model.MemberRemovalResult) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._MemberRemovalResult(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNMembership2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembership(ctx context.Context, sel ast.SelectionSet, v model.Membership) graphql.Marshaler {
 	return ec._Membership(ctx, sel, &v)
 }
@@ -5309,6 +9502,124 @@ func (ec *executionContext) marshalNMembershipRole2githubᚗcomᚋyourusername
 	return v
 }
 
+func (ec *executionContext) unmarshalNMembershipStatus2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipStatus(ctx context.Context, v any) (model.MembershipStatus, error) {
+	var res model.MembershipStatus
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNMembershipStatus2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipStatus(ctx context.Context, sel ast.SelectionSet, v model.MembershipStatus) graphql.Marshaler {
+	return v
+}
+
+func (ec *executionContext) marshalNOffboardUserResult2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐOffboardUserResult(ctx context.Context, sel ast.SelectionSet, v model.OffboardUserResult) graphql.Marshaler {
+	return ec._OffboardUserResult(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNOffboardUserResult2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐOffboardUserResult(ctx context.Context, sel ast.SelectionSet, v *model.OffboardUserResult) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._OffboardUserResult(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNOwnershipSuccessor2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐOwnershipSuccessorᚄ(ctx context.Context, v any) ([]*model.OwnershipSuccessor, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]*model.OwnershipSuccessor, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNOwnershipSuccessor2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐOwnershipSuccessor(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) unmarshalNOwnershipSuccessor2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐOwnershipSuccessor(ctx context.Context, v any) (*model.OwnershipSuccessor, error) {
+	res, err := ec.unmarshalInputOwnershipSuccessor(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalNPlanChange2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐPlanChangeᚄ(ctx context.Context, v any) ([]*model.PlanChange, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]*model.PlanChange, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNPlanChange2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐPlanChange(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) unmarshalNPlanChange2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐPlanChange(ctx context.Context, v any) (*model.PlanChange, error) {
+	res, err := ec.unmarshalInputPlanChange(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNPlanChangeResult2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐPlanChangeResultᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.PlanChangeResult) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNPlanChangeResult2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐPlanChangeResult(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNPlanChangeResult2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐPlanChangeResult(ctx context.Context, sel ast.SelectionSet, v *model.PlanChangeResult) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._PlanChangeResult(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNString2string(ctx context.Context, v any) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -5403,6 +9714,60 @@ func (ec *executionContext) marshalNTenantPlan2githubᚗcomᚋyourusernameᚋgrg
 	return v
 }
 
+func (ec *executionContext) marshalNTenantRoleCount2ᚕᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantRoleCountᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.TenantRoleCount) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNTenantRoleCount2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantRoleCount(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNTenantRoleCount2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantRoleCount(ctx context.Context, sel ast.SelectionSet, v *model.TenantRoleCount) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._TenantRoleCount(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNTenantStatus2githubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐTenantStatus(ctx context.Context, v any) (model.TenantStatus, error) {
 	var res model.TenantStatus
 	err := res.UnmarshalGQL(v)
@@ -5730,6 +10095,72 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
+func (ec *executionContext) marshalODeletionInfo2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐDeletionInfo(ctx context.Context, sel ast.SelectionSet, v *model.DeletionInfo) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._DeletionInfo(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalOID2ᚖstring(ctx context.Context, v any) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalID(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOID2ᚖstring(ctx context.Context, sel ast.SelectionSet, v *string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	_ = sel
+	_ = ctx
+	res := graphql.MarshalID(*v)
+	return res
+}
+
+func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v any) (*int, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalInt(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOInt2ᚖint(ctx context.Context, sel ast.SelectionSet, v *int) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	_ = sel
+	_ = ctx
+	res := graphql.MarshalInt(*v)
+	return res
+}
+
+func (ec *executionContext) unmarshalOLeaveBlockedReason2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐLeaveBlockedReason(ctx context.Context, v any) (*model.LeaveBlockedReason, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.LeaveBlockedReason)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOLeaveBlockedReason2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐLeaveBlockedReason(ctx context.Context, sel ast.SelectionSet, v *model.LeaveBlockedReason) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
+func (ec *executionContext) marshalOMembership2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembership(ctx context.Context, sel ast.SelectionSet, v *model.Membership) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Membership(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOMembershipRole2ᚖgithubᚗcomᚋyourusernameᚋgrgnᚑstackᚋservicesᚋcoreᚋsharedᚋgeneratedᚋgraphqlᚋmodelᚐMembershipRole(ctx context.Context, v any) (*model.MembershipRole, error) {
 	if v == nil {
 		return nil, nil
@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	identityRepo "github.com/yourusername/grgn-stack/services/core/identity/repository"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+	tenantRepo "github.com/yourusername/grgn-stack/services/core/tenant/repository"
+	tenantSvc "github.com/yourusername/grgn-stack/services/core/tenant/service"
+)
+
+// newMembershipClient serves the schema over a tenant where admin-1 is an
+// active ADMIN and invitee-1 holds the pending invite m-pending.
+func newMembershipClient() *client.Client {
+	memberships := tenantRepo.NewMockMembershipRepository()
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Acme"}
+	memberships.AddMembership(&model.Membership{
+		ID: "m-admin", Role: model.MembershipRoleAdmin, Status: model.MembershipStatusActive,
+		User: &model.User{ID: "admin-1"}, Tenant: tenant,
+	})
+	memberships.AddMembership(&model.Membership{
+		ID: "m-pending", Role: model.MembershipRoleMember, Status: model.MembershipStatusPending,
+		User: &model.User{ID: "invitee-1"}, Tenant: tenant,
+	})
+
+	svc := tenantSvc.NewTenantService(tenantRepo.NewMockTenantRepository(), memberships, identityRepo.NewMockUserRepository(), shared.NewMockUnitOfWork())
+	cfg := Config{Resolvers: &Resolver{TenantService: svc}}
+	cfg.Directives.Trace = shared.TraceDirective(false, nil, nil)
+	server := handler.NewDefaultServer(NewExecutableSchema(cfg))
+	server.SetErrorPresenter(shared.ErrorPresenter)
+	return client.New(server)
+}
+
+// asUser sends the request as the given authenticated user.
+func asUser(userID string) client.Option {
+	return func(bd *client.Request) {
+		bd.HTTP = bd.HTTP.WithContext(auth.WithUserID(bd.HTTP.Context(), userID))
+	}
+}
+
+// errorCodes extracts the extension codes from a raw GraphQL response.
+func errorCodes(t *testing.T, resp *client.Response) []any {
+	t.Helper()
+	var errs []struct {
+		Extensions map[string]any `json:"extensions"`
+	}
+	if len(resp.Errors) > 0 {
+		require.NoError(t, json.Unmarshal(resp.Errors, &errs))
+	}
+	codes := make([]any, len(errs))
+	for i, e := range errs {
+		codes[i] = e.Extensions["code"]
+	}
+	return codes
+}
+
+func TestQueryMembership_Authorization(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		userID string
+		id     string
+		code   string
+	}{
+		{"tenant member", "admin-1", "m-pending", ""},
+		{"membership's own user", "invitee-1", "m-pending", ""},
+		{"unrelated user", "stranger-1", "m-pending", errors.CodeForbidden},
+		{"missing membership", "admin-1", "m-missing", errors.CodeNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			c := newMembershipClient()
+
+			query := `query($id: ID!) { membership(id: $id) { id status user { id } } }`
+
+			// Act
+			resp, err := c.RawPost(query, client.Var("id", tc.id), asUser(tc.userID))
+
+			// Assert
+			require.NoError(t, err)
+			if tc.code != "" {
+				assert.Equal(t, []any{tc.code}, errorCodes(t, resp))
+				assert.Equal(t, map[string]any{"membership": nil}, resp.Data)
+				return
+			}
+
+			assert.Empty(t, errorCodes(t, resp))
+			assert.Equal(t, map[string]any{"membership": map[string]any{
+				"id": "m-pending", "status": "PENDING", "user": map[string]any{"id": "invitee-1"},
+			}}, resp.Data)
+		})
+	}
+}
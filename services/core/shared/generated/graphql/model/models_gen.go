@@ -16,23 +16,77 @@ type CreateTenantInput struct {
 	Plan *TenantPlan `json:"plan,omitempty"`
 }
 
+type DeleteTenantResult struct {
+	Success     bool   `json:"success"`
+	TenantID    string `json:"tenantId"`
+	MemberCount *int   `json:"memberCount,omitempty"`
+}
+
+type DeletionInfo struct {
+	DeletedAt time.Time `json:"deletedAt"`
+	DeletedBy *string   `json:"deletedBy,omitempty"`
+	Reason    *string   `json:"reason,omitempty"`
+}
+
 type InviteMemberInput struct {
 	Email string          `json:"email"`
 	Role  *MembershipRole `json:"role,omitempty"`
 }
 
+type LeaveEligibility struct {
+	Allowed bool                `json:"allowed"`
+	Reason  *LeaveBlockedReason `json:"reason,omitempty"`
+}
+
+type MemberRemovalResult struct {
+	Success              bool   `json:"success"`
+	TenantID             string `json:"tenantId"`
+	MembershipID         string `json:"membershipId"`
+	UserID               string `json:"userId"`
+	RemainingMemberCount *int   `json:"remainingMemberCount,omitempty"`
+	RemainingOwnerCount  *int   `json:"remainingOwnerCount,omitempty"`
+	InvitesHandedOff     int    `json:"invitesHandedOff"`
+}
+
 type Membership struct {
-	ID        string         `json:"id"`
-	User      *User          `json:"user"`
-	Tenant    *Tenant        `json:"tenant"`
-	Role      MembershipRole `json:"role"`
-	JoinedAt  time.Time      `json:"joinedAt"`
-	InvitedBy *User          `json:"invitedBy,omitempty"`
+	ID        string           `json:"id"`
+	User      *User            `json:"user"`
+	Tenant    *Tenant          `json:"tenant"`
+	Role      MembershipRole   `json:"role"`
+	Status    MembershipStatus `json:"status"`
+	JoinedAt  time.Time        `json:"joinedAt"`
+	InvitedBy *User            `json:"invitedBy,omitempty"`
 }
 
 type Mutation struct {
 }
 
+type OffboardUserResult struct {
+	UserID                 string        `json:"userId"`
+	TransferredOwnerships  []*Membership `json:"transferredOwnerships"`
+	RemovedMembershipCount int           `json:"removedMembershipCount"`
+	InvitesHandedOff       int           `json:"invitesHandedOff"`
+}
+
+type OwnershipSuccessor struct {
+	TenantID     string `json:"tenantId"`
+	MembershipID string `json:"membershipId"`
+}
+
+type PlanChange struct {
+	TenantID string     `json:"tenantId"`
+	Plan     TenantPlan `json:"plan"`
+}
+
+type PlanChangeResult struct {
+	TenantID        string     `json:"tenantId"`
+	Plan            TenantPlan `json:"plan"`
+	Applied         bool       `json:"applied"`
+	OverMemberLimit bool       `json:"overMemberLimit"`
+	MemberCount     int        `json:"memberCount"`
+	Error           *string    `json:"error,omitempty"`
+}
+
 type Query struct {
 }
 
@@ -47,14 +101,24 @@ type Tenant struct {
 	IsolationMode TenantIsolationMode `json:"isolationMode"`
 	Status        TenantStatus        `json:"status"`
 	Members       []*Membership       `json:"members"`
+	Owners        []*Membership       `json:"owners"`
 	MemberCount   int                 `json:"memberCount"`
+	BillingEmail  *string             `json:"billingEmail,omitempty"`
+	MyRole        *MembershipRole     `json:"myRole,omitempty"`
+	CanLeave      *LeaveEligibility   `json:"canLeave"`
 	CreatedAt     time.Time           `json:"createdAt"`
 	UpdatedAt     time.Time           `json:"updatedAt"`
 }
 
+type TenantRoleCount struct {
+	Role  MembershipRole `json:"role"`
+	Count int            `json:"count"`
+}
+
 type UpdateProfileInput struct {
-	Name      *string `json:"name,omitempty"`
-	AvatarURL *string `json:"avatarUrl,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	AvatarURL   *string `json:"avatarUrl,omitempty"`
+	ClearAvatar *bool   `json:"clearAvatar,omitempty"`
 }
 
 type UpdateTenantInput struct {
@@ -73,6 +137,61 @@ type User struct {
 	UpdatedAt time.Time  `json:"updatedAt"`
 }
 
+type LeaveBlockedReason string
+
+const (
+	LeaveBlockedReasonNotMember LeaveBlockedReason = "NOT_MEMBER"
+	LeaveBlockedReasonSoleOwner LeaveBlockedReason = "SOLE_OWNER"
+)
+
+var AllLeaveBlockedReason = []LeaveBlockedReason{
+	LeaveBlockedReasonNotMember,
+	LeaveBlockedReasonSoleOwner,
+}
+
+func (e LeaveBlockedReason) IsValid() bool {
+	switch e {
+	case LeaveBlockedReasonNotMember, LeaveBlockedReasonSoleOwner:
+		return true
+	}
+	return false
+}
+
+func (e LeaveBlockedReason) String() string {
+	return string(e)
+}
+
+func (e *LeaveBlockedReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LeaveBlockedReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LeaveBlockedReason", str)
+	}
+	return nil
+}
+
+func (e LeaveBlockedReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *LeaveBlockedReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e LeaveBlockedReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type MembershipRole string
 
 const (
@@ -132,6 +251,61 @@ func (e MembershipRole) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type MembershipStatus string
+
+const (
+	MembershipStatusActive  MembershipStatus = "ACTIVE"
+	MembershipStatusPending MembershipStatus = "PENDING"
+)
+
+var AllMembershipStatus = []MembershipStatus{
+	MembershipStatusActive,
+	MembershipStatusPending,
+}
+
+func (e MembershipStatus) IsValid() bool {
+	switch e {
+	case MembershipStatusActive, MembershipStatusPending:
+		return true
+	}
+	return false
+}
+
+func (e MembershipStatus) String() string {
+	return string(e)
+}
+
+func (e *MembershipStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MembershipStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MembershipStatus", str)
+	}
+	return nil
+}
+
+func (e MembershipStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *MembershipStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e MembershipStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type TenantIsolationMode string
 
 const (
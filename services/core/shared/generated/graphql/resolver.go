@@ -1,7 +1,11 @@
 package graphql
 
 import (
+	"context"
+
+	"github.com/yourusername/grgn-stack/pkg/errors"
 	identitySvc "github.com/yourusername/grgn-stack/services/core/identity/service"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 	tenantSvc "github.com/yourusername/grgn-stack/services/core/tenant/service"
 )
 
@@ -20,3 +24,38 @@ type Resolver struct {
 func stringPtr(s string) *string {
 	return &s
 }
+
+// successorsByTenant maps offboarding successors by tenant ID, rejecting
+// tenants listed more than once.
+func successorsByTenant(successors []*model.OwnershipSuccessor) (map[string]string, error) {
+	byTenant := make(map[string]string, len(successors))
+	for _, successor := range successors {
+		if _, dup := byTenant[successor.TenantID]; dup {
+			return nil, errors.NewValidationError("ownershipSuccessors", "tenant "+successor.TenantID+" is listed more than once")
+		}
+		byTenant[successor.TenantID] = successor.MembershipID
+	}
+	return byTenant, nil
+}
+
+// loadUser returns the full user ref points to, batched through the
+// request's UserLoader. Memberships carry only the user fields their query
+// returned, so ref is returned as is when the request has no loader or the
+// user is gone.
+func loadUser(ctx context.Context, ref *model.User) (*model.User, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	loader, ok := identitySvc.UserLoaderFromContext(ctx)
+	if !ok {
+		return ref, nil
+	}
+	user, err := loader.Load(ctx, ref.ID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return ref, nil
+	}
+	return user, nil
+}
@@ -0,0 +1,35 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+)
+
+func TestQueryMyTenantSummary_ListsEveryRole(t *testing.T) {
+	// Arrange
+	cfg := Config{Resolvers: &Resolver{TenantService: stubTenantService{}}}
+	cfg.Directives.Trace = shared.TraceDirective(false, nil, nil)
+	c := client.New(handler.NewDefaultServer(NewExecutableSchema(cfg)))
+
+	var resp struct {
+		MyTenantSummary []struct {
+			Role  string
+			Count int
+		}
+	}
+
+	// Act
+	err := c.Post(`{ myTenantSummary { role count } }`, &resp)
+
+	// Assert - roles the user doesn't hold are reported as zero
+	require.NoError(t, err)
+	assert.Equal(t, []struct {
+		Role  string
+		Count int
+	}{{"OWNER", 2}, {"ADMIN", 0}, {"MEMBER", 5}, {"VIEWER", 0}}, resp.MyTenantSummary)
+}
@@ -0,0 +1,64 @@
+package graphql
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+	tenantSvc "github.com/yourusername/grgn-stack/services/core/tenant/service"
+)
+
+// stubTenantService answers the queries used below.
+// Methods not overridden panic via the nil embedded interface.
+type stubTenantService struct {
+	tenantSvc.ITenantService
+}
+
+func (s stubTenantService) GetMyTenants(ctx context.Context, limit, offset *int) ([]*model.Tenant, error) {
+	return []*model.Tenant{{ID: "tenant-1", Name: "Acme"}}, nil
+}
+
+func (s stubTenantService) GetTenantBySlug(ctx context.Context, slug string) (*model.Tenant, error) {
+	return &model.Tenant{ID: "tenant-1", Name: "Acme", Slug: slug}, nil
+}
+
+func (s stubTenantService) GetMyTenantRoleSummary(ctx context.Context) (map[model.MembershipRole]int, error) {
+	return map[model.MembershipRole]int{model.MembershipRoleOwner: 2, model.MembershipRoleMember: 5}, nil
+}
+
+// timingRecorder collects the timings it receives.
+type timingRecorder struct {
+	fields []string
+}
+
+func (r *timingRecorder) RecordTiming(ctx context.Context, timing shared.ResolverTiming) {
+	r.fields = append(r.fields, timing.Field)
+}
+
+func TestTraceDirective_OnlyTaggedResolversRecorded(t *testing.T) {
+	// Arrange - myTenants is tagged with @trace, tenantBySlug is not
+	recorder := &timingRecorder{}
+	cfg := Config{Resolvers: &Resolver{TenantService: stubTenantService{}}}
+	cfg.Directives.Trace = shared.TraceDirective(true, slog.Default(), recorder)
+	c := client.New(handler.NewDefaultServer(NewExecutableSchema(cfg)))
+
+	var resp struct {
+		MyTenants    []struct{ ID string }
+		TenantBySlug struct{ Slug string }
+	}
+
+	// Act
+	err := c.Post(`{ myTenants { id } tenantBySlug(slug: "acme") { slug } }`, &resp)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-1", resp.MyTenants[0].ID)
+	assert.Equal(t, "acme", resp.TenantBySlug.Slug)
+	assert.Equal(t, []string{"Query.myTenants"}, recorder.fields)
+}
@@ -11,14 +11,38 @@ import (
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 )
 
+// User is the resolver for the user field.
+func (r *membershipResolver) User(ctx context.Context, obj *model.Membership) (*model.User, error) {
+	return loadUser(ctx, obj.User)
+}
+
+// InvitedBy is the resolver for the invitedBy field.
+func (r *membershipResolver) InvitedBy(ctx context.Context, obj *model.Membership) (*model.User, error) {
+	return loadUser(ctx, obj.InvitedBy)
+}
+
 // UpdateProfile is the resolver for the updateProfile field.
 func (r *mutationResolver) UpdateProfile(ctx context.Context, input model.UpdateProfileInput) (*model.User, error) {
 	return r.UserService.UpdateProfile(ctx, input)
 }
 
 // DeleteAccount is the resolver for the deleteAccount field.
-func (r *mutationResolver) DeleteAccount(ctx context.Context) (bool, error) {
-	err := r.UserService.DeleteAccount(ctx)
+func (r *mutationResolver) DeleteAccount(ctx context.Context, reason *string) (bool, error) {
+	err := r.UserService.DeleteAccount(ctx, reason)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RestoreAccount is the resolver for the restoreAccount field.
+func (r *mutationResolver) RestoreAccount(ctx context.Context, userID string) (*model.User, error) {
+	return r.UserService.RestoreAccount(ctx, userID)
+}
+
+// RevokeUserTokens is the resolver for the revokeUserTokens field.
+func (r *mutationResolver) RevokeUserTokens(ctx context.Context, userID string) (bool, error) {
+	err := r.UserService.RevokeUserTokens(ctx, userID)
 	if err != nil {
 		return false, err
 	}
@@ -35,9 +59,34 @@ func (r *mutationResolver) UpdateTenant(ctx context.Context, id string, input mo
 	return r.TenantService.UpdateTenant(ctx, id, input)
 }
 
+// RenameTenant is the resolver for the renameTenant field.
+func (r *mutationResolver) RenameTenant(ctx context.Context, id string, name string, slug string) (*model.Tenant, error) {
+	return r.TenantService.RenameTenant(ctx, id, name, slug)
+}
+
+// SetTenantBillingEmail is the resolver for the setTenantBillingEmail field.
+func (r *mutationResolver) SetTenantBillingEmail(ctx context.Context, tenantID string, email string) (*model.Tenant, error) {
+	return r.TenantService.SetBillingEmail(ctx, tenantID, email)
+}
+
 // DeleteTenant is the resolver for the deleteTenant field.
-func (r *mutationResolver) DeleteTenant(ctx context.Context, id string) (bool, error) {
-	return r.TenantService.DeleteTenant(ctx, id)
+func (r *mutationResolver) DeleteTenant(ctx context.Context, id string, reason *string) (*model.DeleteTenantResult, error) {
+	return r.TenantService.DeleteTenant(ctx, id, reason)
+}
+
+// RestoreTenant is the resolver for the restoreTenant field.
+func (r *mutationResolver) RestoreTenant(ctx context.Context, id string) (*model.Tenant, error) {
+	return r.TenantService.RestoreTenant(ctx, id)
+}
+
+// SuspendTenant is the resolver for the suspendTenant field.
+func (r *mutationResolver) SuspendTenant(ctx context.Context, id string) (*model.Tenant, error) {
+	return r.TenantService.SuspendTenant(ctx, id)
+}
+
+// UnsuspendTenant is the resolver for the unsuspendTenant field.
+func (r *mutationResolver) UnsuspendTenant(ctx context.Context, id string) (*model.Tenant, error) {
+	return r.TenantService.UnsuspendTenant(ctx, id)
 }
 
 // InviteMember is the resolver for the inviteMember field.
@@ -45,21 +94,60 @@ func (r *mutationResolver) InviteMember(ctx context.Context, tenantID string, in
 	return r.TenantService.InviteMember(ctx, tenantID, input)
 }
 
+// InviteByEmail is the resolver for the inviteByEmail field.
+func (r *mutationResolver) InviteByEmail(ctx context.Context, tenantID string, input model.InviteMemberInput) (*model.Membership, error) {
+	return r.TenantService.InviteByEmail(ctx, tenantID, input)
+}
+
 // UpdateMemberRole is the resolver for the updateMemberRole field.
 func (r *mutationResolver) UpdateMemberRole(ctx context.Context, membershipID string, role model.MembershipRole) (*model.Membership, error) {
 	return r.TenantService.UpdateMemberRole(ctx, membershipID, role)
 }
 
+// UpdateMemberRoleByUser is the resolver for the updateMemberRoleByUser field.
+func (r *mutationResolver) UpdateMemberRoleByUser(ctx context.Context, tenantID string, userID string, role model.MembershipRole) (*model.Membership, error) {
+	return r.TenantService.UpdateMemberRoleByUser(ctx, tenantID, userID, role)
+}
+
 // RemoveMember is the resolver for the removeMember field.
-func (r *mutationResolver) RemoveMember(ctx context.Context, membershipID string) (bool, error) {
+func (r *mutationResolver) RemoveMember(ctx context.Context, membershipID string) (*model.MemberRemovalResult, error) {
 	return r.TenantService.RemoveMember(ctx, membershipID)
 }
 
 // LeaveTenant is the resolver for the leaveTenant field.
-func (r *mutationResolver) LeaveTenant(ctx context.Context, tenantID string) (bool, error) {
+func (r *mutationResolver) LeaveTenant(ctx context.Context, tenantID string) (*model.MemberRemovalResult, error) {
 	return r.TenantService.LeaveTenant(ctx, tenantID)
 }
 
+// ReassignInvites is the resolver for the reassignInvites field.
+func (r *mutationResolver) ReassignInvites(ctx context.Context, tenantID string, fromUserID string, toUserID string) (int, error) {
+	return r.TenantService.ReassignInvites(ctx, fromUserID, toUserID, tenantID)
+}
+
+// CreateInviteToken is the resolver for the createInviteToken field.
+func (r *mutationResolver) CreateInviteToken(ctx context.Context, membershipID string) (string, error) {
+	return r.TenantService.CreateInviteToken(ctx, membershipID)
+}
+
+// AcceptInviteByToken is the resolver for the acceptInviteByToken field.
+func (r *mutationResolver) AcceptInviteByToken(ctx context.Context, token string) (*model.Membership, error) {
+	return r.TenantService.AcceptInviteByToken(ctx, token)
+}
+
+// UpdateTenantPlans is the resolver for the updateTenantPlans field.
+func (r *mutationResolver) UpdateTenantPlans(ctx context.Context, changes []*model.PlanChange) ([]*model.PlanChangeResult, error) {
+	return r.TenantService.UpdateTenantPlans(ctx, changes)
+}
+
+// OffboardUser is the resolver for the offboardUser field.
+func (r *mutationResolver) OffboardUser(ctx context.Context, userID string, ownershipSuccessors []*model.OwnershipSuccessor) (*model.OffboardUserResult, error) {
+	successors, err := successorsByTenant(ownershipSuccessors)
+	if err != nil {
+		return nil, err
+	}
+	return r.TenantService.OffboardUser(ctx, userID, successors)
+}
+
 // Me is the resolver for the me field.
 func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
 	return r.UserService.GetCurrentUser(ctx)
@@ -70,6 +158,11 @@ func (r *queryResolver) User(ctx context.Context, id string) (*model.User, error
 	return r.UserService.GetUserByID(ctx, id)
 }
 
+// UserDeletion is the resolver for the userDeletion field.
+func (r *queryResolver) UserDeletion(ctx context.Context, userID string) (*model.DeletionInfo, error) {
+	return r.UserService.GetUserDeletion(ctx, userID)
+}
+
 // Tenant is the resolver for the tenant field.
 func (r *queryResolver) Tenant(ctx context.Context, id string) (*model.Tenant, error) {
 	return r.TenantService.GetTenant(ctx, id)
@@ -80,12 +173,75 @@ func (r *queryResolver) TenantBySlug(ctx context.Context, slug string) (*model.T
 	return r.TenantService.GetTenantBySlug(ctx, slug)
 }
 
+// SuggestTenantSlug is the resolver for the suggestTenantSlug field.
+func (r *queryResolver) SuggestTenantSlug(ctx context.Context, name string) (string, error) {
+	return r.TenantService.SuggestAvailableSlug(ctx, name)
+}
+
 // MyTenants is the resolver for the myTenants field.
-func (r *queryResolver) MyTenants(ctx context.Context) ([]*model.Tenant, error) {
-	return r.TenantService.GetMyTenants(ctx)
+func (r *queryResolver) MyTenants(ctx context.Context, limit *int, offset *int) ([]*model.Tenant, error) {
+	return r.TenantService.GetMyTenants(ctx, limit, offset)
+}
+
+// MyInvitations is the resolver for the myInvitations field.
+func (r *queryResolver) MyInvitations(ctx context.Context, limit *int, offset *int) ([]*model.Membership, error) {
+	return r.TenantService.GetMyInvitations(ctx, limit, offset)
+}
+
+// MyTenantSummary is the resolver for the myTenantSummary field.
+func (r *queryResolver) MyTenantSummary(ctx context.Context) ([]*model.TenantRoleCount, error) {
+	counts, err := r.TenantService.GetMyTenantRoleSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := make([]*model.TenantRoleCount, len(model.AllMembershipRole))
+	for i, role := range model.AllMembershipRole {
+		summary[i] = &model.TenantRoleCount{Role: role, Count: counts[role]}
+	}
+	return summary, nil
 }
 
 // TenantMembers is the resolver for the tenantMembers field.
 func (r *queryResolver) TenantMembers(ctx context.Context, tenantID string) ([]*model.Membership, error) {
 	return r.TenantService.GetTenantMembers(ctx, tenantID)
 }
+
+// PendingInvitations is the resolver for the pendingInvitations field.
+func (r *queryResolver) PendingInvitations(ctx context.Context, tenantID string) ([]*model.Membership, error) {
+	return r.TenantService.ListPendingInvitations(ctx, tenantID)
+}
+
+// Membership is the resolver for the membership field.
+func (r *queryResolver) Membership(ctx context.Context, id string) (*model.Membership, error) {
+	return r.TenantService.GetMembership(ctx, id)
+}
+
+// TenantDeletion is the resolver for the tenantDeletion field.
+func (r *queryResolver) TenantDeletion(ctx context.Context, tenantID string) (*model.DeletionInfo, error) {
+	return r.TenantService.GetTenantDeletion(ctx, tenantID)
+}
+
+// Owners is the resolver for the owners field.
+func (r *tenantResolver) Owners(ctx context.Context, obj *model.Tenant) ([]*model.Membership, error) {
+	return r.TenantService.GetTenantOwners(ctx, obj.ID)
+}
+
+// BillingEmail is the resolver for the billingEmail field.
+func (r *tenantResolver) BillingEmail(ctx context.Context, obj *model.Tenant) (*string, error) {
+	return r.TenantService.GetBillingEmail(ctx, obj), nil
+}
+
+// CanLeave is the resolver for the canLeave field.
+func (r *tenantResolver) CanLeave(ctx context.Context, obj *model.Tenant) (*model.LeaveEligibility, error) {
+	return r.TenantService.CanLeaveTenant(ctx, obj.ID)
+}
+
+// Membership returns MembershipResolver implementation.
+func (r *Resolver) Membership() MembershipResolver { return &membershipResolver{r} }
+
+// Tenant returns TenantResolver implementation.
+func (r *Resolver) Tenant() TenantResolver { return &tenantResolver{r} }
+
+type membershipResolver struct{ *Resolver }
+type tenantResolver struct{ *Resolver }
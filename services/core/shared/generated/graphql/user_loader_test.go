@@ -0,0 +1,147 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	identityRepo "github.com/yourusername/grgn-stack/services/core/identity/repository"
+	identitySvc "github.com/yourusername/grgn-stack/services/core/identity/service"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+	tenantRepo "github.com/yourusername/grgn-stack/services/core/tenant/repository"
+	tenantSvc "github.com/yourusername/grgn-stack/services/core/tenant/service"
+)
+
+// userQuery is a query the UserRepository ran.
+type userQuery struct {
+	cypher string
+	ids    []string
+}
+
+// userDB answers every read with an active user node for each requested ID
+// and records the queries.
+type userDB struct {
+	shared.IDatabase
+
+	mu      sync.Mutex
+	queries []userQuery
+}
+
+func (db *userDB) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return work(&userTx{db: db})
+}
+
+// userTx records the query and returns the users it asks for.
+type userTx struct {
+	neo4j.ManagedTransaction
+	db *userDB
+}
+
+func (tx *userTx) Run(_ context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	ids, _ := params["ids"].([]string)
+	tx.db.mu.Lock()
+	tx.db.queries = append(tx.db.queries, userQuery{cypher: cypher, ids: ids})
+	tx.db.mu.Unlock()
+
+	records := make([]*neo4j.Record, len(ids))
+	for i, id := range ids {
+		records[i] = &neo4j.Record{
+			Keys: []string{"u"},
+			Values: []any{neo4j.Node{Props: map[string]any{
+				"id": id, "email": id + "@example.com", "name": "Name of " + id, "status": "ACTIVE",
+			}}},
+		}
+	}
+	return &userResult{records: records}, nil
+}
+
+// userResult iterates over records.
+type userResult struct {
+	neo4j.ResultWithContext
+	records []*neo4j.Record
+	current *neo4j.Record
+}
+
+func (r *userResult) Next(context.Context) bool {
+	if len(r.records) == 0 {
+		return false
+	}
+	r.current, r.records = r.records[0], r.records[1:]
+	return true
+}
+
+func (r *userResult) Record() *neo4j.Record {
+	return r.current
+}
+
+func TestMembershipUser_BatchesLookups(t *testing.T) {
+	// Arrange - 50 members, each invited by the first, loaded with only IDs
+	memberships := tenantRepo.NewMockMembershipRepository()
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Acme"}
+	for i := range 50 {
+		memberships.AddMembership(&model.Membership{
+			ID: fmt.Sprintf("m-%d", i), Role: model.MembershipRoleMember, Status: model.MembershipStatusActive,
+			User: &model.User{ID: fmt.Sprintf("user-%d", i)}, InvitedBy: &model.User{ID: "user-0"}, Tenant: tenant,
+		})
+	}
+	db := &userDB{}
+	users := identityRepo.NewUserRepository(db)
+
+	svc := tenantSvc.NewTenantService(tenantRepo.NewMockTenantRepository(), memberships, identityRepo.NewMockUserRepository(), shared.NewMockUnitOfWork())
+	cfg := Config{Resolvers: &Resolver{TenantService: svc}}
+	cfg.Directives.Trace = shared.TraceDirective(false, nil, nil)
+	c := client.New(handler.NewDefaultServer(NewExecutableSchema(cfg)))
+
+	// A longer wait than the default keeps a slow test run in one batch
+	withLoader := func(bd *client.Request) {
+		loader := identitySvc.NewUserLoader(users).WithWait(50 * time.Millisecond)
+		bd.HTTP = bd.HTTP.WithContext(identitySvc.WithUserLoader(bd.HTTP.Context(), loader))
+	}
+
+	var resp struct {
+		TenantMembers []struct {
+			User      struct{ ID, Name string }
+			InvitedBy struct{ ID string }
+		}
+	}
+
+	// Act
+	err := c.Post(`query { tenantMembers(tenantId: "tenant-1") { user { id name } invitedBy { id } } }`,
+		&resp, asUser("user-0"), withLoader)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, resp.TenantMembers, 50)
+	for _, member := range resp.TenantMembers {
+		assert.Equal(t, "Name of "+member.User.ID, member.User.Name)
+		assert.Equal(t, "user-0", member.InvitedBy.ID)
+	}
+	require.Len(t, db.queries, 1, "every user is fetched in one query")
+	assert.Contains(t, db.queries[0].cypher, "MATCH (u:User) WHERE u.id IN $ids")
+	assert.Len(t, db.queries[0].ids, 50)
+}
+
+func TestMembershipUser_WithoutLoader(t *testing.T) {
+	// Arrange
+	c := newMembershipClient()
+	var resp struct {
+		Membership struct {
+			User struct{ ID string }
+		}
+	}
+
+	// Act
+	err := c.Post(`query { membership(id: "m-pending") { user { id } } }`, &resp, asUser("admin-1"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "invitee-1", resp.Membership.User.ID)
+}
@@ -0,0 +1,18 @@
+// ============================================
+// Migration: core/shared/001_event_outbox_schema
+// Description: Create transactional outbox Event schema
+// ============================================
+
+// ----- CONSTRAINTS -----
+
+CREATE CONSTRAINT event_id_unique IF NOT EXISTS
+FOR (e:Event) REQUIRE e.id IS UNIQUE;
+
+// ----- INDEXES -----
+
+// Dispatcher polls for unprocessed events in creation order
+CREATE INDEX event_processed_at IF NOT EXISTS
+FOR (e:Event) ON (e.processedAt);
+
+CREATE INDEX event_created_at IF NOT EXISTS
+FOR (e:Event) ON (e.createdAt);
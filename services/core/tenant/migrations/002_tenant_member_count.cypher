@@ -0,0 +1,13 @@
+// ============================================
+// Migration: core/tenant/002_tenant_member_count
+// Description: Store each tenant's member count on the Tenant node
+// ============================================
+
+// Membership writes keep this up to date; 'grgn db recount-members' rebuilds it.
+// Deleted users are not counted, matching the tenant member list.
+
+MATCH (t:Tenant)
+SET t.memberCount = COUNT {
+  MATCH (u:User)-[:HAS_MEMBERSHIP]->(:Membership)-[:IN_TENANT]->(t)
+  WHERE u.status <> 'DELETED'
+};
@@ -0,0 +1,14 @@
+// ============================================
+// Migration: core/tenant/003_single_inviter
+// Description: Keep one INVITED relationship per membership
+// ============================================
+
+// Resending an invite replaces the inviter, so the latest inviter wins.
+// Relationships written before invitedAt was recorded sort last.
+
+MATCH (:User)-[r:INVITED]->(m:Membership)
+WITH m, r
+ORDER BY coalesce(r.invitedAt, datetime({epochMillis: 0})) DESC
+WITH m, collect(r) AS invites
+WHERE size(invites) > 1
+FOREACH (r IN tail(invites) | DELETE r);
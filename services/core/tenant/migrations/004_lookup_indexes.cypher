@@ -0,0 +1,16 @@
+// ============================================
+// Migration: core/tenant/004_lookup_indexes
+// Description: Index membership lookups by status
+// ============================================
+
+// Tenant.slug holds the canonical slug, and tenant_slug_unique from 001
+// already backs an index on it, so slug lookups need no new index.
+
+// ----- MEMBERSHIP INDEXES -----
+
+// Member lists, role counts and pending invites filter memberships by status
+CREATE INDEX membership_status IF NOT EXISTS
+FOR (m:Membership) ON (m.status);
+
+CREATE INDEX membership_status_role IF NOT EXISTS
+FOR (m:Membership) ON (m.status, m.role);
@@ -0,0 +1,7 @@
+// ============================================
+// Rollback: core/tenant/004_lookup_indexes
+// ============================================
+
+DROP INDEX membership_status_role IF EXISTS;
+
+DROP INDEX membership_status IF EXISTS;
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/grgn-stack/pkg/cache"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// CachedTenantRepository is a read-through cache in front of an ITenantRepository.
+// FindByID and FindBySlug are served from cache when possible; every write
+// invalidates the affected tenant so readers never see a stale record after
+// their own update. Member counts are cached with the tenant and may lag
+// membership changes, which aren't tenant writes, by up to the cache TTL.
+// GetStatus is passed through
+// uncached, so suspension checks see other servers' writes at once.
+type CachedTenantRepository struct {
+	ITenantRepository
+
+	byID   cache.Cache[string, model.Tenant]
+	bySlug cache.Cache[string, string] // slug -> tenant ID
+}
+
+// NewCachedTenantRepository wraps a tenant repository with the given caches.
+func NewCachedTenantRepository(
+	inner ITenantRepository,
+	byID cache.Cache[string, model.Tenant],
+	bySlug cache.Cache[string, string],
+) *CachedTenantRepository {
+	return &CachedTenantRepository{
+		ITenantRepository: inner,
+		byID:              byID,
+		bySlug:            bySlug,
+	}
+}
+
+// FindByID retrieves a tenant by ID, consulting the cache first.
+func (r *CachedTenantRepository) FindByID(ctx context.Context, id string) (*model.Tenant, error) {
+	if tenant, ok := r.cached(id); ok {
+		return tenant, nil
+	}
+
+	tenant, err := r.ITenantRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store(tenant)
+	return tenant, nil
+}
+
+// FindBySlug retrieves a tenant by slug, consulting the cache first.
+func (r *CachedTenantRepository) FindBySlug(ctx context.Context, slug string) (*model.Tenant, error) {
+	if id, ok := r.bySlug.Get(slug); ok {
+		// Only trust the entry if the cached tenant still carries this slug
+		if tenant, ok := r.cached(id); ok && tenant.Slug == slug {
+			return tenant, nil
+		}
+		r.bySlug.Delete(slug)
+	}
+
+	tenant, err := r.ITenantRepository.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store(tenant)
+	return tenant, nil
+}
+
+// Update updates a tenant and invalidates its cache entries.
+func (r *CachedTenantRepository) Update(ctx context.Context, id string, input model.UpdateTenantInput) (*model.Tenant, error) {
+	r.invalidate(id)
+
+	tenant, err := r.ITenantRepository.Update(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(id)
+	return tenant, nil
+}
+
+// Rename renames a tenant and invalidates its cache entries, including the
+// old slug's.
+func (r *CachedTenantRepository) Rename(ctx context.Context, id, name, slug string) (*model.Tenant, error) {
+	r.invalidate(id)
+
+	tenant, err := r.ITenantRepository.Rename(ctx, id, name, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(id)
+	return tenant, nil
+}
+
+// UpdateSettings updates a tenant's settings and invalidates its cache
+// entries, since the write also moves the tenant's updatedAt.
+func (r *CachedTenantRepository) UpdateSettings(ctx context.Context, id string, update func(*TenantSettings) error) (*TenantSettings, error) {
+	r.invalidate(id)
+
+	settings, err := r.ITenantRepository.UpdateSettings(ctx, id, update)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(id)
+	return settings, nil
+}
+
+// SetBillingEmail sets a tenant's billing email and invalidates its cache entries.
+func (r *CachedTenantRepository) SetBillingEmail(ctx context.Context, id, email string) (*model.Tenant, error) {
+	r.invalidate(id)
+
+	tenant, err := r.ITenantRepository.SetBillingEmail(ctx, id, email)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(id)
+	return tenant, nil
+}
+
+// UpdatePlans updates tenant plans and invalidates every affected tenant.
+func (r *CachedTenantRepository) UpdatePlans(ctx context.Context, changes []*model.PlanChange) ([]*model.Tenant, error) {
+	tenants, err := r.ITenantRepository.UpdatePlans(ctx, changes)
+	for _, change := range changes {
+		r.invalidate(change.TenantID)
+	}
+	return tenants, err
+}
+
+// Delete soft-deletes a tenant and invalidates its cache entries.
+func (r *CachedTenantRepository) Delete(ctx context.Context, id, deletedBy string, reason *string) error {
+	r.invalidate(id)
+
+	if err := r.ITenantRepository.Delete(ctx, id, deletedBy, reason); err != nil {
+		return err
+	}
+
+	r.invalidate(id)
+	return nil
+}
+
+// Restore restores a soft-deleted tenant and invalidates its cache entries.
+func (r *CachedTenantRepository) Restore(ctx context.Context, id string) (*model.Tenant, error) {
+	r.invalidate(id)
+
+	tenant, err := r.ITenantRepository.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(id)
+	return tenant, nil
+}
+
+// store caches a copy of the tenant so callers can't mutate cached state.
+func (r *CachedTenantRepository) store(tenant *model.Tenant) {
+	r.byID.Set(tenant.ID, *cloneTenant(tenant))
+	r.bySlug.Set(tenant.Slug, tenant.ID)
+}
+
+// cached returns a copy of the cached tenant with the given ID, if any.
+func (r *CachedTenantRepository) cached(id string) (*model.Tenant, bool) {
+	tenant, ok := r.byID.Get(id)
+	if !ok {
+		return nil, false
+	}
+	return cloneTenant(&tenant), true
+}
+
+// cloneTenant returns a copy of tenant that shares no pointer or slice with
+// it. The memberships in Members and Owners are copied too, though the
+// users and tenants they point to are shared.
+func cloneTenant(tenant *model.Tenant) *model.Tenant {
+	c := *tenant
+	c.BillingEmail = clonePtr(tenant.BillingEmail)
+	c.MyRole = clonePtr(tenant.MyRole)
+	if tenant.CanLeave != nil {
+		c.CanLeave = &model.LeaveEligibility{Allowed: tenant.CanLeave.Allowed, Reason: clonePtr(tenant.CanLeave.Reason)}
+	}
+	c.Members = cloneMemberships(tenant.Members)
+	c.Owners = cloneMemberships(tenant.Owners)
+	return &c
+}
+
+func cloneMemberships(memberships []*model.Membership) []*model.Membership {
+	if memberships == nil {
+		return nil
+	}
+	out := make([]*model.Membership, len(memberships))
+	for i, membership := range memberships {
+		out[i] = clonePtr(membership)
+	}
+	return out
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// invalidate removes the ID entry and, when known, the slug entry.
+func (r *CachedTenantRepository) invalidate(id string) {
+	if tenant, ok := r.byID.Get(id); ok {
+		r.bySlug.Delete(tenant.Slug)
+	}
+	r.byID.Delete(id)
+}
+
+// Ensure CachedTenantRepository implements ITenantRepository
+var _ ITenantRepository = (*CachedTenantRepository)(nil)
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/cache"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// countingTenantRepo counts reads that reach the underlying repository.
+type countingTenantRepo struct {
+	ITenantRepository
+	reads int
+}
+
+func (c *countingTenantRepo) FindByID(ctx context.Context, id string) (*model.Tenant, error) {
+	c.reads++
+	return c.ITenantRepository.FindByID(ctx, id)
+}
+
+func (c *countingTenantRepo) FindBySlug(ctx context.Context, slug string) (*model.Tenant, error) {
+	c.reads++
+	return c.ITenantRepository.FindBySlug(ctx, slug)
+}
+
+// setupCachedRepo returns a cached repository over a mock that counts reads.
+func setupCachedRepo(ttl time.Duration) (*CachedTenantRepository, *int) {
+	mock := NewMockTenantRepository()
+	mock.AddTenant(&model.Tenant{
+		ID:     "tenant-1",
+		Name:   "Acme Corp",
+		Slug:   "acme",
+		Status: model.TenantStatusActive,
+	})
+	inner := &countingTenantRepo{ITenantRepository: mock}
+
+	repo := NewCachedTenantRepository(
+		inner,
+		cache.NewLRU[string, model.Tenant](100, ttl),
+		cache.NewLRU[string, string](100, ttl),
+	)
+	return repo, &inner.reads
+}
+
+func TestCachedTenantRepository_FindBySlug_HitAndMiss(t *testing.T) {
+	// Arrange
+	repo, reads := setupCachedRepo(time.Minute)
+	ctx := context.Background()
+
+	// Act - first read misses, second hits
+	first, err := repo.FindBySlug(ctx, "acme")
+	require.NoError(t, err)
+	second, err := repo.FindBySlug(ctx, "acme")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, *reads)
+	assert.Equal(t, "tenant-1", first.ID)
+	assert.Equal(t, "tenant-1", second.ID)
+
+	// A slug hit also primes the ID cache
+	_, err = repo.FindByID(ctx, "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, *reads)
+}
+
+func TestCachedTenantRepository_FindByID_NotFoundIsNotCached(t *testing.T) {
+	// Arrange
+	repo, reads := setupCachedRepo(time.Minute)
+	ctx := context.Background()
+
+	// Act
+	_, err1 := repo.FindByID(ctx, "missing")
+	_, err2 := repo.FindByID(ctx, "missing")
+
+	// Assert
+	assert.ErrorIs(t, err1, errors.ErrTenantNotFound)
+	assert.ErrorIs(t, err2, errors.ErrTenantNotFound)
+	assert.Equal(t, 2, *reads)
+}
+
+func TestCachedTenantRepository_TTLExpiry(t *testing.T) {
+	// Arrange
+	repo, reads := setupCachedRepo(20 * time.Millisecond)
+	ctx := context.Background()
+
+	_, err := repo.FindByID(ctx, "tenant-1")
+	require.NoError(t, err)
+
+	// Act
+	time.Sleep(50 * time.Millisecond)
+	_, err = repo.FindByID(ctx, "tenant-1")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, *reads)
+}
+
+func TestCachedTenantRepository_InvalidatedOnUpdate(t *testing.T) {
+	// Arrange
+	repo, reads := setupCachedRepo(time.Minute)
+	ctx := context.Background()
+
+	_, err := repo.FindBySlug(ctx, "acme")
+	require.NoError(t, err)
+
+	// Act
+	newName := "Acme Renamed"
+	_, err = repo.Update(ctx, "tenant-1", model.UpdateTenantInput{Name: &newName})
+	require.NoError(t, err)
+	tenant, err := repo.FindBySlug(ctx, "acme")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Renamed", tenant.Name)
+	assert.Equal(t, 2, *reads)
+}
+
+func TestCachedTenantRepository_InvalidatedOnRename(t *testing.T) {
+	// Arrange
+	repo, reads := setupCachedRepo(time.Minute)
+	ctx := context.Background()
+
+	_, err := repo.FindBySlug(ctx, "acme")
+	require.NoError(t, err)
+
+	// Act
+	_, err = repo.Rename(ctx, "tenant-1", "Acme Two", "acme-two")
+	require.NoError(t, err)
+	tenant, err := repo.FindByID(ctx, "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Two", tenant.Name)
+	assert.Equal(t, "acme-two", tenant.Slug)
+	assert.Equal(t, 2, *reads)
+}
+
+func TestCachedTenantRepository_InvalidatedOnUpdateSettings(t *testing.T) {
+	// Arrange
+	repo, reads := setupCachedRepo(time.Minute)
+	ctx := context.Background()
+
+	_, err := repo.FindByID(ctx, "tenant-1")
+	require.NoError(t, err)
+
+	// Act
+	_, err = repo.UpdateSettings(ctx, "tenant-1", func(s *TenantSettings) error {
+		s.Custom = map[string]string{"plan": "gold"}
+		return nil
+	})
+	require.NoError(t, err)
+	_, err = repo.FindByID(ctx, "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, *reads)
+}
+
+func TestCachedTenantRepository_GetStatus_BypassesCache(t *testing.T) {
+	// Arrange
+	mock := NewMockTenantRepository()
+	mock.AddTenant(&model.Tenant{ID: "tenant-1", Slug: "acme", Status: model.TenantStatusActive})
+	repo := NewCachedTenantRepository(
+		mock,
+		cache.NewLRU[string, model.Tenant](100, time.Minute),
+		cache.NewLRU[string, string](100, time.Minute),
+	)
+	ctx := context.Background()
+	_, err := repo.FindByID(ctx, "tenant-1")
+	require.NoError(t, err)
+
+	// Act - another server suspends the tenant behind this cache
+	suspended := model.TenantStatusSuspended
+	_, err = mock.Update(ctx, "tenant-1", model.UpdateTenantInput{Status: &suspended})
+	require.NoError(t, err)
+	cached, findErr := repo.FindByID(ctx, "tenant-1")
+	status, statusErr := repo.GetStatus(ctx, "tenant-1")
+
+	// Assert
+	require.NoError(t, findErr)
+	require.NoError(t, statusErr)
+	assert.Equal(t, model.TenantStatusActive, cached.Status, "FindByID is served from cache")
+	assert.Equal(t, model.TenantStatusSuspended, status)
+}
+
+func TestCachedTenantRepository_InvalidatedOnDelete(t *testing.T) {
+	// Arrange
+	repo, _ := setupCachedRepo(time.Minute)
+	ctx := context.Background()
+
+	_, err := repo.FindByID(ctx, "tenant-1")
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, repo.Delete(ctx, "tenant-1", "user-1", nil))
+	_, byIDErr := repo.FindByID(ctx, "tenant-1")
+	_, bySlugErr := repo.FindBySlug(ctx, "acme")
+
+	// Assert
+	assert.ErrorIs(t, byIDErr, errors.ErrTenantNotFound)
+	assert.ErrorIs(t, bySlugErr, errors.ErrTenantNotFound)
+}
+
+func TestCachedTenantRepository_ReturnsCopies(t *testing.T) {
+	// Arrange
+	repo, _ := setupCachedRepo(time.Minute)
+	ctx := context.Background()
+
+	first, err := repo.FindByID(ctx, "tenant-1")
+	require.NoError(t, err)
+
+	// Act - mutating a cached result must not leak into the cache
+	first.Name = "Mutated"
+	second, err := repo.FindByID(ctx, "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Corp", second.Name)
+}
+
+func TestCachedTenantRepository_ReturnsDeepCopies(t *testing.T) {
+	// Arrange
+	mock := NewMockTenantRepository()
+	email := "billing@acme.com"
+	role := model.MembershipRoleOwner
+	mock.AddTenant(&model.Tenant{
+		ID: "tenant-1", Slug: "acme", Status: model.TenantStatusActive,
+		BillingEmail: &email, MyRole: &role,
+		Owners: []*model.Membership{{ID: "m1", Role: model.MembershipRoleOwner}},
+	})
+	repo := NewCachedTenantRepository(mock,
+		cache.NewLRU[string, model.Tenant](100, time.Minute),
+		cache.NewLRU[string, string](100, time.Minute))
+	ctx := context.Background()
+
+	first, err := repo.FindByID(ctx, "tenant-1")
+	require.NoError(t, err)
+	cached, err := repo.FindBySlug(ctx, "acme")
+	require.NoError(t, err)
+
+	// Act - mutate through the pointers and slices of both results
+	for _, tenant := range []*model.Tenant{first, cached} {
+		*tenant.BillingEmail = "attacker@example.com"
+		*tenant.MyRole = model.MembershipRoleViewer
+		tenant.Owners[0].Role = model.MembershipRoleViewer
+	}
+	again, err := repo.FindByID(ctx, "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "billing@acme.com", *again.BillingEmail)
+	assert.Equal(t, model.MembershipRoleOwner, *again.MyRole)
+	assert.Equal(t, model.MembershipRoleOwner, again.Owners[0].Role)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+func TestMembershipRepository_CountByRole_MapsGroupedRows(t *testing.T) {
+	// Arrange - one row per held role, as the grouped query returns
+	rows := []*neo4j.Record{
+		{Keys: []string{"role", "count"}, Values: []any{"OWNER", int64(3)}},
+		{Keys: []string{"role", "count"}, Values: []any{"ADMIN", int64(12)}},
+		{Keys: []string{"role", "count"}, Values: []any{"MEMBER", int64(40)}},
+	}
+	repo := NewMembershipRepository(&cursorDB{cursor: &fakeCursor{records: rows}})
+
+	// Act
+	counts, err := repo.CountByRole(context.Background(), "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[model.MembershipRole]int{
+		model.MembershipRoleOwner:  3,
+		model.MembershipRoleAdmin:  12,
+		model.MembershipRoleMember: 40,
+	}, counts)
+	assert.Zero(t, counts[model.MembershipRoleViewer])
+}
+
+func TestMockMembershipRepository_CountByRole_SkipsDeletedUsers(t *testing.T) {
+	// Arrange
+	repo := NewMockMembershipRepository()
+	tenant := &model.Tenant{ID: "tenant-1"}
+	for i, status := range []model.UserStatus{model.UserStatusActive, model.UserStatusDeleted} {
+		repo.AddMembership(&model.Membership{
+			ID: fmt.Sprintf("m-%d", i), Role: model.MembershipRoleMember, Status: model.MembershipStatusActive,
+			Tenant: tenant, User: &model.User{ID: fmt.Sprintf("user-%d", i), Status: status},
+		})
+	}
+
+	// Act
+	counts, err := repo.CountByRole(context.Background(), "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[model.MembershipRole]int{model.MembershipRoleMember: 1}, counts)
+}
@@ -3,7 +3,9 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 )
 
@@ -17,26 +19,82 @@ type ITenantRepository interface {
 	// Returns ErrTenantNotFound if the tenant doesn't exist or is deleted.
 	FindBySlug(ctx context.Context, slug string) (*model.Tenant, error)
 
-	// FindByUserID retrieves all tenants a user is a member of.
-	FindByUserID(ctx context.Context, userID string) ([]*model.Tenant, error)
+	// FindByUserID retrieves a page of the tenants a user is an active member
+	// of, newest first, with MyRole set to the user's role in each. Pending
+	// invites are left out.
+	FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*model.Tenant, error)
+
+	// FindInvitableTenants retrieves the tenants in which the inviter is ADMIN
+	// or OWNER and the user with inviteeEmail is not yet a member, with MyRole
+	// set to the inviter's role. Suspended tenants can't take invites and are
+	// omitted. Unknown emails match no existing memberships.
+	FindInvitableTenants(ctx context.Context, inviterID, inviteeEmail string) ([]*model.Tenant, error)
 
 	// Create creates a new tenant in the database.
 	// Returns ErrSlugTaken if the slug already exists.
 	Create(ctx context.Context, tenant *model.Tenant) (*model.Tenant, error)
 
+	// CreateTx creates a new tenant in tx, as part of a unit of work.
+	// Returns ErrSlugTaken if the slug already exists.
+	CreateTx(ctx context.Context, tx neo4j.ManagedTransaction, tenant *model.Tenant) (*model.Tenant, error)
+
 	// Update updates an existing tenant.
 	// Returns ErrTenantNotFound if the tenant doesn't exist.
 	Update(ctx context.Context, id string, input model.UpdateTenantInput) (*model.Tenant, error)
 
-	// Delete soft-deletes a tenant by setting their status to DELETED.
+	// Rename sets a tenant's name and slug in a single transaction, adding
+	// the old slug to the tenant's slug history when it changes.
+	// Returns ErrSlugTaken if another tenant has the slug, and
+	// ErrTenantNotFound if the tenant doesn't exist or is deleted.
+	Rename(ctx context.Context, id, name, slug string) (*model.Tenant, error)
+
+	// SetBillingEmail sets the tenant's billing/contact email.
+	// Returns ErrTenantNotFound if the tenant doesn't exist or is deleted.
+	SetBillingEmail(ctx context.Context, id, email string) (*model.Tenant, error)
+
+	// GetSettings retrieves a tenant's settings; a tenant without any has
+	// empty settings. Returns ErrTenantNotFound if the tenant doesn't exist
+	// or is deleted.
+	GetSettings(ctx context.Context, id string) (*TenantSettings, error)
+
+	// UpdateSettings calls update with the tenant's current settings and
+	// stores them as update leaves them, atomically. An error from update
+	// is returned and nothing is stored. Returns ErrTenantNotFound if the
+	// tenant doesn't exist or is deleted.
+	UpdateSettings(ctx context.Context, id string, update func(*TenantSettings) error) (*TenantSettings, error)
+
+	// UpdatePlans sets the plan of each listed tenant in a single transaction.
+	// Returns the updated tenants; IDs that don't exist or are deleted are omitted.
+	UpdatePlans(ctx context.Context, changes []*model.PlanChange) ([]*model.Tenant, error)
+
+	// Delete soft-deletes a tenant by setting their status to DELETED and
+	// recording who deleted it and, optionally, why.
 	// Returns ErrTenantNotFound if the tenant doesn't exist.
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id, deletedBy string, reason *string) error
+
+	// Restore reverses a soft delete, making the tenant ACTIVE again.
+	// Returns ErrTenantNotFound if the tenant doesn't exist, ErrNotDeleted
+	// if it isn't deleted, and ErrSlugTaken if another tenant that isn't
+	// deleted has its slug.
+	Restore(ctx context.Context, id string) (*model.Tenant, error)
 
-	// ExistsBySlug checks if a tenant with the given slug exists.
+	// FindDeletion retrieves the deletion details of a deleted tenant.
+	// Returns ErrTenantNotFound if the tenant doesn't exist or isn't deleted.
+	FindDeletion(ctx context.Context, id string) (*model.DeletionInfo, error)
+
+	// ExistsBySlug checks if a tenant with the given slug exists. Deleted
+	// tenants count, since restoring one needs its slug back.
 	ExistsBySlug(ctx context.Context, slug string) (bool, error)
 
-	// GetMemberCount returns the number of members in a tenant.
+	// GetMemberCount returns the number of members in a tenant, excluding
+	// deleted users, as stored on the tenant.
 	GetMemberCount(ctx context.Context, tenantID string) (int, error)
+
+	// GetStatus returns a tenant's current status. It is always read from
+	// the database, never a cache, so a suspension made on one server holds
+	// on every other at once.
+	// Returns ErrTenantNotFound if the tenant doesn't exist or is deleted.
+	GetStatus(ctx context.Context, id string) (model.TenantStatus, error)
 }
 
 // IMembershipRepository defines the contract for membership data access.
@@ -48,8 +106,36 @@ type IMembershipRepository interface {
 	// FindByTenantID retrieves all memberships for a tenant.
 	FindByTenantID(ctx context.Context, tenantID string) ([]*model.Membership, error)
 
-	// FindByUserID retrieves all memberships for a user.
-	FindByUserID(ctx context.Context, userID string) ([]*model.Membership, error)
+	// StreamMembers calls fn for each membership of a tenant as it is read,
+	// without loading them all into memory. Iteration stops at the first
+	// error returned by fn, which StreamMembers returns.
+	StreamMembers(ctx context.Context, tenantID string, fn func(*model.Membership) error) error
+
+	// FindByUserID retrieves a page of a user's memberships, most recently
+	// joined first. A non-nil status restricts results to that status.
+	FindByUserID(ctx context.Context, userID string, status *model.MembershipStatus, limit, offset int) ([]*model.Membership, error)
+
+	// ListMembers retrieves a page of a tenant's members narrowed by filter.
+	// Returns an error for an unknown filter.Sort.
+	ListMembers(ctx context.Context, tenantID string, filter MemberFilter) ([]*model.Membership, error)
+
+	// CountRolesByUserID returns how many active memberships a user holds in
+	// non-deleted tenants, per role. Roles the user doesn't hold are omitted.
+	CountRolesByUserID(ctx context.Context, userID string) (map[model.MembershipRole]int, error)
+
+	// RolesByUserID returns the user's role in each non-deleted tenant they
+	// are an active member of, keyed by tenant ID, for token role claims.
+	RolesByUserID(ctx context.Context, userID string) (map[string]string, error)
+
+	// FindOwnersByTenantID retrieves the OWNER memberships of a tenant,
+	// ordered by joinedAt (earliest first).
+	FindOwnersByTenantID(ctx context.Context, tenantID string) ([]*model.Membership, error)
+
+	// FindAdminsOfUserTenants retrieves, in one query, the active OWNER and
+	// ADMIN memberships of other users in every non-deleted tenant the user
+	// actively belongs to, ordered by tenant ID, then owners first and by
+	// joinedAt (earliest first).
+	FindAdminsOfUserTenants(ctx context.Context, userID string) ([]*model.Membership, error)
 
 	// FindByUserAndTenant retrieves a membership by user and tenant.
 	// Returns ErrMembershipNotFound if the membership doesn't exist.
@@ -59,20 +145,113 @@ type IMembershipRepository interface {
 	// Returns ErrAlreadyMember if the user is already a member.
 	Create(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error)
 
+	// CreateTx creates a new membership in tx, as part of a unit of work.
+	// Returns ErrAlreadyMember if the user is already a member.
+	CreateTx(ctx context.Context, tx neo4j.ManagedTransaction, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error)
+
+	// CreatePending creates a PENDING membership for an invited user, who
+	// gains access once it is accepted. The membership's joinedAt records
+	// when the invite was sent.
+	// Returns ErrAlreadyMember if the user already has a membership.
+	CreatePending(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error)
+
+	// CreatePendingWithinLimit creates a PENDING membership like CreatePending
+	// unless the tenant already has limit pending invites sent after
+	// sentAfter. The count and the create share a transaction that holds the
+	// tenant's lock, so concurrent invites can't overshoot the limit.
+	// Returns ErrInviteLimitReached at the limit and ErrAlreadyMember if the
+	// user already has a membership.
+	CreatePendingWithinLimit(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string, limit int, sentAfter time.Time) (*model.Membership, error)
+
+	// Accept marks a pending membership as ACTIVE. Accepting an active
+	// membership is a no-op.
+	// Returns ErrMembershipNotFound if the membership doesn't exist.
+	Accept(ctx context.Context, id string) (*model.Membership, error)
+
 	// UpdateRole updates a membership's role.
 	// Returns ErrMembershipNotFound if the membership doesn't exist.
 	UpdateRole(ctx context.Context, id string, role model.MembershipRole) (*model.Membership, error)
 
-	// Delete removes a membership.
+	// UpdateRoleTx updates a membership's role in tx, as part of a unit of work.
+	// Returns ErrMembershipNotFound if the membership doesn't exist.
+	UpdateRoleTx(ctx context.Context, tx neo4j.ManagedTransaction, id string, role model.MembershipRole) (*model.Membership, error)
+
+	// Delete removes a membership, decrementing the tenant's memberCount.
 	// Returns ErrMembershipNotFound if the membership doesn't exist.
 	Delete(ctx context.Context, id string) error
 
+	// DeleteTx removes a membership in tx, as part of a unit of work.
+	// Returns ErrMembershipNotFound if the membership doesn't exist.
+	DeleteTx(ctx context.Context, tx neo4j.ManagedTransaction, id string) error
+
 	// CountOwners returns the number of owners in a tenant.
 	CountOwners(ctx context.Context, tenantID string) (int, error)
 
+	// CountOwnersTx counts a tenant's owners in tx, as part of a unit of
+	// work, so the count reflects the unit's earlier writes.
+	CountOwnersTx(ctx context.Context, tx neo4j.ManagedTransaction, tenantID string) (int, error)
+
+	// CountByRole returns how many active members of a tenant whose users
+	// aren't deleted hold each role. Roles no one holds are omitted.
+	CountByRole(ctx context.Context, tenantID string) (map[model.MembershipRole]int, error)
+
+	// FindPendingByTenantID retrieves a tenant's PENDING memberships whose
+	// invite was last sent after sentAfter, most recently invited first.
+	FindPendingByTenantID(ctx context.Context, tenantID string, sentAfter time.Time) ([]*model.Membership, error)
+
+	// CountPending returns the number of a tenant's PENDING memberships whose
+	// invite was last sent after sentAfter.
+	CountPending(ctx context.Context, tenantID string, sentAfter time.Time) (int, error)
+
 	// GetTenantIDByMembershipID returns the tenant ID for a membership.
 	GetTenantIDByMembershipID(ctx context.Context, membershipID string) (string, error)
 
 	// GetUserIDByMembershipID returns the user ID for a membership.
 	GetUserIDByMembershipID(ctx context.Context, membershipID string) (string, error)
+
+	// SetInviter makes inviterID the membership's only inviter, replacing
+	// the previous one so the invite chain never forks, and renews the
+	// invite as RenewInvite does.
+	// Returns ErrMembershipNotFound if the membership or inviter doesn't exist.
+	SetInviter(ctx context.Context, membershipID, inviterID string) (*model.Membership, error)
+
+	// RenewInvite records that a PENDING membership's invite was sent again
+	// now, restarting its expiry.
+	// Returns ErrMembershipNotFound if there is no such pending membership.
+	RenewInvite(ctx context.Context, membershipID string) (*model.Membership, error)
+
+	// ReassignInvites repoints INVITED relationships created by one user within
+	// a tenant to another user, accepted memberships included. The other
+	// user's own membership is detached instead. Returns the number of
+	// memberships reassigned.
+	ReassignInvites(ctx context.Context, fromUserID, toUserID, tenantID string) (int, error)
+
+	// ReassignInvitesTx repoints invites like ReassignInvites, in tx as part
+	// of a unit of work.
+	ReassignInvitesTx(ctx context.Context, tx neo4j.ManagedTransaction, fromUserID, toUserID, tenantID string) (int, error)
+
+	// DetachInvites deletes the INVITED relationships created by a user within
+	// a tenant, leaving the memberships without an inviter. Returns the number
+	// of memberships detached.
+	DetachInvites(ctx context.Context, fromUserID, tenantID string) (int, error)
+
+	// DetachInvitesTx detaches invites like DetachInvites, in tx as part of
+	// a unit of work.
+	DetachInvitesTx(ctx context.Context, tx neo4j.ManagedTransaction, fromUserID, tenantID string) (int, error)
+
+	// GetInviteChain walks INVITED relationships upward from a membership and
+	// returns the inviters, nearest first, following at most maxHops links.
+	// truncated is true when the chain was cut short by the cap or a cycle.
+	GetInviteChain(ctx context.Context, membershipID string, maxHops int) (inviters []*model.User, truncated bool, err error)
+}
+
+// IMemberCountStore reads and corrects the memberCount stored on Tenant nodes.
+type IMemberCountStore interface {
+	// ListMemberCounts returns up to limit tenants with IDs after afterID, in
+	// ID order, with their stored and actual member counts.
+	ListMemberCounts(ctx context.Context, afterID string, limit int) ([]MemberCount, error)
+
+	// SetMemberCounts replaces each tenant's stored count with its actual
+	// count, unless the stored count changed since it was listed.
+	SetMemberCounts(ctx context.Context, counts []MemberCount) error
 }
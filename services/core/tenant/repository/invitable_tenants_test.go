@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// setupInvitable returns a repository where the inviter is OWNER of acme,
+// ADMIN of beta, MEMBER of gamma and ADMIN of delta, and the invitee is a
+// member of beta.
+func setupInvitable() *MockTenantRepository {
+	repo := NewMockTenantRepository()
+	for _, tenant := range []*model.Tenant{
+		{ID: "acme", Name: "Acme", Status: model.TenantStatusActive},
+		{ID: "beta", Name: "Beta", Status: model.TenantStatusActive},
+		{ID: "gamma", Name: "Gamma", Status: model.TenantStatusActive},
+		{ID: "delta", Name: "Delta", Status: model.TenantStatusActive},
+	} {
+		repo.AddTenant(tenant)
+	}
+
+	repo.AddUserToTenantWithRole("inviter", "acme", model.MembershipRoleOwner)
+	repo.AddUserToTenantWithRole("inviter", "beta", model.MembershipRoleAdmin)
+	repo.AddUserToTenantWithRole("inviter", "gamma", model.MembershipRoleMember)
+	repo.AddUserToTenantWithRole("inviter", "delta", model.MembershipRoleAdmin)
+
+	repo.SetUserEmail("invitee", "invitee@example.com")
+	repo.AddUserToTenant("invitee", "beta")
+	return repo
+}
+
+func tenantIDs(tenants []*model.Tenant) []string {
+	ids := make([]string, len(tenants))
+	for i, tenant := range tenants {
+		ids[i] = tenant.ID
+	}
+	return ids
+}
+
+func TestFindInvitableTenants_ExcludesExistingMemberships(t *testing.T) {
+	// Arrange
+	repo := setupInvitable()
+
+	// Act
+	tenants, err := repo.FindInvitableTenants(context.Background(), "inviter", "invitee@example.com")
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotContains(t, tenantIDs(tenants), "beta")
+}
+
+func TestFindInvitableTenants_ExcludesTenantsWithoutAdmin(t *testing.T) {
+	// Arrange
+	repo := setupInvitable()
+
+	// Act
+	tenants, err := repo.FindInvitableTenants(context.Background(), "inviter", "invitee@example.com")
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotContains(t, tenantIDs(tenants), "gamma")
+}
+
+func TestFindInvitableTenants_HappyPath(t *testing.T) {
+	// Arrange
+	repo := setupInvitable()
+
+	// Act
+	tenants, err := repo.FindInvitableTenants(context.Background(), "inviter", "invitee@example.com")
+	newcomer, newErr := repo.FindInvitableTenants(context.Background(), "inviter", "new@example.com")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "delta"}, tenantIDs(tenants))
+	require.NotNil(t, tenants[0].MyRole)
+	assert.Equal(t, model.MembershipRoleOwner, *tenants[0].MyRole)
+
+	require.NoError(t, newErr)
+	assert.Equal(t, []string{"acme", "beta", "delta"}, tenantIDs(newcomer))
+}
+
+func TestFindInvitableTenants_ExcludesSuspendedTenants(t *testing.T) {
+	// Arrange
+	repo := setupInvitable()
+	suspended := model.TenantStatusSuspended
+	_, err := repo.Update(context.Background(), "delta", model.UpdateTenantInput{Status: &suspended})
+	require.NoError(t, err)
+
+	// Act
+	tenants, err := repo.FindInvitableTenants(context.Background(), "inviter", "newcomer@example.com")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "beta"}, tenantIDs(tenants))
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// addChain adds memberships user-0 <- user-1 <- ... <- user-n, where each user
+// was invited by the next one, and returns the repository.
+func addChain(n int) *MockMembershipRepository {
+	repo := NewMockMembershipRepository()
+	tenant := &model.Tenant{ID: "tenant-1"}
+	for i := 0; i <= n; i++ {
+		membership := &model.Membership{
+			ID:     fmt.Sprintf("m-%d", i),
+			Role:   model.MembershipRoleMember,
+			User:   &model.User{ID: fmt.Sprintf("user-%d", i)},
+			Tenant: tenant,
+		}
+		if i < n {
+			membership.InvitedBy = &model.User{ID: fmt.Sprintf("user-%d", i+1)}
+		}
+		repo.AddMembership(membership)
+	}
+	return repo
+}
+
+func TestBoundInviteChain(t *testing.T) {
+	a := &model.User{ID: "a"}
+	b := &model.User{ID: "b"}
+	c := &model.User{ID: "c"}
+
+	testCases := []struct {
+		chain     []*model.User
+		maxHops   int
+		expected  []*model.User
+		truncated bool
+		desc      string
+	}{
+		{[]*model.User{a, b}, 5, []*model.User{a, b}, false, "within cap"},
+		{[]*model.User{a, b, c}, 2, []*model.User{a, b}, true, "over cap"},
+		{[]*model.User{a, b, a, b}, 10, []*model.User{a, b}, true, "cycle"},
+		{[]*model.User{}, 3, []*model.User{}, false, "empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			chain, truncated := boundInviteChain(tc.chain, tc.maxHops)
+			assert.Equal(t, tc.expected, chain)
+			assert.Equal(t, tc.truncated, truncated)
+		})
+	}
+}
+
+func TestMockMembershipRepository_GetInviteChain_Complete(t *testing.T) {
+	// Arrange
+	repo := addChain(3)
+
+	// Act
+	chain, truncated, err := repo.GetInviteChain(context.Background(), "m-0", 10)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	require.Len(t, chain, 3)
+	assert.Equal(t, "user-1", chain[0].ID)
+	assert.Equal(t, "user-3", chain[2].ID)
+}
+
+func TestMockMembershipRepository_GetInviteChain_LongChainCapped(t *testing.T) {
+	// Arrange
+	repo := addChain(500)
+
+	// Act
+	chain, truncated, err := repo.GetInviteChain(context.Background(), "m-0", 10)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Len(t, chain, 10)
+}
+
+func TestMockMembershipRepository_GetInviteChain_CycleTerminates(t *testing.T) {
+	// Arrange - corrupted data: user-a and user-b invited each other
+	repo := NewMockMembershipRepository()
+	tenant := &model.Tenant{ID: "tenant-1"}
+	repo.AddMembership(&model.Membership{ID: "m-a", User: &model.User{ID: "user-a"}, Tenant: tenant, InvitedBy: &model.User{ID: "user-b"}})
+	repo.AddMembership(&model.Membership{ID: "m-b", User: &model.User{ID: "user-b"}, Tenant: tenant, InvitedBy: &model.User{ID: "user-a"}})
+
+	// Act
+	chain, truncated, err := repo.GetInviteChain(context.Background(), "m-a", 25)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	require.Len(t, chain, 2)
+	assert.Equal(t, "user-b", chain[0].ID)
+	assert.Equal(t, "user-a", chain[1].ID)
+}
+
+func TestMockMembershipRepository_GetInviteChain_InvalidCap(t *testing.T) {
+	// Arrange
+	repo := addChain(1)
+
+	// Act
+	_, _, err := repo.GetInviteChain(context.Background(), "m-0", 0)
+
+	// Assert
+	var validationErr *errors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noRowTx answers every query with a result that has no single row.
+type noRowTx struct {
+	neo4j.ManagedTransaction
+	cypher string
+}
+
+func (tx *noRowTx) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	tx.cypher = cypher
+	return noRowResult{}, nil
+}
+
+type noRowResult struct {
+	neo4j.ResultWithContext
+}
+
+func (noRowResult) Single(ctx context.Context) (*neo4j.Record, error) {
+	return nil, fmt.Errorf("result contains no more records")
+}
+
+func TestMembershipRepository_ReassignInvitesTx_ReturnsReadError(t *testing.T) {
+	// Arrange
+	repo := NewMembershipRepository(nil)
+	tx := &noRowTx{}
+
+	// Act
+	count, err := repo.ReassignInvitesTx(context.Background(), tx, "old-admin", "new-admin", "tenant-1")
+
+	// Assert
+	require.Error(t, err)
+	assert.Zero(t, count)
+	assert.Contains(t, tx.cypher, "EXISTS { (to)-[:HAS_MEMBERSHIP]->(m) }", "the new inviter's own membership is left out")
+}
+
+func TestMembershipRepository_DetachInvitesTx_ReturnsReadError(t *testing.T) {
+	// Arrange
+	repo := NewMembershipRepository(nil)
+
+	// Act
+	count, err := repo.DetachInvitesTx(context.Background(), &noRowTx{}, "old-admin", "tenant-1")
+
+	// Assert
+	require.Error(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+)
+
+// DefaultRecountBatchSize is the number of tenants recounted per transaction.
+const DefaultRecountBatchSize = 500
+
+// activeMembersCount counts t's memberships whose user isn't deleted, the
+// same members FindByTenantID lists. Membership writes keep t.memberCount
+// equal to it; it is only evaluated to rebuild the stored value.
+const activeMembersCount = `COUNT {
+				MATCH (mu:User)-[:HAS_MEMBERSHIP]->(:Membership)-[:IN_TENANT]->(t)
+				WHERE mu.status <> 'DELETED'
+			}`
+
+// MemberCount is a tenant's stored member count alongside the actual one.
+type MemberCount struct {
+	TenantID string
+	Stored   int
+	Actual   int
+}
+
+// RecountMembers rebuilds every tenant's stored memberCount from its
+// memberships in batches, correcting drift. It returns the tenants whose
+// stored count was wrong, with the count they had.
+func RecountMembers(ctx context.Context, store IMemberCountStore, batchSize int) ([]MemberCount, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultRecountBatchSize
+	}
+
+	var corrected []MemberCount
+	afterID := ""
+	for {
+		counts, err := store.ListMemberCounts(ctx, afterID, batchSize)
+		if err != nil {
+			return corrected, fmt.Errorf("count members after %q: %w", afterID, err)
+		}
+
+		var fixes []MemberCount
+		for _, count := range counts {
+			if count.Stored != count.Actual {
+				fixes = append(fixes, count)
+			}
+		}
+		if len(fixes) > 0 {
+			if err := store.SetMemberCounts(ctx, fixes); err != nil {
+				return corrected, fmt.Errorf("set member counts: %w", err)
+			}
+			corrected = append(corrected, fixes...)
+		}
+
+		if len(counts) < batchSize {
+			return corrected, nil
+		}
+		afterID = counts[len(counts)-1].TenantID
+	}
+}
+
+// Neo4jMemberCountStore implements IMemberCountStore with Cypher.
+type Neo4jMemberCountStore struct {
+	db shared.IDatabase
+}
+
+// NewNeo4jMemberCountStore creates a new Neo4jMemberCountStore.
+func NewNeo4jMemberCountStore(db shared.IDatabase) *Neo4jMemberCountStore {
+	return &Neo4jMemberCountStore{db: db}
+}
+
+// ListMemberCounts returns a page of tenants with stored and actual counts.
+// A missing stored count reads as -1 so it is always corrected.
+func (s *Neo4jMemberCountStore) ListMemberCounts(ctx context.Context, afterID string, limit int) ([]MemberCount, error) {
+	result, err := s.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (t:Tenant)
+			WHERE t.id > $afterID
+			RETURN t.id as tenantId,
+				coalesce(t.memberCount, -1) as stored,
+				`+activeMembersCount+` as actual
+			ORDER BY t.id
+			LIMIT $limit
+		`, map[string]any{"afterID": afterID, "limit": limit})
+		if err != nil {
+			return nil, err
+		}
+
+		counts := []MemberCount{}
+		for result.Next(ctx) {
+			record := result.Record()
+			tenantID, _ := record.Get("tenantId")
+			stored, _ := record.Get("stored")
+			actual, _ := record.Get("actual")
+			counts = append(counts, MemberCount{
+				TenantID: tenantID.(string),
+				Stored:   int(stored.(int64)),
+				Actual:   int(actual.(int64)),
+			})
+		}
+		return counts, result.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]MemberCount), nil
+}
+
+// SetMemberCounts stores actual counts in a single transaction. A tenant
+// whose count changed after it was listed is skipped rather than given a
+// stale count; running the recount again corrects it.
+func (s *Neo4jMemberCountStore) SetMemberCounts(ctx context.Context, counts []MemberCount) error {
+	rows := make([]map[string]any, len(counts))
+	for i, count := range counts {
+		rows[i] = map[string]any{"tenantId": count.TenantID, "stored": count.Stored, "actual": count.Actual}
+	}
+
+	_, err := s.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		_, err := tx.Run(ctx, `
+			UNWIND $counts AS row
+			MATCH (t:Tenant {id: row.tenantId})
+			WHERE coalesce(t.memberCount, -1) = row.stored
+			SET t.memberCount = row.actual
+		`, map[string]any{"counts": rows})
+		return nil, err
+	})
+	return err
+}
+
+// Ensure Neo4jMemberCountStore implements IMemberCountStore
+var _ IMemberCountStore = (*Neo4jMemberCountStore)(nil)
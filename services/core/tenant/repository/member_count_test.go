@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// storedCountDB answers tenant queries with a single tenant node whose
+// stored memberCount is fixed.
+type storedCountDB struct {
+	shared.IDatabase
+	memberCount int64
+}
+
+func (d *storedCountDB) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return work(&storedCountTx{db: d})
+}
+
+func (d *storedCountDB) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return work(&storedCountTx{db: d})
+}
+
+type storedCountTx struct {
+	neo4j.ManagedTransaction
+	db *storedCountDB
+}
+
+func (tx *storedCountTx) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	tenant := neo4j.Node{Props: map[string]any{
+		"id": "tenant-1", "name": "Acme", "slug": "acme",
+		"plan": "FREE", "isolationMode": "SHARED", "status": "ACTIVE",
+		"memberCount": tx.db.memberCount,
+	}}
+	return &singleResult{fakeCursor: &fakeCursor{records: []*neo4j.Record{{
+		Keys:   []string{"t", "count"},
+		Values: []any{tenant, tx.db.memberCount},
+	}}}}, nil
+}
+
+// singleResult adds Single to fakeCursor for queries expecting one row.
+type singleResult struct {
+	*fakeCursor
+}
+
+func (r *singleResult) Single(ctx context.Context) (*neo4j.Record, error) {
+	return r.records[0], nil
+}
+
+func TestTenantRepository_MemberCount_ReadsStoredCount(t *testing.T) {
+	ctx := context.Background()
+	name := "Renamed"
+
+	tests := []struct {
+		name  string
+		count func(repo *TenantRepository) (int, error)
+	}{
+		{"FindByID", func(repo *TenantRepository) (int, error) {
+			tenant, err := repo.FindByID(ctx, "tenant-1")
+			if err != nil {
+				return 0, err
+			}
+			return tenant.MemberCount, nil
+		}},
+		{"FindByUserID", func(repo *TenantRepository) (int, error) {
+			tenants, err := repo.FindByUserID(ctx, "user-1", 10, 0)
+			if err != nil {
+				return 0, err
+			}
+			return tenants[0].MemberCount, nil
+		}},
+		{"Update", func(repo *TenantRepository) (int, error) {
+			tenant, err := repo.Update(ctx, "tenant-1", model.UpdateTenantInput{Name: &name})
+			if err != nil {
+				return 0, err
+			}
+			return tenant.MemberCount, nil
+		}},
+		{"GetMemberCount", func(repo *TenantRepository) (int, error) {
+			return repo.GetMemberCount(ctx, "tenant-1")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := NewTenantRepository(&storedCountDB{memberCount: 3})
+
+			// Act
+			count, err := tt.count(repo)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 3, count)
+		})
+	}
+}
+
+func TestMockMembershipRepository_MemberCountFollowsAddAndRemove(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	tenants := NewMockTenantRepository()
+	tenants.AddTenant(&model.Tenant{ID: "tenant-1", Status: model.TenantStatusActive})
+	memberships := NewMockMembershipRepository()
+	memberships.Tenants = tenants
+
+	// Act
+	first, err := memberships.Create(ctx, "user-1", "tenant-1", model.MembershipRoleOwner, nil)
+	require.NoError(t, err)
+	_, err = memberships.CreatePending(ctx, "user-2", "tenant-1", model.MembershipRoleMember, nil)
+	require.NoError(t, err)
+	_, dupErr := memberships.Create(ctx, "user-1", "tenant-1", model.MembershipRoleMember, nil)
+	afterAdd, _ := tenants.GetMemberCount(ctx, "tenant-1")
+	require.NoError(t, memberships.Delete(ctx, first.ID))
+	afterRemove, _ := tenants.GetMemberCount(ctx, "tenant-1")
+
+	// Assert
+	assert.Error(t, dupErr)
+	assert.Equal(t, 2, afterAdd, "a rejected duplicate is not counted")
+	assert.Equal(t, 1, afterRemove)
+	tenant, err := tenants.FindByID(ctx, "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, tenant.MemberCount)
+}
+
+// countStore is an in-memory IMemberCountStore. stored holds each tenant's
+// memberCount property, with -1 for a missing one, and actual the count its
+// memberships imply.
+type countStore struct {
+	stored    map[string]int
+	actual    map[string]int
+	listCalls int
+	setErr    error
+}
+
+func (s *countStore) ListMemberCounts(ctx context.Context, afterID string, limit int) ([]MemberCount, error) {
+	s.listCalls++
+	ids := make([]string, 0, len(s.actual))
+	for id := range s.actual {
+		if id > afterID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	counts := []MemberCount{}
+	for _, id := range ids[:min(limit, len(ids))] {
+		counts = append(counts, MemberCount{TenantID: id, Stored: s.stored[id], Actual: s.actual[id]})
+	}
+	return counts, nil
+}
+
+func (s *countStore) SetMemberCounts(ctx context.Context, counts []MemberCount) error {
+	if s.setErr != nil {
+		return s.setErr
+	}
+	for _, count := range counts {
+		if s.stored[count.TenantID] == count.Stored {
+			s.stored[count.TenantID] = count.Actual
+		}
+	}
+	return nil
+}
+
+func TestRecountMembers_FixesWrongCounts(t *testing.T) {
+	// Arrange
+	store := &countStore{
+		stored: map[string]int{"tenant-a": 2, "tenant-b": 7, "tenant-c": -1},
+		actual: map[string]int{"tenant-a": 2, "tenant-b": 3, "tenant-c": 1},
+	}
+
+	// Act
+	corrected, err := RecountMembers(context.Background(), store, 10)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []MemberCount{
+		{TenantID: "tenant-b", Stored: 7, Actual: 3},
+		{TenantID: "tenant-c", Stored: -1, Actual: 1},
+	}, corrected)
+	assert.Equal(t, store.actual, store.stored)
+}
+
+func TestRecountMembers_Batches(t *testing.T) {
+	// Arrange
+	store := &countStore{stored: map[string]int{}, actual: map[string]int{}}
+	for i := range 5 {
+		id := fmt.Sprintf("tenant-%d", i)
+		store.stored[id] = 0
+		store.actual[id] = i
+	}
+
+	// Act
+	corrected, err := RecountMembers(context.Background(), store, 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, corrected, 4)
+	assert.Equal(t, store.actual, store.stored)
+	assert.Equal(t, 3, store.listCalls)
+}
+
+func TestRecountMembers_SetError(t *testing.T) {
+	// Arrange
+	store := &countStore{
+		stored: map[string]int{"tenant-a": 9},
+		actual: map[string]int{"tenant-a": 1},
+		setErr: fmt.Errorf("write failed"),
+	}
+
+	// Act
+	corrected, err := RecountMembers(context.Background(), store, 10)
+
+	// Assert
+	assert.ErrorIs(t, err, store.setErr)
+	assert.Empty(t, corrected)
+	assert.Equal(t, 9, store.stored["tenant-a"])
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// MemberSort orders the members ListMembers returns.
+type MemberSort string
+
+const (
+	// MemberSortJoinedDesc lists the most recently joined first. It is the
+	// default.
+	MemberSortJoinedDesc MemberSort = "JOINED_DESC"
+	// MemberSortJoinedAsc lists the earliest joined first.
+	MemberSortJoinedAsc MemberSort = "JOINED_ASC"
+	// MemberSortName lists members alphabetically by name, using the email
+	// for members without one.
+	MemberSortName MemberSort = "NAME"
+)
+
+// memberOrderBy holds the ORDER BY clause for each sort. Cypher can't take
+// the order as a parameter, so only these fixed clauses are ever used.
+var memberOrderBy = map[MemberSort]string{
+	MemberSortJoinedDesc: "m.joinedAt DESC, m.id",
+	MemberSortJoinedAsc:  "m.joinedAt, m.id",
+	MemberSortName:       "toLower(coalesce(u.name, u.email)), m.id",
+}
+
+// ValidMemberSort reports whether sort is a known sort. Empty is valid and
+// means MemberSortJoinedDesc.
+func ValidMemberSort(sort MemberSort) bool {
+	_, ok := memberOrderBy[sort]
+	return ok || sort == ""
+}
+
+// MemberFilter narrows and pages the members ListMembers returns.
+type MemberFilter struct {
+	// Role restricts results to one role; nil matches every role
+	Role *model.MembershipRole
+	// Search matches members whose name or email contains it, ignoring
+	// case; empty matches everyone
+	Search string
+	// Sort orders results; empty means MemberSortJoinedDesc
+	Sort MemberSort
+
+	Limit  int
+	Offset int
+}
+
+// orderBy returns the ORDER BY clause for f's sort.
+func (f MemberFilter) orderBy() (string, error) {
+	if f.Sort == "" {
+		return memberOrderBy[MemberSortJoinedDesc], nil
+	}
+	clause, ok := memberOrderBy[f.Sort]
+	if !ok {
+		return "", fmt.Errorf("unknown member sort %q", f.Sort)
+	}
+	return clause, nil
+}
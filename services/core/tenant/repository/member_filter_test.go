@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// queryDB records the query and params of each read and returns no rows.
+type queryDB struct {
+	shared.IDatabase
+	cypher string
+	params map[string]any
+}
+
+func (d *queryDB) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return work(&queryTx{db: d})
+}
+
+type queryTx struct {
+	neo4j.ManagedTransaction
+	db *queryDB
+}
+
+func (tx *queryTx) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	tx.db.cypher, tx.db.params = cypher, params
+	return &fakeCursor{}, nil
+}
+
+// addMembers adds a tenant with an owner, two admins and a member, joined an
+// hour apart in that order.
+func addMembers() *MockMembershipRepository {
+	repo := NewMockMembershipRepository()
+	tenant := &model.Tenant{ID: "tenant-1"}
+	name := func(s string) *string { return &s }
+	joined := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	members := []struct {
+		id    string
+		role  model.MembershipRole
+		email string
+		name  *string
+	}{
+		{"m-owner", model.MembershipRoleOwner, "olive@acme.com", name("Olive Owner")},
+		{"m-admin-1", model.MembershipRoleAdmin, "ada@acme.com", name("Ada Lovelace")},
+		{"m-admin-2", model.MembershipRoleAdmin, "bob@example.com", nil},
+		{"m-member", model.MembershipRoleMember, "carol@example.com", name("Carol ACME-Fan")},
+	}
+	for i, member := range members {
+		repo.AddMembership(&model.Membership{
+			ID: member.id, Role: member.role, Status: model.MembershipStatusActive,
+			JoinedAt: joined.Add(time.Duration(i) * time.Hour), Tenant: tenant,
+			User: &model.User{ID: "user-" + member.id, Email: member.email, Name: member.name},
+		})
+	}
+	return repo
+}
+
+func membershipIDs(memberships []*model.Membership) []string {
+	ids := make([]string, len(memberships))
+	for i, membership := range memberships {
+		ids[i] = membership.ID
+	}
+	return ids
+}
+
+func TestMockMembershipRepository_ListMembers_RoleFilter(t *testing.T) {
+	// Arrange
+	repo := addMembers()
+	role := model.MembershipRoleAdmin
+
+	// Act
+	members, err := repo.ListMembers(context.Background(), "tenant-1", MemberFilter{Role: &role, Limit: 10})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m-admin-2", "m-admin-1"}, membershipIDs(members))
+}
+
+func TestMockMembershipRepository_ListMembers_SearchMatchesNameOrEmail(t *testing.T) {
+	// Arrange
+	repo := addMembers()
+
+	// Act - "acme" is in two emails and, in another case, one name
+	members, err := repo.ListMembers(context.Background(), "tenant-1", MemberFilter{Search: "AcMe", Limit: 10})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m-member", "m-admin-1", "m-owner"}, membershipIDs(members))
+}
+
+func TestMockMembershipRepository_ListMembers_SkipsDeletedUsers(t *testing.T) {
+	// Arrange
+	repo := addMembers()
+	repo.memberships["m-admin-1"].User.Status = model.UserStatusDeleted
+
+	// Act
+	members, err := repo.ListMembers(context.Background(), "tenant-1", MemberFilter{Limit: 10})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m-member", "m-admin-2", "m-owner"}, membershipIDs(members))
+}
+
+func TestMockMembershipRepository_ListMembers_Pagination(t *testing.T) {
+	// Arrange
+	repo := addMembers()
+	ctx := context.Background()
+
+	// Act
+	first, firstErr := repo.ListMembers(ctx, "tenant-1", MemberFilter{Sort: MemberSortJoinedAsc, Limit: 3})
+	second, secondErr := repo.ListMembers(ctx, "tenant-1", MemberFilter{Sort: MemberSortJoinedAsc, Limit: 3, Offset: 3})
+	past, pastErr := repo.ListMembers(ctx, "tenant-1", MemberFilter{Limit: 3, Offset: 10})
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.NoError(t, pastErr)
+	assert.Equal(t, []string{"m-owner", "m-admin-1", "m-admin-2"}, membershipIDs(first))
+	assert.Equal(t, []string{"m-member"}, membershipIDs(second))
+	assert.Empty(t, past)
+}
+
+func TestMockMembershipRepository_ListMembers_SortByName(t *testing.T) {
+	// Arrange
+	repo := addMembers()
+
+	// Act
+	members, err := repo.ListMembers(context.Background(), "tenant-1", MemberFilter{Sort: MemberSortName, Limit: 10})
+
+	// Assert - the member without a name sorts by email
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m-admin-1", "m-admin-2", "m-member", "m-owner"}, membershipIDs(members))
+}
+
+func TestMembershipRepository_ListMembers_ParameterizesFilter(t *testing.T) {
+	// Arrange
+	db := &queryDB{}
+	repo := NewMembershipRepository(db)
+	role := model.MembershipRoleAdmin
+
+	// Act
+	_, err := repo.ListMembers(context.Background(), "tenant-1", MemberFilter{
+		Role: &role, Search: "Ada' OR 1=1", Sort: MemberSortName, Limit: 20, Offset: 40,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotContains(t, db.cypher, "Ada")
+	assert.Contains(t, db.cypher, "ORDER BY "+memberOrderBy[MemberSortName])
+	assert.Equal(t, "ADMIN", db.params["role"])
+	assert.Equal(t, "ada' or 1=1", db.params["search"])
+	assert.Equal(t, 20, db.params["limit"])
+	assert.Equal(t, 40, db.params["offset"])
+}
+
+func TestMembershipRepository_ListMembers_UnknownSort(t *testing.T) {
+	// Arrange
+	db := &queryDB{}
+	repo := NewMembershipRepository(db)
+
+	// Act
+	_, err := repo.ListMembers(context.Background(), "tenant-1", MemberFilter{Sort: "m.id; DROP", Limit: 10})
+
+	// Assert
+	require.Error(t, err)
+	assert.Empty(t, db.cypher, "no query runs for an unknown sort")
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/events"
 	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 )
@@ -77,16 +80,68 @@ func (r *MembershipRepository) FindByTenantID(ctx context.Context, tenantID stri
 	return result.([]*model.Membership), nil
 }
 
-// FindByUserID retrieves all memberships for a user.
-func (r *MembershipRepository) FindByUserID(ctx context.Context, userID string) ([]*model.Membership, error) {
+// StreamMembers calls fn for each membership of a tenant, reading records
+// from the result cursor one at a time. It uses an explicit transaction
+// rather than a managed one: fn's effects, such as rows already sent to a
+// client, can't be undone, so a transient failure must fail the stream
+// instead of rerunning it from the first row. The query timeout doesn't
+// apply either, since a large export can take longer; ctx bounds it.
+func (r *MembershipRepository) StreamMembers(ctx context.Context, tenantID string, fn func(*model.Membership) error) error {
+	session := r.db.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	tx, err := session.BeginTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("begin member stream: %w", err)
+	}
+	defer tx.Close(ctx)
+
+	result, err := tx.Run(ctx, `
+		MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t:Tenant {id: $tenantID})
+		WHERE u.status <> 'DELETED'
+		OPTIONAL MATCH (inviter:User)-[:INVITED]->(m)
+		RETURN m, u, t, inviter
+		ORDER BY m.joinedAt DESC
+	`, map[string]any{"tenantID": tenantID})
+	if err != nil {
+		return err
+	}
+
+	for result.Next(ctx) {
+		membership, err := r.mapRecordToMembership(result.Record())
+		if err != nil {
+			return err
+		}
+		if err := fn(membership); err != nil {
+			return err
+		}
+	}
+	if err := result.Err(); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FindByUserID retrieves a page of a user's memberships.
+func (r *MembershipRepository) FindByUserID(ctx context.Context, userID string, status *model.MembershipStatus, limit, offset int) ([]*model.Membership, error) {
+	params := map[string]any{"userID": userID, "status": nil, "limit": limit, "offset": offset}
+	if status != nil {
+		params["status"] = string(*status)
+	}
+
 	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, `
 			MATCH (u:User {id: $userID})-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t:Tenant)
-			WHERE t.status <> 'DELETED'
+			WHERE t.status <> 'DELETED' AND ($status IS NULL OR m.status = $status)
+			WITH m, u, t
+			ORDER BY m.joinedAt DESC, m.id
+			SKIP $offset
+			LIMIT $limit
 			OPTIONAL MATCH (inviter:User)-[:INVITED]->(m)
 			RETURN m, u, t, inviter
-			ORDER BY m.joinedAt DESC
-		`, map[string]any{"userID": userID})
+			ORDER BY m.joinedAt DESC, m.id
+		`, params)
 		if err != nil {
 			return nil, err
 		}
@@ -108,6 +163,179 @@ func (r *MembershipRepository) FindByUserID(ctx context.Context, userID string)
 	return result.([]*model.Membership), nil
 }
 
+// ListMembers retrieves a page of a tenant's members narrowed by filter. The
+// search is matched case-insensitively against the user's email and name.
+func (r *MembershipRepository) ListMembers(ctx context.Context, tenantID string, filter MemberFilter) ([]*model.Membership, error) {
+	orderBy, err := filter.orderBy()
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]any{
+		"tenantID": tenantID,
+		"role":     nil,
+		"search":   strings.ToLower(filter.Search),
+		"limit":    filter.Limit,
+		"offset":   filter.Offset,
+	}
+	if filter.Role != nil {
+		params["role"] = string(*filter.Role)
+	}
+
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t:Tenant {id: $tenantID})
+			WHERE u.status <> 'DELETED'
+				AND ($role IS NULL OR m.role = $role)
+				AND ($search = '' OR toLower(u.email) CONTAINS $search OR toLower(coalesce(u.name, '')) CONTAINS $search)
+			WITH m, u, t
+			ORDER BY `+orderBy+`
+			SKIP $offset
+			LIMIT $limit
+			OPTIONAL MATCH (inviter:User)-[:INVITED]->(m)
+			RETURN m, u, t, inviter
+			ORDER BY `+orderBy, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var memberships []*model.Membership
+		for result.Next(ctx) {
+			membership, err := r.mapRecordToMembership(result.Record())
+			if err != nil {
+				return nil, err
+			}
+			memberships = append(memberships, membership)
+		}
+
+		return memberships, result.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*model.Membership), nil
+}
+
+// CountRolesByUserID counts a user's active memberships per role in one grouped query.
+func (r *MembershipRepository) CountRolesByUserID(ctx context.Context, userID string) (map[model.MembershipRole]int, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $userID})-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t:Tenant)
+			WHERE t.status <> 'DELETED' AND m.status = 'ACTIVE'
+			RETURN m.role as role, count(m) as count
+		`, map[string]any{"userID": userID})
+		if err != nil {
+			return nil, err
+		}
+
+		counts := make(map[model.MembershipRole]int)
+		for result.Next(ctx) {
+			record := result.Record()
+			role, _ := record.Get("role")
+			count, _ := record.Get("count")
+			counts[model.MembershipRole(role.(string))] = int(count.(int64))
+		}
+		return counts, result.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[model.MembershipRole]int), nil
+}
+
+// RolesByUserID returns a user's active roles keyed by tenant ID.
+func (r *MembershipRepository) RolesByUserID(ctx context.Context, userID string) (map[string]string, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $userID})-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t:Tenant)
+			WHERE t.status <> 'DELETED' AND m.status = 'ACTIVE'
+			RETURN t.id as tenantId, m.role as role
+		`, map[string]any{"userID": userID})
+		if err != nil {
+			return nil, err
+		}
+
+		roles := make(map[string]string)
+		for result.Next(ctx) {
+			record := result.Record()
+			tenantID, _ := record.Get("tenantId")
+			role, _ := record.Get("role")
+			roles[tenantID.(string)] = role.(string)
+		}
+		return roles, result.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]string), nil
+}
+
+// FindOwnersByTenantID retrieves the active OWNER memberships of a tenant,
+// leaving out pending invites. It reads
+// after the request's writes so a newly created tenant lists its owner.
+func (r *MembershipRepository) FindOwnersByTenantID(ctx context.Context, tenantID string) ([]*model.Membership, error) {
+	result, err := r.db.ExecuteReadAfterWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {role: 'OWNER'})-[:IN_TENANT]->(t:Tenant {id: $tenantID})
+			WHERE u.status <> 'DELETED' AND m.status = 'ACTIVE'
+			OPTIONAL MATCH (inviter:User)-[:INVITED]->(m)
+			RETURN m, u, t, inviter
+			ORDER BY m.joinedAt ASC
+		`, map[string]any{"tenantID": tenantID})
+		if err != nil {
+			return nil, err
+		}
+
+		memberships := []*model.Membership{}
+		for result.Next(ctx) {
+			membership, err := r.mapRecordToMembership(result.Record())
+			if err != nil {
+				return nil, err
+			}
+			memberships = append(memberships, membership)
+		}
+
+		return memberships, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*model.Membership), nil
+}
+
+// FindAdminsOfUserTenants retrieves the admins of a user's tenants in one query.
+func (r *MembershipRepository) FindAdminsOfUserTenants(ctx context.Context, userID string) ([]*model.Membership, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (:User {id: $userID})-[:HAS_MEMBERSHIP]->(:Membership {status: 'ACTIVE'})-[:IN_TENANT]->(t:Tenant)
+			WHERE t.status <> 'DELETED'
+			MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {status: 'ACTIVE'})-[:IN_TENANT]->(t)
+			WHERE m.role IN ['OWNER', 'ADMIN'] AND u.id <> $userID AND u.status <> 'DELETED'
+			OPTIONAL MATCH (inviter:User)-[:INVITED]->(m)
+			RETURN m, u, t, inviter
+			ORDER BY t.id, m.role = 'OWNER' DESC, m.joinedAt ASC
+		`, map[string]any{"userID": userID})
+		if err != nil {
+			return nil, err
+		}
+
+		memberships := []*model.Membership{}
+		for result.Next(ctx) {
+			membership, err := r.mapRecordToMembership(result.Record())
+			if err != nil {
+				return nil, err
+			}
+			memberships = append(memberships, membership)
+		}
+
+		return memberships, result.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*model.Membership), nil
+}
+
 // FindByUserAndTenant retrieves a membership by user and tenant.
 func (r *MembershipRepository) FindByUserAndTenant(ctx context.Context, userID, tenantID string) (*model.Membership, error) {
 	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
@@ -135,80 +363,154 @@ func (r *MembershipRepository) FindByUserAndTenant(ctx context.Context, userID,
 
 // Create creates a new membership.
 func (r *MembershipRepository) Create(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error) {
-	membershipID := uuid.New().String()
+	return r.create(ctx, userID, tenantID, role, invitedByID, model.MembershipStatusActive)
+}
 
+// CreateTx creates a new membership in tx, leaving commit or rollback to
+// the caller.
+func (r *MembershipRepository) CreateTx(ctx context.Context, tx neo4j.ManagedTransaction, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error) {
+	return r.createTx(ctx, tx, uuid.New().String(), userID, tenantID, role, invitedByID, model.MembershipStatusActive)
+}
+
+// CreatePending creates a PENDING membership for an invited user.
+func (r *MembershipRepository) CreatePending(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error) {
+	return r.create(ctx, userID, tenantID, role, invitedByID, model.MembershipStatusPending)
+}
+
+// CreatePendingWithinLimit creates a PENDING membership unless the tenant
+// already has limit pending invites sent after sentAfter.
+func (r *MembershipRepository) CreatePendingWithinLimit(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string, limit int, sentAfter time.Time) (*model.Membership, error) {
+	membershipID := uuid.New().String()
 	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-		// Check if user is already a member
-		checkResult, err := tx.Run(ctx, `
-			MATCH (u:User {id: $userID})-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t:Tenant {id: $tenantID})
-			RETURN count(m) > 0 as exists
-		`, map[string]any{"userID": userID, "tenantID": tenantID})
+		// Writing to the tenant takes its lock, so concurrent invites queue
+		// here and each counts the ones committed before it
+		lockResult, err := tx.Run(ctx, `
+			MATCH (t:Tenant {id: $tenantID})
+			SET t._lock = true
+			REMOVE t._lock
+			RETURN t.id as id
+		`, map[string]any{"tenantID": tenantID})
 		if err != nil {
 			return nil, err
 		}
+		if _, err := lockResult.Single(ctx); err != nil {
+			return nil, errors.ErrTenantNotFound
+		}
 
-		checkRecord, err := checkResult.Single(ctx)
+		pending, err := r.countPendingTx(ctx, tx, tenantID, sentAfter)
 		if err != nil {
 			return nil, err
 		}
-
-		if exists, _ := checkRecord.Get("exists"); exists.(bool) {
-			return nil, errors.ErrAlreadyMember
+		if pending >= limit {
+			return nil, errors.ErrInviteLimitReached
 		}
+		return r.createTx(ctx, tx, membershipID, userID, tenantID, role, invitedByID, model.MembershipStatusPending)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.Membership), nil
+}
 
-		// Create the membership
-		params := map[string]any{
-			"membershipID": membershipID,
-			"userID":       userID,
-			"tenantID":     tenantID,
-			"role":         string(role),
-		}
+// create creates a membership with the given status in its own transaction.
+func (r *MembershipRepository) create(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string, status model.MembershipStatus) (*model.Membership, error) {
+	membershipID := uuid.New().String()
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return r.createTx(ctx, tx, membershipID, userID, tenantID, role, invitedByID, status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.Membership), nil
+}
 
-		query := `
-			MATCH (u:User {id: $userID}), (t:Tenant {id: $tenantID})
-			CREATE (m:Membership {id: $membershipID, role: $role, joinedAt: datetime()})
-			CREATE (u)-[:HAS_MEMBERSHIP]->(m)-[:IN_TENANT]->(t)
-			RETURN m, u, t
-		`
+// createTx creates a membership with the given status in tx, recording
+// MemberAdded for active memberships and MemberInvited for pending ones.
+// The tenant's stored memberCount is incremented in the same transaction.
+func (r *MembershipRepository) createTx(ctx context.Context, tx neo4j.ManagedTransaction, membershipID, userID, tenantID string, role model.MembershipRole, invitedByID *string, status model.MembershipStatus) (*model.Membership, error) {
+	eventType := events.MemberAdded
+	if status == model.MembershipStatusPending {
+		eventType = events.MemberInvited
+	}
 
-		result, err := tx.Run(ctx, query, params)
-		if err != nil {
-			return nil, err
-		}
+	// Check if user is already a member
+	checkResult, err := tx.Run(ctx, `
+		MATCH (u:User {id: $userID})-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t:Tenant {id: $tenantID})
+		RETURN count(m) > 0 as exists
+	`, map[string]any{"userID": userID, "tenantID": tenantID})
+	if err != nil {
+		return nil, err
+	}
 
-		record, err := result.Single(ctx)
+	checkRecord, err := checkResult.Single(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists, _ := checkRecord.Get("exists"); exists.(bool) {
+		return nil, errors.ErrAlreadyMember
+	}
+
+	// Create the membership
+	params := map[string]any{
+		"membershipID": membershipID,
+		"userID":       userID,
+		"tenantID":     tenantID,
+		"role":         string(role),
+		"status":       string(status),
+	}
+
+	query := `
+		MATCH (u:User {id: $userID}), (t:Tenant {id: $tenantID})
+		CREATE (m:Membership {id: $membershipID, role: $role, status: $status, joinedAt: datetime()})
+		CREATE (u)-[:HAS_MEMBERSHIP]->(m)-[:IN_TENANT]->(t)
+		SET t.memberCount = coalesce(t.memberCount, 0) + CASE WHEN u.status = 'DELETED' THEN 0 ELSE 1 END
+		RETURN m, u, t
+	`
+
+	result, err := tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// If there's an inviter, create the INVITED relationship
+	if invitedByID != nil && *invitedByID != "" {
+		_, err = tx.Run(ctx, `
+			MATCH (inviter:User {id: $inviterID}), (m:Membership {id: $membershipID})
+			MERGE (inviter)-[r:INVITED]->(m)
+			SET r.invitedAt = datetime()
+		`, map[string]any{"inviterID": *invitedByID, "membershipID": membershipID})
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		// If there's an inviter, create the INVITED relationship
-		if invitedByID != nil && *invitedByID != "" {
-			_, err = tx.Run(ctx, `
-				MATCH (inviter:User {id: $inviterID}), (m:Membership {id: $membershipID})
-				CREATE (inviter)-[:INVITED]->(m)
-			`, map[string]any{"inviterID": *invitedByID, "membershipID": membershipID})
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		return r.mapRecordToMembershipBasic(record)
-	})
-	if err != nil {
+	if err := shared.WriteOutboxEvent(ctx, tx, eventType, membershipID, map[string]any{
+		"membershipId": membershipID,
+		"tenantId":     tenantID,
+		"userId":       userID,
+		"role":         string(role),
+	}); err != nil {
 		return nil, err
 	}
-	return result.(*model.Membership), nil
+
+	return r.mapRecordToMembershipBasic(record)
 }
 
-// UpdateRole updates a membership's role.
-func (r *MembershipRepository) UpdateRole(ctx context.Context, id string, role model.MembershipRole) (*model.Membership, error) {
+// Accept marks a pending membership as ACTIVE.
+func (r *MembershipRepository) Accept(ctx context.Context, id string) (*model.Membership, error) {
 	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, `
 			MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {id: $id})-[:IN_TENANT]->(t:Tenant)
-			SET m.role = $role
+			SET m.status = 'ACTIVE'
 			OPTIONAL MATCH (inviter:User)-[:INVITED]->(m)
 			RETURN m, u, t, inviter
-		`, map[string]any{"id": id, "role": string(role)})
+		`, map[string]any{"id": id})
 		if err != nil {
 			return nil, err
 		}
@@ -218,7 +520,23 @@ func (r *MembershipRepository) UpdateRole(ctx context.Context, id string, role m
 			return nil, errors.ErrMembershipNotFound
 		}
 
-		return r.mapRecordToMembership(record)
+		membership, err := r.mapRecordToMembership(record)
+		if err != nil {
+			return nil, err
+		}
+
+		// The invitee only becomes a member now, so the matching
+		// membership.invited event is followed by membership.created
+		if err := shared.WriteOutboxEvent(ctx, tx, events.MemberAdded, id, map[string]any{
+			"membershipId": id,
+			"tenantId":     membership.Tenant.ID,
+			"userId":       membership.User.ID,
+			"role":         string(membership.Role),
+		}); err != nil {
+			return nil, err
+		}
+
+		return membership, nil
 	})
 	if err != nil {
 		return nil, err
@@ -226,46 +544,184 @@ func (r *MembershipRepository) UpdateRole(ctx context.Context, id string, role m
 	return result.(*model.Membership), nil
 }
 
-// Delete removes a membership.
+// UpdateRole updates a membership's role.
+func (r *MembershipRepository) UpdateRole(ctx context.Context, id string, role model.MembershipRole) (*model.Membership, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return r.UpdateRoleTx(ctx, tx, id, role)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.Membership), nil
+}
+
+// UpdateRoleTx updates a membership's role in tx, leaving commit or
+// rollback to the caller.
+func (r *MembershipRepository) UpdateRoleTx(ctx context.Context, tx neo4j.ManagedTransaction, id string, role model.MembershipRole) (*model.Membership, error) {
+	result, err := tx.Run(ctx, `
+		MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {id: $id})-[:IN_TENANT]->(t:Tenant)
+		SET m.role = $role
+		OPTIONAL MATCH (inviter:User)-[:INVITED]->(m)
+		RETURN m, u, t, inviter
+	`, map[string]any{"id": id, "role": string(role)})
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return nil, errors.ErrMembershipNotFound
+	}
+
+	membership, err := r.mapRecordToMembership(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := shared.WriteOutboxEvent(ctx, tx, events.MemberRoleChanged, id, map[string]any{
+		"membershipId": id,
+		"tenantId":     membership.Tenant.ID,
+		"userId":       membership.User.ID,
+		"role":         string(role),
+	}); err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// Delete removes a membership and decrements the tenant's stored memberCount.
 func (r *MembershipRepository) Delete(ctx context.Context, id string) error {
 	_, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return nil, r.DeleteTx(ctx, tx, id)
+	})
+	return err
+}
+
+// DeleteTx removes a membership in tx, leaving commit or rollback to the
+// caller.
+func (r *MembershipRepository) DeleteTx(ctx context.Context, tx neo4j.ManagedTransaction, id string) error {
+	result, err := tx.Run(ctx, `
+		MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {id: $id})-[:IN_TENANT]->(t:Tenant)
+		SET t.memberCount = coalesce(t.memberCount, 1) - CASE WHEN u.status = 'DELETED' THEN 0 ELSE 1 END
+		DETACH DELETE m
+		RETURN u.id as userId, t.id as tenantId
+	`, map[string]any{"id": id})
+	if err != nil {
+		return err
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return errors.ErrMembershipNotFound
+	}
+
+	userID, _ := record.Get("userId")
+	tenantID, _ := record.Get("tenantId")
+	return shared.WriteOutboxEvent(ctx, tx, events.MemberRemoved, id, map[string]any{
+		"membershipId": id,
+		"tenantId":     tenantID,
+		"userId":       userID,
+	})
+}
+
+// CountOwners returns the number of active owners in a tenant. Pending
+// OWNER invites and deleted users don't count.
+func (r *MembershipRepository) CountOwners(ctx context.Context, tenantID string) (int, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return r.CountOwnersTx(ctx, tx, tenantID)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// CountOwnersTx returns the number of active owners in a tenant, reading
+// in tx.
+func (r *MembershipRepository) CountOwnersTx(ctx context.Context, tx neo4j.ManagedTransaction, tenantID string) (int, error) {
+	result, err := tx.Run(ctx, `
+		MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {role: 'OWNER'})-[:IN_TENANT]->(t:Tenant {id: $tenantID})
+		WHERE u.status <> 'DELETED' AND m.status = 'ACTIVE'
+		RETURN count(m) as count
+	`, map[string]any{"tenantID": tenantID})
+	if err != nil {
+		return 0, err
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return 0, nil
+	}
+
+	count, _ := record.Get("count")
+	return int(count.(int64)), nil
+}
+
+// FindPendingByTenantID retrieves a tenant's pending memberships sent after
+// sentAfter, newest first.
+func (r *MembershipRepository) FindPendingByTenantID(ctx context.Context, tenantID string, sentAfter time.Time) ([]*model.Membership, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, `
-			MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {id: $id})-[:IN_TENANT]->(t:Tenant)
-			DETACH DELETE m
-			RETURN u.id as userId
-		`, map[string]any{"id": id})
+			MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {status: 'PENDING'})-[:IN_TENANT]->(t:Tenant {id: $tenantID})
+			WHERE u.status <> 'DELETED' AND m.joinedAt > $sentAfter
+			OPTIONAL MATCH (inviter:User)-[:INVITED]->(m)
+			RETURN m, u, t, inviter
+			ORDER BY m.joinedAt DESC
+		`, map[string]any{"tenantID": tenantID, "sentAfter": sentAfter})
 		if err != nil {
 			return nil, err
 		}
 
-		_, err = result.Single(ctx)
-		if err != nil {
-			return nil, errors.ErrMembershipNotFound
+		memberships := []*model.Membership{}
+		for result.Next(ctx) {
+			membership, err := r.mapRecordToMembership(result.Record())
+			if err != nil {
+				return nil, err
+			}
+			memberships = append(memberships, membership)
 		}
 
-		return nil, nil
+		return memberships, result.Err()
 	})
-	return err
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*model.Membership), nil
 }
 
-// CountOwners returns the number of owners in a tenant.
-func (r *MembershipRepository) CountOwners(ctx context.Context, tenantID string) (int, error) {
+// CountByRole counts a tenant's active members per role in one grouped query.
+func (r *MembershipRepository) CountByRole(ctx context.Context, tenantID string) (map[model.MembershipRole]int, error) {
 	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, `
-			MATCH (m:Membership {role: 'OWNER'})-[:IN_TENANT]->(t:Tenant {id: $tenantID})
-			RETURN count(m) as count
+			MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {status: 'ACTIVE'})-[:IN_TENANT]->(t:Tenant {id: $tenantID})
+			WHERE u.status <> 'DELETED'
+			RETURN m.role as role, count(*) as count
 		`, map[string]any{"tenantID": tenantID})
 		if err != nil {
 			return nil, err
 		}
 
-		record, err := result.Single(ctx)
-		if err != nil {
-			return 0, nil
+		counts := make(map[model.MembershipRole]int)
+		for result.Next(ctx) {
+			record := result.Record()
+			role, _ := record.Get("role")
+			count, _ := record.Get("count")
+			counts[model.MembershipRole(role.(string))] = int(count.(int64))
 		}
+		return counts, result.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[model.MembershipRole]int), nil
+}
 
-		count, _ := record.Get("count")
-		return int(count.(int64)), nil
+// CountPending returns the number of pending memberships in a tenant sent
+// after sentAfter.
+func (r *MembershipRepository) CountPending(ctx context.Context, tenantID string, sentAfter time.Time) (int, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return r.countPendingTx(ctx, tx, tenantID, sentAfter)
 	})
 	if err != nil {
 		return 0, err
@@ -273,6 +729,27 @@ func (r *MembershipRepository) CountOwners(ctx context.Context, tenantID string)
 	return result.(int), nil
 }
 
+// countPendingTx counts the pending memberships FindPendingByTenantID
+// would return, in tx.
+func (r *MembershipRepository) countPendingTx(ctx context.Context, tx neo4j.ManagedTransaction, tenantID string, sentAfter time.Time) (int, error) {
+	result, err := tx.Run(ctx, `
+		MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {status: 'PENDING'})-[:IN_TENANT]->(t:Tenant {id: $tenantID})
+		WHERE u.status <> 'DELETED' AND m.joinedAt > $sentAfter
+		RETURN count(m) as count
+	`, map[string]any{"tenantID": tenantID, "sentAfter": sentAfter})
+	if err != nil {
+		return 0, err
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return 0, nil
+	}
+
+	count, _ := record.Get("count")
+	return int(count.(int64)), nil
+}
+
 // GetTenantIDByMembershipID returns the tenant ID for a membership.
 func (r *MembershipRepository) GetTenantIDByMembershipID(ctx context.Context, membershipID string) (string, error) {
 	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
@@ -323,6 +800,199 @@ func (r *MembershipRepository) GetUserIDByMembershipID(ctx context.Context, memb
 	return result.(string), nil
 }
 
+// SetInviter makes inviterID the membership's only inviter, replacing any
+// existing INVITED relationships.
+func (r *MembershipRepository) SetInviter(ctx context.Context, membershipID, inviterID string) (*model.Membership, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {id: $membershipID})-[:IN_TENANT]->(t:Tenant)
+			MATCH (inviter:User {id: $inviterID})
+			OPTIONAL MATCH (previous:User)-[old:INVITED]->(m)
+			WHERE previous.id <> $inviterID
+			DELETE old
+			WITH DISTINCT u, m, t, inviter
+			MERGE (inviter)-[r:INVITED]->(m)
+			SET r.invitedAt = datetime(), m.joinedAt = datetime()
+			RETURN m, u, t, inviter
+		`, map[string]any{"membershipID": membershipID, "inviterID": inviterID})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrMembershipNotFound
+		}
+
+		return r.mapRecordToMembership(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.Membership), nil
+}
+
+// RenewInvite records that a pending membership's invite was sent again now.
+func (r *MembershipRepository) RenewInvite(ctx context.Context, membershipID string) (*model.Membership, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User)-[:HAS_MEMBERSHIP]->(m:Membership {id: $membershipID, status: 'PENDING'})-[:IN_TENANT]->(t:Tenant)
+			SET m.joinedAt = datetime()
+			WITH u, m, t
+			OPTIONAL MATCH (inviter:User)-[:INVITED]->(m)
+			RETURN m, u, t, inviter
+		`, map[string]any{"membershipID": membershipID})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrMembershipNotFound
+		}
+
+		return r.mapRecordToMembership(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.Membership), nil
+}
+
+// ReassignInvites repoints INVITED relationships created by one user within
+// a tenant. Accepted memberships are repointed as well as pending invites,
+// so invite chains stay intact after the inviter leaves.
+func (r *MembershipRepository) ReassignInvites(ctx context.Context, fromUserID, toUserID, tenantID string) (int, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return r.ReassignInvitesTx(ctx, tx, fromUserID, toUserID, tenantID)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// ReassignInvitesTx repoints invites in tx, leaving commit or rollback to
+// the caller. The new inviter's own membership, if the departing user
+// invited them, is detached rather than made self-invited.
+func (r *MembershipRepository) ReassignInvitesTx(ctx context.Context, tx neo4j.ManagedTransaction, fromUserID, toUserID, tenantID string) (int, error) {
+	result, err := tx.Run(ctx, `
+		MATCH (from:User {id: $fromUserID})-[r:INVITED]->(m:Membership)-[:IN_TENANT]->(t:Tenant {id: $tenantID})
+		MATCH (to:User {id: $toUserID})
+		FOREACH (_ IN CASE WHEN EXISTS { (to)-[:HAS_MEMBERSHIP]->(m) } THEN [] ELSE [1] END |
+			MERGE (to)-[:INVITED]->(m)
+		)
+		DELETE r
+		RETURN count(m) as count
+	`, map[string]any{"fromUserID": fromUserID, "toUserID": toUserID, "tenantID": tenantID})
+	if err != nil {
+		return 0, err
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count, _ := record.Get("count")
+	return int(count.(int64)), nil
+}
+
+// DetachInvites deletes INVITED relationships created by one user within a tenant.
+func (r *MembershipRepository) DetachInvites(ctx context.Context, fromUserID, tenantID string) (int, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return r.DetachInvitesTx(ctx, tx, fromUserID, tenantID)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// DetachInvitesTx deletes a user's invites within a tenant in tx, leaving
+// commit or rollback to the caller.
+func (r *MembershipRepository) DetachInvitesTx(ctx context.Context, tx neo4j.ManagedTransaction, fromUserID, tenantID string) (int, error) {
+	result, err := tx.Run(ctx, `
+		MATCH (from:User {id: $fromUserID})-[r:INVITED]->(m:Membership)-[:IN_TENANT]->(t:Tenant {id: $tenantID})
+		DELETE r
+		RETURN count(m) as count
+	`, map[string]any{"fromUserID": fromUserID, "tenantID": tenantID})
+	if err != nil {
+		return 0, err
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count, _ := record.Get("count")
+	return int(count.(int64)), nil
+}
+
+// GetInviteChain walks INVITED relationships upward from a membership.
+// The chain stops at the first inviter who is no longer a member of the tenant.
+func (r *MembershipRepository) GetInviteChain(ctx context.Context, membershipID string, maxHops int) ([]*model.User, bool, error) {
+	if maxHops < 1 {
+		return nil, false, errors.NewValidationError("maxHops", "must be at least 1")
+	}
+
+	// Quantifier bounds can't be parameters; maxHops is an int so inlining is safe.
+	// Fetch one extra hop so the Go side can tell a capped chain from a complete one.
+	query := fmt.Sprintf(`
+		MATCH (start:Membership {id: $id})-[:IN_TENANT]->(t:Tenant)
+		OPTIONAL MATCH (start) ((a:Membership)<-[:INVITED]-(u:User)-[:HAS_MEMBERSHIP]->(b:Membership)){1,%d}
+		WHERE all(x IN b WHERE (x)-[:IN_TENANT]->(t))
+		WITH u AS inviters
+		ORDER BY size(inviters) DESC
+		LIMIT 1
+		RETURN inviters
+	`, maxHops+1)
+
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, map[string]any{"id": membershipID})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrMembershipNotFound
+		}
+
+		users := []*model.User{}
+		nodes, _ := record.Values[0].([]any)
+		for _, value := range nodes {
+			node, ok := value.(neo4j.Node)
+			if !ok {
+				continue
+			}
+			users = append(users, mapNodeToUser(node))
+		}
+		return users, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	inviters, truncated := boundInviteChain(result.([]*model.User), maxHops)
+	return inviters, truncated, nil
+}
+
+// boundInviteChain caps a chain at maxHops and cuts it at the first repeated
+// user, guarding against cycles in corrupted data.
+// Returns the bounded chain and whether anything was dropped.
+func boundInviteChain(chain []*model.User, maxHops int) ([]*model.User, bool) {
+	seen := make(map[string]bool, len(chain))
+	for i, user := range chain {
+		if i == maxHops || seen[user.ID] {
+			return chain[:i], true
+		}
+		seen[user.ID] = true
+	}
+	return chain, false
+}
+
 // mapRecordToMembership converts a Neo4j record to a Membership model.
 func (r *MembershipRepository) mapRecordToMembership(record *neo4j.Record) (*model.Membership, error) {
 	mVal, ok := record.Get("m")
@@ -334,8 +1004,9 @@ func (r *MembershipRepository) mapRecordToMembership(record *neo4j.Record) (*mod
 	mProps := mNode.Props
 
 	membership := &model.Membership{
-		ID:   mProps["id"].(string),
-		Role: model.MembershipRole(mProps["role"].(string)),
+		ID:     mProps["id"].(string),
+		Role:   model.MembershipRole(mProps["role"].(string)),
+		Status: mapMembershipStatus(mProps),
 	}
 
 	if joinedAt, ok := mProps["joinedAt"]; ok {
@@ -404,8 +1075,9 @@ func (r *MembershipRepository) mapRecordToMembershipBasic(record *neo4j.Record)
 	mProps := mNode.Props
 
 	membership := &model.Membership{
-		ID:   mProps["id"].(string),
-		Role: model.MembershipRole(mProps["role"].(string)),
+		ID:     mProps["id"].(string),
+		Role:   model.MembershipRole(mProps["role"].(string)),
+		Status: mapMembershipStatus(mProps),
 	}
 
 	if joinedAt, ok := mProps["joinedAt"]; ok {
@@ -446,3 +1118,27 @@ func (r *MembershipRepository) mapRecordToMembershipBasic(record *neo4j.Record)
 
 // Ensure MembershipRepository implements IMembershipRepository
 var _ IMembershipRepository = (*MembershipRepository)(nil)
+
+// mapMembershipStatus reads a membership's status; memberships created
+// before statuses existed are active.
+func mapMembershipStatus(props map[string]any) model.MembershipStatus {
+	if status, ok := props["status"].(string); ok {
+		return model.MembershipStatus(status)
+	}
+	return model.MembershipStatusActive
+}
+
+// mapNodeToUser maps a User node to a model.User.
+func mapNodeToUser(node neo4j.Node) *model.User {
+	props := node.Props
+	user := &model.User{
+		ID:     props["id"].(string),
+		Email:  props["email"].(string),
+		Status: model.UserStatus(props["status"].(string)),
+	}
+	if name, ok := props["name"]; ok && name != nil {
+		nameStr := name.(string)
+		user.Name = &nameStr
+	}
+	return user
+}
@@ -2,11 +2,16 @@ package repository
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/events"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 )
 
@@ -19,17 +24,47 @@ type MockMembershipRepository struct {
 	byTenant map[string][]string // tenantID -> []membershipID
 	byUser   map[string][]string // userID -> []membershipID
 
+	// inviteMu serializes CreatePendingWithinLimit, as the tenant lock does
+	inviteMu sync.Mutex
+
 	// Function overrides for testing specific behaviors
 	FindByIDFunc                  func(ctx context.Context, id string) (*model.Membership, error)
 	FindByTenantIDFunc            func(ctx context.Context, tenantID string) ([]*model.Membership, error)
-	FindByUserIDFunc              func(ctx context.Context, userID string) ([]*model.Membership, error)
+	StreamMembersFunc             func(ctx context.Context, tenantID string, fn func(*model.Membership) error) error
+	FindByUserIDFunc              func(ctx context.Context, userID string, status *model.MembershipStatus, limit, offset int) ([]*model.Membership, error)
+	ListMembersFunc               func(ctx context.Context, tenantID string, filter MemberFilter) ([]*model.Membership, error)
+	CountRolesByUserIDFunc        func(ctx context.Context, userID string) (map[model.MembershipRole]int, error)
+	FindOwnersByTenantIDFunc      func(ctx context.Context, tenantID string) ([]*model.Membership, error)
+	FindAdminsOfUserTenantsFunc   func(ctx context.Context, userID string) ([]*model.Membership, error)
 	FindByUserAndTenantFunc       func(ctx context.Context, userID, tenantID string) (*model.Membership, error)
 	CreateFunc                    func(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error)
+	CreateTxFunc                  func(ctx context.Context, tx neo4j.ManagedTransaction, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error)
+	CreatePendingFunc             func(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error)
+	AcceptFunc                    func(ctx context.Context, id string) (*model.Membership, error)
 	UpdateRoleFunc                func(ctx context.Context, id string, role model.MembershipRole) (*model.Membership, error)
+	UpdateRoleTxFunc              func(ctx context.Context, tx neo4j.ManagedTransaction, id string, role model.MembershipRole) (*model.Membership, error)
 	DeleteFunc                    func(ctx context.Context, id string) error
+	DeleteTxFunc                  func(ctx context.Context, tx neo4j.ManagedTransaction, id string) error
 	CountOwnersFunc               func(ctx context.Context, tenantID string) (int, error)
+	CountOwnersTxFunc             func(ctx context.Context, tx neo4j.ManagedTransaction, tenantID string) (int, error)
+	CountByRoleFunc               func(ctx context.Context, tenantID string) (map[model.MembershipRole]int, error)
+	CountPendingFunc              func(ctx context.Context, tenantID string, sentAfter time.Time) (int, error)
 	GetTenantIDByMembershipIDFunc func(ctx context.Context, membershipID string) (string, error)
 	GetUserIDByMembershipIDFunc   func(ctx context.Context, membershipID string) (string, error)
+	SetInviterFunc                func(ctx context.Context, membershipID, inviterID string) (*model.Membership, error)
+	RenewInviteFunc               func(ctx context.Context, membershipID string) (*model.Membership, error)
+	ReassignInvitesFunc           func(ctx context.Context, fromUserID, toUserID, tenantID string) (int, error)
+	ReassignInvitesTxFunc         func(ctx context.Context, tx neo4j.ManagedTransaction, fromUserID, toUserID, tenantID string) (int, error)
+	DetachInvitesFunc             func(ctx context.Context, fromUserID, tenantID string) (int, error)
+	DetachInvitesTxFunc           func(ctx context.Context, tx neo4j.ManagedTransaction, fromUserID, tenantID string) (int, error)
+	GetInviteChainFunc            func(ctx context.Context, membershipID string, maxHops int) ([]*model.User, bool, error)
+
+	// Outbox, when set, receives the events the Neo4j implementation would write
+	Outbox *shared.MockOutboxRepository
+
+	// Tenants, when set, has its member counts kept up to date the way the
+	// Neo4j implementation maintains Tenant.memberCount
+	Tenants *MockTenantRepository
 }
 
 // NewMockMembershipRepository creates a new MockMembershipRepository.
@@ -41,11 +76,17 @@ func NewMockMembershipRepository() *MockMembershipRepository {
 	}
 }
 
-// AddMembership adds a membership to the mock repository for testing.
+// AddMembership adds a membership to the mock repository for testing. A
+// membership without a status is active, as when the real repository reads
+// one created before statuses existed.
 func (m *MockMembershipRepository) AddMembership(membership *model.Membership) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if membership.Status == "" {
+		membership.Status = model.MembershipStatusActive
+	}
+
 	m.memberships[membership.ID] = membership
 
 	// Update indexes
@@ -105,10 +146,34 @@ func (m *MockMembershipRepository) FindByTenantID(ctx context.Context, tenantID
 	return memberships, nil
 }
 
-// FindByUserID retrieves all memberships for a user.
-func (m *MockMembershipRepository) FindByUserID(ctx context.Context, userID string) ([]*model.Membership, error) {
+// StreamMembers calls fn for each membership of a tenant.
+// The lock is released before fn runs so callbacks may use the repository.
+func (m *MockMembershipRepository) StreamMembers(ctx context.Context, tenantID string, fn func(*model.Membership) error) error {
+	if m.StreamMembersFunc != nil {
+		return m.StreamMembersFunc(ctx, tenantID, fn)
+	}
+
+	m.mu.RLock()
+	var memberships []*model.Membership
+	for _, id := range m.byTenant[tenantID] {
+		if membership, ok := m.memberships[id]; ok {
+			memberships = append(memberships, membership)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, membership := range memberships {
+		if err := fn(membership); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByUserID retrieves a page of a user's memberships, optionally filtered by status.
+func (m *MockMembershipRepository) FindByUserID(ctx context.Context, userID string, status *model.MembershipStatus, limit, offset int) ([]*model.Membership, error) {
 	if m.FindByUserIDFunc != nil {
-		return m.FindByUserIDFunc(ctx, userID)
+		return m.FindByUserIDFunc(ctx, userID, status, limit, offset)
 	}
 
 	m.mu.RLock()
@@ -121,11 +186,191 @@ func (m *MockMembershipRepository) FindByUserID(ctx context.Context, userID stri
 
 	var memberships []*model.Membership
 	for _, id := range membershipIDs {
-		if membership, ok := m.memberships[id]; ok {
+		if membership, ok := m.memberships[id]; ok && (status == nil || membership.Status == *status) {
 			memberships = append(memberships, membership)
 		}
 	}
-	return memberships, nil
+
+	// Mirror the real repository's ORDER BY so pages are deterministic
+	sort.Slice(memberships, func(i, j int) bool {
+		if !memberships[i].JoinedAt.Equal(memberships[j].JoinedAt) {
+			return memberships[i].JoinedAt.After(memberships[j].JoinedAt)
+		}
+		return memberships[i].ID < memberships[j].ID
+	})
+	return paginate(memberships, limit, offset), nil
+}
+
+// ListMembers retrieves a page of a tenant's members narrowed by filter.
+func (m *MockMembershipRepository) ListMembers(ctx context.Context, tenantID string, filter MemberFilter) ([]*model.Membership, error) {
+	if m.ListMembersFunc != nil {
+		return m.ListMembersFunc(ctx, tenantID, filter)
+	}
+	if _, err := filter.orderBy(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	search := strings.ToLower(filter.Search)
+	memberships := []*model.Membership{}
+	for _, id := range m.byTenant[tenantID] {
+		membership, ok := m.memberships[id]
+		if !ok || userDeleted(membership.User) || (filter.Role != nil && membership.Role != *filter.Role) {
+			continue
+		}
+		if search != "" && !memberMatches(membership.User, search) {
+			continue
+		}
+		memberships = append(memberships, membership)
+	}
+
+	// Mirror the real repository's ORDER BY so pages are deterministic
+	sort.Slice(memberships, func(i, j int) bool {
+		a, b := memberships[i], memberships[j]
+		switch filter.Sort {
+		case MemberSortJoinedAsc:
+			if !a.JoinedAt.Equal(b.JoinedAt) {
+				return a.JoinedAt.Before(b.JoinedAt)
+			}
+		case MemberSortName:
+			if an, bn := memberSortName(a.User), memberSortName(b.User); an != bn {
+				return an < bn
+			}
+		default:
+			if !a.JoinedAt.Equal(b.JoinedAt) {
+				return a.JoinedAt.After(b.JoinedAt)
+			}
+		}
+		return a.ID < b.ID
+	})
+	return paginate(memberships, filter.Limit, filter.Offset), nil
+}
+
+// userDeleted reports whether user has been deleted, which the real
+// repository's queries leave out.
+func userDeleted(user *model.User) bool {
+	return user != nil && user.Status == model.UserStatusDeleted
+}
+
+// memberMatches reports whether user's email or name contains the lowercased
+// search term.
+func memberMatches(user *model.User, search string) bool {
+	if user == nil {
+		return false
+	}
+	if strings.Contains(strings.ToLower(user.Email), search) {
+		return true
+	}
+	return user.Name != nil && strings.Contains(strings.ToLower(*user.Name), search)
+}
+
+// memberSortName is the key MemberSortName orders by: the lowercased name,
+// or the email for users without one.
+func memberSortName(user *model.User) string {
+	if user == nil {
+		return ""
+	}
+	if user.Name != nil {
+		return strings.ToLower(*user.Name)
+	}
+	return strings.ToLower(user.Email)
+}
+
+// CountRolesByUserID counts a user's active memberships per role.
+func (m *MockMembershipRepository) CountRolesByUserID(ctx context.Context, userID string) (map[model.MembershipRole]int, error) {
+	if m.CountRolesByUserIDFunc != nil {
+		return m.CountRolesByUserIDFunc(ctx, userID)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	counts := make(map[model.MembershipRole]int)
+	for _, id := range m.byUser[userID] {
+		if membership, ok := m.memberships[id]; ok && membership.Status == model.MembershipStatusActive {
+			counts[membership.Role]++
+		}
+	}
+	return counts, nil
+}
+
+// RolesByUserID returns a user's active roles keyed by tenant ID.
+func (m *MockMembershipRepository) RolesByUserID(ctx context.Context, userID string) (map[string]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	roles := make(map[string]string)
+	for _, id := range m.byUser[userID] {
+		if membership, ok := m.memberships[id]; ok && membership.Status == model.MembershipStatusActive {
+			roles[membership.Tenant.ID] = string(membership.Role)
+		}
+	}
+	return roles, nil
+}
+
+// FindOwnersByTenantID retrieves the active OWNER memberships of a tenant
+// ordered by joinedAt.
+func (m *MockMembershipRepository) FindOwnersByTenantID(ctx context.Context, tenantID string) ([]*model.Membership, error) {
+	if m.FindOwnersByTenantIDFunc != nil {
+		return m.FindOwnersByTenantIDFunc(ctx, tenantID)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	owners := []*model.Membership{}
+	for _, id := range m.byTenant[tenantID] {
+		if membership, ok := m.memberships[id]; ok && isActiveOwner(membership) {
+			owners = append(owners, membership)
+		}
+	}
+
+	sort.SliceStable(owners, func(i, j int) bool {
+		return owners[i].JoinedAt.Before(owners[j].JoinedAt)
+	})
+	return owners, nil
+}
+
+// FindAdminsOfUserTenants retrieves the active OWNER and ADMIN memberships
+// of other users in the user's active, non-deleted tenants.
+func (m *MockMembershipRepository) FindAdminsOfUserTenants(ctx context.Context, userID string) ([]*model.Membership, error) {
+	if m.FindAdminsOfUserTenantsFunc != nil {
+		return m.FindAdminsOfUserTenantsFunc(ctx, userID)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	admins := []*model.Membership{}
+	for _, id := range m.byUser[userID] {
+		mine, ok := m.memberships[id]
+		if !ok || mine.Status != model.MembershipStatusActive || mine.Tenant.Status == model.TenantStatusDeleted {
+			continue
+		}
+		for _, otherID := range m.byTenant[mine.Tenant.ID] {
+			membership, ok := m.memberships[otherID]
+			if !ok || membership.User.ID == userID || membership.Status != model.MembershipStatusActive {
+				continue
+			}
+			if membership.Role == model.MembershipRoleOwner || membership.Role == model.MembershipRoleAdmin {
+				admins = append(admins, membership)
+			}
+		}
+	}
+
+	sort.SliceStable(admins, func(i, j int) bool {
+		a, b := admins[i], admins[j]
+		if a.Tenant.ID != b.Tenant.ID {
+			return a.Tenant.ID < b.Tenant.ID
+		}
+		if a.Role != b.Role {
+			return a.Role == model.MembershipRoleOwner
+		}
+		return a.JoinedAt.Before(b.JoinedAt)
+	})
+	return admins, nil
 }
 
 // FindByUserAndTenant retrieves a membership by user and tenant.
@@ -153,6 +398,76 @@ func (m *MockMembershipRepository) Create(ctx context.Context, userID, tenantID
 		return m.CreateFunc(ctx, userID, tenantID, role, invitedByID)
 	}
 
+	return m.create(userID, tenantID, role, invitedByID, model.MembershipStatusActive)
+}
+
+// CreateTx creates a new ACTIVE membership in tx. A MockUnitOfWork removes
+// it again if tx rolls back and only records MemberAdded once tx commits.
+func (m *MockMembershipRepository) CreateTx(ctx context.Context, tx neo4j.ManagedTransaction, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error) {
+	if m.CreateTxFunc != nil {
+		return m.CreateTxFunc(ctx, tx, userID, tenantID, role, invitedByID)
+	}
+
+	membership, err := m.store(userID, tenantID, role, invitedByID, model.MembershipStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	shared.TrackMockChange(tx, func() {
+		m.mu.Lock()
+		m.removeMembership(membership)
+		m.mu.Unlock()
+		if m.Tenants != nil {
+			m.Tenants.adjustMemberCount(tenantID, -1)
+		}
+	}, func() {
+		m.recordEvent(events.MemberAdded, membership)
+	})
+	return membership, nil
+}
+
+// CreatePending creates a new PENDING membership.
+func (m *MockMembershipRepository) CreatePending(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error) {
+	if m.CreatePendingFunc != nil {
+		return m.CreatePendingFunc(ctx, userID, tenantID, role, invitedByID)
+	}
+
+	return m.create(userID, tenantID, role, invitedByID, model.MembershipStatusPending)
+}
+
+// CreatePendingWithinLimit creates a new PENDING membership unless the
+// tenant already has limit pending invites sent after sentAfter.
+func (m *MockMembershipRepository) CreatePendingWithinLimit(ctx context.Context, userID, tenantID string, role model.MembershipRole, invitedByID *string, limit int, sentAfter time.Time) (*model.Membership, error) {
+	m.inviteMu.Lock()
+	defer m.inviteMu.Unlock()
+
+	pending, err := m.CountPending(ctx, tenantID, sentAfter)
+	if err != nil {
+		return nil, err
+	}
+	if pending >= limit {
+		return nil, errors.ErrInviteLimitReached
+	}
+	return m.CreatePending(ctx, userID, tenantID, role, invitedByID)
+}
+
+// create stores a membership with the given status and records its event.
+func (m *MockMembershipRepository) create(userID, tenantID string, role model.MembershipRole, invitedByID *string, status model.MembershipStatus) (*model.Membership, error) {
+	membership, err := m.store(userID, tenantID, role, invitedByID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == model.MembershipStatusPending {
+		m.recordEvent(events.MemberInvited, membership)
+	} else {
+		m.recordEvent(events.MemberAdded, membership)
+	}
+	return membership, nil
+}
+
+// store adds a membership with the given status, keeping the tenant's
+// member count current when Tenants is set.
+func (m *MockMembershipRepository) store(userID, tenantID string, role model.MembershipRole, invitedByID *string, status model.MembershipStatus) (*model.Membership, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -168,6 +483,7 @@ func (m *MockMembershipRepository) Create(ctx context.Context, userID, tenantID
 	membership := &model.Membership{
 		ID:       uuid.New().String(),
 		Role:     role,
+		Status:   status,
 		JoinedAt: time.Now(),
 		User:     &model.User{ID: userID},
 		Tenant:   &model.Tenant{ID: tenantID},
@@ -181,6 +497,28 @@ func (m *MockMembershipRepository) Create(ctx context.Context, userID, tenantID
 	m.byTenant[tenantID] = append(m.byTenant[tenantID], membership.ID)
 	m.byUser[userID] = append(m.byUser[userID], membership.ID)
 
+	if m.Tenants != nil {
+		m.Tenants.adjustMemberCount(tenantID, 1)
+	}
+	return membership, nil
+}
+
+// Accept marks a pending membership as ACTIVE.
+func (m *MockMembershipRepository) Accept(ctx context.Context, id string) (*model.Membership, error) {
+	if m.AcceptFunc != nil {
+		return m.AcceptFunc(ctx, id)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	membership, ok := m.memberships[id]
+	if !ok {
+		return nil, errors.ErrMembershipNotFound
+	}
+
+	membership.Status = model.MembershipStatusActive
+	m.recordEvent(events.MemberAdded, membership)
 	return membership, nil
 }
 
@@ -199,6 +537,35 @@ func (m *MockMembershipRepository) UpdateRole(ctx context.Context, id string, ro
 	}
 
 	membership.Role = role
+	m.recordEvent(events.MemberRoleChanged, membership)
+	return membership, nil
+}
+
+// UpdateRoleTx updates a membership's role in tx. A MockUnitOfWork restores
+// the previous role if tx rolls back and only records MemberRoleChanged
+// once tx commits.
+func (m *MockMembershipRepository) UpdateRoleTx(ctx context.Context, tx neo4j.ManagedTransaction, id string, role model.MembershipRole) (*model.Membership, error) {
+	if m.UpdateRoleTxFunc != nil {
+		return m.UpdateRoleTxFunc(ctx, tx, id, role)
+	}
+
+	m.mu.Lock()
+	membership, ok := m.memberships[id]
+	if !ok {
+		m.mu.Unlock()
+		return nil, errors.ErrMembershipNotFound
+	}
+	previous := membership.Role
+	membership.Role = role
+	m.mu.Unlock()
+
+	shared.TrackMockChange(tx, func() {
+		m.mu.Lock()
+		membership.Role = previous
+		m.mu.Unlock()
+	}, func() {
+		m.recordEvent(events.MemberRoleChanged, membership)
+	})
 	return membership, nil
 }
 
@@ -216,38 +583,145 @@ func (m *MockMembershipRepository) Delete(ctx context.Context, id string) error
 		return errors.ErrMembershipNotFound
 	}
 
-	// Remove from indexes
-	if membership.Tenant != nil {
-		m.byTenant[membership.Tenant.ID] = m.removeFromSlice(m.byTenant[membership.Tenant.ID], id)
+	m.removeMembership(membership)
+	m.recordEvent(events.MemberRemoved, membership)
+	if m.Tenants != nil && membership.Tenant != nil {
+		m.Tenants.adjustMemberCount(membership.Tenant.ID, -1)
 	}
-	if membership.User != nil {
-		m.byUser[membership.User.ID] = m.removeFromSlice(m.byUser[membership.User.ID], id)
+	return nil
+}
+
+// DeleteTx removes a membership in tx. A MockUnitOfWork restores it if tx
+// rolls back and only records MemberRemoved once tx commits.
+func (m *MockMembershipRepository) DeleteTx(ctx context.Context, tx neo4j.ManagedTransaction, id string) error {
+	if m.DeleteTxFunc != nil {
+		return m.DeleteTxFunc(ctx, tx, id)
+	}
+
+	m.mu.Lock()
+	membership, ok := m.memberships[id]
+	if !ok {
+		m.mu.Unlock()
+		return errors.ErrMembershipNotFound
+	}
+	m.removeMembership(membership)
+	m.mu.Unlock()
+	if m.Tenants != nil && membership.Tenant != nil {
+		m.Tenants.adjustMemberCount(membership.Tenant.ID, -1)
 	}
 
-	delete(m.memberships, id)
+	shared.TrackMockChange(tx, func() {
+		m.AddMembership(membership)
+		if m.Tenants != nil && membership.Tenant != nil {
+			m.Tenants.adjustMemberCount(membership.Tenant.ID, 1)
+		}
+	}, func() {
+		m.recordEvent(events.MemberRemoved, membership)
+	})
 	return nil
 }
 
-// CountOwners returns the number of owners in a tenant.
+// removeMembership deletes a membership and its index entries. The caller
+// must hold m.mu.
+func (m *MockMembershipRepository) removeMembership(membership *model.Membership) {
+	if membership.Tenant != nil {
+		m.byTenant[membership.Tenant.ID] = m.removeFromSlice(m.byTenant[membership.Tenant.ID], membership.ID)
+	}
+	if membership.User != nil {
+		m.byUser[membership.User.ID] = m.removeFromSlice(m.byUser[membership.User.ID], membership.ID)
+	}
+	delete(m.memberships, membership.ID)
+}
+
+// CountOwners returns the number of active owners in a tenant.
 func (m *MockMembershipRepository) CountOwners(ctx context.Context, tenantID string) (int, error) {
 	if m.CountOwnersFunc != nil {
 		return m.CountOwnersFunc(ctx, tenantID)
 	}
+	return m.countOwners(tenantID), nil
+}
+
+// CountOwnersTx returns the number of active owners in a tenant.
+func (m *MockMembershipRepository) CountOwnersTx(ctx context.Context, tx neo4j.ManagedTransaction, tenantID string) (int, error) {
+	if m.CountOwnersTxFunc != nil {
+		return m.CountOwnersTxFunc(ctx, tx, tenantID)
+	}
+	return m.countOwners(tenantID), nil
+}
 
+// countOwners implements CountOwners and CountOwnersTx.
+func (m *MockMembershipRepository) countOwners(tenantID string) int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	count := 0
-	membershipIDs, ok := m.byTenant[tenantID]
-	if !ok {
-		return 0, nil
+	for _, id := range m.byTenant[tenantID] {
+		if membership, ok := m.memberships[id]; ok && isActiveOwner(membership) {
+			count++
+		}
 	}
+	return count
+}
 
-	for _, id := range membershipIDs {
-		if membership, ok := m.memberships[id]; ok {
-			if membership.Role == model.MembershipRoleOwner {
-				count++
-			}
+// isActiveOwner reports whether membership is an accepted OWNER membership
+// of a user who hasn't been deleted.
+func isActiveOwner(membership *model.Membership) bool {
+	return membership.Role == model.MembershipRoleOwner &&
+		membership.Status == model.MembershipStatusActive &&
+		!userDeleted(membership.User)
+}
+
+// CountByRole counts a tenant's active members per role.
+func (m *MockMembershipRepository) CountByRole(ctx context.Context, tenantID string) (map[model.MembershipRole]int, error) {
+	if m.CountByRoleFunc != nil {
+		return m.CountByRoleFunc(ctx, tenantID)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	counts := make(map[model.MembershipRole]int)
+	for _, id := range m.byTenant[tenantID] {
+		if membership, ok := m.memberships[id]; ok && membership.Status == model.MembershipStatusActive && !userDeleted(membership.User) {
+			counts[membership.Role]++
+		}
+	}
+	return counts, nil
+}
+
+// FindPendingByTenantID retrieves a tenant's pending memberships sent after
+// sentAfter, newest first.
+func (m *MockMembershipRepository) FindPendingByTenantID(ctx context.Context, tenantID string, sentAfter time.Time) ([]*model.Membership, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pending := []*model.Membership{}
+	for _, id := range m.byTenant[tenantID] {
+		if membership, ok := m.memberships[id]; ok && membership.Status == model.MembershipStatusPending && membership.JoinedAt.After(sentAfter) {
+			pending = append(pending, membership)
+		}
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].JoinedAt.After(pending[j].JoinedAt)
+	})
+	return pending, nil
+}
+
+// CountPending returns the number of pending memberships in a tenant sent
+// after sentAfter.
+func (m *MockMembershipRepository) CountPending(ctx context.Context, tenantID string, sentAfter time.Time) (int, error) {
+	if m.CountPendingFunc != nil {
+		return m.CountPendingFunc(ctx, tenantID, sentAfter)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	count := 0
+	for _, id := range m.byTenant[tenantID] {
+		if membership, ok := m.memberships[id]; ok && membership.Status == model.MembershipStatusPending && membership.JoinedAt.After(sentAfter) {
+			count++
 		}
 	}
 	return count, nil
@@ -285,6 +759,153 @@ func (m *MockMembershipRepository) GetUserIDByMembershipID(ctx context.Context,
 	return membership.User.ID, nil
 }
 
+// SetInviter replaces a membership's inviter.
+func (m *MockMembershipRepository) SetInviter(ctx context.Context, membershipID, inviterID string) (*model.Membership, error) {
+	if m.SetInviterFunc != nil {
+		return m.SetInviterFunc(ctx, membershipID, inviterID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	membership, ok := m.memberships[membershipID]
+	if !ok {
+		return nil, errors.ErrMembershipNotFound
+	}
+	membership.InvitedBy = &model.User{ID: inviterID}
+	membership.JoinedAt = time.Now()
+	return membership, nil
+}
+
+// RenewInvite marks a pending membership's invite as sent now.
+func (m *MockMembershipRepository) RenewInvite(ctx context.Context, membershipID string) (*model.Membership, error) {
+	if m.RenewInviteFunc != nil {
+		return m.RenewInviteFunc(ctx, membershipID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	membership, ok := m.memberships[membershipID]
+	if !ok || membership.Status != model.MembershipStatusPending {
+		return nil, errors.ErrMembershipNotFound
+	}
+	membership.JoinedAt = time.Now()
+	return membership, nil
+}
+
+// ReassignInvites repoints invites created by one user within a tenant.
+func (m *MockMembershipRepository) ReassignInvites(ctx context.Context, fromUserID, toUserID, tenantID string) (int, error) {
+	if m.ReassignInvitesFunc != nil {
+		return m.ReassignInvitesFunc(ctx, fromUserID, toUserID, tenantID)
+	}
+	return m.repointInvites(nil, fromUserID, tenantID, &model.User{ID: toUserID}), nil
+}
+
+// ReassignInvitesTx repoints invites created by one user within a tenant,
+// undoing the change if tx rolls back.
+func (m *MockMembershipRepository) ReassignInvitesTx(ctx context.Context, tx neo4j.ManagedTransaction, fromUserID, toUserID, tenantID string) (int, error) {
+	if m.ReassignInvitesTxFunc != nil {
+		return m.ReassignInvitesTxFunc(ctx, tx, fromUserID, toUserID, tenantID)
+	}
+	return m.repointInvites(tx, fromUserID, tenantID, &model.User{ID: toUserID}), nil
+}
+
+// DetachInvites clears invites created by one user within a tenant.
+func (m *MockMembershipRepository) DetachInvites(ctx context.Context, fromUserID, tenantID string) (int, error) {
+	if m.DetachInvitesFunc != nil {
+		return m.DetachInvitesFunc(ctx, fromUserID, tenantID)
+	}
+	return m.repointInvites(nil, fromUserID, tenantID, nil), nil
+}
+
+// DetachInvitesTx clears invites created by one user within a tenant,
+// undoing the change if tx rolls back.
+func (m *MockMembershipRepository) DetachInvitesTx(ctx context.Context, tx neo4j.ManagedTransaction, fromUserID, tenantID string) (int, error) {
+	if m.DetachInvitesTxFunc != nil {
+		return m.DetachInvitesTxFunc(ctx, tx, fromUserID, tenantID)
+	}
+	return m.repointInvites(tx, fromUserID, tenantID, nil), nil
+}
+
+// repointInvites sets the inviter of every membership in a tenant invited
+// by fromUserID to to, or clears it when to is nil or the membership is
+// to's own, and returns how many changed. A non-nil tx undoes the change if
+// it rolls back.
+func (m *MockMembershipRepository) repointInvites(tx neo4j.ManagedTransaction, fromUserID, tenantID string, to *model.User) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous := make(map[*model.Membership]*model.User)
+	for _, id := range m.byTenant[tenantID] {
+		membership, ok := m.memberships[id]
+		if !ok || membership.InvitedBy == nil || membership.InvitedBy.ID != fromUserID {
+			continue
+		}
+		previous[membership] = membership.InvitedBy
+		membership.InvitedBy = nil
+		if to != nil && (membership.User == nil || membership.User.ID != to.ID) {
+			inviter := *to
+			membership.InvitedBy = &inviter
+		}
+	}
+
+	if tx != nil {
+		shared.TrackMockChange(tx, func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			for membership, inviter := range previous {
+				membership.InvitedBy = inviter
+			}
+		}, nil)
+	}
+	return len(previous)
+}
+
+// GetInviteChain walks InvitedBy links upward from a membership.
+func (m *MockMembershipRepository) GetInviteChain(ctx context.Context, membershipID string, maxHops int) ([]*model.User, bool, error) {
+	if m.GetInviteChainFunc != nil {
+		return m.GetInviteChainFunc(ctx, membershipID, maxHops)
+	}
+	if maxHops < 1 {
+		return nil, false, errors.NewValidationError("maxHops", "must be at least 1")
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	current, ok := m.memberships[membershipID]
+	if !ok || current.Tenant == nil {
+		return nil, false, errors.ErrMembershipNotFound
+	}
+	tenantID := current.Tenant.ID
+
+	// Like the Cypher, follow at most maxHops+1 links and let boundInviteChain trim
+	chain := []*model.User{}
+	for len(chain) <= maxHops && current.InvitedBy != nil {
+		next := m.findInTenantLocked(current.InvitedBy.ID, tenantID)
+		if next == nil {
+			break // inviter has left the tenant
+		}
+		chain = append(chain, current.InvitedBy)
+		current = next
+	}
+
+	inviters, truncated := boundInviteChain(chain, maxHops)
+	return inviters, truncated, nil
+}
+
+// findInTenantLocked returns a user's membership in a tenant, or nil.
+// Callers must hold m.mu.
+func (m *MockMembershipRepository) findInTenantLocked(userID, tenantID string) *model.Membership {
+	for _, id := range m.byTenant[tenantID] {
+		if membership, ok := m.memberships[id]; ok && membership.User != nil && membership.User.ID == userID {
+			return membership
+		}
+	}
+	return nil
+}
+
 // removeFromSlice removes an element from a slice and returns the new slice.
 func (m *MockMembershipRepository) removeFromSlice(slice []string, item string) []string {
 	for i, v := range slice {
@@ -295,5 +916,24 @@ func (m *MockMembershipRepository) removeFromSlice(slice []string, item string)
 	return slice
 }
 
+// recordEvent appends a membership event to the outbox if one is attached.
+func (m *MockMembershipRepository) recordEvent(eventType string, membership *model.Membership) {
+	if m.Outbox == nil {
+		return
+	}
+
+	payload := map[string]any{
+		"membershipId": membership.ID,
+		"role":         string(membership.Role),
+	}
+	if membership.Tenant != nil {
+		payload["tenantId"] = membership.Tenant.ID
+	}
+	if membership.User != nil {
+		payload["userId"] = membership.User.ID
+	}
+	m.Outbox.Record(eventType, membership.ID, payload)
+}
+
 // Ensure MockMembershipRepository implements IMembershipRepository
 var _ IMembershipRepository = (*MockMembershipRepository)(nil)
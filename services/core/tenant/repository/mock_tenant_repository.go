@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/events"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 )
 
@@ -16,17 +20,34 @@ type MockTenantRepository struct {
 	tenants map[string]*model.Tenant
 
 	// Function overrides for testing specific behaviors
-	FindByIDFunc       func(ctx context.Context, id string) (*model.Tenant, error)
-	FindBySlugFunc     func(ctx context.Context, slug string) (*model.Tenant, error)
-	FindByUserIDFunc   func(ctx context.Context, userID string) ([]*model.Tenant, error)
-	CreateFunc         func(ctx context.Context, tenant *model.Tenant) (*model.Tenant, error)
-	UpdateFunc         func(ctx context.Context, id string, input model.UpdateTenantInput) (*model.Tenant, error)
-	DeleteFunc         func(ctx context.Context, id string) error
-	ExistsBySlugFunc   func(ctx context.Context, slug string) (bool, error)
-	GetMemberCountFunc func(ctx context.Context, tenantID string) (int, error)
+	FindByIDFunc             func(ctx context.Context, id string) (*model.Tenant, error)
+	FindBySlugFunc           func(ctx context.Context, slug string) (*model.Tenant, error)
+	FindByUserIDFunc         func(ctx context.Context, userID string, limit, offset int) ([]*model.Tenant, error)
+	FindInvitableTenantsFunc func(ctx context.Context, inviterID, inviteeEmail string) ([]*model.Tenant, error)
+	CreateFunc               func(ctx context.Context, tenant *model.Tenant) (*model.Tenant, error)
+	CreateTxFunc             func(ctx context.Context, tx neo4j.ManagedTransaction, tenant *model.Tenant) (*model.Tenant, error)
+	UpdateFunc               func(ctx context.Context, id string, input model.UpdateTenantInput) (*model.Tenant, error)
+	RenameFunc               func(ctx context.Context, id, name, slug string) (*model.Tenant, error)
+	UpdatePlansFunc          func(ctx context.Context, changes []*model.PlanChange) ([]*model.Tenant, error)
+	SetBillingEmailFunc      func(ctx context.Context, id, email string) (*model.Tenant, error)
+	GetSettingsFunc          func(ctx context.Context, id string) (*TenantSettings, error)
+	UpdateSettingsFunc       func(ctx context.Context, id string, update func(*TenantSettings) error) (*TenantSettings, error)
+	DeleteFunc               func(ctx context.Context, id, deletedBy string, reason *string) error
+	RestoreFunc              func(ctx context.Context, id string) (*model.Tenant, error)
+	ExistsBySlugFunc         func(ctx context.Context, slug string) (bool, error)
+	GetMemberCountFunc       func(ctx context.Context, tenantID string) (int, error)
 
 	// For testing: track user-tenant relationships
-	userTenants map[string][]string // userID -> []tenantID
+	userTenants map[string][]string                        // userID -> []tenantID
+	userRoles   map[string]map[string]model.MembershipRole // userID -> tenantID -> role
+	userPending map[string]map[string]bool                 // userID -> tenantID -> invite not yet accepted
+	userEmails  map[string]string                          // email -> userID
+	deletions   map[string]*model.DeletionInfo             // tenantID -> deletion
+	slugHistory map[string][]string                        // tenantID -> previous slugs
+	settings    map[string]*TenantSettings                 // tenantID -> settings
+
+	// Outbox, when set, receives the events the Neo4j implementation would write
+	Outbox *shared.MockOutboxRepository
 }
 
 // NewMockTenantRepository creates a new MockTenantRepository.
@@ -34,6 +55,12 @@ func NewMockTenantRepository() *MockTenantRepository {
 	return &MockTenantRepository{
 		tenants:     make(map[string]*model.Tenant),
 		userTenants: make(map[string][]string),
+		userRoles:   make(map[string]map[string]model.MembershipRole),
+		userPending: make(map[string]map[string]bool),
+		userEmails:  make(map[string]string),
+		deletions:   make(map[string]*model.DeletionInfo),
+		slugHistory: make(map[string][]string),
+		settings:    make(map[string]*TenantSettings),
 	}
 }
 
@@ -44,11 +71,39 @@ func (m *MockTenantRepository) AddTenant(tenant *model.Tenant) {
 	m.tenants[tenant.ID] = tenant
 }
 
-// AddUserToTenant associates a user with a tenant for testing.
+// AddUserToTenant associates a user with a tenant as a MEMBER for testing.
 func (m *MockTenantRepository) AddUserToTenant(userID, tenantID string) {
+	m.AddUserToTenantWithRole(userID, tenantID, model.MembershipRoleMember)
+}
+
+// AddUserToTenantWithRole associates a user with a tenant in the given role for testing.
+func (m *MockTenantRepository) AddUserToTenantWithRole(userID, tenantID string, role model.MembershipRole) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.userTenants[userID] = append(m.userTenants[userID], tenantID)
+	if m.userRoles[userID] == nil {
+		m.userRoles[userID] = make(map[string]model.MembershipRole)
+	}
+	m.userRoles[userID][tenantID] = role
+}
+
+// AddPendingUserToTenant records a user's unaccepted invite to a tenant in
+// the given role for testing.
+func (m *MockTenantRepository) AddPendingUserToTenant(userID, tenantID string, role model.MembershipRole) {
+	m.AddUserToTenantWithRole(userID, tenantID, role)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.userPending[userID] == nil {
+		m.userPending[userID] = make(map[string]bool)
+	}
+	m.userPending[userID][tenantID] = true
+}
+
+// SetUserEmail records a user's email so FindInvitableTenants can resolve invitees.
+func (m *MockTenantRepository) SetUserEmail(userID, email string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.userEmails[email] = userID
 }
 
 // Reset clears all data from the mock repository.
@@ -57,6 +112,18 @@ func (m *MockTenantRepository) Reset() {
 	defer m.mu.Unlock()
 	m.tenants = make(map[string]*model.Tenant)
 	m.userTenants = make(map[string][]string)
+	m.userRoles = make(map[string]map[string]model.MembershipRole)
+	m.userPending = make(map[string]map[string]bool)
+	m.userEmails = make(map[string]string)
+	m.deletions = make(map[string]*model.DeletionInfo)
+	m.slugHistory = make(map[string][]string)
+}
+
+// PreviousSlugs returns the slugs a tenant had before being renamed, oldest first.
+func (m *MockTenantRepository) PreviousSlugs(tenantID string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.slugHistory[tenantID]
 }
 
 // FindByID retrieves a tenant by ID.
@@ -92,10 +159,10 @@ func (m *MockTenantRepository) FindBySlug(ctx context.Context, slug string) (*mo
 	return nil, errors.ErrTenantNotFound
 }
 
-// FindByUserID retrieves all tenants a user is a member of.
-func (m *MockTenantRepository) FindByUserID(ctx context.Context, userID string) ([]*model.Tenant, error) {
+// FindByUserID retrieves a page of the tenants a user is an active member of.
+func (m *MockTenantRepository) FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*model.Tenant, error) {
 	if m.FindByUserIDFunc != nil {
-		return m.FindByUserIDFunc(ctx, userID)
+		return m.FindByUserIDFunc(ctx, userID, limit, offset)
 	}
 
 	m.mu.RLock()
@@ -108,10 +175,55 @@ func (m *MockTenantRepository) FindByUserID(ctx context.Context, userID string)
 
 	var tenants []*model.Tenant
 	for _, tenantID := range tenantIDs {
+		if m.userPending[userID][tenantID] {
+			continue
+		}
 		if tenant, ok := m.tenants[tenantID]; ok && tenant.Status != model.TenantStatusDeleted {
-			tenants = append(tenants, tenant)
+			// Copy so the per-user role doesn't leak into other readers
+			withRole := *tenant
+			role := m.userRoles[userID][tenantID]
+			withRole.MyRole = &role
+			tenants = append(tenants, &withRole)
+		}
+	}
+
+	// Mirror the real repository's ORDER BY so pages are deterministic
+	sort.Slice(tenants, func(i, j int) bool {
+		if !tenants[i].CreatedAt.Equal(tenants[j].CreatedAt) {
+			return tenants[i].CreatedAt.After(tenants[j].CreatedAt)
+		}
+		return tenants[i].ID < tenants[j].ID
+	})
+	return paginate(tenants, limit, offset), nil
+}
+
+// FindInvitableTenants retrieves tenants the inviter administers that the invitee hasn't joined.
+func (m *MockTenantRepository) FindInvitableTenants(ctx context.Context, inviterID, inviteeEmail string) ([]*model.Tenant, error) {
+	if m.FindInvitableTenantsFunc != nil {
+		return m.FindInvitableTenantsFunc(ctx, inviterID, inviteeEmail)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	inviteeID, known := m.userEmails[inviteeEmail]
+
+	tenants := []*model.Tenant{}
+	for tenantID, role := range m.userRoles[inviterID] {
+		if role != model.MembershipRoleOwner && role != model.MembershipRoleAdmin {
+			continue
+		}
+		if _, isMember := m.userRoles[inviteeID][tenantID]; known && isMember {
+			continue
+		}
+		if tenant, ok := m.tenants[tenantID]; ok && tenant.Status == model.TenantStatusActive {
+			withRole := *tenant
+			withRole.MyRole = &role
+			tenants = append(tenants, &withRole)
 		}
 	}
+
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].Name < tenants[j].Name })
 	return tenants, nil
 }
 
@@ -121,6 +233,37 @@ func (m *MockTenantRepository) Create(ctx context.Context, tenant *model.Tenant)
 		return m.CreateFunc(ctx, tenant)
 	}
 
+	created, err := m.create(tenant)
+	if err != nil {
+		return nil, err
+	}
+	m.recordEvent(events.TenantCreated, created.ID, map[string]any{"tenantId": created.ID, "slug": created.Slug})
+	return created, nil
+}
+
+// CreateTx creates a new tenant in tx. A MockUnitOfWork removes it again if
+// tx rolls back and only records TenantCreated once tx commits.
+func (m *MockTenantRepository) CreateTx(ctx context.Context, tx neo4j.ManagedTransaction, tenant *model.Tenant) (*model.Tenant, error) {
+	if m.CreateTxFunc != nil {
+		return m.CreateTxFunc(ctx, tx, tenant)
+	}
+
+	created, err := m.create(tenant)
+	if err != nil {
+		return nil, err
+	}
+	shared.TrackMockChange(tx, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.tenants, created.ID)
+	}, func() {
+		m.recordEvent(events.TenantCreated, created.ID, map[string]any{"tenantId": created.ID, "slug": created.Slug})
+	})
+	return created, nil
+}
+
+// create stores a new tenant, applying the defaults Create sets.
+func (m *MockTenantRepository) create(tenant *model.Tenant) (*model.Tenant, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -179,13 +322,137 @@ func (m *MockTenantRepository) Update(ctx context.Context, id string, input mode
 	}
 	tenant.UpdatedAt = time.Now()
 
+	m.recordEvent(events.TenantUpdated, id, map[string]any{"tenantId": id})
 	return tenant, nil
 }
 
+// Rename sets a tenant's name and slug, recording the old slug.
+func (m *MockTenantRepository) Rename(ctx context.Context, id, name, slug string) (*model.Tenant, error) {
+	if m.RenameFunc != nil {
+		return m.RenameFunc(ctx, id, name, slug)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.tenants {
+		if existing.ID != id && existing.Slug == slug && existing.Status != model.TenantStatusDeleted {
+			return nil, errors.ErrSlugTaken
+		}
+	}
+
+	tenant, ok := m.tenants[id]
+	if !ok || tenant.Status == model.TenantStatusDeleted {
+		return nil, errors.ErrTenantNotFound
+	}
+
+	if tenant.Slug != slug {
+		m.slugHistory[id] = append(m.slugHistory[id], tenant.Slug)
+	}
+	tenant.Name = name
+	tenant.Slug = slug
+	tenant.UpdatedAt = time.Now()
+
+	m.recordEvent(events.TenantUpdated, id, map[string]any{"tenantId": id, "slug": slug})
+	return tenant, nil
+}
+
+// SetBillingEmail sets a tenant's billing email.
+func (m *MockTenantRepository) SetBillingEmail(ctx context.Context, id, email string) (*model.Tenant, error) {
+	if m.SetBillingEmailFunc != nil {
+		return m.SetBillingEmailFunc(ctx, id, email)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tenant, ok := m.tenants[id]
+	if !ok || tenant.Status == model.TenantStatusDeleted {
+		return nil, errors.ErrTenantNotFound
+	}
+
+	tenant.BillingEmail = &email
+	tenant.UpdatedAt = time.Now()
+
+	m.recordEvent(events.TenantUpdated, id, map[string]any{"tenantId": id})
+	return tenant, nil
+}
+
+// GetSettings retrieves a copy of a tenant's settings.
+func (m *MockTenantRepository) GetSettings(ctx context.Context, id string) (*TenantSettings, error) {
+	if m.GetSettingsFunc != nil {
+		return m.GetSettingsFunc(ctx, id)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tenant, ok := m.tenants[id]
+	if !ok || tenant.Status == model.TenantStatusDeleted {
+		return nil, errors.ErrTenantNotFound
+	}
+	if settings, ok := m.settings[id]; ok {
+		return settings.clone(), nil
+	}
+	return &TenantSettings{}, nil
+}
+
+// UpdateSettings applies update to a copy of a tenant's settings and keeps
+// the copy only if update succeeds, as the real transaction would.
+func (m *MockTenantRepository) UpdateSettings(ctx context.Context, id string, update func(*TenantSettings) error) (*TenantSettings, error) {
+	if m.UpdateSettingsFunc != nil {
+		return m.UpdateSettingsFunc(ctx, id, update)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tenant, ok := m.tenants[id]
+	if !ok || tenant.Status == model.TenantStatusDeleted {
+		return nil, errors.ErrTenantNotFound
+	}
+
+	settings := &TenantSettings{}
+	if stored, ok := m.settings[id]; ok {
+		settings = stored.clone()
+	}
+	if err := update(settings); err != nil {
+		return nil, err
+	}
+	m.settings[id] = settings.clone()
+	tenant.UpdatedAt = time.Now()
+
+	m.recordEvent(events.TenantUpdated, id, map[string]any{"tenantId": id})
+	return settings, nil
+}
+
+// UpdatePlans sets the plan of each listed tenant.
+func (m *MockTenantRepository) UpdatePlans(ctx context.Context, changes []*model.PlanChange) ([]*model.Tenant, error) {
+	if m.UpdatePlansFunc != nil {
+		return m.UpdatePlansFunc(ctx, changes)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tenants := []*model.Tenant{}
+	for _, change := range changes {
+		tenant, ok := m.tenants[change.TenantID]
+		if !ok || tenant.Status == model.TenantStatusDeleted {
+			continue
+		}
+		tenant.Plan = change.Plan
+		tenant.UpdatedAt = time.Now()
+		m.recordEvent(events.TenantUpdated, tenant.ID, map[string]any{"tenantId": tenant.ID, "plan": string(tenant.Plan)})
+		tenants = append(tenants, tenant)
+	}
+	return tenants, nil
+}
+
 // Delete soft-deletes a tenant.
-func (m *MockTenantRepository) Delete(ctx context.Context, id string) error {
+func (m *MockTenantRepository) Delete(ctx context.Context, id, deletedBy string, reason *string) error {
 	if m.DeleteFunc != nil {
-		return m.DeleteFunc(ctx, id)
+		return m.DeleteFunc(ctx, id, deletedBy, reason)
 	}
 
 	m.mu.Lock()
@@ -198,10 +465,57 @@ func (m *MockTenantRepository) Delete(ctx context.Context, id string) error {
 
 	tenant.Status = model.TenantStatusDeleted
 	tenant.UpdatedAt = time.Now()
+	m.deletions[id] = &model.DeletionInfo{DeletedAt: tenant.UpdatedAt, DeletedBy: &deletedBy, Reason: reason}
+	m.recordEvent(events.TenantDeleted, id, map[string]any{"tenantId": id, "deletedBy": deletedBy})
 	return nil
 }
 
-// ExistsBySlug checks if a tenant with the given slug exists.
+// Restore reverses a soft delete, making the tenant ACTIVE again.
+func (m *MockTenantRepository) Restore(ctx context.Context, id string) (*model.Tenant, error) {
+	if m.RestoreFunc != nil {
+		return m.RestoreFunc(ctx, id)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tenant, ok := m.tenants[id]
+	if !ok {
+		return nil, errors.ErrTenantNotFound
+	}
+	if tenant.Status != model.TenantStatusDeleted {
+		return nil, errors.ErrNotDeleted
+	}
+	for _, other := range m.tenants {
+		if other.ID != id && other.Slug == tenant.Slug && other.Status != model.TenantStatusDeleted {
+			return nil, errors.ErrSlugTaken
+		}
+	}
+
+	tenant.Status = model.TenantStatusActive
+	tenant.UpdatedAt = time.Now()
+	delete(m.deletions, id)
+	m.recordEvent(events.TenantRestored, id, map[string]any{"tenantId": id})
+	return tenant, nil
+}
+
+// FindDeletion retrieves the deletion details of a deleted tenant.
+func (m *MockTenantRepository) FindDeletion(ctx context.Context, id string) (*model.DeletionInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tenant, ok := m.tenants[id]
+	if !ok || tenant.Status != model.TenantStatusDeleted {
+		return nil, errors.ErrTenantNotFound
+	}
+	if info, ok := m.deletions[id]; ok {
+		return info, nil
+	}
+	return &model.DeletionInfo{DeletedAt: tenant.UpdatedAt}, nil
+}
+
+// ExistsBySlug checks if a tenant with the given slug exists, deleted
+// tenants included.
 func (m *MockTenantRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
 	if m.ExistsBySlugFunc != nil {
 		return m.ExistsBySlugFunc(ctx, slug)
@@ -211,22 +525,64 @@ func (m *MockTenantRepository) ExistsBySlug(ctx context.Context, slug string) (b
 	defer m.mu.RUnlock()
 
 	for _, tenant := range m.tenants {
-		if tenant.Slug == slug && tenant.Status != model.TenantStatusDeleted {
+		if tenant.Slug == slug {
 			return true, nil
 		}
 	}
 	return false, nil
 }
 
-// GetMemberCount returns the number of members in a tenant.
+// GetMemberCount returns the tenant's stored member count.
 func (m *MockTenantRepository) GetMemberCount(ctx context.Context, tenantID string) (int, error) {
 	if m.GetMemberCountFunc != nil {
 		return m.GetMemberCountFunc(ctx, tenantID)
 	}
 
-	// Default: return 0 (tests should override if needed)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if tenant, ok := m.tenants[tenantID]; ok {
+		return tenant.MemberCount, nil
+	}
 	return 0, nil
 }
 
+// GetStatus returns a tenant's current status.
+func (m *MockTenantRepository) GetStatus(ctx context.Context, id string) (model.TenantStatus, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tenant, ok := m.tenants[id]
+	if !ok || tenant.Status == model.TenantStatusDeleted {
+		return "", errors.ErrTenantNotFound
+	}
+	return tenant.Status, nil
+}
+
+// adjustMemberCount applies a membership change to a tenant's stored count.
+func (m *MockTenantRepository) adjustMemberCount(tenantID string, delta int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if tenant, ok := m.tenants[tenantID]; ok {
+		tenant.MemberCount += delta
+	}
+}
+
+// recordEvent appends an event to the outbox if one is attached.
+func (m *MockTenantRepository) recordEvent(eventType, aggregateID string, payload map[string]any) {
+	if m.Outbox != nil {
+		m.Outbox.Record(eventType, aggregateID, payload)
+	}
+}
+
 // Ensure MockTenantRepository implements ITenantRepository
 var _ ITenantRepository = (*MockTenantRepository)(nil)
+
+// paginate returns the page of items selected by limit and offset.
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	return items[offset:min(offset+limit, len(items))]
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/events"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+func TestMockTenantRepository_Create_WritesOutboxEvent(t *testing.T) {
+	// Arrange
+	outbox := shared.NewMockOutboxRepository()
+	repo := NewMockTenantRepository()
+	repo.Outbox = outbox
+	ctx := context.Background()
+
+	// Act
+	tenant, err := repo.Create(ctx, &model.Tenant{Name: "Acme", Slug: "acme"})
+	require.NoError(t, err)
+
+	// A rejected write must not produce an event
+	_, dupErr := repo.Create(ctx, &model.Tenant{Name: "Other", Slug: "acme"})
+
+	// Assert
+	assert.ErrorIs(t, dupErr, errors.ErrSlugTaken)
+	recorded := outbox.Events()
+	require.Len(t, recorded, 1)
+	assert.Equal(t, events.TenantCreated, recorded[0].Type)
+	assert.Equal(t, tenant.ID, recorded[0].AggregateID)
+}
+
+func TestMockMembershipRepository_Mutations_DispatchedExactlyOnce(t *testing.T) {
+	// Arrange
+	outbox := shared.NewMockOutboxRepository()
+	repo := NewMockMembershipRepository()
+	repo.Outbox = outbox
+	ctx := context.Background()
+
+	membership, err := repo.Create(ctx, "user-1", "tenant-1", model.MembershipRoleMember, nil)
+	require.NoError(t, err)
+	_, err = repo.UpdateRole(ctx, membership.ID, model.MembershipRoleAdmin)
+	require.NoError(t, err)
+
+	broker := events.NewBroker(10)
+	received, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+	dispatcher := shared.NewOutboxDispatcher(outbox, broker, 10, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Act
+	first, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	second, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, first)
+	assert.Equal(t, 0, second)
+	assert.Equal(t, events.MemberAdded, (<-received).Type)
+	assert.Equal(t, events.MemberRoleChanged, (<-received).Type)
+	for _, evt := range outbox.Events() {
+		assert.Equal(t, 1, outbox.ProcessedCount(evt.ID))
+	}
+}
+
+func TestMockMembershipRepository_Accept_WritesOutboxEvent(t *testing.T) {
+	// Arrange
+	outbox := shared.NewMockOutboxRepository()
+	repo := NewMockMembershipRepository()
+	repo.Outbox = outbox
+	ctx := context.Background()
+	invite, err := repo.CreatePending(ctx, "user-1", "tenant-1", model.MembershipRoleMember, nil)
+	require.NoError(t, err)
+
+	// Act
+	_, err = repo.Accept(ctx, invite.ID)
+
+	// Assert
+	require.NoError(t, err)
+	recorded := outbox.Events()
+	require.Len(t, recorded, 2)
+	assert.Equal(t, events.MemberInvited, recorded[0].Type)
+	assert.Equal(t, events.MemberAdded, recorded[1].Type)
+	assert.Equal(t, invite.ID, recorded[1].AggregateID)
+}
+
+// acceptDB runs write work that finds one membership and records the
+// outbox events written.
+type acceptDB struct {
+	shared.IDatabase
+	events []string
+}
+
+func (d *acceptDB) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return work(&acceptTx{db: d})
+}
+
+type acceptTx struct {
+	neo4j.ManagedTransaction
+	db *acceptDB
+}
+
+func (tx *acceptTx) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	if strings.Contains(cypher, "CREATE (e:Event") {
+		tx.db.events = append(tx.db.events, params["type"].(string))
+	}
+	return &singleResult{fakeCursor: &fakeCursor{records: memberRecords(1)}}, nil
+}
+
+func TestMembershipRepository_Accept_WritesOutboxEvent(t *testing.T) {
+	// Arrange
+	db := &acceptDB{}
+	repo := NewMembershipRepository(db)
+
+	// Act
+	membership, err := repo.Accept(context.Background(), "m-0")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "m-0", membership.ID)
+	assert.Equal(t, []string{events.MemberAdded}, db.events)
+}
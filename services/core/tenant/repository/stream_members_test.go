@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// cursorDB runs read work, and explicit transactions, against a fixed
+// result cursor. Methods not overridden panic via the nil embedded interface.
+type cursorDB struct {
+	shared.IDatabase
+	cursor *fakeCursor
+
+	sessionClosed bool
+	committed     bool
+}
+
+func (d *cursorDB) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return work(&cursorTx{cursor: d.cursor})
+}
+
+func (d *cursorDB) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	return &cursorSession{db: d}
+}
+
+type cursorSession struct {
+	neo4j.SessionWithContext
+	db *cursorDB
+}
+
+func (s *cursorSession) BeginTransaction(ctx context.Context, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ExplicitTransaction, error) {
+	return &cursorExplicitTx{db: s.db}, nil
+}
+
+func (s *cursorSession) Close(ctx context.Context) error {
+	s.db.sessionClosed = true
+	return nil
+}
+
+type cursorExplicitTx struct {
+	neo4j.ExplicitTransaction
+	db *cursorDB
+}
+
+func (tx *cursorExplicitTx) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	return tx.db.cursor, nil
+}
+
+func (tx *cursorExplicitTx) Commit(ctx context.Context) error {
+	tx.db.committed = true
+	return nil
+}
+
+func (tx *cursorExplicitTx) Close(ctx context.Context) error {
+	return nil
+}
+
+type cursorTx struct {
+	neo4j.ManagedTransaction
+	cursor *fakeCursor
+}
+
+func (tx *cursorTx) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	return tx.cursor, nil
+}
+
+// fakeCursor yields records one at a time and counts how many were read.
+type fakeCursor struct {
+	neo4j.ResultWithContext
+	records []*neo4j.Record
+	read    int
+}
+
+func (r *fakeCursor) Next(ctx context.Context) bool {
+	if r.read == len(r.records) {
+		return false
+	}
+	r.read++
+	return true
+}
+
+func (r *fakeCursor) Record() *neo4j.Record {
+	return r.records[r.read-1]
+}
+
+func (r *fakeCursor) Err() error {
+	return nil
+}
+
+// memberRecords builds n membership rows shaped like the StreamMembers query.
+func memberRecords(n int) []*neo4j.Record {
+	tenant := neo4j.Node{Props: map[string]any{
+		"id": "tenant-1", "name": "Acme", "slug": "acme",
+		"plan": "FREE", "isolationMode": "SHARED", "status": "ACTIVE",
+	}}
+	records := make([]*neo4j.Record, n)
+	for i := range records {
+		records[i] = &neo4j.Record{
+			Keys: []string{"m", "u", "t", "inviter"},
+			Values: []any{
+				neo4j.Node{Props: map[string]any{"id": fmt.Sprintf("m-%d", i), "role": "MEMBER"}},
+				neo4j.Node{Props: map[string]any{"id": fmt.Sprintf("user-%d", i), "email": fmt.Sprintf("user-%d@example.com", i), "status": "ACTIVE"}},
+				tenant,
+				nil,
+			},
+		}
+	}
+	return records
+}
+
+func TestMembershipRepository_StreamMembers_CallbackPerRow(t *testing.T) {
+	// Arrange
+	cursor := &fakeCursor{records: memberRecords(3)}
+	db := &cursorDB{cursor: cursor}
+	repo := NewMembershipRepository(db)
+
+	// Act
+	var ids []string
+	err := repo.StreamMembers(context.Background(), "tenant-1", func(m *model.Membership) error {
+		ids = append(ids, m.ID)
+		return nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m-0", "m-1", "m-2"}, ids)
+	assert.Equal(t, 3, cursor.read)
+	assert.True(t, db.committed)
+	assert.True(t, db.sessionClosed)
+}
+
+func TestMembershipRepository_StreamMembers_StopsOnCallbackError(t *testing.T) {
+	// Arrange
+	cursor := &fakeCursor{records: memberRecords(5)}
+	db := &cursorDB{cursor: cursor}
+	repo := NewMembershipRepository(db)
+
+	// Act
+	calls := 0
+	err := repo.StreamMembers(context.Background(), "tenant-1", func(m *model.Membership) error {
+		calls++
+		if calls == 2 {
+			return errors.ErrForbidden
+		}
+		return nil
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, cursor.read, "no rows are read after the callback fails")
+	assert.False(t, db.committed, "the stream fails rather than being retried or committed")
+	assert.True(t, db.sessionClosed)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/events"
 	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 )
@@ -27,8 +28,7 @@ func (r *TenantRepository) FindByID(ctx context.Context, id string) (*model.Tena
 		result, err := tx.Run(ctx, `
 			MATCH (t:Tenant {id: $id})
 			WHERE t.status <> 'DELETED'
-			OPTIONAL MATCH (m:Membership)-[:IN_TENANT]->(t)
-			RETURN t, count(m) as memberCount
+			RETURN t
 		`, map[string]any{"id": id})
 		if err != nil {
 			return nil, err
@@ -53,8 +53,7 @@ func (r *TenantRepository) FindBySlug(ctx context.Context, slug string) (*model.
 		result, err := tx.Run(ctx, `
 			MATCH (t:Tenant {slug: $slug})
 			WHERE t.status <> 'DELETED'
-			OPTIONAL MATCH (m:Membership)-[:IN_TENANT]->(t)
-			RETURN t, count(m) as memberCount
+			RETURN t
 		`, map[string]any{"slug": slug})
 		if err != nil {
 			return nil, err
@@ -73,17 +72,17 @@ func (r *TenantRepository) FindBySlug(ctx context.Context, slug string) (*model.
 	return result.(*model.Tenant), nil
 }
 
-// FindByUserID retrieves all tenants a user is a member of.
-func (r *TenantRepository) FindByUserID(ctx context.Context, userID string) ([]*model.Tenant, error) {
+// FindByUserID retrieves a page of the tenants a user is an active member of.
+func (r *TenantRepository) FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*model.Tenant, error) {
 	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, `
 			MATCH (u:User {id: $userID})-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t:Tenant)
-			WHERE t.status <> 'DELETED'
-			WITH t
-			OPTIONAL MATCH (m2:Membership)-[:IN_TENANT]->(t)
-			RETURN t, count(m2) as memberCount
-			ORDER BY t.createdAt DESC
-		`, map[string]any{"userID": userID})
+			WHERE t.status <> 'DELETED' AND m.status = 'ACTIVE'
+			RETURN t, m.role as myRole
+			ORDER BY t.createdAt DESC, t.id
+			SKIP $offset
+			LIMIT $limit
+		`, map[string]any{"userID": userID, "limit": limit, "offset": offset})
 		if err != nil {
 			return nil, err
 		}
@@ -105,8 +104,54 @@ func (r *TenantRepository) FindByUserID(ctx context.Context, userID string) ([]*
 	return result.([]*model.Tenant), nil
 }
 
+// FindInvitableTenants retrieves tenants the inviter administers that the invitee hasn't joined.
+func (r *TenantRepository) FindInvitableTenants(ctx context.Context, inviterID, inviteeEmail string) ([]*model.Tenant, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (u:User {id: $inviterID})-[:HAS_MEMBERSHIP]->(m:Membership)-[:IN_TENANT]->(t:Tenant)
+			WHERE t.status = 'ACTIVE'
+			  AND m.role IN ['OWNER', 'ADMIN']
+			  AND NOT EXISTS {
+				MATCH (:User {email: $inviteeEmail})-[:HAS_MEMBERSHIP]->(:Membership)-[:IN_TENANT]->(t)
+			  }
+			RETURN t, m.role as myRole
+			ORDER BY t.name
+		`, map[string]any{"inviterID": inviterID, "inviteeEmail": inviteeEmail})
+		if err != nil {
+			return nil, err
+		}
+
+		tenants := []*model.Tenant{}
+		for result.Next(ctx) {
+			tenant, err := r.mapRecordToTenant(result.Record())
+			if err != nil {
+				return nil, err
+			}
+			tenants = append(tenants, tenant)
+		}
+
+		return tenants, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*model.Tenant), nil
+}
+
 // Create creates a new tenant in the database.
 func (r *TenantRepository) Create(ctx context.Context, tenant *model.Tenant) (*model.Tenant, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return r.CreateTx(ctx, tx, tenant)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.Tenant), nil
+}
+
+// CreateTx creates a new tenant in tx, leaving commit or rollback to the
+// caller. It is safe to run again if tx is retried.
+func (r *TenantRepository) CreateTx(ctx context.Context, tx neo4j.ManagedTransaction, tenant *model.Tenant) (*model.Tenant, error) {
 	// Generate ID if not provided
 	if tenant.ID == "" {
 		tenant.ID = uuid.New().String()
@@ -127,13 +172,132 @@ func (r *TenantRepository) Create(ctx context.Context, tenant *model.Tenant) (*m
 		tenant.IsolationMode = model.TenantIsolationModeShared
 	}
 
+	// Check if slug already exists
+	checkResult, err := tx.Run(ctx, `
+		MATCH (t:Tenant {slug: $slug})
+		WHERE t.status <> 'DELETED'
+		RETURN count(t) > 0 as exists
+	`, map[string]any{"slug": tenant.Slug})
+	if err != nil {
+		return nil, err
+	}
+
+	checkRecord, err := checkResult.Single(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists, _ := checkRecord.Get("exists"); exists.(bool) {
+		return nil, errors.ErrSlugTaken
+	}
+
+	// Create the tenant
+	params := map[string]any{
+		"id":            tenant.ID,
+		"name":          tenant.Name,
+		"slug":          tenant.Slug,
+		"plan":          string(tenant.Plan),
+		"isolationMode": string(tenant.IsolationMode),
+		"status":        string(tenant.Status),
+	}
+
+	result, err := tx.Run(ctx, `
+		CREATE (t:Tenant {
+			id: $id,
+			name: $name,
+			slug: $slug,
+			plan: $plan,
+			isolationMode: $isolationMode,
+			status: $status,
+			memberCount: 0,
+			createdAt: datetime(),
+			updatedAt: datetime()
+		})
+		RETURN t
+	`, params)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := shared.WriteOutboxEvent(ctx, tx, events.TenantCreated, tenant.ID, map[string]any{
+		"tenantId": tenant.ID,
+		"slug":     tenant.Slug,
+	}); err != nil {
+		return nil, err
+	}
+
+	return r.mapRecordToTenant(record)
+}
+
+// Update updates an existing tenant.
+func (r *TenantRepository) Update(ctx context.Context, id string, input model.UpdateTenantInput) (*model.Tenant, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		params := map[string]any{
+			"id":        id,
+			"updatedAt": time.Now(),
+		}
+
+		// Build SET clause dynamically
+		setClause := "t.updatedAt = datetime($updatedAt)"
+		if input.Name != nil {
+			params["name"] = *input.Name
+			setClause += ", t.name = $name"
+		}
+		if input.Plan != nil {
+			params["plan"] = string(*input.Plan)
+			setClause += ", t.plan = $plan"
+		}
+		if input.Status != nil {
+			params["status"] = string(*input.Status)
+			setClause += ", t.status = $status"
+		}
+
+		query := `
+			MATCH (t:Tenant {id: $id})
+			WHERE t.status <> 'DELETED'
+			SET ` + setClause + `
+			RETURN t
+		`
+
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrTenantNotFound
+		}
+
+		if err := shared.WriteOutboxEvent(ctx, tx, events.TenantUpdated, id, map[string]any{
+			"tenantId": id,
+		}); err != nil {
+			return nil, err
+		}
+
+		return r.mapRecordToTenant(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.Tenant), nil
+}
+
+// Rename sets a tenant's name and slug together, appending the old slug to
+// previousSlugs when it changes. The slug check and update share a
+// transaction, so a taken slug leaves the tenant unchanged.
+func (r *TenantRepository) Rename(ctx context.Context, id, name, slug string) (*model.Tenant, error) {
 	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-		// Check if slug already exists
 		checkResult, err := tx.Run(ctx, `
 			MATCH (t:Tenant {slug: $slug})
-			WHERE t.status <> 'DELETED'
+			WHERE t.status <> 'DELETED' AND t.id <> $id
 			RETURN count(t) > 0 as exists
-		`, map[string]any{"slug": tenant.Slug})
+		`, map[string]any{"id": id, "slug": slug})
 		if err != nil {
 			return nil, err
 		}
@@ -147,35 +311,64 @@ func (r *TenantRepository) Create(ctx context.Context, tenant *model.Tenant) (*m
 			return nil, errors.ErrSlugTaken
 		}
 
-		// Create the tenant
-		params := map[string]any{
-			"id":            tenant.ID,
-			"name":          tenant.Name,
-			"slug":          tenant.Slug,
-			"plan":          string(tenant.Plan),
-			"isolationMode": string(tenant.IsolationMode),
-			"status":        string(tenant.Status),
+		// previousSlugs is set first so it reads the slug being replaced
+		result, err := tx.Run(ctx, `
+			MATCH (t:Tenant {id: $id})
+			WHERE t.status <> 'DELETED'
+			SET t.previousSlugs = CASE
+					WHEN t.slug = $slug THEN coalesce(t.previousSlugs, [])
+					ELSE coalesce(t.previousSlugs, []) + t.slug
+				END,
+				t.name = $name,
+				t.slug = $slug,
+				t.updatedAt = datetime()
+			RETURN t
+		`, map[string]any{"id": id, "name": name, "slug": slug})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrTenantNotFound
 		}
 
+		if err := shared.WriteOutboxEvent(ctx, tx, events.TenantUpdated, id, map[string]any{
+			"tenantId": id,
+			"slug":     slug,
+		}); err != nil {
+			return nil, err
+		}
+
+		return r.mapRecordToTenant(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.Tenant), nil
+}
+
+// SetBillingEmail sets the tenant's billing/contact email.
+func (r *TenantRepository) SetBillingEmail(ctx context.Context, id, email string) (*model.Tenant, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, `
-			CREATE (t:Tenant {
-				id: $id,
-				name: $name,
-				slug: $slug,
-				plan: $plan,
-				isolationMode: $isolationMode,
-				status: $status,
-				createdAt: datetime(),
-				updatedAt: datetime()
-			})
-			RETURN t, 0 as memberCount
-		`, params)
+			MATCH (t:Tenant {id: $id})
+			WHERE t.status <> 'DELETED'
+			SET t.billingEmail = $email, t.updatedAt = datetime()
+			RETURN t
+		`, map[string]any{"id": id, "email": email})
 		if err != nil {
 			return nil, err
 		}
 
 		record, err := result.Single(ctx)
 		if err != nil {
+			return nil, errors.ErrTenantNotFound
+		}
+
+		if err := shared.WriteOutboxEvent(ctx, tx, events.TenantUpdated, id, map[string]any{
+			"tenantId": id,
+		}); err != nil {
 			return nil, err
 		}
 
@@ -187,39 +380,115 @@ func (r *TenantRepository) Create(ctx context.Context, tenant *model.Tenant) (*m
 	return result.(*model.Tenant), nil
 }
 
-// Update updates an existing tenant.
-func (r *TenantRepository) Update(ctx context.Context, id string, input model.UpdateTenantInput) (*model.Tenant, error) {
+// UpdatePlans sets the plan of each listed tenant in a single transaction.
+func (r *TenantRepository) UpdatePlans(ctx context.Context, changes []*model.PlanChange) ([]*model.Tenant, error) {
+	rows := make([]map[string]any, len(changes))
+	for i, change := range changes {
+		rows[i] = map[string]any{"tenantId": change.TenantID, "plan": string(change.Plan)}
+	}
+
 	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
-		params := map[string]any{
-			"id":        id,
-			"updatedAt": time.Now(),
+		result, err := tx.Run(ctx, `
+			UNWIND $changes AS change
+			MATCH (t:Tenant {id: change.tenantId})
+			WHERE t.status <> 'DELETED'
+			SET t.plan = change.plan, t.updatedAt = datetime()
+			RETURN t
+		`, map[string]any{"changes": rows})
+		if err != nil {
+			return nil, err
 		}
 
-		// Build SET clause dynamically
-		setClause := "t.updatedAt = datetime($updatedAt)"
-		if input.Name != nil {
-			params["name"] = *input.Name
-			setClause += ", t.name = $name"
-		}
-		if input.Plan != nil {
-			params["plan"] = string(*input.Plan)
-			setClause += ", t.plan = $plan"
+		tenants := []*model.Tenant{}
+		for result.Next(ctx) {
+			tenant, err := r.mapRecordToTenant(result.Record())
+			if err != nil {
+				return nil, err
+			}
+			tenants = append(tenants, tenant)
 		}
-		if input.Status != nil {
-			params["status"] = string(*input.Status)
-			setClause += ", t.status = $status"
+
+		for _, tenant := range tenants {
+			if err := shared.WriteOutboxEvent(ctx, tx, events.TenantUpdated, tenant.ID, map[string]any{
+				"tenantId": tenant.ID,
+				"plan":     string(tenant.Plan),
+			}); err != nil {
+				return nil, err
+			}
 		}
 
-		query := `
+		return tenants, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*model.Tenant), nil
+}
+
+// Delete soft-deletes a tenant.
+func (r *TenantRepository) Delete(ctx context.Context, id, deletedBy string, reason *string) error {
+	_, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
 			MATCH (t:Tenant {id: $id})
 			WHERE t.status <> 'DELETED'
-			SET ` + setClause + `
-			WITH t
-			OPTIONAL MATCH (m:Membership)-[:IN_TENANT]->(t)
-			RETURN t, count(m) as memberCount
-		`
+			SET t.status = 'DELETED',
+				t.deletedAt = datetime(),
+				t.deletedBy = $deletedBy,
+				t.deletedReason = $reason,
+				t.updatedAt = datetime()
+			RETURN t
+		`, map[string]any{"id": id, "deletedBy": deletedBy, "reason": reason})
+		if err != nil {
+			return nil, err
+		}
 
-		result, err := tx.Run(ctx, query, params)
+		_, err = result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrTenantNotFound
+		}
+
+		return nil, shared.WriteOutboxEvent(ctx, tx, events.TenantDeleted, id, map[string]any{
+			"tenantId":  id,
+			"deletedBy": deletedBy,
+		})
+	})
+	return err
+}
+
+// Restore reverses a soft delete, making the tenant ACTIVE again. The check
+// for another tenant having taken the slug shares the restoring transaction.
+func (r *TenantRepository) Restore(ctx context.Context, id string) (*model.Tenant, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		checkResult, err := tx.Run(ctx, `
+			MATCH (t:Tenant {id: $id})
+			RETURN t.status = 'DELETED' as deleted,
+				COUNT {
+					MATCH (other:Tenant {slug: t.slug})
+					WHERE other.id <> t.id AND other.status <> 'DELETED'
+				} > 0 as slugTaken
+		`, map[string]any{"id": id})
+		if err != nil {
+			return nil, err
+		}
+
+		checkRecord, err := checkResult.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrTenantNotFound
+		}
+		if deleted, _ := checkRecord.Get("deleted"); !deleted.(bool) {
+			return nil, errors.ErrNotDeleted
+		}
+		if taken, _ := checkRecord.Get("slugTaken"); taken.(bool) {
+			return nil, errors.ErrSlugTaken
+		}
+
+		result, err := tx.Run(ctx, `
+			MATCH (t:Tenant {id: $id})
+			SET t.status = 'ACTIVE',
+				t.updatedAt = datetime()
+			REMOVE t.deletedAt, t.deletedBy, t.deletedReason
+			RETURN t
+		`, map[string]any{"id": id})
 		if err != nil {
 			return nil, err
 		}
@@ -229,6 +498,12 @@ func (r *TenantRepository) Update(ctx context.Context, id string, input model.Up
 			return nil, errors.ErrTenantNotFound
 		}
 
+		if err := shared.WriteOutboxEvent(ctx, tx, events.TenantRestored, id, map[string]any{
+			"tenantId": id,
+		}); err != nil {
+			return nil, err
+		}
+
 		return r.mapRecordToTenant(record)
 	})
 	if err != nil {
@@ -237,35 +512,39 @@ func (r *TenantRepository) Update(ctx context.Context, id string, input model.Up
 	return result.(*model.Tenant), nil
 }
 
-// Delete soft-deletes a tenant.
-func (r *TenantRepository) Delete(ctx context.Context, id string) error {
-	_, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+// FindDeletion retrieves the deletion details of a deleted tenant.
+func (r *TenantRepository) FindDeletion(ctx context.Context, id string) (*model.DeletionInfo, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, `
 			MATCH (t:Tenant {id: $id})
-			WHERE t.status <> 'DELETED'
-			SET t.status = 'DELETED', t.updatedAt = datetime()
-			RETURN t
+			WHERE t.status = 'DELETED'
+			RETURN coalesce(t.deletedAt, t.updatedAt) as deletedAt,
+				t.deletedBy as deletedBy,
+				t.deletedReason as reason
 		`, map[string]any{"id": id})
 		if err != nil {
 			return nil, err
 		}
 
-		_, err = result.Single(ctx)
+		record, err := result.Single(ctx)
 		if err != nil {
 			return nil, errors.ErrTenantNotFound
 		}
 
-		return nil, nil
+		return shared.MapDeletionInfo(record), nil
 	})
-	return err
+	if err != nil {
+		return nil, err
+	}
+	return result.(*model.DeletionInfo), nil
 }
 
-// ExistsBySlug checks if a tenant with the given slug exists.
+// ExistsBySlug checks if a tenant with the given slug exists, deleted
+// tenants included.
 func (r *TenantRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
 	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, `
 			MATCH (t:Tenant {slug: $slug})
-			WHERE t.status <> 'DELETED'
 			RETURN count(t) > 0 as exists
 		`, map[string]any{"slug": slug})
 		if err != nil {
@@ -290,8 +569,8 @@ func (r *TenantRepository) ExistsBySlug(ctx context.Context, slug string) (bool,
 func (r *TenantRepository) GetMemberCount(ctx context.Context, tenantID string) (int, error) {
 	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
 		result, err := tx.Run(ctx, `
-			MATCH (m:Membership)-[:IN_TENANT]->(t:Tenant {id: $tenantID})
-			RETURN count(m) as count
+			MATCH (t:Tenant {id: $tenantID})
+			RETURN coalesce(t.memberCount, 0) as count
 		`, map[string]any{"tenantID": tenantID})
 		if err != nil {
 			return nil, err
@@ -311,6 +590,32 @@ func (r *TenantRepository) GetMemberCount(ctx context.Context, tenantID string)
 	return result.(int), nil
 }
 
+// GetStatus returns a tenant's current status.
+func (r *TenantRepository) GetStatus(ctx context.Context, id string) (model.TenantStatus, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (t:Tenant {id: $id})
+			WHERE t.status <> 'DELETED'
+			RETURN t.status as status
+		`, map[string]any{"id": id})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrTenantNotFound
+		}
+
+		status, _ := record.Get("status")
+		return model.TenantStatus(status.(string)), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.(model.TenantStatus), nil
+}
+
 // mapRecordToTenant converts a Neo4j record to a Tenant model.
 func (r *TenantRepository) mapRecordToTenant(record *neo4j.Record) (*model.Tenant, error) {
 	nodeVal, ok := record.Get("t")
@@ -330,6 +635,10 @@ func (r *TenantRepository) mapRecordToTenant(record *neo4j.Record) (*model.Tenan
 		Status:        model.TenantStatus(props["status"].(string)),
 	}
 
+	if billingEmail, ok := props["billingEmail"].(string); ok {
+		tenant.BillingEmail = &billingEmail
+	}
+
 	if createdAt, ok := props["createdAt"]; ok {
 		tenant.CreatedAt = createdAt.(time.Time)
 	}
@@ -338,9 +647,15 @@ func (r *TenantRepository) mapRecordToTenant(record *neo4j.Record) (*model.Tenan
 		tenant.UpdatedAt = updatedAt.(time.Time)
 	}
 
-	// Get member count from the query result
-	if memberCount, ok := record.Get("memberCount"); ok {
-		tenant.MemberCount = int(memberCount.(int64))
+	// Maintained by membership writes; see RecountMembers
+	if memberCount, ok := props["memberCount"].(int64); ok {
+		tenant.MemberCount = int(memberCount)
+	}
+
+	// Caller's role, present only on per-user queries
+	if myRole, ok := record.Get("myRole"); ok && myRole != nil {
+		role := model.MembershipRole(myRole.(string))
+		tenant.MyRole = &role
 	}
 
 	return tenant, nil
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/events"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// TenantSettings are a tenant's optional settings. They are stored
+// JSON-encoded in the tenant's settings property, so adding a setting needs
+// no migration. Unset settings are nil.
+type TenantSettings struct {
+	// DefaultRole is the role invites get when they don't name one
+	DefaultRole *model.MembershipRole `json:"defaultRole,omitempty"`
+	// BrandingColor is a #RRGGBB color for the tenant's UI
+	BrandingColor *string `json:"brandingColor,omitempty"`
+	// Timezone is an IANA time zone name such as "Europe/Berlin"
+	Timezone *string `json:"timezone,omitempty"`
+	// Custom holds the application's own keys, which aren't validated
+	Custom map[string]string `json:"custom,omitempty"`
+}
+
+// clone returns a copy of s that shares no memory with it.
+func (s *TenantSettings) clone() *TenantSettings {
+	c := *s
+	c.Custom = maps.Clone(s.Custom)
+	return &c
+}
+
+// decodeSettings decodes a stored settings property; a missing one means
+// no settings.
+func decodeSettings(stored any) (*TenantSettings, error) {
+	settings := &TenantSettings{}
+	encoded, ok := stored.(string)
+	if !ok || encoded == "" {
+		return settings, nil
+	}
+	if err := json.Unmarshal([]byte(encoded), settings); err != nil {
+		return nil, fmt.Errorf("decode tenant settings: %w", err)
+	}
+	return settings, nil
+}
+
+// GetSettings retrieves a tenant's settings.
+func (r *TenantRepository) GetSettings(ctx context.Context, id string) (*TenantSettings, error) {
+	result, err := r.db.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, `
+			MATCH (t:Tenant {id: $id})
+			WHERE t.status <> 'DELETED'
+			RETURN t.settings as settings
+		`, map[string]any{"id": id})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrTenantNotFound
+		}
+
+		stored, _ := record.Get("settings")
+		return decodeSettings(stored)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*TenantSettings), nil
+}
+
+// UpdateSettings applies update to a tenant's settings and stores the
+// result. The read, update and write share a transaction, so concurrent
+// updates of different settings don't overwrite each other.
+func (r *TenantRepository) UpdateSettings(ctx context.Context, id string, update func(*TenantSettings) error) (*TenantSettings, error) {
+	result, err := r.db.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		// Setting a property first takes the write lock before the read
+		result, err := tx.Run(ctx, `
+			MATCH (t:Tenant {id: $id})
+			WHERE t.status <> 'DELETED'
+			SET t.updatedAt = datetime()
+			RETURN t.settings as settings
+		`, map[string]any{"id": id})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, errors.ErrTenantNotFound
+		}
+
+		stored, _ := record.Get("settings")
+		settings, err := decodeSettings(stored)
+		if err != nil {
+			return nil, err
+		}
+		if err := update(settings); err != nil {
+			return nil, err
+		}
+
+		encoded, err := json.Marshal(settings)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.Run(ctx, `
+			MATCH (t:Tenant {id: $id})
+			SET t.settings = $settings
+		`, map[string]any{"id": id, "settings": string(encoded)}); err != nil {
+			return nil, err
+		}
+
+		if err := shared.WriteOutboxEvent(ctx, tx, events.TenantUpdated, id, map[string]any{
+			"tenantId": id,
+		}); err != nil {
+			return nil, err
+		}
+
+		return settings, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*TenantSettings), nil
+}
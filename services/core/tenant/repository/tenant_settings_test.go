@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// settingsDB holds one tenant's settings property and counts the events
+// written.
+type settingsDB struct {
+	shared.IDatabase
+	stored any
+	events int
+}
+
+func (d *settingsDB) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return work(&settingsTx{db: d})
+}
+
+func (d *settingsDB) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	return work(&settingsTx{db: d})
+}
+
+type settingsTx struct {
+	neo4j.ManagedTransaction
+	db *settingsDB
+}
+
+func (tx *settingsTx) Run(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+	switch {
+	case strings.Contains(cypher, "SET t.settings"):
+		tx.db.stored = params["settings"]
+	case strings.Contains(cypher, "CREATE (e:Event"):
+		tx.db.events++
+	}
+	return &singleResult{fakeCursor: &fakeCursor{records: []*neo4j.Record{{
+		Keys: []string{"settings"}, Values: []any{tx.db.stored},
+	}}}}, nil
+}
+
+func TestTenantRepository_GetSettings_NoneStored(t *testing.T) {
+	// Arrange
+	repo := NewTenantRepository(&settingsDB{})
+
+	// Act
+	settings, err := repo.GetSettings(context.Background(), "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &TenantSettings{}, settings)
+}
+
+func TestTenantRepository_UpdateSettings_RoundTrip(t *testing.T) {
+	// Arrange
+	db := &settingsDB{stored: `{"timezone":"Europe/Berlin","custom":{"plan":"gold"}}`}
+	repo := NewTenantRepository(db)
+	color := "#1A2B3C"
+
+	// Act
+	updated, updateErr := repo.UpdateSettings(context.Background(), "tenant-1", func(s *TenantSettings) error {
+		s.BrandingColor = &color
+		return nil
+	})
+	read, readErr := repo.GetSettings(context.Background(), "tenant-1")
+
+	// Assert - the update sees and keeps the stored settings
+	require.NoError(t, updateErr)
+	require.NoError(t, readErr)
+	assert.Equal(t, updated, read)
+	assert.Equal(t, "#1A2B3C", *read.BrandingColor)
+	assert.Equal(t, "Europe/Berlin", *read.Timezone)
+	assert.Equal(t, map[string]string{"plan": "gold"}, read.Custom)
+	assert.Equal(t, 1, db.events)
+}
+
+func TestTenantRepository_UpdateSettings_FailedUpdateStoresNothing(t *testing.T) {
+	// Arrange
+	db := &settingsDB{stored: `{"timezone":"UTC"}`}
+	repo := NewTenantRepository(db)
+
+	// Act
+	_, err := repo.UpdateSettings(context.Background(), "tenant-1", func(s *TenantSettings) error {
+		s.Timezone = nil
+		return errors.NewValidationError("timezone", "invalid")
+	})
+
+	// Assert
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, `{"timezone":"UTC"}`, db.stored)
+	assert.Zero(t, db.events)
+}
+
+func TestMockTenantRepository_Settings_CopiesDontAlias(t *testing.T) {
+	// Arrange
+	repo := NewMockTenantRepository()
+	repo.AddTenant(&model.Tenant{ID: "tenant-1", Status: model.TenantStatusActive})
+	ctx := context.Background()
+	_, err := repo.UpdateSettings(ctx, "tenant-1", func(s *TenantSettings) error {
+		s.Custom = map[string]string{"plan": "gold"}
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Act - changing a returned copy, or failing an update, changes nothing
+	read, err := repo.GetSettings(ctx, "tenant-1")
+	require.NoError(t, err)
+	read.Custom["plan"] = "lead"
+	_, failErr := repo.UpdateSettings(ctx, "tenant-1", func(s *TenantSettings) error {
+		s.Custom["plan"] = "tin"
+		return fmt.Errorf("rejected")
+	})
+	again, err := repo.GetSettings(ctx, "tenant-1")
+
+	// Assert
+	require.Error(t, failErr)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"plan": "gold"}, again.Custom)
+}
+
+func TestMockTenantRepository_Settings_TenantNotFound(t *testing.T) {
+	// Arrange
+	repo := NewMockTenantRepository()
+
+	// Act
+	_, getErr := repo.GetSettings(context.Background(), "missing")
+	_, updateErr := repo.UpdateSettings(context.Background(), "missing", func(*TenantSettings) error { return nil })
+
+	// Assert
+	assert.ErrorIs(t, getErr, errors.ErrTenantNotFound)
+	assert.ErrorIs(t, updateErr, errors.ErrTenantNotFound)
+}
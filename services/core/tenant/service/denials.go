@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/yourusername/grgn-stack/pkg/metrics"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// Denial logs are capped at denialLogLimit entries per denialLogWindow so a
+// client probing in a loop cannot flood the logs; the counter still sees
+// every denial.
+const (
+	denialLogLimit  = 10
+	denialLogWindow = time.Minute
+)
+
+// Denial is one request the service refused with ErrForbidden or ErrNotMember.
+type Denial struct {
+	// Operation is the service method that denied access, e.g. "UpdateTenant".
+	Operation string
+	TenantID  string
+	UserID    string
+	// Role is the caller's role in the tenant, empty when not a member.
+	Role model.MembershipRole
+	// RequiredRole is the role the operation needed.
+	RequiredRole model.MembershipRole
+	Err          error
+}
+
+// IDenialRecorder receives authorization denials, e.g. to feed a metrics backend.
+type IDenialRecorder interface {
+	RecordDenial(ctx context.Context, denial Denial)
+}
+
+// DenialKey tags a DenialCounter count.
+type DenialKey struct {
+	Operation    string
+	RequiredRole model.MembershipRole
+}
+
+// DenialCounter is an IDenialRecorder that counts denials in memory by
+// operation and required role. It is safe for concurrent use.
+type DenialCounter struct {
+	mu     sync.Mutex
+	counts map[DenialKey]int
+}
+
+// NewDenialCounter creates an empty DenialCounter.
+func NewDenialCounter() *DenialCounter {
+	return &DenialCounter{counts: make(map[DenialKey]int)}
+}
+
+// RecordDenial implements IDenialRecorder.
+func (c *DenialCounter) RecordDenial(ctx context.Context, denial Denial) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[DenialKey{Operation: denial.Operation, RequiredRole: denial.RequiredRole}]++
+}
+
+// Counts returns a copy of the counts recorded so far.
+func (c *DenialCounter) Counts() map[DenialKey]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.counts)
+}
+
+// MetricsDenialRecorder is an IDenialRecorder that counts denials in the
+// Prometheus metrics by operation and required role.
+type MetricsDenialRecorder struct{}
+
+// RecordDenial implements IDenialRecorder.
+func (MetricsDenialRecorder) RecordDenial(ctx context.Context, denial Denial) {
+	metrics.ObserveDenial(denial.Operation, string(denial.RequiredRole))
+}
+
+// logLimiter allows up to limit events per fixed window and counts the
+// ones it suppresses.
+type logLimiter struct {
+	mu          sync.Mutex
+	limit       int
+	window      time.Duration
+	now         func() time.Time
+	windowStart time.Time
+	allowed     int
+	suppressed  int
+}
+
+func newLogLimiter(limit int, window time.Duration) *logLimiter {
+	return &logLimiter{limit: limit, window: window, now: time.Now}
+}
+
+// allow reports whether an event may be logged and, if so, how many were
+// suppressed since the last one that was.
+func (l *logLimiter) allow() (bool, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now := l.now(); now.Sub(l.windowStart) >= l.window {
+		l.windowStart = now
+		l.allowed = 0
+	}
+	if l.allowed >= l.limit {
+		l.suppressed++
+		return false, 0
+	}
+	l.allowed++
+	suppressed := l.suppressed
+	l.suppressed = 0
+	return true, suppressed
+}
+
+// deny records denial and returns its error, so call sites can
+// `return nil, s.deny(ctx, denial)`.
+func (s *TenantService) deny(ctx context.Context, denial Denial) error {
+	if s.denials != nil {
+		s.denials.RecordDenial(ctx, denial)
+	}
+	if ok, suppressed := s.denialLog.allow(); ok {
+		s.logger.WarnContext(ctx, "authorization denied",
+			"operation", denial.Operation,
+			"tenantId", denial.TenantID,
+			"userId", denial.UserID,
+			"role", denial.Role,
+			"requiredRole", denial.RequiredRole,
+			"error", denial.Err,
+			"suppressed", suppressed)
+	}
+	return denial.Err
+}
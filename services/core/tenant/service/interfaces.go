@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+	"github.com/yourusername/grgn-stack/services/core/tenant/repository"
 )
 
 // ITenantService defines the contract for tenant business operations.
@@ -17,32 +18,155 @@ type ITenantService interface {
 	// GetTenantBySlug retrieves a tenant by slug.
 	GetTenantBySlug(ctx context.Context, slug string) (*model.Tenant, error)
 
-	// GetMyTenants retrieves all tenants the current user is a member of.
-	GetMyTenants(ctx context.Context) ([]*model.Tenant, error)
+	// GetMyTenants retrieves a page of the tenants the current user is a member of.
+	GetMyTenants(ctx context.Context, limit, offset *int) ([]*model.Tenant, error)
+
+	// GetMyInvitations retrieves a page of the current user's pending memberships.
+	GetMyInvitations(ctx context.Context, limit, offset *int) ([]*model.Membership, error)
+
+	// GetMyTenantRoleSummary counts the current user's active memberships
+	// per role, omitting roles they don't hold.
+	GetMyTenantRoleSummary(ctx context.Context) (map[model.MembershipRole]int, error)
+
+	// GetContactableAdmins returns the other owners and admins of each of
+	// the current user's tenants, keyed by tenant ID, owners first.
+	// Returns ErrNotAuthenticated if no user is in context.
+	GetContactableAdmins(ctx context.Context) (map[string][]*model.Membership, error)
+
+	// SuggestAvailableSlug derives a slug from a name, adding a numeric
+	// suffix until it is not taken.
+	SuggestAvailableSlug(ctx context.Context, base string) (string, error)
 
 	// CreateTenant creates a new tenant with the current user as owner.
+	// An invalid name and slug are reported together as ValidationErrors.
 	CreateTenant(ctx context.Context, input model.CreateTenantInput) (*model.Tenant, error)
 
-	// UpdateTenant updates a tenant. Requires ADMIN+ role.
+	// UpdateTenant updates a tenant's name and plan. Requires ADMIN+ role.
+	// Returns a ValidationError if input sets the status.
 	UpdateTenant(ctx context.Context, id string, input model.UpdateTenantInput) (*model.Tenant, error)
 
-	// DeleteTenant soft-deletes a tenant. Requires OWNER role.
-	DeleteTenant(ctx context.Context, id string) (bool, error)
+	// RenameTenant sets a tenant's name and slug together, recording the old
+	// slug; nothing changes if either is invalid or the slug is taken.
+	// Requires ADMIN+ role.
+	RenameTenant(ctx context.Context, tenantID, name, slug string) (*model.Tenant, error)
+
+	// SetBillingEmail sets a tenant's billing/contact email. Requires ADMIN+ role.
+	SetBillingEmail(ctx context.Context, tenantID, email string) (*model.Tenant, error)
+
+	// GetBillingEmail returns the tenant's billing email for ADMIN+ members, nil otherwise.
+	GetBillingEmail(ctx context.Context, tenant *model.Tenant) *string
+
+	// GetSettings retrieves a tenant's settings. Requires membership in the
+	// tenant.
+	GetSettings(ctx context.Context, tenantID string) (*repository.TenantSettings, error)
+
+	// UpdateSettings applies patch to a tenant's settings, keeping the
+	// settings patch leaves out. Unknown keys and invalid values are
+	// reported together as ValidationErrors. Requires ADMIN+ role.
+	UpdateSettings(ctx context.Context, tenantID string, patch SettingsPatch) (*repository.TenantSettings, error)
+
+	// DeleteTenant soft-deletes a tenant, recording the caller and the
+	// optional reason. Requires OWNER role.
+	DeleteTenant(ctx context.Context, id string, reason *string) (*model.DeleteTenantResult, error)
+
+	// GetTenantDeletion retrieves who deleted a tenant, when and why.
+	// Returns ErrForbidden if the caller is not a platform admin.
+	GetTenantDeletion(ctx context.Context, tenantID string) (*model.DeletionInfo, error)
+
+	// RestoreTenant reverses the soft delete of a tenant.
+	// Returns ErrForbidden if the caller is not a platform admin,
+	// ErrNotDeleted if the tenant isn't deleted, and ErrSlugTaken if
+	// another tenant has since taken the slug.
+	RestoreTenant(ctx context.Context, id string) (*model.Tenant, error)
+
+	// SuspendTenant makes a tenant read-only; writes to it return
+	// ErrTenantSuspended until it is unsuspended. Returns
+	// ErrAlreadySuspended if it is already suspended. Requires OWNER role.
+	SuspendTenant(ctx context.Context, id string) (*model.Tenant, error)
+
+	// UnsuspendTenant makes a suspended tenant writable again. Returns
+	// ErrNotSuspended if it isn't suspended. Requires OWNER role.
+	UnsuspendTenant(ctx context.Context, id string) (*model.Tenant, error)
 
 	// Membership operations
 
+	// GetMembership retrieves a membership by ID. Visible to active members of
+	// its tenant and to the membership's own user; anyone else gets ErrForbidden.
+	GetMembership(ctx context.Context, id string) (*model.Membership, error)
+
 	// GetTenantMembers retrieves all members of a tenant.
 	GetTenantMembers(ctx context.Context, tenantID string) ([]*model.Membership, error)
 
-	// InviteMember invites a user to a tenant. Requires ADMIN+ role.
+	// ListMembers retrieves a page of a tenant's members, narrowed by role and
+	// a name or email search and sorted by filter.Sort. Requires membership
+	// in the tenant.
+	ListMembers(ctx context.Context, tenantID string, filter repository.MemberFilter) ([]*model.Membership, error)
+
+	// StreamTenantMembers calls fn for each member of a tenant without
+	// loading them all. Requires ADMIN+ role.
+	StreamTenantMembers(ctx context.Context, tenantID string, fn func(*model.Membership) error) error
+
+	// GetTenantRoleCounts counts a tenant's active members per role.
+	// Requires membership in the tenant.
+	GetTenantRoleCounts(ctx context.Context, tenantID string) (*RoleCounts, error)
+
+	// GetTenantOwners retrieves the owners of a tenant, earliest first.
+	GetTenantOwners(ctx context.Context, tenantID string) ([]*model.Membership, error)
+
+	// GetInviteChain returns a member's inviters, nearest first, bounded by the
+	// traversal limit. Requires membership in the tenant.
+	GetInviteChain(ctx context.Context, membershipID string) (inviters []*model.User, truncated bool, err error)
+
+	// InviteMember invites someone by email like InviteByEmail, so people
+	// without an account get a pending invite. Requires ADMIN+ role.
 	InviteMember(ctx context.Context, tenantID string, input model.InviteMemberInput) (*model.Membership, error)
 
+	// InviteByEmail invites someone by email, creating a pending placeholder
+	// user and membership if they have no account. Requires ADMIN+ role.
+	InviteByEmail(ctx context.Context, tenantID string, input model.InviteMemberInput) (*model.Membership, error)
+
+	// ListPendingInvitations retrieves a tenant's pending memberships,
+	// newest first. Requires ADMIN+ role.
+	ListPendingInvitations(ctx context.Context, tenantID string) ([]*model.Membership, error)
+
 	// UpdateMemberRole updates a member's role. Requires OWNER role.
 	UpdateMemberRole(ctx context.Context, membershipID string, role model.MembershipRole) (*model.Membership, error)
 
+	// UpdateMemberRoleByUser updates a member's role, identifying the
+	// membership by user and tenant. Requires OWNER role.
+	UpdateMemberRoleByUser(ctx context.Context, tenantID, userID string, role model.MembershipRole) (*model.Membership, error)
+
 	// RemoveMember removes a member from a tenant. Requires ADMIN+ role.
-	RemoveMember(ctx context.Context, membershipID string) (bool, error)
+	// Invites the member sent are handed off per the InviteHandoff policy.
+	RemoveMember(ctx context.Context, membershipID string) (*model.MemberRemovalResult, error)
 
 	// LeaveTenant removes the current user from a tenant.
-	LeaveTenant(ctx context.Context, tenantID string) (bool, error)
+	LeaveTenant(ctx context.Context, tenantID string) (*model.MemberRemovalResult, error)
+
+	// CanLeaveTenant reports whether LeaveTenant would let the current user
+	// leave, and if not, why.
+	CanLeaveTenant(ctx context.Context, tenantID string) (*model.LeaveEligibility, error)
+
+	// ReassignInvites repoints invites created by a departing user to another
+	// active ADMIN+ member of the tenant. Requires OWNER role.
+	ReassignInvites(ctx context.Context, fromUserID, toUserID, tenantID string) (int, error)
+
+	// CreateInviteToken signs an invite link token for a membership.
+	// Requires ADMIN+ role in the membership's tenant.
+	CreateInviteToken(ctx context.Context, membershipID string) (string, error)
+
+	// AcceptInviteByToken accepts the pending membership named by an invite
+	// token. The caller's email must match the token.
+	// Returns ErrInvalidToken if the token is invalid or expired.
+	AcceptInviteByToken(ctx context.Context, token string) (*model.Membership, error)
+
+	// UpdateTenantPlans applies plan changes for many tenants in batches and
+	// reports a result per change. Requires platform admin.
+	UpdateTenantPlans(ctx context.Context, changes []*model.PlanChange) ([]*model.PlanChangeResult, error)
+
+	// OffboardUser hands a departing user's sole ownerships to the
+	// successors named per tenant, removes all their memberships and
+	// soft-deletes their account in one unit of work. Requires platform admin.
+	// Returns a ValidationError if a successor is invalid or missing.
+	OffboardUser(ctx context.Context, userID string, ownershipSuccessors map[string]string) (*model.OffboardUserResult, error)
 }
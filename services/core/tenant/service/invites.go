@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/notify"
+	"github.com/yourusername/grgn-stack/pkg/validation"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// errInviteTokensDisabled is returned when the service has no token manager.
+var errInviteTokensDisabled = fmt.Errorf("invite tokens are not configured")
+
+// InviteByEmail invites someone to a tenant by email address. Existing users
+// become members straight away, as with InviteMember. For an email without
+// an account, a PENDING placeholder user and a PENDING membership are
+// created and the notifier sends an invite link so they can sign up.
+// Re-inviting a pending member sends the link again, renewing the invite, and
+// makes the caller its only inviter, so the latest inviter wins; inviting an
+// active member returns
+// ErrAlreadyMember. A new pending invite beyond the tenant's limit returns
+// ErrInviteLimitReached. Requires ADMIN+ role.
+//
+// The pending membership is the invitation; there is no separate record of
+// it. Its joinedAt holds when it was last sent, from which it expires after
+// the invite TTL, and accepting it with AcceptInviteByToken makes it active
+// in place. Invitees thus already have their role, inviter and invite chain,
+// and every membership query and owner check covers them without a
+// conversion step.
+func (s *TenantService) InviteByEmail(ctx context.Context, tenantID string, input model.InviteMemberInput) (*model.Membership, error) {
+	return s.inviteByEmail(ctx, "InviteByEmail", tenantID, input)
+}
+
+// inviteByEmail implements InviteByEmail, recording denials under operation.
+func (s *TenantService) inviteByEmail(ctx context.Context, operation, tenantID string, input model.InviteMemberInput) (*model.Membership, error) {
+	userID, role, err := s.authorizeInvite(ctx, operation, tenantID, input.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Placeholder accounts are stored normalized, like ones created on sign-in
+	email := validation.NormalizeEmail(input.Email)
+	if err := validation.ValidateEmail(email); err != nil {
+		return nil, err
+	}
+
+	// Check the cap before creating a placeholder account for the invitee;
+	// creating the invite checks it again atomically
+	cutoff := s.inviteCutoff()
+	if err := s.checkPendingInviteLimit(ctx, tenantID, email, cutoff); err != nil {
+		return nil, err
+	}
+
+	invitee, _, err := s.userRepo.FindOrCreateByEmail(ctx, email, model.UserStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users who have signed up join immediately
+	if invitee.Status != model.UserStatusPending {
+		return s.membershipRepo.Create(ctx, invitee.ID, tenantID, role, &userID)
+	}
+
+	membership, err := s.membershipRepo.FindByUserAndTenant(ctx, invitee.ID, tenantID)
+	switch {
+	case errors.Is(err, errors.ErrMembershipNotFound):
+		membership, err = s.membershipRepo.CreatePendingWithinLimit(ctx, invitee.ID, tenantID, role, &userID, s.maxPendingInvites, cutoff)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case membership.Status != model.MembershipStatusPending:
+		return nil, errors.ErrAlreadyMember
+	case membership.InvitedBy == nil || membership.InvitedBy.ID != userID:
+		// The latest inviter wins, so the chain leads to whoever resent it
+		membership, err = s.membershipRepo.SetInviter(ctx, membership.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		membership, err = s.membershipRepo.RenewInvite(ctx, membership.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.sendInvite(ctx, email, membership)
+	return membership, nil
+}
+
+// ListPendingInvitations retrieves a tenant's open invites, newest first,
+// including those of people who haven't signed up yet. An invite expires,
+// along with its link, once the invite TTL has passed since it was last
+// sent; expired invites are left out until they are resent.
+// Requires ADMIN+ role.
+func (s *TenantService) ListPendingInvitations(ctx context.Context, tenantID string) ([]*model.Membership, error) {
+	if _, err := s.requireRole(ctx, "ListPendingInvitations", tenantID, model.MembershipRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	return s.membershipRepo.FindPendingByTenantID(ctx, tenantID, s.inviteCutoff())
+}
+
+// inviteCutoff returns the time before which a pending invite's last send
+// makes it expired.
+func (s *TenantService) inviteCutoff() time.Time {
+	return time.Now().Add(-s.inviteTTL)
+}
+
+// checkPendingInviteLimit returns ErrInviteLimitReached if the tenant is at
+// its pending invite limit and inviting email would add another. Signed-up
+// users join immediately and resending an open invite adds nothing, so both
+// are still allowed at the limit. Accepted, removed and expired invites stop
+// counting; invites last sent before cutoff have expired.
+func (s *TenantService) checkPendingInviteLimit(ctx context.Context, tenantID, email string, cutoff time.Time) error {
+	pending, err := s.membershipRepo.CountPending(ctx, tenantID, cutoff)
+	if err != nil {
+		return err
+	}
+	if pending < s.maxPendingInvites {
+		return nil
+	}
+
+	invitee, err := s.userRepo.FindByEmail(ctx, email)
+	if errors.Is(err, errors.ErrUserNotFound) {
+		return errors.ErrInviteLimitReached
+	}
+	if err != nil {
+		return err
+	}
+	if invitee.Status != model.UserStatusPending {
+		return nil
+	}
+
+	// An existing membership is either resent or rejected as already a
+	// member, but resending an expired invite reopens it
+	membership, err := s.membershipRepo.FindByUserAndTenant(ctx, invitee.ID, tenantID)
+	if errors.Is(err, errors.ErrMembershipNotFound) {
+		return errors.ErrInviteLimitReached
+	}
+	if err != nil {
+		return err
+	}
+	if membership.Status == model.MembershipStatusPending && !membership.JoinedAt.After(cutoff) {
+		return errors.ErrInviteLimitReached
+	}
+	return nil
+}
+
+// sendInvite notifies the invitee of a committed pending membership, with an
+// invite link when tokens are enabled. Failures are logged rather than
+// returned: the membership stands and the invite can be resent.
+func (s *TenantService) sendInvite(ctx context.Context, email string, membership *model.Membership) {
+	invite := notify.Invite{
+		To:           email,
+		TenantName:   s.tenantName(ctx, membership.Tenant.ID),
+		Role:         string(membership.Role),
+		MembershipID: membership.ID,
+	}
+
+	if s.inviteTokens != nil {
+		token, err := s.inviteTokens.IssueInviteToken(membership.ID, email, s.inviteTTL)
+		if err != nil {
+			s.logger.WarnContext(ctx, "issue invite token failed", "membershipId", membership.ID, "error", err)
+			return
+		}
+		invite.Token = token
+	}
+
+	if err := s.notifier.SendInvite(ctx, invite); err != nil {
+		s.logger.WarnContext(ctx, "send invite failed", "membershipId", membership.ID, "error", err)
+	}
+}
+
+// tenantName returns the tenant's display name for notifications, or the
+// ID if the tenant can't be loaded.
+func (s *TenantService) tenantName(ctx context.Context, tenantID string) string {
+	tenant, err := s.tenantRepo.FindByID(ctx, tenantID)
+	if err != nil {
+		return tenantID
+	}
+	return tenant.Name
+}
+
+// CreateInviteToken signs an invite link token for a membership.
+// The token carries the invitee's current email and expires after the
+// configured invite TTL.
+func (s *TenantService) CreateInviteToken(ctx context.Context, membershipID string) (string, error) {
+	if s.inviteTokens == nil {
+		return "", errInviteTokensDisabled
+	}
+
+	membership, err := s.membershipRepo.FindByID(ctx, membershipID)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := s.requireWritable(ctx, "CreateInviteToken", membership.Tenant.ID, model.MembershipRoleAdmin); err != nil {
+		return "", err
+	}
+
+	invitee, err := s.userRepo.FindByID(ctx, membership.User.ID)
+	if err != nil {
+		return "", err
+	}
+
+	return s.inviteTokens.IssueInviteToken(membership.ID, invitee.Email, s.inviteTTL)
+}
+
+// AcceptInviteByToken accepts the membership named by an invite token on
+// behalf of the authenticated user. The caller's email must match the
+// token's, and the membership must still belong to that user. Accepting an
+// already active membership returns it unchanged. An invite last sent more
+// than the invite TTL ago has expired, and its tokens are rejected with
+// ErrInvalidToken like expired ones, even if issued since.
+func (s *TenantService) AcceptInviteByToken(ctx context.Context, token string) (*model.Membership, error) {
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.inviteTokens == nil {
+		return nil, errInviteTokensDisabled
+	}
+
+	claims, err := s.inviteTokens.ParseInviteToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(caller.Email, claims.Email) {
+		return nil, errors.ErrForbidden
+	}
+
+	membership, err := s.membershipRepo.FindByID(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if membership.User == nil || membership.User.ID != userID {
+		return nil, errors.ErrForbidden
+	}
+
+	if membership.Status != model.MembershipStatusPending {
+		return membership, nil
+	}
+	if !membership.JoinedAt.After(s.inviteCutoff()) {
+		return nil, errors.ErrInvalidToken
+	}
+	if err := s.checkNotSuspended(ctx, membership.Tenant.ID); err != nil {
+		return nil, err
+	}
+	return s.membershipRepo.Accept(ctx, membership.ID)
+}
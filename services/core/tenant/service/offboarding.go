@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// OffboardUser removes a departing user from every tenant and soft-deletes
+// their account as one unit of work. ownershipSuccessors maps tenant IDs
+// to the membership ID of the member who becomes OWNER in the user's
+// place; every tenant the user is the sole owner of needs one, so no
+// tenant is left without an owner. Requires platform admin.
+//
+// The invites the user sent go to the tenant's new owner, else to its
+// earliest other owner, or are detached under InviteHandoffDetach; the
+// platform admin doing the offboarding isn't a member, so
+// InviteHandoffRemover falls back to the owner too. Memberships in deleted
+// tenants are left as they are. The user's tokens stop parsing once they
+// are deleted, so none need revoking.
+func (s *TenantService) OffboardUser(ctx context.Context, userID string, ownershipSuccessors map[string]string) (*model.OffboardUserResult, error) {
+	adminID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !auth.IsPlatformAdmin(ctx) {
+		return nil, errors.ErrForbidden
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	memberships, err := s.allMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	successors, err := s.ownershipSuccessors(ctx, userID, memberships, ownershipSuccessors)
+	if err != nil {
+		return nil, err
+	}
+	handoffs, err := s.offboardingHandoffs(ctx, userID, memberships, successors)
+	if err != nil {
+		return nil, err
+	}
+
+	var transferred []*model.Membership
+	handedOff := 0
+	err = s.uow.Write(ctx, func(tx neo4j.ManagedTransaction) error {
+		transferred = make([]*model.Membership, 0, len(successors))
+		handedOff = 0
+		for _, successor := range successors {
+			if successor.Role == model.MembershipRoleOwner {
+				transferred = append(transferred, successor)
+				continue
+			}
+			promoted, err := s.membershipRepo.UpdateRoleTx(ctx, tx, successor.ID, model.MembershipRoleOwner)
+			if err != nil {
+				return err
+			}
+			transferred = append(transferred, promoted)
+		}
+
+		// Owners may have left since ownershipSuccessors counted them
+		if err := s.checkOwnersRemain(ctx, tx, memberships, successors); err != nil {
+			return err
+		}
+
+		for _, membership := range memberships {
+			n, err := s.handOffInvitesTx(ctx, tx, membership.Tenant.ID, userID, handoffs[membership.Tenant.ID])
+			if err != nil {
+				return err
+			}
+			handedOff += n
+		}
+
+		for _, membership := range memberships {
+			if err := s.membershipRepo.DeleteTx(ctx, tx, membership.ID); err != nil {
+				return err
+			}
+		}
+		return s.userRepo.DeleteTx(ctx, tx, userID, adminID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, membership := range transferred {
+		if successors[i].Role != model.MembershipRoleOwner {
+			s.sendRoleChanged(ctx, membership)
+		}
+	}
+
+	return &model.OffboardUserResult{
+		UserID:                 userID,
+		TransferredOwnerships:  transferred,
+		RemovedMembershipCount: len(memberships),
+		InvitesHandedOff:       handedOff,
+	}, nil
+}
+
+// offboardingHandoffs returns, for each tenant an offboarded user belongs
+// to, the user who takes over the invites they sent there: the tenant's
+// new owner, else its earliest other owner. "" means the invites are
+// detached.
+func (s *TenantService) offboardingHandoffs(ctx context.Context, userID string, memberships, successors []*model.Membership) (map[string]string, error) {
+	handoffs := make(map[string]string, len(memberships))
+	if s.inviteHandoff == InviteHandoffDetach {
+		return handoffs, nil
+	}
+
+	for _, successor := range successors {
+		handoffs[successor.Tenant.ID] = successor.User.ID
+	}
+	for _, membership := range memberships {
+		tenantID := membership.Tenant.ID
+		if _, ok := handoffs[tenantID]; ok {
+			continue
+		}
+		owner, err := s.otherOwner(ctx, tenantID, userID)
+		if err != nil {
+			return nil, err
+		}
+		handoffs[tenantID] = owner
+	}
+	return handoffs, nil
+}
+
+// checkOwnersRemain fails with the same error as ownershipSuccessors if a
+// tenant the user owns without a successor would be left without an owner,
+// counting in tx so the check holds for the unit of work that removes them.
+func (s *TenantService) checkOwnersRemain(ctx context.Context, tx neo4j.ManagedTransaction, memberships, successors []*model.Membership) error {
+	succeeded := make(map[string]bool, len(successors))
+	for _, successor := range successors {
+		succeeded[successor.Tenant.ID] = true
+	}
+
+	var missing []string
+	for _, membership := range memberships {
+		tenantID := membership.Tenant.ID
+		if membership.Role != model.MembershipRoleOwner || membership.Status != model.MembershipStatusActive || succeeded[tenantID] {
+			continue
+		}
+		owners, err := s.membershipRepo.CountOwnersTx(ctx, tx, tenantID)
+		if err != nil {
+			return err
+		}
+		if owners <= 1 {
+			missing = append(missing, tenantID)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return errors.NewValidationError("ownershipSuccessors",
+			"a successor is required for each tenant the user is the sole owner of: "+strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// allMemberships returns every membership of a user in non-deleted
+// tenants, pending invites included.
+func (s *TenantService) allMemberships(ctx context.Context, userID string) ([]*model.Membership, error) {
+	var all []*model.Membership
+	for offset := 0; ; offset += MaxPageSize {
+		page, err := s.membershipRepo.FindByUserID(ctx, userID, nil, MaxPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < MaxPageSize {
+			return all, nil
+		}
+	}
+}
+
+// ownershipSuccessors checks the successors named for an offboarded
+// user's tenants and returns their memberships, ordered by tenant ID.
+// Each must be another active member of a tenant the user owns, and every
+// tenant the user is the sole owner of must have one.
+func (s *TenantService) ownershipSuccessors(ctx context.Context, userID string, memberships []*model.Membership, successorIDs map[string]string) ([]*model.Membership, error) {
+	owned := make(map[string]*model.Membership)
+	for _, membership := range memberships {
+		if membership.Role == model.MembershipRoleOwner && membership.Status == model.MembershipStatusActive {
+			owned[membership.Tenant.ID] = membership
+		}
+	}
+
+	tenantIDs := make([]string, 0, len(successorIDs))
+	for tenantID := range successorIDs {
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+	slices.Sort(tenantIDs)
+
+	successors := make([]*model.Membership, 0, len(tenantIDs))
+	for _, tenantID := range tenantIDs {
+		if _, ok := owned[tenantID]; !ok {
+			return nil, errors.NewValidationError("ownershipSuccessors", fmt.Sprintf("user does not own tenant %s", tenantID))
+		}
+
+		successor, err := s.membershipRepo.FindByID(ctx, successorIDs[tenantID])
+		if errors.Is(err, errors.ErrMembershipNotFound) {
+			return nil, errors.NewValidationError("ownershipSuccessors", fmt.Sprintf("successor for tenant %s must be a member of it", tenantID))
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case successor.Tenant.ID != tenantID:
+			return nil, errors.NewValidationError("ownershipSuccessors", fmt.Sprintf("successor for tenant %s must be a member of it", tenantID))
+		case successor.User.ID == userID:
+			return nil, errors.NewValidationError("ownershipSuccessors", fmt.Sprintf("successor for tenant %s must be another member", tenantID))
+		case successor.Status != model.MembershipStatusActive:
+			return nil, errors.NewValidationError("ownershipSuccessors", fmt.Sprintf("successor for tenant %s must be an active member", tenantID))
+		}
+		successors = append(successors, successor)
+	}
+
+	var missing []string
+	for tenantID, membership := range owned {
+		if _, ok := successorIDs[tenantID]; ok {
+			continue
+		}
+		soleOwner, err := s.isSoleOwner(ctx, membership)
+		if err != nil {
+			return nil, err
+		}
+		if soleOwner {
+			missing = append(missing, tenantID)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return nil, errors.NewValidationError("ownershipSuccessors",
+			"a successor is required for each tenant the user is the sole owner of: "+strings.Join(missing, ", "))
+	}
+
+	return successors, nil
+}
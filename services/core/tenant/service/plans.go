@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// planBatchSize is the number of plan changes applied per repository call.
+const planBatchSize = 100
+
+// PlanMemberLimits is the maximum number of members allowed on each plan.
+// Plans without an entry are unlimited.
+var PlanMemberLimits = map[model.TenantPlan]int{
+	model.TenantPlanFree: 5,
+	model.TenantPlanPro:  50,
+}
+
+// exceedsMemberLimit reports whether memberCount is over the plan's limit.
+func exceedsMemberLimit(plan model.TenantPlan, memberCount int) bool {
+	limit, ok := PlanMemberLimits[plan]
+	return ok && memberCount > limit
+}
+
+// UpdateTenantPlans applies plan changes pushed by the billing system.
+// Changes with an invalid plan or an unknown tenant are reported as failed
+// without affecting the rest of the batch. Downgrades are applied even when
+// the tenant has more members than the new plan allows; those results are
+// flagged with OverMemberLimit so billing can follow up.
+func (s *TenantService) UpdateTenantPlans(ctx context.Context, changes []*model.PlanChange) ([]*model.PlanChangeResult, error) {
+	if _, err := auth.GetUserID(ctx); err != nil {
+		return nil, err
+	}
+	if !auth.IsPlatformAdmin(ctx) {
+		return nil, errors.ErrForbidden
+	}
+
+	results := make([]*model.PlanChangeResult, len(changes))
+	valid := make([]*model.PlanChange, 0, len(changes))
+	for i, change := range changes {
+		results[i] = &model.PlanChangeResult{TenantID: change.TenantID, Plan: change.Plan}
+		if !change.Plan.IsValid() {
+			results[i].Error = errorMessage(errors.NewValidationError("plan", "unknown plan "+string(change.Plan)))
+			continue
+		}
+		valid = append(valid, change)
+	}
+
+	updated := make(map[string]*model.Tenant, len(valid))
+	for start := 0; start < len(valid); start += planBatchSize {
+		end := min(start+planBatchSize, len(valid))
+		tenants, err := s.tenantRepo.UpdatePlans(ctx, valid[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, tenant := range tenants {
+			updated[tenant.ID] = tenant
+		}
+	}
+
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		tenant, ok := updated[result.TenantID]
+		if !ok {
+			result.Error = errorMessage(errors.ErrTenantNotFound)
+			continue
+		}
+		result.Applied = true
+		result.MemberCount = tenant.MemberCount
+		result.OverMemberLimit = exceedsMemberLimit(tenant.Plan, tenant.MemberCount)
+	}
+
+	return results, nil
+}
+
+// errorMessage returns a pointer to the error's message for optional result fields.
+func errorMessage(err error) *string {
+	msg := err.Error()
+	return &msg
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// RoleCounts is how many active members of a tenant hold each role.
+type RoleCounts struct {
+	Owners  int
+	Admins  int
+	Members int
+	Viewers int
+}
+
+// Total returns the number of active members across all roles.
+func (c RoleCounts) Total() int {
+	return c.Owners + c.Admins + c.Members + c.Viewers
+}
+
+// GetTenantRoleCounts counts a tenant's active members per role, with 0 for
+// roles no one holds. Requires membership in the tenant.
+func (s *TenantService) GetTenantRoleCounts(ctx context.Context, tenantID string) (*RoleCounts, error) {
+	if _, err := s.requireRole(ctx, "GetTenantRoleCounts", tenantID, model.MembershipRoleViewer); err != nil {
+		return nil, err
+	}
+
+	counts, err := s.membershipRepo.CountByRole(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RoleCounts{
+		Owners:  counts[model.MembershipRoleOwner],
+		Admins:  counts[model.MembershipRoleAdmin],
+		Members: counts[model.MembershipRoleMember],
+		Viewers: counts[model.MembershipRoleViewer],
+	}, nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/validation"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+	"github.com/yourusername/grgn-stack/services/core/tenant/repository"
+)
+
+// Limits on the custom settings a tenant can store.
+const (
+	MaxCustomSettings    = 50
+	MaxCustomKeyLength   = 64
+	MaxCustomValueLength = 1024
+)
+
+// SettingsPatch changes some of a tenant's settings. Keys are the names
+// TenantSettings encodes to: defaultRole, brandingColor, timezone and
+// custom. Settings the patch leaves out keep their values, and a nil value
+// clears one. custom is itself a patch: a map whose string values set
+// custom keys and whose nil values remove them.
+type SettingsPatch map[string]any
+
+// GetSettings retrieves a tenant's settings. Requires membership in the
+// tenant.
+func (s *TenantService) GetSettings(ctx context.Context, tenantID string) (*repository.TenantSettings, error) {
+	if _, err := s.requireRole(ctx, "GetSettings", tenantID, model.MembershipRoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.tenantRepo.GetSettings(ctx, tenantID)
+}
+
+// UpdateSettings applies patch to a tenant's settings and returns them.
+// Every invalid or unknown key is reported at once and nothing is changed
+// if any is. Requires ADMIN+ role.
+func (s *TenantService) UpdateSettings(ctx context.Context, tenantID string, patch SettingsPatch) (*repository.TenantSettings, error) {
+	if _, err := s.requireWritable(ctx, "UpdateSettings", tenantID, model.MembershipRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if len(patch) == 0 {
+		return s.tenantRepo.GetSettings(ctx, tenantID)
+	}
+	return s.tenantRepo.UpdateSettings(ctx, tenantID, func(settings *repository.TenantSettings) error {
+		return applySettingsPatch(settings, patch)
+	})
+}
+
+// applySettingsPatch validates patch and applies it to settings. Keys are
+// checked in sorted order so problems are reported deterministically.
+func applySettingsPatch(settings *repository.TenantSettings, patch SettingsPatch) error {
+	var problems errors.ValidationErrors
+	for _, key := range slices.Sorted(maps.Keys(patch)) {
+		value := patch[key]
+		switch key {
+		case "defaultRole":
+			role, ok := optionalString(value)
+			switch {
+			case !ok:
+				problems.Add(key, "must be a role")
+			case role == nil:
+				settings.DefaultRole = nil
+			case !model.MembershipRole(*role).IsValid():
+				problems.Add(key, fmt.Sprintf("unknown role %q", *role))
+			case model.MembershipRole(*role) == model.MembershipRoleOwner:
+				problems.Add(key, "cannot be OWNER")
+			default:
+				r := model.MembershipRole(*role)
+				settings.DefaultRole = &r
+			}
+		case "brandingColor":
+			settings.BrandingColor = validatedString(&problems, key, value, validation.ValidateBrandingColor, settings.BrandingColor)
+		case "timezone":
+			settings.Timezone = validatedString(&problems, key, value, validation.ValidateTimezone, settings.Timezone)
+		case "custom":
+			applyCustomPatch(&problems, settings, value)
+		default:
+			problems.Add(key, "unknown setting; put your own keys under custom")
+		}
+	}
+	return problems.Err()
+}
+
+// validatedString returns the value a string setting takes from a patch
+// value, recording a problem and keeping current if it is invalid.
+func validatedString(problems *errors.ValidationErrors, key string, value any, validate func(string) error, current *string) *string {
+	s, ok := optionalString(value)
+	if !ok {
+		problems.Add(key, "must be a string")
+		return current
+	}
+	if s == nil {
+		return nil
+	}
+	if err := validate(*s); err != nil {
+		problems.AddError(key, err)
+		return current
+	}
+	return s
+}
+
+// applyCustomPatch applies the custom part of a patch to settings.Custom.
+// nil clears every custom key.
+func applyCustomPatch(problems *errors.ValidationErrors, settings *repository.TenantSettings, value any) {
+	var patch map[string]any
+	switch v := value.(type) {
+	case nil:
+		settings.Custom = nil
+		return
+	case map[string]any:
+		patch = v
+	case map[string]string:
+		patch = make(map[string]any, len(v))
+		for key, s := range v {
+			patch[key] = s
+		}
+	default:
+		problems.Add("custom", "must be an object")
+		return
+	}
+
+	custom := maps.Clone(settings.Custom)
+	if custom == nil {
+		custom = make(map[string]string)
+	}
+	for _, key := range slices.Sorted(maps.Keys(patch)) {
+		field := "custom." + key
+		s, ok := optionalString(patch[key])
+		switch {
+		case key == "" || utf8.RuneCountInString(key) > MaxCustomKeyLength:
+			problems.Add(field, fmt.Sprintf("keys must be 1 to %d characters", MaxCustomKeyLength))
+		case !ok:
+			problems.Add(field, "must be a string")
+		case s == nil:
+			delete(custom, key)
+		case utf8.RuneCountInString(*s) > MaxCustomValueLength:
+			problems.Add(field, fmt.Sprintf("must be at most %d characters", MaxCustomValueLength))
+		default:
+			custom[key] = *s
+		}
+	}
+	if len(custom) > MaxCustomSettings {
+		problems.Add("custom", fmt.Sprintf("at most %d custom settings are allowed", MaxCustomSettings))
+		return
+	}
+
+	if len(custom) == 0 {
+		custom = nil
+	}
+	settings.Custom = custom
+}
+
+// optionalString converts a patch value to a string setting: nil clears it.
+// ok is false for values that are neither.
+func optionalString(value any) (s *string, ok bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, true
+	case string:
+		return &v, true
+	case *string:
+		return v, true
+	}
+	return nil, false
+}
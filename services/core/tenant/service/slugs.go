@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yourusername/grgn-stack/pkg/auth"
+	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/validation"
+	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+)
+
+// maxSlugSuggestions bounds the suffixes tried before giving up.
+const maxSlugSuggestions = 100
+
+// SuggestAvailableSlug slugifies base and returns the first free candidate,
+// appending -2, -3, ... on collisions and skipping reserved slugs. Deleted
+// tenants keep their slugs so they can be restored. Returns ErrSlugTaken if
+// every candidate is in use. The slug is not reserved; CreateTenant may
+// still race another caller for it.
+func (s *TenantService) SuggestAvailableSlug(ctx context.Context, base string) (string, error) {
+	if _, err := auth.GetUserID(ctx); err != nil {
+		return "", err
+	}
+
+	slug := validation.SlugifyName(base)
+	for n := 1; n <= maxSlugSuggestions; n++ {
+		candidate := slug
+		if n > 1 {
+			candidate = validation.SlugWithSuffix(slug, n)
+		}
+		if validation.IsReservedSlug(candidate, s.reservedSlugs) {
+			continue
+		}
+
+		exists, err := s.tenantRepo.ExistsBySlug(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+	}
+	return "", errors.ErrSlugTaken
+}
+
+// validateSlug returns ErrInvalidSlug if slug is malformed and
+// ErrReservedSlug if it is reserved.
+func (s *TenantService) validateSlug(slug string) error {
+	err := validation.ValidateTenantSlug(slug, s.reservedSlugs)
+	if err == nil || errors.Is(err, errors.ErrReservedSlug) {
+		return err
+	}
+	return errors.ErrInvalidSlug
+}
+
+// RenameTenant sets a tenant's name and slug in one transaction, so a client
+// renaming both can't be left with only one applied. Both are validated
+// before anything is written and the old slug is kept in the tenant's slug
+// history. Requires ADMIN+ role.
+func (s *TenantService) RenameTenant(ctx context.Context, tenantID, name, slug string) (*model.Tenant, error) {
+	if _, err := s.requireWritable(ctx, "RenameTenant", tenantID, model.MembershipRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	name = validation.NormalizeSpace(name)
+	slug = strings.TrimSpace(slug)
+
+	var problems errors.ValidationErrors
+	problems.AddError("name", validation.ValidateTenantName(name))
+	problems.AddError("slug", s.validateSlug(slug))
+	if err := problems.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.tenantRepo.Rename(ctx, tenantID, name, slug)
+}
@@ -2,20 +2,79 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/yourusername/grgn-stack/pkg/auth"
 	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/notify"
 	"github.com/yourusername/grgn-stack/pkg/validation"
 	identityRepo "github.com/yourusername/grgn-stack/services/core/identity/repository"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
 	"github.com/yourusername/grgn-stack/services/core/tenant/repository"
 )
 
+// DefaultMaxTraversalDepth is the hop limit used for graph traversals
+// unless overridden with WithMaxTraversalDepth.
+const DefaultMaxTraversalDepth = 25
+
+// DefaultMaxPendingInvites is the pending invite cap per tenant unless
+// overridden with WithMaxPendingInvites.
+const DefaultMaxPendingInvites = 500
+
+// DefaultInviteTTL is how long a pending invite stays open after it was
+// last sent unless WithInviteTokens sets another TTL.
+const DefaultInviteTTL = 7 * 24 * time.Hour
+
+// DefaultPageSize and MaxPageSize bound list queries such as GetMyTenants.
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+// InviteHandoff decides what happens to the invites a member sent when the
+// member is removed from the tenant. The invitees' memberships are kept
+// either way.
+type InviteHandoff int
+
+const (
+	// InviteHandoffOwner repoints the invites to the tenant's earliest owner.
+	InviteHandoffOwner InviteHandoff = iota
+	// InviteHandoffRemover repoints the invites to the member doing the removal.
+	InviteHandoffRemover
+	// InviteHandoffDetach deletes the invites, leaving the memberships
+	// without an inviter.
+	InviteHandoffDetach
+)
+
 // TenantService implements ITenantService with business logic.
 type TenantService struct {
-	tenantRepo     repository.ITenantRepository
-	membershipRepo repository.IMembershipRepository
-	userRepo       identityRepo.IUserRepository
+	tenantRepo        repository.ITenantRepository
+	membershipRepo    repository.IMembershipRepository
+	userRepo          identityRepo.IUserRepository
+	uow               shared.IUnitOfWork
+	maxTraversalDepth int
+	maxPendingInvites int
+	inviteHandoff     InviteHandoff
+	reservedSlugs     []string
+
+	// Pending invites and their links expire inviteTTL after being sent
+	inviteTTL time.Duration
+
+	// Invite links; nil until configured with WithInviteTokens
+	inviteTokens *auth.TokenManager
+
+	// Notifications are sent after the change they describe has committed
+	notifier notify.Notifier
+	logger   *slog.Logger
+
+	// Authorization denials; denials may be nil
+	denials   IDenialRecorder
+	denialLog *logLimiter
 }
 
 // NewTenantService creates a new TenantService.
@@ -23,12 +82,82 @@ func NewTenantService(
 	tenantRepo repository.ITenantRepository,
 	membershipRepo repository.IMembershipRepository,
 	userRepo identityRepo.IUserRepository,
+	uow shared.IUnitOfWork,
 ) *TenantService {
 	return &TenantService{
-		tenantRepo:     tenantRepo,
-		membershipRepo: membershipRepo,
-		userRepo:       userRepo,
+		tenantRepo:        tenantRepo,
+		membershipRepo:    membershipRepo,
+		userRepo:          userRepo,
+		uow:               uow,
+		maxTraversalDepth: DefaultMaxTraversalDepth,
+		maxPendingInvites: DefaultMaxPendingInvites,
+		inviteTTL:         DefaultInviteTTL,
+		reservedSlugs:     validation.DefaultReservedSlugs,
+		notifier:          notify.Nop{},
+		logger:            slog.Default(),
+		denialLog:         newLogLimiter(denialLogLimit, denialLogWindow),
+	}
+}
+
+// WithMaxTraversalDepth sets the hop limit for graph traversals.
+// Non-positive values keep the current limit.
+func (s *TenantService) WithMaxTraversalDepth(depth int) *TenantService {
+	if depth > 0 {
+		s.maxTraversalDepth = depth
 	}
+	return s
+}
+
+// WithMaxPendingInvites sets how many pending invites a tenant may have.
+// Non-positive values keep the current limit.
+func (s *TenantService) WithMaxPendingInvites(limit int) *TenantService {
+	if limit > 0 {
+		s.maxPendingInvites = limit
+	}
+	return s
+}
+
+// WithReservedSlugs sets the slugs tenants can't take. The default is
+// validation.DefaultReservedSlugs.
+func (s *TenantService) WithReservedSlugs(slugs []string) *TenantService {
+	s.reservedSlugs = slugs
+	return s
+}
+
+// WithInviteHandoff sets what RemoveMember does with the invites the
+// removed member sent. The default is InviteHandoffOwner.
+func (s *TenantService) WithInviteHandoff(handoff InviteHandoff) *TenantService {
+	s.inviteHandoff = handoff
+	return s
+}
+
+// WithInviteTokens enables signed invite links valid for ttl. Pending
+// invites expire along with their links.
+func (s *TenantService) WithInviteTokens(tokens *auth.TokenManager, ttl time.Duration) *TenantService {
+	s.inviteTokens = tokens
+	s.inviteTTL = ttl
+	return s
+}
+
+// WithNotifier sends invite and role change notifications through n.
+// Invites include a link only when invite tokens are also enabled.
+func (s *TenantService) WithNotifier(n notify.Notifier) *TenantService {
+	s.notifier = n
+	return s
+}
+
+// WithDenialRecorder passes every authorization denial to r, e.g. a
+// DenialCounter or a metrics backend.
+func (s *TenantService) WithDenialRecorder(r IDenialRecorder) *TenantService {
+	s.denials = r
+	return s
+}
+
+// WithLogger sets the logger for denials and best-effort failures.
+// The default is slog.Default().
+func (s *TenantService) WithLogger(logger *slog.Logger) *TenantService {
+	s.logger = logger
+	return s
 }
 
 // Role hierarchy: OWNER > ADMIN > MEMBER > VIEWER
@@ -44,23 +173,65 @@ func hasMinRole(actual, required model.MembershipRole) bool {
 	return roleOrder[actual] >= roleOrder[required]
 }
 
-// requireRole checks if the current user has at least the required role in a tenant.
-func (s *TenantService) requireRole(ctx context.Context, tenantID string, minRole model.MembershipRole) (*model.Membership, error) {
+// requireRole checks if the current user has at least the required role in a
+// tenant and returns their role. A sufficient role in the request's token
+// claims is trusted without a lookup; otherwise the membership decides.
+// Denials are recorded under operation.
+func (s *TenantService) requireRole(ctx context.Context, operation, tenantID string, minRole model.MembershipRole) (model.MembershipRole, error) {
 	userID, err := auth.GetUserID(ctx)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+
+	if role, ok := claimedRole(ctx, tenantID); ok && hasMinRole(role, minRole) {
+		return role, nil
 	}
 
+	denial := Denial{Operation: operation, TenantID: tenantID, UserID: userID, RequiredRole: minRole}
+
+	// Only a missing or pending membership means "not a member"; other
+	// failures (timeouts, driver errors) must not be reported as such
 	membership, err := s.membershipRepo.FindByUserAndTenant(ctx, userID, tenantID)
+	if errors.Is(err, errors.ErrMembershipNotFound) {
+		denial.Err = errors.ErrNotMember
+		return "", s.deny(ctx, denial)
+	}
 	if err != nil {
-		return nil, errors.ErrNotMember
+		return "", err
+	}
+	if membership.Status == model.MembershipStatusPending {
+		denial.Err = errors.ErrNotMember
+		return "", s.deny(ctx, denial)
 	}
 
 	if !hasMinRole(membership.Role, minRole) {
-		return nil, errors.ErrForbidden
+		denial.Role = membership.Role
+		denial.Err = errors.ErrForbidden
+		return "", s.deny(ctx, denial)
 	}
 
-	return membership, nil
+	return membership.Role, nil
+}
+
+// claimedRole returns the current user's role in tenantID according to the
+// request's token claims, if they name a valid one.
+func claimedRole(ctx context.Context, tenantID string) (model.MembershipRole, bool) {
+	claims, ok := auth.GetClaims(ctx)
+	if !ok {
+		return "", false
+	}
+	role := model.MembershipRole(claims.Roles[tenantID])
+	return role, role.IsValid()
+}
+
+// revokeRoleClaims withdraws a user's token role claims after they lose a
+// role, so tokens claiming the old role stop authorizing without signing
+// the user out: their other tenants fall back to the membership lookup.
+// The change has committed, so a failure is logged rather than returned.
+func (s *TenantService) revokeRoleClaims(ctx context.Context, userID string) {
+	if _, err := s.userRepo.IncrementRoleEpoch(ctx, userID); err != nil {
+		s.logger.WarnContext(ctx, "role claim revocation after role loss failed", "userId", userID, "error", err)
+	}
 }
 
 // GetTenant retrieves a tenant by ID.
@@ -73,14 +244,43 @@ func (s *TenantService) GetTenantBySlug(ctx context.Context, slug string) (*mode
 	return s.tenantRepo.FindBySlug(ctx, slug)
 }
 
-// GetMyTenants retrieves all tenants the current user is a member of.
-func (s *TenantService) GetMyTenants(ctx context.Context) ([]*model.Tenant, error) {
+// GetMyTenants retrieves a page of the tenants the current user is a member
+// of, newest first. See pageBounds for limit and offset handling.
+func (s *TenantService) GetMyTenants(ctx context.Context, limit, offset *int) ([]*model.Tenant, error) {
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pageLimit, pageOffset := pageBounds(limit, offset)
+	return s.tenantRepo.FindByUserID(ctx, userID, pageLimit, pageOffset)
+}
+
+// GetMyInvitations retrieves a page of the current user's pending
+// memberships, most recent first. See pageBounds for limit and offset handling.
+func (s *TenantService) GetMyInvitations(ctx context.Context, limit, offset *int) ([]*model.Membership, error) {
 	userID, err := auth.GetUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.tenantRepo.FindByUserID(ctx, userID)
+	pending := model.MembershipStatusPending
+	pageLimit, pageOffset := pageBounds(limit, offset)
+	return s.membershipRepo.FindByUserID(ctx, userID, &pending, pageLimit, pageOffset)
+}
+
+// pageBounds resolves optional paging arguments. A missing or non-positive
+// limit becomes DefaultPageSize, limits are capped at MaxPageSize, and a
+// missing or negative offset starts at the beginning.
+func pageBounds(limit, offset *int) (int, int) {
+	pageLimit, pageOffset := DefaultPageSize, 0
+	if limit != nil && *limit > 0 {
+		pageLimit = min(*limit, MaxPageSize)
+	}
+	if offset != nil && *offset > 0 {
+		pageOffset = *offset
+	}
+	return pageLimit, pageOffset
 }
 
 // CreateTenant creates a new tenant with the current user as owner.
@@ -90,9 +290,26 @@ func (s *TenantService) CreateTenant(ctx context.Context, input model.CreateTena
 		return nil, err
 	}
 
-	// Validate slug
-	if err := validation.ValidateSlug(input.Slug); err != nil {
-		return nil, errors.ErrInvalidSlug
+	// Normalize before validating so pasted whitespace doesn't fail validation
+	input.Name = validation.NormalizeSpace(input.Name)
+	input.Slug = strings.TrimSpace(input.Slug)
+
+	// Report every invalid field at once
+	var problems errors.ValidationErrors
+	problems.AddError("name", validation.ValidateTenantName(input.Name))
+	problems.AddError("slug", s.validateSlug(input.Slug))
+	if err := problems.Err(); err != nil {
+		return nil, err
+	}
+
+	// A stale token for a deleted or suspended account must not create a
+	// tenant owned by a user who can no longer act. FindByID hides deleted users.
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != model.UserStatusActive {
+		return nil, errors.ErrForbidden
 	}
 
 	// Set default plan if not provided
@@ -110,15 +327,21 @@ func (s *TenantService) CreateTenant(ctx context.Context, input model.CreateTena
 		IsolationMode: model.TenantIsolationModeShared,
 	}
 
-	createdTenant, err := s.tenantRepo.Create(ctx, tenant)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create owner membership for the current user
-	_, err = s.membershipRepo.Create(ctx, userID, createdTenant.ID, model.MembershipRoleOwner, nil)
+	// The tenant and its owner membership commit together, so a failed
+	// membership leaves no ownerless tenant behind
+	var createdTenant *model.Tenant
+	err = s.uow.Write(ctx, func(tx neo4j.ManagedTransaction) error {
+		created, err := s.tenantRepo.CreateTx(ctx, tx, tenant)
+		if err != nil {
+			return err
+		}
+		if _, err := s.membershipRepo.CreateTx(ctx, tx, userID, created.ID, model.MembershipRoleOwner, nil); err != nil {
+			return err
+		}
+		createdTenant = created
+		return nil
+	})
 	if err != nil {
-		// TODO: Consider rolling back tenant creation on membership failure
 		return nil, err
 	}
 
@@ -128,10 +351,16 @@ func (s *TenantService) CreateTenant(ctx context.Context, input model.CreateTena
 	return createdTenant, nil
 }
 
-// UpdateTenant updates a tenant. Requires ADMIN+ role.
+// UpdateTenant updates a tenant. Requires ADMIN+ role. The status can't be
+// changed here; SuspendTenant, UnsuspendTenant and DeleteTenant change it
+// with their own checks.
 func (s *TenantService) UpdateTenant(ctx context.Context, id string, input model.UpdateTenantInput) (*model.Tenant, error) {
+	if input.Status != nil {
+		return nil, errors.NewValidationError("status", "use suspendTenant, unsuspendTenant or deleteTenant")
+	}
+
 	// Check authorization
-	_, err := s.requireRole(ctx, id, model.MembershipRoleAdmin)
+	_, err := s.requireWritable(ctx, "UpdateTenant", id, model.MembershipRoleAdmin)
 	if err != nil {
 		return nil, err
 	}
@@ -139,20 +368,139 @@ func (s *TenantService) UpdateTenant(ctx context.Context, id string, input model
 	return s.tenantRepo.Update(ctx, id, input)
 }
 
+// SetBillingEmail sets the contact address used for billing and notifications.
+// The email is normalized before validation. Requires ADMIN+ role.
+func (s *TenantService) SetBillingEmail(ctx context.Context, tenantID, email string) (*model.Tenant, error) {
+	if _, err := s.requireWritable(ctx, "SetBillingEmail", tenantID, model.MembershipRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	email = validation.NormalizeEmail(email)
+	if err := validation.ValidateEmail(email); err != nil {
+		return nil, err
+	}
+
+	return s.tenantRepo.SetBillingEmail(ctx, tenantID, email)
+}
+
+// GetBillingEmail returns the tenant's billing email if the current user is
+// ADMIN+ in it, and nil otherwise so the field is hidden rather than failing
+// the whole tenant query.
+func (s *TenantService) GetBillingEmail(ctx context.Context, tenant *model.Tenant) *string {
+	if _, err := s.requireRole(ctx, "GetBillingEmail", tenant.ID, model.MembershipRoleAdmin); err != nil {
+		return nil
+	}
+	return tenant.BillingEmail
+}
+
 // DeleteTenant soft-deletes a tenant. Requires OWNER role.
-func (s *TenantService) DeleteTenant(ctx context.Context, id string) (bool, error) {
+func (s *TenantService) DeleteTenant(ctx context.Context, id string, reason *string) (*model.DeleteTenantResult, error) {
 	// Check authorization
-	_, err := s.requireRole(ctx, id, model.MembershipRoleOwner)
+	_, err := s.requireRole(ctx, "DeleteTenant", id, model.MembershipRoleOwner)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	err = s.tenantRepo.Delete(ctx, id)
+	// Read before deleting; the count is informational, so a failed read
+	// doesn't block the deletion
+	memberCount, err := s.tenantRepo.GetMemberCount(ctx, id)
+	countPtr := &memberCount
 	if err != nil {
-		return false, err
+		s.logger.WarnContext(ctx, "failed to read member count of deleted tenant", "tenant", id, "error", err)
+		countPtr = nil
+	}
+
+	userID := auth.MustGetUserID(ctx)
+	err = s.tenantRepo.Delete(ctx, id, userID, validation.NormalizeSpacePtr(reason))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DeleteTenantResult{Success: true, TenantID: id, MemberCount: countPtr}, nil
+}
+
+// GetTenantDeletion retrieves who deleted a tenant, when and why. Requires platform admin.
+func (s *TenantService) GetTenantDeletion(ctx context.Context, tenantID string) (*model.DeletionInfo, error) {
+	if _, err := auth.GetUserID(ctx); err != nil {
+		return nil, err
+	}
+	if !auth.IsPlatformAdmin(ctx) {
+		return nil, errors.ErrForbidden
+	}
+
+	return s.tenantRepo.FindDeletion(ctx, tenantID)
+}
+
+// RestoreTenant reverses the soft delete of a tenant. Requires platform admin.
+func (s *TenantService) RestoreTenant(ctx context.Context, id string) (*model.Tenant, error) {
+	if _, err := auth.GetUserID(ctx); err != nil {
+		return nil, err
+	}
+	if !auth.IsPlatformAdmin(ctx) {
+		return nil, errors.ErrForbidden
 	}
 
-	return true, nil
+	return s.tenantRepo.Restore(ctx, id)
+}
+
+// GetMyTenantRoleSummary counts the tenants the current user actively
+// belongs to, per role. Roles the user doesn't hold are omitted.
+func (s *TenantService) GetMyTenantRoleSummary(ctx context.Context) (map[model.MembershipRole]int, error) {
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.membershipRepo.CountRolesByUserID(ctx, userID)
+}
+
+// GetContactableAdmins returns, keyed by tenant ID, the other owners and
+// admins of each tenant the current user actively belongs to, so the user
+// knows whom to ask for help. Tenants where no one else is an admin are
+// omitted.
+func (s *TenantService) GetContactableAdmins(ctx context.Context) (map[string][]*model.Membership, error) {
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	admins, err := s.membershipRepo.FindAdminsOfUserTenants(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byTenant := make(map[string][]*model.Membership)
+	for _, admin := range admins {
+		byTenant[admin.Tenant.ID] = append(byTenant[admin.Tenant.ID], admin)
+	}
+	return byTenant, nil
+}
+
+// GetMembership retrieves a membership by ID for an active member of its
+// tenant or for the membership's own user, so invitees can see a pending
+// invite. Other callers get ErrForbidden.
+func (s *TenantService) GetMembership(ctx context.Context, id string) (*model.Membership, error) {
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := s.membershipRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if membership.User != nil && membership.User.ID == userID {
+		return membership, nil
+	}
+
+	if _, err := s.requireRole(ctx, "GetMembership", membership.Tenant.ID, model.MembershipRoleViewer); err != nil {
+		if errors.Is(err, errors.ErrNotMember) {
+			return nil, errors.ErrForbidden
+		}
+		return nil, err
+	}
+	return membership, nil
 }
 
 // GetTenantMembers retrieves all members of a tenant.
@@ -162,39 +510,92 @@ func (s *TenantService) GetTenantMembers(ctx context.Context, tenantID string) (
 	return s.membershipRepo.FindByTenantID(ctx, tenantID)
 }
 
-// InviteMember invites a user to a tenant. Requires ADMIN+ role.
-func (s *TenantService) InviteMember(ctx context.Context, tenantID string, input model.InviteMemberInput) (*model.Membership, error) {
-	userID, err := auth.GetUserID(ctx)
-	if err != nil {
+// ListMembers retrieves a page of a tenant's members narrowed by filter.
+// The page size defaults to DefaultPageSize and is capped at MaxPageSize.
+// Requires membership in the tenant.
+func (s *TenantService) ListMembers(ctx context.Context, tenantID string, filter repository.MemberFilter) ([]*model.Membership, error) {
+	if !repository.ValidMemberSort(filter.Sort) {
+		return nil, errors.NewValidationError("sort", fmt.Sprintf("unknown sort %q", filter.Sort))
+	}
+
+	if _, err := s.requireRole(ctx, "ListMembers", tenantID, model.MembershipRoleViewer); err != nil {
 		return nil, err
 	}
 
-	// Check authorization
-	_, err = s.requireRole(ctx, tenantID, model.MembershipRoleAdmin)
+	filter.Search = strings.TrimSpace(filter.Search)
+	filter.Limit, filter.Offset = pageBounds(&filter.Limit, &filter.Offset)
+	return s.membershipRepo.ListMembers(ctx, tenantID, filter)
+}
+
+// StreamTenantMembers calls fn for each member of a tenant as they are read,
+// for exports too large to hold in memory. Requires ADMIN+ role.
+func (s *TenantService) StreamTenantMembers(ctx context.Context, tenantID string, fn func(*model.Membership) error) error {
+	if _, err := s.requireRole(ctx, "StreamTenantMembers", tenantID, model.MembershipRoleAdmin); err != nil {
+		return err
+	}
+
+	return s.membershipRepo.StreamMembers(ctx, tenantID, fn)
+}
+
+// GetTenantOwners retrieves the owners of a tenant, earliest first.
+func (s *TenantService) GetTenantOwners(ctx context.Context, tenantID string) ([]*model.Membership, error) {
+	return s.membershipRepo.FindOwnersByTenantID(ctx, tenantID)
+}
+
+// GetInviteChain returns who invited a member, who invited them, and so on,
+// nearest first. Requires membership in the tenant. truncated reports that the
+// chain was cut at the traversal limit or at a cycle.
+func (s *TenantService) GetInviteChain(ctx context.Context, membershipID string) ([]*model.User, bool, error) {
+	tenantID, err := s.membershipRepo.GetTenantIDByMembershipID(ctx, membershipID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	if _, err := s.requireRole(ctx, "GetInviteChain", tenantID, model.MembershipRoleViewer); err != nil {
+		return nil, false, err
+	}
+
+	return s.membershipRepo.GetInviteChain(ctx, membershipID, s.maxTraversalDepth)
+}
+
+// InviteMember invites someone to a tenant by email. It behaves like
+// InviteByEmail: people without an account get a pending invite they can
+// accept once they sign up. Requires ADMIN+ role.
+func (s *TenantService) InviteMember(ctx context.Context, tenantID string, input model.InviteMemberInput) (*model.Membership, error) {
+	return s.inviteByEmail(ctx, "InviteMember", tenantID, input)
+}
+
+// authorizeInvite checks that the caller may invite members with the
+// requested role (default MEMBER) and returns the caller's ID and the role.
+// Requires ADMIN+; only owners can invite owners. Denials are recorded under
+// operation.
+func (s *TenantService) authorizeInvite(ctx context.Context, operation, tenantID string, requested *model.MembershipRole) (string, model.MembershipRole, error) {
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return "", "", err
 	}
 
-	// Find the user to invite
-	invitee, err := s.userRepo.FindByEmail(ctx, input.Email)
+	// Check authorization
+	inviterRole, err := s.requireWritable(ctx, operation, tenantID, model.MembershipRoleAdmin)
 	if err != nil {
-		return nil, errors.ErrUserNotFound
+		return "", "", err
 	}
 
 	// Set default role if not provided
 	role := model.MembershipRoleMember
-	if input.Role != nil {
-		role = *input.Role
+	if requested != nil {
+		role = *requested
 	}
 
 	// Admins cannot invite owners
-	inviterMembership, _ := s.membershipRepo.FindByUserAndTenant(ctx, userID, tenantID)
-	if role == model.MembershipRoleOwner && inviterMembership.Role != model.MembershipRoleOwner {
-		return nil, errors.ErrForbidden
+	if role == model.MembershipRoleOwner && inviterRole != model.MembershipRoleOwner {
+		return "", "", s.deny(ctx, Denial{
+			Operation: operation, TenantID: tenantID, UserID: userID,
+			Role: inviterRole, RequiredRole: model.MembershipRoleOwner, Err: errors.ErrForbidden,
+		})
 	}
 
-	// Create membership
-	return s.membershipRepo.Create(ctx, invitee.ID, tenantID, role, &userID)
+	return userID, role, nil
 }
 
 // UpdateMemberRole updates a member's role. Requires OWNER role.
@@ -208,14 +609,37 @@ func (s *TenantService) UpdateMemberRole(ctx context.Context, membershipID strin
 	tenantID := membership.Tenant.ID
 
 	// Check authorization - only owners can change roles
-	_, err = s.requireRole(ctx, tenantID, model.MembershipRoleOwner)
+	_, err = s.requireWritable(ctx, "UpdateMemberRole", tenantID, model.MembershipRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyMemberRole(ctx, membership, role)
+}
+
+// UpdateMemberRoleByUser updates the role of a user's membership in a tenant.
+// Requires OWNER role.
+func (s *TenantService) UpdateMemberRoleByUser(ctx context.Context, tenantID, userID string, role model.MembershipRole) (*model.Membership, error) {
+	// Check authorization first so non-owners can't probe for members
+	_, err := s.requireWritable(ctx, "UpdateMemberRoleByUser", tenantID, model.MembershipRoleOwner)
 	if err != nil {
 		return nil, err
 	}
 
+	membership, err := s.membershipRepo.FindByUserAndTenant(ctx, userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyMemberRole(ctx, membership, role)
+}
+
+// applyMemberRole changes an authorized membership's role, refusing to
+// demote the tenant's last owner.
+func (s *TenantService) applyMemberRole(ctx context.Context, membership *model.Membership, role model.MembershipRole) (*model.Membership, error) {
 	// Cannot demote the last owner
 	if membership.Role == model.MembershipRoleOwner && role != model.MembershipRoleOwner {
-		ownerCount, err := s.membershipRepo.CountOwners(ctx, tenantID)
+		ownerCount, err := s.membershipRepo.CountOwners(ctx, membership.Tenant.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -224,39 +648,74 @@ func (s *TenantService) UpdateMemberRole(ctx context.Context, membershipID strin
 		}
 	}
 
-	return s.membershipRepo.UpdateRole(ctx, membershipID, role)
+	previous := membership.Role
+	updated, err := s.membershipRepo.UpdateRole(ctx, membership.ID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasMinRole(role, previous) {
+		s.revokeRoleClaims(ctx, membership.User.ID)
+	}
+	if previous != role {
+		s.sendRoleChanged(ctx, updated)
+	}
+	return updated, nil
+}
+
+// sendRoleChanged notifies a member of their committed new role, logging
+// any failure rather than undoing the change.
+func (s *TenantService) sendRoleChanged(ctx context.Context, membership *model.Membership) {
+	member, err := s.userRepo.FindByID(ctx, membership.User.ID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "role change recipient lookup failed", "membershipId", membership.ID, "error", err)
+		return
+	}
+
+	change := notify.RoleChanged{
+		To:         member.Email,
+		TenantName: s.tenantName(ctx, membership.Tenant.ID),
+		Role:       string(membership.Role),
+	}
+	if err := s.notifier.SendRoleChanged(ctx, change); err != nil {
+		s.logger.WarnContext(ctx, "send role change failed", "membershipId", membership.ID, "error", err)
+	}
 }
 
 // RemoveMember removes a member from a tenant. Requires ADMIN+ role.
-func (s *TenantService) RemoveMember(ctx context.Context, membershipID string) (bool, error) {
+// Invites the member sent are handed off per the InviteHandoff policy.
+func (s *TenantService) RemoveMember(ctx context.Context, membershipID string) (*model.MemberRemovalResult, error) {
 	userID, err := auth.GetUserID(ctx)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Get the membership to find the tenant and check constraints
 	membership, err := s.membershipRepo.FindByID(ctx, membershipID)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	tenantID := membership.Tenant.ID
 
-	// Get current user's membership
-	currentMembership, err := s.requireRole(ctx, tenantID, model.MembershipRoleAdmin)
+	// Get current user's role
+	currentRole, err := s.requireWritable(ctx, "RemoveMember", tenantID, model.MembershipRoleAdmin)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Cannot remove yourself (use LeaveTenant instead)
 	if membership.User.ID == userID {
-		return false, errors.NewValidationError("membership", "use leaveTenant to remove yourself")
+		return nil, errors.NewValidationError("membership", "use leaveTenant to remove yourself")
 	}
 
 	// Admins cannot remove other admins or owners
-	if currentMembership.Role == model.MembershipRoleAdmin {
+	if currentRole == model.MembershipRoleAdmin {
 		if membership.Role == model.MembershipRoleAdmin || membership.Role == model.MembershipRoleOwner {
-			return false, errors.ErrForbidden
+			return nil, s.deny(ctx, Denial{
+				Operation: "RemoveMember", TenantID: tenantID, UserID: userID,
+				Role: currentRole, RequiredRole: model.MembershipRoleOwner, Err: errors.ErrForbidden,
+			})
 		}
 	}
 
@@ -264,51 +723,244 @@ func (s *TenantService) RemoveMember(ctx context.Context, membershipID string) (
 	if membership.Role == model.MembershipRoleOwner {
 		ownerCount, err := s.membershipRepo.CountOwners(ctx, tenantID)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 		if ownerCount <= 1 {
-			return false, errors.ErrLastOwner
+			return nil, errors.ErrLastOwner
 		}
 	}
 
-	err = s.membershipRepo.Delete(ctx, membershipID)
+	toUserID, err := s.inviteHandoffTarget(ctx, tenantID, membership.User.ID, userID)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	handedOff, err := s.removeMembership(ctx, membership, toUserID, errors.ErrLastOwner)
+	if err != nil {
+		return nil, err
+	}
+	s.revokeRoleClaims(ctx, membership.User.ID)
+
+	result := s.removalResult(ctx, membership)
+	result.InvitesHandedOff = handedOff
+	return result, nil
+}
 
-	return true, nil
+// inviteHandoffTarget applies the invite handoff policy to the invites
+// removedUserID sent in a tenant, as removerID removes them, returning the
+// user who takes them over. "" means they are detached, as they are when
+// configured to be or when there is no one left to repoint them to.
+// Members leaving on their own remove themselves, so InviteHandoffRemover
+// falls back to the owner for them.
+func (s *TenantService) inviteHandoffTarget(ctx context.Context, tenantID, removedUserID, removerID string) (string, error) {
+	switch {
+	case s.inviteHandoff == InviteHandoffDetach:
+		return "", nil
+	case s.inviteHandoff == InviteHandoffRemover && removerID != removedUserID:
+		return removerID, nil
+	default:
+		return s.otherOwner(ctx, tenantID, removedUserID)
+	}
 }
 
-// LeaveTenant removes the current user from a tenant.
-func (s *TenantService) LeaveTenant(ctx context.Context, tenantID string) (bool, error) {
+// removeMembership hands off the invites a member sent to toUserID and
+// deletes their membership as one unit of work, returning the number of
+// invites handed off. Removing an owner fails with lastOwnerErr if no
+// active owner would remain. The count is taken after the delete, which
+// writes the tenant's memberCount and so holds the tenant's lock: two
+// owners removing each other can't both see the other still there.
+func (s *TenantService) removeMembership(ctx context.Context, membership *model.Membership, toUserID string, lastOwnerErr error) (int, error) {
+	tenantID := membership.Tenant.ID
+	handedOff := 0
+	err := s.uow.Write(ctx, func(tx neo4j.ManagedTransaction) error {
+		n, err := s.handOffInvitesTx(ctx, tx, tenantID, membership.User.ID, toUserID)
+		if err != nil {
+			return err
+		}
+		handedOff = n
+
+		if err := s.membershipRepo.DeleteTx(ctx, tx, membership.ID); err != nil {
+			return err
+		}
+		if membership.Role != model.MembershipRoleOwner || membership.Status != model.MembershipStatusActive {
+			return nil
+		}
+		owners, err := s.membershipRepo.CountOwnersTx(ctx, tx, tenantID)
+		if err != nil {
+			return err
+		}
+		if owners < 1 {
+			return lastOwnerErr
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return handedOff, nil
+}
+
+// handOffInvitesTx repoints the invites removedUserID sent in a tenant to
+// toUserID in tx, or detaches them when toUserID is "".
+func (s *TenantService) handOffInvitesTx(ctx context.Context, tx neo4j.ManagedTransaction, tenantID, removedUserID, toUserID string) (int, error) {
+	if toUserID == "" {
+		return s.membershipRepo.DetachInvitesTx(ctx, tx, removedUserID, tenantID)
+	}
+	return s.membershipRepo.ReassignInvitesTx(ctx, tx, removedUserID, toUserID, tenantID)
+}
+
+// otherOwner returns the user ID of the tenant's earliest owner other than
+// excludedUserID, or "" if there is none.
+func (s *TenantService) otherOwner(ctx context.Context, tenantID, excludedUserID string) (string, error) {
+	owners, err := s.membershipRepo.FindOwnersByTenantID(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	for _, owner := range owners {
+		if owner.User != nil && owner.User.ID != excludedUserID {
+			return owner.User.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// removalResult describes a membership that has just been deleted, with the
+// tenant's remaining member and owner counts. The removal has committed, so
+// counts that can't be read are logged and left nil rather than failing.
+func (s *TenantService) removalResult(ctx context.Context, removed *model.Membership) *model.MemberRemovalResult {
+	tenantID := removed.Tenant.ID
+	result := &model.MemberRemovalResult{
+		Success:      true,
+		TenantID:     tenantID,
+		MembershipID: removed.ID,
+		UserID:       removed.User.ID,
+	}
+
+	if members, err := s.tenantRepo.GetMemberCount(ctx, tenantID); err == nil {
+		result.RemainingMemberCount = &members
+	} else {
+		s.logger.WarnContext(ctx, "failed to read remaining member count", "tenant", tenantID, "error", err)
+	}
+	if owners, err := s.membershipRepo.CountOwners(ctx, tenantID); err == nil {
+		result.RemainingOwnerCount = &owners
+	} else {
+		s.logger.WarnContext(ctx, "failed to read remaining owner count", "tenant", tenantID, "error", err)
+	}
+	return result
+}
+
+// LeaveTenant removes the current user from a tenant. Invites they sent
+// are handed off per the InviteHandoff policy.
+func (s *TenantService) LeaveTenant(ctx context.Context, tenantID string) (*model.MemberRemovalResult, error) {
 	userID, err := auth.GetUserID(ctx)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Get the user's membership
 	membership, err := s.membershipRepo.FindByUserAndTenant(ctx, userID, tenantID)
+	if errors.Is(err, errors.ErrMembershipNotFound) {
+		return nil, errors.ErrNotMember
+	}
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Cannot leave if you're the last owner
-	if membership.Role == model.MembershipRoleOwner {
-		ownerCount, err := s.membershipRepo.CountOwners(ctx, tenantID)
-		if err != nil {
-			return false, err
-		}
-		if ownerCount <= 1 {
-			return false, errors.ErrCannotLeave
-		}
+	soleOwner, err := s.isSoleOwner(ctx, membership)
+	if err != nil {
+		return nil, err
+	}
+	if soleOwner {
+		return nil, errors.ErrCannotLeave
 	}
 
-	err = s.membershipRepo.Delete(ctx, membership.ID)
+	toUserID, err := s.inviteHandoffTarget(ctx, tenantID, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	handedOff, err := s.removeMembership(ctx, membership, toUserID, errors.ErrCannotLeave)
+	if err != nil {
+		return nil, err
+	}
+	s.revokeRoleClaims(ctx, userID)
+
+	result := s.removalResult(ctx, membership)
+	result.InvitesHandedOff = handedOff
+	return result, nil
+}
+
+// CanLeaveTenant reports whether the current user can leave a tenant, so
+// UIs can disable leaving up front instead of failing with ErrCannotLeave.
+// Non-members get a NOT_MEMBER answer rather than an error.
+func (s *TenantService) CanLeaveTenant(ctx context.Context, tenantID string) (*model.LeaveEligibility, error) {
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := s.membershipRepo.FindByUserAndTenant(ctx, userID, tenantID)
+	if errors.Is(err, errors.ErrMembershipNotFound) {
+		reason := model.LeaveBlockedReasonNotMember
+		return &model.LeaveEligibility{Reason: &reason}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	soleOwner, err := s.isSoleOwner(ctx, membership)
+	if err != nil {
+		return nil, err
+	}
+	if soleOwner {
+		reason := model.LeaveBlockedReasonSoleOwner
+		return &model.LeaveEligibility{Reason: &reason}, nil
+	}
+	return &model.LeaveEligibility{Allowed: true}, nil
+}
+
+// isSoleOwner reports whether membership is its tenant's only active owner.
+func (s *TenantService) isSoleOwner(ctx context.Context, membership *model.Membership) (bool, error) {
+	if membership.Role != model.MembershipRoleOwner || membership.Status != model.MembershipStatusActive {
+		return false, nil
+	}
+	ownerCount, err := s.membershipRepo.CountOwners(ctx, membership.Tenant.ID)
 	if err != nil {
 		return false, err
 	}
+	return ownerCount <= 1, nil
+}
+
+// ReassignInvites repoints invites created by a departing user to another
+// active ADMIN+ member of the tenant, so invite provenance stays valid.
+// Accepted memberships the user invited are repointed too. Requires OWNER role.
+func (s *TenantService) ReassignInvites(ctx context.Context, fromUserID, toUserID, tenantID string) (int, error) {
+	// Check authorization
+	_, err := s.requireWritable(ctx, "ReassignInvites", tenantID, model.MembershipRoleOwner)
+	if err != nil {
+		return 0, err
+	}
+
+	if fromUserID == toUserID {
+		return 0, errors.NewValidationError("toUserId", "must differ from fromUserId")
+	}
+
+	// The new inviter must be able to invite in this tenant
+	// ErrNotMember would suggest the caller should join, so report the target
+	target, err := s.membershipRepo.FindByUserAndTenant(ctx, toUserID, tenantID)
+	if errors.Is(err, errors.ErrMembershipNotFound) {
+		return 0, errors.NewValidationError("toUserId", "must be a member of the tenant")
+	}
+	if err != nil {
+		return 0, err
+	}
+	if target.Status != model.MembershipStatusActive {
+		return 0, errors.NewValidationError("toUserId", "must be an active member of the tenant")
+	}
+	if !hasMinRole(target.Role, model.MembershipRoleAdmin) {
+		return 0, errors.NewValidationError("toUserId", "must be an admin or owner of the tenant")
+	}
 
-	return true, nil
+	return s.membershipRepo.ReassignInvites(ctx, fromUserID, toUserID, tenantID)
 }
 
 // Ensure TenantService implements ITenantService
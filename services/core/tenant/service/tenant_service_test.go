@@ -1,31 +1,55 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yourusername/grgn-stack/pkg/auth"
 	"github.com/yourusername/grgn-stack/pkg/errors"
+	"github.com/yourusername/grgn-stack/pkg/metrics"
+	"github.com/yourusername/grgn-stack/pkg/notify"
 	identityRepo "github.com/yourusername/grgn-stack/services/core/identity/repository"
+	identitySvc "github.com/yourusername/grgn-stack/services/core/identity/service"
+	shared "github.com/yourusername/grgn-stack/services/core/shared/controller"
 	"github.com/yourusername/grgn-stack/services/core/shared/generated/graphql/model"
+	"github.com/yourusername/grgn-stack/services/core/shared/testutil"
 	"github.com/yourusername/grgn-stack/services/core/tenant/repository"
 )
 
 func setupTestService() (*TenantService, *repository.MockTenantRepository, *repository.MockMembershipRepository, *identityRepo.MockUserRepository) {
-	tenantRepo := repository.NewMockTenantRepository()
-	membershipRepo := repository.NewMockMembershipRepository()
-	userRepo := identityRepo.NewMockUserRepository()
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	return svc, h.Tenants, h.Memberships, h.Users
+}
+
+// intPtr returns a pointer to i, for optional counts in expected results.
+func intPtr(i int) *int {
+	return &i
+}
 
-	svc := NewTenantService(tenantRepo, membershipRepo, userRepo)
-	return svc, tenantRepo, membershipRepo, userRepo
+// withActiveUser adds an active user and returns a context authenticated as them.
+func withActiveUser(userRepo *identityRepo.MockUserRepository, userID string) context.Context {
+	testutil.AddActiveUser(userRepo, userID)
+	return testutil.AsUser(userID)
 }
 
 func TestTenantService_CreateTenant_Success(t *testing.T) {
 	// Arrange
-	svc, _, membershipRepo, _ := setupTestService()
-	ctx := auth.WithUserID(context.Background(), "user-123")
+	svc, _, membershipRepo, userRepo := setupTestService()
+	ctx := withActiveUser(userRepo, "user-123")
 
 	input := model.CreateTenantInput{
 		Name: "Acme Corp",
@@ -51,6 +75,25 @@ func TestTenantService_CreateTenant_Success(t *testing.T) {
 	assert.Equal(t, "user-123", memberships[0].User.ID)
 }
 
+func TestTenantService_CreateTenant_NormalizesWhitespace(t *testing.T) {
+	// Arrange
+	svc, _, _, userRepo := setupTestService()
+	ctx := withActiveUser(userRepo, "user-123")
+
+	input := model.CreateTenantInput{
+		Name: "  Acme   Corp ",
+		Slug: " acme-corp\t",
+	}
+
+	// Act
+	tenant, err := svc.CreateTenant(ctx, input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Corp", tenant.Name)
+	assert.Equal(t, "acme-corp", tenant.Slug)
+}
+
 func TestTenantService_CreateTenant_NotAuthenticated(t *testing.T) {
 	// Arrange
 	svc, _, _, _ := setupTestService()
@@ -96,8 +139,8 @@ func TestTenantService_CreateTenant_InvalidSlug(t *testing.T) {
 
 func TestTenantService_CreateTenant_ValidSlugs(t *testing.T) {
 	// Arrange
-	svc, _, _, _ := setupTestService()
-	ctx := auth.WithUserID(context.Background(), "user-123")
+	svc, _, _, userRepo := setupTestService()
+	ctx := withActiveUser(userRepo, "user-123")
 
 	validSlugs := []string{
 		"abc",
@@ -118,10 +161,62 @@ func TestTenantService_CreateTenant_ValidSlugs(t *testing.T) {
 	}
 }
 
+func TestTenantService_CreateTenant_ReportsEveryInvalidField(t *testing.T) {
+	// Arrange
+	svc, _, _, userRepo := setupTestService()
+	ctx := withActiveUser(userRepo, "user-123")
+
+	// Act
+	tenant, err := svc.CreateTenant(ctx, model.CreateTenantInput{Name: "   ", Slug: "a!"})
+
+	// Assert
+	assert.Nil(t, tenant)
+	var problems errors.ValidationErrors
+	require.ErrorAs(t, err, &problems)
+	require.Len(t, problems, 2)
+	assert.Equal(t, "name", problems[0].Field)
+	assert.Equal(t, "slug", problems[1].Field)
+	assert.ErrorIs(t, err, errors.ErrInvalidSlug)
+}
+
+func TestTenantService_CreateTenant_ReservedSlug(t *testing.T) {
+	// Arrange
+	svc, _, _, userRepo := setupTestService()
+	ctx := withActiveUser(userRepo, "user-123")
+
+	for _, slug := range []string{"admin", "GraphQL", "ping"} {
+		t.Run(slug, func(t *testing.T) {
+			// Act
+			tenant, err := svc.CreateTenant(ctx, model.CreateTenantInput{Name: "Test", Slug: slug})
+
+			// Assert
+			assert.Nil(t, tenant)
+			assert.ErrorIs(t, err, errors.ErrReservedSlug)
+		})
+	}
+}
+
+func TestTenantService_CreateTenant_CustomReservedSlugs(t *testing.T) {
+	// Arrange
+	svc, _, _, userRepo := setupTestService()
+	svc.WithReservedSlugs([]string{"billing"})
+	ctx := withActiveUser(userRepo, "user-123")
+
+	// Act
+	reserved, reservedErr := svc.CreateTenant(ctx, model.CreateTenantInput{Name: "Billing", Slug: "billing"})
+	freed, freedErr := svc.CreateTenant(ctx, model.CreateTenantInput{Name: "Admin", Slug: "admin"})
+
+	// Assert
+	assert.Nil(t, reserved)
+	assert.ErrorIs(t, reservedErr, errors.ErrReservedSlug)
+	require.NoError(t, freedErr, "the custom list replaces the default one")
+	assert.Equal(t, "admin", freed.Slug)
+}
+
 func TestTenantService_CreateTenant_DuplicateSlug(t *testing.T) {
 	// Arrange
-	svc, tenantRepo, _, _ := setupTestService()
-	ctx := auth.WithUserID(context.Background(), "user-123")
+	svc, tenantRepo, _, userRepo := setupTestService()
+	ctx := withActiveUser(userRepo, "user-123")
 
 	// Add existing tenant
 	tenantRepo.AddTenant(&model.Tenant{
@@ -143,316 +238,3262 @@ func TestTenantService_CreateTenant_DuplicateSlug(t *testing.T) {
 	assert.ErrorIs(t, err, errors.ErrSlugTaken)
 }
 
-func TestTenantService_GetMyTenants(t *testing.T) {
-	// Arrange
-	svc, tenantRepo, membershipRepo, _ := setupTestService()
-	ctx := auth.WithUserID(context.Background(), "user-123")
-
-	// Add tenants
-	tenant1 := &model.Tenant{ID: "tenant-1", Name: "Tenant 1", Slug: "tenant-1", Status: model.TenantStatusActive}
-	tenant2 := &model.Tenant{ID: "tenant-2", Name: "Tenant 2", Slug: "tenant-2", Status: model.TenantStatusActive}
-	tenantRepo.AddTenant(tenant1)
-	tenantRepo.AddTenant(tenant2)
-	tenantRepo.AddUserToTenant("user-123", "tenant-1")
-	tenantRepo.AddUserToTenant("user-123", "tenant-2")
-
-	// Add memberships
-	membershipRepo.AddMembership(&model.Membership{
-		ID:     "m1",
-		Role:   model.MembershipRoleOwner,
-		User:   &model.User{ID: "user-123"},
-		Tenant: tenant1,
-	})
-	membershipRepo.AddMembership(&model.Membership{
-		ID:     "m2",
-		Role:   model.MembershipRoleMember,
-		User:   &model.User{ID: "user-123"},
-		Tenant: tenant2,
-	})
+func TestTenantService_CreateTenant_DeletedUser(t *testing.T) {
+	// Arrange - the account was deleted after the token was issued
+	svc, tenantRepo, _, userRepo := setupTestService()
+	ctx := withActiveUser(userRepo, "user-123")
+	require.NoError(t, userRepo.Delete(ctx, "user-123", "user-123", nil))
 
 	// Act
-	tenants, err := svc.GetMyTenants(ctx)
+	tenant, err := svc.CreateTenant(ctx, model.CreateTenantInput{Name: "Ghost Corp", Slug: "ghost-corp"})
 
 	// Assert
-	require.NoError(t, err)
-	assert.Len(t, tenants, 2)
+	assert.Nil(t, tenant)
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+	_, findErr := tenantRepo.FindBySlug(ctx, "ghost-corp")
+	assert.ErrorIs(t, findErr, errors.ErrTenantNotFound)
 }
 
-func TestTenantService_UpdateTenant_Success(t *testing.T) {
+func TestTenantService_CreateTenant_UnknownUser(t *testing.T) {
 	// Arrange
-	svc, tenantRepo, membershipRepo, _ := setupTestService()
-	ctx := auth.WithUserID(context.Background(), "user-123")
-
-	tenant := &model.Tenant{ID: "tenant-1", Name: "Old Name", Slug: "tenant-1", Status: model.TenantStatusActive}
-	tenantRepo.AddTenant(tenant)
-
-	// Add admin membership
-	membershipRepo.AddMembership(&model.Membership{
-		ID:     "m1",
-		Role:   model.MembershipRoleAdmin,
-		User:   &model.User{ID: "user-123"},
-		Tenant: tenant,
-	})
-
-	newName := "New Name"
-	input := model.UpdateTenantInput{Name: &newName}
+	svc, _, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "no-such-user")
 
 	// Act
-	updated, err := svc.UpdateTenant(ctx, "tenant-1", input)
+	tenant, err := svc.CreateTenant(ctx, model.CreateTenantInput{Name: "Ghost Corp", Slug: "ghost-corp"})
 
 	// Assert
-	require.NoError(t, err)
-	assert.Equal(t, "New Name", updated.Name)
+	assert.Nil(t, tenant)
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
 }
 
-func TestTenantService_UpdateTenant_NotAdmin(t *testing.T) {
+func TestTenantService_CreateTenant_SuspendedUser(t *testing.T) {
 	// Arrange
-	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	svc, _, _, userRepo := setupTestService()
+	userRepo.AddUser(&model.User{ID: "user-123", Email: "user@example.com", Status: model.UserStatusSuspended})
 	ctx := auth.WithUserID(context.Background(), "user-123")
 
-	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
-	tenantRepo.AddTenant(tenant)
-
-	// Add member (not admin) membership
-	membershipRepo.AddMembership(&model.Membership{
-		ID:     "m1",
-		Role:   model.MembershipRoleMember,
-		User:   &model.User{ID: "user-123"},
-		Tenant: tenant,
-	})
-
-	newName := "New Name"
-	input := model.UpdateTenantInput{Name: &newName}
-
 	// Act
-	updated, err := svc.UpdateTenant(ctx, "tenant-1", input)
+	tenant, err := svc.CreateTenant(ctx, model.CreateTenantInput{Name: "Acme", Slug: "acme"})
 
 	// Assert
-	assert.Nil(t, updated)
+	assert.Nil(t, tenant)
 	assert.ErrorIs(t, err, errors.ErrForbidden)
 }
 
-func TestTenantService_DeleteTenant_Success(t *testing.T) {
+func TestTenantService_CreateTenant_MembershipFailureLeavesNoTenant(t *testing.T) {
 	// Arrange
-	svc, tenantRepo, membershipRepo, _ := setupTestService()
-	ctx := auth.WithUserID(context.Background(), "user-123")
-
-	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
-	tenantRepo.AddTenant(tenant)
-
-	// Add owner membership
-	membershipRepo.AddMembership(&model.Membership{
-		ID:     "m1",
-		Role:   model.MembershipRoleOwner,
-		User:   &model.User{ID: "user-123"},
-		Tenant: tenant,
-	})
+	h := testutil.NewHarness()
+	outbox := shared.NewMockOutboxRepository()
+	h.Tenants.Outbox = outbox
+	h.Memberships.CreateTxFunc = func(ctx context.Context, tx neo4j.ManagedTransaction, userID, tenantID string, role model.MembershipRole, invitedByID *string) (*model.Membership, error) {
+		return nil, fmt.Errorf("membership write failed")
+	}
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	ctx := h.AsActiveUser("user-123")
 
 	// Act
-	deleted, err := svc.DeleteTenant(ctx, "tenant-1")
+	tenant, err := svc.CreateTenant(ctx, model.CreateTenantInput{Name: "Acme", Slug: "acme"})
 
 	// Assert
-	require.NoError(t, err)
-	assert.True(t, deleted)
-
-	// Verify tenant is deleted
-	_, findErr := tenantRepo.FindByID(ctx, "tenant-1")
-	assert.ErrorIs(t, findErr, errors.ErrTenantNotFound)
+	assert.Nil(t, tenant)
+	assert.ErrorContains(t, err, "membership write failed")
+	_, findErr := h.Tenants.FindBySlug(ctx, "acme")
+	assert.ErrorIs(t, findErr, errors.ErrTenantNotFound, "the tenant is rolled back with the membership")
+	assert.Empty(t, outbox.Events(), "TenantCreated is never published")
+	assert.Equal(t, 1, h.UnitOfWork.Rollbacks())
+	assert.Equal(t, 0, h.UnitOfWork.Commits())
 }
 
-func TestTenantService_DeleteTenant_NotOwner(t *testing.T) {
+func TestTenantService_SuggestAvailableSlug_Free(t *testing.T) {
 	// Arrange
-	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	svc, _, _, _ := setupTestService()
 	ctx := auth.WithUserID(context.Background(), "user-123")
 
-	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
-	tenantRepo.AddTenant(tenant)
-
-	// Add admin (not owner) membership
-	membershipRepo.AddMembership(&model.Membership{
-		ID:     "m1",
-		Role:   model.MembershipRoleAdmin,
-		User:   &model.User{ID: "user-123"},
-		Tenant: tenant,
-	})
-
 	// Act
-	deleted, err := svc.DeleteTenant(ctx, "tenant-1")
+	slug, err := svc.SuggestAvailableSlug(ctx, "  Acme Corp! ")
 
 	// Assert
-	assert.False(t, deleted)
-	assert.ErrorIs(t, err, errors.ErrForbidden)
+	require.NoError(t, err)
+	assert.Equal(t, "acme-corp", slug)
 }
 
-func TestTenantService_InviteMember_Success(t *testing.T) {
-	// Arrange
-	svc, tenantRepo, membershipRepo, userRepo := setupTestService()
-	ctx := auth.WithUserID(context.Background(), "admin-123")
-
-	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
-	tenantRepo.AddTenant(tenant)
+func TestTenantService_SuggestAvailableSlug_IncrementsSuffix(t *testing.T) {
+	// Arrange - acme and acme-2 are taken
+	svc, tenantRepo, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+	tenantRepo.AddTenant(&model.Tenant{ID: "t1", Slug: "acme", Status: model.TenantStatusActive})
+	tenantRepo.AddTenant(&model.Tenant{ID: "t2", Slug: "acme-2", Status: model.TenantStatusActive})
 
-	// Add admin membership
-	membershipRepo.AddMembership(&model.Membership{
-		ID:     "m1",
-		Role:   model.MembershipRoleAdmin,
-		User:   &model.User{ID: "admin-123"},
-		Tenant: tenant,
-	})
+	// Act
+	slug, err := svc.SuggestAvailableSlug(ctx, "Acme")
 
-	// Add user to invite
-	userRepo.AddUser(&model.User{ID: "invitee-123", Email: "invitee@example.com", Status: model.UserStatusActive})
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "acme-3", slug)
+}
 
-	input := model.InviteMemberInput{Email: "invitee@example.com"}
+func TestTenantService_SuggestAvailableSlug_SkipsDeletedTenants(t *testing.T) {
+	// Arrange - the deleted tenant keeps acme-2 so it can be restored
+	svc, tenantRepo, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+	tenantRepo.AddTenant(&model.Tenant{ID: "t1", Slug: "acme", Status: model.TenantStatusActive})
+	tenantRepo.AddTenant(&model.Tenant{ID: "t2", Slug: "acme-2", Status: model.TenantStatusDeleted})
 
 	// Act
-	membership, err := svc.InviteMember(ctx, "tenant-1", input)
+	slug, err := svc.SuggestAvailableSlug(ctx, "Acme")
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, model.MembershipRoleMember, membership.Role)
-	assert.Equal(t, "invitee-123", membership.User.ID)
+	assert.Equal(t, "acme-3", slug)
 }
 
-func TestTenantService_InviteMember_UserNotFound(t *testing.T) {
+func TestTenantService_SuggestAvailableSlug_SkipsReserved(t *testing.T) {
 	// Arrange
-	svc, tenantRepo, membershipRepo, _ := setupTestService()
-	ctx := auth.WithUserID(context.Background(), "admin-123")
+	svc, _, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
 
-	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
-	tenantRepo.AddTenant(tenant)
+	// Act
+	slug, err := svc.SuggestAvailableSlug(ctx, "Admin")
 
-	membershipRepo.AddMembership(&model.Membership{
-		ID:     "m1",
-		Role:   model.MembershipRoleAdmin,
-		User:   &model.User{ID: "admin-123"},
-		Tenant: tenant,
-	})
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "admin-2", slug)
+}
 
-	input := model.InviteMemberInput{Email: "nonexistent@example.com"}
+func TestTenantService_SuggestAvailableSlug_Exhausted(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+	tenantRepo.ExistsBySlugFunc = func(ctx context.Context, slug string) (bool, error) {
+		return true, nil
+	}
 
 	// Act
-	membership, err := svc.InviteMember(ctx, "tenant-1", input)
+	slug, err := svc.SuggestAvailableSlug(ctx, "Acme")
 
 	// Assert
-	assert.Nil(t, membership)
-	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+	assert.Empty(t, slug)
+	assert.ErrorIs(t, err, errors.ErrSlugTaken)
 }
 
-func TestTenantService_LeaveTenant_Success(t *testing.T) {
+func TestTenantService_GetMyTenants(t *testing.T) {
 	// Arrange
 	svc, tenantRepo, membershipRepo, _ := setupTestService()
 	ctx := auth.WithUserID(context.Background(), "user-123")
 
-	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
-	tenantRepo.AddTenant(tenant)
+	// Add tenants
+	tenant1 := &model.Tenant{ID: "tenant-1", Name: "Tenant 1", Slug: "tenant-1", Status: model.TenantStatusActive}
+	tenant2 := &model.Tenant{ID: "tenant-2", Name: "Tenant 2", Slug: "tenant-2", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant1)
+	tenantRepo.AddTenant(tenant2)
+	tenantRepo.AddUserToTenant("user-123", "tenant-1")
+	tenantRepo.AddUserToTenant("user-123", "tenant-2")
 
-	// Add two owners so one can leave
+	// Add memberships
 	membershipRepo.AddMembership(&model.Membership{
 		ID:     "m1",
 		Role:   model.MembershipRoleOwner,
 		User:   &model.User{ID: "user-123"},
-		Tenant: tenant,
+		Tenant: tenant1,
 	})
 	membershipRepo.AddMembership(&model.Membership{
 		ID:     "m2",
-		Role:   model.MembershipRoleOwner,
-		User:   &model.User{ID: "other-owner"},
-		Tenant: tenant,
+		Role:   model.MembershipRoleMember,
+		User:   &model.User{ID: "user-123"},
+		Tenant: tenant2,
 	})
 
 	// Act
-	left, err := svc.LeaveTenant(ctx, "tenant-1")
+	tenants, err := svc.GetMyTenants(ctx, nil, nil)
 
 	// Assert
 	require.NoError(t, err)
-	assert.True(t, left)
-
-	// Verify membership is deleted
-	_, findErr := membershipRepo.FindByUserAndTenant(ctx, "user-123", "tenant-1")
-	assert.ErrorIs(t, findErr, errors.ErrMembershipNotFound)
+	assert.Len(t, tenants, 2)
 }
 
-func TestTenantService_LeaveTenant_LastOwner(t *testing.T) {
+func TestTenantService_GetMyTenants_AttachesRole(t *testing.T) {
 	// Arrange
-	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	svc, tenantRepo, _, _ := setupTestService()
 	ctx := auth.WithUserID(context.Background(), "user-123")
 
-	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
-	tenantRepo.AddTenant(tenant)
-
-	// Add only one owner
-	membershipRepo.AddMembership(&model.Membership{
-		ID:     "m1",
-		Role:   model.MembershipRoleOwner,
-		User:   &model.User{ID: "user-123"},
-		Tenant: tenant,
-	})
+	roles := map[string]model.MembershipRole{
+		"owned":  model.MembershipRoleOwner,
+		"admin":  model.MembershipRoleAdmin,
+		"member": model.MembershipRoleMember,
+	}
+	for id, role := range roles {
+		tenantRepo.AddTenant(&model.Tenant{ID: id, Name: id, Slug: id, Status: model.TenantStatusActive})
+		tenantRepo.AddUserToTenantWithRole("user-123", id, role)
+	}
+	// Another user's role in a shared tenant must not leak
+	tenantRepo.AddUserToTenantWithRole("user-456", "owned", model.MembershipRoleViewer)
 
 	// Act
-	left, err := svc.LeaveTenant(ctx, "tenant-1")
+	tenants, err := svc.GetMyTenants(ctx, nil, nil)
 
 	// Assert
-	assert.False(t, left)
-	assert.ErrorIs(t, err, errors.ErrCannotLeave)
+	require.NoError(t, err)
+	require.Len(t, tenants, 3)
+	for _, tenant := range tenants {
+		require.NotNil(t, tenant.MyRole, tenant.ID)
+		assert.Equal(t, roles[tenant.ID], *tenant.MyRole, tenant.ID)
+	}
 }
 
-func TestTenantService_UpdateMemberRole_CannotDemoteLastOwner(t *testing.T) {
+func TestTenantService_GetMyTenants_SkipsPendingInvites(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+	for _, id := range []string{"joined", "invited"} {
+		tenantRepo.AddTenant(&model.Tenant{ID: id, Name: id, Slug: id, Status: model.TenantStatusActive})
+	}
+	tenantRepo.AddUserToTenant("user-123", "joined")
+	tenantRepo.AddPendingUserToTenant("user-123", "invited", model.MembershipRoleAdmin)
+
+	// Act
+	tenants, err := svc.GetMyTenants(ctx, nil, nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, tenants, 1)
+	assert.Equal(t, "joined", tenants[0].ID)
+}
+
+func TestTenantService_GetMyTenants_PagesManyTenants(t *testing.T) {
+	// Arrange - 120 tenants; pairs share a createdAt so ties need the ID tiebreak
+	svc, tenantRepo, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 120 {
+		id := fmt.Sprintf("tenant-%03d", i)
+		tenantRepo.AddTenant(&model.Tenant{
+			ID: id, Name: id, Slug: id, Status: model.TenantStatusActive,
+			CreatedAt: base.Add(time.Duration(i/2) * time.Hour),
+		})
+		tenantRepo.AddUserToTenant("user-123", id)
+	}
+
+	// Act
+	defaultPage, err := svc.GetMyTenants(ctx, nil, nil)
+	require.NoError(t, err)
+
+	var paged []*model.Tenant
+	limit := 25
+	for offset := 0; ; offset += limit {
+		page, err := svc.GetMyTenants(ctx, &limit, &offset)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		paged = append(paged, page...)
+	}
+
+	// Assert
+	assert.Len(t, defaultPage, DefaultPageSize)
+	require.Len(t, paged, 120)
+	assert.Equal(t, defaultPage, paged[:DefaultPageSize], "pages are consistent with each other")
+	seen := make(map[string]bool)
+	for i, tenant := range paged {
+		assert.False(t, seen[tenant.ID], "duplicate %s", tenant.ID)
+		seen[tenant.ID] = true
+		if i > 0 {
+			prev := paged[i-1]
+			assert.False(t, tenant.CreatedAt.After(prev.CreatedAt), "createdAt DESC at %d", i)
+			if tenant.CreatedAt.Equal(prev.CreatedAt) {
+				assert.Less(t, prev.ID, tenant.ID)
+			}
+		}
+	}
+}
+
+func TestTenantService_GetMyTenants_CapsLimit(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+	var gotLimit, gotOffset int
+	tenantRepo.FindByUserIDFunc = func(ctx context.Context, userID string, limit, offset int) ([]*model.Tenant, error) {
+		gotLimit, gotOffset = limit, offset
+		return []*model.Tenant{}, nil
+	}
+	limit, offset := 10000, -5
+
+	// Act
+	_, err := svc.GetMyTenants(ctx, &limit, &offset)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, MaxPageSize, gotLimit)
+	assert.Equal(t, 0, gotOffset)
+}
+
+func TestTenantService_GetMyInvitations_PagesPendingOnly(t *testing.T) {
+	// Arrange
+	svc, _, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 7 {
+		status := model.MembershipStatusPending
+		if i%3 == 0 {
+			status = model.MembershipStatusActive
+		}
+		membershipRepo.AddMembership(&model.Membership{
+			ID: fmt.Sprintf("m%d", i), Role: model.MembershipRoleMember, Status: status,
+			User: &model.User{ID: "user-123"}, Tenant: &model.Tenant{ID: fmt.Sprintf("tenant-%d", i)},
+			JoinedAt: base.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	limit, second := 2, 2
+
+	// Act
+	first, err := svc.GetMyInvitations(ctx, &limit, nil)
+	require.NoError(t, err)
+	rest, err := svc.GetMyInvitations(ctx, &limit, &second)
+	require.NoError(t, err)
+
+	// Assert - pending m1, m2, m4, m5 newest first
+	var ids []string
+	for _, m := range append(first, rest...) {
+		assert.Equal(t, model.MembershipStatusPending, m.Status)
+		ids = append(ids, m.ID)
+	}
+	assert.Equal(t, []string{"m5", "m4", "m2", "m1"}, ids)
+}
+
+func TestTenantService_GetMyTenantRoleSummary_MixedRoles(t *testing.T) {
+	// Arrange
+	svc, _, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+	memberships := []struct {
+		role   model.MembershipRole
+		status model.MembershipStatus
+	}{
+		{model.MembershipRoleOwner, model.MembershipStatusActive},
+		{model.MembershipRoleOwner, model.MembershipStatusActive},
+		{model.MembershipRoleMember, model.MembershipStatusActive},
+		{model.MembershipRoleMember, model.MembershipStatusActive},
+		{model.MembershipRoleMember, model.MembershipStatusPending},
+		{model.MembershipRoleViewer, model.MembershipStatusActive},
+	}
+	for i, m := range memberships {
+		membershipRepo.AddMembership(&model.Membership{
+			ID: fmt.Sprintf("m%d", i), Role: m.role, Status: m.status,
+			User: &model.User{ID: "user-123"}, Tenant: &model.Tenant{ID: fmt.Sprintf("tenant-%d", i)},
+		})
+	}
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "other", Role: model.MembershipRoleAdmin, Status: model.MembershipStatusActive,
+		User: &model.User{ID: "user-456"}, Tenant: &model.Tenant{ID: "tenant-0"},
+	})
+
+	// Act
+	summary, err := svc.GetMyTenantRoleSummary(ctx)
+
+	// Assert - pending invites and other users' memberships are not counted
+	require.NoError(t, err)
+	assert.Equal(t, map[model.MembershipRole]int{
+		model.MembershipRoleOwner:  2,
+		model.MembershipRoleMember: 2,
+		model.MembershipRoleViewer: 1,
+	}, summary)
+}
+
+func TestTenantService_GetMyTenantRoleSummary_NoMemberships(t *testing.T) {
+	// Arrange
+	svc, _, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	// Act
+	summary, err := svc.GetMyTenantRoleSummary(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, summary)
+	assert.Zero(t, summary[model.MembershipRoleOwner])
+}
+
+func TestTenantService_GetMyTenantRoleSummary_RequiresAuth(t *testing.T) {
+	// Arrange
+	svc, _, _, _ := setupTestService()
+
+	// Act
+	summary, err := svc.GetMyTenantRoleSummary(context.Background())
+
+	// Assert
+	assert.Nil(t, summary)
+	assert.ErrorIs(t, err, errors.ErrNotAuthenticated)
+}
+
+func TestTenantService_GetTenantRoleCounts_MixedRoles(t *testing.T) {
+	// Arrange
+	svc, _, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-0")
+	tenant := &model.Tenant{ID: "tenant-1"}
+	memberships := []struct {
+		role   model.MembershipRole
+		status model.MembershipStatus
+	}{
+		{model.MembershipRoleOwner, model.MembershipStatusActive},
+		{model.MembershipRoleAdmin, model.MembershipStatusActive},
+		{model.MembershipRoleAdmin, model.MembershipStatusActive},
+		{model.MembershipRoleMember, model.MembershipStatusActive},
+		{model.MembershipRoleMember, model.MembershipStatusActive},
+		{model.MembershipRoleMember, model.MembershipStatusActive},
+		{model.MembershipRoleMember, model.MembershipStatusPending},
+	}
+	for i, m := range memberships {
+		membershipRepo.AddMembership(&model.Membership{
+			ID: fmt.Sprintf("m%d", i), Role: m.role, Status: m.status,
+			User: &model.User{ID: fmt.Sprintf("user-%d", i)}, Tenant: tenant,
+		})
+	}
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "other", Role: model.MembershipRoleViewer, Status: model.MembershipStatusActive,
+		User: &model.User{ID: "user-0"}, Tenant: &model.Tenant{ID: "tenant-2"},
+	})
+
+	// Act
+	counts, err := svc.GetTenantRoleCounts(ctx, "tenant-1")
+
+	// Assert - pending invites and other tenants are not counted, and the
+	// unheld viewer role is 0
+	require.NoError(t, err)
+	assert.Equal(t, &RoleCounts{Owners: 1, Admins: 2, Members: 3, Viewers: 0}, counts)
+	assert.Equal(t, 6, counts.Total())
+}
+
+func TestTenantService_GetMembership_Access(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		caller  string
+		id      string
+		wantErr error
+	}{
+		{"viewer sees another member", "viewer-1", "m-member", nil},
+		{"invitee sees own pending invite", "invitee-1", "m-invitee", nil},
+		{"outsider is forbidden", "outsider", "m-member", errors.ErrForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			svc, _, membershipRepo, _ := setupTestService()
+			tenant := &model.Tenant{ID: "tenant-1"}
+			membershipRepo.AddMembership(&model.Membership{
+				ID: "m-viewer", Role: model.MembershipRoleViewer, Status: model.MembershipStatusActive,
+				User: &model.User{ID: "viewer-1"}, Tenant: tenant,
+			})
+			membershipRepo.AddMembership(&model.Membership{
+				ID: "m-member", Role: model.MembershipRoleMember, Status: model.MembershipStatusActive,
+				User: &model.User{ID: "member-1"}, Tenant: tenant,
+			})
+			membershipRepo.AddMembership(&model.Membership{
+				ID: "m-invitee", Role: model.MembershipRoleMember, Status: model.MembershipStatusPending,
+				User: &model.User{ID: "invitee-1"}, Tenant: tenant,
+			})
+
+			// Act
+			membership, err := svc.GetMembership(auth.WithUserID(context.Background(), tc.caller), tc.id)
+
+			// Assert
+			if tc.wantErr != nil {
+				assert.Nil(t, membership)
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.id, membership.ID)
+		})
+	}
+}
+
+func TestTenantService_GetTenantRoleCounts_RequiresMembership(t *testing.T) {
+	// Arrange
+	svc, _, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "outsider")
+	membershipRepo.CountByRoleFunc = func(ctx context.Context, tenantID string) (map[model.MembershipRole]int, error) {
+		t.Fatal("counted roles for a non-member")
+		return nil, nil
+	}
+
+	// Act
+	counts, err := svc.GetTenantRoleCounts(ctx, "tenant-1")
+
+	// Assert
+	assert.Nil(t, counts)
+	assert.ErrorIs(t, err, errors.ErrNotMember)
+}
+
+func TestTenantService_GetContactableAdmins(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	ctx := testutil.AsUser("caller")
+
+	// The caller is a member of a tenant with an owner and an admin...
+	a := h.TenantWithMembers("tenant-a", "owner-a", "caller", "member-a")
+	adminA := h.AddMember(a.Tenant, "admin-a", model.MembershipRoleAdmin)
+	// ...an admin of one where they are the only other admin...
+	b := h.TenantWithMembers("tenant-b", "owner-b")
+	h.AddMember(b.Tenant, "caller", model.MembershipRoleAdmin)
+	// ...and the sole owner of one with only members
+	h.TenantWithMembers("tenant-c", "caller", "member-c")
+	// Tenants the caller isn't active in are left out
+	d := h.TenantWithMembers("tenant-d", "owner-d")
+	h.AddMember(d.Tenant, "caller", model.MembershipRoleMember).Status = model.MembershipStatusPending
+
+	// Act
+	admins, err := svc.GetContactableAdmins(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]*model.Membership{
+		"tenant-a": {a.Owner, adminA},
+		"tenant-b": {b.Owner},
+	}, admins)
+}
+
+func TestTenantService_GetContactableAdmins_ExcludesCaller(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	ctx := testutil.AsUser("owner-1")
+	f := h.TenantWithMembers("tenant-1", "owner-1")
+	coOwner := h.AddMember(f.Tenant, "owner-2", model.MembershipRoleOwner)
+
+	// Act
+	admins, err := svc.GetContactableAdmins(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Membership{coOwner}, admins["tenant-1"])
+}
+
+func TestTenantService_GetContactableAdmins_RequiresAuth(t *testing.T) {
+	// Arrange
+	svc, _, _, _ := setupTestService()
+
+	// Act
+	admins, err := svc.GetContactableAdmins(context.Background())
+
+	// Assert
+	assert.Nil(t, admins)
+	assert.ErrorIs(t, err, errors.ErrNotAuthenticated)
+}
+
+func TestTenantService_UpdateTenant_Success(t *testing.T) {
 	// Arrange
 	svc, tenantRepo, membershipRepo, _ := setupTestService()
-	ctx := auth.WithUserID(context.Background(), "owner-123")
+	ctx := auth.WithUserID(context.Background(), "user-123")
 
-	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Old Name", Slug: "tenant-1", Status: model.TenantStatusActive}
 	tenantRepo.AddTenant(tenant)
 
-	// Add only one owner
+	// Add admin membership
 	membershipRepo.AddMembership(&model.Membership{
 		ID:     "m1",
-		Role:   model.MembershipRoleOwner,
-		User:   &model.User{ID: "owner-123"},
+		Role:   model.MembershipRoleAdmin,
+		User:   &model.User{ID: "user-123"},
 		Tenant: tenant,
 	})
 
-	// Act - try to demote ourselves
-	_, err := svc.UpdateMemberRole(ctx, "m1", model.MembershipRoleAdmin)
+	newName := "New Name"
+	input := model.UpdateTenantInput{Name: &newName}
+
+	// Act
+	updated, err := svc.UpdateTenant(ctx, "tenant-1", input)
 
 	// Assert
-	assert.ErrorIs(t, err, errors.ErrLastOwner)
+	require.NoError(t, err)
+	assert.Equal(t, "New Name", updated.Name)
 }
 
-func TestTenantService_RemoveMember_CannotRemoveLastOwner(t *testing.T) {
+func TestTenantService_UpdateTenant_NotAdmin(t *testing.T) {
 	// Arrange
 	svc, tenantRepo, membershipRepo, _ := setupTestService()
-	ctx := auth.WithUserID(context.Background(), "other-admin")
+	ctx := auth.WithUserID(context.Background(), "user-123")
 
 	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
 	tenantRepo.AddTenant(tenant)
 
-	// Add owner
+	// Add member (not admin) membership
 	membershipRepo.AddMembership(&model.Membership{
-		ID:     "owner-membership",
-		Role:   model.MembershipRoleOwner,
-		User:   &model.User{ID: "owner-123"},
+		ID:     "m1",
+		Role:   model.MembershipRoleMember,
+		User:   &model.User{ID: "user-123"},
 		Tenant: tenant,
 	})
 
-	// Add another owner who is trying to remove
+	newName := "New Name"
+	input := model.UpdateTenantInput{Name: &newName}
+
+	// Act
+	updated, err := svc.UpdateTenant(ctx, "tenant-1", input)
+
+	// Assert
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+}
+
+// setupRename returns a service where user-123 is ADMIN of tenant-1 (Acme,
+// slug "acme") and tenant-2 holds the slug "taken".
+func setupRename() (*TenantService, *repository.MockTenantRepository) {
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Acme", Slug: "acme", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	tenantRepo.AddTenant(&model.Tenant{ID: "tenant-2", Name: "Taken", Slug: "taken", Status: model.TenantStatusActive})
 	membershipRepo.AddMembership(&model.Membership{
-		ID:     "admin-membership",
-		Role:   model.MembershipRoleOwner,
-		User:   &model.User{ID: "other-admin"},
-		Tenant: tenant,
+		ID: "m1", Role: model.MembershipRoleAdmin, User: &model.User{ID: "user-123"}, Tenant: tenant,
 	})
+	return svc, tenantRepo
+}
 
-	// Set the count to 1 for this test (simulating single owner scenario)
-	membershipRepo.CountOwnersFunc = func(ctx context.Context, tenantID string) (int, error) {
-		return 1, nil
+func TestTenantService_RenameTenant_Success(t *testing.T) {
+	// Arrange
+	svc, tenantRepo := setupRename()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	// Act
+	renamed, err := svc.RenameTenant(ctx, "tenant-1", "  Acme   Labs ", " acme-labs")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Labs", renamed.Name)
+	assert.Equal(t, "acme-labs", renamed.Slug)
+	assert.Equal(t, []string{"acme"}, tenantRepo.PreviousSlugs("tenant-1"))
+}
+
+func TestTenantService_RenameTenant_RejectedChangesNothing(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		name      string
+		slug      string
+		wantErr   error
+		wantField string
+	}{
+		{"taken slug", "Acme Labs", "taken", errors.ErrSlugTaken, ""},
+		{"invalid slug", "Acme Labs", "a!", errors.ErrInvalidSlug, ""},
+		{"reserved slug", "Acme Labs", "api", errors.ErrReservedSlug, ""},
+		{"invalid name", "   ", "acme-labs", nil, "name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			svc, tenantRepo := setupRename()
+			ctx := auth.WithUserID(context.Background(), "user-123")
+
+			// Act
+			renamed, err := svc.RenameTenant(ctx, "tenant-1", tc.name, tc.slug)
+
+			// Assert
+			assert.Nil(t, renamed)
+			if tc.wantField != "" {
+				var validationErr *errors.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tc.wantField, validationErr.Field)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+
+			tenant, err := tenantRepo.FindByID(ctx, "tenant-1")
+			require.NoError(t, err)
+			assert.Equal(t, "Acme", tenant.Name)
+			assert.Equal(t, "acme", tenant.Slug)
+			assert.Empty(t, tenantRepo.PreviousSlugs("tenant-1"))
+		})
 	}
+}
+
+func TestTenantService_RenameTenant_NotAdmin(t *testing.T) {
+	// Arrange
+	svc, _ := setupRename()
+	ctx := auth.WithUserID(context.Background(), "stranger")
 
 	// Act
-	removed, err := svc.RemoveMember(ctx, "owner-membership")
+	renamed, err := svc.RenameTenant(ctx, "tenant-1", "Acme Labs", "acme-labs")
 
 	// Assert
-	assert.False(t, removed)
-	assert.ErrorIs(t, err, errors.ErrLastOwner)
+	assert.Nil(t, renamed)
+	assert.ErrorIs(t, err, errors.ErrNotMember)
+}
+
+// setupBillingTenant adds tenant-1 with "admin-123" as ADMIN and "member-123" as MEMBER.
+func setupBillingTenant() (*TenantService, *repository.MockTenantRepository) {
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "m1", Role: model.MembershipRoleAdmin, User: &model.User{ID: "admin-123"}, Tenant: tenant,
+	})
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "m2", Role: model.MembershipRoleMember, User: &model.User{ID: "member-123"}, Tenant: tenant,
+	})
+	return svc, tenantRepo
+}
+
+func TestTenantService_SetBillingEmail_Success(t *testing.T) {
+	// Arrange
+	svc, _ := setupBillingTenant()
+	ctx := auth.WithUserID(context.Background(), "admin-123")
+
+	// Act
+	updated, err := svc.SetBillingEmail(ctx, "tenant-1", "  Billing@Acme.COM ")
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, updated.BillingEmail)
+	assert.Equal(t, "billing@acme.com", *updated.BillingEmail)
+	assert.Equal(t, updated.BillingEmail, svc.GetBillingEmail(ctx, updated))
+}
+
+func TestTenantService_SetBillingEmail_Invalid(t *testing.T) {
+	// Arrange
+	svc, tenantRepo := setupBillingTenant()
+	ctx := auth.WithUserID(context.Background(), "admin-123")
+
+	// Act
+	updated, err := svc.SetBillingEmail(ctx, "tenant-1", "not-an-email")
+
+	// Assert
+	assert.Nil(t, updated)
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "email", validationErr.Field)
+	stored, _ := tenantRepo.FindByID(ctx, "tenant-1")
+	assert.Nil(t, stored.BillingEmail)
+}
+
+func TestTenantService_SetBillingEmail_NotAdmin(t *testing.T) {
+	// Arrange
+	svc, _ := setupBillingTenant()
+	ctx := auth.WithUserID(context.Background(), "member-123")
+
+	// Act
+	updated, err := svc.SetBillingEmail(ctx, "tenant-1", "billing@acme.com")
+
+	// Assert
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+}
+
+func TestTenantService_GetBillingEmail_HiddenFromNonAdmins(t *testing.T) {
+	// Arrange
+	svc, _ := setupBillingTenant()
+	email := "billing@acme.com"
+	tenant := &model.Tenant{ID: "tenant-1", BillingEmail: &email}
+
+	// Act
+	asMember := svc.GetBillingEmail(auth.WithUserID(context.Background(), "member-123"), tenant)
+	asOutsider := svc.GetBillingEmail(auth.WithUserID(context.Background(), "outsider"), tenant)
+	anonymous := svc.GetBillingEmail(context.Background(), tenant)
+
+	// Assert
+	assert.Nil(t, asMember)
+	assert.Nil(t, asOutsider)
+	assert.Nil(t, anonymous)
+}
+
+func TestTenantService_DeleteTenant_Success(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive, MemberCount: 3}
+	tenantRepo.AddTenant(tenant)
+
+	// Add owner membership
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m1",
+		Role:   model.MembershipRoleOwner,
+		User:   &model.User{ID: "user-123"},
+		Tenant: tenant,
+	})
+
+	// Act
+	deleted, err := svc.DeleteTenant(ctx, "tenant-1", nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &model.DeleteTenantResult{Success: true, TenantID: "tenant-1", MemberCount: intPtr(3)}, deleted)
+
+	// Verify tenant is deleted
+	_, findErr := tenantRepo.FindByID(ctx, "tenant-1")
+	assert.ErrorIs(t, findErr, errors.ErrTenantNotFound)
+}
+
+func TestTenantService_DeleteTenant_RecordsReasonAndActor(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m1",
+		Role:   model.MembershipRoleOwner,
+		User:   &model.User{ID: "user-123"},
+		Tenant: tenant,
+	})
+	reason := "customer request"
+
+	// Act
+	before := time.Now()
+	_, err := svc.DeleteTenant(ctx, "tenant-1", &reason)
+	require.NoError(t, err)
+
+	adminCtx := auth.WithPlatformAdmin(auth.WithUserID(context.Background(), "admin-1"))
+	info, infoErr := svc.GetTenantDeletion(adminCtx, "tenant-1")
+	_, forbiddenErr := svc.GetTenantDeletion(ctx, "tenant-1")
+
+	// Assert
+	require.NoError(t, infoErr)
+	require.NotNil(t, info.DeletedBy)
+	assert.Equal(t, "user-123", *info.DeletedBy)
+	require.NotNil(t, info.Reason)
+	assert.Equal(t, "customer request", *info.Reason)
+	assert.False(t, info.DeletedAt.Before(before))
+	assert.ErrorIs(t, forbiddenErr, errors.ErrForbidden)
+}
+
+func TestTenantService_DeleteTenant_NotOwner(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+
+	// Add admin (not owner) membership
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m1",
+		Role:   model.MembershipRoleAdmin,
+		User:   &model.User{ID: "user-123"},
+		Tenant: tenant,
+	})
+
+	// Act
+	deleted, err := svc.DeleteTenant(ctx, "tenant-1", nil)
+
+	// Assert
+	assert.Nil(t, deleted)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+}
+
+func TestTenantService_RestoreTenant_Success(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _, _ := setupTestService()
+	tenantRepo.AddTenant(&model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive})
+	require.NoError(t, tenantRepo.Delete(context.Background(), "tenant-1", "user-123", nil))
+	ctx := auth.WithPlatformAdmin(auth.WithUserID(context.Background(), "admin-1"))
+
+	// Act
+	restored, err := svc.RestoreTenant(ctx, "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, model.TenantStatusActive, restored.Status)
+	found, findErr := tenantRepo.FindByID(ctx, "tenant-1")
+	require.NoError(t, findErr)
+	assert.Equal(t, "tenant", found.Slug)
+	_, deletionErr := svc.GetTenantDeletion(ctx, "tenant-1")
+	assert.ErrorIs(t, deletionErr, errors.ErrTenantNotFound)
+}
+
+func TestTenantService_RestoreTenant_NotDeleted(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _, _ := setupTestService()
+	tenantRepo.AddTenant(&model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive})
+	ctx := auth.WithPlatformAdmin(auth.WithUserID(context.Background(), "admin-1"))
+
+	// Act
+	restored, err := svc.RestoreTenant(ctx, "tenant-1")
+
+	// Assert
+	assert.Nil(t, restored)
+	assert.ErrorIs(t, err, errors.ErrNotDeleted)
+}
+
+func TestTenantService_RestoreTenant_SlugReclaimed(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _, _ := setupTestService()
+	tenantRepo.AddTenant(&model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive})
+	require.NoError(t, tenantRepo.Delete(context.Background(), "tenant-1", "user-123", nil))
+	_, err := tenantRepo.Create(context.Background(), &model.Tenant{ID: "tenant-2", Name: "Newer Tenant", Slug: "tenant"})
+	require.NoError(t, err)
+	ctx := auth.WithPlatformAdmin(auth.WithUserID(context.Background(), "admin-1"))
+
+	// Act
+	restored, err := svc.RestoreTenant(ctx, "tenant-1")
+
+	// Assert
+	assert.Nil(t, restored)
+	assert.ErrorIs(t, err, errors.ErrSlugTaken)
+	_, deletionErr := svc.GetTenantDeletion(ctx, "tenant-1")
+	assert.NoError(t, deletionErr, "the tenant stays deleted")
+}
+
+func TestTenantService_RestoreTenant_RequiresPlatformAdmin(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _, _ := setupTestService()
+	tenantRepo.AddTenant(&model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive})
+	require.NoError(t, tenantRepo.Delete(context.Background(), "tenant-1", "user-123", nil))
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	// Act
+	restored, err := svc.RestoreTenant(ctx, "tenant-1")
+
+	// Assert
+	assert.Nil(t, restored)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+}
+
+// setupSuspension returns a service with an active tenant-1 having an owner
+// (owner-1), an admin (admin-1) and a pending invite "m-pending" for
+// invitee-1.
+func setupSuspension() (*TenantService, *repository.MockTenantRepository, *identityRepo.MockUserRepository) {
+	svc, tenantRepo, membershipRepo, userRepo := setupTestService()
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	tenantRepo.AddUserToTenant("owner-1", "tenant-1")
+	membershipRepo.AddMembership(&model.Membership{ID: "m-owner", Role: model.MembershipRoleOwner, Status: model.MembershipStatusActive, User: &model.User{ID: "owner-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "m-admin", Role: model.MembershipRoleAdmin, Status: model.MembershipStatusActive, User: &model.User{ID: "admin-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "m-pending", Role: model.MembershipRoleMember, Status: model.MembershipStatusPending, JoinedAt: time.Now(), User: &model.User{ID: "invitee-1"}, Tenant: tenant})
+	return svc, tenantRepo, userRepo
+}
+
+func TestTenantService_SuspendTenant_BlocksWritesUntilUnsuspended(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupSuspension()
+	ownerCtx := auth.WithUserID(context.Background(), "owner-1")
+	adminCtx := auth.WithUserID(context.Background(), "admin-1")
+	newName := "Renamed"
+
+	// Act
+	suspended, suspendErr := svc.SuspendTenant(ownerCtx, "tenant-1")
+	require.NoError(t, suspendErr)
+	suspendedStatus := suspended.Status
+	_, updateErr := svc.UpdateTenant(adminCtx, "tenant-1", model.UpdateTenantInput{Name: &newName})
+	_, roleErr := svc.UpdateMemberRole(ownerCtx, "m-admin", model.MembershipRoleMember)
+	_, removeErr := svc.RemoveMember(ownerCtx, "m-admin")
+	members, readErr := svc.ListMembers(adminCtx, "tenant-1", repository.MemberFilter{})
+	unsuspended, unsuspendErr := svc.UnsuspendTenant(ownerCtx, "tenant-1")
+	updated, retryErr := svc.UpdateTenant(adminCtx, "tenant-1", model.UpdateTenantInput{Name: &newName})
+
+	// Assert
+	assert.Equal(t, model.TenantStatusSuspended, suspendedStatus)
+	assert.ErrorIs(t, updateErr, errors.ErrTenantSuspended)
+	assert.ErrorIs(t, roleErr, errors.ErrTenantSuspended)
+	assert.ErrorIs(t, removeErr, errors.ErrTenantSuspended)
+	require.NoError(t, readErr, "suspended tenants stay readable")
+	assert.Len(t, members, 3)
+	require.NoError(t, unsuspendErr)
+	assert.Equal(t, model.TenantStatusActive, unsuspended.Status)
+	require.NoError(t, retryErr)
+	assert.Equal(t, "Renamed", updated.Name)
+}
+
+func TestTenantService_SuspendTenant_RequiresOwner(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _ := setupSuspension()
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+
+	// Act
+	suspended, err := svc.SuspendTenant(ctx, "tenant-1")
+
+	// Assert
+	assert.Nil(t, suspended)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+	tenant, _ := tenantRepo.FindByID(context.Background(), "tenant-1")
+	assert.Equal(t, model.TenantStatusActive, tenant.Status)
+}
+
+func TestTenantService_SuspendTenant_AlreadySuspended(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupSuspension()
+	ctx := auth.WithUserID(context.Background(), "owner-1")
+	_, err := svc.SuspendTenant(ctx, "tenant-1")
+	require.NoError(t, err)
+
+	// Act
+	_, err = svc.SuspendTenant(ctx, "tenant-1")
+
+	// Assert
+	assert.ErrorIs(t, err, errors.ErrAlreadySuspended)
+}
+
+func TestTenantService_UnsuspendTenant_NotSuspended(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupSuspension()
+	ctx := auth.WithUserID(context.Background(), "owner-1")
+
+	// Act
+	tenant, err := svc.UnsuspendTenant(ctx, "tenant-1")
+
+	// Assert
+	assert.Nil(t, tenant)
+	assert.ErrorIs(t, err, errors.ErrNotSuspended)
+}
+
+func TestTenantService_SuspendTenant_StillListedForOwner(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupSuspension()
+	ctx := auth.WithUserID(context.Background(), "owner-1")
+	_, err := svc.SuspendTenant(ctx, "tenant-1")
+	require.NoError(t, err)
+
+	// Act
+	tenants, err := svc.GetMyTenants(ctx, nil, nil)
+
+	// Assert - the owner can still find the tenant to unsuspend it
+	require.NoError(t, err)
+	require.Len(t, tenants, 1)
+	assert.Equal(t, model.TenantStatusSuspended, tenants[0].Status)
+}
+
+func TestTenantService_UpdateTenant_RejectsStatus(t *testing.T) {
+	// Arrange - an admin must not bypass the owner-only suspension
+	svc, tenantRepo, _ := setupSuspension()
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+	status := model.TenantStatusSuspended
+
+	// Act
+	_, err := svc.UpdateTenant(ctx, "tenant-1", model.UpdateTenantInput{Status: &status})
+
+	// Assert
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "status", validationErr.Field)
+	tenant, _ := tenantRepo.FindByID(context.Background(), "tenant-1")
+	assert.Equal(t, model.TenantStatusActive, tenant.Status)
+}
+
+func TestTenantService_AcceptInviteByToken_SuspendedTenant(t *testing.T) {
+	// Arrange
+	svc, _, userRepo := setupSuspension()
+	svc.WithInviteTokens(auth.NewTokenManager("secret", time.Hour, userRepo), time.Hour)
+	userRepo.AddUser(&model.User{ID: "invitee-1", Email: "invitee@example.com", Status: model.UserStatusActive})
+	token, err := svc.CreateInviteToken(auth.WithUserID(context.Background(), "admin-1"), "m-pending")
+	require.NoError(t, err)
+	_, err = svc.SuspendTenant(auth.WithUserID(context.Background(), "owner-1"), "tenant-1")
+	require.NoError(t, err)
+
+	// Act
+	_, err = svc.AcceptInviteByToken(auth.WithUserID(context.Background(), "invitee-1"), token)
+
+	// Assert
+	assert.ErrorIs(t, err, errors.ErrTenantSuspended)
+}
+
+// setupSettings returns a service with tenant-1 having an admin (admin-1)
+// and a member (member-1).
+func setupSettings() *TenantService {
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m-admin", Role: model.MembershipRoleAdmin, Status: model.MembershipStatusActive, User: &model.User{ID: "admin-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "m-member", Role: model.MembershipRoleMember, Status: model.MembershipStatusActive, User: &model.User{ID: "member-1"}, Tenant: tenant})
+	return svc
+}
+
+func TestTenantService_UpdateSettings_RoundTrip(t *testing.T) {
+	// Arrange
+	svc := setupSettings()
+	adminCtx := auth.WithUserID(context.Background(), "admin-1")
+	memberCtx := auth.WithUserID(context.Background(), "member-1")
+
+	// Act
+	_, updateErr := svc.UpdateSettings(adminCtx, "tenant-1", SettingsPatch{
+		"defaultRole":   "VIEWER",
+		"brandingColor": "#1A2B3C",
+		"timezone":      "Europe/Berlin",
+		"custom":        map[string]any{"welcomeText": "Hi!"},
+	})
+	settings, getErr := svc.GetSettings(memberCtx, "tenant-1")
+
+	// Assert
+	require.NoError(t, updateErr)
+	require.NoError(t, getErr)
+	require.NotNil(t, settings.DefaultRole)
+	assert.Equal(t, model.MembershipRoleViewer, *settings.DefaultRole)
+	assert.Equal(t, "#1A2B3C", *settings.BrandingColor)
+	assert.Equal(t, "Europe/Berlin", *settings.Timezone)
+	assert.Equal(t, map[string]string{"welcomeText": "Hi!"}, settings.Custom)
+}
+
+func TestTenantService_UpdateSettings_PartialUpdateKeepsOtherKeys(t *testing.T) {
+	// Arrange
+	svc := setupSettings()
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+	_, err := svc.UpdateSettings(ctx, "tenant-1", SettingsPatch{
+		"brandingColor": "#1A2B3C",
+		"timezone":      "Europe/Berlin",
+		"custom":        map[string]any{"a": "1", "b": "2"},
+	})
+	require.NoError(t, err)
+
+	// Act - change one setting, clear another and patch one custom key
+	settings, err := svc.UpdateSettings(ctx, "tenant-1", SettingsPatch{
+		"timezone": "UTC",
+		"custom":   map[string]any{"b": nil, "c": "3"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "#1A2B3C", *settings.BrandingColor)
+	assert.Equal(t, "UTC", *settings.Timezone)
+	assert.Equal(t, map[string]string{"a": "1", "c": "3"}, settings.Custom)
+}
+
+func TestTenantService_UpdateSettings_NilClearsSetting(t *testing.T) {
+	// Arrange
+	svc := setupSettings()
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+	_, err := svc.UpdateSettings(ctx, "tenant-1", SettingsPatch{"brandingColor": "#1A2B3C", "timezone": "UTC"})
+	require.NoError(t, err)
+
+	// Act
+	settings, err := svc.UpdateSettings(ctx, "tenant-1", SettingsPatch{"brandingColor": nil})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, settings.BrandingColor)
+	assert.Equal(t, "UTC", *settings.Timezone)
+}
+
+func TestTenantService_UpdateSettings_ReportsEveryProblem(t *testing.T) {
+	// Arrange
+	svc := setupSettings()
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+	_, err := svc.UpdateSettings(ctx, "tenant-1", SettingsPatch{"timezone": "UTC"})
+	require.NoError(t, err)
+
+	// Act
+	_, err = svc.UpdateSettings(ctx, "tenant-1", SettingsPatch{
+		"theme":         "dark",
+		"defaultRole":   "OWNER",
+		"brandingColor": "red",
+		"timezone":      "Europe/Berlin",
+		"custom":        map[string]any{"count": 3},
+	})
+
+	// Assert - nothing is applied, not even the valid timezone
+	var problems errors.ValidationErrors
+	require.ErrorAs(t, err, &problems)
+	fields := make([]string, len(problems))
+	for i, problem := range problems {
+		fields[i] = problem.Field
+	}
+	assert.Equal(t, []string{"brandingColor", "custom.count", "defaultRole", "theme"}, fields)
+
+	settings, err := svc.GetSettings(ctx, "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", *settings.Timezone)
+}
+
+func TestTenantService_UpdateSettings_CustomLimit(t *testing.T) {
+	// Arrange
+	svc := setupSettings()
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+	custom := map[string]any{}
+	for i := range MaxCustomSettings + 1 {
+		custom[fmt.Sprintf("key-%d", i)] = "v"
+	}
+
+	// Act
+	_, err := svc.UpdateSettings(ctx, "tenant-1", SettingsPatch{"custom": custom})
+
+	// Assert
+	var problems errors.ValidationErrors
+	require.ErrorAs(t, err, &problems)
+	assert.Equal(t, "custom", problems[0].Field)
+}
+
+func TestTenantService_UpdateSettings_CustomLengthsCountCharacters(t *testing.T) {
+	// Arrange - multi-byte characters at the limits fit; one more doesn't
+	svc := setupSettings()
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+	key := strings.Repeat("é", MaxCustomKeyLength)
+	value := strings.Repeat("日", MaxCustomValueLength)
+
+	// Act
+	settings, err := svc.UpdateSettings(ctx, "tenant-1", SettingsPatch{"custom": map[string]any{key: value}})
+	_, longErr := svc.UpdateSettings(ctx, "tenant-1", SettingsPatch{"custom": map[string]any{"k": value + "日"}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, value, settings.Custom[key])
+	var problems errors.ValidationErrors
+	require.ErrorAs(t, longErr, &problems)
+	assert.Equal(t, "custom.k", problems[0].Field)
+}
+
+func TestTenantService_UpdateSettings_RequiresAdmin(t *testing.T) {
+	// Arrange
+	svc := setupSettings()
+
+	// Act
+	_, memberErr := svc.UpdateSettings(auth.WithUserID(context.Background(), "member-1"), "tenant-1", SettingsPatch{"timezone": "UTC"})
+	_, outsiderErr := svc.GetSettings(auth.WithUserID(context.Background(), "outsider"), "tenant-1")
+
+	// Assert
+	assert.ErrorIs(t, memberErr, errors.ErrForbidden)
+	assert.ErrorIs(t, outsiderErr, errors.ErrNotMember)
+}
+
+func TestTenantService_InviteMember_Success(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, userRepo := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "admin-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+
+	// Add admin membership
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m1",
+		Role:   model.MembershipRoleAdmin,
+		User:   &model.User{ID: "admin-123"},
+		Tenant: tenant,
+	})
+
+	// Add user to invite
+	userRepo.AddUser(&model.User{ID: "invitee-123", Email: "invitee@example.com", Status: model.UserStatusActive})
+
+	input := model.InviteMemberInput{Email: "invitee@example.com"}
+
+	// Act
+	membership, err := svc.InviteMember(ctx, "tenant-1", input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipRoleMember, membership.Role)
+	assert.Equal(t, "invitee-123", membership.User.ID)
+}
+
+func TestTenantService_InviteMember_TrimsEmail(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, userRepo := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "admin-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m1",
+		Role:   model.MembershipRoleAdmin,
+		User:   &model.User{ID: "admin-123"},
+		Tenant: tenant,
+	})
+
+	userRepo.AddUser(&model.User{ID: "invitee-123", Email: "invitee@example.com", Status: model.UserStatusActive})
+
+	input := model.InviteMemberInput{Email: "  invitee@example.com "}
+
+	// Act
+	membership, err := svc.InviteMember(ctx, "tenant-1", input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "invitee-123", membership.User.ID)
+}
+
+func TestTenantService_InviteMember_NewEmailCreatesPendingInvite(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, userRepo := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "admin-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m1",
+		Role:   model.MembershipRoleAdmin,
+		User:   &model.User{ID: "admin-123"},
+		Tenant: tenant,
+	})
+
+	input := model.InviteMemberInput{Email: "nonexistent@example.com"}
+
+	// Act
+	membership, err := svc.InviteMember(ctx, "tenant-1", input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipStatusPending, membership.Status)
+	placeholder, err := userRepo.FindByEmail(ctx, "nonexistent@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusPending, placeholder.Status)
+	assert.Equal(t, placeholder.ID, membership.User.ID)
+}
+
+func TestTenantService_LeaveTenant_Success(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive, MemberCount: 2}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.Tenants = tenantRepo
+
+	// Add two owners so one can leave
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m1",
+		Role:   model.MembershipRoleOwner,
+		User:   &model.User{ID: "user-123"},
+		Tenant: tenant,
+	})
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m2",
+		Role:   model.MembershipRoleOwner,
+		User:   &model.User{ID: "other-owner"},
+		Tenant: tenant,
+	})
+
+	// Act
+	left, err := svc.LeaveTenant(ctx, "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &model.MemberRemovalResult{
+		Success: true, TenantID: "tenant-1", MembershipID: "m1", UserID: "user-123",
+		RemainingMemberCount: intPtr(1), RemainingOwnerCount: intPtr(1),
+	}, left)
+
+	// Verify membership is deleted
+	_, findErr := membershipRepo.FindByUserAndTenant(ctx, "user-123", "tenant-1")
+	assert.ErrorIs(t, findErr, errors.ErrMembershipNotFound)
+}
+
+func TestTenantService_LeaveTenant_HandsOffInvites(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		handoff     InviteHandoff
+		wantInviter *model.User
+	}{
+		{"earliest owner by default", InviteHandoffOwner, &model.User{ID: "owner-1"}},
+		{"owner when the remover is the leaver", InviteHandoffRemover, &model.User{ID: "owner-1"}},
+		{"detached", InviteHandoffDetach, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			svc, tenantRepo, membershipRepo, _ := setupTestService()
+			svc.WithInviteHandoff(tc.handoff)
+			ctx := auth.WithUserID(context.Background(), "admin-1")
+
+			tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+			tenantRepo.AddTenant(tenant)
+			membershipRepo.AddMembership(&model.Membership{ID: "owner-1-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-1"}, Tenant: tenant})
+			membershipRepo.AddMembership(&model.Membership{ID: "admin-m", Role: model.MembershipRoleAdmin, User: &model.User{ID: "admin-1"}, Tenant: tenant})
+			membershipRepo.AddMembership(&model.Membership{ID: "invite-1", Role: model.MembershipRoleMember, User: &model.User{ID: "invitee-1"}, Tenant: tenant, InvitedBy: &model.User{ID: "admin-1"}})
+
+			// Act
+			left, err := svc.LeaveTenant(ctx, "tenant-1")
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 1, left.InvitesHandedOff)
+			invite, err := membershipRepo.FindByID(ctx, "invite-1")
+			require.NoError(t, err, "invitee membership survives")
+			assert.Equal(t, tc.wantInviter, invite.InvitedBy)
+		})
+	}
+}
+
+func TestTenantService_LeaveTenant_LastOwner(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+
+	// Add only one owner
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m1",
+		Role:   model.MembershipRoleOwner,
+		User:   &model.User{ID: "user-123"},
+		Tenant: tenant,
+	})
+
+	// Act
+	left, err := svc.LeaveTenant(ctx, "tenant-1")
+
+	// Assert
+	assert.Nil(t, left)
+	assert.ErrorIs(t, err, errors.ErrCannotLeave)
+}
+
+func TestTenantService_LastOwner_PendingOwnerInviteDoesNotCount(t *testing.T) {
+	// Arrange - the only active owner has invited another OWNER who hasn't
+	// accepted yet
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "m1", Role: model.MembershipRoleOwner, Status: model.MembershipStatusActive,
+		User: &model.User{ID: "owner-123"}, Tenant: tenant,
+	})
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "invite-1", Role: model.MembershipRoleOwner, Status: model.MembershipStatusPending,
+		User: &model.User{ID: "invitee-1"}, Tenant: tenant, InvitedBy: &model.User{ID: "owner-123"},
+	})
+
+	// Act
+	left, leaveErr := svc.LeaveTenant(ctx, "tenant-1")
+	_, demoteErr := svc.UpdateMemberRole(ctx, "m1", model.MembershipRoleAdmin)
+	owners, ownersErr := svc.GetTenantOwners(ctx, "tenant-1")
+	declined, declineErr := svc.LeaveTenant(auth.WithUserID(context.Background(), "invitee-1"), "tenant-1")
+
+	// Assert - the invitee can still decline
+	assert.Nil(t, left)
+	assert.ErrorIs(t, leaveErr, errors.ErrCannotLeave)
+	assert.ErrorIs(t, demoteErr, errors.ErrLastOwner)
+	require.NoError(t, ownersErr)
+	require.Len(t, owners, 1)
+	assert.Equal(t, "m1", owners[0].ID)
+	require.NoError(t, declineErr)
+	assert.Equal(t, "invite-1", declined.MembershipID)
+}
+
+func TestTenantService_UpdateMemberRole_CannotDemoteLastOwner(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+
+	// Add only one owner
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m1",
+		Role:   model.MembershipRoleOwner,
+		User:   &model.User{ID: "owner-123"},
+		Tenant: tenant,
+	})
+
+	// Act - try to demote ourselves
+	_, err := svc.UpdateMemberRole(ctx, "m1", model.MembershipRoleAdmin)
+
+	// Assert
+	assert.ErrorIs(t, err, errors.ErrLastOwner)
+}
+
+func TestTenantService_UpdateMemberRoleByUser_Success(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-123"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "m2", Role: model.MembershipRoleMember, User: &model.User{ID: "member-456"}, Tenant: tenant})
+
+	// Act
+	membership, err := svc.UpdateMemberRoleByUser(ctx, "tenant-1", "member-456", model.MembershipRoleAdmin)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "m2", membership.ID)
+	assert.Equal(t, model.MembershipRoleAdmin, membership.Role)
+}
+
+func TestTenantService_UpdateMemberRole_NotifiesMember(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, userRepo := setupTestService()
+	notifier := &fakeNotifier{}
+	svc.WithNotifier(notifier)
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Acme", Slug: "acme", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	userRepo.AddUser(&model.User{ID: "member-456", Email: "member@example.com", Status: model.UserStatusActive})
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-123"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "m2", Role: model.MembershipRoleMember, User: &model.User{ID: "member-456"}, Tenant: tenant})
+
+	// Act
+	_, err := svc.UpdateMemberRole(ctx, "m2", model.MembershipRoleAdmin)
+	_, unchangedErr := svc.UpdateMemberRole(ctx, "m2", model.MembershipRoleAdmin)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, unchangedErr)
+	assert.Equal(t, []notify.RoleChanged{{To: "member@example.com", TenantName: "Acme", Role: "ADMIN"}}, notifier.roleChanges,
+		"only an actual change is notified")
+}
+
+func TestTenantService_UpdateMemberRole_FailingNotifierKeepsRole(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, userRepo := setupTestService()
+	svc.WithNotifier(&fakeNotifier{err: fmt.Errorf("smtp unavailable")})
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Acme", Slug: "acme", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	userRepo.AddUser(&model.User{ID: "member-456", Email: "member@example.com", Status: model.UserStatusActive})
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-123"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "m2", Role: model.MembershipRoleMember, User: &model.User{ID: "member-456"}, Tenant: tenant})
+
+	// Act
+	membership, err := svc.UpdateMemberRole(ctx, "m2", model.MembershipRoleAdmin)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipRoleAdmin, membership.Role)
+	stored, _ := membershipRepo.FindByID(ctx, "m2")
+	assert.Equal(t, model.MembershipRoleAdmin, stored.Role)
+}
+
+func TestTenantService_UpdateMemberRoleByUser_NotMember(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-123"}, Tenant: tenant})
+
+	// Act
+	membership, err := svc.UpdateMemberRoleByUser(ctx, "tenant-1", "stranger-789", model.MembershipRoleAdmin)
+
+	// Assert
+	assert.Nil(t, membership)
+	assert.ErrorIs(t, err, errors.ErrMembershipNotFound)
+}
+
+func TestTenantService_UpdateMemberRoleByUser_MemberCannotPromote(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	fixture := h.TenantWithMembers("tenant-1", "owner-1", "member-1", "member-2")
+
+	// Act
+	_, memberErr := svc.UpdateMemberRoleByUser(testutil.AsUser("member-1"), "tenant-1", "member-2", model.MembershipRoleAdmin)
+	promoted, ownerErr := svc.UpdateMemberRoleByUser(testutil.AsUser("owner-1"), "tenant-1", "member-2", model.MembershipRoleAdmin)
+
+	// Assert
+	assert.ErrorIs(t, memberErr, errors.ErrForbidden)
+	require.NoError(t, ownerErr)
+	assert.Equal(t, fixture.Members[1].ID, promoted.ID)
+	assert.Equal(t, model.MembershipRoleAdmin, promoted.Role)
+}
+
+func TestTenantService_UpdateMemberRoleByUser_CannotDemoteLastOwner(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-123"}, Tenant: tenant})
+
+	// Act
+	_, err := svc.UpdateMemberRoleByUser(ctx, "tenant-1", "owner-123", model.MembershipRoleMember)
+
+	// Assert
+	assert.ErrorIs(t, err, errors.ErrLastOwner)
+	stored, _ := membershipRepo.FindByID(ctx, "m1")
+	assert.Equal(t, model.MembershipRoleOwner, stored.Role)
+}
+
+func TestTenantService_InviteMember_NotMemberVsForbidden(t *testing.T) {
+	// Arrange - member-123 belongs with too low a role, outsider doesn't belong
+	svc, _ := setupBillingTenant()
+	input := model.InviteMemberInput{Email: "invitee@example.com"}
+
+	// Act
+	_, outsiderErr := svc.InviteMember(auth.WithUserID(context.Background(), "outsider"), "tenant-1", input)
+	_, memberErr := svc.InviteMember(auth.WithUserID(context.Background(), "member-123"), "tenant-1", input)
+
+	// Assert
+	assert.ErrorIs(t, outsiderErr, errors.ErrNotMember)
+	assert.Equal(t, errors.CodeNotMember, errors.CodeOf(outsiderErr))
+	assert.ErrorIs(t, memberErr, errors.ErrForbidden)
+	assert.Equal(t, errors.CodeForbidden, errors.CodeOf(memberErr))
+}
+
+func TestTenantService_RequireRole_PropagatesRepositoryErrors(t *testing.T) {
+	// Arrange
+	svc, _, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "admin-123")
+	membershipRepo.FindByUserAndTenantFunc = func(ctx context.Context, userID, tenantID string) (*model.Membership, error) {
+		return nil, errors.ErrTimeout
+	}
+
+	// Act
+	_, err := svc.UpdateTenant(ctx, "tenant-1", model.UpdateTenantInput{})
+
+	// Assert
+	assert.ErrorIs(t, err, errors.ErrTimeout)
+	assert.NotErrorIs(t, err, errors.ErrNotMember)
+}
+
+func TestTenantService_LeaveTenant_NotMember(t *testing.T) {
+	// Arrange
+	svc, _ := setupBillingTenant()
+	ctx := auth.WithUserID(context.Background(), "outsider")
+
+	// Act
+	left, err := svc.LeaveTenant(ctx, "tenant-1")
+
+	// Assert
+	assert.Nil(t, left)
+	assert.ErrorIs(t, err, errors.ErrNotMember)
+}
+
+func TestTenantService_CanLeaveTenant(t *testing.T) {
+	soleOwner := model.LeaveBlockedReasonSoleOwner
+	notMember := model.LeaveBlockedReasonNotMember
+
+	testCases := []struct {
+		desc      string
+		userID    string
+		coOwner   bool
+		want      *model.LeaveEligibility
+		wantLeave error
+	}{
+		{desc: "sole owner", userID: "owner-1", want: &model.LeaveEligibility{Reason: &soleOwner}, wantLeave: errors.ErrCannotLeave},
+		{desc: "owner with a co-owner", userID: "owner-1", coOwner: true, want: &model.LeaveEligibility{Allowed: true}},
+		{desc: "member", userID: "member-1", want: &model.LeaveEligibility{Allowed: true}},
+		{desc: "non-member", userID: "outsider", want: &model.LeaveEligibility{Reason: &notMember}, wantLeave: errors.ErrNotMember},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			h := testutil.NewHarness()
+			svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+			fixture := h.TenantWithMembers("tenant-1", "owner-1", "member-1")
+			if tc.coOwner {
+				h.AddMember(fixture.Tenant, "owner-2", model.MembershipRoleOwner)
+			}
+			ctx := testutil.AsUser(tc.userID)
+
+			// Act
+			got, err := svc.CanLeaveTenant(ctx, "tenant-1")
+
+			// Assert - the answer matches what LeaveTenant then does
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			_, leaveErr := svc.LeaveTenant(ctx, "tenant-1")
+			assert.ErrorIs(t, leaveErr, tc.wantLeave)
+		})
+	}
+}
+
+func TestTenantService_CanLeaveTenant_Unauthenticated(t *testing.T) {
+	// Arrange
+	svc, _, _, _ := setupTestService()
+
+	// Act
+	got, err := svc.CanLeaveTenant(context.Background(), "tenant-1")
+
+	// Assert
+	assert.Nil(t, got)
+	assert.ErrorIs(t, err, errors.ErrNotAuthenticated)
+}
+
+func TestTenantService_RemoveMember_CannotRemoveLastOwner(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "other-admin")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+
+	// Add owner
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "owner-membership",
+		Role:   model.MembershipRoleOwner,
+		User:   &model.User{ID: "owner-123"},
+		Tenant: tenant,
+	})
+
+	// Add another owner who is trying to remove
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "admin-membership",
+		Role:   model.MembershipRoleOwner,
+		User:   &model.User{ID: "other-admin"},
+		Tenant: tenant,
+	})
+
+	// Set the count to 1 for this test (simulating single owner scenario)
+	membershipRepo.CountOwnersFunc = func(ctx context.Context, tenantID string) (int, error) {
+		return 1, nil
+	}
+
+	// Act
+	removed, err := svc.RemoveMember(ctx, "owner-membership")
+
+	// Assert
+	assert.Nil(t, removed)
+	assert.ErrorIs(t, err, errors.ErrLastOwner)
+}
+
+func TestTenantService_RemoveMember_HandsOffInvites(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		handoff     InviteHandoff
+		wantInviter *model.User
+	}{
+		{"earliest owner by default", InviteHandoffOwner, &model.User{ID: "owner-1"}},
+		{"remover", InviteHandoffRemover, &model.User{ID: "owner-2"}},
+		{"detached", InviteHandoffDetach, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			svc, tenantRepo, membershipRepo, _ := setupTestService()
+			svc.WithInviteHandoff(tc.handoff)
+			ctx := auth.WithUserID(context.Background(), "owner-2")
+
+			tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+			tenantRepo.AddTenant(tenant)
+			joined := time.Now().Add(-time.Hour)
+			membershipRepo.AddMembership(&model.Membership{ID: "owner-1-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-1"}, Tenant: tenant, JoinedAt: joined})
+			membershipRepo.AddMembership(&model.Membership{ID: "owner-2-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-2"}, Tenant: tenant, JoinedAt: joined.Add(time.Minute)})
+			membershipRepo.AddMembership(&model.Membership{ID: "admin-m", Role: model.MembershipRoleAdmin, User: &model.User{ID: "admin-1"}, Tenant: tenant})
+			membershipRepo.AddMembership(&model.Membership{ID: "invite-1", Role: model.MembershipRoleMember, User: &model.User{ID: "invitee-1"}, Tenant: tenant, InvitedBy: &model.User{ID: "admin-1"}})
+			membershipRepo.AddMembership(&model.Membership{ID: "invite-2", Role: model.MembershipRoleMember, User: &model.User{ID: "invitee-2"}, Tenant: tenant, InvitedBy: &model.User{ID: "owner-1"}})
+
+			// Act
+			removed, err := svc.RemoveMember(ctx, "admin-m")
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 1, removed.InvitesHandedOff)
+
+			invite1, err := membershipRepo.FindByID(ctx, "invite-1")
+			require.NoError(t, err, "invitee membership survives")
+			assert.Equal(t, tc.wantInviter, invite1.InvitedBy)
+
+			// Invites sent by other members are untouched
+			invite2, err := membershipRepo.FindByID(ctx, "invite-2")
+			require.NoError(t, err)
+			assert.Equal(t, "owner-1", invite2.InvitedBy.ID)
+		})
+	}
+}
+
+func TestTenantService_RemoveMember_ReturnsRemovalResult(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-1")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive, MemberCount: 3}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.Tenants = tenantRepo
+	membershipRepo.AddMembership(&model.Membership{ID: "owner-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "member-m", Role: model.MembershipRoleMember, User: &model.User{ID: "member-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "invite-m", Role: model.MembershipRoleMember, User: &model.User{ID: "invitee-1"}, Tenant: tenant, InvitedBy: &model.User{ID: "member-1"}})
+
+	// Act
+	removed, err := svc.RemoveMember(ctx, "member-m")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &model.MemberRemovalResult{
+		Success: true, TenantID: "tenant-1", MembershipID: "member-m", UserID: "member-1",
+		RemainingMemberCount: intPtr(2), RemainingOwnerCount: intPtr(1), InvitesHandedOff: 1,
+	}, removed)
+}
+
+func TestTenantService_RemoveMember_UnreadableCountsStillSucceed(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-1")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "owner-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "member-m", Role: model.MembershipRoleMember, User: &model.User{ID: "member-1"}, Tenant: tenant})
+
+	readErr := fmt.Errorf("read failed")
+	tenantRepo.GetMemberCountFunc = func(ctx context.Context, tenantID string) (int, error) {
+		return 0, readErr
+	}
+	membershipRepo.CountOwnersFunc = func(ctx context.Context, tenantID string) (int, error) {
+		return 0, readErr
+	}
+
+	// Act
+	removed, err := svc.RemoveMember(ctx, "member-m")
+
+	// Assert - the membership is gone, so the call reports success without counts
+	require.NoError(t, err)
+	assert.True(t, removed.Success)
+	assert.Nil(t, removed.RemainingMemberCount)
+	assert.Nil(t, removed.RemainingOwnerCount)
+	_, err = membershipRepo.FindByID(ctx, "member-m")
+	assert.ErrorIs(t, err, errors.ErrMembershipNotFound)
+}
+
+func TestTenantService_RemoveMember_HandoffFailureKeepsMember(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-1")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "owner-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "admin-m", Role: model.MembershipRoleAdmin, User: &model.User{ID: "admin-1"}, Tenant: tenant})
+
+	writeErr := fmt.Errorf("write failed")
+	membershipRepo.ReassignInvitesTxFunc = func(ctx context.Context, tx neo4j.ManagedTransaction, fromUserID, toUserID, tenantID string) (int, error) {
+		return 0, writeErr
+	}
+
+	// Act
+	removed, err := svc.RemoveMember(ctx, "admin-m")
+
+	// Assert
+	assert.Nil(t, removed)
+	assert.ErrorIs(t, err, writeErr)
+	_, err = membershipRepo.FindByID(ctx, "admin-m")
+	assert.NoError(t, err)
+}
+
+func TestTenantService_RemoveMember_DeleteFailureKeepsInvites(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-1")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "owner-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "admin-m", Role: model.MembershipRoleAdmin, User: &model.User{ID: "admin-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "invite-1", Role: model.MembershipRoleMember, User: &model.User{ID: "invitee-1"}, Tenant: tenant, InvitedBy: &model.User{ID: "admin-1"}})
+
+	writeErr := fmt.Errorf("write failed")
+	membershipRepo.DeleteTxFunc = func(ctx context.Context, tx neo4j.ManagedTransaction, id string) error {
+		return writeErr
+	}
+
+	// Act
+	removed, err := svc.RemoveMember(ctx, "admin-m")
+
+	// Assert - the handoff rolls back with the delete
+	assert.Nil(t, removed)
+	assert.ErrorIs(t, err, writeErr)
+	invite, err := membershipRepo.FindByID(ctx, "invite-1")
+	require.NoError(t, err)
+	assert.Equal(t, "admin-1", invite.InvitedBy.ID)
+}
+
+func TestTenantService_RemoveOwner_RechecksOwnersInTransaction(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		userID  string
+		remove  func(svc *TenantService, ctx context.Context) (*model.MemberRemovalResult, error)
+		wantErr error
+	}{
+		{"remove member", "owner-1", func(svc *TenantService, ctx context.Context) (*model.MemberRemovalResult, error) {
+			return svc.RemoveMember(ctx, "owner-2-m")
+		}, errors.ErrLastOwner},
+		{"leave tenant", "owner-2", func(svc *TenantService, ctx context.Context) (*model.MemberRemovalResult, error) {
+			return svc.LeaveTenant(ctx, "tenant-1")
+		}, errors.ErrCannotLeave},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			svc, tenantRepo, membershipRepo, _ := setupTestService()
+			ctx := auth.WithUserID(context.Background(), tc.userID)
+
+			tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+			tenantRepo.AddTenant(tenant)
+			membershipRepo.AddMembership(&model.Membership{ID: "owner-1-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-1"}, Tenant: tenant})
+			membershipRepo.AddMembership(&model.Membership{ID: "owner-2-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-2"}, Tenant: tenant})
+			membershipRepo.AddMembership(&model.Membership{ID: "invite-1", Role: model.MembershipRoleMember, User: &model.User{ID: "invitee-1"}, Tenant: tenant, InvitedBy: &model.User{ID: "owner-2"}})
+
+			// The other owner is removed concurrently, after the first count
+			membershipRepo.CountOwnersTxFunc = func(ctx context.Context, tx neo4j.ManagedTransaction, tenantID string) (int, error) {
+				return 0, nil
+			}
+
+			// Act
+			removed, err := tc.remove(svc, ctx)
+
+			// Assert - nothing is removed or handed off
+			assert.Nil(t, removed)
+			assert.ErrorIs(t, err, tc.wantErr)
+			_, err = membershipRepo.FindByID(ctx, "owner-2-m")
+			assert.NoError(t, err)
+			invite, err := membershipRepo.FindByID(ctx, "invite-1")
+			require.NoError(t, err)
+			assert.Equal(t, "owner-2", invite.InvitedBy.ID)
+		})
+	}
+}
+
+func TestTenantService_ReassignInvites_Success(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant1 := &model.Tenant{ID: "tenant-1", Name: "Tenant 1", Slug: "tenant-1", Status: model.TenantStatusActive}
+	tenant2 := &model.Tenant{ID: "tenant-2", Name: "Tenant 2", Slug: "tenant-2", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant1)
+	tenantRepo.AddTenant(tenant2)
+
+	membershipRepo.AddMembership(&model.Membership{ID: "owner-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-123"}, Tenant: tenant1})
+	membershipRepo.AddMembership(&model.Membership{ID: "new-admin-m", Role: model.MembershipRoleAdmin, User: &model.User{ID: "new-admin"}, Tenant: tenant1})
+
+	// Invites created by the departing admin in both tenants
+	membershipRepo.AddMembership(&model.Membership{ID: "invite-1", Role: model.MembershipRoleMember, User: &model.User{ID: "invitee-1"}, Tenant: tenant1, InvitedBy: &model.User{ID: "old-admin"}})
+	membershipRepo.AddMembership(&model.Membership{ID: "invite-2", Role: model.MembershipRoleMember, User: &model.User{ID: "invitee-2"}, Tenant: tenant1, InvitedBy: &model.User{ID: "old-admin"}})
+	membershipRepo.AddMembership(&model.Membership{ID: "invite-other", Role: model.MembershipRoleMember, User: &model.User{ID: "invitee-3"}, Tenant: tenant2, InvitedBy: &model.User{ID: "old-admin"}})
+
+	// Act
+	count, err := svc.ReassignInvites(ctx, "old-admin", "new-admin", "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	invite1, _ := membershipRepo.FindByID(ctx, "invite-1")
+	invite2, _ := membershipRepo.FindByID(ctx, "invite-2")
+	assert.Equal(t, "new-admin", invite1.InvitedBy.ID)
+	assert.Equal(t, "new-admin", invite2.InvitedBy.ID)
+
+	// Only the specified tenant's invites are affected
+	other, _ := membershipRepo.FindByID(ctx, "invite-other")
+	assert.Equal(t, "old-admin", other.InvitedBy.ID)
+}
+
+func TestTenantService_ReassignInvites_TargetInvitedByLeaver(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "owner-m", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-123"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "new-admin-m", Role: model.MembershipRoleAdmin, User: &model.User{ID: "new-admin"}, Tenant: tenant, InvitedBy: &model.User{ID: "old-admin"}})
+	membershipRepo.AddMembership(&model.Membership{ID: "invite-1", Role: model.MembershipRoleMember, User: &model.User{ID: "invitee-1"}, Tenant: tenant, InvitedBy: &model.User{ID: "old-admin"}})
+
+	// Act
+	count, err := svc.ReassignInvites(ctx, "old-admin", "new-admin", "tenant-1")
+
+	// Assert - the new admin doesn't become their own inviter
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	target, _ := membershipRepo.FindByID(ctx, "new-admin-m")
+	invite, _ := membershipRepo.FindByID(ctx, "invite-1")
+	assert.Nil(t, target.InvitedBy)
+	assert.Equal(t, "new-admin", invite.InvitedBy.ID)
+}
+
+func TestTenantService_ReassignInvites_TargetPending(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-123"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "m2", Role: model.MembershipRoleAdmin, Status: model.MembershipStatusPending, User: &model.User{ID: "invited-admin"}, Tenant: tenant})
+	membershipRepo.ReassignInvitesFunc = func(ctx context.Context, fromUserID, toUserID, tenantID string) (int, error) {
+		t.Fatal("invites reassigned to a pending member")
+		return 0, nil
+	}
+
+	// Act
+	_, err := svc.ReassignInvites(ctx, "old-admin", "invited-admin", "tenant-1")
+
+	// Assert
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "toUserId", validationErr.Field)
+}
+
+func TestTenantService_ReassignInvites_NotOwner(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "admin-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleAdmin, User: &model.User{ID: "admin-123"}, Tenant: tenant})
+
+	// Act
+	count, err := svc.ReassignInvites(ctx, "old-admin", "admin-123", "tenant-1")
+
+	// Assert
+	assert.Equal(t, 0, count)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+}
+
+func TestTenantService_ReassignInvites_TargetNotAdmin(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-123"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "m2", Role: model.MembershipRoleMember, User: &model.User{ID: "member-123"}, Tenant: tenant})
+
+	// Act
+	_, err := svc.ReassignInvites(ctx, "old-admin", "member-123", "tenant-1")
+
+	// Assert
+	var validationErr *errors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+}
+
+func TestTenantService_ReassignInvites_TargetNotMember(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "owner-123")
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "m1", Role: model.MembershipRoleOwner, User: &model.User{ID: "owner-123"}, Tenant: tenant,
+	})
+
+	// Act
+	_, err := svc.ReassignInvites(ctx, "old-admin", "outsider", "tenant-1")
+
+	// Assert - the caller is a member, so this must not read as NOT_MEMBER
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "toUserId", validationErr.Field)
+	assert.NotErrorIs(t, err, errors.ErrNotMember)
+}
+
+func TestTenantService_StreamTenantMembers_RequiresAdmin(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleAdmin, User: &model.User{ID: "admin-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "m2", Role: model.MembershipRoleMember, User: &model.User{ID: "member-1"}, Tenant: tenant})
+
+	collect := func(userID string) ([]string, error) {
+		var ids []string
+		err := svc.StreamTenantMembers(auth.WithUserID(context.Background(), userID), "tenant-1", func(m *model.Membership) error {
+			ids = append(ids, m.ID)
+			return nil
+		})
+		return ids, err
+	}
+
+	// Act
+	adminIDs, adminErr := collect("admin-1")
+	memberIDs, memberErr := collect("member-1")
+
+	// Assert
+	require.NoError(t, adminErr)
+	assert.ElementsMatch(t, []string{"m1", "m2"}, adminIDs)
+	assert.ErrorIs(t, memberErr, errors.ErrForbidden)
+	assert.Empty(t, memberIDs)
+}
+
+func TestTenantService_ListMembers_RequiresMembership(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleViewer, Status: model.MembershipStatusActive, User: &model.User{ID: "viewer-1", Email: "viewer@example.com"}, Tenant: tenant})
+
+	// Act
+	members, viewerErr := svc.ListMembers(auth.WithUserID(context.Background(), "viewer-1"), "tenant-1", repository.MemberFilter{})
+	_, outsiderErr := svc.ListMembers(auth.WithUserID(context.Background(), "outsider"), "tenant-1", repository.MemberFilter{})
+
+	// Assert
+	require.NoError(t, viewerErr)
+	require.Len(t, members, 1)
+	assert.ErrorIs(t, outsiderErr, errors.ErrNotMember)
+}
+
+func TestTenantService_ListMembers_BoundsPageAndTrimsSearch(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleAdmin, Status: model.MembershipStatusActive, User: &model.User{ID: "admin-1"}, Tenant: tenant})
+
+	var got []repository.MemberFilter
+	membershipRepo.ListMembersFunc = func(ctx context.Context, tenantID string, filter repository.MemberFilter) ([]*model.Membership, error) {
+		got = append(got, filter)
+		return []*model.Membership{}, nil
+	}
+
+	// Act
+	_, defaultErr := svc.ListMembers(ctx, "tenant-1", repository.MemberFilter{Search: "  ada  "})
+	_, cappedErr := svc.ListMembers(ctx, "tenant-1", repository.MemberFilter{Limit: MaxPageSize + 1, Offset: -5})
+
+	// Assert
+	require.NoError(t, defaultErr)
+	require.NoError(t, cappedErr)
+	require.Len(t, got, 2)
+	assert.Equal(t, "ada", got[0].Search)
+	assert.Equal(t, DefaultPageSize, got[0].Limit)
+	assert.Equal(t, MaxPageSize, got[1].Limit)
+	assert.Equal(t, 0, got[1].Offset)
+}
+
+func TestTenantService_ListMembers_UnknownSort(t *testing.T) {
+	// Arrange
+	svc, _, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+
+	// Act
+	_, err := svc.ListMembers(ctx, "tenant-1", repository.MemberFilter{Sort: "EMAIL"})
+
+	// Assert
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "sort", validationErr.Field)
+}
+
+func TestTenantService_GetTenantOwners_OnlyOwnersOrderedByJoinedAt(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+
+	now := time.Now()
+	membershipRepo.AddMembership(&model.Membership{ID: "late-owner", Role: model.MembershipRoleOwner, JoinedAt: now, User: &model.User{ID: "owner-2"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "admin", Role: model.MembershipRoleAdmin, JoinedAt: now.Add(-2 * time.Hour), User: &model.User{ID: "admin-1"}, Tenant: tenant})
+	membershipRepo.AddMembership(&model.Membership{ID: "early-owner", Role: model.MembershipRoleOwner, JoinedAt: now.Add(-time.Hour), User: &model.User{ID: "owner-1"}, Tenant: tenant})
+
+	// Act
+	owners, err := svc.GetTenantOwners(ctx, "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, owners, 2)
+	assert.Equal(t, "early-owner", owners[0].ID)
+	assert.Equal(t, "late-owner", owners[1].ID)
+}
+
+func TestTenantService_GetTenantOwners_NoOwners(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{ID: "m1", Role: model.MembershipRoleMember, User: &model.User{ID: "member-1"}, Tenant: tenant})
+
+	// Act
+	owners, err := svc.GetTenantOwners(ctx, "tenant-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, owners)
+	assert.Empty(t, owners)
+}
+
+func TestTenantService_UpdateTenantPlans_ValidBatch(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _, _ := setupTestService()
+	ctx := auth.WithPlatformAdmin(auth.WithUserID(context.Background(), "billing-bot"))
+
+	tenantRepo.AddTenant(&model.Tenant{ID: "tenant-1", Slug: "one", Plan: model.TenantPlanFree, Status: model.TenantStatusActive, MemberCount: 3})
+	tenantRepo.AddTenant(&model.Tenant{ID: "tenant-2", Slug: "two", Plan: model.TenantPlanFree, Status: model.TenantStatusActive, MemberCount: 8})
+
+	changes := []*model.PlanChange{
+		{TenantID: "tenant-1", Plan: model.TenantPlanPro},
+		{TenantID: "tenant-2", Plan: model.TenantPlanEnterprise},
+		{TenantID: "missing", Plan: model.TenantPlanPro},
+	}
+
+	// Act
+	results, err := svc.UpdateTenantPlans(ctx, changes)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.True(t, results[0].Applied)
+	assert.True(t, results[1].Applied)
+	assert.False(t, results[1].OverMemberLimit)
+	assert.False(t, results[2].Applied)
+	require.NotNil(t, results[2].Error)
+	assert.Equal(t, errors.ErrTenantNotFound.Error(), *results[2].Error)
+
+	tenant, _ := tenantRepo.FindByID(ctx, "tenant-1")
+	assert.Equal(t, model.TenantPlanPro, tenant.Plan)
+}
+
+func TestTenantService_UpdateTenantPlans_InvalidPlan(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _, _ := setupTestService()
+	ctx := auth.WithPlatformAdmin(auth.WithUserID(context.Background(), "billing-bot"))
+
+	tenantRepo.AddTenant(&model.Tenant{ID: "tenant-1", Slug: "one", Plan: model.TenantPlanFree, Status: model.TenantStatusActive})
+
+	// Act
+	results, err := svc.UpdateTenantPlans(ctx, []*model.PlanChange{
+		{TenantID: "tenant-1", Plan: model.TenantPlan("PLATINUM")},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Applied)
+	require.NotNil(t, results[0].Error)
+	assert.Contains(t, *results[0].Error, "PLATINUM")
+
+	tenant, _ := tenantRepo.FindByID(ctx, "tenant-1")
+	assert.Equal(t, model.TenantPlanFree, tenant.Plan)
+}
+
+func TestTenantService_UpdateTenantPlans_DowngradeFlagsOverLimit(t *testing.T) {
+	// Arrange
+	svc, tenantRepo, _, _ := setupTestService()
+	ctx := auth.WithPlatformAdmin(auth.WithUserID(context.Background(), "billing-bot"))
+
+	overLimit := PlanMemberLimits[model.TenantPlanFree] + 1
+	tenantRepo.AddTenant(&model.Tenant{ID: "tenant-1", Slug: "one", Plan: model.TenantPlanPro, Status: model.TenantStatusActive, MemberCount: overLimit})
+
+	// Act
+	results, err := svc.UpdateTenantPlans(ctx, []*model.PlanChange{
+		{TenantID: "tenant-1", Plan: model.TenantPlanFree},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Applied)
+	assert.True(t, results[0].OverMemberLimit)
+	assert.Equal(t, overLimit, results[0].MemberCount)
+}
+
+func TestTenantService_UpdateTenantPlans_RequiresPlatformAdmin(t *testing.T) {
+	// Arrange
+	svc, _, _, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "user-123")
+
+	// Act
+	results, err := svc.UpdateTenantPlans(ctx, []*model.PlanChange{
+		{TenantID: "tenant-1", Plan: model.TenantPlanPro},
+	})
+
+	// Assert
+	assert.Nil(t, results)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+}
+
+func TestTenantService_GetInviteChain_HonorsTraversalDepth(t *testing.T) {
+	// Arrange
+	svc, _, membershipRepo, _ := setupTestService()
+	svc.WithMaxTraversalDepth(3)
+	ctx := auth.WithUserID(context.Background(), "user-0")
+
+	tenant := &model.Tenant{ID: "tenant-1"}
+	for i := 0; i < 10; i++ {
+		membershipRepo.AddMembership(&model.Membership{
+			ID:        fmt.Sprintf("m-%d", i),
+			Role:      model.MembershipRoleMember,
+			User:      &model.User{ID: fmt.Sprintf("user-%d", i)},
+			Tenant:    tenant,
+			InvitedBy: &model.User{ID: fmt.Sprintf("user-%d", i+1)},
+		})
+	}
+
+	// Act
+	chain, truncated, err := svc.GetInviteChain(ctx, "m-0")
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Len(t, chain, 3)
+}
+
+func TestTenantService_GetInviteChain_NotMember(t *testing.T) {
+	// Arrange
+	svc, _, membershipRepo, _ := setupTestService()
+	ctx := auth.WithUserID(context.Background(), "outsider")
+
+	membershipRepo.AddMembership(&model.Membership{
+		ID:     "m-0",
+		User:   &model.User{ID: "user-0"},
+		Tenant: &model.Tenant{ID: "tenant-1"},
+	})
+
+	// Act
+	chain, _, err := svc.GetInviteChain(ctx, "m-0")
+
+	// Assert
+	assert.Nil(t, chain)
+	assert.ErrorIs(t, err, errors.ErrNotMember)
+}
+
+// setupInviteTokens returns a service with invite links valid for ttl and a
+// pending membership "m-pending" for invitee@example.com in tenant-1.
+func setupInviteTokens(ttl time.Duration) (*TenantService, *repository.MockMembershipRepository, *identityRepo.MockUserRepository) {
+	svc, tenantRepo, membershipRepo, userRepo := setupTestService()
+	svc.WithInviteTokens(auth.NewTokenManager("secret", time.Hour, userRepo), ttl)
+
+	userRepo.AddUser(&model.User{ID: "admin-1", Email: "admin@example.com", Status: model.UserStatusActive})
+	userRepo.AddUser(&model.User{ID: "invitee-1", Email: "invitee@example.com", Status: model.UserStatusActive})
+	userRepo.AddUser(&model.User{ID: "other-1", Email: "other@example.com", Status: model.UserStatusActive})
+
+	tenant := &model.Tenant{ID: "tenant-1", Name: "Tenant", Slug: "tenant", Status: model.TenantStatusActive}
+	tenantRepo.AddTenant(tenant)
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "m-admin", Role: model.MembershipRoleAdmin, Status: model.MembershipStatusActive,
+		User: &model.User{ID: "admin-1"}, Tenant: tenant,
+	})
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "m-pending", Role: model.MembershipRoleMember, Status: model.MembershipStatusPending,
+		User: &model.User{ID: "invitee-1"}, Tenant: tenant, JoinedAt: time.Now(),
+	})
+	return svc, membershipRepo, userRepo
+}
+
+func TestTenantService_AcceptInviteByToken_ValidToken(t *testing.T) {
+	// Arrange
+	svc, membershipRepo, _ := setupInviteTokens(time.Hour)
+	adminCtx := auth.WithUserID(context.Background(), "admin-1")
+	token, err := svc.CreateInviteToken(adminCtx, "m-pending")
+	require.NoError(t, err)
+
+	inviteeCtx := auth.WithUserID(context.Background(), "invitee-1")
+	_, _, pendingErr := svc.GetInviteChain(inviteeCtx, "m-pending")
+
+	// Act
+	membership, err := svc.AcceptInviteByToken(inviteeCtx, token)
+
+	// Assert
+	assert.ErrorIs(t, pendingErr, errors.ErrNotMember, "pending members have no access")
+	require.NoError(t, err)
+	assert.Equal(t, "m-pending", membership.ID)
+	assert.Equal(t, model.MembershipStatusActive, membership.Status)
+
+	stored, _ := membershipRepo.FindByID(inviteeCtx, "m-pending")
+	assert.Equal(t, model.MembershipStatusActive, stored.Status)
+	_, _, err = svc.GetInviteChain(inviteeCtx, "m-pending")
+	assert.NoError(t, err)
+}
+
+func TestTenantService_AcceptInviteByToken_ExpiredToken(t *testing.T) {
+	// Arrange
+	svc, membershipRepo, _ := setupInviteTokens(-time.Minute)
+	token, err := svc.CreateInviteToken(auth.WithUserID(context.Background(), "admin-1"), "m-pending")
+	require.NoError(t, err)
+	ctx := auth.WithUserID(context.Background(), "invitee-1")
+
+	// Act
+	membership, err := svc.AcceptInviteByToken(ctx, token)
+
+	// Assert
+	assert.Nil(t, membership)
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+	stored, _ := membershipRepo.FindByID(ctx, "m-pending")
+	assert.Equal(t, model.MembershipStatusPending, stored.Status)
+}
+
+func TestTenantService_AcceptInviteByToken_ExpiredInvite(t *testing.T) {
+	// Arrange - a fresh token for an invite last sent past the invite TTL
+	svc, membershipRepo, _ := setupInviteTokens(time.Hour)
+	stored, err := membershipRepo.FindByID(context.Background(), "m-pending")
+	require.NoError(t, err)
+	stored.JoinedAt = time.Now().Add(-2 * time.Hour)
+	token, err := svc.CreateInviteToken(auth.WithUserID(context.Background(), "admin-1"), "m-pending")
+	require.NoError(t, err)
+	ctx := auth.WithUserID(context.Background(), "invitee-1")
+
+	// Act
+	membership, err := svc.AcceptInviteByToken(ctx, token)
+
+	// Assert
+	assert.Nil(t, membership)
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+	assert.Equal(t, model.MembershipStatusPending, stored.Status)
+}
+
+func TestTenantService_AcceptInviteByToken_EmailMismatch(t *testing.T) {
+	// Arrange
+	svc, membershipRepo, _ := setupInviteTokens(time.Hour)
+	token, err := svc.CreateInviteToken(auth.WithUserID(context.Background(), "admin-1"), "m-pending")
+	require.NoError(t, err)
+	ctx := auth.WithUserID(context.Background(), "other-1")
+
+	// Act
+	membership, err := svc.AcceptInviteByToken(ctx, token)
+
+	// Assert
+	assert.Nil(t, membership)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+	stored, _ := membershipRepo.FindByID(ctx, "m-pending")
+	assert.Equal(t, model.MembershipStatusPending, stored.Status)
+}
+
+func TestTenantService_InviteMember_SignUpAndAccept(t *testing.T) {
+	// Arrange
+	svc, membershipRepo, userRepo := setupInviteTokens(time.Hour)
+	notifier := &fakeNotifier{}
+	svc.WithNotifier(notifier)
+	adminCtx := auth.WithUserID(context.Background(), "admin-1")
+
+	invite, err := svc.InviteMember(adminCtx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+	require.NoError(t, err)
+	require.Len(t, notifier.invites, 1)
+	pendingBefore, err := svc.ListPendingInvitations(adminCtx, "tenant-1")
+	require.NoError(t, err)
+
+	// The invitee signs up with the invited email, claiming the placeholder
+	user, err := identitySvc.NewUserService(userRepo).SignInWithProvider(context.Background(), identitySvc.ProviderIdentity{
+		Provider: "google", Subject: "google-new", Email: "new@example.com", EmailVerified: true,
+	})
+	require.NoError(t, err)
+
+	// Act
+	membership, err := svc.AcceptInviteByToken(auth.WithUserID(context.Background(), user.ID), notifier.invites[0].Token)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, invite.User.ID, user.ID)
+	assert.Equal(t, model.UserStatusActive, user.Status)
+	assert.Equal(t, invite.ID, membership.ID)
+	assert.Equal(t, model.MembershipStatusActive, membership.Status)
+
+	assert.ElementsMatch(t, []string{"m-pending", invite.ID}, membershipIDs(pendingBefore))
+	pendingAfter, err := svc.ListPendingInvitations(adminCtx, "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m-pending"}, membershipIDs(pendingAfter))
+	stored, _ := membershipRepo.FindByID(adminCtx, invite.ID)
+	assert.Equal(t, model.MembershipStatusActive, stored.Status)
+}
+
+func TestTenantService_AcceptInviteByToken_UsedToken(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupInviteTokens(time.Hour)
+	adminCtx := auth.WithUserID(context.Background(), "admin-1")
+	token, err := svc.CreateInviteToken(adminCtx, "m-pending")
+	require.NoError(t, err)
+	ctx := auth.WithUserID(context.Background(), "invitee-1")
+	_, err = svc.AcceptInviteByToken(ctx, token)
+	require.NoError(t, err)
+
+	// Act
+	again, againErr := svc.AcceptInviteByToken(ctx, token)
+	_, err = svc.RemoveMember(adminCtx, "m-pending")
+	require.NoError(t, err)
+	removed, removedErr := svc.AcceptInviteByToken(ctx, token)
+
+	// Assert - reusing a token changes nothing, and can't restore a removed member
+	require.NoError(t, againErr)
+	assert.Equal(t, model.MembershipStatusActive, again.Status)
+	assert.Nil(t, removed)
+	assert.ErrorIs(t, removedErr, errors.ErrMembershipNotFound)
+}
+
+func TestTenantService_ListPendingInvitations_RequiresAdmin(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupInviteTokens(time.Hour)
+
+	// Act
+	pending, err := svc.ListPendingInvitations(auth.WithUserID(context.Background(), "invitee-1"), "tenant-1")
+
+	// Assert
+	assert.Nil(t, pending)
+	assert.ErrorIs(t, err, errors.ErrNotMember)
+}
+
+func TestTenantService_ListPendingInvitations_OmitsExpired(t *testing.T) {
+	// Arrange
+	svc, membershipRepo, userRepo := setupInviteTokens(time.Hour)
+	userRepo.AddUser(&model.User{ID: "stale-1", Email: "stale@example.com", Status: model.UserStatusPending})
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "m-stale", Role: model.MembershipRoleMember, Status: model.MembershipStatusPending,
+		User: &model.User{ID: "stale-1"}, Tenant: &model.Tenant{ID: "tenant-1"},
+		InvitedBy: &model.User{ID: "admin-1"}, JoinedAt: time.Now().Add(-2 * time.Hour),
+	})
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+
+	// Act
+	before, beforeErr := svc.ListPendingInvitations(ctx, "tenant-1")
+	_, resendErr := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "stale@example.com"})
+	after, afterErr := svc.ListPendingInvitations(ctx, "tenant-1")
+
+	// Assert - resending an expired invite renews it
+	require.NoError(t, beforeErr)
+	require.NoError(t, resendErr)
+	require.NoError(t, afterErr)
+	assert.Equal(t, []string{"m-pending"}, membershipIDs(before))
+	assert.Equal(t, []string{"m-stale", "m-pending"}, membershipIDs(after))
+}
+
+// membershipIDs returns the IDs of memberships, in order.
+func membershipIDs(memberships []*model.Membership) []string {
+	ids := make([]string, 0, len(memberships))
+	for _, membership := range memberships {
+		ids = append(ids, membership.ID)
+	}
+	return ids
+}
+
+func TestTenantService_CreateInviteToken_RequiresAdmin(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupInviteTokens(time.Hour)
+	ctx := auth.WithUserID(context.Background(), "invitee-1")
+
+	// Act
+	token, err := svc.CreateInviteToken(ctx, "m-pending")
+
+	// Assert
+	assert.Empty(t, token)
+	assert.ErrorIs(t, err, errors.ErrNotMember)
+}
+
+// fakeNotifier records the notifications it is asked to send and fails
+// every send when err is set.
+type fakeNotifier struct {
+	invites     []notify.Invite
+	roleChanges []notify.RoleChanged
+	err         error
+}
+
+func (f *fakeNotifier) SendInvite(ctx context.Context, invite notify.Invite) error {
+	f.invites = append(f.invites, invite)
+	return f.err
+}
+
+func (f *fakeNotifier) SendRoleChanged(ctx context.Context, change notify.RoleChanged) error {
+	f.roleChanges = append(f.roleChanges, change)
+	return f.err
+}
+
+func TestTenantService_InviteByEmail_ExistingUser(t *testing.T) {
+	// Arrange
+	svc, membershipRepo, userRepo := setupInviteTokens(time.Hour)
+	notifier := &fakeNotifier{}
+	svc.WithNotifier(notifier)
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+
+	// Act
+	membership, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: " Other@Example.com"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "other-1", membership.User.ID)
+	assert.Equal(t, model.MembershipStatusActive, membership.Status)
+	assert.Empty(t, notifier.invites, "existing users join without an invite link")
+	_, err = userRepo.FindByEmail(ctx, "other@example.com")
+	require.NoError(t, err)
+	_, err = membershipRepo.FindByUserAndTenant(ctx, "other-1", "tenant-1")
+	assert.NoError(t, err)
+}
+
+func TestTenantService_InviteByEmail_NewEmailCreatesPlaceholder(t *testing.T) {
+	// Arrange
+	svc, membershipRepo, userRepo := setupInviteTokens(time.Hour)
+	notifier := &fakeNotifier{}
+	svc.WithNotifier(notifier)
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+	role := model.MembershipRoleAdmin
+
+	// Act
+	membership, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "new@example.com", Role: &role})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipStatusPending, membership.Status)
+	assert.Equal(t, model.MembershipRoleAdmin, membership.Role)
+
+	placeholder, err := userRepo.FindByEmail(ctx, "new@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserStatusPending, placeholder.Status)
+	assert.Equal(t, placeholder.ID, membership.User.ID)
+
+	stored, err := membershipRepo.FindByUserAndTenant(ctx, placeholder.ID, "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipStatusPending, stored.Status)
+
+	require.Len(t, notifier.invites, 1)
+	assert.Equal(t, "new@example.com", notifier.invites[0].To)
+	assert.Equal(t, membership.ID, notifier.invites[0].MembershipID)
+	assert.Equal(t, "ADMIN", notifier.invites[0].Role)
+	assert.NotEmpty(t, notifier.invites[0].Token)
+}
+
+func TestTenantService_InviteByEmail_ReinviteResendsPending(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupInviteTokens(time.Hour)
+	notifier := &fakeNotifier{}
+	svc.WithNotifier(notifier)
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+	first, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+	require.NoError(t, err)
+
+	// Act
+	second, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, first.User.ID, second.User.ID, "placeholder user is reused")
+	assert.Len(t, notifier.invites, 2)
+}
+
+func TestTenantService_InviteByEmail_ResendByOtherAdminReplacesInviter(t *testing.T) {
+	// Arrange
+	svc, membershipRepo, userRepo := setupInviteTokens(time.Hour)
+	userRepo.AddUser(&model.User{ID: "admin-2", Email: "admin2@example.com", Status: model.UserStatusActive})
+	membershipRepo.AddMembership(&model.Membership{
+		ID: "m-admin-2", Role: model.MembershipRoleAdmin, Status: model.MembershipStatusActive,
+		User: &model.User{ID: "admin-2"}, Tenant: &model.Tenant{ID: "tenant-1"},
+	})
+	first, err := svc.InviteByEmail(auth.WithUserID(context.Background(), "admin-1"), "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+	require.NoError(t, err)
+
+	setInviterCalls := 0
+	membershipRepo.SetInviterFunc = func(ctx context.Context, membershipID, inviterID string) (*model.Membership, error) {
+		setInviterCalls++
+		membership, err := membershipRepo.FindByID(ctx, membershipID)
+		if err != nil {
+			return nil, err
+		}
+		membership.InvitedBy = &model.User{ID: inviterID}
+		return membership, nil
+	}
+	ctx := auth.WithUserID(context.Background(), "admin-2")
+
+	// Act
+	second, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+	require.NoError(t, err)
+	third, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, first.ID, third.ID)
+	require.NotNil(t, third.InvitedBy)
+	assert.Equal(t, "admin-2", third.InvitedBy.ID, "the latest inviter wins")
+	assert.Equal(t, 1, setInviterCalls, "resending as the current inviter keeps it")
+
+	memberships, err := membershipRepo.FindByTenantID(ctx, "tenant-1")
+	require.NoError(t, err)
+	assert.Len(t, memberships, 4)
+}
+
+func TestTenantService_InviteByEmail_AlreadyMember(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupInviteTokens(time.Hour)
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+
+	// Act
+	membership, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "admin@example.com"})
+
+	// Assert
+	assert.Nil(t, membership)
+	assert.ErrorIs(t, err, errors.ErrAlreadyMember)
+}
+
+func TestTenantService_InviteByEmail_FailingNotifierKeepsMembership(t *testing.T) {
+	// Arrange
+	svc, membershipRepo, userRepo := setupInviteTokens(time.Hour)
+	notifier := &fakeNotifier{err: fmt.Errorf("smtp unavailable")}
+	svc.WithNotifier(notifier)
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+
+	// Act
+	membership, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, notifier.invites, 1)
+	placeholder, err := userRepo.FindByEmail(ctx, "new@example.com")
+	require.NoError(t, err)
+	stored, err := membershipRepo.FindByUserAndTenant(ctx, placeholder.ID, "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, membership.ID, stored.ID)
+	assert.Equal(t, model.MembershipStatusPending, stored.Status)
+}
+
+func TestTenantService_InviteByEmail_InvalidEmail(t *testing.T) {
+	// Arrange
+	svc, _, userRepo := setupInviteTokens(time.Hour)
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+
+	// Act
+	_, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "Bob <bob@example.com>"})
+
+	// Assert
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "email", validationErr.Field)
+	_, err = userRepo.FindByEmail(ctx, "bob@example.com")
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+}
+
+func TestTenantService_InviteByEmail_PendingInviteLimit(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		limit   int
+		email   string
+		wantErr error
+	}{
+		{"below the limit", 2, "new@example.com", nil},
+		{"at the limit", 1, "new@example.com", errors.ErrInviteLimitReached},
+		{"existing user at the limit", 1, "other@example.com", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange - m-pending is the tenant's one pending invite
+			svc, _, userRepo := setupInviteTokens(time.Hour)
+			svc.WithMaxPendingInvites(tc.limit)
+			ctx := auth.WithUserID(context.Background(), "admin-1")
+
+			// Act
+			_, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: tc.email})
+
+			// Assert
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.wantErr)
+			_, err = userRepo.FindByEmail(ctx, tc.email)
+			assert.ErrorIs(t, err, errors.ErrUserNotFound, "no placeholder is created")
+		})
+	}
+}
+
+func TestTenantService_InviteByEmail_ResendAtLimit(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupInviteTokens(time.Hour)
+	svc.WithMaxPendingInvites(2)
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+	first, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+	require.NoError(t, err)
+
+	// Act
+	second, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+}
+
+func TestTenantService_InviteByEmail_ExpiredInviteFreesCapacity(t *testing.T) {
+	// Arrange - m-pending was last sent before the invite TTL
+	svc, membershipRepo, _ := setupInviteTokens(time.Hour)
+	svc.WithMaxPendingInvites(1)
+	stale, err := membershipRepo.FindByID(context.Background(), "m-pending")
+	require.NoError(t, err)
+	stale.JoinedAt = time.Now().Add(-2 * time.Hour)
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+
+	// Act
+	membership, err := svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipStatusPending, membership.Status)
+}
+
+func TestTenantService_InviteByEmail_ConcurrentInvitesRespectLimit(t *testing.T) {
+	// Arrange - m-pending already takes one of the three places
+	svc, membershipRepo, _ := setupInviteTokens(time.Hour)
+	svc.WithMaxPendingInvites(3)
+	ctx := auth.WithUserID(context.Background(), "admin-1")
+
+	// Act
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = svc.InviteByEmail(ctx, "tenant-1", model.InviteMemberInput{Email: fmt.Sprintf("new-%d@example.com", i)})
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	limited := 0
+	for _, err := range errs {
+		if err != nil {
+			require.ErrorIs(t, err, errors.ErrInviteLimitReached)
+			limited++
+		}
+	}
+	assert.Equal(t, 8, limited)
+	pending, err := membershipRepo.CountPending(ctx, "tenant-1", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 3, pending)
+}
+
+func TestTenantService_InviteByEmail_AcceptedInviteFreesCapacity(t *testing.T) {
+	// Arrange
+	svc, _, _ := setupInviteTokens(time.Hour)
+	svc.WithMaxPendingInvites(1)
+	adminCtx := auth.WithUserID(context.Background(), "admin-1")
+	_, err := svc.InviteByEmail(adminCtx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+	require.ErrorIs(t, err, errors.ErrInviteLimitReached)
+
+	token, err := svc.CreateInviteToken(adminCtx, "m-pending")
+	require.NoError(t, err)
+	_, err = svc.AcceptInviteByToken(auth.WithUserID(context.Background(), "invitee-1"), token)
+	require.NoError(t, err)
+
+	// Act
+	membership, err := svc.InviteByEmail(adminCtx, "tenant-1", model.InviteMemberInput{Email: "new@example.com"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipStatusPending, membership.Status)
+}
+
+// setupDenials wires a DenialCounter and a JSON logger writing to the
+// returned buffer into the billing tenant's service.
+func setupDenials() (*TenantService, *DenialCounter, *bytes.Buffer) {
+	svc, _ := setupBillingTenant()
+	counter := NewDenialCounter()
+	var logs bytes.Buffer
+	svc.WithDenialRecorder(counter).WithLogger(slog.New(slog.NewJSONHandler(&logs, nil)))
+	return svc, counter, &logs
+}
+
+// logEntries decodes one JSON log entry per line.
+func logEntries(t *testing.T, logs *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestTenantService_Denial_CountsAndLogs(t *testing.T) {
+	owner := model.MembershipRoleOwner
+	testCases := []struct {
+		desc    string
+		userID  string
+		deny    func(svc *TenantService, ctx context.Context) error
+		wantKey DenialKey
+		role    any
+		wantErr error
+	}{
+		{"requireRole below required role", "member-123", func(svc *TenantService, ctx context.Context) error {
+			_, err := svc.SetBillingEmail(ctx, "tenant-1", "billing@example.com")
+			return err
+		}, DenialKey{"SetBillingEmail", model.MembershipRoleAdmin}, "MEMBER", errors.ErrForbidden},
+		{"requireRole for a non-member", "stranger", func(svc *TenantService, ctx context.Context) error {
+			_, err := svc.UpdateTenant(ctx, "tenant-1", model.UpdateTenantInput{})
+			return err
+		}, DenialKey{"UpdateTenant", model.MembershipRoleAdmin}, "", errors.ErrNotMember},
+		{"admin inviting an owner", "admin-123", func(svc *TenantService, ctx context.Context) error {
+			_, err := svc.InviteMember(ctx, "tenant-1", model.InviteMemberInput{Email: "new@example.com", Role: &owner})
+			return err
+		}, DenialKey{"InviteMember", model.MembershipRoleOwner}, "ADMIN", errors.ErrForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			svc, counter, logs := setupDenials()
+			ctx := auth.WithUserID(context.Background(), tc.userID)
+
+			// Act
+			err := tc.deny(svc, ctx)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, map[DenialKey]int{tc.wantKey: 1}, counter.Counts())
+			entries := logEntries(t, logs)
+			require.Len(t, entries, 1)
+			assert.Equal(t, "authorization denied", entries[0]["msg"])
+			assert.Equal(t, "WARN", entries[0]["level"])
+			assert.Equal(t, tc.wantKey.Operation, entries[0]["operation"])
+			assert.Equal(t, string(tc.wantKey.RequiredRole), entries[0]["requiredRole"])
+			assert.Equal(t, tc.role, entries[0]["role"])
+			assert.Equal(t, tc.userID, entries[0]["userId"])
+			assert.Equal(t, "tenant-1", entries[0]["tenantId"])
+		})
+	}
+}
+
+func TestTenantService_Denial_LogIsRateLimited(t *testing.T) {
+	// Arrange
+	svc, counter, logs := setupDenials()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.denialLog.now = func() time.Time { return now }
+	ctx := auth.WithUserID(context.Background(), "member-123")
+	probe := func() {
+		_, err := svc.SetBillingEmail(ctx, "tenant-1", "billing@example.com")
+		require.ErrorIs(t, err, errors.ErrForbidden)
+	}
+
+	// Act
+	for range denialLogLimit + 5 {
+		probe()
+	}
+	inWindow := len(logEntries(t, logs))
+	now = now.Add(denialLogWindow)
+	probe()
+
+	// Assert
+	assert.Equal(t, denialLogLimit, inWindow)
+	assert.Equal(t, map[DenialKey]int{{"SetBillingEmail", model.MembershipRoleAdmin}: denialLogLimit + 6}, counter.Counts())
+	entries := logEntries(t, logs)
+	require.Len(t, entries, denialLogLimit+1)
+	assert.Equal(t, float64(5), entries[denialLogLimit]["suppressed"])
+}
+
+// scrapeDenials returns the Prometheus denial count for an operation and
+// required role, or 0 if none has been recorded.
+func scrapeDenials(t *testing.T, operation string, role model.MembershipRole) float64 {
+	t.Helper()
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	series := fmt.Sprintf(`grgn_authorization_denials_total{operation=%q,role=%q} `, operation, role)
+	for _, line := range strings.Split(w.Body.String(), "\n") {
+		if value, ok := strings.CutPrefix(line, series); ok {
+			count, err := strconv.ParseFloat(value, 64)
+			require.NoError(t, err)
+			return count
+		}
+	}
+	return 0
+}
+
+func TestTenantService_Denial_MetricsRecorder(t *testing.T) {
+	// Arrange
+	svc, _ := setupBillingTenant()
+	svc.WithDenialRecorder(MetricsDenialRecorder{})
+	ctx := auth.WithUserID(context.Background(), "member-123")
+	before := scrapeDenials(t, "SetBillingEmail", model.MembershipRoleAdmin)
+
+	// Act
+	_, err := svc.SetBillingEmail(ctx, "tenant-1", "billing@example.com")
+
+	// Assert
+	require.ErrorIs(t, err, errors.ErrForbidden)
+	assert.Equal(t, before+1, scrapeDenials(t, "SetBillingEmail", model.MembershipRoleAdmin))
+}
+
+func TestTenantService_RequireRole_TrustsClaimedRole(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	h.TenantWithMembers("tenant-1", "owner-1")
+	lookups := 0
+	h.Memberships.FindByUserAndTenantFunc = func(ctx context.Context, userID, tenantID string) (*model.Membership, error) {
+		lookups++
+		return nil, errors.ErrMembershipNotFound
+	}
+	ctx := testutil.AsUserWithRoles("admin-1", map[string]model.MembershipRole{"tenant-1": model.MembershipRoleAdmin})
+
+	// Act
+	tenant, err := svc.SetBillingEmail(ctx, "tenant-1", "billing@example.com")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "billing@example.com", *tenant.BillingEmail)
+	assert.Zero(t, lookups, "a sufficient claimed role skips the membership lookup")
+}
+
+func TestTenantService_RequireRole_ClaimMissFallsBackToMembership(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		roles map[string]model.MembershipRole
+	}{
+		{"claimed role too low, current role suffices", map[string]model.MembershipRole{"tenant-1": model.MembershipRoleMember}},
+		{"role claimed in another tenant", map[string]model.MembershipRole{"tenant-2": model.MembershipRoleOwner}},
+		{"invalid claimed role", map[string]model.MembershipRole{"tenant-1": "SUPERUSER"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			h := testutil.NewHarness()
+			svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+			fixture := h.TenantWithMembers("tenant-1", "owner-1")
+			h.AddMember(fixture.Tenant, "admin-1", model.MembershipRoleAdmin)
+			ctx := testutil.AsUserWithRoles("admin-1", tc.roles)
+
+			// Act
+			_, err := svc.SetBillingEmail(ctx, "tenant-1", "billing@example.com")
+
+			// Assert
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTenantService_RoleLossRevokesRoleClaims(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		lose        func(svc *TenantService) error
+		userID      string
+		wantRevoked bool
+	}{
+		{"demotion", func(svc *TenantService) error {
+			_, err := svc.UpdateMemberRoleByUser(testutil.AsUser("owner-1"), "tenant-1", "admin-1", model.MembershipRoleMember)
+			return err
+		}, "admin-1", true},
+		{"promotion", func(svc *TenantService) error {
+			_, err := svc.UpdateMemberRoleByUser(testutil.AsUser("owner-1"), "tenant-1", "member-1", model.MembershipRoleAdmin)
+			return err
+		}, "member-1", false},
+		{"removal", func(svc *TenantService) error {
+			_, err := svc.RemoveMember(testutil.AsUser("owner-1"), "m-member-1-tenant-1")
+			return err
+		}, "member-1", true},
+		{"leaving", func(svc *TenantService) error {
+			_, err := svc.LeaveTenant(testutil.AsUser("member-1"), "tenant-1")
+			return err
+		}, "member-1", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			h := testutil.NewHarness()
+			svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+			fixture := h.TenantWithMembers("tenant-1", "owner-1", "member-1")
+			h.AddMember(fixture.Tenant, "admin-1", model.MembershipRoleAdmin)
+			h.Memberships.Tenants = h.Tenants
+
+			// Act
+			err := tc.lose(svc)
+
+			// Assert
+			require.NoError(t, err)
+			epoch, err := h.Users.GetRoleEpoch(context.Background(), tc.userID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRevoked, epoch > 0)
+			tokenEpoch, err := h.Users.GetTokenEpoch(context.Background(), tc.userID)
+			require.NoError(t, err)
+			assert.Zero(t, tokenEpoch, "losing a role doesn't sign the user out")
+		})
+	}
+}
+
+func TestTenantService_RoleLoss_OtherTenantSessionSurvives(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	first := h.TenantWithMembers("tenant-1", "owner-1")
+	second := h.TenantWithMembers("tenant-2", "owner-2")
+	h.AddMember(first.Tenant, "admin-1", model.MembershipRoleAdmin)
+	h.AddMember(second.Tenant, "admin-1", model.MembershipRoleAdmin)
+	tokens := auth.NewTokenManager("secret", time.Hour, h.Users).WithTenantRoles(h.Memberships)
+	token, err := tokens.IssueSessionToken(context.Background(), "admin-1", 0)
+	require.NoError(t, err)
+
+	// Act - demote the user in tenant-1, then use the token they already had
+	_, demoteErr := svc.UpdateMemberRoleByUser(testutil.AsUser("owner-1"), "tenant-1", "admin-1", model.MembershipRoleMember)
+	claims, parseErr := tokens.ParseToken(context.Background(), token)
+	require.NoError(t, parseErr)
+	ctx := auth.WithClaims(context.Background(), claims)
+	_, secondErr := svc.SetBillingEmail(ctx, "tenant-2", "billing@example.com")
+	_, firstErr := svc.SetBillingEmail(ctx, "tenant-1", "billing@example.com")
+
+	// Assert
+	require.NoError(t, demoteErr)
+	assert.NoError(t, secondErr, "the session still works in the other tenant")
+	assert.ErrorIs(t, firstErr, errors.ErrForbidden, "the lost role is no longer trusted")
+}
+
+func TestTenantService_OffboardUser_TransfersOwnership(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	ctx := testutil.AsPlatformAdmin("admin-1")
+
+	owned := h.TenantWithMembers("tenant-a", "leaver", "successor")
+	joined := h.TenantWithMembers("tenant-b", "owner-b")
+	h.AddMember(joined.Tenant, "leaver", model.MembershipRoleMember)
+
+	// Act
+	result, err := svc.OffboardUser(ctx, "leaver", map[string]string{"tenant-a": owned.Members[0].ID})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "leaver", result.UserID)
+	assert.Equal(t, 2, result.RemovedMembershipCount)
+	require.Len(t, result.TransferredOwnerships, 1)
+	assert.Equal(t, "m-successor-tenant-a", result.TransferredOwnerships[0].ID)
+	assert.Equal(t, model.MembershipRoleOwner, result.TransferredOwnerships[0].Role)
+
+	remaining, err := h.Memberships.FindByUserID(ctx, "leaver", nil, MaxPageSize, 0)
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+	_, err = h.Users.FindByID(ctx, "leaver")
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+}
+
+func TestTenantService_OffboardUser_RequiresSuccessorForSoleOwnership(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	ctx := testutil.AsPlatformAdmin("admin-1")
+
+	h.TenantWithMembers("tenant-a", "leaver", "member-a")
+	h.TenantWithMembers("tenant-b", "leaver")
+	coOwned := h.TenantWithMembers("tenant-c", "leaver")
+	h.AddMember(coOwned.Tenant, "co-owner", model.MembershipRoleOwner)
+
+	// Act
+	result, err := svc.OffboardUser(ctx, "leaver", nil)
+
+	// Assert - tenant-c keeps an owner, so only the other two need a successor
+	assert.Nil(t, result)
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "ownershipSuccessors", validationErr.Field)
+	assert.Contains(t, validationErr.Message, "tenant-a, tenant-b")
+
+	remaining, err := h.Memberships.FindByUserID(ctx, "leaver", nil, MaxPageSize, 0)
+	require.NoError(t, err)
+	assert.Len(t, remaining, 3)
+}
+
+func TestTenantService_OffboardUser_RejectsInvalidSuccessors(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		successor func(h *testutil.Harness) (tenantID, membershipID string)
+		wantMsg   string
+	}{
+		{"tenant not owned", func(h *testutil.Harness) (string, string) {
+			f := h.TenantWithMembers("tenant-x", "owner-x", "member-x")
+			h.AddMember(f.Tenant, "leaver", model.MembershipRoleMember)
+			return "tenant-x", f.Members[0].ID
+		}, "does not own"},
+		{"member of another tenant", func(h *testutil.Harness) (string, string) {
+			f := h.TenantWithMembers("tenant-x", "owner-x", "member-x")
+			return "tenant-a", f.Members[0].ID
+		}, "must be a member"},
+		{"the user themselves", func(h *testutil.Harness) (string, string) {
+			return "tenant-a", "m-leaver-tenant-a"
+		}, "another member"},
+		{"pending invitee", func(h *testutil.Harness) (string, string) {
+			tenant, _ := h.Tenants.FindByID(context.Background(), "tenant-a")
+			invite := h.AddMember(tenant, "invitee", model.MembershipRoleMember)
+			invite.Status = model.MembershipStatusPending
+			return "tenant-a", invite.ID
+		}, "active member"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Arrange
+			h := testutil.NewHarness()
+			svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+			ctx := testutil.AsPlatformAdmin("admin-1")
+			h.TenantWithMembers("tenant-a", "leaver")
+			tenantID, membershipID := tc.successor(h)
+
+			// Act
+			_, err := svc.OffboardUser(ctx, "leaver", map[string]string{tenantID: membershipID})
+
+			// Assert
+			var validationErr *errors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, validationErr.Message, tc.wantMsg)
+		})
+	}
+}
+
+func TestTenantService_OffboardUser_FailureRollsBack(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	ctx := testutil.AsPlatformAdmin("admin-1")
+	owned := h.TenantWithMembers("tenant-a", "leaver", "successor")
+
+	writeErr := fmt.Errorf("write failed")
+	h.Users.DeleteTxFunc = func(ctx context.Context, tx neo4j.ManagedTransaction, id, deletedBy string, reason *string) error {
+		return writeErr
+	}
+
+	// Act
+	result, err := svc.OffboardUser(ctx, "leaver", map[string]string{"tenant-a": owned.Members[0].ID})
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, writeErr)
+
+	successor, err := h.Memberships.FindByID(ctx, owned.Members[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipRoleMember, successor.Role)
+	_, err = h.Memberships.FindByID(ctx, owned.Owner.ID)
+	assert.NoError(t, err, "the user's membership survives")
+}
+
+func TestTenantService_OffboardUser_HandsOffInvites(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	ctx := testutil.AsPlatformAdmin("admin-1")
+	owned := h.TenantWithMembers("tenant-a", "leaver", "successor")
+	joined := h.TenantWithMembers("tenant-b", "owner-b")
+	h.AddMember(joined.Tenant, "leaver", model.MembershipRoleMember)
+	inviteA := h.AddMember(owned.Tenant, "invitee-a", model.MembershipRoleMember)
+	inviteA.InvitedBy = &model.User{ID: "leaver"}
+	inviteB := h.AddMember(joined.Tenant, "invitee-b", model.MembershipRoleMember)
+	inviteB.InvitedBy = &model.User{ID: "leaver"}
+
+	// Act
+	result, err := svc.OffboardUser(ctx, "leaver", map[string]string{"tenant-a": owned.Members[0].ID})
+
+	// Assert - the new owner takes over in tenant-a, the remaining owner in tenant-b
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.InvitesHandedOff)
+	assert.Equal(t, "successor", inviteA.InvitedBy.ID)
+	assert.Equal(t, "owner-b", inviteB.InvitedBy.ID)
+}
+
+func TestTenantService_OffboardUser_RechecksOwnersInUnitOfWork(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	ctx := testutil.AsPlatformAdmin("admin-1")
+	coOwned := h.TenantWithMembers("tenant-c", "leaver")
+	h.AddMember(coOwned.Tenant, "co-owner", model.MembershipRoleOwner)
+
+	// The co-owner leaves after the successors were checked
+	h.Memberships.CountOwnersTxFunc = func(ctx context.Context, tx neo4j.ManagedTransaction, tenantID string) (int, error) {
+		return 1, nil
+	}
+
+	// Act
+	result, err := svc.OffboardUser(ctx, "leaver", nil)
+
+	// Assert
+	assert.Nil(t, result)
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Message, "tenant-c")
+	_, err = h.Memberships.FindByID(ctx, coOwned.Owner.ID)
+	assert.NoError(t, err, "the user's membership survives")
+	_, err = h.Users.FindByID(ctx, "leaver")
+	assert.NoError(t, err, "the user isn't deleted")
+}
+
+func TestTenantService_OffboardUser_RequiresPlatformAdmin(t *testing.T) {
+	// Arrange
+	h := testutil.NewHarness()
+	svc := NewTenantService(h.Tenants, h.Memberships, h.Users, h.UnitOfWork)
+	owned := h.TenantWithMembers("tenant-a", "leaver", "successor")
+
+	// Act
+	_, err := svc.OffboardUser(testutil.AsUser("successor"), "leaver", map[string]string{"tenant-a": owned.Members[0].ID})
+
+	// Assert
+	assert.ErrorIs(t, err, errors.ErrForbidden)
 }